@@ -8,6 +8,7 @@ import (
 
 	"github.com/luxdefi/node/api"
 	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/json"
 	"github.com/luxdefi/node/utils/logging"
 	"github.com/luxdefi/node/utils/rpc"
 )
@@ -28,6 +29,18 @@ type Client interface {
 	SetLoggerLevel(ctx context.Context, loggerName, logLevel, displayLevel string, options ...rpc.Option) (map[string]LogAndDisplayLevels, error)
 	GetLoggerLevel(ctx context.Context, loggerName string, options ...rpc.Option) (map[string]LogAndDisplayLevels, error)
 	GetConfig(ctx context.Context, options ...rpc.Option) (interface{}, error)
+	AddPeer(ctx context.Context, nodeID ids.NodeID, ip string, options ...rpc.Option) error
+	RemovePeer(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) error
+	DisconnectPeer(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) error
+	BanPeer(ctx context.Context, args *BanPeerArgs, options ...rpc.Option) error
+	UnbanPeer(ctx context.Context, args *UnbanPeerArgs, options ...rpc.Option) error
+	GetBans(ctx context.Context, options ...rpc.Option) ([]Ban, error)
+	ReloadAllowList(ctx context.Context, options ...rpc.Option) error
+	GetConsensus(ctx context.Context, chain string, numDecisions int, options ...rpc.Option) (*GetConsensusReply, error)
+	GetBenchlist(ctx context.Context, chain string, options ...rpc.Option) ([]ChainBenchlist, error)
+	Unbench(ctx context.Context, chain string, nodeID ids.NodeID, options ...rpc.Option) error
+	SetBenchingEnabled(ctx context.Context, chain string, enabled bool, options ...rpc.Option) error
+	RollbackChain(ctx context.Context, chain string, height uint64, options ...rpc.Option) error
 }
 
 // Client implementation for the Lux Platform Info API Endpoint
@@ -140,3 +153,78 @@ func (c *client) GetConfig(ctx context.Context, options ...rpc.Option) (interfac
 	err := c.requester.SendRequest(ctx, "admin.getConfig", struct{}{}, &res, options...)
 	return res, err
 }
+
+func (c *client) AddPeer(ctx context.Context, nodeID ids.NodeID, ip string, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.addPeer", &AddPeerArgs{
+		NodeID: nodeID,
+		IP:     ip,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) RemovePeer(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.removePeer", &RemovePeerArgs{
+		NodeID: nodeID,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) DisconnectPeer(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.disconnectPeer", &DisconnectPeerArgs{
+		NodeID: nodeID,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) BanPeer(ctx context.Context, args *BanPeerArgs, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.banPeer", args, &api.EmptyReply{}, options...)
+}
+
+func (c *client) UnbanPeer(ctx context.Context, args *UnbanPeerArgs, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.unbanPeer", args, &api.EmptyReply{}, options...)
+}
+
+func (c *client) GetBans(ctx context.Context, options ...rpc.Option) ([]Ban, error) {
+	res := &GetBansReply{}
+	err := c.requester.SendRequest(ctx, "admin.getBans", struct{}{}, res, options...)
+	return res.Bans, err
+}
+
+func (c *client) ReloadAllowList(ctx context.Context, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.reloadAllowList", struct{}{}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) GetConsensus(ctx context.Context, chain string, numDecisions int, options ...rpc.Option) (*GetConsensusReply, error) {
+	res := &GetConsensusReply{}
+	err := c.requester.SendRequest(ctx, "admin.getConsensus", &GetConsensusArgs{
+		Chain:        chain,
+		NumDecisions: numDecisions,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetBenchlist(ctx context.Context, chain string, options ...rpc.Option) ([]ChainBenchlist, error) {
+	res := &GetBenchlistReply{}
+	err := c.requester.SendRequest(ctx, "admin.getBenchlist", &GetBenchlistArgs{
+		Chain: chain,
+	}, res, options...)
+	return res.Chains, err
+}
+
+func (c *client) Unbench(ctx context.Context, chain string, nodeID ids.NodeID, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.unbench", &UnbenchArgs{
+		Chain:  chain,
+		NodeID: nodeID,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) SetBenchingEnabled(ctx context.Context, chain string, enabled bool, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.setBenchingEnabled", &SetBenchingEnabledArgs{
+		Chain:   chain,
+		Enabled: enabled,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) RollbackChain(ctx context.Context, chain string, height uint64, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.rollbackChain", &RollbackChainArgs{
+		Chain:  chain,
+		Height: json.Uint64(height),
+	}, &api.EmptyReply{}, options...)
+}
@@ -66,6 +66,12 @@ func (mc *mockClient) SendRequest(_ context.Context, _ string, _ interface{}, re
 	case *LoggerLevelReply:
 		response := mc.response.(*LoggerLevelReply)
 		*p = *response
+	case *GetConsensusReply:
+		response := mc.response.(*GetConsensusReply)
+		*p = *response
+	case *GetBenchlistReply:
+		response := mc.response.(*GetBenchlistReply)
+		*p = *response
 	case *interface{}:
 		response := mc.response.(*interface{})
 		*p = *response
@@ -333,6 +339,85 @@ func TestGetLoggerLevel(t *testing.T) {
 	}
 }
 
+func TestGetConsensus(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		require := require.New(t)
+
+		expectedReply := &GetConsensusReply{}
+		expectedReply.LastAcceptedID = ids.GenerateTestID()
+		expectedReply.NumPendingBlocks = 1
+		mockClient := client{requester: NewMockClient(expectedReply, nil)}
+
+		reply, err := mockClient.GetConsensus(context.Background(), "chain", 10)
+		require.NoError(err)
+		require.Equal(expectedReply, reply)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&GetConsensusReply{}, errTest)}
+		_, err := mockClient.GetConsensus(context.Background(), "chain", 10)
+		require.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestGetBenchlist(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		require := require.New(t)
+
+		expectedReply := &GetBenchlistReply{
+			Chains: []ChainBenchlist{
+				{
+					ChainID: ids.GenerateTestID(),
+					Alias:   "X",
+					Enabled: true,
+					Benched: []BenchedNode{
+						{
+							NodeID:    ids.GenerateTestNodeID(),
+							Failures:  5,
+							Remaining: "1m0s",
+						},
+					},
+				},
+			},
+		}
+		mockClient := client{requester: NewMockClient(expectedReply, nil)}
+
+		chains, err := mockClient.GetBenchlist(context.Background(), "X")
+		require.NoError(err)
+		require.Equal(expectedReply.Chains, chains)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&GetBenchlistReply{}, errTest)}
+		_, err := mockClient.GetBenchlist(context.Background(), "X")
+		require.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestUnbench(t *testing.T) {
+	require := require.New(t)
+
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.Err)}
+		err := mockClient.Unbench(context.Background(), "X", ids.GenerateTestNodeID())
+		require.ErrorIs(err, test.Err)
+	}
+}
+
+func TestSetBenchingEnabled(t *testing.T) {
+	require := require.New(t)
+
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.Err)}
+		err := mockClient.SetBenchingEnabled(context.Background(), "X", false)
+		require.ErrorIs(err, test.Err)
+	}
+}
+
 func TestGetConfig(t *testing.T) {
 	type test struct {
 		name             string
@@ -371,3 +456,15 @@ func TestGetConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestRollbackChain(t *testing.T) {
+	require := require.New(t)
+
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.Err)}
+		err := mockClient.RollbackChain(context.Background(), "P", 10)
+		require.ErrorIs(err, test.Err)
+	}
+}
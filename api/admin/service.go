@@ -4,21 +4,31 @@
 package admin
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 	"net/http"
 	"path"
 	"sync"
+	"time"
 
 	"github.com/gorilla/rpc/v2"
 
 	"go.uber.org/zap"
 
+	"golang.org/x/exp/slices"
+
 	"github.com/luxdefi/node/api"
 	"github.com/luxdefi/node/api/server"
 	"github.com/luxdefi/node/chains"
 	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/network"
+	"github.com/luxdefi/node/network/banlist"
+	"github.com/luxdefi/node/snow/engine/snowman"
+	"github.com/luxdefi/node/snow/networking/benchlist"
 	"github.com/luxdefi/node/utils"
 	"github.com/luxdefi/node/utils/constants"
+	"github.com/luxdefi/node/utils/ips"
 	"github.com/luxdefi/node/utils/json"
 	"github.com/luxdefi/node/utils/logging"
 	"github.com/luxdefi/node/utils/perms"
@@ -35,8 +45,14 @@ const (
 )
 
 var (
-	errAliasTooLong = errors.New("alias length is too long")
-	errNoLogLevel   = errors.New("need to specify either displayLevel or logLevel")
+	errAliasTooLong     = errors.New("alias length is too long")
+	errNoLogLevel       = errors.New("need to specify either displayLevel or logLevel")
+	errNoBanTarget      = errors.New("need to specify either nodeID or ip")
+	errTooManyTargets   = errors.New("only one of nodeID or ip may be specified")
+	errPeerNotConnected = errors.New("peer is not connected")
+
+	errNegativeNumDecisions = errors.New("numDecisions must be non-negative")
+	errNoBenchlist          = errors.New("chain has no benchlist")
 )
 
 type Config struct {
@@ -48,6 +64,8 @@ type Config struct {
 	HTTPServer   server.PathAdderWithReadLock
 	VMRegistry   registry.VMRegistry
 	VMManager    vms.Manager
+	Network      network.Network
+	Benchlist    benchlist.Manager
 }
 
 // Admin is the API service for node admin management
@@ -350,6 +368,403 @@ func (a *Admin) LoadVMs(r *http.Request, _ *struct{}, reply *LoadVMsReply) error
 	return err
 }
 
+// AddPeerArgs are the arguments for calling AddPeer
+type AddPeerArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	IP     string     `json:"ip"`
+}
+
+// AddPeer marks a peer as persistent. The node will always attempt to
+// (re)connect to it, including after the node is restarted.
+func (a *Admin) AddPeer(_ *http.Request, args *AddPeerArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "addPeer"),
+		zap.Stringer("nodeID", args.NodeID),
+		logging.UserString("ip", args.IP),
+	)
+
+	ip, err := ips.ToIPPort(args.IP)
+	if err != nil {
+		return err
+	}
+
+	return a.Network.PinPeer(args.NodeID, ip)
+}
+
+// RemovePeerArgs are the arguments for calling RemovePeer
+type RemovePeerArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+}
+
+// RemovePeer removes a peer added with AddPeer. The node stops attempting to
+// connect to it, unless the peer is otherwise desired, and doesn't connect to
+// it after a restart. An existing connection isn't closed.
+func (a *Admin) RemovePeer(_ *http.Request, args *RemovePeerArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "removePeer"),
+		zap.Stringer("nodeID", args.NodeID),
+	)
+
+	return a.Network.UnpinPeer(args.NodeID)
+}
+
+// DisconnectPeerArgs are the arguments for calling DisconnectPeer
+type DisconnectPeerArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+}
+
+// DisconnectPeer closes the connection to a peer. If the peer is still
+// desired, the node will attempt to reconnect to it.
+func (a *Admin) DisconnectPeer(_ *http.Request, args *DisconnectPeerArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "disconnectPeer"),
+		zap.Stringer("nodeID", args.NodeID),
+	)
+
+	if !a.Network.Disconnect(args.NodeID) {
+		return errPeerNotConnected
+	}
+	return nil
+}
+
+// BanPeerArgs are the arguments for calling BanPeer
+type BanPeerArgs struct {
+	// Exactly one of NodeID or IP must be provided. IP may be either a single
+	// IP address or a CIDR range.
+	NodeID ids.NodeID `json:"nodeID"`
+	IP     string     `json:"ip"`
+	// Duration of the ban, formatted as a Go duration (e.g. "24h"). If empty,
+	// the ban never expires.
+	Duration string `json:"duration"`
+	Reason   string `json:"reason"`
+}
+
+// BanPeer bans a NodeID or an IP range. Any existing connections to the banned
+// peers are closed and new connections are refused until the ban expires.
+func (a *Admin) BanPeer(_ *http.Request, args *BanPeerArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "banPeer"),
+		zap.Stringer("nodeID", args.NodeID),
+		logging.UserString("ip", args.IP),
+		logging.UserString("duration", args.Duration),
+		logging.UserString("reason", args.Reason),
+	)
+
+	var duration time.Duration
+	if len(args.Duration) > 0 {
+		var err error
+		duration, err = time.ParseDuration(args.Duration)
+		if err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case args.NodeID == ids.EmptyNodeID && len(args.IP) == 0:
+		return errNoBanTarget
+	case args.NodeID != ids.EmptyNodeID && len(args.IP) > 0:
+		return errTooManyTargets
+	case args.NodeID != ids.EmptyNodeID:
+		return a.Network.BanNodeID(args.NodeID, duration, args.Reason)
+	default:
+		subnet, err := banlist.ParseSubnet(args.IP)
+		if err != nil {
+			return err
+		}
+		return a.Network.BanIP(subnet, duration, args.Reason)
+	}
+}
+
+// UnbanPeerArgs are the arguments for calling UnbanPeer
+type UnbanPeerArgs struct {
+	// Exactly one of NodeID or IP must be provided. IP must match the banned
+	// IP or CIDR range.
+	NodeID ids.NodeID `json:"nodeID"`
+	IP     string     `json:"ip"`
+}
+
+// UnbanPeer removes a ban on a NodeID or an IP range.
+func (a *Admin) UnbanPeer(_ *http.Request, args *UnbanPeerArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "unbanPeer"),
+		zap.Stringer("nodeID", args.NodeID),
+		logging.UserString("ip", args.IP),
+	)
+
+	switch {
+	case args.NodeID == ids.EmptyNodeID && len(args.IP) == 0:
+		return errNoBanTarget
+	case args.NodeID != ids.EmptyNodeID && len(args.IP) > 0:
+		return errTooManyTargets
+	case args.NodeID != ids.EmptyNodeID:
+		return a.Network.UnbanNodeID(args.NodeID)
+	default:
+		subnet, err := banlist.ParseSubnet(args.IP)
+		if err != nil {
+			return err
+		}
+		return a.Network.UnbanIP(subnet)
+	}
+}
+
+// Ban describes a single active ban
+type Ban struct {
+	NodeID *ids.NodeID `json:"nodeID,omitempty"`
+	IP     string      `json:"ip,omitempty"`
+	Reason string      `json:"reason,omitempty"`
+	// Expiry is nil if the ban never expires
+	Expiry *time.Time `json:"expiry,omitempty"`
+}
+
+// GetBansReply are the currently active bans
+type GetBansReply struct {
+	Bans []Ban `json:"bans"`
+}
+
+// GetBans returns all the bans that have not yet expired.
+func (a *Admin) GetBans(_ *http.Request, _ *struct{}, reply *GetBansReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "getBans"),
+	)
+
+	bans, err := a.Network.Bans()
+	if err != nil {
+		return err
+	}
+
+	reply.Bans = make([]Ban, len(bans))
+	for i, ban := range bans {
+		ban := ban
+		replyBan := Ban{
+			Reason: ban.Reason,
+		}
+		if ban.Subnet != nil {
+			replyBan.IP = ban.Subnet.String()
+		} else {
+			replyBan.NodeID = &ban.NodeID
+		}
+		if !ban.Expiry.IsZero() {
+			replyBan.Expiry = &ban.Expiry
+		}
+		reply.Bans[i] = replyBan
+	}
+	return nil
+}
+
+// ReloadAllowList reloads the node's peer allow list and disconnects any
+// peers that are no longer allowed.
+func (a *Admin) ReloadAllowList(_ *http.Request, _ *struct{}, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "reloadAllowList"),
+	)
+
+	return a.Network.ReloadAllowList()
+}
+
+// GetConsensusArgs are the arguments for calling GetConsensus
+type GetConsensusArgs struct {
+	Chain string `json:"chain"`
+	// NumDecisions is the maximum number of recent decisions to return. If 0,
+	// all the retained decisions are returned.
+	NumDecisions int `json:"numDecisions"`
+}
+
+// GetConsensusReply is the consensus state of a chain
+type GetConsensusReply struct {
+	snowman.Inspection
+}
+
+// GetConsensus returns the processing block tree, the outstanding polls, and
+// the most recent decisions of a snowman chain.
+func (a *Admin) GetConsensus(r *http.Request, args *GetConsensusArgs, reply *GetConsensusReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "getConsensus"),
+		logging.UserString("chain", args.Chain),
+		zap.Int("numDecisions", args.NumDecisions),
+	)
+
+	if args.NumDecisions < 0 {
+		return errNegativeNumDecisions
+	}
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+
+	reply.Inspection, err = a.ChainManager.InspectConsensus(r.Context(), chainID)
+	if err != nil {
+		return err
+	}
+
+	decisions := reply.RecentDecisions
+	if args.NumDecisions > 0 && len(decisions) > args.NumDecisions {
+		reply.RecentDecisions = decisions[len(decisions)-args.NumDecisions:]
+	}
+	return nil
+}
+
+// GetBenchlistArgs are the arguments for calling GetBenchlist
+type GetBenchlistArgs struct {
+	// Chain to report the benchlist of. If empty, the benchlists of all the
+	// chains are reported.
+	Chain string `json:"chain"`
+}
+
+// BenchedNode describes a node that is currently benched on a chain
+type BenchedNode struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	Reason string     `json:"reason"`
+	// Number of consecutive failed queries that caused the node to be benched
+	Failures     json.Uint32 `json:"failures"`
+	BenchedAt    time.Time   `json:"benchedAt"`
+	BenchedUntil time.Time   `json:"benchedUntil"`
+	// Time left until the node leaves the bench, formatted as a Go duration
+	Remaining string `json:"remaining"`
+}
+
+// ChainBenchlist is the benchlist of a single chain
+type ChainBenchlist struct {
+	ChainID ids.ID        `json:"chainID"`
+	Alias   string        `json:"alias,omitempty"`
+	Enabled bool          `json:"enabled"`
+	Benched []BenchedNode `json:"benched"`
+}
+
+// GetBenchlistReply are the benchlists of the requested chains
+type GetBenchlistReply struct {
+	Chains []ChainBenchlist `json:"chains"`
+}
+
+// GetBenchlist returns whether benching is enabled and which nodes are
+// currently benched, per chain.
+func (a *Admin) GetBenchlist(_ *http.Request, args *GetBenchlistArgs, reply *GetBenchlistReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "getBenchlist"),
+		logging.UserString("chain", args.Chain),
+	)
+
+	status := a.Benchlist.Status()
+	if len(args.Chain) > 0 {
+		chainID, err := a.ChainManager.Lookup(args.Chain)
+		if err != nil {
+			return err
+		}
+		chainStatus, ok := status[chainID]
+		if !ok {
+			return fmt.Errorf("%w: %s", errNoBenchlist, args.Chain)
+		}
+		status = map[ids.ID]benchlist.Status{
+			chainID: chainStatus,
+		}
+	}
+
+	reply.Chains = make([]ChainBenchlist, 0, len(status))
+	for chainID, chainStatus := range status {
+		benched := make([]BenchedNode, len(chainStatus.Benched))
+		for i, node := range chainStatus.Benched {
+			benched[i] = BenchedNode{
+				NodeID:       node.NodeID,
+				Reason:       node.Reason,
+				Failures:     json.Uint32(node.Failures),
+				BenchedAt:    node.BenchedAt,
+				BenchedUntil: node.BenchedUntil,
+				Remaining:    node.Remaining.String(),
+			}
+		}
+		reply.Chains = append(reply.Chains, ChainBenchlist{
+			ChainID: chainID,
+			Alias:   a.ChainManager.PrimaryAliasOrDefault(chainID),
+			Enabled: chainStatus.Enabled,
+			Benched: benched,
+		})
+	}
+	slices.SortFunc(reply.Chains, func(i, j ChainBenchlist) int {
+		return bytes.Compare(i.ChainID[:], j.ChainID[:])
+	})
+	return nil
+}
+
+// UnbenchArgs are the arguments for calling Unbench
+type UnbenchArgs struct {
+	Chain  string     `json:"chain"`
+	NodeID ids.NodeID `json:"nodeID"`
+}
+
+// Unbench removes a node from the bench of a chain before its bench period
+// ends.
+func (a *Admin) Unbench(_ *http.Request, args *UnbenchArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "unbench"),
+		logging.UserString("chain", args.Chain),
+		zap.Stringer("nodeID", args.NodeID),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	return a.Benchlist.Unbench(chainID, args.NodeID)
+}
+
+// SetBenchingEnabledArgs are the arguments for calling SetBenchingEnabled
+type SetBenchingEnabledArgs struct {
+	Chain   string `json:"chain"`
+	Enabled bool   `json:"enabled"`
+}
+
+// SetBenchingEnabled turns benching on or off for a chain. Turning benching off
+// unbenches every node on the chain. The setting is not persisted across
+// restarts.
+func (a *Admin) SetBenchingEnabled(_ *http.Request, args *SetBenchingEnabledArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "setBenchingEnabled"),
+		logging.UserString("chain", args.Chain),
+		zap.Bool("enabled", args.Enabled),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	return a.Benchlist.SetEnabled(chainID, args.Enabled)
+}
+
+// RollbackChainArgs are the arguments for calling RollbackChain
+type RollbackChainArgs struct {
+	Chain  string      `json:"chain"`
+	Height json.Uint64 `json:"height"`
+}
+
+// RollbackChain halts a chain and schedules its accepted state to be reverted
+// to the block at the provided height. The rollback is applied, and consensus
+// resumes from that block, the next time the node is started. Halting the
+// P-chain, X-chain or C-chain shuts the node down.
+func (a *Admin) RollbackChain(r *http.Request, args *RollbackChainArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "rollbackChain"),
+		logging.UserString("chain", args.Chain),
+		zap.Uint64("height", uint64(args.Height)),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	return a.ChainManager.RollbackChain(r.Context(), chainID, uint64(args.Height))
+}
+
 func (a *Admin) getLoggerNames(loggerName string) []string {
 	if len(loggerName) == 0 {
 		// Empty name means all loggers
@@ -6,12 +6,18 @@ package admin
 import (
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"go.uber.org/mock/gomock"
 
+	"github.com/luxdefi/node/chains"
 	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/snow"
+	"github.com/luxdefi/node/snow/networking/benchlist"
+	"github.com/luxdefi/node/snow/validators"
+	"github.com/luxdefi/node/utils/json"
 	"github.com/luxdefi/node/utils/logging"
 	"github.com/luxdefi/node/vms"
 	"github.com/luxdefi/node/vms/registry"
@@ -117,3 +123,66 @@ func TestLoadVMsGetAliasesFails(t *testing.T) {
 	err := resources.admin.LoadVMs(&http.Request{}, nil, &reply)
 	require.ErrorIs(err, errTest)
 }
+
+func TestBenchlist(t *testing.T) {
+	require := require.New(t)
+
+	ctx := snow.DefaultConsensusContextTest()
+	nodeID0 := ids.GenerateTestNodeID()
+	nodeID1 := ids.GenerateTestNodeID()
+	vdrs := validators.NewManager()
+	require.NoError(vdrs.AddStaker(ctx.SubnetID, nodeID0, nil, ids.Empty, 1))
+	require.NoError(vdrs.AddStaker(ctx.SubnetID, nodeID1, nil, ids.Empty, 1))
+
+	benchlistManager := benchlist.NewManager(&benchlist.Config{
+		Benchable:  &benchlist.TestBenchable{},
+		Validators: vdrs,
+		Threshold:  1,
+		Duration:   time.Hour,
+		MaxPortion: 0.5,
+	})
+	require.NoError(benchlistManager.RegisterChain(ctx))
+
+	admin := &Admin{Config: Config{
+		Log:          logging.NoLog{},
+		ChainManager: chains.TestManager,
+		Benchlist:    benchlistManager,
+	}}
+
+	// The second failure is after the minimum failing duration of 0.
+	benchlistManager.RegisterFailure(ctx.ChainID, nodeID0)
+	time.Sleep(time.Millisecond)
+	benchlistManager.RegisterFailure(ctx.ChainID, nodeID0)
+	require.True(benchlistManager.IsBenched(nodeID0, ctx.ChainID))
+
+	reply := GetBenchlistReply{}
+	require.NoError(admin.GetBenchlist(nil, &GetBenchlistArgs{}, &reply))
+	require.Len(reply.Chains, 1)
+	chain := reply.Chains[0]
+	require.Equal(ctx.ChainID, chain.ChainID)
+	require.True(chain.Enabled)
+	require.Len(chain.Benched, 1)
+	require.Equal(nodeID0, chain.Benched[0].NodeID)
+	require.Equal(json.Uint32(2), chain.Benched[0].Failures)
+
+	err := admin.GetBenchlist(nil, &GetBenchlistArgs{Chain: ids.GenerateTestID().String()}, &reply)
+	require.ErrorIs(err, errNoBenchlist)
+
+	args := &UnbenchArgs{
+		Chain:  ctx.ChainID.String(),
+		NodeID: nodeID0,
+	}
+	require.NoError(admin.Unbench(nil, args, nil))
+	require.False(benchlistManager.IsBenched(nodeID0, ctx.ChainID))
+	require.Error(admin.Unbench(nil, args, nil))
+
+	require.NoError(admin.SetBenchingEnabled(nil, &SetBenchingEnabledArgs{
+		Chain:   ctx.ChainID.String(),
+		Enabled: false,
+	}, nil))
+	reply = GetBenchlistReply{}
+	require.NoError(admin.GetBenchlist(nil, &GetBenchlistArgs{Chain: ctx.ChainID.String()}, &reply))
+	require.Len(reply.Chains, 1)
+	require.False(reply.Chains[0].Enabled)
+	require.Empty(reply.Chains[0].Benched)
+}
@@ -117,6 +117,50 @@ type FormattedTx struct {
 	Encoding formatting.Encoding `json:"encoding"`
 }
 
+// MempoolTx describes a tx that is waiting in the mempool
+type MempoolTx struct {
+	TxID ids.ID `json:"txID"`
+	// Size of the signed tx, in bytes
+	Size json.Uint64 `json:"size"`
+	// Amount of the fee asset burned by the tx
+	Fee json.Uint64 `json:"fee"`
+	// Unix time, in seconds, at which the tx was added to the mempool
+	AddedTime json.Uint64 `json:"addedTime"`
+	// Number of seconds the tx has been in the mempool
+	Age json.Uint64 `json:"age"`
+}
+
+// DroppedMempoolTx describes a tx that was dropped from the mempool
+type DroppedMempoolTx struct {
+	TxID   ids.ID `json:"txID"`
+	Reason string `json:"reason"`
+	// Unix time, in seconds, at which the tx was last dropped
+	DroppedTime json.Uint64 `json:"droppedTime"`
+}
+
+// GetMempoolReply is the response from GetMempool
+type GetMempoolReply struct {
+	// Txs in the order they were added to the mempool
+	Txs []MempoolTx `json:"txs"`
+	// Recently dropped txs, from the oldest to the newest
+	Dropped []DroppedMempoolTx `json:"dropped"`
+}
+
+// Types of [MempoolEvent]
+const (
+	MempoolTxAdded   = "added"
+	MempoolTxRemoved = "removed"
+	MempoolTxDropped = "dropped"
+)
+
+// MempoolEvent is published over the pubsub server when the mempool changes
+type MempoolEvent struct {
+	Event string `json:"event"`
+	TxID  ids.ID `json:"txID"`
+	// Reason the tx was dropped. Only set for dropped txs.
+	Reason string `json:"reason,omitempty"`
+}
+
 // Index is an address and an associated UTXO.
 // Marks a starting or stopping point when fetching UTXOs. Used for pagination.
 type Index struct {
@@ -27,6 +27,7 @@ type Client interface {
 	IsBootstrapped(context.Context, string, ...rpc.Option) (bool, error)
 	GetTxFee(context.Context, ...rpc.Option) (*GetTxFeeResponse, error)
 	Uptime(context.Context, ids.ID, ...rpc.Option) (*UptimeResponse, error)
+	UptimeHistory(context.Context, *UptimeHistoryRequest, ...rpc.Option) (*UptimeHistoryResponse, error)
 	GetVMs(context.Context, ...rpc.Option) (map[ids.ID][]string, error)
 }
 
@@ -108,6 +109,12 @@ func (c *client) Uptime(ctx context.Context, subnetID ids.ID, options ...rpc.Opt
 	return res, err
 }
 
+func (c *client) UptimeHistory(ctx context.Context, args *UptimeHistoryRequest, options ...rpc.Option) (*UptimeHistoryResponse, error) {
+	res := &UptimeHistoryResponse{}
+	err := c.requester.SendRequest(ctx, "info.uptimeHistory", args, res, options...)
+	return res, err
+}
+
 func (c *client) GetVMs(ctx context.Context, options ...rpc.Option) (map[ids.ID][]string, error) {
 	res := &GetVMsReply{}
 	err := c.requester.SendRequest(ctx, "info.getVMs", struct{}{}, res, options...)
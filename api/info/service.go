@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/rpc/v2"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/luxdefi/node/network"
 	"github.com/luxdefi/node/network/peer"
 	"github.com/luxdefi/node/snow/networking/benchlist"
+	"github.com/luxdefi/node/snow/uptime"
 	"github.com/luxdefi/node/utils/constants"
 	"github.com/luxdefi/node/utils/ips"
 	"github.com/luxdefi/node/utils/json"
@@ -26,7 +28,10 @@ import (
 	"github.com/luxdefi/node/vms/platformvm/signer"
 )
 
-var errNoChainProvided = errors.New("argument 'chain' not given")
+var (
+	errNoChainProvided   = errors.New("argument 'chain' not given")
+	errStartAfterEndTime = errors.New("start time must be before end time")
+)
 
 // Info is the API service for unprivileged info on a node
 type Info struct {
@@ -37,6 +42,7 @@ type Info struct {
 	chainManager chains.Manager
 	vmManager    vms.Manager
 	benchlist    benchlist.Manager
+	uptimes      uptime.HistoryCalculator
 }
 
 type Parameters struct {
@@ -64,6 +70,7 @@ func NewService(
 	myIP ips.DynamicIPPort,
 	network network.Network,
 	benchlist benchlist.Manager,
+	uptimes uptime.HistoryCalculator,
 ) (http.Handler, error) {
 	server := rpc.NewServer()
 	codec := json.NewCodec()
@@ -78,6 +85,7 @@ func NewService(
 			myIP:         myIP,
 			networking:   network,
 			benchlist:    benchlist,
+			uptimes:      uptimes,
 		},
 		"info",
 	)
@@ -209,6 +217,18 @@ type Peer struct {
 	peer.Info
 
 	Benched []string `json:"benched"`
+	// Chain alias --> Why and for how long the peer is benched on that chain
+	BenchDetails map[string]BenchDetails `json:"benchDetails,omitempty"`
+}
+
+// BenchDetails describes why and for how long a peer is benched on a chain
+type BenchDetails struct {
+	Reason string `json:"reason"`
+	// Number of consecutive failed queries that caused the peer to be benched
+	Failures     json.Uint32 `json:"failures"`
+	BenchedUntil time.Time   `json:"benchedUntil"`
+	// Time left until the peer leaves the bench, formatted as a Go duration
+	Remaining string `json:"remaining"`
 }
 
 // PeersReply are the results from calling Peers
@@ -226,6 +246,19 @@ func (i *Info) Peers(_ *http.Request, args *PeersArgs, reply *PeersReply) error
 		zap.String("method", "peers"),
 	)
 
+	// Node ID --> Chain ID --> Why the node is benched on that chain
+	benchedNodes := make(map[ids.NodeID]map[ids.ID]benchlist.BenchedNode)
+	for chainID, status := range i.benchlist.Status() {
+		for _, benched := range status.Benched {
+			chains, ok := benchedNodes[benched.NodeID]
+			if !ok {
+				chains = make(map[ids.ID]benchlist.BenchedNode)
+				benchedNodes[benched.NodeID] = chains
+			}
+			chains[chainID] = benched
+		}
+	}
+
 	peers := i.networking.PeerInfo(args.NodeIDs)
 	peerInfo := make([]Peer, len(peers))
 	for index, peer := range peers {
@@ -238,9 +271,26 @@ func (i *Info) Peers(_ *http.Request, args *PeersArgs, reply *PeersReply) error
 			}
 			benchedAliases[idx] = alias
 		}
+		var benchDetails map[string]BenchDetails
+		if chains := benchedNodes[peer.ID]; len(chains) > 0 {
+			benchDetails = make(map[string]BenchDetails, len(chains))
+			for chainID, benched := range chains {
+				alias, err := i.chainManager.PrimaryAlias(chainID)
+				if err != nil {
+					return fmt.Errorf("failed to get primary alias for chain ID %s: %w", chainID, err)
+				}
+				benchDetails[alias] = BenchDetails{
+					Reason:       benched.Reason,
+					Failures:     json.Uint32(benched.Failures),
+					BenchedUntil: benched.BenchedUntil,
+					Remaining:    benched.Remaining.String(),
+				}
+			}
+		}
 		peerInfo[index] = Peer{
-			Info:    peer,
-			Benched: benchedAliases,
+			Info:         peer,
+			Benched:      benchedAliases,
+			BenchDetails: benchDetails,
 		}
 	}
 
@@ -319,6 +369,98 @@ func (i *Info) Uptime(_ *http.Request, args *UptimeRequest, reply *UptimeRespons
 	return nil
 }
 
+type UptimeHistoryRequest struct {
+	// if omitted, defaults to this node
+	NodeID ids.NodeID `json:"nodeID"`
+	// if omitted, defaults to primary network
+	SubnetID ids.ID `json:"subnetID"`
+	// Unix time, in seconds, of the start of the range. It is rounded down to
+	// the start of the day.
+	StartTime json.Uint64 `json:"startTime"`
+	// Unix time, in seconds, of the end of the range. If 0, defaults to now.
+	EndTime json.Uint64 `json:"endTime"`
+}
+
+// UptimeEpoch is the uptime of a validator during one day, as observed by this
+// node
+type UptimeEpoch struct {
+	// Unix time, in seconds, of the start of the day
+	StartTime json.Uint64 `json:"startTime"`
+	// Number of seconds this node tracked the validator for
+	Observed json.Uint64 `json:"observed"`
+	// Number of the observed seconds the validator was connected for
+	Connected json.Uint64 `json:"connected"`
+	// Percentage (0-100) of the observed time the validator was connected for
+	UptimePercentage json.Float64 `json:"uptimePercentage"`
+}
+
+// UptimeHistoryResponse are the results from calling UptimeHistory
+type UptimeHistoryResponse struct {
+	// Days during which the validator was observed, in order
+	Epochs []UptimeEpoch `json:"epochs"`
+	// Totals over all of [Epochs]
+	Observed         json.Uint64  `json:"observed"`
+	Connected        json.Uint64  `json:"connected"`
+	UptimePercentage json.Float64 `json:"uptimePercentage"`
+}
+
+// UptimeHistory returns the per-day uptime of a validator, as observed by this
+// node, over a range of time. Unlike Uptime, which reports how the network
+// sees this node in the current staking period, the history persists across
+// staking periods and restarts.
+func (i *Info) UptimeHistory(_ *http.Request, args *UptimeHistoryRequest, reply *UptimeHistoryResponse) error {
+	i.log.Debug("API called",
+		zap.String("service", "info"),
+		zap.String("method", "uptimeHistory"),
+		zap.Stringer("nodeID", args.NodeID),
+		zap.Stringer("subnetID", args.SubnetID),
+	)
+
+	nodeID := args.NodeID
+	if nodeID == ids.EmptyNodeID {
+		nodeID = i.NodeID
+	}
+	startTime := time.Unix(int64(args.StartTime), 0)
+	endTime := time.Now()
+	if args.EndTime != 0 {
+		endTime = time.Unix(int64(args.EndTime), 0)
+	}
+	if endTime.Before(startTime) {
+		return errStartAfterEndTime
+	}
+
+	epochs, err := i.uptimes.CalculateUptimeHistory(nodeID, args.SubnetID, startTime, endTime)
+	if err != nil {
+		return fmt.Errorf("couldn't get uptime history of %s: %w", nodeID, err)
+	}
+
+	reply.Epochs = make([]UptimeEpoch, len(epochs))
+	var observed, connected time.Duration
+	for j, epoch := range epochs {
+		reply.Epochs[j] = UptimeEpoch{
+			StartTime:        json.Uint64(epoch.Start.Unix()),
+			Observed:         json.Uint64(epoch.Observed / time.Second),
+			Connected:        json.Uint64(epoch.Connected / time.Second),
+			UptimePercentage: json.Float64(uptimePercentage(epoch.Observed, epoch.Connected)),
+		}
+		observed += epoch.Observed
+		connected += epoch.Connected
+	}
+	reply.Observed = json.Uint64(observed / time.Second)
+	reply.Connected = json.Uint64(connected / time.Second)
+	reply.UptimePercentage = json.Float64(uptimePercentage(observed, connected))
+	return nil
+}
+
+// uptimePercentage returns the percentage (0-100) of [observed] that is
+// [connected]. If nothing was observed, 0 is returned.
+func uptimePercentage(observed, connected time.Duration) float64 {
+	if observed == 0 {
+		return 0
+	}
+	return 100 * float64(connected) / float64(observed)
+}
+
 type GetTxFeeResponse struct {
 	TxFee                         json.Uint64 `json:"txFee"`
 	CreateAssetTxFee              json.Uint64 `json:"createAssetTxFee"`
@@ -6,13 +6,19 @@ package info
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"go.uber.org/mock/gomock"
 
+	"github.com/luxdefi/node/database/memdb"
 	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/snow/uptime"
+	"github.com/luxdefi/node/utils/constants"
+	"github.com/luxdefi/node/utils/json"
 	"github.com/luxdefi/node/utils/logging"
+	"github.com/luxdefi/node/utils/timer/mockable"
 	"github.com/luxdefi/node/vms"
 )
 
@@ -102,3 +108,45 @@ func TestGetVMsGetAliasesFails(t *testing.T) {
 	err := resources.info.GetVMs(nil, nil, &reply)
 	require.ErrorIs(t, err, errTest)
 }
+
+func TestUptimeHistory(t *testing.T) {
+	require := require.New(t)
+
+	nodeID := ids.GenerateTestNodeID()
+	startTime := time.Unix(0, 0).Add(100 * uptime.EpochDuration)
+
+	state := uptime.NewTestState()
+	state.AddNode(nodeID, constants.PrimaryNetworkID, startTime)
+
+	clk := mockable.Clock{}
+	clk.Set(startTime)
+	uptimes := uptime.NewManagerWithHistory(state, uptime.NewHistory(memdb.New()), &clk)
+	require.NoError(uptimes.StartTracking([]ids.NodeID{nodeID}, constants.PrimaryNetworkID))
+	require.NoError(uptimes.Connect(nodeID, constants.PrimaryNetworkID))
+	clk.Set(startTime.Add(3 * time.Hour))
+	require.NoError(uptimes.Disconnect(nodeID))
+	clk.Set(startTime.Add(4 * time.Hour))
+
+	service := Info{
+		Parameters: Parameters{NodeID: nodeID},
+		log:        logging.NoLog{},
+		uptimes:    uptimes,
+	}
+
+	// The node ID defaults to this node's.
+	args := UptimeHistoryRequest{
+		StartTime: json.Uint64(startTime.Unix()),
+		EndTime:   json.Uint64(startTime.Add(uptime.EpochDuration).Unix()),
+	}
+	reply := UptimeHistoryResponse{}
+	require.NoError(service.UptimeHistory(nil, &args, &reply))
+	require.Len(reply.Epochs, 1)
+	require.Equal(json.Uint64(startTime.Unix()), reply.Epochs[0].StartTime)
+	require.Equal(json.Uint64(4*time.Hour/time.Second), reply.Observed)
+	require.Equal(json.Uint64(3*time.Hour/time.Second), reply.Connected)
+	require.Equal(json.Float64(75), reply.UptimePercentage)
+
+	args.EndTime = json.Uint64(startTime.Add(-time.Second).Unix())
+	err := service.UptimeHistory(nil, &args, &reply)
+	require.ErrorIs(err, errStartAfterEndTime)
+}
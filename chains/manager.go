@@ -43,6 +43,7 @@ import (
 	"github.com/luxdefi/node/snow/networking/sender"
 	"github.com/luxdefi/node/snow/networking/timeout"
 	"github.com/luxdefi/node/snow/validators"
+	"github.com/luxdefi/node/snow/validators/sampling"
 	"github.com/luxdefi/node/staking"
 	"github.com/luxdefi/node/subnets"
 	"github.com/luxdefi/node/trace"
@@ -52,6 +53,7 @@ import (
 	"github.com/luxdefi/node/utils/logging"
 	"github.com/luxdefi/node/utils/perms"
 	"github.com/luxdefi/node/utils/set"
+	"github.com/luxdefi/node/utils/timer/mockable"
 	"github.com/luxdefi/node/version"
 	"github.com/luxdefi/node/vms"
 	"github.com/luxdefi/node/vms/metervm"
@@ -94,6 +96,8 @@ var (
 	errNotBootstrapped         = errors.New("subnets not bootstrapped")
 	errNoPrimaryNetworkConfig  = errors.New("no subnet config for primary network found")
 	errPartialSyncAsAValidator = errors.New("partial sync should not be configured for a validator")
+	errUnknownChain            = errors.New("unknown chain")
+	errNotSnowmanChain         = errors.New("chain isn't running snowman consensus")
 
 	_ Manager = (*manager)(nil)
 )
@@ -129,6 +133,14 @@ type Manager interface {
 	// Returns true iff the chain with the given ID exists and is finished bootstrapping
 	IsBootstrapped(ids.ID) bool
 
+	// Returns a snapshot of the consensus state of the snowman chain with the
+	// given ID
+	InspectConsensus(ctx context.Context, chainID ids.ID) (smeng.Inspection, error)
+
+	// Halts the chain with the given ID after scheduling its accepted state
+	// to be reverted to [height] the next time the node is started
+	RollbackChain(ctx context.Context, chainID ids.ID, height uint64) error
+
 	// Starts the chain creator with the initial platform chain parameters, must
 	// be called once.
 	StartChainCreator(platformChain ChainParameters) error
@@ -258,6 +270,9 @@ type manager struct {
 	// Key: Chain's ID
 	// Value: The chain
 	chains map[ids.ID]handler.Handler
+	// Key: Chain's ID
+	// Value: The VM of the chain
+	chainVMs map[ids.ID]common.VM
 
 	// snowman++ related interface to allow validators retrieval
 	validatorState validators.State
@@ -272,6 +287,7 @@ func New(config *ManagerConfig) Manager {
 		stakingCert:            staking.CertificateFromX509(config.StakingTLSCert.Leaf),
 		subnets:                make(map[ids.ID]subnets.Subnet),
 		chains:                 make(map[ids.ID]handler.Handler),
+		chainVMs:               make(map[ids.ID]common.VM),
 		chainsQueue:            buffer.NewUnboundedBlockingDeque[ChainParameters](initialQueueSize),
 		unblockChainCreatorCh:  make(chan struct{}),
 		chainCreatorShutdownCh: make(chan struct{}),
@@ -390,6 +406,7 @@ func (m *manager) createChain(chainParams ChainParameters) {
 
 	m.chainsLock.Lock()
 	m.chains[chainParams.ID] = chain.Handler
+	m.chainVMs[chainParams.ID] = chain.VM
 	m.chainsLock.Unlock()
 
 	// Associate the newly created chain with its default alias
@@ -858,6 +875,11 @@ func (m *manager) createLuxChain(
 
 	// Create engine, bootstrapper and state-syncer in this order,
 	// to make sure start callbacks are duly initialized
+	querySampler, err := m.createQuerySampler(ctx, vdrs, sb.Config().QuerySampling)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing query sampler: %w", err)
+	}
+
 	snowmanEngineConfig := smeng.Config{
 		Ctx:                 ctx,
 		AllGetsServer:       snowGetHandler,
@@ -867,6 +889,7 @@ func (m *manager) createLuxChain(
 		ConnectedValidators: connectedValidators,
 		Params:              consensusParams,
 		Consensus:           snowmanConsensus,
+		Sampler:             querySampler,
 	}
 	snowmanEngine, err := smeng.New(snowmanEngineConfig)
 	if err != nil {
@@ -977,6 +1000,28 @@ func (m *manager) createLuxChain(
 	}, nil
 }
 
+// createQuerySampler returns the sampler of the validators that the queries of
+// [ctx]'s chain are sent to. If the validators are sampled by stake, nil is
+// returned and the engine samples from [vdrs] directly.
+func (m *manager) createQuerySampler(
+	ctx *snow.ConsensusContext,
+	vdrs validators.Manager,
+	config sampling.Config,
+) (sampling.Sampler, error) {
+	if config.Strategy == "" || config.Strategy == sampling.StakeStrategy {
+		return nil, nil
+	}
+	return sampling.New(
+		config,
+		ctx.SubnetID,
+		vdrs,
+		func(nodeID ids.NodeID) bool {
+			return m.TimeoutManager.IsBenched(nodeID, ctx.ChainID)
+		},
+		&mockable.Clock{},
+	)
+}
+
 // Create a linear chain using the Snowman consensus engine
 func (m *manager) createSnowmanChain(
 	ctx *snow.ConsensusContext,
@@ -1002,6 +1047,7 @@ func (m *manager) createSnowmanChain(
 	prefixDB := prefixdb.New(ctx.ChainID[:], meterDB)
 	vmDB := prefixdb.New(vmDBPrefix, prefixDB)
 	bootstrappingDB := prefixdb.New(bootstrappingDB, prefixDB)
+	rollbackDB := prefixdb.New(rollbackDBPrefix, prefixDB)
 
 	blocked, err := queue.NewWithMissing(bootstrappingDB, "block", ctx.Registerer)
 	if err != nil {
@@ -1145,6 +1191,10 @@ func (m *manager) createSnowmanChain(
 		return nil, err
 	}
 
+	if err := applyScheduledRollback(ctx, rollbackDB, vm); err != nil {
+		return nil, err
+	}
+
 	bootstrapWeight, err := beacons.TotalWeight(ctx.SubnetID)
 	if err != nil {
 		return nil, fmt.Errorf("error while fetching weight for subnet %s: %w", ctx.SubnetID, err)
@@ -1201,6 +1251,11 @@ func (m *manager) createSnowmanChain(
 
 	// Create engine, bootstrapper and state-syncer in this order,
 	// to make sure start callbacks are duly initialized
+	querySampler, err := m.createQuerySampler(ctx, vdrs, sb.Config().QuerySampling)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing query sampler: %w", err)
+	}
+
 	engineConfig := smeng.Config{
 		Ctx:                 ctx,
 		AllGetsServer:       snowGetHandler,
@@ -1211,6 +1266,7 @@ func (m *manager) createSnowmanChain(
 		Params:              consensusParams,
 		Consensus:           consensus,
 		PartialSync:         m.PartialSyncPrimaryNetwork && ctx.ChainID == constants.PlatformChainID,
+		Sampler:             querySampler,
 	}
 	engine, err := smeng.New(engineConfig)
 	if err != nil {
@@ -1306,6 +1362,25 @@ func (m *manager) IsBootstrapped(id ids.ID) bool {
 	return chain.Context().State.Get().State == snow.NormalOp
 }
 
+func (m *manager) InspectConsensus(ctx context.Context, chainID ids.ID) (smeng.Inspection, error) {
+	m.chainsLock.Lock()
+	chain, exists := m.chains[chainID]
+	m.chainsLock.Unlock()
+	if !exists {
+		return smeng.Inspection{}, fmt.Errorf("%w: %s", errUnknownChain, chainID)
+	}
+
+	engine := chain.GetEngineManager().Snowman
+	if engine == nil {
+		return smeng.Inspection{}, fmt.Errorf("%w: %s", errNotSnowmanChain, chainID)
+	}
+	consensus, ok := engine.Consensus.(smeng.Engine)
+	if !ok {
+		return smeng.Inspection{}, fmt.Errorf("%w: %s", errNotSnowmanChain, chainID)
+	}
+	return consensus.Inspect(ctx)
+}
+
 func (m *manager) subnetsNotBootstrapped() []ids.ID {
 	m.subnetsLock.RLock()
 	defer m.subnetsLock.RUnlock()
@@ -0,0 +1,133 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/luxdefi/node/database"
+	"github.com/luxdefi/node/database/prefixdb"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/snow"
+	"github.com/luxdefi/node/snow/engine/snowman/block"
+)
+
+var (
+	// Prefix of the rollback scheduled for a chain
+	rollbackDBPrefix = []byte("rollback")
+
+	rollbackHeightKey = []byte("height")
+)
+
+// ScheduleRollback records that the chain [chainID] should be rolled back to
+// [height] the next time it is created. [db] is the database of the node.
+func ScheduleRollback(db database.Database, chainID ids.ID, height uint64) error {
+	return database.PutUInt64(newRollbackDB(db, chainID), rollbackHeightKey, height)
+}
+
+// CancelRollback removes the rollback scheduled for the chain [chainID], if
+// any. [db] is the database of the node.
+func CancelRollback(db database.Database, chainID ids.ID) error {
+	return newRollbackDB(db, chainID).Delete(rollbackHeightKey)
+}
+
+// GetScheduledRollback returns the height that the chain [chainID] will be
+// rolled back to the next time it is created. If no rollback is scheduled,
+// false is returned. [db] is the database of the node.
+func GetScheduledRollback(db database.Database, chainID ids.ID) (uint64, bool, error) {
+	return getScheduledRollback(newRollbackDB(db, chainID))
+}
+
+func newRollbackDB(db database.Database, chainID ids.ID) database.Database {
+	return prefixdb.New(rollbackDBPrefix, prefixdb.New(chainID[:], db))
+}
+
+func getScheduledRollback(rollbackDB database.KeyValueReader) (uint64, bool, error) {
+	height, err := database.GetUInt64(rollbackDB, rollbackHeightKey)
+	if err == database.ErrNotFound {
+		return 0, false, nil
+	}
+	return height, err == nil, err
+}
+
+// applyScheduledRollback rolls [vm] back to the height scheduled in
+// [rollbackDB], if any. The schedule is only removed once the rollback
+// succeeded, so an interrupted rollback is retried on the next start.
+//
+// Invariant: [vm] has been initialized and the engine hasn't been started.
+func applyScheduledRollback(
+	ctx *snow.ConsensusContext,
+	rollbackDB database.KeyValueReaderWriterDeleter,
+	vm block.ChainVM,
+) error {
+	height, scheduled, err := getScheduledRollback(rollbackDB)
+	if err != nil || !scheduled {
+		return err
+	}
+
+	rollbackVM, ok := vm.(block.RollbackableVM)
+	if !ok {
+		return fmt.Errorf("%w: %T", block.ErrRollbackNotSupported, vm)
+	}
+
+	ctx.Log.Info("rolling back chain",
+		zap.Uint64("height", height),
+	)
+	if err := rollbackVM.Rollback(context.TODO(), height); err != nil {
+		return fmt.Errorf("failed to roll back to height %d: %w", height, err)
+	}
+	if err := rollbackDB.Delete(rollbackHeightKey); err != nil {
+		return err
+	}
+
+	lastAcceptedID, err := vm.LastAccepted(context.TODO())
+	if err != nil {
+		return err
+	}
+	ctx.Log.Info("rolled back chain",
+		zap.Uint64("height", height),
+		zap.Stringer("lastAcceptedID", lastAcceptedID),
+	)
+	return nil
+}
+
+// RollbackChain verifies that the chain [chainID] can be rolled back to
+// [height], schedules the rollback and halts the chain. The rollback is
+// applied the next time the node is started.
+func (m *manager) RollbackChain(ctx context.Context, chainID ids.ID, height uint64) error {
+	m.chainsLock.Lock()
+	chain, exists := m.chains[chainID]
+	vm := m.chainVMs[chainID]
+	m.chainsLock.Unlock()
+	if !exists {
+		return fmt.Errorf("%w: %s", errUnknownChain, chainID)
+	}
+
+	rollbackVM, ok := vm.(block.RollbackableVM)
+	if !ok {
+		return fmt.Errorf("%w: %T", block.ErrRollbackNotSupported, vm)
+	}
+
+	chainCtx := chain.Context()
+	chainCtx.Lock.Lock()
+	err := rollbackVM.VerifyRollback(ctx, height)
+	chainCtx.Lock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := ScheduleRollback(m.DB, chainID, height); err != nil {
+		return err
+	}
+
+	m.Log.Warn("halting chain for rollback",
+		zap.Stringer("chainID", chainID),
+		zap.Uint64("height", height),
+	)
+	chain.Stop(ctx)
+	return nil
+}
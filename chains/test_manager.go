@@ -4,7 +4,10 @@
 package chains
 
 import (
+	"context"
+
 	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/snow/engine/snowman"
 	"github.com/luxdefi/node/snow/networking/router"
 )
 
@@ -56,6 +59,14 @@ func (testManager) IsBootstrapped(ids.ID) bool {
 	return false
 }
 
+func (testManager) InspectConsensus(context.Context, ids.ID) (snowman.Inspection, error) {
+	return snowman.Inspection{}, nil
+}
+
+func (testManager) RollbackChain(context.Context, ids.ID, uint64) error {
+	return nil
+}
+
 func (testManager) Lookup(s string) (ids.ID, error) {
 	return ids.FromString(s)
 }
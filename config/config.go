@@ -28,12 +28,14 @@ import (
 	"github.com/luxdefi/node/nat"
 	"github.com/luxdefi/node/network"
 	"github.com/luxdefi/node/network/dialer"
+	"github.com/luxdefi/node/network/peer"
 	"github.com/luxdefi/node/network/throttling"
 	"github.com/luxdefi/node/node"
 	"github.com/luxdefi/node/snow/consensus/snowball"
 	"github.com/luxdefi/node/snow/networking/benchlist"
 	"github.com/luxdefi/node/snow/networking/router"
 	"github.com/luxdefi/node/snow/networking/tracker"
+	"github.com/luxdefi/node/snow/validators/sampling"
 	"github.com/luxdefi/node/staking"
 	"github.com/luxdefi/node/subnets"
 	"github.com/luxdefi/node/trace"
@@ -92,6 +94,7 @@ var (
 	errStakeMaxConsumptionTooLarge            = fmt.Errorf("max stake consumption must be less than or equal to %d", reward.PercentDenominator)
 	errStakeMaxConsumptionBelowMin            = errors.New("stake max consumption can't be less than min stake consumption")
 	errStakeMintingPeriodBelowMin             = errors.New("stake minting period can't be less than max stake duration")
+	errInvalidDynamicFeeConfig                = errors.New("invalid dynamic fee config")
 	errCannotTrackPrimaryNetwork              = errors.New("cannot track primary network")
 	errStakingKeyContentUnset                 = fmt.Errorf("%s key not set but %s set", StakingTLSKeyContentKey, StakingCertContentKey)
 	errStakingCertContentUnset                = fmt.Errorf("%s key set but %s not set", StakingTLSKeyContentKey, StakingCertContentKey)
@@ -120,6 +123,7 @@ func getConsensusConfig(v *viper.Viper) snowball.Parameters {
 		OptimalProcessing:     v.GetInt(SnowOptimalProcessingKey),
 		MaxOutstandingItems:   v.GetInt(SnowMaxProcessingKey),
 		MaxItemProcessingTime: v.GetDuration(SnowMaxTimeProcessingKey),
+		StakeWeightedPolls:    v.GetBool(SnowStakeWeightedPollsKey),
 	}
 	if v.IsSet(SnowQuorumSizeKey) {
 		p.AlphaPreference = v.GetInt(SnowQuorumSizeKey)
@@ -346,6 +350,11 @@ func getNetworkConfig(
 		allowPrivateIPs = v.GetBool(NetworkAllowPrivateIPsKey)
 	}
 
+	allowListSubnetIDs, err := getAllowListSubnets(v)
+	if err != nil {
+		return network.Config{}, err
+	}
+
 	config := network.Config{
 		ThrottlerConfig: network.ThrottlerConfig{
 			MaxInboundConnsPerSec: maxInboundConnsPerSec,
@@ -393,6 +402,9 @@ func getNetworkConfig(
 		ProxyEnabled:           v.GetBool(NetworkTCPProxyEnabledKey),
 		ProxyReadHeaderTimeout: v.GetDuration(NetworkTCPProxyReadTimeoutKey),
 
+		QUICEnabled:     v.GetBool(NetworkQUICEnabledKey),
+		QUICDialTimeout: v.GetDuration(NetworkQUICDialTimeoutKey),
+
 		DialerConfig: dialer.Config{
 			ThrottleRps:       v.GetUint32(NetworkOutboundConnectionThrottlingRpsKey),
 			ConnectionTimeout: v.GetDuration(NetworkOutboundConnectionTimeoutKey),
@@ -400,6 +412,9 @@ func getNetworkConfig(
 
 		TLSKeyLogFile: v.GetString(NetworkTLSKeyLogFileKey),
 
+		AllowListFile:      v.GetString(NetworkAllowListFileKey),
+		AllowListSubnetIDs: allowListSubnetIDs,
+
 		TimeoutConfig: network.TimeoutConfig{
 			PingPongTimeout:      v.GetDuration(NetworkPingTimeoutKey),
 			ReadHandshakeTimeout: v.GetDuration(NetworkReadHandshakeTimeoutKey),
@@ -418,6 +433,8 @@ func getNetworkConfig(
 			InitialReconnectDelay: v.GetDuration(NetworkInitialReconnectDelayKey),
 		},
 
+		AddressBookMaxAge: v.GetDuration(NetworkAddressBookMaxAgeKey),
+
 		MaxClockDifference:           v.GetDuration(NetworkMaxClockDifferenceKey),
 		CompressionType:              compressionType,
 		PingFrequency:                v.GetDuration(NetworkPingFrequencyKey),
@@ -428,6 +445,21 @@ func getNetworkConfig(
 		RequireValidatorToConnect: v.GetBool(NetworkRequireValidatorToConnectKey),
 		PeerReadBufferSize:        int(v.GetUint(NetworkPeerReadBufferSizeKey)),
 		PeerWriteBufferSize:       int(v.GetUint(NetworkPeerWriteBufferSizeKey)),
+
+		MessageQueueConfig: peer.PriorityMessageQueueConfig{
+			Consensus: peer.MessageClassConfig{
+				MaxBytes:   v.GetUint64(NetworkSendQueueConsensusMaxBytesKey),
+				DropOldest: v.GetBool(NetworkSendQueueConsensusDropOldestKey),
+			},
+			Bootstrap: peer.MessageClassConfig{
+				MaxBytes:   v.GetUint64(NetworkSendQueueBootstrapMaxBytesKey),
+				DropOldest: v.GetBool(NetworkSendQueueBootstrapDropOldestKey),
+			},
+			App: peer.MessageClassConfig{
+				MaxBytes:   v.GetUint64(NetworkSendQueueAppMaxBytesKey),
+				DropOldest: v.GetBool(NetworkSendQueueAppDropOldestKey),
+			},
+		},
 	}
 
 	switch {
@@ -453,6 +485,8 @@ func getNetworkConfig(
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkInitialReconnectDelayKey)
 	case config.MaxReconnectDelay < config.InitialReconnectDelay:
 		return network.Config{}, fmt.Errorf("%s must be >= %s", NetworkMaxReconnectDelayKey, NetworkInitialReconnectDelayKey)
+	case config.AddressBookMaxAge <= 0:
+		return network.Config{}, fmt.Errorf("%s must be > 0", NetworkAddressBookMaxAgeKey)
 	case config.PingPongTimeout < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkPingTimeoutKey)
 	case config.PingFrequency < 0:
@@ -463,6 +497,10 @@ func getNetworkConfig(
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkReadHandshakeTimeoutKey)
 	case config.MaxClockDifference < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkMaxClockDifferenceKey)
+	case config.QUICEnabled && config.ProxyEnabled:
+		return network.Config{}, fmt.Errorf("%s can't be enabled with %s", NetworkQUICEnabledKey, NetworkTCPProxyEnabledKey)
+	case config.QUICDialTimeout <= 0:
+		return network.Config{}, fmt.Errorf("%s must be > 0", NetworkQUICDialTimeoutKey)
 	}
 	return config, nil
 }
@@ -532,6 +570,11 @@ func getBootstrapConfig(v *viper.Viper, networkID uint32) (node.BootstrapConfig,
 		BootstrapMaxTimeGetAncestors:            v.GetDuration(BootstrapMaxTimeGetAncestorsKey),
 		BootstrapAncestorsMaxContainersSent:     int(v.GetUint(BootstrapAncestorsMaxContainersSentKey)),
 		BootstrapAncestorsMaxContainersReceived: int(v.GetUint(BootstrapAncestorsMaxContainersReceivedKey)),
+		DNSSeeds:                                v.GetStringSlice(DNSSeedsKey),
+		DNSSeedTimeout:                          v.GetDuration(DNSSeedTimeoutKey),
+	}
+	if config.DNSSeedTimeout <= 0 {
+		return node.BootstrapConfig{}, fmt.Errorf("%q must be > 0", DNSSeedTimeoutKey)
 	}
 
 	// TODO: Add a "BootstrappersKey" flag to more clearly enforce ID and IP
@@ -858,9 +901,10 @@ func getStakingConfig(v *viper.Viper, networkID uint32) (node.StakingConfig, err
 	return config, nil
 }
 
-func getTxFeeConfig(v *viper.Viper, networkID uint32) genesis.TxFeeConfig {
+func getTxFeeConfig(v *viper.Viper, networkID uint32) (genesis.TxFeeConfig, error) {
+	config := genesis.GetTxFeeConfig(networkID)
 	if networkID != constants.MainnetID && networkID != constants.TestnetID {
-		return genesis.TxFeeConfig{
+		config = genesis.TxFeeConfig{
 			TxFee:                         v.GetUint64(TxFeeKey),
 			CreateAssetTxFee:              v.GetUint64(CreateAssetTxFeeKey),
 			CreateSubnetTxFee:             v.GetUint64(CreateSubnetTxFeeKey),
@@ -870,9 +914,15 @@ func getTxFeeConfig(v *viper.Viper, networkID uint32) genesis.TxFeeConfig {
 			AddPrimaryNetworkDelegatorFee: v.GetUint64(AddPrimaryNetworkDelegatorFeeKey),
 			AddSubnetValidatorFee:         v.GetUint64(AddSubnetValidatorFeeKey),
 			AddSubnetDelegatorFee:         v.GetUint64(AddSubnetDelegatorFeeKey),
+			DynamicFeeConfig:              config.DynamicFeeConfig,
+		}
+	}
+	if config.DynamicFeeConfig != nil {
+		if err := config.DynamicFeeConfig.Verify(); err != nil {
+			return genesis.TxFeeConfig{}, fmt.Errorf("%w: %w", errInvalidDynamicFeeConfig, err)
 		}
 	}
-	return genesis.GetTxFeeConfig(networkID)
+	return config, nil
 }
 
 func getGenesisData(v *viper.Viper, networkID uint32, stakingCfg *genesis.StakingConfig) ([]byte, ids.ID, error) {
@@ -913,6 +963,23 @@ func getTrackedSubnets(v *viper.Viper) (set.Set[ids.ID], error) {
 	return trackedSubnetIDs, nil
 }
 
+func getAllowListSubnets(v *viper.Viper) (set.Set[ids.ID], error) {
+	subnetsStrs := strings.Split(v.GetString(NetworkAllowListSubnetsKey), ",")
+	subnetIDs := set.NewSet[ids.ID](len(subnetsStrs))
+	for _, subnet := range subnetsStrs {
+		subnet = strings.TrimSpace(subnet)
+		if subnet == "" {
+			continue
+		}
+		subnetID, err := ids.FromString(subnet)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse subnetID %q: %w", subnet, err)
+		}
+		subnetIDs.Add(subnetID)
+	}
+	return subnetIDs, nil
+}
+
 func getDatabaseConfig(v *viper.Viper, networkID uint32) (node.DatabaseConfig, error) {
 	var (
 		configBytes []byte
@@ -1196,6 +1263,7 @@ func getSubnetConfigsFromDir(v *viper.Viper, subnetIDs []ids.ID) (map[ids.ID]sub
 func getDefaultSubnetConfig(v *viper.Viper) subnets.Config {
 	return subnets.Config{
 		ConsensusParameters:         getConsensusConfig(v),
+		QuerySampling:               sampling.Config{Strategy: v.GetString(SnowQuerySamplingStrategyKey)},
 		ValidatorOnly:               false,
 		GossipConfig:                getGossipConfig(v),
 		ProposerMinBlockDelay:       proposervm.DefaultMinBlockDelay,
@@ -1444,7 +1512,10 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 	nodeConfig.FdLimit = v.GetUint64(FdLimitKey)
 
 	// Tx Fee
-	nodeConfig.TxFeeConfig = getTxFeeConfig(v, nodeConfig.NetworkID)
+	nodeConfig.TxFeeConfig, err = getTxFeeConfig(v, nodeConfig.NetworkID)
+	if err != nil {
+		return node.Config{}, err
+	}
 
 	// Genesis Data
 	genesisStakingCfg := nodeConfig.StakingConfig.StakingConfig
@@ -18,6 +18,7 @@ import (
 	"github.com/luxdefi/node/database/pebble"
 	"github.com/luxdefi/node/genesis"
 	"github.com/luxdefi/node/snow/consensus/snowball"
+	"github.com/luxdefi/node/snow/validators/sampling"
 	"github.com/luxdefi/node/trace"
 	"github.com/luxdefi/node/utils/compression"
 	"github.com/luxdefi/node/utils/constants"
@@ -160,6 +161,12 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Bool(NetworkRequireValidatorToConnectKey, constants.DefaultNetworkRequireValidatorToConnect, "If true, this node will only maintain a connection with another node if this node is a validator, the other node is a validator, or the other node is a beacon")
 	fs.Uint(NetworkPeerReadBufferSizeKey, constants.DefaultNetworkPeerReadBufferSize, "Size, in bytes, of the buffer that we read peer messages into (there is one buffer per peer)")
 	fs.Uint(NetworkPeerWriteBufferSizeKey, constants.DefaultNetworkPeerWriteBufferSize, "Size, in bytes, of the buffer that we write peer messages into (there is one buffer per peer)")
+	fs.Uint64(NetworkSendQueueConsensusMaxBytesKey, 0, "Maximum number of bytes of consensus messages that can be queued to be sent to a peer. If 0, only the outbound message throttler limits the queue")
+	fs.Bool(NetworkSendQueueConsensusDropOldestKey, false, fmt.Sprintf("If true, the oldest queued consensus messages are dropped once %s is reached. Otherwise, new consensus messages are dropped", NetworkSendQueueConsensusMaxBytesKey))
+	fs.Uint64(NetworkSendQueueBootstrapMaxBytesKey, 0, "Maximum number of bytes of bootstrapping messages that can be queued to be sent to a peer. If 0, only the outbound message throttler limits the queue")
+	fs.Bool(NetworkSendQueueBootstrapDropOldestKey, false, fmt.Sprintf("If true, the oldest queued bootstrapping messages are dropped once %s is reached. Otherwise, new bootstrapping messages are dropped", NetworkSendQueueBootstrapMaxBytesKey))
+	fs.Uint64(NetworkSendQueueAppMaxBytesKey, 8*units.MiB, "Maximum number of bytes of app messages that can be queued to be sent to a peer. If 0, only the outbound message throttler limits the queue")
+	fs.Bool(NetworkSendQueueAppDropOldestKey, true, fmt.Sprintf("If true, the oldest queued app messages are dropped once %s is reached. Otherwise, new app messages are dropped", NetworkSendQueueAppMaxBytesKey))
 
 	fs.Bool(NetworkTCPProxyEnabledKey, constants.DefaultNetworkTCPProxyEnabled, "Require all P2P connections to be initiated with a TCP proxy header")
 	// The PROXY protocol specification recommends setting this value to be at
@@ -168,8 +175,12 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	// Specifying a timeout of 0 will actually result in a timeout of 200ms, but
 	// a timeout of 0 should generally not be provided.
 	fs.Duration(NetworkTCPProxyReadTimeoutKey, constants.DefaultNetworkTCPProxyReadTimeout, "Maximum duration to wait for a TCP proxy header")
+	fs.Bool(NetworkQUICEnabledKey, false, "If true, this node will accept QUIC connections on the UDP staking port and will attempt to connect to peers over QUIC before falling back to TCP")
+	fs.Duration(NetworkQUICDialTimeoutKey, 3*time.Second, "Timeout for establishing a QUIC connection before falling back to TCP")
 
 	fs.String(NetworkTLSKeyLogFileKey, "", "TLS key log file path. Should only be specified for debugging")
+	fs.String(NetworkAllowListFileKey, "", fmt.Sprintf("Path to a JSON array of node IDs that this node is allowed to connect to. If this or %s is set, connections with all other nodes, except for the bootstrappers, are rejected during the TLS handshake. The file can be reloaded through the admin API", NetworkAllowListSubnetsKey))
+	fs.String(NetworkAllowListSubnetsKey, "", fmt.Sprintf("Comma separated list of subnet IDs whose validators this node is allowed to connect to. If this or %s is set, connections with all other nodes, except for the bootstrappers, are rejected during the TLS handshake", NetworkAllowListFileKey))
 
 	// Benchlist
 	fs.Int(BenchlistFailThresholdKey, constants.DefaultBenchlistFailThreshold, "Number of consecutive failed queries before benchlisting a node")
@@ -292,6 +303,8 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	// TODO: combine "BootstrapIPsKey" and "BootstrapIDsKey" into one flag
 	fs.String(BootstrapIPsKey, "", "Comma separated list of bootstrap peer ips to connect to. Example: 127.0.0.1:9630,127.0.0.1:9631")
 	fs.String(BootstrapIDsKey, "", "Comma separated list of bootstrap peer ids to connect to. Example: NodeID-JR4dVmy6ffUGAKCBDkyCbeZbyHQBeDsET,NodeID-8CrVPQZ4VSqgL8zTdvL14G8HqAfrBr4z")
+	fs.StringSlice(DNSSeedsKey, nil, "Comma separated list of DNS seed hostnames. Each TXT record of a seed should advertise a peer to connect to. Example: NodeID-JR4dVmy6ffUGAKCBDkyCbeZbyHQBeDsET@127.0.0.1:9630")
+	fs.Duration(DNSSeedTimeoutKey, 10*time.Second, "Timeout for resolving the DNS seeds")
 	fs.Duration(BootstrapBeaconConnectionTimeoutKey, time.Minute, "Timeout before emitting a warn log when connecting to bootstrapping beacons")
 	fs.Duration(BootstrapMaxTimeGetAncestorsKey, 50*time.Millisecond, "Max Time to spend fetching a container and its ancestors when responding to a GetAncestors")
 	fs.Uint(BootstrapAncestorsMaxContainersSentKey, 2000, "Max number of containers in an Ancestors message sent by this node")
@@ -310,6 +323,8 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Int(SnowOptimalProcessingKey, snowball.DefaultParameters.OptimalProcessing, "Optimal number of processing containers in consensus")
 	fs.Int(SnowMaxProcessingKey, snowball.DefaultParameters.MaxOutstandingItems, "Maximum number of processing items to be considered healthy")
 	fs.Duration(SnowMaxTimeProcessingKey, snowball.DefaultParameters.MaxItemProcessingTime, "Maximum amount of time an item should be processing and still be healthy")
+	fs.Bool(SnowStakeWeightedPollsKey, snowball.DefaultParameters.StakeWeightedPolls, "If true, network polls are decided by the stake of the responding validators rather than the number of responses")
+	fs.String(SnowQuerySamplingStrategyKey, sampling.StakeStrategy, fmt.Sprintf("Strategy used to sample the validators that network polls are sent to. One of %q, %q, %q, or %q", sampling.StakeStrategy, sampling.LatencyStrategy, sampling.BenchlistStrategy, sampling.StakeCappedStrategy))
 
 	// ProposerVM
 	fs.Bool(ProposerVMUseCurrentHeightKey, false, "Have the ProposerVM always report the last accepted P-chain block height")
@@ -351,6 +366,9 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Duration(NetworkInitialReconnectDelayKey, constants.DefaultNetworkInitialReconnectDelay, "Initial delay duration must be waited before attempting to reconnect a peer")
 	fs.Duration(NetworkMaxReconnectDelayKey, constants.DefaultNetworkMaxReconnectDelay, "Maximum delay duration must be waited before attempting to reconnect a peer")
 
+	// Address book
+	fs.Duration(NetworkAddressBookMaxAgeKey, constants.DefaultNetworkAddressBookMaxAge, "Duration a peer's address is remembered across restarts after the peer was last seen")
+
 	// System resource trackers
 	fs.Duration(SystemTrackerFrequencyKey, 500*time.Millisecond, "Frequency to check the real system usage of tracked processes. More frequent checks --> usage metrics are more accurate, but more expensive to track")
 	fs.Duration(SystemTrackerProcessingHalflifeKey, 15*time.Second, "Halflife to use for the processing requests tracker. Larger halflife --> usage metrics change more slowly")
@@ -63,6 +63,8 @@ const (
 	StateSyncIDsKey                                    = "state-sync-ids"
 	BootstrapIPsKey                                    = "bootstrap-ips"
 	BootstrapIDsKey                                    = "bootstrap-ids"
+	DNSSeedsKey                                        = "dns-seeds"
+	DNSSeedTimeoutKey                                  = "dns-seed-timeout"
 	StakingHostKey                                     = "staking-host"
 	StakingPortKey                                     = "staking-port"
 	StakingEphemeralCertEnabledKey                     = "staking-ephemeral-cert-enabled"
@@ -97,15 +99,26 @@ const (
 	NetworkPingTimeoutKey                              = "network-ping-timeout"
 	NetworkPingFrequencyKey                            = "network-ping-frequency"
 	NetworkMaxReconnectDelayKey                        = "network-max-reconnect-delay"
+	NetworkAddressBookMaxAgeKey                        = "network-address-book-max-age"
 	NetworkCompressionTypeKey                          = "network-compression-type"
 	NetworkMaxClockDifferenceKey                       = "network-max-clock-difference"
 	NetworkAllowPrivateIPsKey                          = "network-allow-private-ips"
 	NetworkRequireValidatorToConnectKey                = "network-require-validator-to-connect"
 	NetworkPeerReadBufferSizeKey                       = "network-peer-read-buffer-size"
 	NetworkPeerWriteBufferSizeKey                      = "network-peer-write-buffer-size"
+	NetworkSendQueueConsensusMaxBytesKey               = "network-send-queue-consensus-max-bytes"
+	NetworkSendQueueConsensusDropOldestKey             = "network-send-queue-consensus-drop-oldest"
+	NetworkSendQueueBootstrapMaxBytesKey               = "network-send-queue-bootstrap-max-bytes"
+	NetworkSendQueueBootstrapDropOldestKey             = "network-send-queue-bootstrap-drop-oldest"
+	NetworkSendQueueAppMaxBytesKey                     = "network-send-queue-app-max-bytes"
+	NetworkSendQueueAppDropOldestKey                   = "network-send-queue-app-drop-oldest"
 	NetworkTCPProxyEnabledKey                          = "network-tcp-proxy-enabled"
 	NetworkTCPProxyReadTimeoutKey                      = "network-tcp-proxy-read-timeout"
+	NetworkQUICEnabledKey                              = "network-quic-enabled"
+	NetworkQUICDialTimeoutKey                          = "network-quic-dial-timeout"
 	NetworkTLSKeyLogFileKey                            = "network-tls-key-log-file-unsafe"
+	NetworkAllowListFileKey                            = "network-allow-list-file"
+	NetworkAllowListSubnetsKey                         = "network-allow-list-subnets"
 	NetworkInboundConnUpgradeThrottlerCooldownKey      = "network-inbound-connection-throttling-cooldown"
 	NetworkInboundThrottlerMaxConnsPerSecKey           = "network-inbound-connection-throttling-max-conns-per-sec"
 	NetworkOutboundConnectionThrottlingRpsKey          = "network-outbound-connection-throttling-rps"
@@ -132,6 +145,8 @@ const (
 	SnowOptimalProcessingKey                           = "snow-optimal-processing"
 	SnowMaxProcessingKey                               = "snow-max-processing"
 	SnowMaxTimeProcessingKey                           = "snow-max-time-processing"
+	SnowStakeWeightedPollsKey                          = "snow-stake-weighted-polls"
+	SnowQuerySamplingStrategyKey                       = "snow-query-sampling-strategy"
 	PartialSyncPrimaryNetworkKey                       = "partial-sync-primary-network"
 	TrackSubnetsKey                                    = "track-subnets"
 	AdminAPIEnabledKey                                 = "api-admin-enabled"
@@ -10,6 +10,7 @@ import (
 
 	"github.com/luxdefi/node/utils/units"
 	"github.com/luxdefi/node/vms/platformvm/reward"
+	"github.com/luxdefi/node/vms/platformvm/txs/fee"
 )
 
 var (
@@ -28,6 +29,18 @@ var (
 			AddPrimaryNetworkDelegatorFee: 0,
 			AddSubnetValidatorFee:         units.MilliLux,
 			AddSubnetDelegatorFee:         units.MilliLux,
+			DynamicFeeConfig: &fee.DynamicConfig{
+				Weights: fee.Dimensions{
+					Bandwidth:    1,
+					Signatures:   1_000,
+					UTXOsRead:    1_000,
+					UTXOsWritten: 1_000,
+				},
+				MinGasPrice:               25,
+				MaxGasPrice:               units.MilliLux,
+				TargetGasPerBlock:         250_000,
+				GasPriceChangeDenominator: 8,
+			},
 		},
 		StakingConfig: StakingConfig{
 			UptimeRequirement: .8, // 80%
@@ -8,6 +8,7 @@ import (
 
 	"github.com/luxdefi/node/utils/constants"
 	"github.com/luxdefi/node/vms/platformvm/reward"
+	"github.com/luxdefi/node/vms/platformvm/txs/fee"
 )
 
 type StakingConfig struct {
@@ -52,6 +53,9 @@ type TxFeeConfig struct {
 	AddSubnetValidatorFee uint64 `json:"addSubnetValidatorFee"`
 	// Transaction fee for adding a subnet delegator
 	AddSubnetDelegatorFee uint64 `json:"addSubnetDelegatorFee"`
+	// Gas based fee model enforced after the dynamic fees upgrade. If nil,
+	// only the static fees are charged.
+	DynamicFeeConfig *fee.DynamicConfig `json:"dynamicFeeConfig"`
 }
 
 type Params struct {
@@ -0,0 +1,41 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package genesis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxdefi/node/utils/constants"
+)
+
+func TestTxFeeConfigDynamicFeeConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		networkID uint32
+	}{
+		{
+			name:      "mainnet",
+			networkID: constants.MainnetID,
+		},
+		{
+			name:      "testnet",
+			networkID: constants.TestnetID,
+		},
+		{
+			name:      "local",
+			networkID: constants.LocalID,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			config := GetTxFeeConfig(test.networkID)
+			require.NotNil(config.DynamicFeeConfig)
+			require.NoError(config.DynamicFeeConfig.Verify())
+		})
+	}
+}
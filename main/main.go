@@ -18,6 +18,14 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == rollbackCommand {
+		if err := runRollback(os.Args[2:]); err != nil {
+			fmt.Printf("couldn't schedule rollback: %s\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	fs := config.BuildFlagSet()
 	v, err := config.BuildViper(fs, os.Args[1:])
 
@@ -0,0 +1,114 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/spf13/pflag"
+
+	"github.com/luxdefi/node/chains"
+	"github.com/luxdefi/node/config"
+	"github.com/luxdefi/node/database"
+	"github.com/luxdefi/node/genesis"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/node"
+	"github.com/luxdefi/node/utils/logging"
+)
+
+const (
+	// rollbackCommand is the subcommand that schedules a chain to be rolled
+	// back the next time the node is started. The node must not be running.
+	rollbackCommand = "rollback"
+
+	rollbackChainKey  = "rollback-chain"
+	rollbackHeightKey = "rollback-height"
+	rollbackCancelKey = "rollback-cancel"
+)
+
+var errNoRollbackChain = errors.New("--" + rollbackChainKey + " must be provided")
+
+// runRollback schedules, or cancels, the rollback of a chain in the database
+// of a stopped node. The database is located using the regular node flags.
+func runRollback(args []string) error {
+	fs := config.BuildFlagSet()
+	fs.String(rollbackChainKey, "", "ID or alias of the chain to roll back")
+	fs.Uint64(rollbackHeightKey, 0, "Height of the accepted block to roll the chain back to")
+	fs.Bool(rollbackCancelKey, false, "If true, the rollback scheduled for the chain is removed")
+
+	v, err := config.BuildViper(fs, args)
+	if errors.Is(err, pflag.ErrHelp) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't configure flags: %w", err)
+	}
+
+	chain := v.GetString(rollbackChainKey)
+	if len(chain) == 0 {
+		return errNoRollbackChain
+	}
+
+	nodeConfig, err := config.GetNodeConfig(v)
+	if err != nil {
+		return fmt.Errorf("couldn't load node config: %w", err)
+	}
+
+	chainID, err := lookupChain(nodeConfig.GenesisBytes, chain)
+	if err != nil {
+		return err
+	}
+
+	db, err := node.NewDatabase(nodeConfig.DatabaseConfig, logging.NoLog{}, prometheus.NewRegistry())
+	if err != nil {
+		return fmt.Errorf("couldn't open database: %w", err)
+	}
+
+	if err := updateRollback(db, chainID, v.GetBool(rollbackCancelKey), v.GetUint64(rollbackHeightKey)); err != nil {
+		_ = db.Close()
+		return err
+	}
+	return db.Close()
+}
+
+func updateRollback(db database.Database, chainID ids.ID, cancel bool, height uint64) error {
+	if cancel {
+		if err := chains.CancelRollback(db, chainID); err != nil {
+			return err
+		}
+		fmt.Printf("cancelled the rollback of chain %s\n", chainID)
+		return nil
+	}
+
+	if err := chains.ScheduleRollback(db, chainID, height); err != nil {
+		return err
+	}
+	fmt.Printf("chain %s will be rolled back to height %d the next time the node is started\n", chainID, height)
+	return nil
+}
+
+// lookupChain returns the ID of [chain], which may be an alias of a chain
+// created in the genesis.
+func lookupChain(genesisBytes []byte, chain string) (ids.ID, error) {
+	_, chainAliases, err := genesis.Aliases(genesisBytes)
+	if err != nil {
+		return ids.Empty, err
+	}
+
+	aliaser := ids.NewAliaser()
+	for chainID, aliases := range chainAliases {
+		for _, alias := range aliases {
+			if err := aliaser.Alias(chainID, alias); err != nil {
+				return ids.Empty, err
+			}
+		}
+	}
+	if chainID, err := aliaser.Lookup(chain); err == nil {
+		return chainID, nil
+	}
+	return ids.FromString(chain)
+}
@@ -24,6 +24,7 @@ var (
 	_ chainIDGetter = (*p2p.GetAccepted)(nil)
 	_ chainIDGetter = (*p2p.Accepted)(nil)
 	_ chainIDGetter = (*p2p.GetAncestors)(nil)
+	_ chainIDGetter = (*p2p.GetAncestorsAtHeight)(nil)
 	_ chainIDGetter = (*p2p.Ancestors)(nil)
 	_ chainIDGetter = (*p2p.Get)(nil)
 	_ chainIDGetter = (*p2p.Put)(nil)
@@ -43,6 +44,7 @@ var (
 	_ requestIDGetter = (*p2p.GetAccepted)(nil)
 	_ requestIDGetter = (*p2p.Accepted)(nil)
 	_ requestIDGetter = (*p2p.GetAncestors)(nil)
+	_ requestIDGetter = (*p2p.GetAncestorsAtHeight)(nil)
 	_ requestIDGetter = (*p2p.Ancestors)(nil)
 	_ requestIDGetter = (*p2p.Get)(nil)
 	_ requestIDGetter = (*p2p.Put)(nil)
@@ -55,6 +57,7 @@ var (
 	_ engineTypeGetter = (*p2p.GetAcceptedFrontier)(nil)
 	_ engineTypeGetter = (*p2p.GetAccepted)(nil)
 	_ engineTypeGetter = (*p2p.GetAncestors)(nil)
+	_ engineTypeGetter = (*p2p.GetAncestorsAtHeight)(nil)
 	_ engineTypeGetter = (*p2p.Get)(nil)
 	_ engineTypeGetter = (*p2p.Put)(nil)
 	_ engineTypeGetter = (*p2p.PushQuery)(nil)
@@ -65,6 +68,7 @@ var (
 	_ deadlineGetter = (*p2p.GetAcceptedFrontier)(nil)
 	_ deadlineGetter = (*p2p.GetAccepted)(nil)
 	_ deadlineGetter = (*p2p.GetAncestors)(nil)
+	_ deadlineGetter = (*p2p.GetAncestorsAtHeight)(nil)
 	_ deadlineGetter = (*p2p.Get)(nil)
 	_ deadlineGetter = (*p2p.PushQuery)(nil)
 	_ deadlineGetter = (*p2p.PullQuery)(nil)
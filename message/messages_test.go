@@ -475,6 +475,24 @@ func TestMessage(t *testing.T) {
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
+		{
+			desc: "get_ancestors_at_height message with no compression",
+			op:   GetAncestorsAtHeightOp,
+			msg: &p2p.Message{
+				Message: &p2p.Message_GetAncestorsAtHeight{
+					GetAncestorsAtHeight: &p2p.GetAncestorsAtHeight{
+						ChainId:    testID[:],
+						RequestId:  1,
+						Deadline:   1,
+						Height:     100,
+						EngineType: p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+					},
+				},
+			},
+			compressionType:  compression.TypeNone,
+			bypassThrottling: true,
+			bytesSaved:       false,
+		},
 		{
 			desc: "ancestors message with no compression",
 			op:   AncestorsOp,
@@ -235,6 +235,21 @@ func (mr *MockOutboundMsgBuilderMockRecorder) GetAncestors(arg0, arg1, arg2, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAncestors", reflect.TypeOf((*MockOutboundMsgBuilder)(nil).GetAncestors), arg0, arg1, arg2, arg3, arg4)
 }
 
+// GetAncestorsAtHeight mocks base method.
+func (m *MockOutboundMsgBuilder) GetAncestorsAtHeight(arg0 ids.ID, arg1 uint32, arg2 time.Duration, arg3 uint64, arg4 p2p.EngineType) (OutboundMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAncestorsAtHeight", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(OutboundMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAncestorsAtHeight indicates an expected call of GetAncestorsAtHeight.
+func (mr *MockOutboundMsgBuilderMockRecorder) GetAncestorsAtHeight(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAncestorsAtHeight", reflect.TypeOf((*MockOutboundMsgBuilder)(nil).GetAncestorsAtHeight), arg0, arg1, arg2, arg3, arg4)
+}
+
 // GetStateSummaryFrontier mocks base method.
 func (m *MockOutboundMsgBuilder) GetStateSummaryFrontier(arg0 ids.ID, arg1 uint32, arg2 time.Duration) (OutboundMessage, error) {
 	m.ctrl.T.Helper()
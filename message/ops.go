@@ -39,6 +39,7 @@ const (
 	GetAcceptedFailedOp
 	AcceptedOp
 	GetAncestorsOp
+	GetAncestorsAtHeightOp
 	GetAncestorsFailedOp
 	AncestorsOp
 	// Consensus:
@@ -83,6 +84,7 @@ var (
 		GetAcceptedFrontierOp,
 		GetAcceptedOp,
 		GetAncestorsOp,
+		GetAncestorsAtHeightOp,
 		GetOp,
 		PushQueryOp,
 		PullQueryOp,
@@ -146,6 +148,7 @@ var (
 		GetAcceptedFailedOp,
 		AcceptedOp,
 		GetAncestorsOp,
+		GetAncestorsAtHeightOp,
 		GetAncestorsFailedOp,
 		AncestorsOp,
 		// Consensus
@@ -189,6 +192,7 @@ var (
 		GetAcceptedFrontierOp,
 		GetAcceptedOp,
 		GetAncestorsOp,
+		GetAncestorsAtHeightOp,
 		GetOp,
 		PushQueryOp,
 		PullQueryOp,
@@ -243,6 +247,8 @@ func (op Op) String() string {
 		return "accepted"
 	case GetAncestorsOp:
 		return "get_ancestors"
+	case GetAncestorsAtHeightOp:
+		return "get_ancestors_at_height"
 	case GetAncestorsFailedOp:
 		return "get_ancestors_failed"
 	case AncestorsOp:
@@ -329,6 +335,8 @@ func Unwrap(m *p2p.Message) (fmt.Stringer, error) {
 		return msg.Accepted_, nil
 	case *p2p.Message_GetAncestors:
 		return msg.GetAncestors, nil
+	case *p2p.Message_GetAncestorsAtHeight:
+		return msg.GetAncestorsAtHeight, nil
 	case *p2p.Message_Ancestors_:
 		return msg.Ancestors_, nil
 	// Consensus:
@@ -384,6 +392,8 @@ func ToOp(m *p2p.Message) (Op, error) {
 		return AcceptedOp, nil
 	case *p2p.Message_GetAncestors:
 		return GetAncestorsOp, nil
+	case *p2p.Message_GetAncestorsAtHeight:
+		return GetAncestorsAtHeightOp, nil
 	case *p2p.Message_Ancestors_:
 		return AncestorsOp, nil
 	case *p2p.Message_Get:
@@ -107,6 +107,14 @@ type OutboundMsgBuilder interface {
 		engineType p2p.EngineType,
 	) (OutboundMessage, error)
 
+	GetAncestorsAtHeight(
+		chainID ids.ID,
+		requestID uint32,
+		deadline time.Duration,
+		height uint64,
+		engineType p2p.EngineType,
+	) (OutboundMessage, error)
+
 	Ancestors(
 		chainID ids.ID,
 		requestID uint32,
@@ -492,6 +500,30 @@ func (b *outMsgBuilder) GetAncestors(
 	)
 }
 
+func (b *outMsgBuilder) GetAncestorsAtHeight(
+	chainID ids.ID,
+	requestID uint32,
+	deadline time.Duration,
+	height uint64,
+	engineType p2p.EngineType,
+) (OutboundMessage, error) {
+	return b.builder.createOutbound(
+		&p2p.Message{
+			Message: &p2p.Message_GetAncestorsAtHeight{
+				GetAncestorsAtHeight: &p2p.GetAncestorsAtHeight{
+					ChainId:    chainID[:],
+					RequestId:  requestID,
+					Deadline:   uint64(deadline),
+					Height:     height,
+					EngineType: engineType,
+				},
+			},
+		},
+		compression.TypeNone,
+		false,
+	)
+}
+
 func (b *outMsgBuilder) Ancestors(
 	chainID ids.ID,
 	requestID uint32,
@@ -0,0 +1,314 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package addrbook
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"golang.org/x/exp/maps"
+
+	"github.com/luxdefi/node/database"
+	"github.com/luxdefi/node/database/prefixdb"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/network/peer"
+	"github.com/luxdefi/node/staking"
+	"github.com/luxdefi/node/utils/ips"
+	"github.com/luxdefi/node/utils/logging"
+	"github.com/luxdefi/node/utils/timer/mockable"
+)
+
+var (
+	_ Book = (*book)(nil)
+
+	ipPrefix  = []byte("ip")
+	pinPrefix = []byte("pin")
+)
+
+// Book is a persisted record of the most recent signed IP of each peer. It
+// allows a restarting node to reconnect to the peers it previously knew
+// about without relying on bootstrappers. It also records the peers that the
+// operator pinned, which should always be connected to.
+type Book interface {
+	// Put records [ip] as the address of the peer that signed it and marks
+	// the peer as seen now. If a more recent IP is already known for the
+	// peer, this is a noop.
+	//
+	// Invariant: [ip]'s signature must have been verified by the caller.
+	Put(ip *ips.ClaimedIPPort) error
+
+	// Remove forgets the address of [nodeID], if one is known.
+	Remove(nodeID ids.NodeID) error
+
+	// Prune forgets the addresses of the peers that haven't been seen for
+	// more than [maxAge].
+	Prune(maxAge time.Duration) error
+
+	// Pin records that [nodeID], reachable at [ip], should always be
+	// connected to. Pinning an already pinned peer replaces its IP. Pinned
+	// peers are never pruned.
+	Pin(nodeID ids.NodeID, ip ips.IPPort) error
+
+	// Unpin removes the pin of [nodeID], if it is pinned.
+	Unpin(nodeID ids.NodeID) error
+
+	// Pinned returns the IP of every pinned peer.
+	Pinned() map[ids.NodeID]ips.IPPort
+
+	// IPs returns the most recent signed IP of every known peer.
+	IPs() []*ips.ClaimedIPPort
+}
+
+type entry struct {
+	Cert      []byte `serialize:"true"`
+	IP        []byte `serialize:"true"`
+	Port      uint16 `serialize:"true"`
+	Timestamp uint64 `serialize:"true"`
+	Signature []byte `serialize:"true"`
+	// Unix time, in seconds, at which the peer was last seen
+	LastSeen uint64 `serialize:"true"`
+}
+
+type pinEntry struct {
+	IP   []byte `serialize:"true"`
+	Port uint16 `serialize:"true"`
+}
+
+type book struct {
+	clock mockable.Clock
+
+	lock     sync.RWMutex
+	ipDB     database.Database
+	pinDB    database.Database
+	ips      map[ids.NodeID]*ips.ClaimedIPPort
+	lastSeen map[ids.NodeID]uint64
+	pinned   map[ids.NodeID]ips.IPPort
+}
+
+// New returns a Book that persists addresses into [db]. Any addresses
+// previously written into [db] are loaded. Entries that can no longer be
+// parsed or whose signatures are no longer valid are dropped.
+func New(log logging.Logger, db database.Database) (Book, error) {
+	b := &book{
+		ipDB:     prefixdb.New(ipPrefix, db),
+		pinDB:    prefixdb.New(pinPrefix, db),
+		ips:      make(map[ids.NodeID]*ips.ClaimedIPPort),
+		lastSeen: make(map[ids.NodeID]uint64),
+		pinned:   make(map[ids.NodeID]ips.IPPort),
+	}
+	if err := b.loadIPs(log); err != nil {
+		return nil, fmt.Errorf("failed to load peer IPs: %w", err)
+	}
+	if err := b.loadPins(); err != nil {
+		return nil, fmt.Errorf("failed to load pinned peers: %w", err)
+	}
+	return b, nil
+}
+
+func (b *book) loadIPs(log logging.Logger) error {
+	it := b.ipDB.NewIterator()
+	defer it.Release()
+
+	var invalid [][]byte
+	for it.Next() {
+		key := it.Key()
+		ip, lastSeen, err := parseEntry(it.Value())
+		if err != nil {
+			log.Debug("dropping invalid peer address",
+				zap.Binary("key", key),
+				zap.Error(err),
+			)
+			invalid = append(invalid, key)
+			continue
+		}
+		nodeID := ids.NodeIDFromCert(ip.Cert)
+		b.ips[nodeID] = ip
+		b.lastSeen[nodeID] = lastSeen
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	for _, key := range invalid {
+		if err := b.ipDB.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *book) loadPins() error {
+	it := b.pinDB.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		nodeID, err := ids.ToNodeID(it.Key())
+		if err != nil {
+			return err
+		}
+		var e pinEntry
+		if _, err := codecManager.Unmarshal(it.Value(), &e); err != nil {
+			return err
+		}
+		b.pinned[nodeID] = ips.IPPort{
+			IP:   net.IP(e.IP),
+			Port: e.Port,
+		}
+	}
+	return it.Error()
+}
+
+func (b *book) Put(ip *ips.ClaimedIPPort) error {
+	nodeID := ids.NodeIDFromCert(ip.Cert)
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if prevIP, ok := b.ips[nodeID]; ok && prevIP.Timestamp > ip.Timestamp {
+		return nil
+	}
+
+	lastSeen := b.clock.Unix()
+	bytes, err := codecManager.Marshal(codecVersion, &entry{
+		Cert:      ip.Cert.Raw,
+		IP:        ip.IPPort.IP.To16(),
+		Port:      ip.IPPort.Port,
+		Timestamp: ip.Timestamp,
+		Signature: ip.Signature,
+		LastSeen:  lastSeen,
+	})
+	if err != nil {
+		return err
+	}
+	if err := b.ipDB.Put(nodeID.Bytes(), bytes); err != nil {
+		return err
+	}
+
+	b.ips[nodeID] = &ips.ClaimedIPPort{
+		Cert:      ip.Cert,
+		IPPort:    ip.IPPort,
+		Timestamp: ip.Timestamp,
+		Signature: ip.Signature,
+	}
+	b.lastSeen[nodeID] = lastSeen
+	return nil
+}
+
+func (b *book) Remove(nodeID ids.NodeID) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if _, ok := b.ips[nodeID]; !ok {
+		return nil
+	}
+
+	delete(b.ips, nodeID)
+	delete(b.lastSeen, nodeID)
+	return b.ipDB.Delete(nodeID.Bytes())
+}
+
+func (b *book) Prune(maxAge time.Duration) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	minLastSeen := b.clock.Time().Add(-maxAge)
+	for nodeID, lastSeen := range b.lastSeen {
+		if !time.Unix(int64(lastSeen), 0).Before(minLastSeen) {
+			continue
+		}
+
+		delete(b.ips, nodeID)
+		delete(b.lastSeen, nodeID)
+		if err := b.ipDB.Delete(nodeID.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *book) Pin(nodeID ids.NodeID, ip ips.IPPort) error {
+	bytes, err := codecManager.Marshal(codecVersion, &pinEntry{
+		IP:   ip.IP.To16(),
+		Port: ip.Port,
+	})
+	if err != nil {
+		return err
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if err := b.pinDB.Put(nodeID.Bytes(), bytes); err != nil {
+		return err
+	}
+	b.pinned[nodeID] = ip
+	return nil
+}
+
+func (b *book) Unpin(nodeID ids.NodeID) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if _, ok := b.pinned[nodeID]; !ok {
+		return nil
+	}
+
+	delete(b.pinned, nodeID)
+	return b.pinDB.Delete(nodeID.Bytes())
+}
+
+func (b *book) Pinned() map[ids.NodeID]ips.IPPort {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	return maps.Clone(b.pinned)
+}
+
+func (b *book) IPs() []*ips.ClaimedIPPort {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	ips := make([]*ips.ClaimedIPPort, 0, len(b.ips))
+	for _, ip := range b.ips {
+		ips = append(ips, ip)
+	}
+	return ips
+}
+
+func parseEntry(bytes []byte) (*ips.ClaimedIPPort, uint64, error) {
+	var e entry
+	if _, err := codecManager.Unmarshal(bytes, &e); err != nil {
+		return nil, 0, err
+	}
+
+	cert, err := staking.ParseCertificate(e.Cert)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	signedIP := peer.SignedIP{
+		UnsignedIP: peer.UnsignedIP{
+			IPPort: ips.IPPort{
+				IP:   net.IP(e.IP),
+				Port: e.Port,
+			},
+			Timestamp: e.Timestamp,
+		},
+		Signature: e.Signature,
+	}
+	if err := signedIP.Verify(cert); err != nil {
+		return nil, 0, err
+	}
+
+	return &ips.ClaimedIPPort{
+		Cert:      cert,
+		IPPort:    signedIP.IPPort,
+		Timestamp: signedIP.Timestamp,
+		Signature: signedIP.Signature,
+	}, e.LastSeen, nil
+}
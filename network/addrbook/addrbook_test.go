@@ -0,0 +1,198 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package addrbook
+
+import (
+	"crypto"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxdefi/node/database"
+	"github.com/luxdefi/node/database/memdb"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/network/peer"
+	"github.com/luxdefi/node/staking"
+	"github.com/luxdefi/node/utils/ips"
+	"github.com/luxdefi/node/utils/logging"
+)
+
+func newSignedIP(t *testing.T, ip ips.IPPort, timestamp uint64) (*staking.Certificate, crypto.Signer, *ips.ClaimedIPPort) {
+	require := require.New(t)
+
+	tlsCert, err := staking.NewTLSCert()
+	require.NoError(err)
+
+	cert := staking.CertificateFromX509(tlsCert.Leaf)
+	signer := tlsCert.PrivateKey.(crypto.Signer)
+	return cert, signer, signIP(t, cert, signer, ip, timestamp)
+}
+
+func signIP(t *testing.T, cert *staking.Certificate, signer crypto.Signer, ip ips.IPPort, timestamp uint64) *ips.ClaimedIPPort {
+	unsignedIP := peer.UnsignedIP{
+		IPPort:    ip,
+		Timestamp: timestamp,
+	}
+	signedIP, err := unsignedIP.Sign(signer)
+	require.NoError(t, err)
+
+	return &ips.ClaimedIPPort{
+		Cert:      cert,
+		IPPort:    ip,
+		Timestamp: timestamp,
+		Signature: signedIP.Signature,
+	}
+}
+
+func TestBookPersistence(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	b, err := New(logging.NoLog{}, db)
+	require.NoError(err)
+	require.Empty(b.IPs())
+
+	ip1 := ips.IPPort{IP: net.IPv4(1, 2, 3, 4), Port: 9651}
+	cert, signer, claimedIP := newSignedIP(t, ip1, 1)
+	require.NoError(b.Put(claimedIP))
+
+	b, err = New(logging.NoLog{}, db)
+	require.NoError(err)
+
+	loadedIPs := b.IPs()
+	require.Len(loadedIPs, 1)
+	require.Equal(ids.NodeIDFromCert(cert), ids.NodeIDFromCert(loadedIPs[0].Cert))
+	require.True(ip1.Equal(loadedIPs[0].IPPort))
+	require.Equal(uint64(1), loadedIPs[0].Timestamp)
+
+	// Older IPs should be ignored
+	ip2 := ips.IPPort{IP: net.IPv4(5, 6, 7, 8), Port: 9651}
+	require.NoError(b.Put(signIP(t, cert, signer, ip2, 0)))
+	require.True(ip1.Equal(b.IPs()[0].IPPort))
+
+	// Newer IPs should replace the prior IP
+	require.NoError(b.Put(signIP(t, cert, signer, ip2, 2)))
+	require.True(ip2.Equal(b.IPs()[0].IPPort))
+
+	b, err = New(logging.NoLog{}, db)
+	require.NoError(err)
+	require.True(ip2.Equal(b.IPs()[0].IPPort))
+
+	require.NoError(b.Remove(ids.NodeIDFromCert(cert)))
+	require.Empty(b.IPs())
+
+	b, err = New(logging.NoLog{}, db)
+	require.NoError(err)
+	require.Empty(b.IPs())
+}
+
+func TestBookDropsInvalidSignatures(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	b, err := New(logging.NoLog{}, db)
+	require.NoError(err)
+
+	ip := ips.IPPort{IP: net.IPv4(1, 2, 3, 4), Port: 9651}
+	_, _, claimedIP := newSignedIP(t, ip, 1)
+	claimedIP.Signature = []byte{0x00}
+	require.NoError(b.Put(claimedIP))
+
+	b, err = New(logging.NoLog{}, db)
+	require.NoError(err)
+	require.Empty(b.IPs())
+
+	// The invalid entry should have been removed from the database
+	isEmpty, err := database.IsEmpty(db)
+	require.NoError(err)
+	require.True(isEmpty)
+}
+
+func TestBookPrune(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	b, err := New(logging.NoLog{}, db)
+	require.NoError(err)
+
+	now := time.Unix(1_000_000, 0)
+	book := b.(*book)
+	book.clock.Set(now)
+
+	ip := ips.IPPort{IP: net.IPv4(1, 2, 3, 4), Port: 9651}
+	staleCert, _, staleIP := newSignedIP(t, ip, 1)
+	require.NoError(b.Put(staleIP))
+
+	book.clock.Set(now.Add(time.Hour))
+	freshCert, freshSigner, freshIP := newSignedIP(t, ip, 1)
+	require.NoError(b.Put(freshIP))
+
+	// Seeing a peer again with the same signed IP refreshes it
+	book.clock.Set(now.Add(2 * time.Hour))
+	require.NoError(b.Put(signIP(t, freshCert, freshSigner, ip, 1)))
+
+	book.clock.Set(now.Add(3 * time.Hour))
+	require.NoError(b.Prune(90 * time.Minute))
+
+	persistedIPs := b.IPs()
+	require.Len(persistedIPs, 1)
+	require.Equal(ids.NodeIDFromCert(freshCert), ids.NodeIDFromCert(persistedIPs[0].Cert))
+
+	// The pruned entry should have been removed from the database
+	b, err = New(logging.NoLog{}, db)
+	require.NoError(err)
+	persistedIPs = b.IPs()
+	require.Len(persistedIPs, 1)
+	require.NotEqual(ids.NodeIDFromCert(staleCert), ids.NodeIDFromCert(persistedIPs[0].Cert))
+}
+
+func TestBookPins(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	b, err := New(logging.NoLog{}, db)
+	require.NoError(err)
+	require.Empty(b.Pinned())
+
+	nodeID := ids.GenerateTestNodeID()
+	ip1 := ips.IPPort{IP: net.IPv4(1, 2, 3, 4), Port: 9651}
+	require.NoError(b.Pin(nodeID, ip1))
+
+	// Pinning a peer again replaces its IP
+	ip2 := ips.IPPort{IP: net.IPv4(5, 6, 7, 8), Port: 9651}
+	require.NoError(b.Pin(nodeID, ip2))
+
+	// Pinned peers are never pruned
+	require.NoError(b.Prune(0))
+
+	b, err = New(logging.NoLog{}, db)
+	require.NoError(err)
+	pinned := b.Pinned()
+	require.Len(pinned, 1)
+	require.True(ip2.Equal(pinned[nodeID]))
+	require.Empty(b.IPs())
+}
+
+func TestBookUnpin(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	b, err := New(logging.NoLog{}, db)
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	require.NoError(b.Pin(nodeID, ips.IPPort{IP: net.IPv4(1, 2, 3, 4), Port: 9651}))
+	require.NoError(b.Unpin(nodeID))
+	require.Empty(b.Pinned())
+
+	// Unpinning a peer that isn't pinned is a noop
+	require.NoError(b.Unpin(nodeID))
+
+	// The removed pin isn't loaded again
+	b, err = New(logging.NoLog{}, db)
+	require.NoError(err)
+	require.Empty(b.Pinned())
+}
@@ -0,0 +1,22 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package addrbook
+
+import (
+	"github.com/luxdefi/node/codec"
+	"github.com/luxdefi/node/codec/linearcodec"
+)
+
+const codecVersion = 0
+
+// codecManager is used to marshal and unmarshal persisted peer addresses.
+var codecManager codec.Manager
+
+func init() {
+	linearCodec := linearcodec.NewDefault()
+	codecManager = codec.NewDefaultManager()
+	if err := codecManager.RegisterCodec(codecVersion, linearCodec); err != nil {
+		panic(err)
+	}
+}
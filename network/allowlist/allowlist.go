@@ -0,0 +1,157 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package allowlist restricts the peers a node is willing to connect to.
+//
+// Because a NodeID is derived from the peer's TLS certificate, allowing a
+// NodeID pins the certificate that the peer must present during the mutual TLS
+// handshake.
+package allowlist
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/snow/validators"
+	"github.com/luxdefi/node/utils/set"
+)
+
+var (
+	_ List     = Func(nil)
+	_ List     = (*Static)(nil)
+	_ List     = (*File)(nil)
+	_ List     = (*validatorList)(nil)
+	_ List     = (union)(nil)
+	_ Reloader = (*File)(nil)
+	_ Reloader = (union)(nil)
+)
+
+// List reports whether a peer is allowed to connect.
+type List interface {
+	// IsAllowed returns true if a connection with [nodeID] is permitted.
+	// Must be thread safe.
+	IsAllowed(nodeID ids.NodeID) bool
+}
+
+// Reloader is implemented by Lists whose contents are loaded from an external
+// source that may change while the node is running.
+type Reloader interface {
+	// Reload re-reads the List's source. If an error is returned, the prior
+	// contents of the List are unmodified.
+	Reload() error
+}
+
+// Func adapts a callback into a List.
+type Func func(nodeID ids.NodeID) bool
+
+func (f Func) IsAllowed(nodeID ids.NodeID) bool {
+	return f(nodeID)
+}
+
+// Static is a List of explicitly provided NodeIDs. Its contents can be
+// replaced at runtime.
+type Static struct {
+	lock    sync.RWMutex
+	nodeIDs set.Set[ids.NodeID]
+}
+
+func NewStatic(nodeIDs ...ids.NodeID) *Static {
+	return &Static{
+		nodeIDs: set.Of(nodeIDs...),
+	}
+}
+
+func (s *Static) IsAllowed(nodeID ids.NodeID) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.nodeIDs.Contains(nodeID)
+}
+
+// Replace atomically replaces the allowed NodeIDs with [nodeIDs].
+func (s *Static) Replace(nodeIDs set.Set[ids.NodeID]) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.nodeIDs = nodeIDs
+}
+
+// File is a List loaded from a file containing a JSON array of NodeIDs.
+type File struct {
+	Static
+
+	path string
+}
+
+// NewFile returns a List populated from the file at [path].
+func NewFile(path string) (*File, error) {
+	f := &File{path: path}
+	return f, f.Reload()
+}
+
+func (f *File) Reload() error {
+	bytes, err := os.ReadFile(f.path)
+	if err != nil {
+		return fmt.Errorf("couldn't read allow list %q: %w", f.path, err)
+	}
+
+	var nodeIDs []ids.NodeID
+	if err := json.Unmarshal(bytes, &nodeIDs); err != nil {
+		return fmt.Errorf("couldn't parse allow list %q: %w", f.path, err)
+	}
+
+	f.Replace(set.Of(nodeIDs...))
+	return nil
+}
+
+type validatorList struct {
+	vdrs     validators.Manager
+	subnetID ids.ID
+}
+
+// NewValidators returns a List that allows the current validators of
+// [subnetID]. The List follows changes to the validator set as they happen.
+func NewValidators(vdrs validators.Manager, subnetID ids.ID) List {
+	return &validatorList{
+		vdrs:     vdrs,
+		subnetID: subnetID,
+	}
+}
+
+func (v *validatorList) IsAllowed(nodeID ids.NodeID) bool {
+	_, ok := v.vdrs.GetValidator(v.subnetID, nodeID)
+	return ok
+}
+
+type union []List
+
+// NewUnion returns a List that allows a NodeID if any of [lists] allows it.
+// Reloading the returned List reloads every list that supports it.
+func NewUnion(lists ...List) List {
+	return union(lists)
+}
+
+func (u union) IsAllowed(nodeID ids.NodeID) bool {
+	for _, list := range u {
+		if list.IsAllowed(nodeID) {
+			return true
+		}
+	}
+	return false
+}
+
+func (u union) Reload() error {
+	for _, list := range u {
+		reloader, ok := list.(Reloader)
+		if !ok {
+			continue
+		}
+		if err := reloader.Reload(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
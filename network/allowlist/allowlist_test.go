@@ -0,0 +1,112 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package allowlist
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/snow/validators"
+	"github.com/luxdefi/node/utils/set"
+)
+
+func writeNodeIDs(t *testing.T, path string, nodeIDs ...ids.NodeID) {
+	bytes, err := json.Marshal(nodeIDs)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, bytes, 0o600))
+}
+
+func TestStatic(t *testing.T) {
+	require := require.New(t)
+
+	nodeID0 := ids.GenerateTestNodeID()
+	nodeID1 := ids.GenerateTestNodeID()
+
+	l := NewStatic(nodeID0)
+	require.True(l.IsAllowed(nodeID0))
+	require.False(l.IsAllowed(nodeID1))
+
+	l.Replace(set.Of(nodeID1))
+	require.False(l.IsAllowed(nodeID0))
+	require.True(l.IsAllowed(nodeID1))
+}
+
+func TestFileReload(t *testing.T) {
+	require := require.New(t)
+
+	nodeID0 := ids.GenerateTestNodeID()
+	nodeID1 := ids.GenerateTestNodeID()
+
+	path := filepath.Join(t.TempDir(), "allowlist.json")
+	writeNodeIDs(t, path, nodeID0)
+
+	l, err := NewFile(path)
+	require.NoError(err)
+	require.True(l.IsAllowed(nodeID0))
+	require.False(l.IsAllowed(nodeID1))
+
+	writeNodeIDs(t, path, nodeID1)
+	require.NoError(l.Reload())
+	require.False(l.IsAllowed(nodeID0))
+	require.True(l.IsAllowed(nodeID1))
+
+	// A failed reload should leave the prior contents in place
+	require.NoError(os.WriteFile(path, []byte("not json"), 0o600))
+	var syntaxErr *json.SyntaxError
+	require.ErrorAs(l.Reload(), &syntaxErr)
+	require.True(l.IsAllowed(nodeID1))
+
+	_, err = NewFile(filepath.Join(t.TempDir(), "missing.json"))
+	require.ErrorIs(err, os.ErrNotExist)
+}
+
+func TestValidators(t *testing.T) {
+	require := require.New(t)
+
+	subnetID := ids.GenerateTestID()
+	nodeID := ids.GenerateTestNodeID()
+	vdrs := validators.NewManager()
+
+	l := NewValidators(vdrs, subnetID)
+	require.False(l.IsAllowed(nodeID))
+
+	require.NoError(vdrs.AddStaker(subnetID, nodeID, nil, ids.Empty, 1))
+	require.True(l.IsAllowed(nodeID))
+
+	require.NoError(vdrs.RemoveWeight(subnetID, nodeID, 1))
+	require.False(l.IsAllowed(nodeID))
+}
+
+func TestUnion(t *testing.T) {
+	require := require.New(t)
+
+	nodeID0 := ids.GenerateTestNodeID()
+	nodeID1 := ids.GenerateTestNodeID()
+	nodeID2 := ids.GenerateTestNodeID()
+
+	path := filepath.Join(t.TempDir(), "allowlist.json")
+	writeNodeIDs(t, path)
+	file, err := NewFile(path)
+	require.NoError(err)
+
+	l := NewUnion(
+		NewStatic(nodeID0),
+		Func(func(nodeID ids.NodeID) bool {
+			return nodeID == nodeID1
+		}),
+		file,
+	)
+	require.True(l.IsAllowed(nodeID0))
+	require.True(l.IsAllowed(nodeID1))
+	require.False(l.IsAllowed(nodeID2))
+
+	writeNodeIDs(t, path, nodeID2)
+	require.NoError(l.(Reloader).Reload())
+	require.True(l.IsAllowed(nodeID2))
+}
@@ -0,0 +1,51 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package allowlist
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/network/peer"
+	"github.com/luxdefi/node/staking"
+)
+
+var (
+	_ peer.Upgrader = (*upgrader)(nil)
+
+	ErrNotAllowed = errors.New("node is not in the allow list")
+)
+
+type upgrader struct {
+	upgrader   peer.Upgrader
+	list       List
+	notAllowed prometheus.Counter
+}
+
+// NewUpgrader returns an Upgrader that performs the TLS handshake using
+// [u] and then closes the connection if the peer isn't allowed by [list].
+func NewUpgrader(u peer.Upgrader, list List, notAllowed prometheus.Counter) peer.Upgrader {
+	return &upgrader{
+		upgrader:   u,
+		list:       list,
+		notAllowed: notAllowed,
+	}
+}
+
+func (u *upgrader) Upgrade(conn net.Conn) (ids.NodeID, net.Conn, *staking.Certificate, error) {
+	nodeID, tlsConn, cert, err := u.upgrader.Upgrade(conn)
+	if err != nil {
+		return ids.EmptyNodeID, nil, nil, err
+	}
+	if !u.list.IsAllowed(nodeID) {
+		_ = tlsConn.Close()
+		u.notAllowed.Inc()
+		return ids.EmptyNodeID, nil, nil, fmt.Errorf("%w: %s", ErrNotAllowed, nodeID)
+	}
+	return nodeID, tlsConn, cert, nil
+}
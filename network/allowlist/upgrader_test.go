@@ -0,0 +1,46 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package allowlist
+
+import (
+	"net"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/staking"
+)
+
+type testUpgrader struct {
+	nodeID ids.NodeID
+}
+
+func (u *testUpgrader) Upgrade(conn net.Conn) (ids.NodeID, net.Conn, *staking.Certificate, error) {
+	return u.nodeID, conn, nil, nil
+}
+
+func TestUpgrader(t *testing.T) {
+	require := require.New(t)
+
+	allowedNodeID := ids.GenerateTestNodeID()
+	notAllowedNodeID := ids.GenerateTestNodeID()
+	notAllowed := prometheus.NewCounter(prometheus.CounterOpts{})
+	list := NewStatic(allowedNodeID)
+
+	conn, _ := net.Pipe()
+	u := NewUpgrader(&testUpgrader{nodeID: allowedNodeID}, list, notAllowed)
+	nodeID, _, _, err := u.Upgrade(conn)
+	require.NoError(err)
+	require.Equal(allowedNodeID, nodeID)
+	require.Zero(testutil.ToFloat64(notAllowed))
+
+	conn, _ = net.Pipe()
+	u = NewUpgrader(&testUpgrader{nodeID: notAllowedNodeID}, list, notAllowed)
+	_, _, _, err = u.Upgrade(conn)
+	require.ErrorIs(err, ErrNotAllowed)
+	require.Equal(float64(1), testutil.ToFloat64(notAllowed))
+}
@@ -0,0 +1,319 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package banlist
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/luxdefi/node/database"
+	"github.com/luxdefi/node/database/prefixdb"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/timer/mockable"
+)
+
+var (
+	_ List = (*list)(nil)
+
+	nodeIDPrefix = []byte("nodeID")
+	ipPrefix     = []byte("ip")
+
+	errNegativeDuration = errors.New("ban duration must be non-negative")
+	errNilSubnet        = errors.New("ip subnet must be provided")
+)
+
+// Ban describes a single banned NodeID or IP range.
+type Ban struct {
+	// NodeID is the banned node. Empty if this is an IP ban.
+	NodeID ids.NodeID
+	// Subnet is the banned IP range. Nil if this is a NodeID ban.
+	Subnet *net.IPNet
+	// Reason is the operator provided description of the ban.
+	Reason string
+	// Expiry is the time the ban is lifted. The zero value means the ban
+	// never expires.
+	Expiry time.Time
+}
+
+// List tracks the NodeIDs and IP ranges that this node refuses to connect to.
+// Bans are persisted so that they survive restarts.
+type List interface {
+	// BanNodeID bans [nodeID] for [duration]. If [duration] is 0, the ban
+	// never expires. Banning an already banned node replaces the prior ban.
+	BanNodeID(nodeID ids.NodeID, duration time.Duration, reason string) error
+
+	// BanIP bans every IP in [subnet] for [duration]. If [duration] is 0, the
+	// ban never expires. Banning an already banned subnet replaces the prior
+	// ban.
+	BanIP(subnet *net.IPNet, duration time.Duration, reason string) error
+
+	// UnbanNodeID removes the ban on [nodeID], if one exists.
+	UnbanNodeID(nodeID ids.NodeID) error
+
+	// UnbanIP removes the ban on [subnet], if one exists. [subnet] must match
+	// the banned range exactly.
+	UnbanIP(subnet *net.IPNet) error
+
+	// IsNodeIDBanned returns true if [nodeID] is currently banned.
+	IsNodeIDBanned(nodeID ids.NodeID) bool
+
+	// IsIPBanned returns true if [ip] is contained in a currently banned
+	// subnet.
+	IsIPBanned(ip net.IP) bool
+
+	// Bans returns all the bans that have not yet expired. Expired bans are
+	// removed from the database.
+	Bans() ([]Ban, error)
+}
+
+type entry struct {
+	Reason string `serialize:"true"`
+	// Expiry is the unix time the ban is lifted, or 0 if it never expires.
+	Expiry uint64 `serialize:"true"`
+}
+
+type ipBan struct {
+	subnet *net.IPNet
+	entry  entry
+}
+
+type list struct {
+	clock mockable.Clock
+
+	lock     sync.RWMutex
+	nodeIDDB database.Database
+	ipDB     database.Database
+	nodeIDs  map[ids.NodeID]entry
+	// CIDR string -> ban
+	ips map[string]*ipBan
+}
+
+// New returns a List that persists bans into [db]. Any bans previously
+// written into [db] are loaded.
+func New(db database.Database) (List, error) {
+	l := &list{
+		nodeIDDB: prefixdb.New(nodeIDPrefix, db),
+		ipDB:     prefixdb.New(ipPrefix, db),
+		nodeIDs:  make(map[ids.NodeID]entry),
+		ips:      make(map[string]*ipBan),
+	}
+	if err := l.loadNodeIDs(); err != nil {
+		return nil, fmt.Errorf("failed to load banned nodeIDs: %w", err)
+	}
+	if err := l.loadIPs(); err != nil {
+		return nil, fmt.Errorf("failed to load banned IPs: %w", err)
+	}
+	return l, nil
+}
+
+func (l *list) BanNodeID(nodeID ids.NodeID, duration time.Duration, reason string) error {
+	e, bytes, err := l.newEntry(duration, reason)
+	if err != nil {
+		return err
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if err := l.nodeIDDB.Put(nodeID.Bytes(), bytes); err != nil {
+		return err
+	}
+	l.nodeIDs[nodeID] = e
+	return nil
+}
+
+func (l *list) BanIP(subnet *net.IPNet, duration time.Duration, reason string) error {
+	if subnet == nil {
+		return errNilSubnet
+	}
+	e, bytes, err := l.newEntry(duration, reason)
+	if err != nil {
+		return err
+	}
+
+	key := subnet.String()
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if err := l.ipDB.Put([]byte(key), bytes); err != nil {
+		return err
+	}
+	l.ips[key] = &ipBan{
+		subnet: subnet,
+		entry:  e,
+	}
+	return nil
+}
+
+func (l *list) UnbanNodeID(nodeID ids.NodeID) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	delete(l.nodeIDs, nodeID)
+	return l.nodeIDDB.Delete(nodeID.Bytes())
+}
+
+func (l *list) UnbanIP(subnet *net.IPNet) error {
+	if subnet == nil {
+		return errNilSubnet
+	}
+
+	key := subnet.String()
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	delete(l.ips, key)
+	return l.ipDB.Delete([]byte(key))
+}
+
+func (l *list) IsNodeIDBanned(nodeID ids.NodeID) bool {
+	now := l.clock.Unix()
+
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	e, ok := l.nodeIDs[nodeID]
+	return ok && !e.expired(now)
+}
+
+func (l *list) IsIPBanned(ip net.IP) bool {
+	now := l.clock.Unix()
+
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	for _, ban := range l.ips {
+		if !ban.entry.expired(now) && ban.subnet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *list) Bans() ([]Ban, error) {
+	now := l.clock.Unix()
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	bans := make([]Ban, 0, len(l.nodeIDs)+len(l.ips))
+	for nodeID, e := range l.nodeIDs {
+		if e.expired(now) {
+			delete(l.nodeIDs, nodeID)
+			if err := l.nodeIDDB.Delete(nodeID.Bytes()); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		bans = append(bans, Ban{
+			NodeID: nodeID,
+			Reason: e.Reason,
+			Expiry: e.expiryTime(),
+		})
+	}
+	for key, ban := range l.ips {
+		if ban.entry.expired(now) {
+			delete(l.ips, key)
+			if err := l.ipDB.Delete([]byte(key)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		bans = append(bans, Ban{
+			Subnet: ban.subnet,
+			Reason: ban.entry.Reason,
+			Expiry: ban.entry.expiryTime(),
+		})
+	}
+	return bans, nil
+}
+
+func (l *list) newEntry(duration time.Duration, reason string) (entry, []byte, error) {
+	if duration < 0 {
+		return entry{}, nil, errNegativeDuration
+	}
+
+	e := entry{
+		Reason: reason,
+	}
+	if duration > 0 {
+		e.Expiry = uint64(l.clock.Time().Add(duration).Unix())
+	}
+	bytes, err := codecManager.Marshal(codecVersion, &e)
+	return e, bytes, err
+}
+
+func (l *list) loadNodeIDs() error {
+	it := l.nodeIDDB.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		nodeID, err := ids.ToNodeID(it.Key())
+		if err != nil {
+			return err
+		}
+		var e entry
+		if _, err := codecManager.Unmarshal(it.Value(), &e); err != nil {
+			return err
+		}
+		l.nodeIDs[nodeID] = e
+	}
+	return it.Error()
+}
+
+func (l *list) loadIPs() error {
+	it := l.ipDB.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		_, subnet, err := net.ParseCIDR(string(it.Key()))
+		if err != nil {
+			return err
+		}
+		var e entry
+		if _, err := codecManager.Unmarshal(it.Value(), &e); err != nil {
+			return err
+		}
+		l.ips[subnet.String()] = &ipBan{
+			subnet: subnet,
+			entry:  e,
+		}
+	}
+	return it.Error()
+}
+
+func (e entry) expired(now uint64) bool {
+	return e.Expiry != 0 && e.Expiry <= now
+}
+
+func (e entry) expiryTime() time.Time {
+	if e.Expiry == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(e.Expiry), 0)
+}
+
+// ParseSubnet parses [s] as either a CIDR range or a single IP address. A
+// single IP address is treated as a range containing only that address.
+func ParseSubnet(s string) (*net.IPNet, error) {
+	if _, subnet, err := net.ParseCIDR(s); err == nil {
+		return subnet, nil
+	}
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, fmt.Errorf("%q is neither an IP nor a CIDR range", s)
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	return &net.IPNet{
+		IP:   ip,
+		Mask: net.CIDRMask(len(ip)*8, len(ip)*8),
+	}, nil
+}
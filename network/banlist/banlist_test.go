@@ -0,0 +1,157 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package banlist
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxdefi/node/database/memdb"
+	"github.com/luxdefi/node/ids"
+)
+
+func TestBanNodeID(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	l, err := New(db)
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	require.False(l.IsNodeIDBanned(nodeID))
+
+	require.NoError(l.BanNodeID(nodeID, 0, "misbehaving"))
+	require.True(l.IsNodeIDBanned(nodeID))
+
+	// Bans should be reloaded from the database
+	l, err = New(db)
+	require.NoError(err)
+	require.True(l.IsNodeIDBanned(nodeID))
+
+	bans, err := l.Bans()
+	require.NoError(err)
+	require.Equal([]Ban{{
+		NodeID: nodeID,
+		Reason: "misbehaving",
+	}}, bans)
+
+	require.NoError(l.UnbanNodeID(nodeID))
+	require.False(l.IsNodeIDBanned(nodeID))
+
+	l, err = New(db)
+	require.NoError(err)
+	require.False(l.IsNodeIDBanned(nodeID))
+}
+
+func TestBanIP(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	l, err := New(db)
+	require.NoError(err)
+
+	subnet, err := ParseSubnet("10.0.0.0/24")
+	require.NoError(err)
+
+	require.NoError(l.BanIP(subnet, 0, ""))
+	require.True(l.IsIPBanned(net.IPv4(10, 0, 0, 1)))
+	require.False(l.IsIPBanned(net.IPv4(10, 0, 1, 1)))
+
+	l, err = New(db)
+	require.NoError(err)
+	require.True(l.IsIPBanned(net.IPv4(10, 0, 0, 255)))
+
+	require.NoError(l.UnbanIP(subnet))
+	require.False(l.IsIPBanned(net.IPv4(10, 0, 0, 1)))
+}
+
+func TestBanExpiry(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	lIntf, err := New(db)
+	require.NoError(err)
+	l := lIntf.(*list)
+
+	now := time.Unix(1_000_000, 0)
+	l.clock.Set(now)
+
+	nodeID := ids.GenerateTestNodeID()
+	require.NoError(l.BanNodeID(nodeID, time.Hour, ""))
+
+	ip, err := ParseSubnet("192.168.1.1")
+	require.NoError(err)
+	require.NoError(l.BanIP(ip, time.Minute, ""))
+
+	require.True(l.IsNodeIDBanned(nodeID))
+	require.True(l.IsIPBanned(ip.IP))
+
+	l.clock.Set(now.Add(time.Minute))
+	require.True(l.IsNodeIDBanned(nodeID))
+	require.False(l.IsIPBanned(ip.IP))
+
+	bans, err := l.Bans()
+	require.NoError(err)
+	require.Equal([]Ban{{
+		NodeID: nodeID,
+		Expiry: now.Add(time.Hour),
+	}}, bans)
+
+	// The expired IP ban should have been removed from the database
+	l2, err := New(db)
+	require.NoError(err)
+	require.Empty(l2.(*list).ips)
+
+	l.clock.Set(now.Add(time.Hour))
+	require.False(l.IsNodeIDBanned(nodeID))
+}
+
+func TestBanNegativeDuration(t *testing.T) {
+	l, err := New(memdb.New())
+	require.NoError(t, err)
+
+	err = l.BanNodeID(ids.GenerateTestNodeID(), -time.Second, "")
+	require.ErrorIs(t, err, errNegativeDuration)
+}
+
+func TestParseSubnet(t *testing.T) {
+	tests := []struct {
+		in          string
+		expected    string
+		expectedErr bool
+	}{
+		{
+			in:       "1.2.3.4",
+			expected: "1.2.3.4/32",
+		},
+		{
+			in:       "1.2.3.4/16",
+			expected: "1.2.0.0/16",
+		},
+		{
+			in:       "::1",
+			expected: "::1/128",
+		},
+		{
+			in:          "not an ip",
+			expectedErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			require := require.New(t)
+
+			subnet, err := ParseSubnet(test.in)
+			if test.expectedErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			require.Equal(test.expected, subnet.String())
+		})
+	}
+}
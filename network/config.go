@@ -9,6 +9,9 @@ import (
 	"time"
 
 	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/network/addrbook"
+	"github.com/luxdefi/node/network/allowlist"
+	"github.com/luxdefi/node/network/banlist"
 	"github.com/luxdefi/node/network/dialer"
 	"github.com/luxdefi/node/network/peer"
 	"github.com/luxdefi/node/network/throttling"
@@ -113,6 +116,13 @@ type Config struct {
 	ProxyEnabled           bool          `json:"proxyEnabled"`
 	ProxyReadHeaderTimeout time.Duration `json:"proxyReadHeaderTimeout"`
 
+	// QUICEnabled specifies whether peers can connect over QUIC. Peers that
+	// don't support QUIC are connected to over TCP.
+	QUICEnabled bool `json:"quicEnabled"`
+	// QUICDialTimeout is the time to wait for a QUIC connection to be
+	// established before falling back to TCP.
+	QUICDialTimeout time.Duration `json:"quicDialTimeout"`
+
 	DialerConfig dialer.Config `json:"dialerConfig"`
 	TLSConfig    *tls.Config   `json:"-"`
 
@@ -161,6 +171,10 @@ type Config struct {
 	// will be reset to this value.
 	MaximumInboundMessageTimeout time.Duration `json:"maximumInboundMessageTimeout"`
 
+	// MessageQueueConfig limits the messages of each class that can be
+	// queued to be sent to a peer.
+	MessageQueueConfig peer.PriorityMessageQueueConfig `json:"messageQueueConfig"`
+
 	// Size, in bytes, of the buffer that we read peer messages into
 	// (there is one buffer per peer)
 	PeerReadBufferSize int `json:"peerReadBufferSize"`
@@ -182,4 +196,27 @@ type Config struct {
 
 	// Tracks which validators have been sent to which peers
 	GossipTracker peer.GossipTracker `json:"-"`
+
+	// Tracks the NodeIDs and IPs that this node refuses to connect to
+	BanList banlist.List `json:"-"`
+
+	// Persists the signed IPs of desired peers so that they can be
+	// reconnected to after a restart
+	AddressBook addrbook.Book `json:"-"`
+
+	// AddressBookMaxAge is how long the address of a peer is kept in
+	// [AddressBook] after the peer was last seen
+	AddressBookMaxAge time.Duration `json:"addressBookMaxAge"`
+
+	// AllowListFile is the path of a JSON array of NodeIDs that are allowed
+	// to connect. Ignored if empty.
+	AllowListFile string `json:"allowListFile"`
+
+	// AllowListSubnetIDs are the subnets whose validators are allowed to
+	// connect.
+	AllowListSubnetIDs set.Set[ids.ID] `json:"allowListSubnetIDs"`
+
+	// If non-nil, only peers in the AllowList are connected to. Connections
+	// with any other peer are closed during the TLS upgrade.
+	AllowList allowlist.List `json:"-"`
 }
@@ -0,0 +1,125 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package dnsseed discovers peers by querying DNS seeds.
+//
+// A DNS seed is a hostname whose TXT records each advertise a single peer in
+// the form "<NodeID>@<IP>:<port>". For example:
+//
+//	NodeID-7Xhw2mDxuDS44j42TCB6U5579esbSt3Lg@1.2.3.4:9651
+package dnsseed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/ips"
+	"github.com/luxdefi/node/utils/logging"
+)
+
+const recordSeparator = "@"
+
+var (
+	_ Resolver = (*net.Resolver)(nil)
+	_ Resolver = StaticResolver(nil)
+
+	errMissingSeparator = errors.New("missing separator")
+)
+
+// Resolver looks up the TXT records of a host.
+type Resolver interface {
+	LookupTXT(ctx context.Context, host string) ([]string, error)
+}
+
+// Seed is a peer advertised by a DNS seed.
+type Seed struct {
+	NodeID ids.NodeID
+	IP     ips.IPPort
+}
+
+// Resolve queries every host in [hosts] using [resolver] and returns the
+// de-duplicated peers they advertise.
+//
+// Hosts that fail to resolve and records that can't be parsed are logged and
+// skipped, so that a single misconfigured seed doesn't prevent the others from
+// being used.
+func Resolve(ctx context.Context, log logging.Logger, resolver Resolver, hosts []string) []Seed {
+	var (
+		seeds []Seed
+		seen  = make(map[ids.NodeID]struct{})
+	)
+	for _, host := range hosts {
+		records, err := resolver.LookupTXT(ctx, host)
+		if err != nil {
+			log.Warn("failed to resolve DNS seed",
+				zap.String("host", host),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		for _, record := range records {
+			seed, err := ParseRecord(record)
+			if err != nil {
+				log.Debug("skipping invalid DNS seed record",
+					zap.String("host", host),
+					zap.String("record", record),
+					zap.Error(err),
+				)
+				continue
+			}
+			if _, ok := seen[seed.NodeID]; ok {
+				continue
+			}
+			seen[seed.NodeID] = struct{}{}
+			seeds = append(seeds, seed)
+		}
+	}
+	return seeds
+}
+
+// ParseRecord parses a single "<NodeID>@<IP>:<port>" TXT record.
+func ParseRecord(record string) (Seed, error) {
+	nodeIDStr, ipStr, ok := strings.Cut(strings.TrimSpace(record), recordSeparator)
+	if !ok {
+		return Seed{}, fmt.Errorf("%w %q in %q", errMissingSeparator, recordSeparator, record)
+	}
+
+	nodeID, err := ids.NodeIDFromString(nodeIDStr)
+	if err != nil {
+		return Seed{}, fmt.Errorf("couldn't parse nodeID: %w", err)
+	}
+
+	ip, err := ips.ToIPPort(ipStr)
+	if err != nil {
+		return Seed{}, fmt.Errorf("couldn't parse ip: %w", err)
+	}
+
+	return Seed{
+		NodeID: nodeID,
+		IP:     ip,
+	}, nil
+}
+
+// StaticResolver is a Resolver that serves TXT records from memory rather
+// than querying DNS. It is intended to stand in for a real resolver in tests
+// and local networks.
+type StaticResolver map[string][]string
+
+func (r StaticResolver) LookupTXT(_ context.Context, host string) ([]string, error) {
+	records, ok := r[host]
+	if !ok {
+		return nil, &net.DNSError{
+			Err:        "no such host",
+			Name:       host,
+			IsNotFound: true,
+		}
+	}
+	return records, nil
+}
@@ -0,0 +1,99 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dnsseed
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/ips"
+	"github.com/luxdefi/node/utils/logging"
+)
+
+func TestParseRecord(t *testing.T) {
+	nodeID := ids.GenerateTestNodeID()
+
+	tests := []struct {
+		name        string
+		record      string
+		expected    Seed
+		expectedErr error
+	}{
+		{
+			name:   "valid",
+			record: nodeID.String() + "@1.2.3.4:9651",
+			expected: Seed{
+				NodeID: nodeID,
+				IP: ips.IPPort{
+					IP:   net.IPv4(1, 2, 3, 4),
+					Port: 9651,
+				},
+			},
+		},
+		{
+			name:   "surrounding whitespace",
+			record: " " + nodeID.String() + "@[::1]:9651 ",
+			expected: Seed{
+				NodeID: nodeID,
+				IP: ips.IPPort{
+					IP:   net.IPv6loopback,
+					Port: 9651,
+				},
+			},
+		},
+		{
+			name:        "missing separator",
+			record:      nodeID.String(),
+			expectedErr: errMissingSeparator,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			seed, err := ParseRecord(test.record)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+			require.Equal(test.expected.NodeID, seed.NodeID)
+			require.True(test.expected.IP.Equal(seed.IP))
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	require := require.New(t)
+
+	nodeID0 := ids.GenerateTestNodeID()
+	nodeID1 := ids.GenerateTestNodeID()
+	resolver := StaticResolver{
+		"seed0.lux.network": {
+			nodeID0.String() + "@1.2.3.4:9651",
+			"garbage",
+		},
+		"seed1.lux.network": {
+			nodeID0.String() + "@1.2.3.4:9651",
+			nodeID1.String() + "@5.6.7.8:9651",
+		},
+	}
+
+	seeds := Resolve(
+		context.Background(),
+		logging.NoLog{},
+		resolver,
+		[]string{
+			"seed0.lux.network",
+			"unknown.lux.network",
+			"seed1.lux.network",
+		},
+	)
+	require.Len(seeds, 2)
+	require.Equal(nodeID0, seeds[0].NodeID)
+	require.Equal(nodeID1, seeds[1].NodeID)
+}
@@ -29,6 +29,7 @@ type metrics struct {
 	inboundConnRateLimited          prometheus.Counter
 	inboundConnAllowed              prometheus.Counter
 	tlsConnRejected                 prometheus.Counter
+	notAllowedConnRejected          prometheus.Counter
 	numUselessPeerListBytes         prometheus.Counter
 	nodeUptimeWeightedAverage       prometheus.Gauge
 	nodeUptimeRewardingStake        prometheus.Gauge
@@ -101,6 +102,11 @@ func newMetrics(namespace string, registerer prometheus.Registerer, initialSubne
 			Name:      "tls_conn_rejected",
 			Help:      "Times this node rejected a connection due to an unsupported TLS certificate",
 		}),
+		notAllowedConnRejected: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "not_allowed_conn_rejected",
+			Help:      "Times this node rejected a connection because the peer wasn't in the allow list",
+		}),
 		numUselessPeerListBytes: prometheus.NewCounter(prometheus.CounterOpts{
 			Namespace: namespace,
 			Name:      "num_useless_peerlist_bytes",
@@ -159,6 +165,7 @@ func newMetrics(namespace string, registerer prometheus.Registerer, initialSubne
 		registerer.Register(m.acceptFailed),
 		registerer.Register(m.inboundConnAllowed),
 		registerer.Register(m.tlsConnRejected),
+		registerer.Register(m.notAllowedConnRejected),
 		registerer.Register(m.numUselessPeerListBytes),
 		registerer.Register(m.inboundConnRateLimited),
 		registerer.Register(m.nodeUptimeWeightedAverage),
@@ -25,6 +25,8 @@ import (
 	"github.com/luxdefi/node/api/health"
 	"github.com/luxdefi/node/ids"
 	"github.com/luxdefi/node/message"
+	"github.com/luxdefi/node/network/allowlist"
+	"github.com/luxdefi/node/network/banlist"
 	"github.com/luxdefi/node/network/dialer"
 	"github.com/luxdefi/node/network/peer"
 	"github.com/luxdefi/node/network/throttling"
@@ -58,6 +60,7 @@ var (
 	errNotTracked          = errors.New("subnet is not tracked")
 	errExpectedProxy       = errors.New("expected proxy")
 	errExpectedTCPProtocol = errors.New("expected TCP protocol")
+	errNoAllowList         = errors.New("allow list is not enabled")
 )
 
 // Network defines the functionality of the networking library.
@@ -89,6 +92,15 @@ type Network interface {
 	// connect to this ID.
 	ManuallyTrack(nodeID ids.NodeID, ip ips.IPPort)
 
+	// PinPeer manually tracks [nodeID] at [ip] and persists it, so that it is
+	// tracked again after a restart.
+	PinPeer(nodeID ids.NodeID, ip ips.IPPort) error
+
+	// UnpinPeer removes the persisted pin of [nodeID] and stops manually
+	// tracking it. If [nodeID] is still desired, such as a validator, it
+	// continues to be connected to.
+	UnpinPeer(nodeID ids.NodeID) error
+
 	// PeerInfo returns information about peers. If [nodeIDs] is empty, returns
 	// info about all peers that have finished the handshake. Otherwise, returns
 	// info about the peers in [nodeIDs] that have finished the handshake.
@@ -97,6 +109,35 @@ type Network interface {
 	// NodeUptime returns given node's [subnetID] UptimeResults in the view of
 	// this node's peer validators.
 	NodeUptime(subnetID ids.ID) (UptimeResult, error)
+
+	// Disconnect closes the connection to [nodeID], if one exists. If the
+	// connection is still desired, the network will attempt to reconnect.
+	// Returns true if there was a connection to close.
+	Disconnect(nodeID ids.NodeID) bool
+
+	// BanNodeID refuses all connections with [nodeID] for [duration] and
+	// closes any existing connection to it. If [duration] is 0, the ban never
+	// expires. Bans persist across restarts.
+	BanNodeID(nodeID ids.NodeID, duration time.Duration, reason string) error
+
+	// BanIP refuses all connections with IPs in [subnet] for [duration] and
+	// closes any existing connections from it. If [duration] is 0, the ban
+	// never expires. Bans persist across restarts.
+	BanIP(subnet *net.IPNet, duration time.Duration, reason string) error
+
+	// UnbanNodeID removes the ban on [nodeID], if one exists.
+	UnbanNodeID(nodeID ids.NodeID) error
+
+	// UnbanIP removes the ban on [subnet], if one exists.
+	UnbanIP(subnet *net.IPNet) error
+
+	// Bans returns all bans that have not yet expired.
+	Bans() ([]banlist.Ban, error)
+
+	// ReloadAllowList reloads the allow list from its source and closes any
+	// existing connections with peers that are no longer allowed. Returns an
+	// error if the allow list isn't enabled.
+	ReloadAllowList() error
 }
 
 type UptimeResult struct {
@@ -262,6 +303,7 @@ func NewNetwork(
 
 		Log:                  log,
 		InboundMsgThrottler:  inboundMsgThrottler,
+		OutboundMsgThrottler: outboundMsgThrottler,
 		Network:              nil, // This is set below.
 		Router:               router,
 		VersionCompatibility: version.GetCompatibility(config.NetworkID),
@@ -276,6 +318,20 @@ func NewNetwork(
 		IPSigner:             peer.NewIPSigner(config.MyIPPort, config.TLSKey),
 	}
 
+	// Inbound connections from banned IPs are dropped before being upgraded.
+	inboundConnUpgradeThrottler := throttling.NewBanAwareInboundConnUpgradeThrottler(
+		log,
+		throttling.NewInboundConnUpgradeThrottler(log, config.ThrottlerConfig.InboundConnUpgradeThrottlerConfig),
+		config.BanList,
+	)
+
+	serverUpgrader := peer.NewTLSServerUpgrader(config.TLSConfig, metrics.tlsConnRejected)
+	clientUpgrader := peer.NewTLSClientUpgrader(config.TLSConfig, metrics.tlsConnRejected)
+	if config.AllowList != nil {
+		serverUpgrader = allowlist.NewUpgrader(serverUpgrader, config.AllowList, metrics.notAllowedConnRejected)
+		clientUpgrader = allowlist.NewUpgrader(clientUpgrader, config.AllowList, metrics.notAllowedConnRejected)
+	}
+
 	onCloseCtx, cancel := context.WithCancel(context.Background())
 	n := &network{
 		config:               config,
@@ -283,11 +339,11 @@ func NewNetwork(
 		metrics:              metrics,
 		outboundMsgThrottler: outboundMsgThrottler,
 
-		inboundConnUpgradeThrottler: throttling.NewInboundConnUpgradeThrottler(log, config.ThrottlerConfig.InboundConnUpgradeThrottlerConfig),
+		inboundConnUpgradeThrottler: inboundConnUpgradeThrottler,
 		listener:                    listener,
 		dialer:                      dialer,
-		serverUpgrader:              peer.NewTLSServerUpgrader(config.TLSConfig, metrics.tlsConnRejected),
-		clientUpgrader:              peer.NewTLSClientUpgrader(config.TLSConfig, metrics.tlsConnRejected),
+		serverUpgrader:              serverUpgrader,
+		clientUpgrader:              clientUpgrader,
 
 		onCloseCtx:       onCloseCtx,
 		onCloseCtxCancel: cancel,
@@ -422,6 +478,19 @@ func (n *network) Connected(nodeID ids.NodeID) {
 		return
 	}
 
+	if n.isBanned(peer) {
+		n.peersLock.Unlock()
+
+		// The peer will remain marked as connecting until [Disconnected] is
+		// called.
+		n.peerConfig.Log.Debug("dropping connection",
+			zap.String("reason", "peer is banned"),
+			zap.Stringer("nodeID", nodeID),
+		)
+		peer.StartClose()
+		return
+	}
+
 	peerIP := peer.IP()
 	newIP := &ips.ClaimedIPPort{
 		Cert:      peer.Cert(),
@@ -456,6 +525,10 @@ func (n *network) Connected(nodeID ids.NodeID) {
 	n.connectedPeers.Add(peer)
 	n.peersLock.Unlock()
 
+	if n.WantsConnection(nodeID) {
+		n.persistIP(newIP)
+	}
+
 	n.metrics.markConnected(peer)
 
 	peerVersion := peer.Version()
@@ -502,6 +575,15 @@ func (n *network) Track(peerID ids.NodeID, claimedIPPorts []*ips.ClaimedIPPort)
 	// Information for us to update about them
 	txIDsWithUpToDateIP := make([]ids.ID, 0, ipLen)
 
+	// The verified IPs are persisted after [peersLock] is released, so that
+	// database writes are never performed while holding it.
+	var ipsToPersist []*ips.ClaimedIPPort
+	defer func() {
+		for _, ip := range ipsToPersist {
+			n.persistIP(ip)
+		}
+	}()
+
 	// Atomically modify peer data
 	n.peersLock.Lock()
 	defer n.peersLock.Unlock()
@@ -544,6 +626,7 @@ func (n *network) Track(peerID ids.NodeID, claimedIPPorts []*ips.ClaimedIPPort)
 
 			// In the future, we should gossip this IP rather than the old IP.
 			n.peerIPs[nodeID] = ip
+			ipsToPersist = append(ipsToPersist, ip)
 
 			// If the new IP is equal to the old IP, there is no reason to
 			// refresh the references to it. This can happen when a node
@@ -577,6 +660,7 @@ func (n *network) Track(peerID ids.NodeID, claimedIPPorts []*ips.ClaimedIPPort)
 			// We don't need to reset gossip about this validator because
 			// we've never gossiped it before.
 			n.peerIPs[nodeID] = ip
+			ipsToPersist = append(ipsToPersist, ip)
 
 			tracked := newTrackedIP(ip.IPPort)
 			n.trackedIPs[nodeID] = tracked
@@ -730,6 +814,9 @@ func (n *network) Peers(peerID ids.NodeID) ([]ips.ClaimedIPPort, error) {
 // Dispatch starts accepting connections from other nodes attempting to connect
 // to this node.
 func (n *network) Dispatch() error {
+	n.trackPinnedPeers()
+	n.trackPersistedIPs()
+
 	go n.runTimers() // Periodically perform operations
 	go n.inboundConnUpgradeThrottler.Dispatch()
 	for { // Continuously accept new connections
@@ -839,6 +926,34 @@ func (n *network) ManuallyTrack(nodeID ids.NodeID, ip ips.IPPort) {
 	}
 }
 
+func (n *network) PinPeer(nodeID ids.NodeID, ip ips.IPPort) error {
+	if err := n.config.AddressBook.Pin(nodeID, ip); err != nil {
+		return err
+	}
+	n.ManuallyTrack(nodeID, ip)
+	return nil
+}
+
+func (n *network) UnpinPeer(nodeID ids.NodeID) error {
+	if err := n.config.AddressBook.Unpin(nodeID); err != nil {
+		return err
+	}
+
+	n.peersLock.Lock()
+	defer n.peersLock.Unlock()
+
+	n.manuallyTrackedIDsLock.Lock()
+	n.manuallyTrackedIDs.Remove(nodeID)
+	n.manuallyTrackedIDsLock.Unlock()
+
+	if tracked, ok := n.trackedIPs[nodeID]; ok && !n.WantsConnection(nodeID) {
+		tracked.stopTracking()
+		delete(n.peerIPs, nodeID)
+		delete(n.trackedIPs, nodeID)
+	}
+	return nil
+}
+
 // getPeers returns a slice of connected peers from a set of [nodeIDs].
 //
 //   - [nodeIDs] the IDs of the peers that should be returned if they are
@@ -998,6 +1113,72 @@ func (n *network) disconnectedFromConnected(peer peer.Peer, nodeID ids.NodeID) {
 	n.metrics.markDisconnected(peer)
 }
 
+// persistIP records [ip] in the address book so that the peer can be
+// reconnected to after a restart. [ip]'s signature must have been verified.
+func (n *network) persistIP(ip *ips.ClaimedIPPort) {
+	if err := n.config.AddressBook.Put(ip); err != nil {
+		n.peerConfig.Log.Warn("failed to persist peer IP",
+			zap.Stringer("nodeID", ids.NodeIDFromCert(ip.Cert)),
+			zap.Stringer("peerIP", ip.IPPort),
+			zap.Error(err),
+		)
+	}
+}
+
+// trackPinnedPeers starts connecting to the peers that were pinned by a prior
+// run of the node.
+func (n *network) trackPinnedPeers() {
+	for nodeID, ip := range n.config.AddressBook.Pinned() {
+		n.peerConfig.Log.Debug("tracking pinned peer",
+			zap.Stringer("nodeID", nodeID),
+			zap.Stringer("peerIP", ip),
+		)
+		n.ManuallyTrack(nodeID, ip)
+	}
+}
+
+// trackPersistedIPs starts connecting to the desired peers whose IPs were
+// persisted in the address book by a prior run of the node. Peers that haven't
+// been seen for longer than [AddressBookMaxAge] are removed from the address
+// book. Peers that aren't currently desired are kept, as the validator set may
+// not be populated yet.
+func (n *network) trackPersistedIPs() {
+	if err := n.config.AddressBook.Prune(n.config.AddressBookMaxAge); err != nil {
+		n.peerConfig.Log.Warn("failed to prune peer IPs",
+			zap.Error(err),
+		)
+	}
+
+	n.peersLock.Lock()
+	defer n.peersLock.Unlock()
+
+	for _, ip := range n.config.AddressBook.IPs() {
+		nodeID := ids.NodeIDFromCert(ip.Cert)
+		if !n.WantsConnection(nodeID) {
+			continue
+		}
+
+		// If we are already attempting to connect to this peer, for example
+		// because it is a bootstrapper, the existing attempt is left as is.
+		if _, isTracked := n.trackedIPs[nodeID]; isTracked {
+			continue
+		}
+		if _, ok := n.peerIPs[nodeID]; ok {
+			continue
+		}
+
+		n.peerConfig.Log.Debug("tracking persisted peer IP",
+			zap.Stringer("nodeID", nodeID),
+			zap.Stringer("peerIP", ip.IPPort),
+		)
+
+		n.peerIPs[nodeID] = ip
+		tracked := newTrackedIP(ip.IPPort)
+		n.trackedIPs[nodeID] = tracked
+		n.dial(nodeID, tracked)
+	}
+}
+
 // ipAuth is a helper struct used to convey information about an
 // [*ips.ClaimedIPPort].
 type ipAuth struct {
@@ -1145,6 +1326,18 @@ func (n *network) dial(nodeID ids.NodeID, ip *trackedIP) {
 				continue
 			}
 
+			// Banned peers are skipped rather than untracked so that the
+			// connection can be re-established once the ban expires.
+			if n.config.BanList.IsNodeIDBanned(nodeID) || n.config.BanList.IsIPBanned(ip.ip.IP) {
+				n.peerConfig.Log.Verbo("skipping connection dial",
+					zap.String("reason", "peer is banned"),
+					zap.Stringer("nodeID", nodeID),
+					zap.Stringer("peerIP", ip.ip.IP),
+					zap.Duration("delay", ip.delay),
+				)
+				continue
+			}
+
 			conn, err := n.dialer.Dial(n.onCloseCtx, ip.ip)
 			if err != nil {
 				n.peerConfig.Log.Verbo(
@@ -1218,6 +1411,15 @@ func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
 		return nil
 	}
 
+	if n.config.BanList.IsNodeIDBanned(nodeID) {
+		_ = tlsConn.Close()
+		n.peerConfig.Log.Verbo(
+			"dropping banned connection",
+			zap.Stringer("nodeID", nodeID),
+		)
+		return nil
+	}
+
 	if !n.AllowConnection(nodeID) {
 		_ = tlsConn.Close()
 		n.peerConfig.Log.Verbo(
@@ -1283,7 +1485,8 @@ func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
 		tlsConn,
 		cert,
 		nodeID,
-		peer.NewThrottledMessageQueue(
+		peer.NewPriorityMessageQueue(
+			n.config.MessageQueueConfig,
 			n.peerConfig.Metrics,
 			nodeID,
 			n.peerConfig.Log,
@@ -1305,6 +1508,105 @@ func (n *network) PeerInfo(nodeIDs []ids.NodeID) []peer.Info {
 	return n.connectedPeers.Info(nodeIDs)
 }
 
+func (n *network) Disconnect(nodeID ids.NodeID) bool {
+	n.peersLock.RLock()
+	peer, ok := n.connectedPeers.GetByID(nodeID)
+	if !ok {
+		peer, ok = n.connectingPeers.GetByID(nodeID)
+	}
+	n.peersLock.RUnlock()
+
+	if ok {
+		peer.StartClose()
+	}
+	return ok
+}
+
+func (n *network) BanNodeID(nodeID ids.NodeID, duration time.Duration, reason string) error {
+	if err := n.config.BanList.BanNodeID(nodeID, duration, reason); err != nil {
+		return err
+	}
+
+	n.peerConfig.Log.Info("banned peer",
+		zap.Stringer("nodeID", nodeID),
+		zap.Duration("duration", duration),
+		zap.String("reason", reason),
+	)
+	n.Disconnect(nodeID)
+	return nil
+}
+
+func (n *network) BanIP(subnet *net.IPNet, duration time.Duration, reason string) error {
+	if err := n.config.BanList.BanIP(subnet, duration, reason); err != nil {
+		return err
+	}
+
+	n.peerConfig.Log.Info("banned ip",
+		zap.Stringer("subnet", subnet),
+		zap.Duration("duration", duration),
+		zap.String("reason", reason),
+	)
+
+	n.peersLock.RLock()
+	peers := n.connectedPeers.Sample(n.connectedPeers.Len(), n.isBanned)
+	n.peersLock.RUnlock()
+
+	for _, peer := range peers {
+		peer.StartClose()
+	}
+	return nil
+}
+
+func (n *network) UnbanNodeID(nodeID ids.NodeID) error {
+	return n.config.BanList.UnbanNodeID(nodeID)
+}
+
+func (n *network) UnbanIP(subnet *net.IPNet) error {
+	return n.config.BanList.UnbanIP(subnet)
+}
+
+func (n *network) Bans() ([]banlist.Ban, error) {
+	return n.config.BanList.Bans()
+}
+
+func (n *network) ReloadAllowList() error {
+	if n.config.AllowList == nil {
+		return errNoAllowList
+	}
+	if reloader, ok := n.config.AllowList.(allowlist.Reloader); ok {
+		if err := reloader.Reload(); err != nil {
+			return err
+		}
+	}
+
+	n.peersLock.RLock()
+	peers := n.connectedPeers.Sample(n.connectedPeers.Len(), func(p peer.Peer) bool {
+		return !n.config.AllowList.IsAllowed(p.ID())
+	})
+	n.peersLock.RUnlock()
+
+	n.peerConfig.Log.Info("reloaded allow list",
+		zap.Int("numDisconnected", len(peers)),
+	)
+	for _, peer := range peers {
+		peer.StartClose()
+	}
+	return nil
+}
+
+// isBanned returns true if [p]'s nodeID, claimed IP, or remote IP is banned.
+// It should only be called after [p] has finished the handshake.
+func (n *network) isBanned(p peer.Peer) bool {
+	if n.config.BanList.IsNodeIDBanned(p.ID()) {
+		return true
+	}
+	if n.config.BanList.IsIPBanned(p.IP().IPPort.IP) {
+		return true
+	}
+	remoteIP, err := ips.ToIPPort(p.Info().IP)
+	return err == nil && n.config.BanList.IsIPBanned(remoteIP.IP)
+}
+
 func (n *network) StartClose() {
 	n.closeOnce.Do(func() {
 		n.peerConfig.Log.Info("shutting down the p2p networking")
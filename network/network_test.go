@@ -16,8 +16,11 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/luxdefi/node/database/memdb"
 	"github.com/luxdefi/node/ids"
 	"github.com/luxdefi/node/message"
+	"github.com/luxdefi/node/network/addrbook"
+	"github.com/luxdefi/node/network/banlist"
 	"github.com/luxdefi/node/network/dialer"
 	"github.com/luxdefi/node/network/peer"
 	"github.com/luxdefi/node/network/throttling"
@@ -105,6 +108,8 @@ var (
 		DelayConfig:          defaultDelayConfig,
 		ThrottlerConfig:      defaultThrottlerConfig,
 
+		AddressBookMaxAge: constants.DefaultNetworkAddressBookMaxAge,
+
 		DialerConfig: defaultDialerConfig,
 
 		Namespace:          "",
@@ -176,6 +181,14 @@ func newTestNetwork(t *testing.T, count int) (*testDialer, []*testListener, []id
 		config.MyIPPort = ip
 		config.TLSKey = tlsCert.PrivateKey.(crypto.Signer)
 
+		banList, err := banlist.New(memdb.New())
+		require.NoError(t, err)
+		config.BanList = banList
+
+		addressBook, err := addrbook.New(logging.NoLog{}, memdb.New())
+		require.NoError(t, err)
+		config.AddressBook = addressBook
+
 		listeners[i] = listener
 		nodeIDs[i] = nodeID
 		configs[i] = &config
@@ -396,6 +409,55 @@ func TestSendAndGossipWithFilter(t *testing.T) {
 	wg.Wait()
 }
 
+func TestBanNodeIDDisconnects(t *testing.T) {
+	require := require.New(t)
+
+	nodeIDs, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil, nil, nil})
+
+	net0 := networks[0]
+	bannedNodeID := nodeIDs[1]
+	require.NoError(net0.BanNodeID(bannedNodeID, 0, "test"))
+
+	require.Eventually(
+		func() bool {
+			return len(net0.PeerInfo([]ids.NodeID{bannedNodeID})) == 0
+		},
+		10*time.Second,
+		50*time.Millisecond,
+	)
+
+	bans, err := net0.Bans()
+	require.NoError(err)
+	require.Equal([]banlist.Ban{{
+		NodeID: bannedNodeID,
+		Reason: "test",
+	}}, bans)
+
+	// The banned node should not be able to reconnect
+	time.Sleep(time.Second)
+	require.Empty(net0.PeerInfo([]ids.NodeID{bannedNodeID}))
+	require.Len(net0.PeerInfo([]ids.NodeID{nodeIDs[2]}), 1)
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
+func TestReloadAllowListWhenDisabled(t *testing.T) {
+	require := require.New(t)
+
+	_, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil})
+
+	err := networks[0].ReloadAllowList()
+	require.ErrorIs(err, errNoAllowList)
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
 func TestTrackVerifiesSignatures(t *testing.T) {
 	require := require.New(t)
 
@@ -517,6 +579,187 @@ func TestTrackDoesNotDialPrivateIPs(t *testing.T) {
 	wg.Wait()
 }
 
+func TestDispatchDialsPersistedIPs(t *testing.T) {
+	require := require.New(t)
+
+	dialer, listeners, nodeIDs, configs := newTestNetwork(t, 2)
+
+	// Persist node 0's IP in node 1's address book
+	_, tlsCert, _ := getTLS(t, 0)
+	unsignedIP := peer.UnsignedIP{
+		IPPort:    configs[0].MyIPPort.IPPort(),
+		Timestamp: 1,
+	}
+	signedIP, err := unsignedIP.Sign(configs[0].TLSKey)
+	require.NoError(err)
+	require.NoError(configs[1].AddressBook.Put(&ips.ClaimedIPPort{
+		Cert:      staking.CertificateFromX509(tlsCert.Leaf),
+		IPPort:    signedIP.IPPort,
+		Timestamp: signedIP.Timestamp,
+		Signature: signedIP.Signature,
+	}))
+
+	// Persist the IP of a node that isn't a validator in node 1's address book
+	_, nonValidatorCert, _ := getTLS(t, 2)
+	unsignedIP = peer.UnsignedIP{
+		IPPort:    ips.IPPort{IP: net.IPv4(1, 2, 3, 4), Port: 9651},
+		Timestamp: 1,
+	}
+	signedIP, err = unsignedIP.Sign(nonValidatorCert.PrivateKey.(crypto.Signer))
+	require.NoError(err)
+	require.NoError(configs[1].AddressBook.Put(&ips.ClaimedIPPort{
+		Cert:      staking.CertificateFromX509(nonValidatorCert.Leaf),
+		IPPort:    signedIP.IPPort,
+		Timestamp: signedIP.Timestamp,
+		Signature: signedIP.Signature,
+	}))
+
+	connected := make(chan ids.NodeID, 1)
+	networks := make([]Network, len(configs))
+	for i, config := range configs {
+		msgCreator := newMessageCreator(t)
+		registry := prometheus.NewRegistry()
+
+		g, err := peer.NewGossipTracker(registry, "foobar")
+		require.NoError(err)
+
+		log := logging.NoLog{}
+		gossipTrackerCallback := peer.GossipTrackerCallback{
+			Log:           log,
+			GossipTracker: g,
+		}
+
+		beacons := validators.NewManager()
+		require.NoError(beacons.AddStaker(constants.PrimaryNetworkID, nodeIDs[0], nil, ids.GenerateTestID(), 1))
+
+		vdrs := validators.NewManager()
+		vdrs.RegisterCallbackListener(constants.PrimaryNetworkID, &gossipTrackerCallback)
+		for _, nodeID := range nodeIDs {
+			require.NoError(vdrs.AddStaker(constants.PrimaryNetworkID, nodeID, nil, ids.GenerateTestID(), 1))
+		}
+
+		config := config
+
+		config.GossipTracker = g
+		config.Beacons = beacons
+		config.Validators = vdrs
+
+		handler := &testHandler{}
+		if i == 1 {
+			handler.ConnectedF = func(nodeID ids.NodeID, _ *version.Application, subnetID ids.ID) {
+				if subnetID == constants.PrimaryNetworkID {
+					connected <- nodeID
+				}
+			}
+		}
+
+		net, err := NewNetwork(
+			config,
+			msgCreator,
+			registry,
+			log,
+			listeners[i],
+			dialer,
+			handler,
+		)
+		require.NoError(err)
+		networks[i] = net
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(networks))
+	for _, net := range networks {
+		go func(net Network) {
+			defer wg.Done()
+
+			require.NoError(net.Dispatch())
+		}(net)
+	}
+
+	// Node 1 should connect to node 0 without being told about it
+	require.Equal(nodeIDs[0], <-connected)
+
+	// The non-validator should not have been dialed, but should remain in the
+	// address book
+	require.Len(configs[1].AddressBook.IPs(), 2)
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
+func TestDispatchTracksPinnedPeers(t *testing.T) {
+	require := require.New(t)
+
+	dialer, listeners, nodeIDs, configs := newTestNetwork(t, 2)
+
+	// Pin node 0 in node 1's address book. Node 0 isn't a validator, so node
+	// 1 only connects to it because it is pinned.
+	require.NoError(configs[1].AddressBook.Pin(nodeIDs[0], configs[0].MyIPPort.IPPort()))
+
+	connected := make(chan ids.NodeID, 1)
+	networks := make([]Network, len(configs))
+	for i, config := range configs {
+		msgCreator := newMessageCreator(t)
+		registry := prometheus.NewRegistry()
+
+		g, err := peer.NewGossipTracker(registry, "foobar")
+		require.NoError(err)
+
+		config := config
+
+		config.GossipTracker = g
+		config.Beacons = validators.NewManager()
+		config.Validators = validators.NewManager()
+
+		handler := &testHandler{}
+		if i == 1 {
+			handler.ConnectedF = func(nodeID ids.NodeID, _ *version.Application, subnetID ids.ID) {
+				if subnetID == constants.PrimaryNetworkID {
+					connected <- nodeID
+				}
+			}
+		}
+
+		net, err := NewNetwork(
+			config,
+			msgCreator,
+			registry,
+			logging.NoLog{},
+			listeners[i],
+			dialer,
+			handler,
+		)
+		require.NoError(err)
+		networks[i] = net
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(networks))
+	for _, net := range networks {
+		go func(net Network) {
+			defer wg.Done()
+
+			require.NoError(net.Dispatch())
+		}(net)
+	}
+
+	require.Equal(nodeIDs[0], <-connected)
+	require.True(networks[1].WantsConnection(nodeIDs[0]))
+
+	// Unpinning the peer removes it from the address book, so it isn't
+	// tracked after a restart.
+	require.NoError(networks[1].UnpinPeer(nodeIDs[0]))
+	require.False(networks[1].WantsConnection(nodeIDs[0]))
+	require.Empty(configs[1].AddressBook.Pinned())
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
 func TestDialDeletesNonValidators(t *testing.T) {
 	require := require.New(t)
 
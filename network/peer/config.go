@@ -44,6 +44,10 @@ type Config struct {
 	// Must only be accessed atomically
 	LastSent, LastReceived int64
 
+	// Limits the messages queued by connections that implement
+	// MessageWriter. Must be the throttler used by the peers' message queues.
+	OutboundMsgThrottler throttling.OutboundMsgThrottler
+
 	// Tracks CPU/disk usage caused by each peer.
 	ResourceTracker tracker.ResourceTracker
 
@@ -84,6 +84,11 @@ type Metrics struct {
 	ClockSkew      metric.Averager
 	FailedToParse  prometheus.Counter
 	MessageMetrics map[message.Op]*MessageMetrics
+
+	// Number of bytes of messages queued to be sent, by message class
+	QueuedBytes *prometheus.GaugeVec
+	// Number of messages dropped from the send queues, by message class
+	QueueDropped *prometheus.CounterVec
 }
 
 func NewMetrics(
@@ -99,11 +104,29 @@ func NewMetrics(
 			Help:      "Number of messages that could not be parsed or were invalidly formed",
 		}),
 		MessageMetrics: make(map[message.Op]*MessageMetrics, len(message.ExternalOps)),
+		QueuedBytes: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "send_queue_bytes",
+				Help:      "Number of bytes of messages queued to be sent",
+			},
+			[]string{classLabel},
+		),
+		QueueDropped: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "send_queue_dropped",
+				Help:      "Number of messages dropped due to the send queue limits",
+			},
+			[]string{classLabel},
+		),
 	}
 
 	errs := wrappers.Errs{}
 	errs.Add(
 		registerer.Register(m.FailedToParse),
+		registerer.Register(m.QueuedBytes),
+		registerer.Register(m.QueueDropped),
 	)
 	for _, op := range message.ExternalOps {
 		m.MessageMetrics[op] = NewMessageMetrics(op, namespace, registerer, &errs)
@@ -35,6 +35,18 @@ var (
 	_ Peer = (*peer)(nil)
 )
 
+// MessageWriter is implemented by connections that can send messages
+// independently of each other, such as multi-stream QUIC connections. If a
+// peer's connection implements MessageWriter, each message is written using
+// WriteMessage rather than through a single buffered byte stream.
+type MessageWriter interface {
+	// WriteMessage queues the length prefixed message [msg] with [op] to be
+	// written. It must not block until the message is written. If nil is
+	// returned, [onWritten] is called once the message has been written or
+	// dropped.
+	WriteMessage(op message.Op, msg net.Buffers, onWritten func()) error
+}
+
 // Peer encapsulates all of the functionality required to send and receive
 // messages with a remote peer.
 type Peer interface {
@@ -571,7 +583,11 @@ func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
 
 	// Write the message
 	var buf net.Buffers = [][]byte{msgLenBytes[:], msgBytes}
-	if _, err := io.CopyN(writer, &buf, int64(wrappers.IntLen+msgLen)); err != nil {
+	if messageWriter, ok := p.conn.(MessageWriter); ok {
+		if !p.writeQueuedMessage(messageWriter, msg, buf) {
+			return
+		}
+	} else if _, err := io.CopyN(writer, &buf, int64(wrappers.IntLen+msgLen)); err != nil {
 		p.Log.Verbo("error writing message",
 			zap.Stringer("nodeID", p.id),
 			zap.Error(err),
@@ -584,6 +600,38 @@ func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
 	p.Metrics.Sent(msg)
 }
 
+// writeQueuedMessage queues [msg] to be written by [messageWriter]. The
+// message queue released [msg] from the outbound message throttler when it
+// was popped, so it's acquired again until [messageWriter] has written it.
+// This keeps the messages queued by the connection limited by the throttler.
+// Returns true if [msg] was queued.
+func (p *peer) writeQueuedMessage(messageWriter MessageWriter, msg message.OutboundMessage, buf net.Buffers) bool {
+	if !p.OutboundMsgThrottler.Acquire(msg, p.id) {
+		p.Log.Debug("dropping outgoing message",
+			zap.String("reason", "rate-limiting"),
+			zap.Stringer("messageOp", msg.Op()),
+			zap.Stringer("nodeID", p.id),
+		)
+		p.Metrics.SendFailed(msg)
+		return false
+	}
+
+	onWritten := func() {
+		p.OutboundMsgThrottler.Release(msg, p.id)
+	}
+	if err := messageWriter.WriteMessage(msg.Op(), buf, onWritten); err != nil {
+		p.Log.Debug("dropping outgoing message",
+			zap.Stringer("messageOp", msg.Op()),
+			zap.Stringer("nodeID", p.id),
+			zap.Error(err),
+		)
+		p.OutboundMsgThrottler.Release(msg, p.id)
+		p.Metrics.SendFailed(msg)
+		return false
+	}
+	return true
+}
+
 func (p *peer) sendNetworkMessages() {
 	sendPingsTicker := time.NewTicker(p.PingFrequency)
 	defer func() {
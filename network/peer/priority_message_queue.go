@@ -0,0 +1,314 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/message"
+	"github.com/luxdefi/node/network/throttling"
+	"github.com/luxdefi/node/utils/buffer"
+	"github.com/luxdefi/node/utils/logging"
+)
+
+const classLabel = "class"
+
+var _ MessageQueue = (*priorityMessageQueue)(nil)
+
+// MessageClass groups outbound messages that share a send priority. Lower
+// classes are sent before higher classes.
+type MessageClass int
+
+const (
+	// ConsensusClass contains the handshake, queries, and votes.
+	ConsensusClass MessageClass = iota
+	// BootstrapClass contains the messages used while state syncing and
+	// bootstrapping.
+	BootstrapClass
+	// AppClass contains VM defined messages.
+	AppClass
+
+	numMessageClasses
+)
+
+var messageClasses = map[message.Op]MessageClass{
+	// State sync:
+	message.GetStateSummaryFrontierOp: BootstrapClass,
+	message.StateSummaryFrontierOp:    BootstrapClass,
+	message.GetAcceptedStateSummaryOp: BootstrapClass,
+	message.AcceptedStateSummaryOp:    BootstrapClass,
+	// Bootstrapping:
+	message.GetAcceptedFrontierOp:  BootstrapClass,
+	message.AcceptedFrontierOp:     BootstrapClass,
+	message.GetAcceptedOp:          BootstrapClass,
+	message.AcceptedOp:             BootstrapClass,
+	message.GetAncestorsOp:         BootstrapClass,
+	message.GetAncestorsAtHeightOp: BootstrapClass,
+	message.AncestorsOp:            BootstrapClass,
+	// Application:
+	message.AppRequestOp:  AppClass,
+	message.AppResponseOp: AppClass,
+	message.AppGossipOp:   AppClass,
+}
+
+// ClassOf returns the class of messages with [op]. Ops that aren't explicitly
+// classified are treated as consensus messages.
+func ClassOf(op message.Op) MessageClass {
+	return messageClasses[op]
+}
+
+func (c MessageClass) String() string {
+	switch c {
+	case ConsensusClass:
+		return "consensus"
+	case BootstrapClass:
+		return "bootstrap"
+	case AppClass:
+		return "app"
+	default:
+		return "unknown"
+	}
+}
+
+// MessageClassConfig limits the messages of a single class that can be queued
+// to be sent to a peer.
+type MessageClassConfig struct {
+	// MaxBytes is the maximum number of bytes of messages of this class that
+	// can be queued. If 0, the number of bytes is only limited by the outbound
+	// message throttler.
+	MaxBytes uint64 `json:"maxBytes"`
+
+	// If true, the oldest queued messages are dropped to make room for a new
+	// message once MaxBytes is reached. Otherwise, the new message is dropped.
+	DropOldest bool `json:"dropOldest"`
+}
+
+type PriorityMessageQueueConfig struct {
+	Consensus MessageClassConfig `json:"consensus"`
+	Bootstrap MessageClassConfig `json:"bootstrap"`
+	App       MessageClassConfig `json:"app"`
+}
+
+func (c *PriorityMessageQueueConfig) class(class MessageClass) MessageClassConfig {
+	switch class {
+	case BootstrapClass:
+		return c.Bootstrap
+	case AppClass:
+		return c.App
+	default:
+		return c.Consensus
+	}
+}
+
+type priorityMessageQueue struct {
+	config  PriorityMessageQueueConfig
+	metrics *Metrics
+	// [id] of the peer we're sending messages to
+	id                   ids.NodeID
+	log                  logging.Logger
+	outboundMsgThrottler throttling.OutboundMsgThrottler
+
+	// Signalled when a message is added to the queue and when Close() is
+	// called.
+	cond *sync.Cond
+
+	// closed flags whether the send queue has been closed.
+	// [cond.L] must be held while accessing [closed].
+	closed bool
+
+	// queue of the messages of each class
+	// [cond.L] must be held while accessing [queues] and [queuedBytes].
+	queues      [numMessageClasses]buffer.Deque[message.OutboundMessage]
+	queuedBytes [numMessageClasses]uint64
+}
+
+// NewPriorityMessageQueue returns a MessageQueue that pops consensus messages
+// before bootstrapping messages and bootstrapping messages before app
+// messages. Messages of the same class are popped in the order they were
+// pushed.
+func NewPriorityMessageQueue(
+	config PriorityMessageQueueConfig,
+	metrics *Metrics,
+	id ids.NodeID,
+	log logging.Logger,
+	outboundMsgThrottler throttling.OutboundMsgThrottler,
+) MessageQueue {
+	q := &priorityMessageQueue{
+		config:               config,
+		metrics:              metrics,
+		id:                   id,
+		log:                  log,
+		outboundMsgThrottler: outboundMsgThrottler,
+		cond:                 sync.NewCond(&sync.Mutex{}),
+	}
+	for i := range q.queues {
+		q.queues[i] = buffer.NewUnboundedDeque[message.OutboundMessage](initialQueueSize)
+	}
+	return q
+}
+
+func (q *priorityMessageQueue) Push(ctx context.Context, msg message.OutboundMessage) bool {
+	if err := ctx.Err(); err != nil {
+		q.log.Debug(
+			"dropping outgoing message",
+			zap.Stringer("messageOp", msg.Op()),
+			zap.Stringer("nodeID", q.id),
+			zap.Error(err),
+		)
+		q.metrics.SendFailed(msg)
+		return false
+	}
+
+	// Acquire space on the outbound message queue, or drop [msg] if we can't.
+	if !q.outboundMsgThrottler.Acquire(msg, q.id) {
+		q.log.Debug(
+			"dropping outgoing message",
+			zap.String("reason", "rate-limiting"),
+			zap.Stringer("messageOp", msg.Op()),
+			zap.Stringer("nodeID", q.id),
+		)
+		q.metrics.SendFailed(msg)
+		return false
+	}
+
+	// Invariant: must call q.outboundMsgThrottler.Release(msg, q.id) when [msg]
+	// is popped or dropped or, if this queue closes before [msg] is popped,
+	// when this queue closes.
+
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	if q.closed {
+		q.log.Debug(
+			"dropping outgoing message",
+			zap.String("reason", "closed queue"),
+			zap.Stringer("messageOp", msg.Op()),
+			zap.Stringer("nodeID", q.id),
+		)
+		q.outboundMsgThrottler.Release(msg, q.id)
+		q.metrics.SendFailed(msg)
+		return false
+	}
+
+	var (
+		class       = ClassOf(msg.Op())
+		classConfig = q.config.class(class)
+		msgLen      = uint64(len(msg.Bytes()))
+	)
+	if classConfig.MaxBytes != 0 {
+		if msgLen > classConfig.MaxBytes || (!classConfig.DropOldest && q.queuedBytes[class]+msgLen > classConfig.MaxBytes) {
+			q.log.Debug(
+				"dropping outgoing message",
+				zap.String("reason", "queue full"),
+				zap.Stringer("class", class),
+				zap.Stringer("messageOp", msg.Op()),
+				zap.Stringer("nodeID", q.id),
+			)
+			q.outboundMsgThrottler.Release(msg, q.id)
+			q.drop(class, msg)
+			return false
+		}
+
+		for q.queuedBytes[class]+msgLen > classConfig.MaxBytes {
+			oldMsg := q.popClass(class)
+			q.log.Debug(
+				"dropping outgoing message",
+				zap.String("reason", "queue full"),
+				zap.Stringer("class", class),
+				zap.Stringer("messageOp", oldMsg.Op()),
+				zap.Stringer("nodeID", q.id),
+			)
+			q.drop(class, oldMsg)
+		}
+	}
+
+	q.queues[class].PushRight(msg)
+	q.queuedBytes[class] += msgLen
+	q.metrics.QueuedBytes.WithLabelValues(class.String()).Add(float64(msgLen))
+	q.cond.Signal()
+	return true
+}
+
+func (q *priorityMessageQueue) Pop() (message.OutboundMessage, bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	for {
+		if q.closed {
+			return nil, false
+		}
+		if msg, ok := q.pop(); ok {
+			return msg, true
+		}
+		// Wait until there is a message
+		q.cond.Wait()
+	}
+}
+
+func (q *priorityMessageQueue) PopNow() (message.OutboundMessage, bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	if q.closed {
+		return nil, false
+	}
+	return q.pop()
+}
+
+// pop returns the oldest message of the highest priority non-empty class.
+//
+// Assumes [cond.L] is held.
+func (q *priorityMessageQueue) pop() (message.OutboundMessage, bool) {
+	for class := range q.queues {
+		if q.queues[class].Len() > 0 {
+			return q.popClass(MessageClass(class)), true
+		}
+	}
+	return nil, false
+}
+
+// popClass removes the oldest message of [class] and releases it from the
+// outbound message throttler.
+//
+// Assumes [cond.L] is held and that there is a message of [class].
+func (q *priorityMessageQueue) popClass(class MessageClass) message.OutboundMessage {
+	msg, _ := q.queues[class].PopLeft()
+	msgLen := uint64(len(msg.Bytes()))
+	q.queuedBytes[class] -= msgLen
+	q.metrics.QueuedBytes.WithLabelValues(class.String()).Sub(float64(msgLen))
+
+	q.outboundMsgThrottler.Release(msg, q.id)
+	return msg
+}
+
+// drop reports that [msg] of [class] was dropped due to the queue limits.
+func (q *priorityMessageQueue) drop(class MessageClass, msg message.OutboundMessage) {
+	q.metrics.QueueDropped.WithLabelValues(class.String()).Inc()
+	q.metrics.SendFailed(msg)
+}
+
+func (q *priorityMessageQueue) Close() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	if q.closed {
+		return
+	}
+
+	q.closed = true
+
+	for {
+		msg, ok := q.pop()
+		if !ok {
+			break
+		}
+		q.metrics.SendFailed(msg)
+	}
+
+	q.cond.Broadcast()
+}
@@ -0,0 +1,127 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/message"
+	"github.com/luxdefi/node/network/throttling"
+	"github.com/luxdefi/node/utils/logging"
+)
+
+func newPriorityMessageQueue(t *testing.T, config PriorityMessageQueueConfig) MessageQueue {
+	metrics, err := NewMetrics(logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	return NewPriorityMessageQueue(
+		config,
+		metrics,
+		ids.GenerateTestNodeID(),
+		logging.NoLog{},
+		throttling.NewNoOutboundThrottler(),
+	)
+}
+
+func TestPriorityMessageQueueOrder(t *testing.T) {
+	require := require.New(t)
+
+	q := newPriorityMessageQueue(t, PriorityMessageQueueConfig{})
+
+	mc := newMessageCreator(t)
+	chainID := ids.GenerateTestID()
+
+	gossip, err := mc.AppGossip(chainID, []byte{1})
+	require.NoError(err)
+	ancestors, err := mc.Ancestors(chainID, 1, [][]byte{{2}})
+	require.NoError(err)
+	chits0, err := mc.Chits(chainID, 2, ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID())
+	require.NoError(err)
+	chits1, err := mc.Chits(chainID, 3, ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID())
+	require.NoError(err)
+
+	for _, msg := range []message.OutboundMessage{gossip, ancestors, chits0, chits1} {
+		require.True(q.Push(context.Background(), msg))
+	}
+
+	// Consensus messages are popped first, in the order they were pushed,
+	// followed by bootstrapping messages and then app messages.
+	for _, expected := range []message.OutboundMessage{chits0, chits1, ancestors, gossip} {
+		msg, ok := q.PopNow()
+		require.True(ok)
+		require.Equal(expected, msg)
+	}
+
+	_, ok := q.PopNow()
+	require.False(ok)
+
+	q.Close()
+	require.False(q.Push(context.Background(), chits0))
+	_, ok = q.Pop()
+	require.False(ok)
+}
+
+func TestPriorityMessageQueueLimits(t *testing.T) {
+	mc := newMessageCreator(t)
+	chainID := ids.GenerateTestID()
+
+	gossip0, err := mc.AppGossip(chainID, []byte{0})
+	require.NoError(t, err)
+	gossip1, err := mc.AppGossip(chainID, []byte{1})
+	require.NoError(t, err)
+	msgLen := uint64(len(gossip0.Bytes()))
+
+	tests := []struct {
+		name       string
+		dropOldest bool
+		expected   message.OutboundMessage
+	}{
+		{
+			name:       "drop newest",
+			dropOldest: false,
+			expected:   gossip0,
+		},
+		{
+			name:       "drop oldest",
+			dropOldest: true,
+			expected:   gossip1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			q := newPriorityMessageQueue(t, PriorityMessageQueueConfig{
+				App: MessageClassConfig{
+					MaxBytes:   msgLen,
+					DropOldest: test.dropOldest,
+				},
+			})
+			require.True(q.Push(context.Background(), gossip0))
+			require.Equal(test.dropOldest, q.Push(context.Background(), gossip1))
+
+			msg, ok := q.PopNow()
+			require.True(ok)
+			require.Equal(test.expected, msg)
+
+			_, ok = q.PopNow()
+			require.False(ok)
+		})
+	}
+
+	t.Run("message larger than limit", func(t *testing.T) {
+		q := newPriorityMessageQueue(t, PriorityMessageQueueConfig{
+			App: MessageClassConfig{
+				MaxBytes:   msgLen - 1,
+				DropOldest: true,
+			},
+		})
+		require.False(t, q.Push(context.Background(), gossip0))
+	})
+}
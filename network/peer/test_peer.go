@@ -111,6 +111,7 @@ func StartTestPeer(
 			MessageCreator:       mc,
 			Log:                  logging.NoLog{},
 			InboundMsgThrottler:  throttling.NewNoInboundThrottler(),
+			OutboundMsgThrottler: throttling.NewNoOutboundThrottler(),
 			Network:              TestNetwork,
 			Router:               router,
 			VersionCompatibility: version.GetCompatibility(networkID),
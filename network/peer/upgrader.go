@@ -26,6 +26,15 @@ type Upgrader interface {
 	Upgrade(net.Conn) (ids.NodeID, net.Conn, *staking.Certificate, error)
 }
 
+// TLSConn is a connection whose TLS handshake was already performed by its
+// transport, such as a QUIC connection. Upgrading a TLSConn only verifies the
+// peer's certificate.
+type TLSConn interface {
+	net.Conn
+
+	ConnectionState() tls.ConnectionState
+}
+
 type tlsServerUpgrader struct {
 	config       *tls.Config
 	invalidCerts prometheus.Counter
@@ -39,6 +48,9 @@ func NewTLSServerUpgrader(config *tls.Config, invalidCerts prometheus.Counter) U
 }
 
 func (t *tlsServerUpgrader) Upgrade(conn net.Conn) (ids.NodeID, net.Conn, *staking.Certificate, error) {
+	if tlsConn, ok := conn.(TLSConn); ok {
+		return stateToIDAndCert(tlsConn, tlsConn.ConnectionState(), t.invalidCerts)
+	}
 	return connToIDAndCert(tls.Server(conn, t.config), t.invalidCerts)
 }
 
@@ -55,6 +67,9 @@ func NewTLSClientUpgrader(config *tls.Config, invalidCerts prometheus.Counter) U
 }
 
 func (t *tlsClientUpgrader) Upgrade(conn net.Conn) (ids.NodeID, net.Conn, *staking.Certificate, error) {
+	if tlsConn, ok := conn.(TLSConn); ok {
+		return stateToIDAndCert(tlsConn, tlsConn.ConnectionState(), t.invalidCerts)
+	}
 	return connToIDAndCert(tls.Client(conn, t.config), t.invalidCerts)
 }
 
@@ -62,8 +77,10 @@ func connToIDAndCert(conn *tls.Conn, invalidCerts prometheus.Counter) (ids.NodeI
 	if err := conn.Handshake(); err != nil {
 		return ids.EmptyNodeID, nil, nil, err
 	}
+	return stateToIDAndCert(conn, conn.ConnectionState(), invalidCerts)
+}
 
-	state := conn.ConnectionState()
+func stateToIDAndCert(conn net.Conn, state tls.ConnectionState, invalidCerts prometheus.Counter) (ids.NodeID, net.Conn, *staking.Certificate, error) {
 	if len(state.PeerCertificates) == 0 {
 		return ids.EmptyNodeID, nil, nil, errNoCert
 	}
@@ -0,0 +1,428 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quic
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	quicgo "github.com/quic-go/quic-go"
+
+	"github.com/luxdefi/node/message"
+	"github.com/luxdefi/node/network/peer"
+	"github.com/luxdefi/node/utils/buffer"
+	"github.com/luxdefi/node/utils/constants"
+	"github.com/luxdefi/node/utils/wrappers"
+)
+
+const (
+	// The maximum size of a frame is the maximum size of a message plus its
+	// length prefix.
+	maxFrameSize = constants.DefaultMaxMessageSize + wrappers.IntLen
+
+	// The number of bytes of frames that can be queued per stream. Frames
+	// that don't fit are rejected, rather than blocking the caller, so that a
+	// backed up stream doesn't delay the frames of the other streams.
+	maxStreamQueueBytes = 2 * maxFrameSize
+
+	initialStreamQueueSize = 64
+
+	// Peers send exactly two messages, Version and PeerList, before any
+	// non-handshake message.
+	numHandshakeFrames = 2
+)
+
+var (
+	_ net.Conn           = (*Conn)(nil)
+	_ peer.TLSConn       = (*Conn)(nil)
+	_ peer.MessageWriter = (*Conn)(nil)
+
+	errFrameTooLarge = errors.New("frame too large")
+	errStreamFull    = errors.New("stream queue full")
+)
+
+// outboundFrame is a frame queued to be written to a stream.
+type outboundFrame struct {
+	bytes []byte
+	// Called once the frame has been written or dropped. May be nil.
+	onWritten func()
+}
+
+// outboundStream holds the frames queued to be written to a stream.
+type outboundStream struct {
+	lock sync.Mutex
+	// Set once the stream is no longer written to.
+	// [lock] must be held while accessing [closed].
+	closed bool
+	// [lock] must be held while accessing [frames] and [bytes].
+	frames buffer.Deque[outboundFrame]
+	bytes  int
+	// Written to when a frame is queued.
+	ready chan struct{}
+}
+
+// Conn adapts a QUIC connection into a net.Conn.
+//
+// Every message is sent as a frame over the stream returned by StreamOf. The
+// frames received over all the streams are merged into a single byte stream
+// that can be read using Read.
+type Conn struct {
+	conn quicgo.Connection
+
+	// Closed when the connection is closed.
+	ctx       context.Context
+	cancel    context.CancelFunc
+	closeOnce sync.Once
+
+	// Inbound frames in the order they should be read.
+	inbound chan []byte
+	// Closed once the handshake frames have been received. Only the control
+	// stream is read before this happens to ensure that the handshake isn't
+	// overtaken by other messages.
+	handshakeDone chan struct{}
+	// The unread remainder of the current inbound frame. Only accessed by
+	// Read.
+	current []byte
+
+	outbound [numStreams]*outboundStream
+
+	deadlineLock  sync.RWMutex
+	readDeadline  time.Time
+	writeDeadline time.Time
+}
+
+func newConn(conn quicgo.Connection) *Conn {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &Conn{
+		conn:          conn,
+		ctx:           ctx,
+		cancel:        cancel,
+		inbound:       make(chan []byte),
+		handshakeDone: make(chan struct{}),
+	}
+	for i := range c.outbound {
+		c.outbound[i] = newOutboundStream()
+		go c.writeFrames(Stream(i))
+	}
+	go c.acceptStreams()
+	return c
+}
+
+// ConnectionState returns the state of the TLS handshake that was performed
+// while establishing the QUIC connection.
+func (c *Conn) ConnectionState() tls.ConnectionState {
+	return c.conn.ConnectionState().TLS
+}
+
+func (c *Conn) Read(b []byte) (int, error) {
+	if len(c.current) == 0 {
+		c.deadlineLock.RLock()
+		deadline := c.readDeadline
+		c.deadlineLock.RUnlock()
+
+		timeout, stop := deadlineChan(deadline)
+		defer stop()
+
+		select {
+		case frame := <-c.inbound:
+			c.current = frame
+		case <-timeout:
+			return 0, os.ErrDeadlineExceeded
+		case <-c.ctx.Done():
+			return 0, io.EOF
+		}
+	}
+
+	n := copy(b, c.current)
+	c.current = c.current[n:]
+	return n, nil
+}
+
+// Write sends [b] as a single frame over the control stream.
+func (c *Conn) Write(b []byte) (int, error) {
+	frame := make([]byte, len(b))
+	copy(frame, b)
+	if err := c.enqueue(ControlStream, frame, nil); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// WriteMessage queues [msg] to be sent as a single frame over the stream that
+// [op] is mapped to. If the stream's queue is full, an error is returned
+// immediately.
+func (c *Conn) WriteMessage(op message.Op, msg net.Buffers, onWritten func()) error {
+	var frame []byte
+	for _, b := range msg {
+		frame = append(frame, b...)
+	}
+	return c.enqueue(StreamOf(op), frame, onWritten)
+}
+
+// enqueue queues [frame] to be written to [stream]. If nil is returned,
+// [onWritten], if non-nil, is called once the frame has been written or
+// dropped.
+func (c *Conn) enqueue(stream Stream, frame []byte, onWritten func()) error {
+	if len(frame) > maxFrameSize {
+		return fmt.Errorf("%w: %d > %d", errFrameTooLarge, len(frame), maxFrameSize)
+	}
+
+	outbound := c.outbound[stream]
+	outbound.lock.Lock()
+	defer outbound.lock.Unlock()
+
+	if outbound.closed {
+		return net.ErrClosed
+	}
+	// A frame is always accepted by an empty queue so that frames of the
+	// maximum size can be sent.
+	if outbound.bytes > 0 && outbound.bytes+len(frame) > maxStreamQueueBytes {
+		return fmt.Errorf("%w: %d", errStreamFull, stream)
+	}
+
+	outbound.frames.PushRight(outboundFrame{
+		bytes:     frame,
+		onWritten: onWritten,
+	})
+	outbound.bytes += len(frame)
+	select {
+	case outbound.ready <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (c *Conn) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		c.cancel()
+		err = c.conn.CloseWithError(0, "")
+	})
+	return err
+}
+
+func (c *Conn) LocalAddr() net.Addr {
+	return c.conn.LocalAddr()
+}
+
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+func (c *Conn) SetDeadline(t time.Time) error {
+	c.deadlineLock.Lock()
+	defer c.deadlineLock.Unlock()
+
+	c.readDeadline = t
+	c.writeDeadline = t
+	return nil
+}
+
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	c.deadlineLock.Lock()
+	defer c.deadlineLock.Unlock()
+
+	c.readDeadline = t
+	return nil
+}
+
+func (c *Conn) SetWriteDeadline(t time.Time) error {
+	c.deadlineLock.Lock()
+	defer c.deadlineLock.Unlock()
+
+	c.writeDeadline = t
+	return nil
+}
+
+// writeFrames opens [stream] and writes the frames queued for it until the
+// connection is closed.
+func (c *Conn) writeFrames(stream Stream) {
+	outbound := c.outbound[stream]
+	defer func() {
+		_ = c.Close()
+		outbound.close()
+	}()
+
+	var (
+		sendStream quicgo.SendStream
+		header     [wrappers.IntLen]byte
+	)
+	for {
+		frame, ok := outbound.peek()
+		if !ok {
+			select {
+			case <-outbound.ready:
+				continue
+			case <-c.ctx.Done():
+				return
+			}
+		}
+
+		// Streams are opened lazily to avoid consuming the peer's stream
+		// limit for streams that are never used.
+		if sendStream == nil {
+			var err error
+			sendStream, err = c.conn.OpenUniStreamSync(c.ctx)
+			if err != nil {
+				return
+			}
+			if _, err := sendStream.Write([]byte{byte(stream)}); err != nil {
+				return
+			}
+		}
+
+		c.deadlineLock.RLock()
+		deadline := c.writeDeadline
+		c.deadlineLock.RUnlock()
+		if err := sendStream.SetWriteDeadline(deadline); err != nil {
+			return
+		}
+
+		binary.BigEndian.PutUint32(header[:], uint32(len(frame.bytes)))
+		if _, err := sendStream.Write(header[:]); err != nil {
+			return
+		}
+		if _, err := sendStream.Write(frame.bytes); err != nil {
+			return
+		}
+		outbound.pop()
+	}
+}
+
+func newOutboundStream() *outboundStream {
+	return &outboundStream{
+		frames: buffer.NewUnboundedDeque[outboundFrame](initialStreamQueueSize),
+		ready:  make(chan struct{}, 1),
+	}
+}
+
+// peek returns the oldest queued frame without removing it, so that its bytes
+// are counted against the queue until it has been written.
+func (s *outboundStream) peek() (outboundFrame, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.frames.PeekLeft()
+}
+
+// pop removes the oldest queued frame once it has been written.
+func (s *outboundStream) pop() {
+	s.lock.Lock()
+	frame, _ := s.frames.PopLeft()
+	s.bytes -= len(frame.bytes)
+	s.lock.Unlock()
+
+	if frame.onWritten != nil {
+		frame.onWritten()
+	}
+}
+
+// close drops the queued frames and rejects any further frames.
+func (s *outboundStream) close() {
+	s.lock.Lock()
+	s.closed = true
+	frames := s.frames
+	s.frames = buffer.NewUnboundedDeque[outboundFrame](0)
+	s.bytes = 0
+	s.lock.Unlock()
+
+	for frames.Len() > 0 {
+		frame, _ := frames.PopLeft()
+		if frame.onWritten != nil {
+			frame.onWritten()
+		}
+	}
+}
+
+// acceptStreams accepts the streams opened by the peer and starts reading
+// frames from them.
+func (c *Conn) acceptStreams() {
+	var accepted [numStreams]bool
+	for {
+		receiveStream, err := c.conn.AcceptUniStream(c.ctx)
+		if err != nil {
+			_ = c.Close()
+			return
+		}
+
+		var streamID [1]byte
+		if _, err := io.ReadFull(receiveStream, streamID[:]); err != nil {
+			_ = c.Close()
+			return
+		}
+		stream := Stream(streamID[0])
+		if stream >= numStreams || accepted[stream] {
+			_ = c.Close()
+			return
+		}
+		accepted[stream] = true
+
+		go c.readFrames(stream, receiveStream)
+	}
+}
+
+// readFrames reads frames from [receiveStream] until the connection is closed.
+func (c *Conn) readFrames(stream Stream, receiveStream quicgo.ReceiveStream) {
+	defer c.Close()
+
+	if stream != ControlStream {
+		select {
+		case <-c.handshakeDone:
+		case <-c.ctx.Done():
+			return
+		}
+	}
+
+	var (
+		header    [wrappers.IntLen]byte
+		numFrames int
+	)
+	for {
+		if _, err := io.ReadFull(receiveStream, header[:]); err != nil {
+			return
+		}
+		frameLen := binary.BigEndian.Uint32(header[:])
+		if frameLen > maxFrameSize {
+			return
+		}
+
+		frame := make([]byte, frameLen)
+		if _, err := io.ReadFull(receiveStream, frame); err != nil {
+			return
+		}
+
+		select {
+		case c.inbound <- frame:
+		case <-c.ctx.Done():
+			return
+		}
+
+		if stream == ControlStream && numFrames < numHandshakeFrames {
+			numFrames++
+			if numFrames == numHandshakeFrames {
+				close(c.handshakeDone)
+			}
+		}
+	}
+}
+
+// deadlineChan returns a channel that is written to once [deadline] has
+// passed. If [deadline] is zero, the returned channel is never written to.
+func deadlineChan(deadline time.Time) (<-chan time.Time, func()) {
+	if deadline.IsZero() {
+		return nil, func() {}
+	}
+	timer := time.NewTimer(time.Until(deadline))
+	return timer.C, func() {
+		timer.Stop()
+	}
+}
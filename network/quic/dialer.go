@@ -0,0 +1,115 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quic
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"sync"
+	"time"
+
+	quicgo "github.com/quic-go/quic-go"
+	"go.uber.org/zap"
+
+	"github.com/luxdefi/node/network/dialer"
+	"github.com/luxdefi/node/utils/ips"
+	"github.com/luxdefi/node/utils/linkedhashmap"
+	"github.com/luxdefi/node/utils/logging"
+	"github.com/luxdefi/node/utils/timer/mockable"
+)
+
+// After failing to connect to an IP over QUIC, connections to that IP are
+// only attempted over TCP for this long.
+const fallbackDuration = 10 * time.Minute
+
+var _ dialer.Dialer = (*quicDialer)(nil)
+
+type quicDialer struct {
+	log       logging.Logger
+	tlsConfig *tls.Config
+	timeout   time.Duration
+	fallback  dialer.Dialer
+	clock     mockable.Clock
+
+	lock sync.Mutex
+	// IP -> time until which QUIC shouldn't be attempted. Every entry is
+	// created with the same [fallbackDuration], so the oldest entry expires
+	// first.
+	fallbackUntil linkedhashmap.LinkedHashmap[string, time.Time]
+}
+
+// NewDialer returns a Dialer that first attempts to connect over QUIC. If the
+// QUIC connection can't be established within [timeout], the connection is
+// established by [fallback] instead.
+func NewDialer(
+	log logging.Logger,
+	tlsConfig *tls.Config,
+	timeout time.Duration,
+	fallback dialer.Dialer,
+) dialer.Dialer {
+	return &quicDialer{
+		log:           log,
+		tlsConfig:     TLSConfig(tlsConfig),
+		timeout:       timeout,
+		fallback:      fallback,
+		fallbackUntil: linkedhashmap.New[string, time.Time](),
+	}
+}
+
+func (d *quicDialer) Dial(ctx context.Context, ip ips.IPPort) (net.Conn, error) {
+	addr := ip.String()
+	if !d.shouldAttempt(addr) {
+		return d.fallback.Dial(ctx, ip)
+	}
+
+	d.log.Verbo("dialing",
+		zap.String("transport", "quic"),
+		zap.Stringer("ip", ip),
+	)
+	dialCtx, cancel := context.WithTimeout(ctx, d.timeout)
+	conn, err := quicgo.DialAddr(dialCtx, addr, d.tlsConfig, quicConfig)
+	cancel()
+	if err == nil {
+		return newConn(conn), nil
+	}
+	if ctx.Err() != nil {
+		return nil, err
+	}
+
+	d.log.Debug("falling back to tcp",
+		zap.Stringer("ip", ip),
+		zap.Error(err),
+	)
+
+	d.lock.Lock()
+	d.fallbackUntil.Put(addr, d.clock.Time().Add(fallbackDuration))
+	d.lock.Unlock()
+
+	return d.fallback.Dial(ctx, ip)
+}
+
+func (d *quicDialer) shouldAttempt(addr string) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.pruneExpired()
+	_, ok := d.fallbackUntil.Get(addr)
+	return !ok
+}
+
+// pruneExpired removes the IPs that QUIC should be attempted with again, so
+// that IPs which are never dialed again aren't tracked forever.
+//
+// Assumes [lock] is held.
+func (d *quicDialer) pruneExpired() {
+	now := d.clock.Time()
+	for {
+		addr, until, ok := d.fallbackUntil.Oldest()
+		if !ok || now.Before(until) {
+			return
+		}
+		d.fallbackUntil.Delete(addr)
+	}
+}
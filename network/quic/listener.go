@@ -0,0 +1,157 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quic
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	quicgo "github.com/quic-go/quic-go"
+
+	"github.com/luxdefi/node/utils"
+)
+
+// ALPN is the application protocol negotiated during the QUIC handshake.
+const ALPN = "lux-p2p/1"
+
+var (
+	_ net.Listener = (*listener)(nil)
+	_ net.Listener = (*dualListener)(nil)
+
+	quicConfig = &quicgo.Config{
+		MaxIdleTimeout:  time.Minute,
+		KeepAlivePeriod: 15 * time.Second,
+		// Bidirectional streams are never used.
+		MaxIncomingStreams:    -1,
+		MaxIncomingUniStreams: int64(numStreams),
+	}
+)
+
+type listener struct {
+	listener *quicgo.Listener
+}
+
+// Listen returns a listener that accepts QUIC connections on the UDP address
+// [addr]. [tlsConfig] must require client certificates so that the peer's
+// identity can be verified.
+func Listen(addr string, tlsConfig *tls.Config) (net.Listener, error) {
+	l, err := quicgo.ListenAddr(addr, TLSConfig(tlsConfig), quicConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &listener{listener: l}, nil
+}
+
+// TLSConfig returns a copy of [tlsConfig] that negotiates ALPN.
+func TLSConfig(tlsConfig *tls.Config) *tls.Config {
+	tlsConfig = tlsConfig.Clone()
+	tlsConfig.NextProtos = []string{ALPN}
+	return tlsConfig
+}
+
+// Accept returns the next QUIC connection. The TLS handshake has been
+// completed by the time the connection is returned.
+func (l *listener) Accept() (net.Conn, error) {
+	conn, err := l.listener.Accept(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return newConn(conn), nil
+}
+
+func (l *listener) Close() error {
+	return l.listener.Close()
+}
+
+func (l *listener) Addr() net.Addr {
+	return l.listener.Addr()
+}
+
+type acceptResult struct {
+	conn net.Conn
+	err  error
+}
+
+type dualListener struct {
+	primary   net.Listener
+	secondary net.Listener
+
+	results   chan acceptResult
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// NewDualListener returns a listener that accepts connections from both
+// [primary] and [secondary]. The returned listener reports the address of
+// [primary]. If one of the listeners fails permanently, its error is returned
+// once and connections continue to be accepted from the other.
+func NewDualListener(primary, secondary net.Listener) net.Listener {
+	l := &dualListener{
+		primary:   primary,
+		secondary: secondary,
+		results:   make(chan acceptResult),
+		closed:    make(chan struct{}),
+	}
+	go l.accept(primary)
+	go l.accept(secondary)
+	return l
+}
+
+func (l *dualListener) accept(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		select {
+		case l.results <- acceptResult{conn: conn, err: err}:
+		case <-l.closed:
+			if conn != nil {
+				_ = conn.Close()
+			}
+			return
+		}
+
+		// A listener that failed permanently will never return another
+		// connection, so polling it would only spin.
+		if err != nil && !isTemporary(err) {
+			return
+		}
+	}
+}
+
+// isTemporary returns true if [err], returned by Accept, doesn't prevent the
+// listener from accepting future connections.
+func isTemporary(err error) bool {
+	var netErr net.Error
+	// Temporary is deprecated, but, as in net/http, it is the only way to tell
+	// the transient errors returned by Accept apart.
+	return errors.As(err, &netErr) && netErr.Temporary() //nolint:staticcheck
+}
+
+func (l *dualListener) Accept() (net.Conn, error) {
+	select {
+	case result := <-l.results:
+		return result.conn, result.err
+	case <-l.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *dualListener) Close() error {
+	var err error
+	l.closeOnce.Do(func() {
+		close(l.closed)
+		err = utils.Err(
+			l.primary.Close(),
+			l.secondary.Close(),
+		)
+	})
+	return err
+}
+
+func (l *dualListener) Addr() net.Addr {
+	return l.primary.Addr()
+}
@@ -0,0 +1,298 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quic
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/message"
+	"github.com/luxdefi/node/network/dialer"
+	"github.com/luxdefi/node/network/peer"
+	"github.com/luxdefi/node/staking"
+	"github.com/luxdefi/node/utils/ips"
+	"github.com/luxdefi/node/utils/logging"
+)
+
+var _ dialer.Dialer = (*testDialer)(nil)
+
+type testDialer struct {
+	dialed []ips.IPPort
+}
+
+func (d *testDialer) Dial(_ context.Context, ip ips.IPPort) (net.Conn, error) {
+	d.dialed = append(d.dialed, ip)
+	conn, _ := net.Pipe()
+	return conn, nil
+}
+
+func newTLSConfig(t *testing.T) (ids.NodeID, *tls.Config) {
+	tlsCert, err := staking.NewTLSCert()
+	require.NoError(t, err)
+
+	nodeID := ids.NodeIDFromCert(staking.CertificateFromX509(tlsCert.Leaf))
+	return nodeID, peer.TLSConfig(*tlsCert, nil)
+}
+
+func TestStreamOf(t *testing.T) {
+	tests := []struct {
+		op     message.Op
+		stream Stream
+	}{
+		{op: message.VersionOp, stream: ControlStream},
+		{op: message.PingOp, stream: ControlStream},
+		{op: message.ChitsOp, stream: ConsensusStream},
+		{op: message.PushQueryOp, stream: ConsensusStream},
+		{op: message.AncestorsOp, stream: BulkStream},
+		{op: message.AppGossipOp, stream: AppStream},
+	}
+	for _, test := range tests {
+		t.Run(test.op.String(), func(t *testing.T) {
+			require.Equal(t, test.stream, StreamOf(test.op))
+		})
+	}
+}
+
+func TestConn(t *testing.T) {
+	require := require.New(t)
+
+	serverNodeID, serverTLSConfig := newTLSConfig(t)
+	clientNodeID, clientTLSConfig := newTLSConfig(t)
+
+	listener, err := Listen("127.0.0.1:0", serverTLSConfig)
+	require.NoError(err)
+	defer listener.Close()
+
+	serverIP, err := ips.ToIPPort(listener.Addr().String())
+	require.NoError(err)
+
+	type acceptResult struct {
+		conn net.Conn
+		err  error
+	}
+	accepted := make(chan acceptResult, 1)
+	go func() {
+		conn, err := listener.Accept()
+		accepted <- acceptResult{conn: conn, err: err}
+	}()
+
+	fallback := &testDialer{}
+	d := NewDialer(logging.NoLog{}, clientTLSConfig, 5*time.Second, fallback)
+	clientConn, err := d.Dial(context.Background(), serverIP)
+	require.NoError(err)
+	require.Empty(fallback.dialed)
+	defer clientConn.Close()
+
+	result := <-accepted
+	require.NoError(result.err)
+	serverConn := result.conn
+	defer serverConn.Close()
+
+	// The staking certificates should be verified without performing another
+	// TLS handshake.
+	invalidCerts := prometheus.NewCounter(prometheus.CounterOpts{})
+	nodeID, clientConn, _, err := peer.NewTLSClientUpgrader(clientTLSConfig, invalidCerts).Upgrade(clientConn)
+	require.NoError(err)
+	require.Equal(serverNodeID, nodeID)
+
+	nodeID, serverConn, _, err = peer.NewTLSServerUpgrader(serverTLSConfig, invalidCerts).Upgrade(serverConn)
+	require.NoError(err)
+	require.Equal(clientNodeID, nodeID)
+
+	writer, ok := clientConn.(peer.MessageWriter)
+	require.True(ok)
+
+	// The handshake messages must be read before any other messages.
+	messages := []struct {
+		op    message.Op
+		bytes []byte
+	}{
+		{op: message.VersionOp, bytes: []byte("version")},
+		{op: message.AppGossipOp, bytes: []byte("gossip")},
+		{op: message.PeerListOp, bytes: []byte("peerlist")},
+	}
+	for _, msg := range messages {
+		require.NoError(writer.WriteMessage(msg.op, net.Buffers{msg.bytes}, nil))
+	}
+
+	require.NoError(serverConn.SetReadDeadline(time.Now().Add(5 * time.Second)))
+	for _, expected := range [][]byte{
+		[]byte("version"),
+		[]byte("peerlist"),
+		[]byte("gossip"),
+	} {
+		bytes := make([]byte, len(expected))
+		_, err := io.ReadFull(serverConn, bytes)
+		require.NoError(err)
+		require.Equal(expected, bytes)
+	}
+}
+
+func TestDialerFallback(t *testing.T) {
+	require := require.New(t)
+
+	_, tlsConfig := newTLSConfig(t)
+
+	// Reserve a UDP port that nothing is listening for QUIC connections on.
+	udpConn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(err)
+	ip, err := ips.ToIPPort(udpConn.LocalAddr().String())
+	require.NoError(err)
+	defer udpConn.Close()
+
+	fallback := &testDialer{}
+	d := NewDialer(logging.NoLog{}, tlsConfig, 100*time.Millisecond, fallback)
+
+	conn, err := d.Dial(context.Background(), ip)
+	require.NoError(err)
+	require.NoError(conn.Close())
+	require.Equal([]ips.IPPort{ip}, fallback.dialed)
+
+	// QUIC shouldn't be attempted again until the fallback duration has
+	// passed.
+	qd := d.(*quicDialer)
+	require.False(qd.shouldAttempt(ip.String()))
+
+	qd.clock.Set(time.Now().Add(fallbackDuration + time.Second))
+	require.True(qd.shouldAttempt(ip.String()))
+}
+
+func TestDialerPrunesExpiredFallbacks(t *testing.T) {
+	require := require.New(t)
+
+	_, tlsConfig := newTLSConfig(t)
+	d := NewDialer(logging.NoLog{}, tlsConfig, time.Second, &testDialer{}).(*quicDialer)
+
+	now := time.Now()
+	d.clock.Set(now)
+	d.fallbackUntil.Put("127.0.0.1:1", now.Add(fallbackDuration))
+	d.clock.Set(now.Add(time.Minute))
+	d.fallbackUntil.Put("127.0.0.1:2", now.Add(time.Minute+fallbackDuration))
+
+	// Expired IPs are removed even if they aren't dialed again.
+	d.clock.Set(now.Add(fallbackDuration + time.Second))
+	require.True(d.shouldAttempt("127.0.0.1:3"))
+	require.Equal(1, d.fallbackUntil.Len())
+	require.False(d.shouldAttempt("127.0.0.1:2"))
+}
+
+func TestReadDeadline(t *testing.T) {
+	require := require.New(t)
+
+	c := &Conn{
+		inbound:       make(chan []byte),
+		handshakeDone: make(chan struct{}),
+	}
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+	defer c.cancel()
+
+	require.NoError(c.SetReadDeadline(time.Now().Add(10 * time.Millisecond)))
+	_, err := c.Read(make([]byte, 1))
+	require.ErrorIs(err, os.ErrDeadlineExceeded)
+}
+
+func TestWriteMessageStreamFull(t *testing.T) {
+	require := require.New(t)
+
+	// The streams aren't written to, so queued frames are never removed.
+	c := &Conn{}
+	for i := range c.outbound {
+		c.outbound[i] = newOutboundStream()
+	}
+
+	var numWritten int
+	onWritten := func() {
+		numWritten++
+	}
+	frame := net.Buffers{make([]byte, maxFrameSize)}
+	for i := 0; i < maxStreamQueueBytes/maxFrameSize; i++ {
+		require.NoError(c.WriteMessage(message.AncestorsOp, frame, onWritten))
+	}
+
+	// A full stream rejects frames without blocking the other streams.
+	err := c.WriteMessage(message.AncestorsOp, frame, onWritten)
+	require.ErrorIs(err, errStreamFull)
+	require.NoError(c.WriteMessage(message.ChitsOp, net.Buffers{[]byte("chits")}, onWritten))
+
+	// Frames that are dropped are reported as written.
+	for _, outbound := range c.outbound {
+		outbound.close()
+	}
+	require.Equal(maxStreamQueueBytes/maxFrameSize+1, numWritten)
+
+	err = c.WriteMessage(message.ChitsOp, net.Buffers{[]byte("chits")}, onWritten)
+	require.ErrorIs(err, net.ErrClosed)
+}
+
+type testListener struct {
+	conns chan net.Conn
+	err   error
+
+	lock     sync.Mutex
+	accepted int
+}
+
+func (l *testListener) Accept() (net.Conn, error) {
+	l.lock.Lock()
+	l.accepted++
+	l.lock.Unlock()
+
+	if l.err != nil {
+		return nil, l.err
+	}
+	return <-l.conns, nil
+}
+
+func (l *testListener) numAccepted() int {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return l.accepted
+}
+
+func (*testListener) Close() error {
+	return nil
+}
+
+func (*testListener) Addr() net.Addr {
+	return &net.TCPAddr{}
+}
+
+func TestDualListenerStopsFailedListener(t *testing.T) {
+	require := require.New(t)
+
+	primary := &testListener{
+		conns: make(chan net.Conn),
+	}
+	secondary := &testListener{
+		err: net.ErrClosed,
+	}
+	l := NewDualListener(primary, secondary)
+
+	// The permanent error is reported once.
+	_, err := l.Accept()
+	require.ErrorIs(err, net.ErrClosed)
+
+	// Connections are still accepted from the other listener.
+	conn, _ := net.Pipe()
+	go func() {
+		primary.conns <- conn
+	}()
+	acceptedConn, err := l.Accept()
+	require.NoError(err)
+	require.Equal(conn, acceptedConn)
+
+	require.Equal(1, secondary.numAccepted())
+	require.NoError(l.Close())
+}
@@ -0,0 +1,58 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quic
+
+import "github.com/luxdefi/node/message"
+
+// Stream identifies one of the unidirectional QUIC streams that messages are
+// sent over. Messages sent over the same stream are delivered in order, but
+// messages sent over different streams may be delivered in any order. This
+// prevents large, latency tolerant, messages from delaying small, latency
+// sensitive, messages.
+type Stream byte
+
+const (
+	// ControlStream carries the handshake and any messages that aren't
+	// explicitly mapped to another stream.
+	ControlStream Stream = iota
+	// ConsensusStream carries latency sensitive consensus messages.
+	ConsensusStream
+	// BulkStream carries large container transfers.
+	BulkStream
+	// AppStream carries VM defined messages.
+	AppStream
+
+	numStreams
+)
+
+var streams = map[message.Op]Stream{
+	// State sync:
+	message.GetStateSummaryFrontierOp: ConsensusStream,
+	message.StateSummaryFrontierOp:    BulkStream,
+	message.GetAcceptedStateSummaryOp: ConsensusStream,
+	message.AcceptedStateSummaryOp:    ConsensusStream,
+	// Bootstrapping:
+	message.GetAcceptedFrontierOp:  ConsensusStream,
+	message.AcceptedFrontierOp:     ConsensusStream,
+	message.GetAcceptedOp:          ConsensusStream,
+	message.AcceptedOp:             ConsensusStream,
+	message.GetAncestorsOp:         ConsensusStream,
+	message.GetAncestorsAtHeightOp: ConsensusStream,
+	message.AncestorsOp:            BulkStream,
+	// Consensus:
+	message.GetOp:       ConsensusStream,
+	message.PutOp:       ConsensusStream,
+	message.PushQueryOp: ConsensusStream,
+	message.PullQueryOp: ConsensusStream,
+	message.ChitsOp:     ConsensusStream,
+	// Application:
+	message.AppRequestOp:  AppStream,
+	message.AppResponseOp: AppStream,
+	message.AppGossipOp:   AppStream,
+}
+
+// StreamOf returns the stream that messages with [op] are sent over.
+func StreamOf(op message.Op) Stream {
+	return streams[op]
+}
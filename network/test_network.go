@@ -13,8 +13,11 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/luxdefi/node/database/memdb"
 	"github.com/luxdefi/node/ids"
 	"github.com/luxdefi/node/message"
+	"github.com/luxdefi/node/network/addrbook"
+	"github.com/luxdefi/node/network/banlist"
 	"github.com/luxdefi/node/network/dialer"
 	"github.com/luxdefi/node/network/peer"
 	"github.com/luxdefi/node/network/throttling"
@@ -164,6 +167,8 @@ func NewTestNetwork(
 			MaxReconnectDelay:     constants.DefaultNetworkMaxReconnectDelay,
 		},
 
+		AddressBookMaxAge: constants.DefaultNetworkAddressBookMaxAge,
+
 		MaxClockDifference:           constants.DefaultNetworkMaxClockDifference,
 		CompressionType:              constants.DefaultNetworkCompressionType,
 		PingFrequency:                constants.DefaultPingFrequency,
@@ -234,6 +239,16 @@ func NewTestNetwork(
 		return nil, err
 	}
 
+	networkConfig.BanList, err = banlist.New(memdb.New())
+	if err != nil {
+		return nil, err
+	}
+
+	networkConfig.AddressBook, err = addrbook.New(log, memdb.New())
+	if err != nil {
+		return nil, err
+	}
+
 	return NewNetwork(
 		&networkConfig,
 		msgCreator,
@@ -0,0 +1,56 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"net"
+
+	"go.uber.org/zap"
+
+	"github.com/luxdefi/node/utils/ips"
+	"github.com/luxdefi/node/utils/logging"
+)
+
+var _ InboundConnUpgradeThrottler = (*banAwareInboundConnUpgradeThrottler)(nil)
+
+// IPBanList reports whether connections from an IP have been banned by the
+// node operator.
+type IPBanList interface {
+	IsIPBanned(ip net.IP) bool
+}
+
+// NewBanAwareInboundConnUpgradeThrottler returns an
+// InboundConnUpgradeThrottler that never upgrades connections from IPs banned
+// in [bans]. All other connections are rate-limited by [throttler].
+//
+// Unlike the rate-limiting performed by [throttler], bans are also enforced on
+// loopback IPs.
+func NewBanAwareInboundConnUpgradeThrottler(
+	log logging.Logger,
+	throttler InboundConnUpgradeThrottler,
+	bans IPBanList,
+) InboundConnUpgradeThrottler {
+	return &banAwareInboundConnUpgradeThrottler{
+		InboundConnUpgradeThrottler: throttler,
+		log:                         log,
+		bans:                        bans,
+	}
+}
+
+type banAwareInboundConnUpgradeThrottler struct {
+	InboundConnUpgradeThrottler
+	log  logging.Logger
+	bans IPBanList
+}
+
+func (t *banAwareInboundConnUpgradeThrottler) ShouldUpgrade(ip ips.IPPort) bool {
+	if t.bans.IsIPBanned(ip.IP) {
+		t.log.Debug("not upgrading connection",
+			zap.String("reason", "ip is banned"),
+			zap.Stringer("peerIP", ip),
+		)
+		return false
+	}
+	return t.InboundConnUpgradeThrottler.ShouldUpgrade(ip)
+}
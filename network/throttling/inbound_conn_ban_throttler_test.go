@@ -0,0 +1,47 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxdefi/node/utils/logging"
+)
+
+type testIPBanList struct {
+	banned []net.IP
+}
+
+func (l *testIPBanList) IsIPBanned(ip net.IP) bool {
+	for _, banned := range l.banned {
+		if banned.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestBanAwareInboundConnUpgradeThrottler(t *testing.T) {
+	require := require.New(t)
+
+	throttler := NewBanAwareInboundConnUpgradeThrottler(
+		logging.NoLog{},
+		NewInboundConnUpgradeThrottler(
+			logging.NoLog{},
+			InboundConnUpgradeThrottlerConfig{},
+		),
+		&testIPBanList{
+			banned: []net.IP{host1.IP, loopbackIP.IP},
+		},
+	)
+
+	require.False(throttler.ShouldUpgrade(host1))
+	require.True(throttler.ShouldUpgrade(host2))
+
+	// Bans are enforced even for loopback IPs
+	require.False(throttler.ShouldUpgrade(loopbackIP))
+}
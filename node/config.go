@@ -122,6 +122,13 @@ type BootstrapConfig struct {
 	BootstrapMaxTimeGetAncestors time.Duration `json:"bootstrapMaxTimeGetAncestors"`
 
 	Bootstrappers []genesis.Bootstrapper `json:"bootstrappers"`
+
+	// DNSSeeds are hostnames whose TXT records advertise additional peers to
+	// connect to.
+	DNSSeeds []string `json:"dnsSeeds"`
+
+	// Timeout for resolving [DNSSeeds]
+	DNSSeedTimeout time.Duration `json:"dnsSeedTimeout"`
 }
 
 type DatabaseConfig struct {
@@ -49,8 +49,13 @@ import (
 	"github.com/luxdefi/node/ipcs"
 	"github.com/luxdefi/node/message"
 	"github.com/luxdefi/node/network"
+	"github.com/luxdefi/node/network/addrbook"
+	"github.com/luxdefi/node/network/allowlist"
+	"github.com/luxdefi/node/network/banlist"
 	"github.com/luxdefi/node/network/dialer"
+	"github.com/luxdefi/node/network/dnsseed"
 	"github.com/luxdefi/node/network/peer"
+	"github.com/luxdefi/node/network/quic"
 	"github.com/luxdefi/node/network/throttling"
 	"github.com/luxdefi/node/snow"
 	"github.com/luxdefi/node/snow/networking/benchlist"
@@ -96,6 +101,8 @@ var (
 
 	indexerDBPrefix  = []byte{0x00}
 	keystoreDBPrefix = []byte("keystore")
+	banlistDBPrefix  = []byte("banlist")
+	addrbookDBPrefix = []byte("addrbook")
 
 	errInvalidTLSKey = errors.New("invalid TLS key")
 	errShuttingDown  = errors.New("server shutting down")
@@ -442,6 +449,27 @@ func (n *Node) initNetworking() error {
 
 	tlsConfig := peer.TLSConfig(n.Config.StakingTLSCert, n.tlsKeyLogWriterCloser)
 
+	// Accept QUIC connections on the UDP port matching the TCP staking port.
+	var networkDialer dialer.Dialer = dialer.NewDialer(constants.NetworkType, n.Config.NetworkConfig.DialerConfig, n.Log)
+	if n.Config.NetworkConfig.QUICEnabled {
+		_, port, err := net.SplitHostPort(listener.Addr().String())
+		if err != nil {
+			return err
+		}
+		quicListenAddress := net.JoinHostPort(n.Config.ListenHost, port)
+		quicListener, err := quic.Listen(quicListenAddress, tlsConfig)
+		if err != nil {
+			return err
+		}
+		quicListener = throttling.NewThrottledListener(quicListener, n.Config.NetworkConfig.ThrottlerConfig.MaxInboundConnsPerSec)
+		listener = quic.NewDualListener(listener, quicListener)
+		networkDialer = quic.NewDialer(n.Log, tlsConfig, n.Config.NetworkConfig.QUICDialTimeout, networkDialer)
+
+		n.Log.Info("accepting QUIC connections",
+			zap.String("address", quicListenAddress),
+		)
+	}
+
 	// Configure benchlist
 	n.Config.BenchlistConfig.Validators = n.vdrs
 	n.Config.BenchlistConfig.Benchable = n.Config.ConsensusRouter
@@ -516,6 +544,38 @@ func (n *Node) initNetworking() error {
 		GossipTracker: gossipTracker,
 	})
 
+	// load the persisted peer bans
+	banList, err := banlist.New(prefixdb.New(banlistDBPrefix, n.DB))
+	if err != nil {
+		return err
+	}
+
+	// load the peer addresses known from prior runs
+	addressBook, err := addrbook.New(n.Log, prefixdb.New(addrbookDBPrefix, n.DB))
+	if err != nil {
+		return err
+	}
+
+	// restrict the peers that can be connected to, if requested
+	if n.Config.NetworkConfig.AllowListFile != "" || n.Config.NetworkConfig.AllowListSubnetIDs.Len() > 0 {
+		// The bootstrappers are always allowed so that the node is able to
+		// sync the validator sets that the allow list may depend on.
+		lists := []allowlist.List{
+			allowlist.NewValidators(n.bootstrappers, constants.PrimaryNetworkID),
+		}
+		if n.Config.NetworkConfig.AllowListFile != "" {
+			fileList, err := allowlist.NewFile(n.Config.NetworkConfig.AllowListFile)
+			if err != nil {
+				return err
+			}
+			lists = append(lists, fileList)
+		}
+		for subnetID := range n.Config.NetworkConfig.AllowListSubnetIDs {
+			lists = append(lists, allowlist.NewValidators(n.vdrs, subnetID))
+		}
+		n.Config.NetworkConfig.AllowList = allowlist.NewUnion(lists...)
+	}
+
 	// add node configs to network config
 	n.Config.NetworkConfig.Namespace = n.networkNamespace
 	n.Config.NetworkConfig.MyNodeID = n.ID
@@ -532,6 +592,8 @@ func (n *Node) initNetworking() error {
 	n.Config.NetworkConfig.CPUTargeter = n.cpuTargeter
 	n.Config.NetworkConfig.DiskTargeter = n.diskTargeter
 	n.Config.NetworkConfig.GossipTracker = gossipTracker
+	n.Config.NetworkConfig.BanList = banList
+	n.Config.NetworkConfig.AddressBook = addressBook
 
 	n.Net, err = network.NewNetwork(
 		&n.Config.NetworkConfig,
@@ -539,7 +601,7 @@ func (n *Node) initNetworking() error {
 		n.MetricsRegisterer,
 		n.Log,
 		listener,
-		dialer.NewDialer(constants.NetworkType, n.Config.NetworkConfig.DialerConfig, n.Log),
+		networkDialer,
 		consensusRouter,
 	)
 
@@ -614,6 +676,22 @@ func (n *Node) Dispatch() error {
 		n.Net.ManuallyTrack(bootstrapper.ID, ips.IPPort(bootstrapper.IP))
 	}
 
+	// Add the peers advertised by the DNS seeds to the peer network
+	if len(n.Config.DNSSeeds) > 0 {
+		go n.Log.RecoverAndPanic(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), n.Config.DNSSeedTimeout)
+			defer cancel()
+
+			seeds := dnsseed.Resolve(ctx, n.Log, net.DefaultResolver, n.Config.DNSSeeds)
+			n.Log.Info("resolved DNS seeds",
+				zap.Int("numPeers", len(seeds)),
+			)
+			for _, seed := range seeds {
+				n.Net.ManuallyTrack(seed.NodeID, seed.IP)
+			}
+		})
+	}
+
 	// Start P2P connections
 	err := n.Net.Dispatch()
 
@@ -652,42 +730,50 @@ func (n *Node) Dispatch() error {
  ******************************************************************************
  */
 
-func (n *Node) initDatabase() error {
-	// start the db
-	switch n.Config.DatabaseConfig.Name {
+// NewDatabase opens the database of the node described by [config].
+func NewDatabase(config DatabaseConfig, log logging.Logger, reg prometheus.Registerer) (database.Database, error) {
+	switch config.Name {
 	case leveldb.Name:
 		// Prior to v1.10.15, the only on-disk database was leveldb, and its
 		// files went to [dbPath]/[networkID]/v1.4.5.
-		dbPath := filepath.Join(n.Config.DatabaseConfig.Path, version.CurrentDatabase.String())
-		var err error
-		n.DB, err = leveldb.New(dbPath, n.Config.DatabaseConfig.Config, n.Log, "db_internal", n.MetricsRegisterer)
+		dbPath := filepath.Join(config.Path, version.CurrentDatabase.String())
+		db, err := leveldb.New(dbPath, config.Config, log, "db_internal", reg)
 		if err != nil {
-			return fmt.Errorf("couldn't create leveldb at %s: %w", dbPath, err)
+			return nil, fmt.Errorf("couldn't create leveldb at %s: %w", dbPath, err)
 		}
+		return db, nil
 	case memdb.Name:
-		n.DB = memdb.New()
+		return memdb.New(), nil
 	case pebble.Name:
-		dbPath := filepath.Join(n.Config.DatabaseConfig.Path, pebble.Name)
-		var err error
-		n.DB, err = pebble.New(dbPath, n.Config.DatabaseConfig.Config, n.Log, "db_internal", n.MetricsRegisterer)
+		dbPath := filepath.Join(config.Path, pebble.Name)
+		db, err := pebble.New(dbPath, config.Config, log, "db_internal", reg)
 		if err != nil {
-			return fmt.Errorf("couldn't create pebbledb at %s: %w", dbPath, err)
+			return nil, fmt.Errorf("couldn't create pebbledb at %s: %w", dbPath, err)
 		}
+		return db, nil
 	default:
-		return fmt.Errorf(
+		return nil, fmt.Errorf(
 			"db-type was %q but should have been one of {%s, %s, %s}",
-			n.Config.DatabaseConfig.Name,
+			config.Name,
 			leveldb.Name,
 			memdb.Name,
 			pebble.Name,
 		)
 	}
+}
+
+func (n *Node) initDatabase() error {
+	// start the db
+	var err error
+	n.DB, err = NewDatabase(n.Config.DatabaseConfig, n.Log, n.MetricsRegisterer)
+	if err != nil {
+		return err
+	}
 
 	if n.Config.ReadOnly && n.Config.DatabaseConfig.Name != memdb.Name {
 		n.DB = versiondb.New(n.DB)
 	}
 
-	var err error
 	n.DB, err = meterdb.New("db", n.MetricsRegisterer, n.DB)
 	if err != nil {
 		return err
@@ -1085,6 +1171,11 @@ func (n *Node) initVMs() error {
 				BanffTime:                     version.GetBanffTime(n.Config.NetworkID),
 				CortinaTime:                   version.GetCortinaTime(n.Config.NetworkID),
 				DurangoTime:                   version.GetDurangoTime(n.Config.NetworkID),
+				ContinuousStakingTime:         version.GetContinuousStakingTime(n.Config.NetworkID),
+				SubnetConversionTime:          version.GetSubnetConversionTime(n.Config.NetworkID),
+				PooledDelegationTime:          version.GetPooledDelegationTime(n.Config.NetworkID),
+				DynamicFeesTime:               version.GetDynamicFeesTime(n.Config.NetworkID),
+				DynamicFeeConfig:              n.Config.DynamicFeeConfig,
 				UseCurrentHeight:              n.Config.UseCurrentHeight,
 			},
 		}),
@@ -1207,6 +1298,8 @@ func (n *Node) initAdminAPI() error {
 			NodeConfig:   n.Config,
 			VMManager:    n.VMManager,
 			VMRegistry:   n.VMRegistry,
+			Network:      n.Net,
+			Benchlist:    n.benchlistManager,
 		},
 	)
 	if err != nil {
@@ -1274,6 +1367,7 @@ func (n *Node) initInfoAPI() error {
 		n.Config.NetworkConfig.MyIPPort,
 		n.Net,
 		n.benchlistManager,
+		n.uptimeCalculator,
 	)
 	if err != nil {
 		return err
@@ -0,0 +1,38 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pubsub
+
+var _ Filterer = (*addressFilterer)(nil)
+
+type addressFilterer struct {
+	addrs [][]byte
+	msg   interface{}
+}
+
+// NewAddressFilterer returns a Filterer that sends [msg] to the connections
+// whose filter contains any of [addrs]. If [addrs] is nil, [msg] is sent to
+// every subscribed connection.
+func NewAddressFilterer(addrs [][]byte, msg interface{}) Filterer {
+	return &addressFilterer{
+		addrs: addrs,
+		msg:   msg,
+	}
+}
+
+func (f *addressFilterer) Filter(filters []Filter) ([]bool, interface{}) {
+	resp := make([]bool, len(filters))
+	for i, c := range filters {
+		if f.addrs == nil {
+			resp[i] = true
+			continue
+		}
+		for _, addr := range f.addrs {
+			if c.Check(addr) {
+				resp[i] = true
+				break
+			}
+		}
+	}
+	return resp, f.msg
+}
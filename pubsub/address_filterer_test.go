@@ -0,0 +1,31 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddressFilterer(t *testing.T) {
+	require := require.New(t)
+
+	fp1 := NewFilterParam()
+	require.NoError(fp1.Add([]byte("abc")))
+	fp2 := NewFilterParam()
+	require.NoError(fp2.Add([]byte("def")))
+	filters := []Filter{fp1, fp2}
+
+	msg := "message"
+	toNotify, sentMsg := NewAddressFilterer([][]byte{[]byte("xyz"), []byte("def")}, msg).Filter(filters)
+	require.Equal([]bool{false, true}, toNotify)
+	require.Equal(msg, sentMsg)
+
+	toNotify, _ = NewAddressFilterer([][]byte{}, msg).Filter(filters)
+	require.Equal([]bool{false, false}, toNotify)
+
+	toNotify, _ = NewAddressFilterer(nil, msg).Filter(filters)
+	require.Equal([]bool{true, true}, toNotify)
+}
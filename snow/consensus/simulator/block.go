@@ -0,0 +1,73 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"context"
+	"time"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/snow"
+	"github.com/luxdefi/node/snow/choices"
+	"github.com/luxdefi/node/snow/consensus/snowman"
+)
+
+var (
+	_ snowman.Block = (*block)(nil)
+	_ snow.Acceptor = noOpAcceptor{}
+)
+
+// block is a node's local copy of an issued block.
+type block struct {
+	id       ids.ID
+	parentID ids.ID
+	height   uint64
+	status   choices.Status
+	onAccept func(*block)
+}
+
+func (b *block) ID() ids.ID {
+	return b.id
+}
+
+func (b *block) Accept(context.Context) error {
+	b.status = choices.Accepted
+	b.onAccept(b)
+	return nil
+}
+
+func (b *block) Reject(context.Context) error {
+	b.status = choices.Rejected
+	return nil
+}
+
+func (b *block) Status() choices.Status {
+	return b.status
+}
+
+func (b *block) Parent() ids.ID {
+	return b.parentID
+}
+
+func (*block) Verify(context.Context) error {
+	return nil
+}
+
+func (b *block) Bytes() []byte {
+	return b.id[:]
+}
+
+func (b *block) Height() uint64 {
+	return b.height
+}
+
+func (*block) Timestamp() time.Time {
+	return time.Time{}
+}
+
+type noOpAcceptor struct{}
+
+func (noOpAcceptor) Accept(*snow.ConsensusContext, ids.ID, []byte) error {
+	return nil
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/luxdefi/node/snow/consensus/simulator"
+	"github.com/luxdefi/node/snow/consensus/snowball"
+)
+
+var errUnknownOutput = errors.New("output must be either text or json")
+
+func main() {
+	var (
+		config       = simulator.Config{Params: snowball.DefaultParameters}
+		strategyName string
+		latency      string
+		slowLatency  string
+		partitions   []string
+		numBuckets   int
+		output       string
+	)
+	rootCmd := &cobra.Command{
+		Use:   "simulator",
+		Short: "Simulate snowman consensus over a virtual network",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if output != "text" && output != "json" {
+				return errUnknownOutput
+			}
+
+			var err error
+			config.Strategy, err = simulator.ParseStrategy(strategyName)
+			if err != nil {
+				return err
+			}
+			config.Latency, err = simulator.ParseLatency(latency)
+			if err != nil {
+				return err
+			}
+			if config.NumSlow > 0 {
+				config.SlowLatency, err = simulator.ParseLatency(slowLatency)
+				if err != nil {
+					return err
+				}
+			}
+			for _, partition := range partitions {
+				p, err := simulator.ParsePartition(partition)
+				if err != nil {
+					return err
+				}
+				config.Partitions = append(config.Partitions, p)
+			}
+
+			result, err := simulator.Run(cmd.Context(), config)
+			if err != nil {
+				return err
+			}
+
+			report := result.Report(numBuckets)
+			if output == "text" {
+				return report.WriteText(os.Stdout)
+			}
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(report)
+		},
+	}
+
+	flags := rootCmd.Flags()
+	flags.Int64Var(&config.Seed, "seed", 0, "Seed used to derive all randomness of the simulation")
+	flags.IntVar(&config.NumNodes, "nodes", 1000, "Total number of nodes, including byzantine nodes")
+	flags.IntVar(&config.NumByzantine, "byzantine", 0, "Number of byzantine nodes")
+	flags.StringVar(&strategyName, "byzantine-strategy", "random", "Strategy used by byzantine nodes to answer queries: silent, random, or contrarian")
+	flags.Uint64Var(&config.ByzantineWeight, "byzantine-weight", 1, "Stake of every byzantine node. Honest nodes have a stake of 1")
+	flags.IntVar(&config.NumBlocks, "blocks", 10, "Number of conflicting blocks issued to every node")
+	flags.IntVar(&config.Params.K, "snow-sample-size", config.Params.K, "Number of nodes to query for each network poll")
+	flags.IntVar(&config.Params.AlphaPreference, "snow-preference-quorum-size", config.Params.AlphaPreference, "Threshold of nodes required to update a node's preference in a network poll")
+	flags.IntVar(&config.Params.AlphaConfidence, "snow-confidence-quorum-size", config.Params.AlphaConfidence, "Threshold of nodes required to increase a node's confidence in a network poll")
+	flags.IntVar(&config.Params.BetaVirtuous, "snow-virtuous-commit-threshold", config.Params.BetaVirtuous, "Beta value to use for virtuous transactions")
+	flags.IntVar(&config.Params.BetaRogue, "snow-rogue-commit-threshold", config.Params.BetaRogue, "Beta value to use for rogue transactions")
+	flags.StringVar(&latency, "latency", "uniform:10ms:200ms", "Latency model formatted as constant:<delay>, uniform:<min>:<max>, or normal:<mean>:<stddev>")
+	flags.IntVar(&config.NumSlow, "slow-nodes", 0, "Number of nodes whose messages are delayed by the slow latency model")
+	flags.StringVar(&slowLatency, "slow-latency", "constant:1s", "Latency model of the slow nodes, in the same format as --latency")
+	flags.StringVar(&config.Sampling.Strategy, "sampling-strategy", "", "Strategy used to sample the peers to query: stake, latency, benchlist, or stake-capped. If empty, peers are sampled uniformly at random")
+	flags.DurationVar(&config.Sampling.TargetLatency, "sampling-target-latency", 0, "Response time at or below which a peer is sampled with its full stake by the latency strategy")
+	flags.Float64Var(&config.Sampling.MinWeightFactor, "sampling-min-weight-factor", 0, "Fraction of its stake that an unresponsive peer is sampled with by the latency strategy")
+	flags.Float64Var(&config.Sampling.MaxEntityWeight, "sampling-max-entity-weight", 0, "Maximum fraction of the sampling weight given to any node by the stake-capped strategy")
+	flags.StringSliceVar(&partitions, "partition", nil, "Partition formatted as <start>:<end>:<fraction>. May be repeated")
+	flags.DurationVar(&config.QueryTimeout, "query-timeout", 2*time.Second, "Duration after which a poll is finished with the responses received so far")
+	flags.DurationVar(&config.MaxTime, "max-time", time.Hour, "Virtual time after which the simulation is stopped")
+	flags.IntVar(&numBuckets, "buckets", 20, "Number of buckets in the finality time histogram")
+	flags.StringVar(&output, "output", "text", "Output format: text or json")
+
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "simulator failed: %v\n", err)
+		os.Exit(1)
+	}
+}
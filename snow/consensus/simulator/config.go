@@ -0,0 +1,161 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luxdefi/node/snow/consensus/snowball"
+	"github.com/luxdefi/node/snow/validators/sampling"
+)
+
+var (
+	errNoNodes                 = errors.New("at least one honest node is required")
+	errInvalidNumByzantine     = errors.New("number of byzantine nodes must be non-negative")
+	errNotEnoughNodes          = errors.New("not enough peers to sample k")
+	errNoBlocks                = errors.New("at least one block is required")
+	errNoLatency               = errors.New("a latency model is required")
+	errNoStrategy              = errors.New("a byzantine strategy is required")
+	errInvalidQueryTimeout     = errors.New("query timeout must be positive")
+	errInvalidMaxTime          = errors.New("max time must be positive")
+	errInvalidPartitionWindow  = errors.New("partition must end after it starts")
+	errInvalidPartitionPercent = errors.New("partition fraction must be in (0, 1)")
+	errInvalidPartitionFormat  = errors.New("partition must be formatted as start:end:fraction")
+	errInvalidNumSlow          = errors.New("number of slow nodes must be in [0, number of nodes]")
+	errNoSlowLatency           = errors.New("a latency model for slow nodes is required")
+)
+
+// Config describes a simulated network. Every source of randomness in the
+// simulation is derived from Seed, so running the same Config twice produces
+// the same Result.
+type Config struct {
+	Seed int64 `json:"seed"`
+
+	// NumNodes is the total number of nodes, including byzantine nodes.
+	NumNodes int `json:"numNodes"`
+	// NumByzantine nodes answer queries according to Strategy rather than
+	// running consensus.
+	NumByzantine int      `json:"numByzantine"`
+	Strategy     Strategy `json:"-"`
+	// ByzantineWeight is the stake of every byzantine node. Honest nodes have
+	// a stake of 1. If 0, byzantine nodes also have a stake of 1. Stake is
+	// only used if Sampling.Strategy is set.
+	ByzantineWeight uint64 `json:"byzantineWeight"`
+
+	Params snowball.Parameters `json:"params"`
+	// Sampling selects how nodes sample the peers they query. If the strategy
+	// is empty, peers are sampled uniformly at random, regardless of their
+	// stake.
+	Sampling sampling.Config `json:"sampling"`
+
+	// NumBlocks is the number of processing blocks issued to every node. The
+	// blocks form a random tree rooted at genesis, so blocks at the same
+	// height conflict.
+	NumBlocks int `json:"numBlocks"`
+
+	// Latency is sampled for every message sent between two nodes.
+	Latency    Latency     `json:"-"`
+	Partitions []Partition `json:"partitions"`
+	// NumSlow nodes, chosen at random, send and receive every message with a
+	// delay sampled from SlowLatency rather than Latency.
+	NumSlow     int     `json:"numSlow"`
+	SlowLatency Latency `json:"-"`
+
+	// QueryTimeout is the duration after which a poll is finished with the
+	// responses received so far.
+	QueryTimeout time.Duration `json:"queryTimeout"`
+	// MaxTime is the virtual time after which the simulation is stopped, even
+	// if some honest nodes haven't finalized.
+	MaxTime time.Duration `json:"maxTime"`
+}
+
+func (c *Config) Verify() error {
+	switch {
+	case c.NumByzantine < 0:
+		return errInvalidNumByzantine
+	case c.NumNodes-c.NumByzantine <= 0:
+		return errNoNodes
+	case c.NumNodes-1 < c.Params.K:
+		return fmt.Errorf("%w: %d peers < k = %d", errNotEnoughNodes, c.NumNodes-1, c.Params.K)
+	case c.NumBlocks <= 0:
+		return errNoBlocks
+	case c.Latency == nil:
+		return errNoLatency
+	case c.NumByzantine > 0 && c.Strategy == nil:
+		return errNoStrategy
+	case c.NumSlow < 0 || c.NumSlow > c.NumNodes:
+		return errInvalidNumSlow
+	case c.NumSlow > 0 && c.SlowLatency == nil:
+		return errNoSlowLatency
+	case c.QueryTimeout <= 0:
+		return errInvalidQueryTimeout
+	case c.MaxTime <= 0:
+		return errInvalidMaxTime
+	}
+	for _, p := range c.Partitions {
+		if err := p.Verify(); err != nil {
+			return err
+		}
+	}
+	if err := c.Sampling.Verify(); err != nil {
+		return err
+	}
+	return c.Params.Verify()
+}
+
+// Partition splits the network into two groups between Start and End.
+// Messages sent between the groups while the partition is active are dropped.
+type Partition struct {
+	Start time.Duration `json:"start"`
+	End   time.Duration `json:"end"`
+	// Fraction of the nodes, chosen at random, that are cut off from the rest
+	// of the network.
+	Fraction float64 `json:"fraction"`
+}
+
+func (p *Partition) Verify() error {
+	switch {
+	case p.End <= p.Start:
+		return errInvalidPartitionWindow
+	case p.Fraction <= 0 || p.Fraction >= 1:
+		return errInvalidPartitionPercent
+	default:
+		return nil
+	}
+}
+
+func (p *Partition) active(now time.Duration) bool {
+	return p.Start <= now && now < p.End
+}
+
+// ParsePartition parses a partition formatted as "start:end:fraction", for
+// example "1s:5s:0.3".
+func ParsePartition(s string) (Partition, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return Partition{}, fmt.Errorf("%w: %q", errInvalidPartitionFormat, s)
+	}
+	start, err := time.ParseDuration(parts[0])
+	if err != nil {
+		return Partition{}, err
+	}
+	end, err := time.ParseDuration(parts[1])
+	if err != nil {
+		return Partition{}, err
+	}
+	fraction, err := strconv.ParseFloat(parts[2], 64)
+	if err != nil {
+		return Partition{}, err
+	}
+	p := Partition{
+		Start:    start,
+		End:      end,
+		Fraction: fraction,
+	}
+	return p, p.Verify()
+}
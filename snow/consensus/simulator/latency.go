@@ -0,0 +1,92 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+var (
+	_ Latency = Constant{}
+	_ Latency = Uniform{}
+	_ Latency = Normal{}
+
+	errInvalidLatencyFormat = errors.New("invalid latency format")
+	errInvalidUniform       = errors.New("uniform latency maximum must not be less than its minimum")
+)
+
+// Latency models the delay of a message sent between two nodes.
+type Latency interface {
+	// Sample returns the delay of a single message.
+	Sample(rng *rand.Rand) time.Duration
+}
+
+// Constant delays every message by Delay.
+type Constant struct {
+	Delay time.Duration
+}
+
+func (c Constant) Sample(*rand.Rand) time.Duration {
+	return c.Delay
+}
+
+// Uniform delays messages by a duration chosen uniformly from [Min, Max].
+type Uniform struct {
+	Min time.Duration
+	Max time.Duration
+}
+
+func (u Uniform) Sample(rng *rand.Rand) time.Duration {
+	return u.Min + time.Duration(rng.Int63n(int64(u.Max-u.Min)+1))
+}
+
+// Normal delays messages by a normally distributed duration. Negative samples
+// are treated as zero.
+type Normal struct {
+	Mean   time.Duration
+	StdDev time.Duration
+}
+
+func (n Normal) Sample(rng *rand.Rand) time.Duration {
+	delay := n.Mean + time.Duration(rng.NormFloat64()*float64(n.StdDev))
+	if delay < 0 {
+		return 0
+	}
+	return delay
+}
+
+// ParseLatency parses a latency model formatted as one of:
+//
+//   - constant:<delay>
+//   - uniform:<min>:<max>
+//   - normal:<mean>:<stddev>
+func ParseLatency(s string) (Latency, error) {
+	parts := strings.Split(s, ":")
+	durations := make([]time.Duration, len(parts)-1)
+	for i, part := range parts[1:] {
+		d, err := time.ParseDuration(part)
+		if err != nil {
+			return nil, err
+		}
+		durations[i] = d
+	}
+
+	switch {
+	case parts[0] == "constant" && len(durations) == 1:
+		return Constant{Delay: durations[0]}, nil
+	case parts[0] == "uniform" && len(durations) == 2:
+		if durations[1] < durations[0] {
+			return nil, errInvalidUniform
+		}
+		return Uniform{Min: durations[0], Max: durations[1]}, nil
+	case parts[0] == "normal" && len(durations) == 2:
+		return Normal{Mean: durations[0], StdDev: durations[1]}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", errInvalidLatencyFormat, s)
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/math"
+)
+
+// The width of the largest bar printed in a histogram.
+const maxBarWidth = 50
+
+// SafetyViolation reports that two honest nodes accepted conflicting blocks at
+// the same height.
+type SafetyViolation struct {
+	// Virtual time at which NodeID accepted BlockID.
+	Time               time.Duration `json:"time"`
+	Height             uint64        `json:"height"`
+	NodeID             int           `json:"nodeID"`
+	BlockID            ids.ID        `json:"blockID"`
+	ConflictingNodeID  int           `json:"conflictingNodeID"`
+	ConflictingBlockID ids.ID        `json:"conflictingBlockID"`
+}
+
+// Result is the outcome of a simulation.
+type Result struct {
+	Seed      int64 `json:"seed"`
+	NumHonest int   `json:"numHonest"`
+	// NumUnfinalized is the number of honest nodes that still had processing
+	// blocks when the simulation stopped.
+	NumUnfinalized int `json:"numUnfinalized"`
+	// FinalityTimes contains, in increasing order, the virtual time at which
+	// each finalized honest node accepted its last block.
+	FinalityTimes []time.Duration `json:"finalityTimes"`
+	// Duration is the virtual time at which the simulation stopped.
+	Duration         time.Duration     `json:"duration"`
+	NumPolls         uint64            `json:"numPolls"`
+	NumMessages      uint64            `json:"numMessages"`
+	NumDropped       uint64            `json:"numDropped"`
+	SafetyViolations []SafetyViolation `json:"safetyViolations"`
+}
+
+func (r *Result) sort() {
+	slices.Sort(r.FinalityTimes)
+}
+
+// Percentile returns the finality time that [p] percent of the finalized honest
+// nodes reached. Returns 0 if no node finalized.
+func (r *Result) Percentile(p float64) time.Duration {
+	if len(r.FinalityTimes) == 0 {
+		return 0
+	}
+	i := int(p / 100 * float64(len(r.FinalityTimes)))
+	if i >= len(r.FinalityTimes) {
+		i = len(r.FinalityTimes) - 1
+	}
+	return r.FinalityTimes[i]
+}
+
+// Bucket counts the finality times in [Start, End).
+type Bucket struct {
+	Start time.Duration `json:"start"`
+	End   time.Duration `json:"end"`
+	Count int           `json:"count"`
+}
+
+// Histogram groups the finality times into [numBuckets] equally sized buckets
+// spanning from 0 to the largest finality time.
+func (r *Result) Histogram(numBuckets int) []Bucket {
+	if len(r.FinalityTimes) == 0 || numBuckets <= 0 {
+		return nil
+	}
+
+	maxTime := r.FinalityTimes[len(r.FinalityTimes)-1]
+	width := maxTime/time.Duration(numBuckets) + 1
+	buckets := make([]Bucket, numBuckets)
+	for i := range buckets {
+		buckets[i].Start = time.Duration(i) * width
+		buckets[i].End = time.Duration(i+1) * width
+	}
+	for _, t := range r.FinalityTimes {
+		buckets[t/width].Count++
+	}
+	return buckets
+}
+
+// Report summarizes a Result.
+type Report struct {
+	Seed             int64             `json:"seed"`
+	NumHonest        int               `json:"numHonest"`
+	NumFinalized     int               `json:"numFinalized"`
+	NumUnfinalized   int               `json:"numUnfinalized"`
+	Duration         time.Duration     `json:"duration"`
+	NumPolls         uint64            `json:"numPolls"`
+	NumMessages      uint64            `json:"numMessages"`
+	NumDropped       uint64            `json:"numDropped"`
+	P50              time.Duration     `json:"p50"`
+	P90              time.Duration     `json:"p90"`
+	P99              time.Duration     `json:"p99"`
+	Histogram        []Bucket          `json:"histogram"`
+	SafetyViolations []SafetyViolation `json:"safetyViolations"`
+}
+
+func (r *Result) Report(numBuckets int) *Report {
+	return &Report{
+		Seed:             r.Seed,
+		NumHonest:        r.NumHonest,
+		NumFinalized:     len(r.FinalityTimes),
+		NumUnfinalized:   r.NumUnfinalized,
+		Duration:         r.Duration,
+		NumPolls:         r.NumPolls,
+		NumMessages:      r.NumMessages,
+		NumDropped:       r.NumDropped,
+		P50:              r.Percentile(50),
+		P90:              r.Percentile(90),
+		P99:              r.Percentile(99),
+		Histogram:        r.Histogram(numBuckets),
+		SafetyViolations: r.SafetyViolations,
+	}
+}
+
+// WriteText writes a human readable version of the report to [w].
+func (r *Report) WriteText(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "seed:             %d\n", r.Seed)
+	fmt.Fprintf(&b, "honest nodes:     %d\n", r.NumHonest)
+	fmt.Fprintf(&b, "finalized:        %d\n", r.NumFinalized)
+	fmt.Fprintf(&b, "unfinalized:      %d\n", r.NumUnfinalized)
+	fmt.Fprintf(&b, "duration:         %s\n", r.Duration)
+	fmt.Fprintf(&b, "polls:            %d\n", r.NumPolls)
+	fmt.Fprintf(&b, "messages:         %d (%d dropped)\n", r.NumMessages, r.NumDropped)
+	fmt.Fprintf(&b, "finality p50:     %s\n", r.P50)
+	fmt.Fprintf(&b, "finality p90:     %s\n", r.P90)
+	fmt.Fprintf(&b, "finality p99:     %s\n", r.P99)
+
+	if len(r.Histogram) > 0 {
+		maxCount := 0
+		for _, bucket := range r.Histogram {
+			maxCount = math.Max(maxCount, bucket.Count)
+		}
+		b.WriteString("\nfinality time histogram:\n")
+		for _, bucket := range r.Histogram {
+			bar := strings.Repeat("#", bucket.Count*maxBarWidth/maxCount)
+			fmt.Fprintf(&b, "%12s - %-12s %8d %s\n", bucket.Start, bucket.End, bucket.Count, bar)
+		}
+	}
+
+	fmt.Fprintf(&b, "\nsafety violations: %d\n", len(r.SafetyViolations))
+	for _, v := range r.SafetyViolations {
+		fmt.Fprintf(&b,
+			"  height %d at %s: node %d accepted %s, node %d accepted %s\n",
+			v.Height,
+			v.Time,
+			v.NodeID,
+			v.BlockID,
+			v.ConflictingNodeID,
+			v.ConflictingBlockID,
+		)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,540 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"context"
+	"encoding/binary"
+	"math/rand"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/snow"
+	"github.com/luxdefi/node/snow/choices"
+	"github.com/luxdefi/node/snow/consensus/snowman"
+	"github.com/luxdefi/node/snow/validators"
+	"github.com/luxdefi/node/snow/validators/sampling"
+	"github.com/luxdefi/node/utils/bag"
+	"github.com/luxdefi/node/utils/heap"
+	"github.com/luxdefi/node/utils/logging"
+	"github.com/luxdefi/node/utils/set"
+	"github.com/luxdefi/node/utils/timer/mockable"
+)
+
+const (
+	// The number of events processed between checks for cancellation.
+	cancellationCheckFrequency = 1024
+
+	// When the benchlist sampling strategy is used, a node benches a peer
+	// after [benchlistThreshold] consecutive queries to it failed, for
+	// [benchlistDuration].
+	benchlistThreshold = 3
+	benchlistDuration  = time.Minute
+)
+
+var genesisID = ids.Empty
+
+type event struct {
+	time time.Duration
+	// Breaks ties between events scheduled at the same time so that events
+	// are always processed in a deterministic order.
+	seq uint64
+	fn  func()
+}
+
+func lessEvent(a, b *event) bool {
+	if a.time != b.time {
+		return a.time < b.time
+	}
+	return a.seq < b.seq
+}
+
+type node struct {
+	id        int
+	byzantine bool
+	consensus *snowman.Topological
+	finalized bool
+
+	// The fields below are only set if Sampling.Strategy is set.
+	sampler   sampling.Sampler
+	requestID uint32
+	// peer -> number of consecutive failed queries
+	failures map[int]int
+	// peer -> time until which the peer is benched
+	benchedUntil map[int]time.Duration
+}
+
+type poll struct {
+	requestID uint32
+	votes     bag.Bag[ids.ID]
+	pending   int
+	// peer -> number of times the peer was sampled
+	queried   map[int]int
+	responded set.Set[int]
+	done      bool
+}
+
+type acceptance struct {
+	nodeID int
+	blkID  ids.ID
+}
+
+type simulation struct {
+	ctx    context.Context
+	config Config
+	rng    *rand.Rand
+
+	now    time.Duration
+	seq    uint64
+	events heap.Queue[*event]
+	err    error
+
+	blocks *Blocks
+	nodes  []*node
+	// sides[i][j] is true if node j is cut off from the rest of the network
+	// during Partitions[i].
+	sides [][]bool
+	// slow[i] is true if messages to and from node i are delayed by
+	// SlowLatency.
+	slow []bool
+
+	// The fields below are only set if Sampling.Strategy is set.
+	nodeIDs map[ids.NodeID]int
+	clock   *mockable.Clock
+
+	numFinalized int
+	// height -> first honest acceptance at that height
+	accepted map[uint64]acceptance
+	result   *Result
+}
+
+// Run simulates the network described by [config] until every honest node has
+// finalized all the issued blocks or config.MaxTime has passed.
+func Run(ctx context.Context, config Config) (*Result, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+
+	s := &simulation{
+		ctx:      ctx,
+		config:   config,
+		rng:      rand.New(rand.NewSource(config.Seed)), // #nosec G404
+		events:   heap.NewQueue(lessEvent),
+		accepted: make(map[uint64]acceptance),
+		result: &Result{
+			Seed:      config.Seed,
+			NumHonest: config.NumNodes - config.NumByzantine,
+		},
+	}
+	if err := s.initialize(); err != nil {
+		return nil, err
+	}
+
+	for _, n := range s.nodes {
+		if !n.byzantine {
+			s.startPoll(n)
+		}
+	}
+
+	var numEvents uint64
+	for s.err == nil && s.numFinalized < s.result.NumHonest {
+		e, ok := s.events.Pop()
+		if !ok || e.time > config.MaxTime {
+			break
+		}
+		s.now = e.time
+		if s.clock != nil {
+			s.clock.Set(time.Unix(0, 0).Add(s.now))
+		}
+		e.fn()
+
+		numEvents++
+		if numEvents%cancellationCheckFrequency == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	s.result.Duration = s.now
+	s.result.NumUnfinalized = s.result.NumHonest - s.numFinalized
+	s.result.sort()
+	return s.result, nil
+}
+
+func (s *simulation) initialize() error {
+	s.blocks = newBlocks(s.rng, genesisID, s.config.NumBlocks)
+
+	byzantine := make([]bool, s.config.NumNodes)
+	for _, i := range s.rng.Perm(s.config.NumNodes)[:s.config.NumByzantine] {
+		byzantine[i] = true
+	}
+
+	s.sides = make([][]bool, len(s.config.Partitions))
+	for i, p := range s.config.Partitions {
+		s.sides[i] = make([]bool, s.config.NumNodes)
+		numCutOff := int(p.Fraction * float64(s.config.NumNodes))
+		for _, j := range s.rng.Perm(s.config.NumNodes)[:numCutOff] {
+			s.sides[i][j] = true
+		}
+	}
+
+	s.nodes = make([]*node, s.config.NumNodes)
+	for i := range s.nodes {
+		n := &node{
+			id:        i,
+			byzantine: byzantine[i],
+		}
+		s.nodes[i] = n
+		if n.byzantine {
+			continue
+		}
+		if err := s.initializeConsensus(n); err != nil {
+			return err
+		}
+	}
+
+	// Additional randomness is only consumed when the corresponding options
+	// are set so that the results of existing configurations don't change.
+	s.slow = make([]bool, s.config.NumNodes)
+	if s.config.NumSlow > 0 {
+		for _, i := range s.rng.Perm(s.config.NumNodes)[:s.config.NumSlow] {
+			s.slow[i] = true
+		}
+	}
+	if s.config.Sampling.Strategy != "" {
+		return s.initializeSamplers()
+	}
+	return nil
+}
+
+// initializeSamplers registers every node as a validator and gives every
+// honest node its own sampler, configured by Sampling.
+func (s *simulation) initializeSamplers() error {
+	vdrs := validators.NewManager()
+	s.nodeIDs = make(map[ids.NodeID]int, s.config.NumNodes)
+	for _, n := range s.nodes {
+		weight := uint64(1)
+		if n.byzantine && s.config.ByzantineWeight > 0 {
+			weight = s.config.ByzantineWeight
+		}
+		nodeID := simulatedNodeID(n.id)
+		if err := vdrs.AddStaker(ids.Empty, nodeID, nil, ids.Empty, weight); err != nil {
+			return err
+		}
+		s.nodeIDs[nodeID] = n.id
+	}
+
+	s.clock = &mockable.Clock{}
+	s.clock.Set(time.Unix(0, 0))
+	for _, n := range s.nodes {
+		if n.byzantine {
+			continue
+		}
+
+		n.failures = make(map[int]int)
+		n.benchedUntil = make(map[int]time.Duration)
+		isBenched := func(nodeID ids.NodeID) bool {
+			return s.now < n.benchedUntil[s.nodeIDs[nodeID]]
+		}
+
+		var err error
+		n.sampler, err = sampling.New(s.config.Sampling, ids.Empty, vdrs, isBenched, s.clock)
+		if err != nil {
+			return err
+		}
+		n.sampler.Seed(s.rng.Int63())
+	}
+	return nil
+}
+
+// simulatedNodeID returns the NodeID that node [i] is registered as.
+func simulatedNodeID(i int) ids.NodeID {
+	var nodeID ids.NodeID
+	binary.BigEndian.PutUint64(nodeID[:], uint64(i))
+	return nodeID
+}
+
+// initializeConsensus issues every block to [n]. The order in which sibling
+// blocks are issued is randomized per node, which determines the node's
+// initial preference.
+func (s *simulation) initializeConsensus(n *node) error {
+	ctx := &snow.ConsensusContext{
+		Context: &snow.Context{
+			Log: logging.NoLog{},
+		},
+		Registerer:    prometheus.NewRegistry(),
+		BlockAcceptor: noOpAcceptor{},
+	}
+	n.consensus = &snowman.Topological{}
+	if err := n.consensus.Initialize(ctx, s.config.Params, genesisID, 0, time.Time{}); err != nil {
+		return err
+	}
+
+	// Blocks must be issued after their parents, so they are issued in order
+	// of height.
+	order := s.rng.Perm(len(s.blocks.ids))
+	byHeight := make([][]ids.ID, 0)
+	for _, i := range order {
+		blkID := s.blocks.ids[i]
+		height := int(s.blocks.heights[blkID])
+		for len(byHeight) < height {
+			byHeight = append(byHeight, nil)
+		}
+		byHeight[height-1] = append(byHeight[height-1], blkID)
+	}
+	for _, blkIDs := range byHeight {
+		for _, blkID := range blkIDs {
+			blk := &block{
+				id:       blkID,
+				parentID: s.blocks.parents[blkID],
+				height:   s.blocks.heights[blkID],
+				status:   choices.Processing,
+				onAccept: func(blk *block) {
+					s.accept(n, blk)
+				},
+			}
+			if err := n.consensus.Add(s.ctx, blk); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// schedule runs [fn] once the virtual clock reaches [t].
+func (s *simulation) schedule(t time.Duration, fn func()) {
+	s.seq++
+	s.events.Push(&event{
+		time: t,
+		seq:  s.seq,
+		fn:   fn,
+	})
+}
+
+// send delivers a message from [from] to [to] by calling [onDelivery], unless
+// the message is dropped by a partition.
+func (s *simulation) send(from, to int, onDelivery func()) {
+	s.result.NumMessages++
+	if s.partitioned(from, to) {
+		s.result.NumDropped++
+		return
+	}
+	latency := s.config.Latency
+	if s.slow[from] || s.slow[to] {
+		latency = s.config.SlowLatency
+	}
+	s.schedule(s.now+latency.Sample(s.rng), func() {
+		if s.partitioned(from, to) {
+			s.result.NumDropped++
+			return
+		}
+		onDelivery()
+	})
+}
+
+func (s *simulation) partitioned(a, b int) bool {
+	for i, p := range s.config.Partitions {
+		if p.active(s.now) && s.sides[i][a] != s.sides[i][b] {
+			return true
+		}
+	}
+	return false
+}
+
+// startPoll queries K peers for their preferences. Peers are sampled
+// uniformly at random unless Sampling.Strategy is set, in which case they are
+// sampled by the node's sampler.
+func (s *simulation) startPoll(n *node) {
+	s.result.NumPolls++
+
+	p := &poll{
+		pending: s.config.Params.K,
+		queried: make(map[int]int, s.config.Params.K),
+	}
+	peerIDs, err := s.sample(n)
+	if err != nil {
+		s.err = err
+		return
+	}
+	// A peer may be sampled multiple times, in which case its vote is counted
+	// once per sample but it's only queried once.
+	uniquePeerIDs := make([]int, 0, len(peerIDs))
+	for _, peerID := range peerIDs {
+		if p.queried[peerID] == 0 {
+			uniquePeerIDs = append(uniquePeerIDs, peerID)
+		}
+		p.queried[peerID]++
+	}
+	if n.sampler != nil {
+		n.requestID++
+		p.requestID = n.requestID
+		nodeIDs := set.NewSet[ids.NodeID](len(uniquePeerIDs))
+		for _, peerID := range uniquePeerIDs {
+			nodeIDs.Add(simulatedNodeID(peerID))
+		}
+		n.sampler.RegisterQuery(nodeIDs, p.requestID)
+	}
+
+	for _, peerID := range uniquePeerIDs {
+		peer := s.nodes[peerID]
+		s.send(n.id, peer.id, func() {
+			vote, ok := s.preference(peer, n)
+			if !ok {
+				return
+			}
+			s.send(peer.id, n.id, func() {
+				if p.done {
+					return
+				}
+				s.registerResponse(n, p, peer.id)
+
+				count := p.queried[peer.id]
+				p.votes.AddCount(vote, count)
+				p.pending -= count
+				if p.pending == 0 {
+					s.finishPoll(n, p)
+				}
+			})
+		})
+	}
+	s.schedule(s.now+s.config.QueryTimeout, func() {
+		s.finishPoll(n, p)
+	})
+}
+
+// sample returns the K peers that [n] queries.
+func (s *simulation) sample(n *node) ([]int, error) {
+	if n.sampler == nil {
+		return s.samplePeers(n.id), nil
+	}
+
+	nodeIDs, err := n.sampler.Sample(s.config.Params.K)
+	if err != nil {
+		return nil, err
+	}
+	peerIDs := make([]int, len(nodeIDs))
+	for i, nodeID := range nodeIDs {
+		peerIDs[i] = s.nodeIDs[nodeID]
+	}
+	return peerIDs, nil
+}
+
+func (s *simulation) registerResponse(n *node, p *poll, peerID int) {
+	p.responded.Add(peerID)
+	if n.sampler == nil {
+		return
+	}
+	n.sampler.RegisterResponse(simulatedNodeID(peerID), p.requestID)
+	n.failures[peerID] = 0
+}
+
+// registerFailures notifies the sampler of [n] about every peer that didn't
+// respond to [p] and benches peers that repeatedly failed to respond.
+func (s *simulation) registerFailures(n *node, p *poll) {
+	if n.sampler == nil {
+		return
+	}
+	for peerID := range p.queried {
+		if p.responded.Contains(peerID) {
+			continue
+		}
+		n.sampler.RegisterFailure(simulatedNodeID(peerID), p.requestID)
+
+		if s.config.Sampling.Strategy != sampling.BenchlistStrategy {
+			continue
+		}
+		n.failures[peerID]++
+		if n.failures[peerID] >= benchlistThreshold {
+			n.failures[peerID] = 0
+			n.benchedUntil[peerID] = s.now + benchlistDuration
+		}
+	}
+}
+
+// samplePeers returns K distinct nodes other than [nodeID].
+func (s *simulation) samplePeers(nodeID int) []int {
+	// Partial Fisher-Yates shuffle over every node other than [nodeID].
+	numPeers := s.config.NumNodes - 1
+	swapped := make(map[int]int, s.config.Params.K)
+	get := func(i int) int {
+		if v, ok := swapped[i]; ok {
+			return v
+		}
+		return i
+	}
+
+	peers := make([]int, s.config.Params.K)
+	for i := range peers {
+		j := i + s.rng.Intn(numPeers-i)
+		vi, vj := get(i), get(j)
+		swapped[i], swapped[j] = vj, vi
+
+		peerID := vj
+		if peerID >= nodeID {
+			peerID++
+		}
+		peers[i] = peerID
+	}
+	return peers
+}
+
+// preference returns the block that [peer] reports as preferred when queried
+// by [querier].
+func (s *simulation) preference(peer, querier *node) (ids.ID, bool) {
+	if !peer.byzantine {
+		return peer.consensus.Preference(), true
+	}
+	return s.config.Strategy.Vote(s.rng, s.blocks, querier.consensus.Preference())
+}
+
+func (s *simulation) finishPoll(n *node, p *poll) {
+	if p.done || s.err != nil {
+		return
+	}
+	p.done = true
+	s.registerFailures(n, p)
+
+	if err := n.consensus.RecordPoll(s.ctx, p.votes); err != nil {
+		s.err = err
+		return
+	}
+	if n.consensus.NumProcessing() > 0 {
+		s.startPoll(n)
+		return
+	}
+
+	n.finalized = true
+	s.numFinalized++
+	s.result.FinalityTimes = append(s.result.FinalityTimes, s.now)
+}
+
+// accept records that [n] accepted [blk] and reports a safety violation if
+// another honest node accepted a different block at the same height.
+func (s *simulation) accept(n *node, blk *block) {
+	first, ok := s.accepted[blk.height]
+	if !ok {
+		s.accepted[blk.height] = acceptance{
+			nodeID: n.id,
+			blkID:  blk.id,
+		}
+		return
+	}
+	if first.blkID == blk.id {
+		return
+	}
+	s.result.SafetyViolations = append(s.result.SafetyViolations, SafetyViolation{
+		Time:               s.now,
+		Height:             blk.height,
+		NodeID:             n.id,
+		BlockID:            blk.id,
+		ConflictingNodeID:  first.nodeID,
+		ConflictingBlockID: first.blkID,
+	})
+}
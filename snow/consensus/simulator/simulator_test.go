@@ -0,0 +1,339 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"bytes"
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/snow/consensus/snowball"
+	"github.com/luxdefi/node/snow/validators/sampling"
+)
+
+func testConfig() Config {
+	return Config{
+		Seed:     1,
+		NumNodes: 50,
+		Params: snowball.Parameters{
+			K:                     20,
+			AlphaPreference:       15,
+			AlphaConfidence:       15,
+			BetaVirtuous:          15,
+			BetaRogue:             20,
+			ConcurrentRepolls:     1,
+			OptimalProcessing:     1,
+			MaxOutstandingItems:   1,
+			MaxItemProcessingTime: 1,
+		},
+		NumBlocks: 10,
+		Latency: Uniform{
+			Min: 10 * time.Millisecond,
+			Max: 100 * time.Millisecond,
+		},
+		QueryTimeout: time.Second,
+		MaxTime:      time.Hour,
+	}
+}
+
+func TestRunHonest(t *testing.T) {
+	require := require.New(t)
+
+	config := testConfig()
+	result, err := Run(context.Background(), config)
+	require.NoError(err)
+
+	require.Equal(config.NumNodes, result.NumHonest)
+	require.Zero(result.NumUnfinalized)
+	require.Len(result.FinalityTimes, config.NumNodes)
+	require.Empty(result.SafetyViolations)
+	require.Zero(result.NumDropped)
+	require.Equal(result.FinalityTimes[len(result.FinalityTimes)-1], result.Duration)
+}
+
+func TestRunDeterministic(t *testing.T) {
+	require := require.New(t)
+
+	config := testConfig()
+	config.NumByzantine = 5
+	config.Strategy = Random{}
+	config.Partitions = []Partition{{
+		Start:    0,
+		End:      2 * time.Second,
+		Fraction: 0.2,
+	}}
+
+	result1, err := Run(context.Background(), config)
+	require.NoError(err)
+	result2, err := Run(context.Background(), config)
+	require.NoError(err)
+	require.Equal(result1, result2)
+
+	config.Seed++
+	result3, err := Run(context.Background(), config)
+	require.NoError(err)
+	require.NotEqual(result1, result3)
+}
+
+func TestRunPartition(t *testing.T) {
+	require := require.New(t)
+
+	config := testConfig()
+	config.Partitions = []Partition{{
+		Start:    0,
+		End:      10 * time.Second,
+		Fraction: 0.5,
+	}}
+
+	result, err := Run(context.Background(), config)
+	require.NoError(err)
+
+	// Neither side of the partition is able to reach alpha while the
+	// partition is active.
+	require.NotZero(result.NumDropped)
+	require.Zero(result.NumUnfinalized)
+	require.GreaterOrEqual(result.FinalityTimes[0], 10*time.Second)
+	require.Empty(result.SafetyViolations)
+}
+
+func TestRunSilentByzantineStall(t *testing.T) {
+	require := require.New(t)
+
+	config := testConfig()
+	config.NumByzantine = 25
+	config.Strategy = Silent{}
+	config.MaxTime = time.Minute
+
+	result, err := Run(context.Background(), config)
+	require.NoError(err)
+
+	// With half of the nodes silent, polls can't reach alpha.
+	require.Equal(25, result.NumHonest)
+	require.Equal(25, result.NumUnfinalized)
+	require.Empty(result.FinalityTimes)
+}
+
+func TestRunInvalidConfig(t *testing.T) {
+	require := require.New(t)
+
+	config := testConfig()
+	config.NumNodes = config.Params.K
+	_, err := Run(context.Background(), config)
+	require.ErrorIs(err, errNotEnoughNodes)
+
+	config = testConfig()
+	config.NumSlow = 1
+	_, err = Run(context.Background(), config)
+	require.ErrorIs(err, errNoSlowLatency)
+
+	config = testConfig()
+	config.Sampling.Strategy = "unknown"
+	_, err = Run(context.Background(), config)
+	require.ErrorIs(err, sampling.ErrUnknownStrategy)
+}
+
+func TestRunSamplingDeterministic(t *testing.T) {
+	require := require.New(t)
+
+	config := testConfig()
+	config.NumByzantine = 5
+	config.Strategy = Silent{}
+	config.NumSlow = 5
+	config.SlowLatency = Constant{Delay: 400 * time.Millisecond}
+	config.Sampling.Strategy = sampling.LatencyStrategy
+
+	result1, err := Run(context.Background(), config)
+	require.NoError(err)
+	result2, err := Run(context.Background(), config)
+	require.NoError(err)
+	require.Equal(result1, result2)
+}
+
+func TestRunStakeCappedSampling(t *testing.T) {
+	require := require.New(t)
+
+	config := testConfig()
+	config.NumByzantine = 5
+	config.ByzantineWeight = 20
+	config.Strategy = Silent{}
+	config.MaxTime = time.Minute
+	config.Sampling.Strategy = sampling.StakeStrategy
+
+	// The silent nodes hold most of the stake, so polls sampled by stake
+	// can't reach alpha.
+	result, err := Run(context.Background(), config)
+	require.NoError(err)
+	require.Equal(result.NumHonest, result.NumUnfinalized)
+
+	// Capping every node to 2% of the sampling weight limits the silent nodes
+	// to the weight of an honest node.
+	config.Sampling.Strategy = sampling.StakeCappedStrategy
+	config.Sampling.MaxEntityWeight = .02
+	config.MaxTime = 10 * time.Minute
+	result, err = Run(context.Background(), config)
+	require.NoError(err)
+	require.Zero(result.NumUnfinalized)
+	require.Empty(result.SafetyViolations)
+}
+
+func TestRunBenchlistSampling(t *testing.T) {
+	require := require.New(t)
+
+	config := testConfig()
+	config.NumByzantine = 5
+	config.Strategy = Silent{}
+	config.Sampling.Strategy = sampling.StakeStrategy
+
+	stakeResult, err := Run(context.Background(), config)
+	require.NoError(err)
+	require.Zero(stakeResult.NumUnfinalized)
+
+	// Once the silent nodes are benched, they are no longer sampled.
+	config.Sampling.Strategy = sampling.BenchlistStrategy
+	benchlistResult, err := Run(context.Background(), config)
+	require.NoError(err)
+	require.Zero(benchlistResult.NumUnfinalized)
+	require.Less(benchlistResult.Percentile(50), stakeResult.Percentile(50))
+}
+
+func TestRunLatencySampling(t *testing.T) {
+	require := require.New(t)
+
+	config := testConfig()
+	config.NumSlow = 10
+	config.SlowLatency = Constant{Delay: 400 * time.Millisecond}
+	config.Sampling.Strategy = sampling.StakeStrategy
+
+	stakeResult, err := Run(context.Background(), config)
+	require.NoError(err)
+	require.Zero(stakeResult.NumUnfinalized)
+
+	// Slow nodes are sampled less often, so polls finish sooner.
+	config.Sampling.Strategy = sampling.LatencyStrategy
+	config.Sampling.TargetLatency = 200 * time.Millisecond
+	config.Sampling.MinWeightFactor = .01
+	latencyResult, err := Run(context.Background(), config)
+	require.NoError(err)
+	require.Zero(latencyResult.NumUnfinalized)
+	require.Less(latencyResult.Percentile(50), stakeResult.Percentile(50))
+}
+
+func TestBlocksConflicts(t *testing.T) {
+	require := require.New(t)
+
+	blocks := newBlocks(rand.New(rand.NewSource(0)), genesisID, 50) // #nosec G404
+	for _, blkID := range blocks.IDs() {
+		require.False(blocks.Conflicts(blkID, blkID))
+		require.False(blocks.Conflicts(genesisID, blkID))
+		require.False(blocks.Conflicts(blocks.parents[blkID], blkID))
+	}
+
+	for _, blkID1 := range blocks.IDs() {
+		for _, blkID2 := range blocks.IDs() {
+			if blocks.heights[blkID1] == blocks.heights[blkID2] && blkID1 != blkID2 {
+				require.True(blocks.Conflicts(blkID1, blkID2))
+			}
+		}
+	}
+}
+
+func TestContrarian(t *testing.T) {
+	require := require.New(t)
+
+	rng := rand.New(rand.NewSource(0)) // #nosec G404
+	blocks := newBlocks(rng, genesisID, 20)
+	for _, preference := range blocks.IDs() {
+		vote, ok := Contrarian{}.Vote(rng, blocks, preference)
+		require.True(ok)
+		require.True(blocks.Conflicts(vote, preference))
+	}
+}
+
+func TestParseLatency(t *testing.T) {
+	tests := []struct {
+		input       string
+		expected    Latency
+		expectedErr error
+	}{
+		{
+			input:    "constant:50ms",
+			expected: Constant{Delay: 50 * time.Millisecond},
+		},
+		{
+			input:    "uniform:10ms:20ms",
+			expected: Uniform{Min: 10 * time.Millisecond, Max: 20 * time.Millisecond},
+		},
+		{
+			input:    "normal:100ms:10ms",
+			expected: Normal{Mean: 100 * time.Millisecond, StdDev: 10 * time.Millisecond},
+		},
+		{
+			input:       "uniform:20ms:10ms",
+			expectedErr: errInvalidUniform,
+		},
+		{
+			input:       "constant",
+			expectedErr: errInvalidLatencyFormat,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			require := require.New(t)
+
+			latency, err := ParseLatency(test.input)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expected, latency)
+		})
+	}
+}
+
+func TestParsePartition(t *testing.T) {
+	require := require.New(t)
+
+	p, err := ParsePartition("1s:5s:0.25")
+	require.NoError(err)
+	require.Equal(Partition{Start: time.Second, End: 5 * time.Second, Fraction: 0.25}, p)
+
+	_, err = ParsePartition("5s:1s:0.25")
+	require.ErrorIs(err, errInvalidPartitionWindow)
+
+	_, err = ParsePartition("1s:5s")
+	require.ErrorIs(err, errInvalidPartitionFormat)
+}
+
+func TestReport(t *testing.T) {
+	require := require.New(t)
+
+	result := &Result{
+		NumHonest: 4,
+		FinalityTimes: []time.Duration{
+			time.Second,
+			2 * time.Second,
+			3 * time.Second,
+			4 * time.Second,
+		},
+		SafetyViolations: []SafetyViolation{{
+			Height:             1,
+			BlockID:            ids.Empty.Prefix(1),
+			ConflictingNodeID:  1,
+			ConflictingBlockID: ids.Empty.Prefix(2),
+		}},
+	}
+	require.Equal(3*time.Second, result.Percentile(50))
+	require.Equal(4*time.Second, result.Percentile(99))
+
+	report := result.Report(2)
+	require.Len(report.Histogram, 2)
+	require.Equal(2, report.Histogram[0].Count)
+	require.Equal(2, report.Histogram[1].Count)
+
+	var b bytes.Buffer
+	require.NoError(report.WriteText(&b))
+	require.Contains(b.String(), "safety violations: 1")
+}
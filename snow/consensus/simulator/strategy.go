@@ -0,0 +1,140 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/luxdefi/node/ids"
+)
+
+var (
+	_ Strategy = Silent{}
+	_ Strategy = Random{}
+	_ Strategy = Contrarian{}
+
+	errUnknownStrategy = errors.New("unknown byzantine strategy")
+)
+
+// Strategy determines how a byzantine node answers queries.
+type Strategy interface {
+	// Vote returns the block that is reported as preferred to a querier that
+	// currently prefers [preference]. If false is returned, the query is
+	// never answered.
+	Vote(rng *rand.Rand, blocks *Blocks, preference ids.ID) (ids.ID, bool)
+}
+
+// Silent never answers queries.
+type Silent struct{}
+
+func (Silent) Vote(*rand.Rand, *Blocks, ids.ID) (ids.ID, bool) {
+	return ids.Empty, false
+}
+
+// Random answers every query with a block chosen uniformly at random. Because
+// every query is answered independently, different queriers are told
+// different preferences.
+type Random struct{}
+
+func (Random) Vote(rng *rand.Rand, blocks *Blocks, _ ids.ID) (ids.ID, bool) {
+	return blocks.ids[rng.Intn(len(blocks.ids))], true
+}
+
+// Contrarian answers every query with a random block that conflicts with the
+// querier's preference.
+type Contrarian struct{}
+
+func (Contrarian) Vote(rng *rand.Rand, blocks *Blocks, preference ids.ID) (ids.ID, bool) {
+	var conflicting []ids.ID
+	for _, blkID := range blocks.ids {
+		if blocks.Conflicts(blkID, preference) {
+			conflicting = append(conflicting, blkID)
+		}
+	}
+	if len(conflicting) == 0 {
+		return Random{}.Vote(rng, blocks, preference)
+	}
+	return conflicting[rng.Intn(len(conflicting))], true
+}
+
+// ParseStrategy returns the strategy named [name]. The supported names are
+// "silent", "random", and "contrarian".
+func ParseStrategy(name string) (Strategy, error) {
+	switch name {
+	case "silent":
+		return Silent{}, nil
+	case "random":
+		return Random{}, nil
+	case "contrarian":
+		return Contrarian{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnknownStrategy, name)
+	}
+}
+
+// Blocks is the tree of blocks issued during a simulation.
+type Blocks struct {
+	genesisID ids.ID
+	// ids of the issued blocks, in the order they were generated
+	ids     []ids.ID
+	parents map[ids.ID]ids.ID
+	heights map[ids.ID]uint64
+}
+
+func newBlocks(rng *rand.Rand, genesisID ids.ID, numBlocks int) *Blocks {
+	b := &Blocks{
+		genesisID: genesisID,
+		parents:   make(map[ids.ID]ids.ID, numBlocks),
+		heights: map[ids.ID]uint64{
+			genesisID: 0,
+		},
+	}
+	for i := 0; i < numBlocks; i++ {
+		// Each block is built on either genesis or a previously issued block,
+		// chosen uniformly at random.
+		parentID := genesisID
+		if j := rng.Intn(i + 1); j < i {
+			parentID = b.ids[j]
+		}
+		blkID := ids.Empty.Prefix(uint64(i + 1))
+		b.ids = append(b.ids, blkID)
+		b.parents[blkID] = parentID
+		b.heights[blkID] = b.heights[parentID] + 1
+	}
+	return b
+}
+
+// IDs returns the IDs of the issued blocks.
+func (b *Blocks) IDs() []ids.ID {
+	return b.ids
+}
+
+// Conflicts returns true if neither [blkID1] nor [blkID2] is an ancestor of
+// the other.
+func (b *Blocks) Conflicts(blkID1, blkID2 ids.ID) bool {
+	return !b.isAncestor(blkID1, blkID2) && !b.isAncestor(blkID2, blkID1)
+}
+
+// isAncestor returns true if [ancestor] is [blkID] or one of its ancestors.
+func (b *Blocks) isAncestor(ancestor, blkID ids.ID) bool {
+	ancestorHeight, ok := b.heights[ancestor]
+	if !ok {
+		return false
+	}
+	for {
+		height, ok := b.heights[blkID]
+		if !ok || height < ancestorHeight {
+			return false
+		}
+		if blkID == ancestor {
+			return true
+		}
+		if height == 0 {
+			return false
+		}
+		blkID = b.parents[blkID]
+	}
+}
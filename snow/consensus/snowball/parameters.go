@@ -74,6 +74,13 @@ type Parameters struct {
 	// blocks are processing.
 	OptimalProcessing int `json:"optimalProcessing" yaml:"optimalProcessing"`
 
+	// StakeWeightedPolls determines if polls are decided by the stake of the
+	// responding validators rather than by the number of responses. If true,
+	// AlphaPreference and AlphaConfidence are interpreted as fractions of K of
+	// the polled stake, and polls terminate once the outstanding stake can no
+	// longer change their outcome.
+	StakeWeightedPolls bool `json:"stakeWeightedPolls" yaml:"stakeWeightedPolls"`
+
 	// Reports unhealthy if more than this number of items are outstanding.
 	MaxOutstandingItems int `json:"maxOutstandingItems" yaml:"maxOutstandingItems"`
 
@@ -60,4 +60,8 @@ type Consensus interface {
 	// RecordPoll collects the results of a network poll. Assumes all decisions
 	// have been previously added. Returns if a critical error has occurred.
 	RecordPoll(context.Context, bag.Bag[ids.ID]) error
+
+	// Inspect returns a snapshot of the processing blocks and the most recent
+	// decisions.
+	Inspect() Inspection
 }
@@ -69,6 +69,7 @@ var (
 		ErrorOnAddDecidedBlockTest,
 		ErrorOnAddDuplicateBlockIDTest,
 		RecordPollWithDefaultParameters,
+		InspectTest,
 	}
 
 	errTest = errors.New("non-nil error")
@@ -520,6 +521,91 @@ func RecordPollAcceptAndRejectTest(t *testing.T, factory Factory) {
 	require.Equal(choices.Rejected, secondBlock.Status())
 }
 
+func InspectTest(t *testing.T, factory Factory) {
+	require := require.New(t)
+
+	sm := factory.New()
+
+	ctx := snow.DefaultConsensusContextTest()
+	params := snowball.Parameters{
+		K:                     1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
+		BetaVirtuous:          1,
+		BetaRogue:             2,
+		ConcurrentRepolls:     1,
+		OptimalProcessing:     1,
+		MaxOutstandingItems:   1,
+		MaxItemProcessingTime: 1,
+	}
+	require.NoError(sm.Initialize(ctx, params, GenesisID, GenesisHeight, GenesisTimestamp))
+
+	firstBlock := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(1),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis.IDV,
+		HeightV: Genesis.HeightV + 1,
+	}
+	secondBlock := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(2),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis.IDV,
+		HeightV: Genesis.HeightV + 1,
+	}
+	thirdBlock := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(3),
+			StatusV: choices.Processing,
+		},
+		ParentV: firstBlock.IDV,
+		HeightV: firstBlock.HeightV + 1,
+	}
+
+	require.NoError(sm.Add(context.Background(), firstBlock))
+	require.NoError(sm.Add(context.Background(), secondBlock))
+	require.NoError(sm.Add(context.Background(), thirdBlock))
+
+	inspection := sm.Inspect()
+	require.Equal(GenesisID, inspection.LastAcceptedID)
+	require.Equal(thirdBlock.ID(), inspection.Preference)
+	require.Empty(inspection.RecentDecisions)
+	require.Len(inspection.Blocks, 4)
+
+	genesis := inspection.Blocks[0]
+	require.Equal(GenesisID, genesis.ID)
+	require.Equal(choices.Accepted, genesis.Status)
+	require.ElementsMatch([]ids.ID{firstBlock.ID(), secondBlock.ID()}, genesis.Children)
+	require.Equal(firstBlock.ID(), genesis.PreferredChild)
+	require.NotEmpty(genesis.Snowball)
+
+	third := inspection.Blocks[3]
+	require.Equal(thirdBlock.ID(), third.ID)
+	require.Equal(firstBlock.ID(), third.ParentID)
+	require.Equal(choices.Processing, third.Status)
+	require.True(third.Preferred)
+	require.Empty(third.Children)
+
+	votes := bag.Of(secondBlock.ID())
+	require.NoError(sm.RecordPoll(context.Background(), votes))
+	require.NoError(sm.RecordPoll(context.Background(), votes))
+
+	inspection = sm.Inspect()
+	require.Equal(secondBlock.ID(), inspection.LastAcceptedID)
+	require.Equal(uint64(2), inspection.NumPolls)
+	require.Len(inspection.Blocks, 1)
+	require.Len(inspection.RecentDecisions, 3)
+	require.Equal(secondBlock.ID(), inspection.RecentDecisions[0].ID)
+	require.Equal(choices.Accepted, inspection.RecentDecisions[0].Status)
+	require.Equal(firstBlock.ID(), inspection.RecentDecisions[1].ID)
+	require.Equal(choices.Rejected, inspection.RecentDecisions[1].Status)
+	require.Equal(thirdBlock.ID(), inspection.RecentDecisions[2].ID)
+	require.Equal(choices.Rejected, inspection.RecentDecisions[2].Status)
+}
+
 func RecordPollSplitVoteNoChangeTest(t *testing.T, factory Factory) {
 	require := require.New(t)
 	sm := factory.New()
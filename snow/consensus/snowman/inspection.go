@@ -0,0 +1,63 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowman
+
+import (
+	"time"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/snow/choices"
+)
+
+// maxRecentDecisions is the number of decisions that are reported by Inspect.
+const maxRecentDecisions = 128
+
+// Inspection is a snapshot of the state of a Consensus instance.
+type Inspection struct {
+	LastAcceptedID     ids.ID `json:"lastAcceptedID"`
+	LastAcceptedHeight uint64 `json:"lastAcceptedHeight"`
+	Preference         ids.ID `json:"preference"`
+	NumPolls           uint64 `json:"numPolls"`
+	// Blocks contains the last accepted block followed by every processing
+	// block. Blocks are sorted by height.
+	Blocks []BlockState `json:"blocks"`
+	// RecentDecisions contains the most recently accepted and rejected
+	// blocks, from oldest to newest.
+	RecentDecisions []Decision `json:"recentDecisions"`
+}
+
+// BlockState describes a block in the processing tree.
+type BlockState struct {
+	ID       ids.ID         `json:"id"`
+	ParentID ids.ID         `json:"parentID"`
+	Height   uint64         `json:"height"`
+	Status   choices.Status `json:"status"`
+	// Preferred is true if the block is on the preferred chain.
+	Preferred bool `json:"preferred"`
+	// Children are the processing blocks that name this block as their
+	// parent.
+	Children []ids.ID `json:"children"`
+	// PreferredChild is the child currently preferred by the snowball
+	// instance deciding between Children. Empty if there are no children.
+	PreferredChild ids.ID `json:"preferredChild"`
+	// Snowball describes the state of the snowball instance deciding between
+	// Children, including the confidence of each of its decisions. Empty if
+	// there are no children.
+	Snowball string `json:"snowball"`
+}
+
+func (b BlockState) Less(other BlockState) bool {
+	if b.Height != other.Height {
+		return b.Height < other.Height
+	}
+	return b.ID.Less(other.ID)
+}
+
+// Decision describes a block that was accepted or rejected.
+type Decision struct {
+	ID     ids.ID         `json:"id"`
+	Height uint64         `json:"height"`
+	Status choices.Status `json:"status"`
+	Time   time.Time      `json:"time"`
+}
@@ -88,6 +88,10 @@ func (p *earlyTermNoTraversalPoll) Result() bag.Bag[ids.ID] {
 	return p.votes
 }
 
+func (p *earlyTermNoTraversalPoll) Pending() []ids.NodeID {
+	return p.polled.List()
+}
+
 func (p *earlyTermNoTraversalPoll) PrefixedString(prefix string) string {
 	return fmt.Sprintf(
 		"waiting on %s\n%sreceived %s",
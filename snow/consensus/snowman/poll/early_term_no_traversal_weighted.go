@@ -0,0 +1,183 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package poll
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/bag"
+)
+
+type earlyTermNoTraversalWeightedFactory struct {
+	alphaPreference int
+	alphaConfidence int
+	weight          func(ids.NodeID) uint64
+}
+
+// NewEarlyTermNoTraversalWeightedFactory returns a factory that creates polls
+// whose outcome is determined by the stake of the responding validators rather
+// than by the number of responses.
+//
+// Each distinct validator in a poll contributes its [weight], regardless of
+// how many times it was sampled. An element reaches an alpha majority once the
+// stake voting for it is at least alpha/k of the polled stake, where k is the
+// number of sampled validators. The result of the poll is scaled to k votes so
+// that it can be applied to consensus like an unweighted result.
+func NewEarlyTermNoTraversalWeightedFactory(
+	alphaPreference int,
+	alphaConfidence int,
+	weight func(ids.NodeID) uint64,
+) Factory {
+	return &earlyTermNoTraversalWeightedFactory{
+		alphaPreference: alphaPreference,
+		alphaConfidence: alphaConfidence,
+		weight:          weight,
+	}
+}
+
+func (f *earlyTermNoTraversalWeightedFactory) New(vdrs bag.Bag[ids.NodeID]) Poll {
+	p := &earlyTermNoTraversalWeightedPoll{
+		polled:          vdrs,
+		k:               uint64(vdrs.Len()),
+		alphaPreference: uint64(f.alphaPreference),
+		alphaConfidence: uint64(f.alphaConfidence),
+		weights:         make(map[ids.NodeID]uint64),
+	}
+	for _, vdr := range vdrs.List() {
+		weight := f.weight(vdr)
+		p.weights[vdr] = weight
+		p.totalWeight += weight
+		p.remainingWeight += weight
+	}
+	return p
+}
+
+// earlyTermNoTraversalWeightedPoll finishes when any remaining validators
+// can't change the result of the poll, measured in stake.
+type earlyTermNoTraversalWeightedPoll struct {
+	votes  bag.Bag[ids.ID]
+	polled bag.Bag[ids.NodeID]
+	// k is the number of sampled validators, including duplicates
+	k               uint64
+	alphaPreference uint64
+	alphaConfidence uint64
+
+	// weight of each polled validator when the poll was created
+	weights map[ids.NodeID]uint64
+	// totalWeight is the sum of the weights of the polled validators
+	totalWeight uint64
+	// remainingWeight is the weight of the validators that haven't responded
+	remainingWeight uint64
+	// votedWeight is the weight voting for each element
+	votedWeight map[ids.ID]uint64
+	// receivedWeight is the weight of the validators that have voted
+	receivedWeight uint64
+}
+
+// Vote registers a response for this poll
+func (p *earlyTermNoTraversalWeightedPoll) Vote(vdr ids.NodeID, vote ids.ID) {
+	if p.polled.Count(vdr) == 0 {
+		// make sure that a validator can't respond multiple times
+		return
+	}
+	p.polled.Remove(vdr)
+
+	weight := p.weights[vdr]
+	p.remainingWeight -= weight
+	p.receivedWeight += weight
+	if p.votedWeight == nil {
+		p.votedWeight = make(map[ids.ID]uint64)
+	}
+	p.votedWeight[vote] += weight
+}
+
+// Drop any future response for this poll
+func (p *earlyTermNoTraversalWeightedPoll) Drop(vdr ids.NodeID) {
+	if p.polled.Count(vdr) == 0 {
+		return
+	}
+	p.polled.Remove(vdr)
+	p.remainingWeight -= p.weights[vdr]
+}
+
+// Finished returns true when one of the following conditions is met.
+//
+//  1. There are no outstanding votes.
+//  2. It is impossible for the poll to achieve an alphaPreference majority of
+//     stake after applying transitive voting.
+//  3. A single element has achieved an alphaPreference majority of stake and
+//     it is impossible for it to achieve an alphaConfidence majority of stake
+//     after applying transitive voting.
+//  4. A single element has achieved an alphaConfidence majority of stake.
+func (p *earlyTermNoTraversalWeightedPoll) Finished() bool {
+	if p.polled.Len() == 0 {
+		return true // Case 1
+	}
+
+	maxPossibleWeight := p.receivedWeight + p.remainingWeight
+	if !p.reaches(maxPossibleWeight, p.alphaPreference) {
+		return true // Case 2
+	}
+
+	var modeWeight uint64
+	for _, weight := range p.votedWeight {
+		if weight > modeWeight {
+			modeWeight = weight
+		}
+	}
+	return p.reaches(modeWeight, p.alphaPreference) && !p.reaches(maxPossibleWeight, p.alphaConfidence) || // Case 3
+		p.reaches(modeWeight, p.alphaConfidence) // Case 4
+}
+
+// reaches returns true if [weight] is at least [alpha]/k of the polled weight.
+func (p *earlyTermNoTraversalWeightedPoll) reaches(weight uint64, alpha uint64) bool {
+	// weight * k >= alpha * totalWeight is calculated using 128 bits to avoid
+	// overflows.
+	weightHi, weightLo := bits.Mul64(weight, p.k)
+	alphaHi, alphaLo := bits.Mul64(alpha, p.totalWeight)
+	return weightHi > alphaHi || weightHi == alphaHi && weightLo >= alphaLo
+}
+
+// Result returns the result of this poll. The weight voting for each element
+// is scaled to the number of sampled validators, so an element reaches an
+// alpha majority in the result iff it reached one in stake.
+func (p *earlyTermNoTraversalWeightedPoll) Result() bag.Bag[ids.ID] {
+	var result bag.Bag[ids.ID]
+	if p.totalWeight == 0 {
+		return result
+	}
+	for vote, weight := range p.votedWeight {
+		// weight <= totalWeight, so the quotient is at most k and fits in 64
+		// bits.
+		hi, lo := bits.Mul64(weight, p.k)
+		count, _ := bits.Div64(hi, lo, p.totalWeight)
+		if count > 0 {
+			result.AddCount(vote, int(count))
+		}
+	}
+	return result
+}
+
+func (p *earlyTermNoTraversalWeightedPoll) Pending() []ids.NodeID {
+	return p.polled.List()
+}
+
+func (p *earlyTermNoTraversalWeightedPoll) PrefixedString(prefix string) string {
+	result := p.Result()
+	return fmt.Sprintf(
+		"waiting on %s\n%swaiting on weight %d of %d\n%sreceived %s",
+		p.polled.PrefixedString(prefix),
+		prefix,
+		p.remainingWeight,
+		p.totalWeight,
+		prefix,
+		result.PrefixedString(prefix),
+	)
+}
+
+func (p *earlyTermNoTraversalWeightedPoll) String() string {
+	return p.PrefixedString("")
+}
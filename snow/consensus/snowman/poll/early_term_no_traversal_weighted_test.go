@@ -0,0 +1,160 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package poll
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/bag"
+)
+
+func weights(w map[ids.NodeID]uint64) func(ids.NodeID) uint64 {
+	return func(nodeID ids.NodeID) uint64 {
+		return w[nodeID]
+	}
+}
+
+func TestEarlyTermNoTraversalWeightedResults(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1, vdr2, vdr3, vdr4) // k = 4
+	alpha := 3
+
+	factory := NewEarlyTermNoTraversalWeightedFactory(alpha, alpha, weights(map[ids.NodeID]uint64{
+		vdr1: 1,
+		vdr2: 1,
+		vdr3: 1,
+		vdr4: 1,
+	}))
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr1, blkID1)
+	require.False(poll.Finished())
+	poll.Vote(vdr2, blkID1)
+	require.False(poll.Finished())
+	poll.Vote(vdr3, blkID1)
+	require.True(poll.Finished())
+
+	result := poll.Result()
+	require.Equal([]ids.ID{blkID1}, result.List())
+	require.Equal(3, result.Count(blkID1))
+}
+
+func TestEarlyTermNoTraversalWeightedTerminatesOnStake(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1, vdr2, vdr3, vdr4, vdr5) // k = 5
+	alphaPreference := 3
+	alphaConfidence := 4
+
+	factory := NewEarlyTermNoTraversalWeightedFactory(alphaPreference, alphaConfidence, weights(map[ids.NodeID]uint64{
+		vdr1: 100,
+		vdr2: 1,
+		vdr3: 1,
+		vdr4: 1,
+		vdr5: 1,
+	}))
+	poll := factory.New(vdrs)
+
+	// A single validator holding more than alphaConfidence/k of the polled
+	// stake determines the outcome.
+	poll.Vote(vdr1, blkID1)
+	require.True(poll.Finished())
+
+	result := poll.Result()
+	require.Equal([]ids.ID{blkID1}, result.List())
+	require.Equal(4, result.Count(blkID1))
+	require.ElementsMatch([]ids.NodeID{vdr2, vdr3, vdr4, vdr5}, poll.Pending())
+}
+
+func TestEarlyTermNoTraversalWeightedTerminatesOnDrop(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1, vdr2, vdr3) // k = 3
+	alpha := 2
+
+	factory := NewEarlyTermNoTraversalWeightedFactory(alpha, alpha, weights(map[ids.NodeID]uint64{
+		vdr1: 10,
+		vdr2: 1,
+		vdr3: 1,
+	}))
+	poll := factory.New(vdrs)
+
+	// Without vdr1's stake, alphaPreference can't be reached.
+	poll.Drop(vdr1)
+	require.True(poll.Finished())
+	result := poll.Result()
+	require.Zero(result.Len())
+}
+
+func TestEarlyTermNoTraversalWeightedDuplicateSamples(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1, vdr1, vdr2) // k = 3
+	alpha := 2
+
+	factory := NewEarlyTermNoTraversalWeightedFactory(alpha, alpha, weights(map[ids.NodeID]uint64{
+		vdr1: 1,
+		vdr2: 1,
+	}))
+	poll := factory.New(vdrs)
+
+	// vdr1 only contributes its stake once, despite being sampled twice.
+	poll.Vote(vdr1, blkID1)
+	require.False(poll.Finished())
+
+	// Duplicate votes are dropped.
+	poll.Vote(vdr1, blkID2)
+	require.False(poll.Finished())
+
+	poll.Vote(vdr2, blkID1)
+	require.True(poll.Finished())
+
+	result := poll.Result()
+	require.Equal([]ids.ID{blkID1}, result.List())
+	require.Equal(3, result.Count(blkID1))
+}
+
+func TestEarlyTermNoTraversalWeightedNoOverflow(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1, vdr2) // k = 2
+	alpha := 2
+
+	factory := NewEarlyTermNoTraversalWeightedFactory(alpha, alpha, weights(map[ids.NodeID]uint64{
+		vdr1: math.MaxUint64 / 2,
+		vdr2: math.MaxUint64 / 2,
+	}))
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr1, blkID1)
+	require.False(poll.Finished())
+	poll.Vote(vdr2, blkID1)
+	require.True(poll.Finished())
+	result := poll.Result()
+	require.Equal(2, result.Count(blkID1))
+}
+
+func TestEarlyTermNoTraversalWeightedString(t *testing.T) {
+	vdrs := bag.Of(vdr1, vdr2) // k = 2
+	alpha := 2
+
+	factory := NewEarlyTermNoTraversalWeightedFactory(alpha, alpha, weights(map[ids.NodeID]uint64{
+		vdr1: 1,
+		vdr2: 3,
+	}))
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr1, blkID1)
+
+	expected := `waiting on Bag[ids.NodeID]: (Size = 1)
+    NodeID-BaMPFdqMUQ46BV8iRcwbVfsam55kMqcp: 1
+waiting on weight 3 of 4
+received Bag[ids.ID]: (Size = 0)`
+	require.Equal(t, expected, poll.String())
+}
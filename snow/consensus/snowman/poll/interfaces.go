@@ -19,6 +19,8 @@ type Set interface {
 	Vote(requestID uint32, vdr ids.NodeID, vote ids.ID) []bag.Bag[ids.ID]
 	Drop(requestID uint32, vdr ids.NodeID) []bag.Bag[ids.ID]
 	Len() int
+	// Polls returns the outstanding polls from oldest to newest.
+	Polls() []Info
 }
 
 // Poll is an outstanding poll
@@ -29,6 +31,8 @@ type Poll interface {
 	Drop(vdr ids.NodeID)
 	Finished() bool
 	Result() bag.Bag[ids.ID]
+	// Pending returns the validators that haven't responded to the poll.
+	Pending() []ids.NodeID
 }
 
 // Factory creates a new Poll
@@ -25,6 +25,17 @@ var (
 	errFailedPollDurationMetrics = errors.New("failed to register poll_duration metrics")
 )
 
+// Info describes an outstanding poll.
+type Info struct {
+	RequestID uint32    `json:"requestID"`
+	StartTime time.Time `json:"startTime"`
+	// Pending are the validators that haven't responded to the poll.
+	Pending []ids.NodeID `json:"pending"`
+	// Votes maps each block that was voted for to the number of votes it
+	// received.
+	Votes map[ids.ID]int `json:"votes"`
+}
+
 type pollHolder interface {
 	GetPoll() Poll
 	StartTime() time.Time
@@ -205,6 +216,28 @@ func (s *set) Len() int {
 	return s.polls.Len()
 }
 
+func (s *set) Polls() []Info {
+	polls := make([]Info, 0, s.polls.Len())
+	iter := s.polls.NewIterator()
+	for iter.Next() {
+		holder := iter.Value()
+		p := holder.GetPoll()
+		result := p.Result()
+		blkIDs := result.List()
+		votes := make(map[ids.ID]int, len(blkIDs))
+		for _, blkID := range blkIDs {
+			votes[blkID] = result.Count(blkID)
+		}
+		polls = append(polls, Info{
+			RequestID: iter.Key(),
+			StartTime: holder.StartTime(),
+			Pending:   p.Pending(),
+			Votes:     votes,
+		})
+	}
+	return polls
+}
+
 func (s *set) String() string {
 	sb := strings.Builder{}
 	sb.WriteString(fmt.Sprintf("current polls: (Size = %d)", s.polls.Len()))
@@ -265,3 +265,34 @@ func TestSetString(t *testing.T) {
 	require.True(s.Add(0, vdrs))
 	require.Equal(expected, s.String())
 }
+
+func TestSetPolls(t *testing.T) {
+	require := require.New(t)
+
+	alpha := 3
+
+	factory := NewEarlyTermNoTraversalFactory(alpha, alpha)
+	log := logging.NoLog{}
+	namespace := ""
+	registerer := prometheus.NewRegistry()
+	s, err := NewSet(factory, log, namespace, registerer)
+	require.NoError(err)
+
+	require.Empty(s.Polls())
+
+	require.True(s.Add(0, bag.Of(vdr1, vdr2, vdr3)))
+	require.True(s.Add(1, bag.Of(vdr4, vdr5, vdr1)))
+	require.Empty(s.Vote(0, vdr1, blkID1))
+	require.Empty(s.Drop(1, vdr4))
+
+	polls := s.Polls()
+	require.Len(polls, 2)
+
+	require.Equal(uint32(0), polls[0].RequestID)
+	require.ElementsMatch([]ids.NodeID{vdr2, vdr3}, polls[0].Pending)
+	require.Equal(map[ids.ID]int{blkID1: 1}, polls[0].Votes)
+
+	require.Equal(uint32(1), polls[1].RequestID)
+	require.ElementsMatch([]ids.NodeID{vdr5, vdr1}, polls[1].Pending)
+	require.Empty(polls[1].Votes)
+}
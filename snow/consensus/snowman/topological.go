@@ -17,7 +17,9 @@ import (
 	"github.com/luxdefi/node/snow"
 	"github.com/luxdefi/node/snow/choices"
 	"github.com/luxdefi/node/snow/consensus/snowball"
+	"github.com/luxdefi/node/utils"
 	"github.com/luxdefi/node/utils/bag"
+	"github.com/luxdefi/node/utils/buffer"
 	"github.com/luxdefi/node/utils/set"
 )
 
@@ -80,6 +82,9 @@ type Topological struct {
 	// We use this one map instead of creating a new map
 	// during each call to [calculateInDegree].
 	kahnNodes map[ids.ID]kahnNode
+
+	// decisions contains the most recently decided blocks
+	decisions buffer.Queue[Decision]
 }
 
 // Used to track the kahn topological sort status
@@ -122,6 +127,11 @@ func (ts *Topological) Initialize(
 		return err
 	}
 
+	ts.decisions, err = buffer.NewBoundedQueue[Decision](maxRecentDecisions, nil)
+	if err != nil {
+		return err
+	}
+
 	ts.leaves = set.Set[ids.ID]{}
 	ts.kahnNodes = make(map[ids.ID]kahnNode)
 	ts.ctx = ctx
@@ -332,6 +342,42 @@ func (ts *Topological) RecordPoll(ctx context.Context, voteBag bag.Bag[ids.ID])
 	return nil
 }
 
+func (ts *Topological) Inspect() Inspection {
+	blocks := make([]BlockState, 0, len(ts.blocks))
+	for blkID, n := range ts.blocks {
+		state := BlockState{
+			ID:        blkID,
+			Preferred: ts.preferredIDs.Contains(blkID),
+		}
+		if n.blk == nil {
+			// The genesis block is only represented by its ID.
+			state.Height = ts.lastAcceptedHeight
+			state.Status = choices.Accepted
+		} else {
+			state.ParentID = n.blk.Parent()
+			state.Height = n.blk.Height()
+			state.Status = n.blk.Status()
+		}
+		if n.sb != nil {
+			state.Children = maps.Keys(n.children)
+			utils.Sort(state.Children)
+			state.PreferredChild = n.sb.Preference()
+			state.Snowball = n.sb.String()
+		}
+		blocks = append(blocks, state)
+	}
+	utils.Sort(blocks)
+
+	return Inspection{
+		LastAcceptedID:     ts.lastAcceptedID,
+		LastAcceptedHeight: ts.lastAcceptedHeight,
+		Preference:         ts.preference,
+		NumPolls:           ts.pollNumber,
+		Blocks:             blocks,
+		RecentDecisions:    ts.decisions.List(),
+	}
+}
+
 // HealthCheck returns information about the consensus health.
 func (ts *Topological) HealthCheck(context.Context) (interface{}, error) {
 	var errs []error
@@ -631,6 +677,7 @@ func (ts *Topological) acceptPreferredChild(ctx context.Context, n *snowmanBlock
 	if err := child.Accept(ctx); err != nil {
 		return err
 	}
+	ts.recordDecision(pref, height, choices.Accepted)
 
 	// Update the last accepted values to the newly accepted block.
 	ts.lastAcceptedID = pref
@@ -668,6 +715,7 @@ func (ts *Topological) acceptPreferredChild(ctx context.Context, n *snowmanBlock
 			return err
 		}
 		ts.metrics.Rejected(childID, ts.pollNumber, len(child.Bytes()))
+		ts.recordDecision(childID, child.Height(), choices.Rejected)
 
 		// Track which blocks have been directly rejected
 		rejects = append(rejects, childID)
@@ -702,6 +750,7 @@ func (ts *Topological) rejectTransitively(ctx context.Context, rejected []ids.ID
 				return err
 			}
 			ts.metrics.Rejected(childID, ts.pollNumber, len(child.Bytes()))
+			ts.recordDecision(childID, child.Height(), choices.Rejected)
 
 			// add the newly rejected block to the end of the stack
 			rejected = append(rejected, childID)
@@ -709,3 +758,12 @@ func (ts *Topological) rejectTransitively(ctx context.Context, rejected []ids.ID
 	}
 	return nil
 }
+
+func (ts *Topological) recordDecision(blkID ids.ID, height uint64, status choices.Status) {
+	ts.decisions.Push(Decision{
+		ID:     blkID,
+		Height: height,
+		Status: status,
+		Time:   time.Now(),
+	})
+}
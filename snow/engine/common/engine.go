@@ -220,6 +220,18 @@ type GetAncestorsHandler interface {
 		requestID uint32,
 		containerID ids.ID,
 	) error
+
+	// Notify this engine of a request for an Ancestors message with the same
+	// requestID, the accepted container at height, and some of its ancestors
+	// on a best effort basis.
+	//
+	// This function can be called by any node at any time.
+	GetAncestorsAtHeight(
+		ctx context.Context,
+		nodeID ids.NodeID,
+		requestID uint32,
+		height uint64,
+	) error
 }
 
 type AncestorsHandler interface {
@@ -258,6 +258,18 @@ func (mr *MockSenderMockRecorder) SendGetAncestors(arg0, arg1, arg2, arg3 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendGetAncestors", reflect.TypeOf((*MockSender)(nil).SendGetAncestors), arg0, arg1, arg2, arg3)
 }
 
+// SendGetAncestorsAtHeight mocks base method.
+func (m *MockSender) SendGetAncestorsAtHeight(arg0 context.Context, arg1 ids.NodeID, arg2 uint32, arg3 uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SendGetAncestorsAtHeight", arg0, arg1, arg2, arg3)
+}
+
+// SendGetAncestorsAtHeight indicates an expected call of SendGetAncestorsAtHeight.
+func (mr *MockSenderMockRecorder) SendGetAncestorsAtHeight(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendGetAncestorsAtHeight", reflect.TypeOf((*MockSender)(nil).SendGetAncestorsAtHeight), arg0, arg1, arg2, arg3)
+}
+
 // SendGetStateSummaryFrontier mocks base method.
 func (m *MockSender) SendGetStateSummaryFrontier(arg0 context.Context, arg1 set.Set[ids.NodeID], arg2 uint32) {
 	m.ctrl.T.Helper()
@@ -141,54 +141,13 @@ func (j *Jobs) ExecuteAll(
 			return numExecuted, nil
 		}
 
-		job, err := j.state.RemoveRunnableJob(ctx)
-		if err == database.ErrNotFound {
-			break
-		}
+		executed, err := j.executeNext(ctx, chainCtx, acceptors)
 		if err != nil {
-			return 0, fmt.Errorf("failed to removing runnable job with %w", err)
-		}
-
-		jobID := job.ID()
-		chainCtx.Log.Debug("executing",
-			zap.Stringer("jobID", jobID),
-		)
-		jobBytes := job.Bytes()
-		// Note that acceptor.Accept must be called before executing [job] to
-		// honor Acceptor.Accept's invariant.
-		for _, acceptor := range acceptors {
-			if err := acceptor.Accept(chainCtx, jobID, jobBytes); err != nil {
-				return numExecuted, err
-			}
-		}
-		if err := job.Execute(ctx); err != nil {
-			return 0, fmt.Errorf("failed to execute job %s due to %w", jobID, err)
-		}
-
-		dependentIDs, err := j.state.RemoveDependencies(jobID)
-		if err != nil {
-			return 0, fmt.Errorf("failed to remove blocking jobs for %s due to %w", jobID, err)
-		}
-
-		for _, dependentID := range dependentIDs {
-			job, err := j.state.GetJob(ctx, dependentID)
-			if err != nil {
-				return 0, fmt.Errorf("failed to get job %s from blocking jobs due to %w", dependentID, err)
-			}
-			hasMissingDeps, err := job.HasMissingDependencies(ctx)
-			if err != nil {
-				return 0, fmt.Errorf("failed to get missing dependencies for %s due to %w", dependentID, err)
-			}
-			if hasMissingDeps {
-				continue
-			}
-			if err := j.state.AddRunnableJob(dependentID); err != nil {
-				return 0, fmt.Errorf("failed to add %s as a runnable job due to %w", dependentID, err)
-			}
-		}
-		if err := j.Commit(); err != nil {
 			return 0, err
 		}
+		if !executed {
+			break
+		}
 
 		numExecuted++
 		if time.Since(lastProgressUpdate) > progressUpdateFrequency { // Periodically print progress
@@ -232,6 +191,90 @@ func (j *Jobs) ExecuteAll(
 	return numExecuted, nil
 }
 
+// ExecuteRunnable executes at most [maxToExecute] of the jobs that are
+// currently runnable. Unlike ExecuteAll, the chain isn't marked as executing,
+// so that responses to outstanding requests can continue to be handled while
+// the remaining jobs are being fetched.
+//
+// Caching is left enabled, as the jobs are still being fetched. It is only
+// disabled once ExecuteAll is called.
+func (j *Jobs) ExecuteRunnable(
+	ctx context.Context,
+	chainCtx *snow.ConsensusContext,
+	halter common.Haltable,
+	maxToExecute int,
+	acceptors ...snow.Acceptor,
+) (int, error) {
+	numExecuted := 0
+	for numExecuted < maxToExecute && !halter.Halted() {
+		executed, err := j.executeNext(ctx, chainCtx, acceptors)
+		if err != nil {
+			return numExecuted, err
+		}
+		if !executed {
+			break
+		}
+		numExecuted++
+	}
+	return numExecuted, nil
+}
+
+// executeNext executes the next runnable job, if there is one, and marks any
+// of its dependents that no longer have missing dependencies as runnable.
+// Returns false if there were no runnable jobs.
+func (j *Jobs) executeNext(
+	ctx context.Context,
+	chainCtx *snow.ConsensusContext,
+	acceptors []snow.Acceptor,
+) (bool, error) {
+	job, err := j.state.RemoveRunnableJob(ctx)
+	if err == database.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to removing runnable job with %w", err)
+	}
+
+	jobID := job.ID()
+	chainCtx.Log.Debug("executing",
+		zap.Stringer("jobID", jobID),
+	)
+	jobBytes := job.Bytes()
+	// Note that acceptor.Accept must be called before executing [job] to
+	// honor Acceptor.Accept's invariant.
+	for _, acceptor := range acceptors {
+		if err := acceptor.Accept(chainCtx, jobID, jobBytes); err != nil {
+			return false, err
+		}
+	}
+	if err := job.Execute(ctx); err != nil {
+		return false, fmt.Errorf("failed to execute job %s due to %w", jobID, err)
+	}
+
+	dependentIDs, err := j.state.RemoveDependencies(jobID)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove blocking jobs for %s due to %w", jobID, err)
+	}
+
+	for _, dependentID := range dependentIDs {
+		job, err := j.state.GetJob(ctx, dependentID)
+		if err != nil {
+			return false, fmt.Errorf("failed to get job %s from blocking jobs due to %w", dependentID, err)
+		}
+		hasMissingDeps, err := job.HasMissingDependencies(ctx)
+		if err != nil {
+			return false, fmt.Errorf("failed to get missing dependencies for %s due to %w", dependentID, err)
+		}
+		if hasMissingDeps {
+			continue
+		}
+		if err := j.state.AddRunnableJob(dependentID); err != nil {
+			return false, fmt.Errorf("failed to add %s as a runnable job due to %w", dependentID, err)
+		}
+	}
+	return true, j.Commit()
+}
+
 func (j *Jobs) Clear() error {
 	return j.state.Clear()
 }
@@ -197,6 +197,81 @@ func TestRemoveDependency(t *testing.T) {
 	require.Equal(bootstrapProgressCheckpointSize, dbSize)
 }
 
+// Test that ExecuteRunnable only executes up to the provided number of jobs,
+// doesn't mark the chain as executing and doesn't disable caching.
+func TestExecuteRunnable(t *testing.T) {
+	require := require.New(t)
+
+	parser := &TestParser{T: t}
+	db := memdb.New()
+
+	jobs, err := New(db, "", prometheus.NewRegistry())
+	require.NoError(err)
+	require.NoError(jobs.SetParser(parser))
+
+	job0ID, executed0 := ids.GenerateTestID(), false
+	job1ID, executed1 := ids.GenerateTestID(), false
+	job2ID, executed2 := ids.GenerateTestID(), false
+
+	job0 := testJob(t, job0ID, &executed0, ids.Empty, nil)
+	job1 := testJob(t, job1ID, &executed1, job0ID, &executed0)
+	job1.BytesF = func() []byte {
+		return []byte{1}
+	}
+	job2 := testJob(t, job2ID, &executed2, job1ID, &executed1)
+	job2.BytesF = func() []byte {
+		return []byte{2}
+	}
+
+	for _, job := range []Job{job2, job1, job0} {
+		pushed, err := jobs.Push(context.Background(), job)
+		require.True(pushed)
+		require.NoError(err)
+	}
+
+	parser.ParseF = func(_ context.Context, b []byte) (Job, error) {
+		switch {
+		case bytes.Equal(b, []byte{0}):
+			return job0, nil
+		case bytes.Equal(b, []byte{1}):
+			return job1, nil
+		case bytes.Equal(b, []byte{2}):
+			return job2, nil
+		default:
+			require.FailNow("Unknown job")
+			return nil, nil
+		}
+	}
+
+	chainCtx := snow.DefaultConsensusContextTest()
+	job0.ExecuteF = func(context.Context) error {
+		require.False(chainCtx.Executing.Get())
+		executed0 = true
+		return nil
+	}
+
+	count, err := jobs.ExecuteRunnable(context.Background(), chainCtx, &common.Halter{}, 2)
+	require.NoError(err)
+	require.Equal(2, count)
+	require.True(executed0)
+	require.True(executed1)
+	require.False(executed2)
+	require.Equal(uint64(1), jobs.PendingJobs())
+
+	// Caching should only be disabled by ExecuteAll.
+	require.True(jobs.state.cachingEnabled)
+
+	count, err = jobs.ExecuteRunnable(context.Background(), chainCtx, &common.Halter{}, 2)
+	require.NoError(err)
+	require.Equal(1, count)
+	require.True(executed2)
+
+	count, err = jobs.ExecuteRunnable(context.Background(), chainCtx, &common.Halter{}, 2)
+	require.NoError(err)
+	require.Zero(count)
+	require.Zero(jobs.PendingJobs())
+}
+
 // Test that a job that is ready to be executed can only be added once
 func TestDuplicatedExecutablePush(t *testing.T) {
 	require := require.New(t)
@@ -169,6 +169,17 @@ func (e *tracedEngine) GetAncestors(ctx context.Context, nodeID ids.NodeID, requ
 	return e.engine.GetAncestors(ctx, nodeID, requestID, containerID)
 }
 
+func (e *tracedEngine) GetAncestorsAtHeight(ctx context.Context, nodeID ids.NodeID, requestID uint32, height uint64) error {
+	ctx, span := e.tracer.Start(ctx, "tracedEngine.GetAncestorsAtHeight", oteltrace.WithAttributes(
+		attribute.Stringer("nodeID", nodeID),
+		attribute.Int64("requestID", int64(requestID)),
+		attribute.Int64("height", int64(height)),
+	))
+	defer span.End()
+
+	return e.engine.GetAncestorsAtHeight(ctx, nodeID, requestID, height)
+}
+
 func (e *tracedEngine) Ancestors(ctx context.Context, nodeID ids.NodeID, requestID uint32, containers [][]byte) error {
 	ctx, span := e.tracer.Start(ctx, "tracedEngine.Ancestors", oteltrace.WithAttributes(
 		attribute.Stringer("nodeID", nodeID),
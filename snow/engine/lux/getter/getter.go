@@ -107,6 +107,17 @@ func (gh *getter) GetAccepted(ctx context.Context, nodeID ids.NodeID, requestID
 	return nil
 }
 
+// GetAncestorsAtHeight is dropped, as vertices are not indexed by height.
+func (gh *getter) GetAncestorsAtHeight(_ context.Context, nodeID ids.NodeID, requestID uint32, height uint64) error {
+	gh.log.Debug("dropping GetAncestorsAtHeight message",
+		zap.String("reason", "vertices are not indexed by height"),
+		zap.Stringer("nodeID", nodeID),
+		zap.Uint32("requestID", requestID),
+		zap.Uint64("height", height),
+	)
+	return nil
+}
+
 func (gh *getter) GetAncestors(ctx context.Context, nodeID ids.NodeID, requestID uint32, vtxID ids.ID) error {
 	startTime := time.Now()
 	gh.log.Verbo("called GetAncestors",
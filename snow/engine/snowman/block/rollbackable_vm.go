@@ -0,0 +1,31 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrRollbackNotSupported = errors.New("vm does not support rolling back to the requested height")
+
+// RollbackableVM contains the functionality to allow VMs to revert their
+// accepted state to a previously accepted block. This is used to recover from
+// bad upgrades without resyncing the chain.
+type RollbackableVM interface {
+	// VerifyRollback returns nil if Rollback is expected to succeed for
+	// [height]. If the VM can't revert to [height], as it may happen with a
+	// wrapper VM, ErrRollbackNotSupported should be returned.
+	VerifyRollback(ctx context.Context, height uint64) error
+
+	// Rollback reverts the accepted state of the VM to the block that was
+	// accepted at [height]. Blocks accepted after [height] are treated as if
+	// they were never accepted, so the chain continues from [height] once the
+	// engine is started.
+	//
+	// Rollback is only called after Initialize and before the engine is
+	// started. Rolling back to the last accepted height is a no-op, so
+	// a rollback that was interrupted can be retried.
+	Rollback(ctx context.Context, height uint64) error
+}
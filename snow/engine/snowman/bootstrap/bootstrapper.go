@@ -13,6 +13,8 @@ import (
 
 	"go.uber.org/zap"
 
+	"golang.org/x/exp/maps"
+
 	"github.com/luxdefi/node/ids"
 	"github.com/luxdefi/node/proto/pb/p2p"
 	"github.com/luxdefi/node/snow"
@@ -38,6 +40,18 @@ const (
 	// maxOutstandingBroadcastRequests is the maximum number of requests to have
 	// outstanding when broadcasting.
 	maxOutstandingBroadcastRequests = 50
+
+	// maxExecutedWhileFetching is the maximum number of blocks to execute
+	// after handling a single Ancestors message while other blocks are still
+	// being fetched. Bounding this keeps the engine responsive to the
+	// responses of the outstanding requests.
+	maxExecutedWhileFetching = 1024
+
+	// maxOutstandingRanges is the maximum number of height ranges to have
+	// outstanding requests for at once. The traversal of the fetched blocks
+	// never gets more than this many ranges behind the furthest requested
+	// range, which bounds the number of blocks held in memory.
+	maxOutstandingRanges = 8
 )
 
 var (
@@ -46,14 +60,24 @@ var (
 	errUnexpectedTimeout = errors.New("unexpected timeout fired")
 )
 
+// heightRange is an inclusive range of block heights.
+type heightRange struct {
+	top    uint64
+	bottom uint64
+}
+
 // bootstrapper repeatedly performs the bootstrapping protocol.
 //
 //  1. Wait until a sufficient amount of stake is connected.
 //  2. Sample a small number of nodes to get the last accepted block ID
 //  3. Verify against the full network that the last accepted block ID received
 //     in step 2 is an accepted block.
-//  4. Sync the full ancestry of the last accepted block.
+//  4. Sync the full ancestry of the last accepted block. The ancestry is
+//     traversed by block ID, while disjoint height ranges below the
+//     traversal are fetched from other peers at the same time.
 //  5. Execute all the fetched blocks that haven't already been executed.
+//     Blocks whose parents are already accepted are executed while the
+//     remaining blocks are still being fetched.
 //  6. Restart the bootstrapping protocol until the number of blocks being
 //     accepted during a bootstrapping round stops decreasing.
 //
@@ -89,12 +113,35 @@ type Bootstrapper struct {
 	startingHeight uint64
 	// Number of blocks that were fetched on startSyncing
 	initiallyFetched uint64
+	// Number of blocks that were fetched since startSyncing was last called
+	fetchedThisRun uint64
+	// Number of blocks that were executed since startSyncing was last called
+	// while other blocks were still being fetched
+	executedWhileFetching int
 	// Time that startSyncing was last called
 	startTime time.Time
 
 	// tracks which validators were asked for which containers in which requests
 	outstandingRequests *bimap.BiMap[common.Request, ids.ID]
 
+	// tracks which validators were asked for which height ranges in which
+	// requests
+	outstandingRanges map[common.Request]heightRange
+	// ranges that were only partially received and should be requested again
+	pendingRanges []heightRange
+	// top of the next range to request. Ranges are only requested while this
+	// is greater than [startingHeight].
+	nextRangeTop uint64
+	// lowest height that the traversal has needed to fetch a block at, or 0
+	// if it hasn't needed to fetch any
+	walkHeight uint64
+	// blocks received in response to range requests that the traversal hasn't
+	// reached yet
+	prefetched map[ids.ID]snowman.Block
+	// missing blocks, and their heights, that the traversal expects to be
+	// included in the response to an outstanding range request
+	awaitingRanges map[ids.ID]uint64
+
 	// number of state transitions executed
 	executedStateTransitions int
 
@@ -135,6 +182,9 @@ func New(config Config, onFinished func(ctx context.Context, lastReqID uint32) e
 		majority: bootstrapper.Noop,
 
 		outstandingRequests: bimap.New[common.Request, ids.ID](),
+		outstandingRanges:   make(map[common.Request]heightRange),
+		prefetched:          make(map[ids.ID]snowman.Block),
+		awaitingRanges:      make(map[ids.ID]uint64),
 
 		executedStateTransitions: math.MaxInt,
 		onFinished:               onFinished,
@@ -388,6 +438,7 @@ func (b *Bootstrapper) GetAcceptedFailed(ctx context.Context, nodeID ids.NodeID,
 func (b *Bootstrapper) startSyncing(ctx context.Context, acceptedContainerIDs []ids.ID) error {
 	// Initialize the fetch from set to the currently preferred peers
 	b.fetchFrom = b.StartupTracker.PreferredPeers()
+	b.clearRanges()
 
 	pendingContainerIDs := b.Blocked.MissingIDs()
 	// Append the list of accepted container IDs to pendingContainerIDs to ensure
@@ -415,6 +466,8 @@ func (b *Bootstrapper) startSyncing(ctx context.Context, acceptedContainerIDs []
 	}
 
 	b.initiallyFetched = b.Blocked.PendingJobs()
+	b.fetchedThisRun = 0
+	b.executedWhileFetching = 0
 	b.startTime = time.Now()
 
 	// Process received blocks
@@ -463,11 +516,17 @@ func (b *Bootstrapper) fetch(ctx context.Context, blkID ids.ID) error {
 // Ancestors handles the receipt of multiple containers. Should be received in
 // response to a GetAncestors message to [nodeID] with request ID [requestID]
 func (b *Bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, requestID uint32, blks [][]byte) error {
-	// Make sure this is in response to a request we made
-	wantedBlkID, ok := b.outstandingRequests.DeleteKey(common.Request{
+	request := common.Request{
 		NodeID:    nodeID,
 		RequestID: requestID,
-	})
+	}
+	if r, ok := b.outstandingRanges[request]; ok {
+		delete(b.outstandingRanges, request)
+		return b.rangeAncestors(ctx, nodeID, requestID, r, blks)
+	}
+
+	// Make sure this is in response to a request we made
+	wantedBlkID, ok := b.outstandingRequests.DeleteKey(request)
 	if !ok { // this message isn't in response to a request we made
 		b.Ctx.Log.Debug("received unexpected Ancestors",
 			zap.Stringer("nodeID", nodeID),
@@ -528,18 +587,47 @@ func (b *Bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, request
 		return b.fetch(ctx, wantedBlkID)
 	}
 
+	// Only keep the blocks that form a chain of ancestors of the requested
+	// block. Anything after the first break in the chain couldn't be
+	// traversed to anyways.
 	blockSet := make(map[ids.ID]snowman.Block, len(blocks))
-	for _, block := range blocks[1:] {
-		blockSet[block.ID()] = block
+	parentID := requestedBlock.Parent()
+	for i, block := range blocks[1:] {
+		blkID := block.ID()
+		if blkID != parentID {
+			numDropped := len(blocks) - 1 - i
+			b.Ctx.Log.Debug("dropping non-contiguous blocks in Ancestors",
+				zap.Stringer("nodeID", nodeID),
+				zap.Uint32("requestID", requestID),
+				zap.Stringer("expectedBlkID", parentID),
+				zap.Stringer("blkID", blkID),
+				zap.Int("numDropped", numDropped),
+			)
+			b.numDropped.Add(float64(numDropped))
+			break
+		}
+		blockSet[blkID] = block
+		parentID = block.Parent()
 	}
 	return b.process(ctx, requestedBlock, blockSet)
 }
 
 func (b *Bootstrapper) GetAncestorsFailed(ctx context.Context, nodeID ids.NodeID, requestID uint32) error {
-	blkID, ok := b.outstandingRequests.DeleteKey(common.Request{
+	request := common.Request{
 		NodeID:    nodeID,
 		RequestID: requestID,
-	})
+	}
+	if r, ok := b.outstandingRanges[request]; ok {
+		delete(b.outstandingRanges, request)
+
+		// The request may have only failed because the peer was busy, so the
+		// range is requested again, unless the traversal passes it first.
+		b.pendingRanges = append(b.pendingRanges, r)
+		b.fetchFrom.Add(nodeID)
+		return b.resumeAwaiting(ctx)
+	}
+
+	blkID, ok := b.outstandingRequests.DeleteKey(request)
 	if !ok {
 		b.Ctx.Log.Debug("unexpectedly called GetAncestorsFailed",
 			zap.Stringer("nodeID", nodeID),
@@ -555,6 +643,207 @@ func (b *Bootstrapper) GetAncestorsFailed(ctx context.Context, nodeID ids.NodeID
 	return b.fetch(ctx, blkID)
 }
 
+// fetchAncestor fetches [blkID], which is expected to be at [height], and its
+// ancestors. If [height] is included in an outstanding range request, the
+// response to that request is awaited instead. Ranges below the fetched
+// blocks are then requested from the other available peers.
+func (b *Bootstrapper) fetchAncestor(ctx context.Context, blkID ids.ID, height uint64) error {
+	if b.walkHeight == 0 || height < b.walkHeight {
+		b.walkHeight = height
+	}
+
+	if b.isRangeOutstanding(height) {
+		b.awaitingRanges[blkID] = height
+		b.fetchRanges(ctx)
+		return nil
+	}
+
+	if err := b.fetch(ctx, blkID); err != nil {
+		return err
+	}
+
+	// The response to the request for [blkID] includes up to
+	// [AncestorsMaxContainersReceived] blocks, so ranges are requested
+	// starting below those.
+	maxContainers := uint64(b.Config.AncestorsMaxContainersReceived)
+	if height > maxContainers && (b.nextRangeTop == 0 || height-maxContainers < b.nextRangeTop) {
+		b.nextRangeTop = height - maxContainers
+	}
+	b.fetchRanges(ctx)
+	return nil
+}
+
+// fetchRanges requests disjoint height ranges below the traversal from the
+// available peers, so that blocks are fetched from multiple peers at once. At
+// least one peer is left available for the requests made by the traversal.
+func (b *Bootstrapper) fetchRanges(ctx context.Context) {
+	for len(b.outstandingRanges) < maxOutstandingRanges && b.fetchFrom.Len() > 1 {
+		r, ok := b.nextRange()
+		if !ok {
+			return
+		}
+
+		nodeID, _ := b.fetchFrom.Peek()
+		b.markUnavailable(nodeID)
+
+		b.requestID++
+
+		b.outstandingRanges[common.Request{
+			NodeID:    nodeID,
+			RequestID: b.requestID,
+		}] = r
+		b.Config.Sender.SendGetAncestorsAtHeight(ctx, nodeID, b.requestID, r.top)
+	}
+}
+
+// nextRange returns the next range that should be requested, if any.
+func (b *Bootstrapper) nextRange() (heightRange, bool) {
+	for len(b.pendingRanges) > 0 {
+		r := b.pendingRanges[len(b.pendingRanges)-1]
+		b.pendingRanges = b.pendingRanges[:len(b.pendingRanges)-1]
+
+		// Ranges that the traversal has already passed are fetched by ID.
+		if r.top < b.walkHeight {
+			return r, true
+		}
+	}
+
+	maxContainers := uint64(b.Config.AncestorsMaxContainersReceived)
+	if b.nextRangeTop <= b.startingHeight ||
+		b.nextRangeTop >= b.walkHeight ||
+		b.walkHeight-b.nextRangeTop > maxOutstandingRanges*maxContainers {
+		return heightRange{}, false
+	}
+
+	r := heightRange{
+		top:    b.nextRangeTop,
+		bottom: b.startingHeight + 1,
+	}
+	if r.top-r.bottom >= maxContainers {
+		r.bottom = r.top - maxContainers + 1
+	}
+	b.nextRangeTop = r.bottom - 1
+	return r, true
+}
+
+// isRangeOutstanding returns true if [height] is included in an outstanding
+// range request.
+func (b *Bootstrapper) isRangeOutstanding(height uint64) bool {
+	for _, r := range b.outstandingRanges {
+		if r.bottom <= height && height <= r.top {
+			return true
+		}
+	}
+	return false
+}
+
+// rangeAncestors handles the response to a GetAncestorsAtHeight request for
+// [r]. The received blocks are only kept if they form a chain of ancestors
+// starting at the top of the range. The part of the range that wasn't received
+// is requested again, unless nothing was received, in which case the traversal
+// fetches those blocks by ID.
+func (b *Bootstrapper) rangeAncestors(ctx context.Context, nodeID ids.NodeID, requestID uint32, r heightRange, blks [][]byte) error {
+	if len(blks) == 0 {
+		b.Ctx.Log.Debug("received Ancestors with no block",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+			zap.Uint64("height", r.top),
+		)
+
+		b.markUnavailable(nodeID)
+		return b.resumeAwaiting(ctx)
+	}
+
+	// This node has responded - so add it back into the set
+	b.fetchFrom.Add(nodeID)
+
+	// Blocks below the bottom of the range were either requested by another
+	// range or are already accepted.
+	if numBlks := r.top - r.bottom + 1; uint64(len(blks)) > numBlks {
+		blks = blks[:numBlks]
+	}
+
+	blocks, err := block.BatchedParseBlock(ctx, b.VM, blks)
+	if err != nil {
+		b.Ctx.Log.Debug("failed to parse blocks in Ancestors",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+			zap.Error(err),
+		)
+		return b.resumeAwaiting(ctx)
+	}
+
+	var (
+		numReceived uint64
+		parentID    ids.ID
+	)
+	for i, blk := range blocks {
+		blkID := blk.ID()
+		if (i == 0 && blk.Height() != r.top) || (i > 0 && blkID != parentID) {
+			numDropped := len(blocks) - i
+			b.Ctx.Log.Debug("dropping non-contiguous blocks in Ancestors",
+				zap.Stringer("nodeID", nodeID),
+				zap.Uint32("requestID", requestID),
+				zap.Stringer("blkID", blkID),
+				zap.Int("numDropped", numDropped),
+			)
+			b.numDropped.Add(float64(numDropped))
+			break
+		}
+
+		b.prefetched[blkID] = blk
+		parentID = blk.Parent()
+		numReceived++
+	}
+
+	if numReceived > 0 && r.top-numReceived >= r.bottom {
+		b.pendingRanges = append(b.pendingRanges, heightRange{
+			top:    r.top - numReceived,
+			bottom: r.bottom,
+		})
+	}
+	return b.resumeAwaiting(ctx)
+}
+
+// resumeAwaiting continues the traversal from the missing blocks that were
+// waiting for a range response. Blocks that weren't received, and that aren't
+// included in any other outstanding range request, are fetched by ID.
+func (b *Bootstrapper) resumeAwaiting(ctx context.Context) error {
+	for blkID, height := range maps.Clone(b.awaitingRanges) {
+		if blk, ok := b.prefetched[blkID]; ok {
+			delete(b.awaitingRanges, blkID)
+			delete(b.prefetched, blkID)
+			if err := b.process(ctx, blk, nil); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if b.isRangeOutstanding(height) {
+			continue
+		}
+
+		delete(b.awaitingRanges, blkID)
+		if err := b.fetch(ctx, blkID); err != nil {
+			return err
+		}
+	}
+
+	b.fetchRanges(ctx)
+	return b.tryStartExecuting(ctx)
+}
+
+// clearRanges drops all the state used to fetch height ranges. Responses to
+// the outstanding range requests are ignored.
+func (b *Bootstrapper) clearRanges() {
+	b.outstandingRanges = make(map[common.Request]heightRange)
+	b.pendingRanges = nil
+	b.nextRangeTop = 0
+	b.walkHeight = 0
+	b.prefetched = make(map[ids.ID]snowman.Block)
+	b.awaitingRanges = make(map[ids.ID]uint64)
+}
+
 // markUnavailable removes [nodeID] from the set of peers used to fetch
 // ancestors. If the set becomes empty, it is reset to the currently preferred
 // peers so bootstrapping can continue.
@@ -635,16 +924,13 @@ func (b *Bootstrapper) process(ctx context.Context, blk snowman.Block, processin
 
 		// We added a new block to the queue, so track that it was fetched
 		b.numFetched.Inc()
+		b.fetchedThisRun++
 
 		// Periodically log progress
-		blocksFetchedSoFar := b.Blocked.Jobs.PendingJobs()
+		blocksFetchedSoFar := b.initiallyFetched + b.fetchedThisRun
 		if blocksFetchedSoFar%statusUpdateFrequency == 0 {
 			totalBlocksToFetch := b.tipHeight - b.startingHeight
-			eta := timer.EstimateETA(
-				b.startTime,
-				blocksFetchedSoFar-b.initiallyFetched, // Number of blocks we have fetched during this run
-				totalBlocksToFetch-b.initiallyFetched, // Number of blocks we expect to fetch during this run
-			)
+			eta := b.estimateFetchETA()
 			b.fetchETA.Set(float64(eta))
 
 			if !b.restarted {
@@ -672,6 +958,17 @@ func (b *Bootstrapper) process(ctx context.Context, blk snowman.Block, processin
 			continue
 		}
 
+		// Then check if the parent was received in response to a range
+		// request. These blocks are only reached through the parent IDs of
+		// the blocks being traversed, so they are verified to be ancestors of
+		// the accepted frontier.
+		parent, ok = b.prefetched[parentID]
+		if ok {
+			delete(b.prefetched, parentID)
+			blk = parent
+			continue
+		}
+
 		// If the parent is not available in processing blocks, attempt to get
 		// the block from the vm
 		parent, err = b.VM.GetBlock(ctx, parentID)
@@ -684,7 +981,7 @@ func (b *Bootstrapper) process(ctx context.Context, blk snowman.Block, processin
 		// If the block wasn't able to be acquired immediately, attempt to fetch
 		// it
 		b.Blocked.AddMissingID(parentID)
-		if err := b.fetch(ctx, parentID); err != nil {
+		if err := b.fetchAncestor(ctx, parentID, blkHeight-1); err != nil {
 			return err
 		}
 
@@ -699,14 +996,21 @@ func (b *Bootstrapper) process(ctx context.Context, blk snowman.Block, processin
 // being fetched. After executing all pending blocks it will either restart
 // bootstrapping, or transition into normal operations.
 func (b *Bootstrapper) tryStartExecuting(ctx context.Context) error {
-	if numPending := b.Blocked.NumMissingIDs(); numPending != 0 {
+	if b.Ctx.State.Get().State == snow.NormalOp || b.awaitingTimeout {
 		return nil
 	}
 
-	if b.Ctx.State.Get().State == snow.NormalOp || b.awaitingTimeout {
-		return nil
+	if numPending := b.Blocked.NumMissingIDs(); numPending != 0 {
+		// Blocks are still being fetched, but any blocks whose parents have
+		// already been accepted can be executed while waiting for the
+		// outstanding requests.
+		return b.executeRunnable(ctx)
 	}
 
+	// All the blocks have been fetched, so any prefetched blocks that weren't
+	// reached by the traversal are no longer needed.
+	b.clearRanges()
+
 	if !b.restarted {
 		b.Ctx.Log.Info("executing blocks",
 			zap.Uint64("numPendingJobs", b.Blocked.PendingJobs()),
@@ -727,6 +1031,7 @@ func (b *Bootstrapper) tryStartExecuting(ctx context.Context) error {
 	if err != nil || b.Halted() {
 		return err
 	}
+	executedBlocks += b.executedWhileFetching
 
 	previouslyExecuted := b.executedStateTransitions
 	b.executedStateTransitions = executedBlocks
@@ -765,6 +1070,40 @@ func (b *Bootstrapper) tryStartExecuting(ctx context.Context) error {
 	return b.onFinished(ctx, b.requestID)
 }
 
+// executeRunnable executes up to [maxExecutedWhileFetching] of the fetched
+// blocks whose parents have already been accepted.
+func (b *Bootstrapper) executeRunnable(ctx context.Context) error {
+	executed, err := b.Blocked.ExecuteRunnable(
+		ctx,
+		b.Config.Ctx,
+		b,
+		maxExecutedWhileFetching,
+		b.Ctx.BlockAcceptor,
+	)
+	b.executedWhileFetching += executed
+	if executed > 0 {
+		b.Ctx.Log.Debug("executed blocks while fetching",
+			zap.Int("numExecuted", executed),
+			zap.Int("numMissing", b.Blocked.NumMissingIDs()),
+		)
+	}
+	return err
+}
+
+// estimateFetchETA returns the estimated duration until all the blocks of the
+// current run have been fetched.
+func (b *Bootstrapper) estimateFetchETA() time.Duration {
+	totalBlocksToFetch := b.tipHeight - b.startingHeight
+	if b.fetchedThisRun == 0 || totalBlocksToFetch <= b.initiallyFetched+b.fetchedThisRun {
+		return 0
+	}
+	return timer.EstimateETA(
+		b.startTime,
+		b.fetchedThisRun,                      // Number of blocks we have fetched during this run
+		totalBlocksToFetch-b.initiallyFetched, // Number of blocks we expect to fetch during this run
+	)
+}
+
 func (b *Bootstrapper) Timeout(ctx context.Context) error {
 	if !b.awaitingTimeout {
 		return errUnexpectedTimeout
@@ -805,9 +1144,56 @@ func (b *Bootstrapper) HealthCheck(ctx context.Context) (interface{}, error) {
 		"consensus": struct{}{},
 		"vm":        vmIntf,
 	}
+	if !b.startTime.IsZero() {
+		intf["progress"] = b.progress()
+	}
 	return intf, vmErr
 }
 
+// Progress reports how far the current bootstrapping run has gotten.
+type Progress struct {
+	// StartingHeight is the height of the last accepted block when
+	// bootstrapping started.
+	StartingHeight uint64 `json:"startingHeight"`
+	// TipHeight is the greatest height of the blocks being bootstrapped to.
+	TipHeight uint64 `json:"tipHeight"`
+	// NumFetched is the number of blocks fetched during this run.
+	NumFetched uint64 `json:"numFetched"`
+	// NumPending is the number of fetched blocks that haven't been executed.
+	NumPending uint64 `json:"numPending"`
+	// NumExecuted is the number of blocks executed during this run while
+	// other blocks were still being fetched.
+	NumExecuted int `json:"numExecuted"`
+	// NumOutstandingRequests is the number of Ancestors requests, by block ID
+	// or by height, that haven't been responded to.
+	NumOutstandingRequests int `json:"numOutstandingRequests"`
+	// NumPrefetched is the number of blocks received in response to height
+	// range requests that haven't been traversed yet.
+	NumPrefetched int `json:"numPrefetched"`
+	// FetchRate is the number of blocks fetched per second during this run.
+	FetchRate float64 `json:"fetchRate"`
+	// FetchETA is the estimated duration until fetching finishes.
+	FetchETA time.Duration `json:"fetchETA"`
+}
+
+func (b *Bootstrapper) progress() Progress {
+	var fetchRate float64
+	if elapsed := time.Since(b.startTime).Seconds(); elapsed > 0 {
+		fetchRate = float64(b.fetchedThisRun) / elapsed
+	}
+	return Progress{
+		StartingHeight:         b.startingHeight,
+		TipHeight:              b.tipHeight,
+		NumFetched:             b.fetchedThisRun,
+		NumPending:             b.Blocked.PendingJobs(),
+		NumExecuted:            b.executedWhileFetching,
+		NumOutstandingRequests: b.outstandingRequests.Len() + len(b.outstandingRanges),
+		NumPrefetched:          len(b.prefetched),
+		FetchRate:              fetchRate,
+		FetchETA:               b.estimateFetchETA(),
+	}
+}
+
 func (b *Bootstrapper) Shutdown(ctx context.Context) error {
 	b.Ctx.Log.Info("shutting down bootstrapper")
 
@@ -1423,3 +1423,531 @@ func TestBootstrapNoParseOnNew(t *testing.T) {
 	)
 	require.NoError(err)
 }
+
+func TestBootstrapperDropsNonContiguousAncestors(t *testing.T) {
+	require := require.New(t)
+
+	config, peerID, sender, vm := newConfig(t)
+
+	blkID0 := ids.Empty.Prefix(0)
+	blkID1 := ids.Empty.Prefix(1)
+	blkID2 := ids.Empty.Prefix(2)
+	blkID3 := ids.Empty.Prefix(3)
+	conflictingBlkID1 := ids.Empty.Prefix(4)
+
+	blkBytes0 := []byte{0}
+	blkBytes1 := []byte{1}
+	blkBytes2 := []byte{2}
+	blkBytes3 := []byte{3}
+	conflictingBlkBytes1 := []byte{4}
+
+	blk0 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     blkID0,
+			StatusV: choices.Accepted,
+		},
+		HeightV: 0,
+		BytesV:  blkBytes0,
+	}
+	blk1 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     blkID1,
+			StatusV: choices.Unknown,
+		},
+		ParentV: blk0.IDV,
+		HeightV: 1,
+		BytesV:  blkBytes1,
+	}
+	blk2 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     blkID2,
+			StatusV: choices.Unknown,
+		},
+		ParentV: blk1.IDV,
+		HeightV: 2,
+		BytesV:  blkBytes2,
+	}
+	blk3 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     blkID3,
+			StatusV: choices.Processing,
+		},
+		ParentV: blk2.IDV,
+		HeightV: 3,
+		BytesV:  blkBytes3,
+	}
+	conflictingBlk1 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     conflictingBlkID1,
+			StatusV: choices.Processing,
+		},
+		ParentV: blk0.IDV,
+		HeightV: 1,
+		BytesV:  conflictingBlkBytes1,
+	}
+
+	vm.CantSetState = false
+	vm.CantLastAccepted = false
+	vm.LastAcceptedF = func(context.Context) (ids.ID, error) {
+		return blk0.ID(), nil
+	}
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		require.Equal(blk0.ID(), blkID)
+		return blk0, nil
+	}
+
+	bs, err := New(
+		config,
+		func(context.Context, uint32) error {
+			config.Ctx.State.Set(snow.EngineState{
+				Type:  p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+				State: snow.NormalOp,
+			})
+			return nil
+		},
+	)
+	require.NoError(err)
+
+	require.NoError(bs.Start(context.Background(), 0))
+
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case blkID0:
+			return blk0, nil
+		case blkID1:
+			// Parsing blk1 doesn't persist it, so it is only available once it
+			// has been accepted.
+			if blk1.StatusV == choices.Accepted {
+				return blk1, nil
+			}
+			return nil, database.ErrNotFound
+		case blkID2:
+			if blk2.StatusV != choices.Unknown {
+				return blk2, nil
+			}
+			return nil, database.ErrNotFound
+		case blkID3:
+			return blk3, nil
+		default:
+			require.FailNow(database.ErrNotFound.Error())
+			return nil, database.ErrNotFound
+		}
+	}
+	vm.ParseBlockF = func(_ context.Context, blkBytes []byte) (snowman.Block, error) {
+		switch {
+		case bytes.Equal(blkBytes, blkBytes1):
+			blk1.StatusV = choices.Processing
+			return blk1, nil
+		case bytes.Equal(blkBytes, blkBytes2):
+			blk2.StatusV = choices.Processing
+			return blk2, nil
+		case bytes.Equal(blkBytes, blkBytes3):
+			return blk3, nil
+		case bytes.Equal(blkBytes, conflictingBlkBytes1):
+			return conflictingBlk1, nil
+		}
+		require.FailNow(errUnknownBlock.Error())
+		return nil, errUnknownBlock
+	}
+
+	requestID := new(uint32)
+	requested := ids.Empty
+	sender.SendGetAncestorsF = func(_ context.Context, vdr ids.NodeID, reqID uint32, blkID ids.ID) {
+		require.Equal(peerID, vdr)
+		*requestID = reqID
+		requested = blkID
+	}
+
+	require.NoError(bs.startSyncing(context.Background(), []ids.ID{blkID3})) // should request blk2
+	require.Equal(blkID2, requested)
+
+	// The conflicting block breaks the chain, so blk1 must be dropped along
+	// with it and requested again.
+	require.NoError(bs.Ancestors(context.Background(), peerID, *requestID, [][]byte{blkBytes2, conflictingBlkBytes1, blkBytes1}))
+	require.Equal(blkID1, requested)
+	require.Equal(choices.Processing, blk2.Status())
+	require.Equal(choices.Processing, conflictingBlk1.Status())
+
+	require.NoError(bs.Ancestors(context.Background(), peerID, *requestID, [][]byte{blkBytes1}))
+	require.Equal(choices.Accepted, blk1.Status())
+	require.Equal(choices.Accepted, blk2.Status())
+	require.Equal(choices.Accepted, blk3.Status())
+	require.Equal(choices.Processing, conflictingBlk1.Status())
+}
+
+func TestBootstrapperExecutesWhileFetching(t *testing.T) {
+	require := require.New(t)
+
+	config, peerID, sender, vm := newConfig(t)
+
+	blkID0 := ids.Empty.Prefix(0)
+	blkID1 := ids.Empty.Prefix(1)
+	blkID2 := ids.Empty.Prefix(2)
+	blkID3 := ids.Empty.Prefix(3)
+
+	blkBytes0 := []byte{0}
+	blkBytes1 := []byte{1}
+	blkBytes2 := []byte{2}
+	blkBytes3 := []byte{3}
+
+	blk0 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     blkID0,
+			StatusV: choices.Accepted,
+		},
+		HeightV: 0,
+		BytesV:  blkBytes0,
+	}
+	blk1 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     blkID1,
+			StatusV: choices.Unknown,
+		},
+		ParentV: blk0.IDV,
+		HeightV: 1,
+		BytesV:  blkBytes1,
+	}
+	blk2 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     blkID2,
+			StatusV: choices.Unknown,
+		},
+		ParentV: blk1.IDV,
+		HeightV: 2,
+		BytesV:  blkBytes2,
+	}
+	blk3 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     blkID3,
+			StatusV: choices.Processing,
+		},
+		ParentV: blk2.IDV,
+		HeightV: 3,
+		BytesV:  blkBytes3,
+	}
+
+	vm.CantSetState = false
+	vm.HealthCheckF = func(context.Context) (interface{}, error) {
+		return nil, nil
+	}
+	vm.CantLastAccepted = false
+	vm.LastAcceptedF = func(context.Context) (ids.ID, error) {
+		return blk0.ID(), nil
+	}
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		require.Equal(blk0.ID(), blkID)
+		return blk0, nil
+	}
+
+	bs, err := New(
+		config,
+		func(context.Context, uint32) error {
+			config.Ctx.State.Set(snow.EngineState{
+				Type:  p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+				State: snow.NormalOp,
+			})
+			return nil
+		},
+	)
+	require.NoError(err)
+
+	require.NoError(bs.Start(context.Background(), 0))
+
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case blkID0:
+			return blk0, nil
+		case blkID1:
+			if blk1.StatusV != choices.Unknown {
+				return blk1, nil
+			}
+			return nil, database.ErrNotFound
+		case blkID2:
+			if blk2.StatusV != choices.Unknown {
+				return blk2, nil
+			}
+			return nil, database.ErrNotFound
+		case blkID3:
+			return blk3, nil
+		default:
+			require.FailNow(database.ErrNotFound.Error())
+			return nil, database.ErrNotFound
+		}
+	}
+	vm.ParseBlockF = func(_ context.Context, blkBytes []byte) (snowman.Block, error) {
+		switch {
+		case bytes.Equal(blkBytes, blkBytes1):
+			blk1.StatusV = choices.Processing
+			return blk1, nil
+		case bytes.Equal(blkBytes, blkBytes2):
+			blk2.StatusV = choices.Processing
+			return blk2, nil
+		case bytes.Equal(blkBytes, blkBytes3):
+			return blk3, nil
+		}
+		require.FailNow(errUnknownBlock.Error())
+		return nil, errUnknownBlock
+	}
+
+	requests := map[ids.ID]uint32{}
+	sender.SendGetAncestorsF = func(_ context.Context, vdr ids.NodeID, reqID uint32, blkID ids.ID) {
+		require.Equal(peerID, vdr)
+		requests[blkID] = reqID
+	}
+
+	// Both blk1 and blk2 should be requested at the same time.
+	require.NoError(bs.startSyncing(context.Background(), []ids.ID{blkID1, blkID3}))
+	require.Len(requests, 2)
+	require.Contains(requests, blkID1)
+	require.Contains(requests, blkID2)
+
+	// blk1 can be executed while blk2 is still being fetched.
+	require.NoError(bs.Ancestors(context.Background(), peerID, requests[blkID1], [][]byte{blkBytes1}))
+	require.Equal(snow.Bootstrapping, config.Ctx.State.Get().State)
+	require.Equal(choices.Accepted, blk1.Status())
+	require.Equal(choices.Processing, blk3.Status())
+
+	healthIntf, err := bs.HealthCheck(context.Background())
+	require.NoError(err)
+	health, ok := healthIntf.(map[string]interface{})
+	require.True(ok)
+	progress, ok := health["progress"].(Progress)
+	require.True(ok)
+	require.Equal(uint64(3), progress.TipHeight)
+	require.Equal(uint64(2), progress.NumFetched)
+	require.Equal(uint64(1), progress.NumPending)
+	require.Equal(1, progress.NumExecuted)
+	require.Equal(1, progress.NumOutstandingRequests)
+
+	require.NoError(bs.Ancestors(context.Background(), peerID, requests[blkID2], [][]byte{blkBytes2}))
+	require.Equal(choices.Accepted, blk2.Status())
+	require.Equal(choices.Accepted, blk3.Status())
+}
+
+// newChain returns [numBlks] blocks, where the first block is accepted and
+// every other block is unknown.
+func newChain(numBlks int) []*snowman.TestBlock {
+	blks := make([]*snowman.TestBlock, numBlks)
+	for i := range blks {
+		blk := &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.Empty.Prefix(uint64(i)),
+				StatusV: choices.Unknown,
+			},
+			HeightV: uint64(i),
+			BytesV:  []byte{byte(i)},
+		}
+		if i > 0 {
+			blk.ParentV = blks[i-1].IDV
+		}
+		blks[i] = blk
+	}
+	blks[0].StatusV = choices.Accepted
+	return blks
+}
+
+type ancestorsRequest struct {
+	nodeID ids.NodeID
+	// Exactly one of blkID and height is set
+	blkID  ids.ID
+	height uint64
+}
+
+// newRangeTest returns a bootstrapper, bootstrapping [blks], with
+// [numPeers] connected peers and a limit of [maxContainers] containers per
+// Ancestors message. The outstanding ancestors requests are recorded into the
+// returned map.
+func newRangeTest(
+	t *testing.T,
+	blks []*snowman.TestBlock,
+	numPeers int,
+	maxContainers int,
+) (*Bootstrapper, *block.TestVM, map[uint32]ancestorsRequest) {
+	require := require.New(t)
+
+	config, _, sender, vm := newConfig(t)
+	config.AncestorsMaxContainersReceived = maxContainers
+	for i := 1; i < numPeers; i++ {
+		nodeID := ids.GenerateTestNodeID()
+		require.NoError(config.Beacons.AddStaker(config.Ctx.SubnetID, nodeID, nil, ids.Empty, 1))
+		require.NoError(config.StartupTracker.Connected(context.Background(), nodeID, version.CurrentApp))
+	}
+
+	vm.CantSetState = false
+	vm.HealthCheckF = func(context.Context) (interface{}, error) {
+		return nil, nil
+	}
+	vm.CantLastAccepted = false
+	vm.LastAcceptedF = func(context.Context) (ids.ID, error) {
+		return blks[0].ID(), nil
+	}
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		for _, blk := range blks {
+			if blk.ID() == blkID && blk.StatusV != choices.Unknown {
+				return blk, nil
+			}
+		}
+		return nil, database.ErrNotFound
+	}
+	vm.ParseBlockF = func(_ context.Context, blkBytes []byte) (snowman.Block, error) {
+		for _, blk := range blks {
+			if bytes.Equal(blk.Bytes(), blkBytes) {
+				if blk.StatusV == choices.Unknown {
+					blk.StatusV = choices.Processing
+				}
+				return blk, nil
+			}
+		}
+		require.FailNow(errUnknownBlock.Error())
+		return nil, errUnknownBlock
+	}
+
+	requests := make(map[uint32]ancestorsRequest)
+	sender.SendGetAncestorsF = func(_ context.Context, nodeID ids.NodeID, requestID uint32, blkID ids.ID) {
+		requests[requestID] = ancestorsRequest{
+			nodeID: nodeID,
+			blkID:  blkID,
+		}
+	}
+	sender.SendGetAncestorsAtHeightF = func(_ context.Context, nodeID ids.NodeID, requestID uint32, height uint64) {
+		requests[requestID] = ancestorsRequest{
+			nodeID: nodeID,
+			height: height,
+		}
+	}
+
+	bs, err := New(
+		config,
+		func(context.Context, uint32) error {
+			config.Ctx.State.Set(snow.EngineState{
+				Type:  p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+				State: snow.NormalOp,
+			})
+			return nil
+		},
+	)
+	require.NoError(err)
+	require.NoError(bs.Start(context.Background(), 0))
+	return bs, vm, requests
+}
+
+// ancestorsBytes returns the bytes of up to [maxContainers] blocks, starting
+// at [height] and going down the chain.
+func ancestorsBytes(blks []*snowman.TestBlock, height uint64, maxContainers int) [][]byte {
+	containers := [][]byte{}
+	for i := int(height); i >= 0 && len(containers) < maxContainers; i-- {
+		containers = append(containers, blks[i].Bytes())
+	}
+	return containers
+}
+
+func TestBootstrapperFetchesHeightRanges(t *testing.T) {
+	require := require.New(t)
+
+	blks := newChain(10)
+	blks[9].StatusV = choices.Processing
+	bs, _, requests := newRangeTest(t, blks, 3, 2)
+
+	// blk8 and blk7 are requested by ID, while heights 6 and 5 are requested
+	// from another peer.
+	require.NoError(bs.startSyncing(context.Background(), []ids.ID{blks[9].ID()}))
+	require.Len(requests, 2)
+	var (
+		blkRequestID, rangeRequestID uint32
+		blkRequest, rangeRequest     ancestorsRequest
+	)
+	for requestID, request := range requests {
+		if request.blkID == ids.Empty {
+			rangeRequestID, rangeRequest = requestID, request
+		} else {
+			blkRequestID, blkRequest = requestID, request
+		}
+	}
+	require.Equal(blks[8].ID(), blkRequest.blkID)
+	require.Equal(uint64(6), rangeRequest.height)
+	require.NotEqual(blkRequest.nodeID, rangeRequest.nodeID)
+
+	// The range arrives first, so it is held until the traversal reaches it.
+	delete(requests, rangeRequestID)
+	require.NoError(bs.Ancestors(context.Background(), rangeRequest.nodeID, rangeRequestID, ancestorsBytes(blks, 6, 2)))
+	require.Len(bs.prefetched, 2)
+	require.Equal(choices.Processing, blks[6].Status())
+
+	delete(requests, blkRequestID)
+	require.NoError(bs.Ancestors(context.Background(), blkRequest.nodeID, blkRequestID, ancestorsBytes(blks, 8, 2)))
+	require.Empty(bs.prefetched)
+
+	// Respond to the remaining requests until every block is fetched.
+	numRangeRequests := 1
+	for len(requests) > 0 {
+		for requestID, request := range requests {
+			delete(requests, requestID)
+
+			height := request.height
+			if request.blkID == ids.Empty {
+				numRangeRequests++
+			}
+			for _, blk := range blks {
+				if blk.ID() == request.blkID {
+					height = blk.Height()
+				}
+			}
+			require.NoError(bs.Ancestors(context.Background(), request.nodeID, requestID, ancestorsBytes(blks, height, 2)))
+			break
+		}
+	}
+	require.Greater(numRangeRequests, 1)
+
+	require.Equal(snow.NormalOp, bs.Ctx.State.Get().State)
+	for _, blk := range blks {
+		require.Equal(choices.Accepted, blk.Status())
+	}
+	require.Empty(bs.prefetched)
+	require.Empty(bs.outstandingRanges)
+}
+
+func TestBootstrapperDropsInvalidHeightRange(t *testing.T) {
+	require := require.New(t)
+
+	blks := newChain(10)
+	blks[9].StatusV = choices.Processing
+	bs, _, requests := newRangeTest(t, blks, 3, 2)
+
+	require.NoError(bs.startSyncing(context.Background(), []ids.ID{blks[9].ID()}))
+	require.Len(requests, 2)
+	var (
+		blkRequestID, rangeRequestID uint32
+		blkRequest, rangeRequest     ancestorsRequest
+	)
+	for requestID, request := range requests {
+		if request.blkID == ids.Empty {
+			rangeRequestID, rangeRequest = requestID, request
+		} else {
+			blkRequestID, blkRequest = requestID, request
+		}
+	}
+	require.Equal(uint64(6), rangeRequest.height)
+
+	// The traversal reaches height 6 before the range arrives, so it waits for
+	// the range rather than requesting blk6 by ID.
+	delete(requests, blkRequestID)
+	require.NoError(bs.Ancestors(context.Background(), blkRequest.nodeID, blkRequestID, ancestorsBytes(blks, 8, 2)))
+	require.Contains(bs.awaitingRanges, blks[6].ID())
+	for _, request := range requests {
+		require.NotEqual(blks[6].ID(), request.blkID)
+	}
+
+	// The response doesn't start at the top of the range, so it is dropped and
+	// blk6 is requested by ID.
+	delete(requests, rangeRequestID)
+	require.NoError(bs.Ancestors(context.Background(), rangeRequest.nodeID, rangeRequestID, ancestorsBytes(blks, 5, 2)))
+	require.Empty(bs.awaitingRanges)
+	require.Empty(bs.prefetched)
+
+	var requestedBlk6 bool
+	for _, request := range requests {
+		requestedBlk6 = requestedBlk6 || request.blkID == blks[6].ID()
+	}
+	require.True(requestedBlk6)
+	require.NotEqual(choices.Accepted, blks[5].Status())
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/luxdefi/node/snow/engine/common/tracker"
 	"github.com/luxdefi/node/snow/engine/snowman/block"
 	"github.com/luxdefi/node/snow/validators"
+	"github.com/luxdefi/node/snow/validators/sampling"
 )
 
 // Config wraps all the parameters needed for a snowman engine
@@ -25,4 +26,7 @@ type Config struct {
 	Params              snowball.Parameters
 	Consensus           snowman.Consensus
 	PartialSync         bool
+	// Sampler selects the validators that queries are sent to. If nil,
+	// validators are sampled by stake.
+	Sampler sampling.Sampler
 }
@@ -4,6 +4,10 @@
 package snowman
 
 import (
+	"context"
+
+	"github.com/luxdefi/node/snow/consensus/snowman"
+	"github.com/luxdefi/node/snow/consensus/snowman/poll"
 	"github.com/luxdefi/node/snow/engine/common"
 	"github.com/luxdefi/node/snow/engine/snowman/block"
 )
@@ -19,4 +23,21 @@ import (
 type Engine interface {
 	common.Engine
 	block.Getter
+
+	// Inspect returns a snapshot of the state of consensus. Returns an error
+	// if consensus hasn't started.
+	Inspect(context.Context) (Inspection, error)
+}
+
+// Inspection describes the state of a Snowman engine.
+type Inspection struct {
+	snowman.Inspection
+
+	// Polls are the outstanding network polls, from oldest to newest.
+	Polls []poll.Info `json:"polls"`
+	// NumPendingBlocks is the number of blocks that are waiting on their
+	// ancestors before they can be issued into consensus.
+	NumPendingBlocks int `json:"numPendingBlocks"`
+	// NumBlockRequests is the number of outstanding requests for blocks.
+	NumBlockRequests int `json:"numBlockRequests"`
 }
@@ -11,6 +11,7 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/luxdefi/node/database"
 	"github.com/luxdefi/node/ids"
 	"github.com/luxdefi/node/snow/choices"
 	"github.com/luxdefi/node/snow/engine/common"
@@ -187,6 +188,27 @@ func (gh *getter) GetAncestors(ctx context.Context, nodeID ids.NodeID, requestID
 	return nil
 }
 
+func (gh *getter) GetAncestorsAtHeight(ctx context.Context, nodeID ids.NodeID, requestID uint32, height uint64) error {
+	blkID, err := gh.vm.GetBlockIDAtHeight(ctx, height)
+	if err == database.ErrNotFound {
+		// As with GetAncestors, an empty response signals that this node
+		// doesn't have the requested block.
+		gh.sender.SendAncestors(ctx, nodeID, requestID, nil)
+		return nil
+	}
+	if err != nil {
+		gh.log.Verbo("dropping GetAncestorsAtHeight message",
+			zap.String("reason", "couldn't get block at height"),
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+			zap.Uint64("height", height),
+			zap.Error(err),
+		)
+		return nil
+	}
+	return gh.GetAncestors(ctx, nodeID, requestID, blkID)
+}
+
 func (gh *getter) Get(ctx context.Context, nodeID ids.NodeID, requestID uint32, blkID ids.ID) error {
 	blk, err := gh.vm.GetBlock(ctx, blkID)
 	if err != nil {
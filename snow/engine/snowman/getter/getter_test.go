@@ -15,6 +15,7 @@ import (
 
 	"go.uber.org/mock/gomock"
 
+	"github.com/luxdefi/node/database"
 	"github.com/luxdefi/node/ids"
 	"github.com/luxdefi/node/snow/choices"
 	"github.com/luxdefi/node/snow/consensus/snowman"
@@ -119,3 +120,62 @@ func TestFilterAccepted(t *testing.T) {
 	require.Contains(accepted, blkID1)
 	require.NotContains(accepted, blkID2)
 }
+
+func TestGetAncestorsAtHeight(t *testing.T) {
+	require := require.New(t)
+	bs, vm, sender := newTest(t)
+
+	blkID0 := ids.GenerateTestID()
+	blkID1 := ids.GenerateTestID()
+
+	blk0 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     blkID0,
+			StatusV: choices.Accepted,
+		},
+		HeightV: 0,
+		BytesV:  []byte{0},
+	}
+	blk1 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     blkID1,
+			StatusV: choices.Accepted,
+		},
+		ParentV: blkID0,
+		HeightV: 1,
+		BytesV:  []byte{1},
+	}
+
+	vm.GetBlockIDAtHeightF = func(_ context.Context, height uint64) (ids.ID, error) {
+		switch height {
+		case 0:
+			return blkID0, nil
+		case 1:
+			return blkID1, nil
+		default:
+			return ids.Empty, database.ErrNotFound
+		}
+	}
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case blkID0:
+			return blk0, nil
+		case blkID1:
+			return blk1, nil
+		default:
+			return nil, database.ErrNotFound
+		}
+	}
+
+	var ancestors [][]byte
+	sender.SendAncestorsF = func(_ context.Context, _ ids.NodeID, _ uint32, containers [][]byte) {
+		ancestors = containers
+	}
+
+	require.NoError(bs.GetAncestorsAtHeight(context.Background(), ids.EmptyNodeID, 0, 1))
+	require.Equal([][]byte{{1}, {0}}, ancestors)
+
+	// A height that isn't accepted yet results in an empty response.
+	require.NoError(bs.GetAncestorsAtHeight(context.Background(), ids.EmptyNodeID, 1, 2))
+	require.Empty(ancestors)
+}
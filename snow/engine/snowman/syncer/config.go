@@ -28,8 +28,10 @@ type Config struct {
 	// must be at least one correct node sampled.
 	SampleK int
 
-	// Alpha specifies the amount of weight that validators must put behind a
-	// state summary to consider it valid to sync to.
+	// Alpha specifies the amount of weight that must respond to a round of
+	// requests for state sync to continue. Otherwise, state sync is restarted.
+	// A state summary is only synced to if a majority of the weight of the
+	// state sync beacons consider it accepted.
 	Alpha uint64
 
 	// StateSyncBeacons are the nodes that will be used to sample and vote over
@@ -6,7 +6,6 @@ package syncer
 import (
 	"context"
 	"fmt"
-	"math"
 
 	"go.uber.org/zap"
 
@@ -14,14 +13,13 @@ import (
 	"github.com/luxdefi/node/ids"
 	"github.com/luxdefi/node/proto/pb/p2p"
 	"github.com/luxdefi/node/snow"
+	"github.com/luxdefi/node/snow/consensus/snowman/bootstrapper"
 	"github.com/luxdefi/node/snow/engine/common"
 	"github.com/luxdefi/node/snow/engine/snowman/block"
 	"github.com/luxdefi/node/snow/validators"
 	"github.com/luxdefi/node/utils/logging"
 	"github.com/luxdefi/node/utils/set"
 	"github.com/luxdefi/node/version"
-
-	safemath "github.com/luxdefi/node/utils/math"
 )
 
 // maxOutstandingBroadcastRequests is the maximum number of requests to have
@@ -30,12 +28,6 @@ const maxOutstandingBroadcastRequests = 50
 
 var _ common.StateSyncer = (*stateSyncer)(nil)
 
-// summary content as received from network, along with accumulated weight.
-type weightedSummary struct {
-	summary block.StateSummary
-	weight  uint64
-}
-
 type stateSyncer struct {
 	Config
 
@@ -73,16 +65,14 @@ type stateSyncer struct {
 	// IDs of validators that failed to respond with their state summary frontier
 	failedSeeders set.Set[ids.NodeID]
 
-	// IDs of validators we should request filtering the accepted state summaries from
-	targetVoters set.Set[ids.NodeID]
-	// IDs of validators we requested filtering the accepted state summaries from
-	// but haven't received a reply yet. ID is cleared if/when reply arrives.
-	pendingVoters set.Set[ids.NodeID]
+	// Filters the state summaries of the frontier to the ones that a majority
+	// of the state sync beacons, by weight, report as accepted.
+	majority bootstrapper.Poll
 	// IDs of validators that failed to respond with their filtered accepted state summaries
 	failedVoters set.Set[ids.NodeID]
 
-	// summaryID --> (summary, weight)
-	weightedSummaries map[ids.ID]*weightedSummary
+	// summaryID --> summary
+	summaries map[ids.ID]block.StateSummary
 
 	// summaries received may be different even if referring to the same height
 	// we keep a list of deduplicated height ready for voting
@@ -106,6 +96,7 @@ func New(
 		AppHandler:              cfg.VM,
 		stateSyncVM:             ssVM,
 		onDoneStateSyncing:      onDoneStateSyncing,
+		majority:                bootstrapper.Noop,
 	}
 }
 
@@ -185,9 +176,7 @@ func (ss *stateSyncer) StateSummaryFrontier(ctx context.Context, nodeID ids.Node
 	// make sure next beacons are reached out
 	// even in case invalid summaries are received
 	if summary, err := ss.stateSyncVM.ParseStateSummary(ctx, summaryBytes); err == nil {
-		ss.weightedSummaries[summary.ID()] = &weightedSummary{
-			summary: summary,
-		}
+		ss.summaries[summary.ID()] = summary
 
 		height := summary.Height()
 		if !ss.summariesHeights.Contains(height) {
@@ -282,81 +271,43 @@ func (ss *stateSyncer) AcceptedStateSummary(ctx context.Context, nodeID ids.Node
 		return nil
 	}
 
-	if !ss.pendingVoters.Contains(nodeID) {
-		ss.Ctx.Log.Debug("received unexpected AcceptedStateSummary message",
-			zap.Stringer("nodeID", nodeID),
-		)
-		return nil
-	}
-
-	// Mark that we received a response from [nodeID]
-	ss.pendingVoters.Remove(nodeID)
-
-	nodeWeight := ss.StateSyncBeacons.GetWeight(ss.Ctx.SubnetID, nodeID)
-	ss.Ctx.Log.Debug("adding weight to summaries",
+	ss.Ctx.Log.Debug("received accepted state summaries",
 		zap.Stringer("nodeID", nodeID),
-		zap.Stringer("subnetID", ss.Ctx.SubnetID),
 		zap.Reflect("summaryIDs", summaryIDs),
-		zap.Uint64("nodeWeight", nodeWeight),
 	)
-	for summaryID := range summaryIDs {
-		ws, ok := ss.weightedSummaries[summaryID]
-		if !ok {
-			ss.Ctx.Log.Debug("skipping summary",
-				zap.String("reason", "unknown summary"),
-				zap.Stringer("nodeID", nodeID),
-				zap.Stringer("summaryID", summaryID),
-			)
-			continue
-		}
-
-		newWeight, err := safemath.Add64(nodeWeight, ws.weight)
-		if err != nil {
-			ss.Ctx.Log.Error("failed to calculate new summary weight",
-				zap.Stringer("nodeID", nodeID),
-				zap.Stringer("summaryID", summaryID),
-				zap.Uint64("height", ws.summary.Height()),
-				zap.Uint64("nodeWeight", nodeWeight),
-				zap.Uint64("previousWeight", ws.weight),
-				zap.Error(err),
-			)
-			newWeight = math.MaxUint64
-		}
-
-		ss.Ctx.Log.Verbo("updating summary weight",
-			zap.Stringer("nodeID", nodeID),
-			zap.Stringer("summaryID", summaryID),
-			zap.Uint64("height", ws.summary.Height()),
-			zap.Uint64("previousWeight", ws.weight),
-			zap.Uint64("newWeight", newWeight),
-		)
-		ws.weight = newWeight
+	if err := ss.majority.RecordOpinion(ctx, nodeID, summaryIDs); err != nil {
+		return err
 	}
 
 	ss.sendGetAcceptedStateSummaries(ctx)
 
 	// wait on pending responses
-	if ss.pendingVoters.Len() != 0 {
+	acceptedSummaryIDs, finalized := ss.majority.Result(ctx)
+	if !finalized {
 		return nil
 	}
 
-	// We've received the filtered accepted frontier from every state sync validator
-	// Drop all summaries without a sufficient weight behind them
-	for summaryID, ws := range ss.weightedSummaries {
-		if ws.weight < ss.Alpha {
-			ss.Ctx.Log.Debug("removing summary",
-				zap.String("reason", "insufficient weight"),
+	// Ignore any responses that arrive after the vote has finished.
+	ss.requestID++
+
+	// We've received the filtered accepted frontier from every state sync
+	// validator. Drop all summaries that weren't accepted by a majority of
+	// them.
+	acceptedSummaries := make(map[ids.ID]block.StateSummary, len(acceptedSummaryIDs))
+	for _, summaryID := range acceptedSummaryIDs {
+		summary, ok := ss.summaries[summaryID]
+		if !ok {
+			ss.Ctx.Log.Debug("skipping summary",
+				zap.String("reason", "unknown summary"),
 				zap.Stringer("summaryID", summaryID),
-				zap.Uint64("height", ws.summary.Height()),
-				zap.Uint64("currentWeight", ws.weight),
-				zap.Uint64("requiredWeight", ss.Alpha),
 			)
-			delete(ss.weightedSummaries, summaryID)
+			continue
 		}
+		acceptedSummaries[summaryID] = summary
 	}
 
 	// if we don't have enough weight for the state summary to be accepted then retry or fail the state sync
-	size := len(ss.weightedSummaries)
+	size := len(acceptedSummaries)
 	if size == 0 {
 		// retry the state sync if the weight is not enough to state sync
 		failedVotersWeight, err := ss.StateSyncBeacons.SubsetWeight(ss.Ctx.SubnetID, ss.failedVoters)
@@ -391,7 +342,7 @@ func (ss *stateSyncer) AcceptedStateSummary(ctx context.Context, nodeID ids.Node
 		return ss.onDoneStateSyncing(ctx, ss.requestID)
 	}
 
-	preferredStateSummary := ss.selectSyncableStateSummary()
+	preferredStateSummary := ss.selectSyncableStateSummary(acceptedSummaries)
 	syncMode, err := preferredStateSummary.Accept(ctx)
 	if err != nil {
 		return err
@@ -428,7 +379,7 @@ func (ss *stateSyncer) AcceptedStateSummary(ctx context.Context, nodeID ids.Node
 
 // selectSyncableStateSummary chooses a state summary from all
 // the network validated summaries.
-func (ss *stateSyncer) selectSyncableStateSummary() block.StateSummary {
+func (ss *stateSyncer) selectSyncableStateSummary(summaries map[ids.ID]block.StateSummary) block.StateSummary {
 	var (
 		maxSummaryHeight      uint64
 		preferredStateSummary block.StateSummary
@@ -436,15 +387,15 @@ func (ss *stateSyncer) selectSyncableStateSummary() block.StateSummary {
 
 	// by default pick highest summary, unless locallyAvailableSummary is still valid.
 	// In such case we pick locallyAvailableSummary to allow VM resuming state syncing.
-	for id, ws := range ss.weightedSummaries {
+	for id, summary := range summaries {
 		if ss.locallyAvailableSummary != nil && id == ss.locallyAvailableSummary.ID() {
 			return ss.locallyAvailableSummary
 		}
 
-		height := ws.summary.Height()
+		height := summary.Height()
 		if maxSummaryHeight <= height {
 			maxSummaryHeight = height
-			preferredStateSummary = ws.summary
+			preferredStateSummary = summary
 		}
 	}
 	return preferredStateSummary
@@ -478,15 +429,13 @@ func (ss *stateSyncer) startup(ctx context.Context) error {
 	ss.Config.Ctx.Log.Info("starting state sync")
 
 	// clear up messages trackers
-	ss.weightedSummaries = make(map[ids.ID]*weightedSummary)
+	ss.summaries = make(map[ids.ID]block.StateSummary)
 	ss.summariesHeights.Clear()
 	ss.uniqueSummariesHeights = nil
 
 	ss.targetSeeders.Clear()
 	ss.pendingSeeders.Clear()
 	ss.failedSeeders.Clear()
-	ss.targetVoters.Clear()
-	ss.pendingVoters.Clear()
 	ss.failedVoters.Clear()
 
 	// sample K beacons to retrieve frontier from
@@ -510,7 +459,16 @@ func (ss *stateSyncer) startup(ctx context.Context) error {
 	}
 
 	// list all beacons, to reach them for voting on frontier
-	ss.targetVoters.Add(ss.StateSyncBeacons.GetValidatorIDs(ss.Ctx.SubnetID)...)
+	currentBeacons := ss.StateSyncBeacons.GetMap(ss.Ctx.SubnetID)
+	nodeWeights := make(map[ids.NodeID]uint64, len(currentBeacons))
+	for nodeID, beacon := range currentBeacons {
+		nodeWeights[nodeID] = beacon.Weight
+	}
+	ss.majority = bootstrapper.NewMajority(
+		ss.Ctx.Log,
+		nodeWeights,
+		maxOutstandingBroadcastRequests,
+	)
 
 	// check if there is an ongoing state sync; if so add its state summary
 	// to the frontier to request votes on
@@ -521,9 +479,7 @@ func (ss *stateSyncer) startup(ctx context.Context) error {
 		// no action needed
 	case nil:
 		ss.locallyAvailableSummary = localSummary
-		ss.weightedSummaries[localSummary.ID()] = &weightedSummary{
-			summary: localSummary,
-		}
+		ss.summaries[localSummary.ID()] = localSummary
 
 		height := localSummary.Height()
 		ss.summariesHeights.Add(height)
@@ -563,18 +519,10 @@ func (ss *stateSyncer) sendGetStateSummaryFrontiers(ctx context.Context) {
 // their filtered accepted frontier. It is called again until there are
 // no more voters to be reached in the pending set.
 func (ss *stateSyncer) sendGetAcceptedStateSummaries(ctx context.Context) {
-	vdrs := set.NewSet[ids.NodeID](1)
-	for ss.targetVoters.Len() > 0 && ss.pendingVoters.Len() < maxOutstandingBroadcastRequests {
-		vdr, _ := ss.targetVoters.Pop()
-		vdrs.Add(vdr)
-		ss.pendingVoters.Add(vdr)
-	}
-
-	if len(vdrs) > 0 {
+	if vdrs := ss.majority.GetPeers(ctx); vdrs.Len() > 0 {
 		ss.Sender.SendGetAcceptedStateSummary(ctx, vdrs, ss.requestID, ss.uniqueSummariesHeights)
 		ss.Ctx.Log.Debug("sent GetAcceptedStateSummary messages",
 			zap.Int("numSent", vdrs.Len()),
-			zap.Int("numPending", ss.targetVoters.Len()),
 		)
 	}
 }
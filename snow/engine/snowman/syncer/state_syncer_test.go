@@ -180,10 +180,9 @@ func TestStateSyncLocalSummaryIsIncludedAmongFrontiersIfAvailable(t *testing.T)
 	}
 
 	require.Equal(localSummary, syncer.locallyAvailableSummary)
-	ws, ok := syncer.weightedSummaries[summaryID]
+	summary, ok := syncer.summaries[summaryID]
 	require.True(ok)
-	require.Equal(summaryBytes, ws.summary.Bytes())
-	require.Zero(ws.weight)
+	require.Equal(summaryBytes, summary.Bytes())
 }
 
 func TestStateSyncNotFoundOngoingSummaryIsNotIncludedAmongFrontiers(t *testing.T) {
@@ -212,7 +211,7 @@ func TestStateSyncNotFoundOngoingSummaryIsNotIncludedAmongFrontiers(t *testing.T
 	}
 
 	require.Nil(syncer.locallyAvailableSummary)
-	require.Empty(syncer.weightedSummaries)
+	require.Empty(syncer.summaries)
 }
 
 func TestBeaconsAreReachedForFrontiersUponStartup(t *testing.T) {
@@ -250,7 +249,7 @@ func TestBeaconsAreReachedForFrontiersUponStartup(t *testing.T) {
 	}
 
 	// check that, obviously, no summary is yet registered
-	require.Empty(syncer.weightedSummaries)
+	require.Empty(syncer.summaries)
 }
 
 func TestUnRequestedStateSummaryFrontiersAreDropped(t *testing.T) {
@@ -308,7 +307,7 @@ func TestUnRequestedStateSummaryFrontiersAreDropped(t *testing.T) {
 		summaryBytes,
 	))
 	require.Contains(syncer.pendingSeeders, responsiveBeaconID) // responsiveBeacon still pending
-	require.Empty(syncer.weightedSummaries)
+	require.Empty(syncer.summaries)
 
 	// check a response from unsolicited node is dropped
 	unsolicitedNodeID := ids.GenerateTestNodeID()
@@ -318,7 +317,7 @@ func TestUnRequestedStateSummaryFrontiersAreDropped(t *testing.T) {
 		responsiveBeaconReqID,
 		summaryBytes,
 	))
-	require.Empty(syncer.weightedSummaries)
+	require.Empty(syncer.summaries)
 
 	// check a valid response is duly recorded
 	require.NoError(syncer.StateSummaryFrontier(
@@ -332,9 +331,9 @@ func TestUnRequestedStateSummaryFrontiersAreDropped(t *testing.T) {
 	require.NotContains(syncer.pendingSeeders, responsiveBeaconID)
 
 	// valid summary is recorded
-	ws, ok := syncer.weightedSummaries[summaryID]
+	summary, ok := syncer.summaries[summaryID]
 	require.True(ok)
-	require.True(bytes.Equal(ws.summary.Bytes(), summaryBytes))
+	require.True(bytes.Equal(summary.Bytes(), summaryBytes))
 
 	// other listed vdrs are reached for data
 	require.True(
@@ -401,7 +400,7 @@ func TestMalformedStateSummaryFrontiersAreDropped(t *testing.T) {
 
 	// invalid summary is not recorded
 	require.True(isSummaryDecoded)
-	require.Empty(syncer.weightedSummaries)
+	require.Empty(syncer.summaries)
 
 	// even in case of invalid summaries, other listed vdrs
 	// are reached for data
@@ -489,7 +488,7 @@ func TestLateResponsesFromUnresponsiveFrontiersAreNotRecorded(t *testing.T) {
 	))
 
 	// late summary is not recorded
-	require.Empty(syncer.weightedSummaries)
+	require.Empty(syncer.summaries)
 }
 
 func TestStateSyncIsRestartedIfTooManyFrontierSeedersTimeout(t *testing.T) {
@@ -675,13 +674,25 @@ func TestUnRequestedVotesAreDropped(t *testing.T) {
 	}
 
 	// mock VM to simulate a valid summary is returned
+	summaryAccepted := false
+	summary := &block.TestStateSummary{
+		HeightV: key,
+		IDV:     summaryID,
+		BytesV:  summaryBytes,
+		AcceptF: func(context.Context) (block.StateSyncMode, error) {
+			summaryAccepted = true
+			return block.StateSyncStatic, nil
+		},
+	}
 	fullVM.CantParseStateSummary = true
-	fullVM.ParseStateSummaryF = func(_ context.Context, summaryBytes []byte) (block.StateSummary, error) {
-		return &block.TestStateSummary{
-			HeightV: key,
-			IDV:     summaryID,
-			BytesV:  summaryBytes,
-		}, nil
+	fullVM.ParseStateSummaryF = func(context.Context, []byte) (block.StateSummary, error) {
+		return summary, nil
+	}
+
+	stateSyncFullyDone := false
+	syncer.onDoneStateSyncing = func(context.Context, uint32) error {
+		stateSyncFullyDone = true
+		return nil
 	}
 
 	contactedVoters := make(map[ids.NodeID]uint32) // nodeID -> reqID map
@@ -718,7 +729,7 @@ func TestUnRequestedVotesAreDropped(t *testing.T) {
 	require.Positive(initiallyContactedVotersSize)
 	require.LessOrEqual(initiallyContactedVotersSize, maxOutstandingBroadcastRequests)
 
-	_, found := syncer.weightedSummaries[summaryID]
+	_, found := syncer.summaries[summaryID]
 	require.True(found)
 
 	// pick one of the voters that have been reached out
@@ -733,10 +744,6 @@ func TestUnRequestedVotesAreDropped(t *testing.T) {
 		set.Of(summaryID),
 	))
 
-	// responsiveVoter still pending
-	require.Contains(syncer.pendingVoters, responsiveVoterID)
-	require.Zero(syncer.weightedSummaries[summaryID].weight)
-
 	// check a response from unsolicited node is dropped
 	unsolicitedVoterID := ids.GenerateTestNodeID()
 	require.NoError(syncer.AcceptedStateSummary(
@@ -745,25 +752,47 @@ func TestUnRequestedVotesAreDropped(t *testing.T) {
 		responsiveVoterReqID,
 		set.Of(summaryID),
 	))
-	require.Zero(syncer.weightedSummaries[summaryID].weight)
 
 	// check a valid response is duly recorded
 	require.NoError(syncer.AcceptedStateSummary(
 		context.Background(),
 		responsiveVoterID,
 		responsiveVoterReqID,
-		set.Of(summaryID),
+		nil,
 	))
 
-	// responsiveBeacon not pending anymore
-	require.NotContains(syncer.pendingSeeders, responsiveVoterID)
-	voterWeight := beacons.GetWeight(ctx.SubnetID, responsiveVoterID)
-	require.Equal(voterWeight, syncer.weightedSummaries[summaryID].weight)
-
 	// other listed voters are reached out
 	require.True(
 		len(contactedVoters) > initiallyContactedVotersSize ||
 			len(contactedVoters) == beacons.Count(ctx.SubnetID))
+
+	// let the other voters respond, so that exactly half of the weight votes
+	// for the summary
+	votedWeight := uint64(0)
+	respondedVoters := set.Of(responsiveVoterID)
+	for {
+		voterID, found := pickPendingVoter(contactedVoters, respondedVoters)
+		if !found {
+			break
+		}
+		reqID := contactedVoters[voterID]
+
+		var summaryIDs set.Set[ids.ID]
+		if votedWeight < totalWeight/2 {
+			summaryIDs = set.Of(summaryID)
+			votedWeight += beacons.GetWeight(ctx.SubnetID, voterID)
+		}
+		require.NoError(syncer.AcceptedStateSummary(
+			context.Background(),
+			voterID,
+			reqID,
+			summaryIDs,
+		))
+	}
+
+	// the dropped votes would have given the summary a majority
+	require.False(summaryAccepted)
+	require.True(stateSyncFullyDone)
 }
 
 func TestVotesForUnknownSummariesAreDropped(t *testing.T) {
@@ -791,13 +820,25 @@ func TestVotesForUnknownSummariesAreDropped(t *testing.T) {
 	}
 
 	// mock VM to simulate a valid summary is returned
+	summaryAccepted := false
+	summary := &block.TestStateSummary{
+		HeightV: key,
+		IDV:     summaryID,
+		BytesV:  summaryBytes,
+		AcceptF: func(context.Context) (block.StateSyncMode, error) {
+			summaryAccepted = true
+			return block.StateSyncStatic, nil
+		},
+	}
 	fullVM.CantParseStateSummary = true
-	fullVM.ParseStateSummaryF = func(_ context.Context, summaryBytes []byte) (block.StateSummary, error) {
-		return &block.TestStateSummary{
-			HeightV: key,
-			IDV:     summaryID,
-			BytesV:  summaryBytes,
-		}, nil
+	fullVM.ParseStateSummaryF = func(context.Context, []byte) (block.StateSummary, error) {
+		return summary, nil
+	}
+
+	stateSyncFullyDone := false
+	syncer.onDoneStateSyncing = func(context.Context, uint32) error {
+		stateSyncFullyDone = true
+		return nil
 	}
 
 	contactedVoters := make(map[ids.NodeID]uint32) // nodeID -> reqID map
@@ -834,7 +875,7 @@ func TestVotesForUnknownSummariesAreDropped(t *testing.T) {
 	require.Positive(initiallyContactedVotersSize)
 	require.LessOrEqual(initiallyContactedVotersSize, maxOutstandingBroadcastRequests)
 
-	_, found := syncer.weightedSummaries[summaryID]
+	_, found := syncer.summaries[summaryID]
 	require.True(found)
 
 	// pick one of the voters that have been reached out
@@ -848,24 +889,51 @@ func TestVotesForUnknownSummariesAreDropped(t *testing.T) {
 		responsiveVoterReqID,
 		set.Of(unknownSummaryID),
 	))
-	_, found = syncer.weightedSummaries[unknownSummaryID]
+	_, found = syncer.summaries[unknownSummaryID]
 	require.False(found)
 
 	// check that responsiveVoter cannot cast another vote
-	require.NotContains(syncer.pendingSeeders, responsiveVoterID)
 	require.NoError(syncer.AcceptedStateSummary(
 		context.Background(),
 		responsiveVoterID,
 		responsiveVoterReqID,
 		set.Of(summaryID),
 	))
-	require.Zero(syncer.weightedSummaries[summaryID].weight)
 
 	// other listed voters are reached out, even in the face of vote
 	// on unknown summary
 	require.True(
 		len(contactedVoters) > initiallyContactedVotersSize ||
 			len(contactedVoters) == beacons.Count(ctx.SubnetID))
+
+	// let the other voters respond, so that exactly half of the weight votes
+	// for the summary
+	votedWeight := uint64(0)
+	respondedVoters := set.Of(responsiveVoterID)
+	for {
+		voterID, found := pickPendingVoter(contactedVoters, respondedVoters)
+		if !found {
+			break
+		}
+		reqID := contactedVoters[voterID]
+
+		var summaryIDs set.Set[ids.ID]
+		if votedWeight < totalWeight/2 {
+			summaryIDs = set.Of(summaryID)
+			votedWeight += beacons.GetWeight(ctx.SubnetID, voterID)
+		}
+		require.NoError(syncer.AcceptedStateSummary(
+			context.Background(),
+			voterID,
+			reqID,
+			summaryIDs,
+		))
+	}
+
+	// the second vote of responsiveVoter would have given the summary a
+	// majority
+	require.False(summaryAccepted)
+	require.True(stateSyncFullyDone)
 }
 
 func TestStateSummaryIsPassedToVMAsMajorityOfVotesIsCastedForIt(t *testing.T) {
@@ -876,7 +944,7 @@ func TestStateSummaryIsPassedToVMAsMajorityOfVotesIsCastedForIt(t *testing.T) {
 	totalWeight, err := beacons.TotalWeight(ctx.SubnetID)
 	require.NoError(err)
 	startupAlpha := (3*totalWeight + 3) / 4
-	alpha := (totalWeight + 1) / 2
+	alpha := totalWeight/2 + 1
 
 	peers := tracker.NewPeers()
 	startup := tracker.NewStartup(peers, startupAlpha)
@@ -974,9 +1042,12 @@ func TestStateSummaryIsPassedToVMAsMajorityOfVotesIsCastedForIt(t *testing.T) {
 
 	// let a majority of voters return summaryID, and a minority return minoritySummaryID. The rest timeout.
 	cumulatedWeight := uint64(0)
-	for syncer.pendingVoters.Len() != 0 {
-		voterID, found := syncer.pendingVoters.Peek()
-		require.True(found)
+	respondedVoters := set.Set[ids.NodeID]{}
+	for {
+		voterID, found := pickPendingVoter(contactedVoters, respondedVoters)
+		if !found {
+			break
+		}
 		reqID := contactedVoters[voterID]
 
 		switch {
@@ -1086,9 +1157,12 @@ func TestVotingIsRestartedIfMajorityIsNotReachedDueToTimeouts(t *testing.T) {
 
 	// Let a majority of voters timeout.
 	timedOutWeight := uint64(0)
-	for syncer.pendingVoters.Len() != 0 {
-		voterID, found := syncer.pendingVoters.Peek()
-		require.True(found)
+	respondedVoters := set.Set[ids.NodeID]{}
+	for {
+		voterID, found := pickPendingVoter(contactedVoters, respondedVoters)
+		if !found {
+			break
+		}
 		reqID := contactedVoters[voterID]
 
 		// vdr carries the largest weight by far. Make sure it fails
@@ -1113,7 +1187,8 @@ func TestVotingIsRestartedIfMajorityIsNotReachedDueToTimeouts(t *testing.T) {
 	require.False(minoritySummaryCalled)
 
 	// instead the whole process is restared
-	require.Empty(syncer.pendingVoters)     // no voters reached
+	_, finalized := syncer.majority.Result(context.Background())
+	require.False(finalized)                // no voters reached
 	require.NotEmpty(syncer.pendingSeeders) // frontiers providers reached again
 }
 
@@ -1230,9 +1305,12 @@ func TestStateSyncIsStoppedIfEnoughVotesAreCastedWithNoClearMajority(t *testing.
 	// let all votes respond in time without any summary reaching a majority.
 	// We achieve it by making most nodes voting for an invalid summaryID.
 	votingWeightStake := uint64(0)
-	for syncer.pendingVoters.Len() != 0 {
-		voterID, found := syncer.pendingVoters.Peek()
-		require.True(found)
+	respondedVoters := set.Set[ids.NodeID]{}
+	for {
+		voterID, found := pickPendingVoter(contactedVoters, respondedVoters)
+		if !found {
+			break
+		}
 		reqID := contactedVoters[voterID]
 
 		switch {
@@ -19,6 +19,7 @@ import (
 	"github.com/luxdefi/node/snow/engine/snowman/getter"
 	"github.com/luxdefi/node/snow/validators"
 	"github.com/luxdefi/node/utils/hashing"
+	"github.com/luxdefi/node/utils/set"
 )
 
 const (
@@ -132,3 +133,15 @@ func pickRandomFrom(nodes map[ids.NodeID]uint32) ids.NodeID {
 	}
 	return ids.EmptyNodeID
 }
+
+// pickPendingVoter returns one of [contactedVoters] that isn't in
+// [respondedVoters] and marks it as responded.
+func pickPendingVoter(contactedVoters map[ids.NodeID]uint32, respondedVoters set.Set[ids.NodeID]) (ids.NodeID, bool) {
+	for nodeID := range contactedVoters {
+		if !respondedVoters.Contains(nodeID) {
+			respondedVoters.Add(nodeID)
+			return nodeID, true
+		}
+	}
+	return ids.EmptyNodeID, false
+}
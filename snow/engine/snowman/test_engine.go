@@ -18,6 +18,7 @@ var (
 	_ Engine = (*EngineTest)(nil)
 
 	errGetBlock = errors.New("unexpectedly called GetBlock")
+	errInspect  = errors.New("unexpectedly called Inspect")
 )
 
 // EngineTest is a test engine
@@ -26,11 +27,15 @@ type EngineTest struct {
 
 	CantGetBlock bool
 	GetBlockF    func(context.Context, ids.ID) (snowman.Block, error)
+
+	CantInspect bool
+	InspectF    func(context.Context) (Inspection, error)
 }
 
 func (e *EngineTest) Default(cant bool) {
 	e.EngineTest.Default(cant)
 	e.CantGetBlock = false
+	e.CantInspect = false
 }
 
 func (e *EngineTest) GetBlock(ctx context.Context, blkID ids.ID) (snowman.Block, error) {
@@ -42,3 +47,13 @@ func (e *EngineTest) GetBlock(ctx context.Context, blkID ids.ID) (snowman.Block,
 	}
 	return nil, errGetBlock
 }
+
+func (e *EngineTest) Inspect(ctx context.Context) (Inspection, error) {
+	if e.InspectF != nil {
+		return e.InspectF(ctx)
+	}
+	if e.CantInspect && e.T != nil {
+		require.FailNow(e.T, errInspect.Error())
+	}
+	return Inspection{}, errInspect
+}
@@ -40,3 +40,10 @@ func (e *tracedEngine) GetBlock(ctx context.Context, blkID ids.ID) (snowman.Bloc
 
 	return e.engine.GetBlock(ctx, blkID)
 }
+
+func (e *tracedEngine) Inspect(ctx context.Context) (Inspection, error) {
+	ctx, span := e.tracer.Start(ctx, "tracedEngine.Inspect")
+	defer span.End()
+
+	return e.engine.Inspect(ctx)
+}
@@ -5,6 +5,7 @@ package snowman
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -43,7 +44,11 @@ const (
 	putGossipPeriod = 10
 )
 
-var _ Engine = (*Transitive)(nil)
+var (
+	_ Engine = (*Transitive)(nil)
+
+	errConsensusNotStarted = errors.New("consensus hasn't started")
+)
 
 func New(config Config) (Engine, error) {
 	return newTransitive(config)
@@ -129,6 +134,15 @@ func newTransitive(config Config) (*Transitive, error) {
 		config.Params.AlphaPreference,
 		config.Params.AlphaConfidence,
 	)
+	if config.Params.StakeWeightedPolls {
+		factory = poll.NewEarlyTermNoTraversalWeightedFactory(
+			config.Params.AlphaPreference,
+			config.Params.AlphaConfidence,
+			func(nodeID ids.NodeID) uint64 {
+				return config.Validators.GetWeight(config.Ctx.SubnetID, nodeID)
+			},
+		)
+	}
 	polls, err := poll.NewSet(
 		factory,
 		config.Ctx.Log,
@@ -380,6 +394,9 @@ func (t *Transitive) PushQuery(ctx context.Context, nodeID ids.NodeID, requestID
 }
 
 func (t *Transitive) Chits(ctx context.Context, nodeID ids.NodeID, requestID uint32, preferredID ids.ID, preferredIDAtHeight ids.ID, acceptedID ids.ID) error {
+	if t.Sampler != nil {
+		t.Sampler.RegisterResponse(nodeID, requestID)
+	}
 	t.acceptedFrontiers.SetLastAccepted(nodeID, acceptedID)
 
 	t.Ctx.Log.Verbo("called Chits for the block",
@@ -437,6 +454,13 @@ func (t *Transitive) Chits(ctx context.Context, nodeID ids.NodeID, requestID uin
 }
 
 func (t *Transitive) QueryFailed(ctx context.Context, nodeID ids.NodeID, requestID uint32) error {
+	// The failure is registered before the last accepted block is substituted
+	// as the response below, so that the sampler doesn't count it as a real
+	// response.
+	if t.Sampler != nil {
+		t.Sampler.RegisterFailure(nodeID, requestID)
+	}
+
 	lastAccepted, ok := t.acceptedFrontiers.LastAccepted(nodeID)
 	if ok {
 		return t.Chits(ctx, nodeID, requestID, lastAccepted, lastAccepted, lastAccepted)
@@ -571,6 +595,22 @@ func (t *Transitive) HealthCheck(ctx context.Context) (interface{}, error) {
 	return intf, fmt.Errorf("vm: %w ; consensus: %w", vmErr, consensusErr)
 }
 
+func (t *Transitive) Inspect(context.Context) (Inspection, error) {
+	t.Ctx.Lock.Lock()
+	defer t.Ctx.Lock.Unlock()
+
+	// Consensus is only initialized once the engine has started.
+	if t.Ctx.State.Get().State != snow.NormalOp {
+		return Inspection{}, errConsensusNotStarted
+	}
+	return Inspection{
+		Inspection:       t.Consensus.Inspect(),
+		Polls:            t.polls.Polls(),
+		NumPendingBlocks: len(t.pending),
+		NumBlockRequests: t.blkReqs.Len(),
+	}, nil
+}
+
 func (t *Transitive) GetBlock(ctx context.Context, blkID ids.ID) (snowman.Block, error) {
 	if blk, ok := t.pending[blkID]; ok {
 		return blk, nil
@@ -921,7 +961,7 @@ func (t *Transitive) sendQuery(
 		zap.Stringer("validators", t.Validators),
 	)
 
-	vdrIDs, err := t.Validators.Sample(t.Ctx.SubnetID, t.Params.K)
+	vdrIDs, err := t.sampleValidators()
 	if err != nil {
 		t.Ctx.Log.Error("dropped query for block",
 			zap.String("reason", "insufficient number of validators"),
@@ -955,6 +995,9 @@ func (t *Transitive) sendQuery(
 	}
 
 	vdrSet := set.Of(vdrIDs...)
+	if t.Sampler != nil {
+		t.Sampler.RegisterQuery(vdrSet, t.requestID)
+	}
 	if push {
 		t.Sender.SendPushQuery(ctx, vdrSet, t.requestID, blkBytes, nextHeightToAccept)
 	} else {
@@ -962,6 +1005,14 @@ func (t *Transitive) sendQuery(
 	}
 }
 
+// sampleValidators returns the K validators that a query is sent to.
+func (t *Transitive) sampleValidators() ([]ids.NodeID, error) {
+	if t.Sampler != nil {
+		return t.Sampler.Sample(t.Params.K)
+	}
+	return t.Validators.Sample(t.Ctx.SubnetID, t.Params.K)
+}
+
 // issue [blk] to consensus
 // If [push] is true, a push query will be used. Otherwise, a pull query will be
 // used.
@@ -744,6 +744,114 @@ func TestEngineRepoll(t *testing.T) {
 	require.True(*queried)
 }
 
+func TestEngineInspect(t *testing.T) {
+	require := require.New(t)
+	vdr, _, sender, _, te, gBlk := setupDefaultConfig(t)
+
+	sender.Default(true)
+
+	requestID := new(uint32)
+	sender.SendPullQueryF = func(_ context.Context, _ set.Set[ids.NodeID], reqID uint32, _ ids.ID, _ uint64) {
+		*requestID = reqID
+	}
+
+	te.repoll(context.Background())
+
+	inspection, err := te.Inspect(context.Background())
+	require.NoError(err)
+	require.Equal(gBlk.ID(), inspection.LastAcceptedID)
+	require.Equal(gBlk.ID(), inspection.Preference)
+	require.Len(inspection.Blocks, 1)
+	require.Len(inspection.Polls, 1)
+	require.Equal(*requestID, inspection.Polls[0].RequestID)
+	require.Equal([]ids.NodeID{vdr}, inspection.Polls[0].Pending)
+}
+
+func TestEngineStakeWeightedPolls(t *testing.T) {
+	require := require.New(t)
+
+	engCfg := DefaultConfig()
+	engCfg.Params.StakeWeightedPolls = true
+	_, _, sender, _, te, _ := setup(t, engCfg)
+
+	sender.Default(true)
+	sender.CantSendPullQuery = false
+
+	te.repoll(context.Background())
+
+	require.Equal(1, te.polls.Len())
+	require.Contains(te.polls.String(), "waiting on weight 1 of 1")
+}
+
+type testSampler struct {
+	nodeID    ids.NodeID
+	queries   []uint32
+	responses []uint32
+	failures  []uint32
+}
+
+func (s *testSampler) Sample(size int) ([]ids.NodeID, error) {
+	sampled := make([]ids.NodeID, size)
+	for i := range sampled {
+		sampled[i] = s.nodeID
+	}
+	return sampled, nil
+}
+
+func (s *testSampler) RegisterQuery(_ set.Set[ids.NodeID], requestID uint32) {
+	s.queries = append(s.queries, requestID)
+}
+
+func (s *testSampler) RegisterResponse(_ ids.NodeID, requestID uint32) {
+	s.responses = append(s.responses, requestID)
+}
+
+func (s *testSampler) RegisterFailure(_ ids.NodeID, requestID uint32) {
+	s.failures = append(s.failures, requestID)
+}
+
+func (*testSampler) Seed(int64) {}
+
+func (*testSampler) ClearSeed() {}
+
+func TestEngineSampler(t *testing.T) {
+	require := require.New(t)
+
+	vdr, _, sender, vm, te, gBlk := setupDefaultConfig(t)
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		require.Equal(gBlk.ID(), blkID)
+		return gBlk, nil
+	}
+	sampler := &testSampler{nodeID: vdr}
+	te.Sampler = sampler
+
+	var queried set.Set[ids.NodeID]
+	sender.SendPullQueryF = func(_ context.Context, nodeIDs set.Set[ids.NodeID], _ uint32, _ ids.ID, _ uint64) {
+		queried = nodeIDs
+	}
+
+	te.repoll(context.Background())
+	require.Equal(set.Of(vdr), queried)
+	require.Equal([]uint32{te.requestID}, sampler.queries)
+
+	require.NoError(te.Chits(context.Background(), vdr, te.requestID, gBlk.ID(), gBlk.ID(), gBlk.ID()))
+	require.Equal([]uint32{te.requestID}, sampler.responses)
+
+	te.repoll(context.Background())
+	require.NoError(te.QueryFailed(context.Background(), vdr, te.requestID))
+	require.Equal([]uint32{te.requestID}, sampler.failures)
+}
+
+func TestEngineInspectBeforeStart(t *testing.T) {
+	require := require.New(t)
+
+	te, err := newTransitive(DefaultConfig())
+	require.NoError(err)
+
+	_, err = te.Inspect(context.Background())
+	require.ErrorIs(err, errConsensusNotStarted)
+}
+
 func TestVoteCanceling(t *testing.T) {
 	require := require.New(t)
 
@@ -11,6 +11,8 @@ import (
 
 	"go.uber.org/zap"
 
+	"golang.org/x/exp/slices"
+
 	"github.com/luxdefi/node/ids"
 	"github.com/luxdefi/node/snow"
 	"github.com/luxdefi/node/snow/validators"
@@ -39,6 +41,35 @@ type Benchlist interface {
 	// IsBenched returns true if messages to [validatorID]
 	// should not be sent over the network and should immediately fail.
 	IsBenched(nodeID ids.NodeID) bool
+	// Status returns whether benching is enabled and the currently benched
+	// nodes, ordered by when they will leave the bench.
+	Status() Status
+	// Unbench removes [nodeID] from the bench. Returns false if [nodeID] isn't
+	// benched.
+	Unbench(nodeID ids.NodeID) bool
+	// SetEnabled sets whether nodes may be benched. Disabling benching removes
+	// every node from the bench and discards all failure streaks.
+	SetEnabled(enabled bool)
+}
+
+// Status is the state of the benchlist of a chain
+type Status struct {
+	Enabled bool
+	Benched []BenchedNode
+}
+
+// BenchedNode describes why and for how long a node is benched
+type BenchedNode struct {
+	NodeID ids.NodeID
+	Reason string
+	// Number of consecutive failed queries that caused the node to be benched
+	Failures int
+	// Time the node was benched
+	BenchedAt time.Time
+	// Time the node will leave the bench
+	BenchedUntil time.Time
+	// Time left until the node leaves the bench
+	Remaining time.Duration
 }
 
 type failureStreak struct {
@@ -61,6 +92,9 @@ type benchlist struct {
 	// Tells the time. Can be faked for testing.
 	clock mockable.Clock
 
+	// True if benching has been turned off
+	disabled bool
+
 	// notified when a node is benched or unbenched
 	benchable Benchable
 
@@ -75,6 +109,9 @@ type benchlist struct {
 	// IDs of validators that are currently benched
 	benchlistSet set.Set[ids.NodeID]
 
+	// Validator ID --> Why the validator was benched
+	benchInfo map[ids.NodeID]BenchedNode
+
 	// Min heap of benched validators ordered by when they can be unbenched
 	benchedHeap heap.Map[ids.NodeID, time.Time]
 
@@ -109,6 +146,7 @@ func NewBenchlist(
 		ctx:                    ctx,
 		failureStreaks:         make(map[ids.NodeID]failureStreak),
 		benchlistSet:           set.Set[ids.NodeID]{},
+		benchInfo:              make(map[ids.NodeID]BenchedNode),
 		benchable:              benchable,
 		benchedHeap:            heap.NewMap[ids.NodeID, time.Time](time.Time.Before),
 		vdrs:                   validators,
@@ -145,7 +183,15 @@ func (b *benchlist) remove() {
 	b.ctx.Log.Debug("removing node from benchlist",
 		zap.Stringer("nodeID", nodeID),
 	)
+	b.unbenched(nodeID)
+}
+
+// Updates the benchlist state after [nodeID] has been removed from
+// [b.benchedHeap].
+// Assumes [b.lock] is held
+func (b *benchlist) unbenched(nodeID ids.NodeID) {
 	b.benchlistSet.Remove(nodeID)
+	delete(b.benchInfo, nodeID)
 	b.benchable.Unbenched(b.ctx.ChainID, nodeID)
 
 	// Update metrics
@@ -203,6 +249,65 @@ func (b *benchlist) isBenched(nodeID ids.NodeID) bool {
 	return false
 }
 
+func (b *benchlist) Status() Status {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	now := b.clock.Time()
+	benched := make([]BenchedNode, 0, len(b.benchInfo))
+	for _, info := range b.benchInfo {
+		info.Remaining = safemath.Max(info.BenchedUntil.Sub(now), 0)
+		benched = append(benched, info)
+	}
+	slices.SortFunc(benched, func(i, j BenchedNode) int {
+		return i.BenchedUntil.Compare(j.BenchedUntil)
+	})
+	return Status{
+		Enabled: !b.disabled,
+		Benched: benched,
+	}
+}
+
+func (b *benchlist) Unbench(nodeID ids.NodeID) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if _, ok := b.benchedHeap.Remove(nodeID); !ok {
+		return false
+	}
+	b.ctx.Log.Info("manually removing node from benchlist",
+		zap.Stringer("nodeID", nodeID),
+	)
+	b.unbenched(nodeID)
+	b.setNextLeaveTime()
+	return true
+}
+
+func (b *benchlist) SetEnabled(enabled bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.disabled == !enabled {
+		return
+	}
+	b.disabled = !enabled
+	b.ctx.Log.Info("changing benchlist status",
+		zap.Bool("enabled", enabled),
+	)
+	if enabled {
+		return
+	}
+
+	for b.benchedHeap.Len() > 0 {
+		b.remove()
+	}
+	b.setNextLeaveTime()
+
+	b.streaklock.Lock()
+	b.failureStreaks = make(map[ids.NodeID]failureStreak)
+	b.streaklock.Unlock()
+}
+
 // RegisterResponse notes that we received a response from validator [validatorID]
 func (b *benchlist) RegisterResponse(nodeID ids.NodeID) {
 	b.streaklock.Lock()
@@ -215,6 +320,10 @@ func (b *benchlist) RegisterFailure(nodeID ids.NodeID) {
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
+	if b.disabled {
+		return
+	}
+
 	if b.benchlistSet.Contains(nodeID) {
 		// This validator is benched. Ignore failures until they're not.
 		return
@@ -234,13 +343,13 @@ func (b *benchlist) RegisterFailure(nodeID ids.NodeID) {
 	b.streaklock.Unlock()
 
 	if failureStreak.consecutive >= b.threshold && now.After(failureStreak.firstFailure.Add(b.minimumFailingDuration)) {
-		b.bench(nodeID)
+		b.bench(nodeID, failureStreak)
 	}
 }
 
 // Assumes [b.lock] is held
 // Assumes [nodeID] is not already benched
-func (b *benchlist) bench(nodeID ids.NodeID) {
+func (b *benchlist) bench(nodeID ids.NodeID, failureStreak failureStreak) {
 	validatorStake := b.vdrs.GetWeight(b.ctx.SubnetID, nodeID)
 	if validatorStake == 0 {
 		// We might want to bench a non-validator because they don't respond to
@@ -297,6 +406,17 @@ func (b *benchlist) bench(nodeID ids.NodeID) {
 
 	// Add to benchlist times with randomized delay
 	b.benchlistSet.Add(nodeID)
+	b.benchInfo[nodeID] = BenchedNode{
+		NodeID: nodeID,
+		Reason: fmt.Sprintf(
+			"%d consecutive queries failed over %s",
+			failureStreak.consecutive,
+			now.Sub(failureStreak.firstFailure),
+		),
+		Failures:     failureStreak.consecutive,
+		BenchedAt:    now,
+		BenchedUntil: benchedUntil,
+	}
 	b.benchable.Benched(b.ctx.ChainID, nodeID)
 
 	b.streaklock.Lock()
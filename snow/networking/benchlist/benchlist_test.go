@@ -371,3 +371,143 @@ func TestBenchlistRemove(t *testing.T) {
 
 	require.Equal(3, count)
 }
+
+// Test that benched validators can be inspected and manually unbenched
+func TestBenchlistStatusAndUnbench(t *testing.T) {
+	require := require.New(t)
+
+	ctx := snow.DefaultConsensusContextTest()
+	vdrs := validators.NewManager()
+	vdrID0 := ids.GenerateTestNodeID()
+	vdrID1 := ids.GenerateTestNodeID()
+
+	require.NoError(vdrs.AddStaker(ctx.SubnetID, vdrID0, nil, ids.Empty, 50))
+	require.NoError(vdrs.AddStaker(ctx.SubnetID, vdrID1, nil, ids.Empty, 50))
+
+	unbenched := []ids.NodeID{}
+	benchable := &TestBenchable{
+		T: t,
+		UnbenchedF: func(_ ids.ID, nodeID ids.NodeID) {
+			unbenched = append(unbenched, nodeID)
+		},
+	}
+	benchable.Default(true)
+
+	threshold := 3
+	duration := time.Minute
+	maxPortion := 0.5
+	benchIntf, err := NewBenchlist(
+		ctx,
+		benchable,
+		vdrs,
+		threshold,
+		minimumFailingDuration,
+		duration,
+		maxPortion,
+	)
+	require.NoError(err)
+	b := benchIntf.(*benchlist)
+	defer b.timer.Stop()
+	now := time.Now()
+	b.clock.Set(now)
+
+	status := b.Status()
+	require.True(status.Enabled)
+	require.Empty(status.Benched)
+
+	for i := 0; i < threshold; i++ {
+		b.RegisterFailure(vdrID0)
+	}
+	b.clock.Set(now.Add(minimumFailingDuration).Add(time.Second))
+	benchable.BenchedF = func(ids.ID, ids.NodeID) {}
+	b.RegisterFailure(vdrID0)
+	require.True(b.IsBenched(vdrID0))
+
+	status = b.Status()
+	require.True(status.Enabled)
+	require.Len(status.Benched, 1)
+	benched := status.Benched[0]
+	require.Equal(vdrID0, benched.NodeID)
+	require.Equal(threshold+1, benched.Failures)
+	require.NotEmpty(benched.Reason)
+	require.Equal(b.clock.Time(), benched.BenchedAt)
+	require.True(benched.BenchedUntil.After(benched.BenchedAt))
+	require.Equal(benched.BenchedUntil.Sub(b.clock.Time()), benched.Remaining)
+
+	require.False(b.Unbench(vdrID1))
+	require.Empty(unbenched)
+
+	require.True(b.Unbench(vdrID0))
+	require.Equal([]ids.NodeID{vdrID0}, unbenched)
+	require.False(b.IsBenched(vdrID0))
+	require.Empty(b.Status().Benched)
+	require.Zero(b.benchedHeap.Len())
+	require.False(b.Unbench(vdrID0))
+}
+
+// Test that disabling the benchlist unbenches validators and prevents new ones
+// from being benched
+func TestBenchlistSetEnabled(t *testing.T) {
+	require := require.New(t)
+
+	ctx := snow.DefaultConsensusContextTest()
+	vdrs := validators.NewManager()
+	vdrID0 := ids.GenerateTestNodeID()
+	vdrID1 := ids.GenerateTestNodeID()
+
+	require.NoError(vdrs.AddStaker(ctx.SubnetID, vdrID0, nil, ids.Empty, 50))
+	require.NoError(vdrs.AddStaker(ctx.SubnetID, vdrID1, nil, ids.Empty, 50))
+
+	benchable := &TestBenchable{T: t}
+	benchable.Default(false)
+
+	threshold := 3
+	duration := time.Minute
+	maxPortion := 0.5
+	benchIntf, err := NewBenchlist(
+		ctx,
+		benchable,
+		vdrs,
+		threshold,
+		minimumFailingDuration,
+		duration,
+		maxPortion,
+	)
+	require.NoError(err)
+	b := benchIntf.(*benchlist)
+	defer b.timer.Stop()
+	now := time.Now()
+	b.clock.Set(now)
+
+	for i := 0; i < threshold; i++ {
+		b.RegisterFailure(vdrID0)
+		b.RegisterFailure(vdrID1)
+	}
+	b.clock.Set(now.Add(minimumFailingDuration).Add(time.Second))
+	b.RegisterFailure(vdrID0)
+	require.True(b.IsBenched(vdrID0))
+	require.Contains(b.failureStreaks, vdrID1)
+
+	b.SetEnabled(false)
+	status := b.Status()
+	require.False(status.Enabled)
+	require.Empty(status.Benched)
+	require.False(b.IsBenched(vdrID0))
+	require.Empty(b.failureStreaks)
+
+	// Failures are ignored while benching is disabled
+	for i := 0; i < threshold+1; i++ {
+		b.RegisterFailure(vdrID1)
+	}
+	require.False(b.IsBenched(vdrID1))
+	require.Empty(b.failureStreaks)
+
+	b.SetEnabled(true)
+	require.True(b.Status().Enabled)
+	for i := 0; i < threshold; i++ {
+		b.RegisterFailure(vdrID1)
+	}
+	b.clock.Set(b.clock.Time().Add(minimumFailingDuration).Add(time.Second))
+	b.RegisterFailure(vdrID1)
+	require.True(b.IsBenched(vdrID1))
+}
@@ -4,6 +4,8 @@
 package benchlist
 
 import (
+	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -12,7 +14,12 @@ import (
 	"github.com/luxdefi/node/snow/validators"
 )
 
-var _ Manager = (*manager)(nil)
+var (
+	_ Manager = (*manager)(nil)
+
+	errUnknownChain = errors.New("unknown chain")
+	errNotBenched   = errors.New("node is not benched")
+)
 
 // Manager provides an interface for a benchlist to register whether
 // queries have been successful or unsuccessful and place validators with
@@ -35,6 +42,12 @@ type Manager interface {
 	// [nodeID] is benched. If called on an id.ShortID that does
 	// not map to a validator, it will return an empty array.
 	GetBenched(nodeID ids.NodeID) []ids.ID
+	// Status returns the state of the benchlist of every registered chain.
+	Status() map[ids.ID]Status
+	// Unbench removes [nodeID] from the bench of chain [chainID].
+	Unbench(chainID ids.ID, nodeID ids.NodeID) error
+	// SetEnabled sets whether nodes may be benched on chain [chainID].
+	SetEnabled(chainID ids.ID, enabled bool) error
 }
 
 // Config defines the configuration for a benchlist
@@ -100,6 +113,48 @@ func (m *manager) GetBenched(nodeID ids.NodeID) []ids.ID {
 	return benched
 }
 
+func (m *manager) Status() map[ids.ID]Status {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	status := make(map[ids.ID]Status, len(m.chainBenchlists))
+	for chainID, benchlist := range m.chainBenchlists {
+		status[chainID] = benchlist.Status()
+	}
+	return status
+}
+
+func (m *manager) Unbench(chainID ids.ID, nodeID ids.NodeID) error {
+	benchlist, err := m.getBenchlist(chainID)
+	if err != nil {
+		return err
+	}
+	if !benchlist.Unbench(nodeID) {
+		return fmt.Errorf("%w: %s on chain %s", errNotBenched, nodeID, chainID)
+	}
+	return nil
+}
+
+func (m *manager) SetEnabled(chainID ids.ID, enabled bool) error {
+	benchlist, err := m.getBenchlist(chainID)
+	if err != nil {
+		return err
+	}
+	benchlist.SetEnabled(enabled)
+	return nil
+}
+
+func (m *manager) getBenchlist(chainID ids.ID) (Benchlist, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	benchlist, exists := m.chainBenchlists[chainID]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", errUnknownChain, chainID)
+	}
+	return benchlist, nil
+}
+
 func (m *manager) RegisterChain(ctx *snow.ConsensusContext) error {
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -169,3 +224,15 @@ func (noBenchlist) IsBenched(ids.NodeID, ids.ID) bool {
 func (noBenchlist) GetBenched(ids.NodeID) []ids.ID {
 	return []ids.ID{}
 }
+
+func (noBenchlist) Status() map[ids.ID]Status {
+	return map[ids.ID]Status{}
+}
+
+func (noBenchlist) Unbench(chainID ids.ID, _ ids.NodeID) error {
+	return fmt.Errorf("%w: %s", errUnknownChain, chainID)
+}
+
+func (noBenchlist) SetEnabled(chainID ids.ID, _ bool) error {
+	return fmt.Errorf("%w: %s", errUnknownChain, chainID)
+}
@@ -653,6 +653,9 @@ func (h *handler) handleSyncMsg(ctx context.Context, msg Message) error {
 
 		return engine.GetAncestors(ctx, nodeID, msg.RequestId, containerID)
 
+	case *p2p.GetAncestorsAtHeight:
+		return engine.GetAncestorsAtHeight(ctx, nodeID, msg.RequestId, msg.Height)
+
 	case *message.GetAncestorsFailed:
 		return engine.GetAncestorsFailed(ctx, nodeID, msg.RequestID)
 
@@ -719,6 +719,89 @@ func (s *sender) SendGetAncestors(ctx context.Context, nodeID ids.NodeID, reques
 	}
 }
 
+func (s *sender) SendGetAncestorsAtHeight(ctx context.Context, nodeID ids.NodeID, requestID uint32, height uint64) {
+	ctx = utils.Detach(ctx)
+
+	// Tell the router to expect a response message or a message notifying
+	// that we won't get a response from this node.
+	inMsg := message.InternalGetAncestorsFailed(
+		nodeID,
+		s.ctx.ChainID,
+		requestID,
+		s.engineType,
+	)
+	s.router.RegisterRequest(
+		ctx,
+		nodeID,
+		s.ctx.ChainID,
+		s.ctx.ChainID,
+		requestID,
+		message.AncestorsOp,
+		inMsg,
+		s.engineType,
+	)
+
+	// Sending a GetAncestorsAtHeight to myself always fails.
+	if nodeID == s.ctx.NodeID {
+		go s.router.HandleInbound(ctx, inMsg)
+		return
+	}
+
+	// [nodeID] may be benched. That is, they've been unresponsive so we don't
+	// even bother sending requests to them. We just have them immediately fail.
+	if s.timeouts.IsBenched(nodeID, s.ctx.ChainID) {
+		s.failedDueToBench[message.GetAncestorsAtHeightOp].Inc() // update metric
+		s.timeouts.RegisterRequestToUnreachableValidator()
+		go s.router.HandleInbound(ctx, inMsg)
+		return
+	}
+
+	// Note that this timeout duration won't exactly match the one that gets
+	// registered. That's OK.
+	deadline := s.timeouts.TimeoutDuration()
+	// Create the outbound message.
+	outMsg, err := s.msgCreator.GetAncestorsAtHeight(
+		s.ctx.ChainID,
+		requestID,
+		deadline,
+		height,
+		s.engineType,
+	)
+	if err != nil {
+		s.ctx.Log.Error("failed to build message",
+			zap.Stringer("messageOp", message.GetAncestorsAtHeightOp),
+			zap.Stringer("chainID", s.ctx.ChainID),
+			zap.Uint32("requestID", requestID),
+			zap.Uint64("height", height),
+			zap.Error(err),
+		)
+
+		go s.router.HandleInbound(ctx, inMsg)
+		return
+	}
+
+	// Send the message over the network.
+	nodeIDs := set.Of(nodeID)
+	sentTo := s.sender.Send(
+		outMsg,
+		nodeIDs,
+		s.ctx.SubnetID,
+		s.subnet,
+	)
+	if sentTo.Len() == 0 {
+		s.ctx.Log.Debug("failed to send message",
+			zap.Stringer("messageOp", message.GetAncestorsAtHeightOp),
+			zap.Stringer("nodeID", nodeID),
+			zap.Stringer("chainID", s.ctx.ChainID),
+			zap.Uint32("requestID", requestID),
+			zap.Uint64("height", height),
+		)
+
+		s.timeouts.RegisterRequestToUnreachableValidator()
+		go s.router.HandleInbound(ctx, inMsg)
+	}
+}
+
 func (s *sender) SendAncestors(_ context.Context, nodeID ids.NodeID, requestID uint32, containers [][]byte) {
 	// Create the outbound message.
 	outMsg, err := s.msgCreator.Ancestors(s.ctx.ChainID, requestID, containers)
@@ -0,0 +1,211 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package uptime
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/luxdefi/node/database"
+	"github.com/luxdefi/node/database/prefixdb"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/hashing"
+)
+
+// EpochDuration is the length of the periods that uptime history is bucketed
+// into. Epochs are aligned to UTC days.
+const EpochDuration = 24 * time.Hour
+
+const (
+	historyPrefixLen = ids.IDLen + ids.NodeIDLen
+	historyKeyLen    = historyPrefixLen + database.Uint64Size
+	historyValueLen  = 2 * database.Uint64Size
+)
+
+var (
+	_ History = (*history)(nil)
+
+	historyDBPrefix  = []byte("uptimeHistory")
+	historyKeyPrefix = hashing.ComputeHash256(historyDBPrefix)
+
+	errInvalidHistoryKey   = errors.New("invalid uptime history key")
+	errInvalidHistoryValue = errors.New("invalid uptime history value")
+)
+
+// Epoch is the uptime of a validator during one period of its history, as
+// observed by this node.
+type Epoch struct {
+	// Start of the period, aligned to [EpochDuration].
+	Start time.Time
+	// Observed is how long this node tracked the validator during the period.
+	Observed time.Duration
+	// Connected is how much of [Observed] the validator was connected for.
+	Connected time.Duration
+}
+
+// History persists the intervals during which validators were connected to
+// and disconnected from this node.
+type History interface {
+	// Record adds the interval [start, end) to the history of [nodeID] on
+	// [subnetID]. The interval is split across the epochs that it overlaps.
+	//
+	// Invariant: expects [start] and [end] to be truncated (floored) to the
+	//            nearest second.
+	Record(
+		nodeID ids.NodeID,
+		subnetID ids.ID,
+		start time.Time,
+		end time.Time,
+		connected bool,
+	) error
+
+	// GetHistory returns, in order, the recorded epochs of [nodeID] on
+	// [subnetID] that overlap [start, end). Epochs without any observations
+	// are omitted.
+	GetHistory(
+		nodeID ids.NodeID,
+		subnetID ids.ID,
+		start time.Time,
+		end time.Time,
+	) ([]Epoch, error)
+}
+
+type history struct {
+	db database.Database
+}
+
+// NewHistory returns a history that writes its keys into [db] under a single
+// prefix. IsHistoryKey reports whether a key of [db] belongs to the history.
+func NewHistory(db database.Database) History {
+	return &history{
+		// NewNested is used so that every key of the history is prefixed by
+		// [historyKeyPrefix], regardless of whether [db] is a prefixed
+		// database.
+		db: prefixdb.NewNested(historyDBPrefix, db),
+	}
+}
+
+// IsHistoryKey returns true if [key], in the database provided to NewHistory,
+// was written by the history.
+func IsHistoryKey(key []byte) bool {
+	return bytes.HasPrefix(key, historyKeyPrefix)
+}
+
+func (h *history) Record(
+	nodeID ids.NodeID,
+	subnetID ids.ID,
+	start time.Time,
+	end time.Time,
+	connected bool,
+) error {
+	batch := h.db.NewBatch()
+	for _, epoch := range splitIntoEpochs(start, end, connected) {
+		key := historyKey(nodeID, subnetID, epoch.Start)
+		existing, err := h.getEpoch(key)
+		if err != nil {
+			return err
+		}
+		existing.Observed += epoch.Observed
+		existing.Connected += epoch.Connected
+
+		value := make([]byte, historyValueLen)
+		binary.BigEndian.PutUint64(value, uint64(existing.Observed/time.Second))
+		binary.BigEndian.PutUint64(value[database.Uint64Size:], uint64(existing.Connected/time.Second))
+		if err := batch.Put(key, value); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
+func (h *history) GetHistory(
+	nodeID ids.NodeID,
+	subnetID ids.ID,
+	start time.Time,
+	end time.Time,
+) ([]Epoch, error) {
+	prefix := historyKey(nodeID, subnetID, time.Time{})[:historyPrefixLen]
+	it := h.db.NewIteratorWithStartAndPrefix(
+		historyKey(nodeID, subnetID, start.Truncate(EpochDuration)),
+		prefix,
+	)
+	defer it.Release()
+
+	var epochs []Epoch
+	for it.Next() {
+		key := it.Key()
+		if len(key) != historyKeyLen {
+			return nil, errInvalidHistoryKey
+		}
+		epochStart := time.Unix(int64(binary.BigEndian.Uint64(key[historyPrefixLen:])), 0)
+		if !epochStart.Before(end) {
+			break
+		}
+
+		epoch, err := parseEpoch(it.Value())
+		if err != nil {
+			return nil, err
+		}
+		epoch.Start = epochStart
+		epochs = append(epochs, epoch)
+	}
+	return epochs, it.Error()
+}
+
+func (h *history) getEpoch(key []byte) (Epoch, error) {
+	value, err := h.db.Get(key)
+	if err == database.ErrNotFound {
+		return Epoch{}, nil
+	}
+	if err != nil {
+		return Epoch{}, err
+	}
+	return parseEpoch(value)
+}
+
+func parseEpoch(value []byte) (Epoch, error) {
+	if len(value) != historyValueLen {
+		return Epoch{}, errInvalidHistoryValue
+	}
+	return Epoch{
+		Observed:  time.Duration(binary.BigEndian.Uint64(value)) * time.Second,
+		Connected: time.Duration(binary.BigEndian.Uint64(value[database.Uint64Size:])) * time.Second,
+	}, nil
+}
+
+// historyKey returns subnetID + nodeID + epochStart, which sorts the epochs of
+// every validator by time.
+func historyKey(nodeID ids.NodeID, subnetID ids.ID, epochStart time.Time) []byte {
+	key := make([]byte, historyKeyLen)
+	copy(key, subnetID[:])
+	copy(key[ids.IDLen:], nodeID[:])
+	binary.BigEndian.PutUint64(key[historyPrefixLen:], uint64(epochStart.Unix()))
+	return key
+}
+
+// splitIntoEpochs returns the epochs that [start, end) overlaps along with the
+// time spent in each of them.
+func splitIntoEpochs(start, end time.Time, connected bool) []Epoch {
+	var epochs []Epoch
+	for start.Before(end) {
+		epochStart := start.Truncate(EpochDuration)
+		epochEnd := epochStart.Add(EpochDuration)
+		if end.Before(epochEnd) {
+			epochEnd = end
+		}
+
+		epoch := Epoch{
+			Start:    epochStart,
+			Observed: epochEnd.Sub(start),
+		}
+		if connected {
+			epoch.Connected = epoch.Observed
+		}
+		epochs = append(epochs, epoch)
+		start = epochEnd
+	}
+	return epochs
+}
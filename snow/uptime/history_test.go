@@ -0,0 +1,112 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package uptime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxdefi/node/database/memdb"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/timer/mockable"
+)
+
+func TestHistoryRecordSplitsEpochs(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	h := NewHistory(db)
+	nodeID := ids.GenerateTestNodeID()
+	subnetID := ids.GenerateTestID()
+
+	day0 := time.Unix(0, 0).Add(100 * EpochDuration)
+	day1 := day0.Add(EpochDuration)
+	day2 := day1.Add(EpochDuration)
+
+	// Connected from 12:00 on day 0 until 06:00 on day 1.
+	require.NoError(h.Record(nodeID, subnetID, day0.Add(12*time.Hour), day1.Add(6*time.Hour), true))
+	// Disconnected from 06:00 until 08:00 on day 1.
+	require.NoError(h.Record(nodeID, subnetID, day1.Add(6*time.Hour), day1.Add(8*time.Hour), false))
+	// Recorded for another validator, which must not be returned.
+	require.NoError(h.Record(ids.GenerateTestNodeID(), subnetID, day0, day2, true))
+
+	epochs, err := h.GetHistory(nodeID, subnetID, day0, day2)
+	require.NoError(err)
+	require.Len(epochs, 2)
+	require.Equal(day0.Unix(), epochs[0].Start.Unix())
+	require.Equal(12*time.Hour, epochs[0].Observed)
+	require.Equal(12*time.Hour, epochs[0].Connected)
+	require.Equal(day1.Unix(), epochs[1].Start.Unix())
+	require.Equal(8*time.Hour, epochs[1].Observed)
+	require.Equal(6*time.Hour, epochs[1].Connected)
+
+	// The range is expanded to the start of the epoch containing the start
+	// time and excludes epochs starting at or after the end time.
+	epochs, err = h.GetHistory(nodeID, subnetID, day1.Add(time.Hour), day2)
+	require.NoError(err)
+	require.Len(epochs, 1)
+	require.Equal(day1.Unix(), epochs[0].Start.Unix())
+
+	epochs, err = h.GetHistory(nodeID, subnetID, day0, day1)
+	require.NoError(err)
+	require.Len(epochs, 1)
+	require.Equal(day0.Unix(), epochs[0].Start.Unix())
+
+	// Every key is written under the history prefix.
+	it := db.NewIterator()
+	defer it.Release()
+	for it.Next() {
+		require.True(IsHistoryKey(it.Key()))
+	}
+	require.NoError(it.Error())
+}
+
+func TestManagerUptimeHistory(t *testing.T) {
+	require := require.New(t)
+
+	nodeID := ids.GenerateTestNodeID()
+	subnetID := ids.GenerateTestID()
+	startTime := time.Unix(0, 0).Add(100 * EpochDuration)
+
+	s := NewTestState()
+	s.AddNode(nodeID, subnetID, startTime)
+
+	clk := mockable.Clock{}
+	clk.Set(startTime)
+	up := NewManagerWithHistory(s, NewHistory(memdb.New()), &clk)
+	require.NoError(up.StartTracking([]ids.NodeID{nodeID}, subnetID))
+
+	// Disconnected for the first hour, then connected for two hours.
+	clk.Set(startTime.Add(time.Hour))
+	require.NoError(up.Connect(nodeID, subnetID))
+	clk.Set(startTime.Add(3 * time.Hour))
+	require.NoError(up.Disconnect(nodeID))
+
+	// Disconnected until the next day, where it has been connected for the
+	// last hour without it having been written to the history.
+	clk.Set(startTime.Add(EpochDuration + time.Hour))
+	require.NoError(up.Connect(nodeID, subnetID))
+	clk.Set(startTime.Add(EpochDuration + 2*time.Hour))
+
+	epochs, err := up.CalculateUptimeHistory(nodeID, subnetID, startTime, startTime.Add(2*EpochDuration))
+	require.NoError(err)
+	require.Len(epochs, 2)
+	require.Equal(EpochDuration, epochs[0].Observed)
+	require.Equal(2*time.Hour, epochs[0].Connected)
+	require.Equal(2*time.Hour, epochs[1].Observed)
+	require.Equal(time.Hour, epochs[1].Connected)
+
+	// Non-validators don't have any history.
+	epochs, err = up.CalculateUptimeHistory(ids.GenerateTestNodeID(), subnetID, startTime, startTime.Add(2*EpochDuration))
+	require.NoError(err)
+	require.Empty(epochs)
+}
+
+func TestManagerWithoutHistory(t *testing.T) {
+	up := NewManager(NewTestState(), &mockable.Clock{})
+	_, err := up.CalculateUptimeHistory(ids.GenerateTestNodeID(), ids.GenerateTestID(), time.Time{}, time.Now())
+	require.ErrorIs(t, err, errNoHistory)
+}
@@ -20,6 +20,7 @@ var (
 
 type LockedCalculator interface {
 	Calculator
+	HistoryCalculator
 
 	SetCalculator(isBootstrapped *utils.Atomic[bool], lock sync.Locker, newC Calculator)
 }
@@ -77,6 +78,25 @@ func (c *lockedCalculator) CalculateUptimePercentFrom(nodeID ids.NodeID, subnetI
 	return c.c.CalculateUptimePercentFrom(nodeID, subnetID, startTime)
 }
 
+func (c *lockedCalculator) CalculateUptimeHistory(nodeID ids.NodeID, subnetID ids.ID, startTime, endTime time.Time) ([]Epoch, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.isBootstrapped == nil || !c.isBootstrapped.Get() {
+		return nil, errStillBootstrapping
+	}
+
+	historyCalculator, ok := c.c.(HistoryCalculator)
+	if !ok {
+		return nil, errNoHistory
+	}
+
+	c.calculatorLock.Lock()
+	defer c.calculatorLock.Unlock()
+
+	return historyCalculator.CalculateUptimeHistory(nodeID, subnetID, startTime, endTime)
+}
+
 func (c *lockedCalculator) SetCalculator(isBootstrapped *utils.Atomic[bool], lock sync.Locker, newC Calculator) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
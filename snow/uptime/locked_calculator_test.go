@@ -35,6 +35,9 @@ func TestLockedCalculator(t *testing.T) {
 	_, err = lc.CalculateUptimePercentFrom(nodeID, subnetID, time.Now())
 	require.ErrorIs(err, errStillBootstrapping)
 
+	_, err = lc.CalculateUptimeHistory(nodeID, subnetID, time.Time{}, time.Now())
+	require.ErrorIs(err, errStillBootstrapping)
+
 	var isBootstrapped utils.Atomic[bool]
 	mockCalc := NewMockCalculator(ctrl)
 
@@ -49,6 +52,9 @@ func TestLockedCalculator(t *testing.T) {
 	_, err = lc.CalculateUptimePercentFrom(nodeID, subnetID, time.Now())
 	require.ErrorIs(err, errStillBootstrapping)
 
+	_, err = lc.CalculateUptimeHistory(nodeID, subnetID, time.Time{}, time.Now())
+	require.ErrorIs(err, errStillBootstrapping)
+
 	isBootstrapped.Set(true)
 
 	// Should return the value from the mocked inner calculator
@@ -63,4 +69,8 @@ func TestLockedCalculator(t *testing.T) {
 	mockCalc.EXPECT().CalculateUptimePercentFrom(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(float64(0), errTest)
 	_, err = lc.CalculateUptimePercentFrom(nodeID, subnetID, time.Now())
 	require.ErrorIs(err, errTest)
+
+	// The mocked inner calculator doesn't record any history
+	_, err = lc.CalculateUptimeHistory(nodeID, subnetID, time.Time{}, time.Now())
+	require.ErrorIs(err, errNoHistory)
 }
@@ -4,6 +4,7 @@
 package uptime
 
 import (
+	"errors"
 	"time"
 
 	"github.com/luxdefi/node/database"
@@ -12,11 +13,16 @@ import (
 	"github.com/luxdefi/node/utils/timer/mockable"
 )
 
-var _ Manager = (*manager)(nil)
+var (
+	errNoHistory = errors.New("uptime history isn't being recorded")
+
+	_ Manager = (*manager)(nil)
+)
 
 type Manager interface {
 	Tracker
 	Calculator
+	HistoryCalculator
 }
 
 type Tracker interface {
@@ -35,6 +41,13 @@ type Calculator interface {
 	CalculateUptimePercentFrom(nodeID ids.NodeID, subnetID ids.ID, startTime time.Time) (float64, error)
 }
 
+type HistoryCalculator interface {
+	// CalculateUptimeHistory returns the epochs of [nodeID]'s history on
+	// [subnetID] that overlap [startTime, endTime), including the time that
+	// has passed since its history was last recorded.
+	CalculateUptimeHistory(nodeID ids.NodeID, subnetID ids.ID, startTime, endTime time.Time) ([]Epoch, error)
+}
+
 type manager struct {
 	// Used to get time. Useful for faking time during tests.
 	clock *mockable.Clock
@@ -42,13 +55,29 @@ type manager struct {
 	state          State
 	connections    map[ids.NodeID]map[ids.ID]time.Time // nodeID -> subnetID -> time
 	trackedSubnets set.Set[ids.ID]
+
+	// history is nil if the uptime history isn't recorded.
+	history History
+	// subnetID -> time that the subnet started being tracked
+	trackingStarts map[ids.ID]time.Time
+	// nodeID -> subnetID -> time until which the history has been recorded
+	historyMarks map[ids.NodeID]map[ids.ID]time.Time
 }
 
 func NewManager(state State, clk *mockable.Clock) Manager {
+	return NewManagerWithHistory(state, nil, clk)
+}
+
+// NewManagerWithHistory returns a manager that additionally records the
+// intervals during which validators are connected into [history].
+func NewManagerWithHistory(state State, history History, clk *mockable.Clock) Manager {
 	return &manager{
-		clock:       clk,
-		state:       state,
-		connections: make(map[ids.NodeID]map[ids.ID]time.Time),
+		clock:          clk,
+		state:          state,
+		connections:    make(map[ids.NodeID]map[ids.ID]time.Time),
+		history:        history,
+		trackingStarts: make(map[ids.ID]time.Time),
+		historyMarks:   make(map[ids.NodeID]map[ids.ID]time.Time),
 	}
 }
 
@@ -73,12 +102,17 @@ func (m *manager) StartTracking(nodeIDs []ids.NodeID, subnetID ids.ID) error {
 		}
 	}
 	m.trackedSubnets.Add(subnetID)
+	m.trackingStarts[subnetID] = now
 	return nil
 }
 
 func (m *manager) StopTracking(nodeIDs []ids.NodeID, subnetID ids.ID) error {
 	now := m.clock.UnixTime()
 	for _, nodeID := range nodeIDs {
+		if err := m.recordHistory(nodeID, subnetID); err != nil {
+			return err
+		}
+
 		connectedSubnets := m.connections[nodeID]
 		// If the node is already connected to this subnet, then we can just
 		// update the uptime in the state and remove the connection
@@ -113,6 +147,10 @@ func (m *manager) StopTracking(nodeIDs []ids.NodeID, subnetID ids.ID) error {
 }
 
 func (m *manager) Connect(nodeID ids.NodeID, subnetID ids.ID) error {
+	if err := m.recordHistory(nodeID, subnetID); err != nil {
+		return err
+	}
+
 	subnetConnections, ok := m.connections[nodeID]
 	if !ok {
 		subnetConnections = make(map[ids.ID]time.Time)
@@ -130,6 +168,9 @@ func (m *manager) IsConnected(nodeID ids.NodeID, subnetID ids.ID) bool {
 func (m *manager) Disconnect(nodeID ids.NodeID) error {
 	// Update every subnet that this node was connected to
 	for subnetID := range m.connections[nodeID] {
+		if err := m.recordHistory(nodeID, subnetID); err != nil {
+			return err
+		}
 		if err := m.updateSubnetUptime(nodeID, subnetID); err != nil {
 			return err
 		}
@@ -202,6 +243,107 @@ func (m *manager) CalculateUptimePercentFrom(nodeID ids.NodeID, subnetID ids.ID,
 	return uptime, nil
 }
 
+func (m *manager) CalculateUptimeHistory(nodeID ids.NodeID, subnetID ids.ID, startTime, endTime time.Time) ([]Epoch, error) {
+	if m.history == nil {
+		return nil, errNoHistory
+	}
+
+	epochs, err := m.history.GetHistory(nodeID, subnetID, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	mark, ok, err := m.historyMark(nodeID, subnetID)
+	if err != nil || !ok {
+		return epochs, err
+	}
+
+	// Include the time that hasn't been written to the history yet. Because
+	// the history is only ever written up until [mark], these epochs are
+	// either the last recorded epoch or after it.
+	connected := m.IsConnected(nodeID, subnetID)
+	unrecorded := splitIntoEpochs(mark, m.clock.UnixTime(), connected)
+	firstEpochStart := startTime.Truncate(EpochDuration)
+	for _, epoch := range unrecorded {
+		if epoch.Start.Before(firstEpochStart) || !epoch.Start.Before(endTime) {
+			continue
+		}
+		if len(epochs) > 0 && epochs[len(epochs)-1].Start.Equal(epoch.Start) {
+			lastEpoch := &epochs[len(epochs)-1]
+			lastEpoch.Observed += epoch.Observed
+			lastEpoch.Connected += epoch.Connected
+			continue
+		}
+		epochs = append(epochs, epoch)
+	}
+	return epochs, nil
+}
+
+// recordHistory writes the history of [nodeID] on [subnetID] from the last time
+// it was recorded until now, using the current connection status.
+func (m *manager) recordHistory(nodeID ids.NodeID, subnetID ids.ID) error {
+	mark, ok, err := m.historyMark(nodeID, subnetID)
+	if err != nil || !ok {
+		return err
+	}
+
+	now := m.clock.UnixTime()
+	// If we are in a weird reality where time has gone backwards, make sure
+	// that we don't double count any time.
+	if !mark.Before(now) {
+		return nil
+	}
+
+	connected := m.IsConnected(nodeID, subnetID)
+	if err := m.history.Record(nodeID, subnetID, mark, now, connected); err != nil {
+		return err
+	}
+
+	subnetMarks, ok := m.historyMarks[nodeID]
+	if !ok {
+		subnetMarks = make(map[ids.ID]time.Time)
+		m.historyMarks[nodeID] = subnetMarks
+	}
+	subnetMarks[subnetID] = now
+	return nil
+}
+
+// historyMark returns the time from which the history of [nodeID] on
+// [subnetID] hasn't been recorded yet. False is returned if the history of
+// [nodeID] on [subnetID] isn't being recorded.
+func (m *manager) historyMark(nodeID ids.NodeID, subnetID ids.ID) (time.Time, bool, error) {
+	if m.history == nil {
+		return time.Time{}, false, nil
+	}
+	trackingStart, ok := m.trackingStarts[subnetID]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+
+	startTime, err := m.state.GetStartTime(nodeID, subnetID)
+	if err == database.ErrNotFound {
+		// Only the history of current validators is recorded
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	// The mark is the latest of: when this node started observing the subnet,
+	// when the node started validating, and when the history was last
+	// recorded. Taking the start time into account ensures that a node that
+	// stopped validating and later resumed isn't reported as disconnected in
+	// between.
+	mark := trackingStart
+	if startTime.After(mark) {
+		mark = startTime
+	}
+	if lastRecorded, ok := m.historyMarks[nodeID][subnetID]; ok && lastRecorded.After(mark) {
+		mark = lastRecorded
+	}
+	return mark, true, nil
+}
+
 // updateSubnetUptime updates the subnet uptime of the node on the state by the amount
 // of time that the node has been connected to the subnet.
 func (m *manager) updateSubnetUptime(nodeID ids.NodeID, subnetID ids.ID) error {
@@ -0,0 +1,121 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sampling
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/luxdefi/node/ids"
+)
+
+const (
+	// StakeStrategy samples validators by stake. This is the default.
+	StakeStrategy = "stake"
+	// LatencyStrategy samples validators by stake, reduced for validators
+	// that respond slowly or not at all.
+	LatencyStrategy = "latency"
+	// BenchlistStrategy samples validators by stake, excluding validators that
+	// are benched.
+	BenchlistStrategy = "benchlist"
+	// StakeCappedStrategy samples validators by stake, capping the combined
+	// weight of every entity.
+	StakeCappedStrategy = "stake-capped"
+
+	defaultTargetLatency   = 250 * time.Millisecond
+	defaultMinWeightFactor = .5
+	defaultMaxEntityWeight = .2
+)
+
+var (
+	ErrUnknownStrategy        = errors.New("unknown sampling strategy")
+	errInvalidTargetLatency   = errors.New("targetLatency must not be negative")
+	errInvalidMinWeightFactor = errors.New("minWeightFactor must be in [0, 1]")
+	errInvalidMaxEntityWeight = errors.New("maxEntityWeight must be in [0, 1]")
+	errDuplicateEntityNode    = errors.New("node belongs to multiple entities")
+)
+
+// Config selects the strategy that is used to sample the validators that
+// consensus queries are sent to.
+type Config struct {
+	// Strategy is one of "stake", "latency", "benchlist", or "stake-capped". If
+	// empty, "stake" is used.
+	Strategy string `json:"strategy" yaml:"strategy"`
+
+	// TargetLatency is the response time at or below which a validator is
+	// sampled with its full stake. Only used by the latency strategy. If 0,
+	// defaults to 250ms.
+	TargetLatency time.Duration `json:"targetLatency" yaml:"targetLatency"`
+	// MinWeightFactor is the fraction of its stake that an unresponsive
+	// validator is still sampled with. Only used by the latency strategy. If
+	// 0, defaults to 0.5.
+	MinWeightFactor float64 `json:"minWeightFactor" yaml:"minWeightFactor"`
+
+	// MaxEntityWeight is the maximum fraction of the sampling weight that any
+	// entity is given. Only used by the stake-capped strategy. If 0, defaults
+	// to 0.2.
+	MaxEntityWeight float64 `json:"maxEntityWeight" yaml:"maxEntityWeight"`
+	// Entities groups the validators that are controlled by a single entity.
+	// Validators that aren't part of any group are their own entity. Only used
+	// by the stake-capped strategy.
+	Entities map[string][]ids.NodeID `json:"entities" yaml:"entities"`
+}
+
+func (c *Config) Verify() error {
+	switch c.Strategy {
+	case "", StakeStrategy, BenchlistStrategy:
+		return nil
+	case LatencyStrategy:
+		switch {
+		case c.TargetLatency < 0:
+			return errInvalidTargetLatency
+		case c.MinWeightFactor < 0 || c.MinWeightFactor > 1:
+			return errInvalidMinWeightFactor
+		default:
+			return nil
+		}
+	case StakeCappedStrategy:
+		if c.MaxEntityWeight < 0 || c.MaxEntityWeight > 1 {
+			return errInvalidMaxEntityWeight
+		}
+		_, err := c.entityOf()
+		return err
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownStrategy, c.Strategy)
+	}
+}
+
+// entityOf returns the entity of every node in [Entities].
+func (c *Config) entityOf() (map[ids.NodeID]string, error) {
+	entityOf := make(map[ids.NodeID]string)
+	for entity, nodeIDs := range c.Entities {
+		for _, nodeID := range nodeIDs {
+			if other, ok := entityOf[nodeID]; ok {
+				return nil, fmt.Errorf("%w: %s is in %q and %q", errDuplicateEntityNode, nodeID, other, entity)
+			}
+			entityOf[nodeID] = entity
+		}
+	}
+	return entityOf, nil
+}
+
+// withDefaults returns a copy of the config with the unset parameters replaced
+// by their defaults.
+func (c *Config) withDefaults() Config {
+	config := *c
+	if config.Strategy == "" {
+		config.Strategy = StakeStrategy
+	}
+	if config.TargetLatency == 0 {
+		config.TargetLatency = defaultTargetLatency
+	}
+	if config.MinWeightFactor == 0 {
+		config.MinWeightFactor = defaultMinWeightFactor
+	}
+	if config.MaxEntityWeight == 0 {
+		config.MaxEntityWeight = defaultMaxEntityWeight
+	}
+	return config
+}
@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sampling
+
+import (
+	"time"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/set"
+	"github.com/luxdefi/node/utils/timer/mockable"
+)
+
+// responsivenessSmoothing is the weight given to the most recent observation
+// of a validator's responsiveness.
+const responsivenessSmoothing = .2
+
+type outstandingQuery struct {
+	sent    time.Time
+	nodeIDs set.Set[ids.NodeID]
+}
+
+// latencyTracker scales the stake of every validator by its responsiveness,
+// which is between [minWeightFactor] and 1. A validator that responds within
+// [targetLatency] has a responsiveness of 1, while a validator that doesn't
+// respond at all has a responsiveness of [minWeightFactor].
+type latencyTracker struct {
+	clock           *mockable.Clock
+	targetLatency   time.Duration
+	minWeightFactor float64
+
+	// requestID -> query that hasn't been answered by every validator
+	queries map[uint32]*outstandingQuery
+	// nodeID -> smoothed responsiveness. Validators that were never queried
+	// are assumed to be responsive.
+	responsiveness map[ids.NodeID]float64
+}
+
+func newLatencyTracker(clock *mockable.Clock, targetLatency time.Duration, minWeightFactor float64) *latencyTracker {
+	return &latencyTracker{
+		clock:           clock,
+		targetLatency:   targetLatency,
+		minWeightFactor: minWeightFactor,
+		queries:         make(map[uint32]*outstandingQuery),
+		responsiveness:  make(map[ids.NodeID]float64),
+	}
+}
+
+func (l *latencyTracker) weights(nodeIDs []ids.NodeID, stakes []uint64) []uint64 {
+	weights := make([]uint64, len(stakes))
+	for i, nodeID := range nodeIDs {
+		weights[i] = uint64(float64(stakes[i]) * l.getResponsiveness(nodeID))
+	}
+	return weights
+}
+
+func (l *latencyTracker) getResponsiveness(nodeID ids.NodeID) float64 {
+	if responsiveness, ok := l.responsiveness[nodeID]; ok {
+		return responsiveness
+	}
+	return 1
+}
+
+func (l *latencyTracker) registerQuery(nodeIDs set.Set[ids.NodeID], requestID uint32) {
+	l.queries[requestID] = &outstandingQuery{
+		sent:    l.clock.Time(),
+		nodeIDs: set.Of(nodeIDs.List()...),
+	}
+}
+
+func (l *latencyTracker) registerResponse(nodeID ids.NodeID, requestID uint32) {
+	query, ok := l.removeOutstanding(nodeID, requestID)
+	if !ok {
+		return
+	}
+
+	responsiveness := 1.
+	if latency := l.clock.Time().Sub(query.sent); latency > l.targetLatency {
+		responsiveness = float64(l.targetLatency) / float64(latency)
+	}
+	l.observe(nodeID, responsiveness)
+}
+
+func (l *latencyTracker) registerFailure(nodeID ids.NodeID, requestID uint32) {
+	if _, ok := l.removeOutstanding(nodeID, requestID); ok {
+		l.observe(nodeID, l.minWeightFactor)
+	}
+}
+
+// removeOutstanding marks that [nodeID] is no longer expected to respond to
+// [requestID]. Returns false if [nodeID] wasn't expected to respond.
+func (l *latencyTracker) removeOutstanding(nodeID ids.NodeID, requestID uint32) (*outstandingQuery, bool) {
+	query, ok := l.queries[requestID]
+	if !ok || !query.nodeIDs.Contains(nodeID) {
+		return nil, false
+	}
+
+	query.nodeIDs.Remove(nodeID)
+	if query.nodeIDs.Len() == 0 {
+		delete(l.queries, requestID)
+	}
+	return query, true
+}
+
+func (l *latencyTracker) observe(nodeID ids.NodeID, responsiveness float64) {
+	if responsiveness < l.minWeightFactor {
+		responsiveness = l.minWeightFactor
+	}
+	previous := l.getResponsiveness(nodeID)
+	l.responsiveness[nodeID] = (1-responsivenessSmoothing)*previous + responsivenessSmoothing*responsiveness
+}
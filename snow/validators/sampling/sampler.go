@@ -0,0 +1,189 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sampling
+
+import (
+	"math/rand"
+
+	"golang.org/x/exp/maps"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/snow/validators"
+	"github.com/luxdefi/node/utils"
+	"github.com/luxdefi/node/utils/sampler"
+	"github.com/luxdefi/node/utils/set"
+	"github.com/luxdefi/node/utils/timer/mockable"
+)
+
+var _ Sampler = (*weightedSampler)(nil)
+
+// IsBenchedFunc returns true if queries sent to [nodeID] currently fail
+// without being sent because [nodeID] is benched.
+type IsBenchedFunc func(nodeID ids.NodeID) bool
+
+// Sampler selects the validators that consensus queries are sent to.
+//
+// Sampler is not thread safe.
+type Sampler interface {
+	// Sample returns [size] validators. As with [validators.Manager.Sample],
+	// the sampling weight is sampled without replacement, so a validator may
+	// be returned more than once.
+	Sample(size int) ([]ids.NodeID, error)
+
+	// RegisterQuery notes that a query with [requestID] was sent to
+	// [nodeIDs].
+	RegisterQuery(nodeIDs set.Set[ids.NodeID], requestID uint32)
+	// RegisterResponse notes that [nodeID] responded to the query with
+	// [requestID].
+	RegisterResponse(nodeID ids.NodeID, requestID uint32)
+	// RegisterFailure notes that the query with [requestID] sent to [nodeID]
+	// failed.
+	RegisterFailure(nodeID ids.NodeID, requestID uint32)
+
+	Seed(int64)
+	ClearSeed()
+}
+
+// weighFunc returns the sampling weight of every validator given their stake.
+type weighFunc func(nodeIDs []ids.NodeID, stakes []uint64) []uint64
+
+type weightedSampler struct {
+	subnetID ids.ID
+	vdrs     validators.Manager
+	weigh    weighFunc
+	// latency is only set when the latency strategy is used.
+	latency *latencyTracker
+	sampler sampler.WeightedWithoutReplacement
+	// seeds is only set when the sampler is seeded. Initializing [sampler]
+	// clears its seed, so every sample is seeded from [seeds].
+	seeds *rand.Rand
+}
+
+// New returns a sampler of the validators of [subnetID] that uses the strategy
+// described by [config]. [isBenched] is only used by the benchlist strategy
+// and [clock] is only used by the latency strategy.
+func New(
+	config Config,
+	subnetID ids.ID,
+	vdrs validators.Manager,
+	isBenched IsBenchedFunc,
+	clock *mockable.Clock,
+) (Sampler, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+	config = config.withDefaults()
+
+	s := &weightedSampler{
+		subnetID: subnetID,
+		vdrs:     vdrs,
+		// The deterministic sampler is used so that seeded samples don't
+		// depend on which weighted sampler benchmarks as the fastest.
+		sampler: sampler.NewDeterministicWeightedWithoutReplacement(),
+	}
+	switch config.Strategy {
+	case StakeStrategy:
+		s.weigh = stakeWeights
+	case LatencyStrategy:
+		s.latency = newLatencyTracker(clock, config.TargetLatency, config.MinWeightFactor)
+		s.weigh = s.latency.weights
+	case BenchlistStrategy:
+		s.weigh = benchlistWeights(isBenched)
+	case StakeCappedStrategy:
+		entityOf, err := config.entityOf()
+		if err != nil {
+			return nil, err
+		}
+		s.weigh = stakeCappedWeights(entityOf, config.MaxEntityWeight)
+	}
+	return s, nil
+}
+
+func (s *weightedSampler) Sample(size int) ([]ids.NodeID, error) {
+	vdrs := s.vdrs.GetMap(s.subnetID)
+	// The validators are sorted so that seeded samplers are deterministic.
+	nodeIDs := maps.Keys(vdrs)
+	utils.Sort(nodeIDs)
+	stakes := make([]uint64, len(nodeIDs))
+	for i, nodeID := range nodeIDs {
+		stakes[i] = vdrs[nodeID].Weight
+	}
+
+	weights := s.weigh(nodeIDs, stakes)
+	// If the strategy leaves too little weight to sample from, for example
+	// because every validator is benched, fall back to sampling by stake.
+	if totalWeight(weights) < uint64(size) {
+		weights = stakes
+	}
+
+	if err := s.sampler.Initialize(weights); err != nil {
+		return nil, err
+	}
+	if s.seeds != nil {
+		s.sampler.Seed(s.seeds.Int63())
+	}
+	indices, err := s.sampler.Sample(size)
+	if err != nil {
+		return nil, err
+	}
+
+	sampled := make([]ids.NodeID, size)
+	for i, index := range indices {
+		sampled[i] = nodeIDs[index]
+	}
+	return sampled, nil
+}
+
+func (s *weightedSampler) RegisterQuery(nodeIDs set.Set[ids.NodeID], requestID uint32) {
+	if s.latency != nil {
+		s.latency.registerQuery(nodeIDs, requestID)
+	}
+}
+
+func (s *weightedSampler) RegisterResponse(nodeID ids.NodeID, requestID uint32) {
+	if s.latency != nil {
+		s.latency.registerResponse(nodeID, requestID)
+	}
+}
+
+func (s *weightedSampler) RegisterFailure(nodeID ids.NodeID, requestID uint32) {
+	if s.latency != nil {
+		s.latency.registerFailure(nodeID, requestID)
+	}
+}
+
+func (s *weightedSampler) Seed(seed int64) {
+	s.seeds = rand.New(rand.NewSource(seed)) // #nosec G404
+}
+
+func (s *weightedSampler) ClearSeed() {
+	s.seeds = nil
+}
+
+func stakeWeights(_ []ids.NodeID, stakes []uint64) []uint64 {
+	return stakes
+}
+
+// benchlistWeights returns a weighFunc that excludes benched validators.
+func benchlistWeights(isBenched IsBenchedFunc) weighFunc {
+	return func(nodeIDs []ids.NodeID, stakes []uint64) []uint64 {
+		weights := make([]uint64, len(stakes))
+		for i, nodeID := range nodeIDs {
+			if !isBenched(nodeID) {
+				weights[i] = stakes[i]
+			}
+		}
+		return weights
+	}
+}
+
+// totalWeight returns the sum of [weights]. The weights are derived from the
+// stakes of a validator set, whose total weight fits in a uint64.
+func totalWeight(weights []uint64) uint64 {
+	var total uint64
+	for _, weight := range weights {
+		total += weight
+	}
+	return total
+}
@@ -0,0 +1,279 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sampling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/snow/validators"
+	"github.com/luxdefi/node/utils/sampler"
+	"github.com/luxdefi/node/utils/set"
+	"github.com/luxdefi/node/utils/timer/mockable"
+)
+
+func newTestValidators(t *testing.T, subnetID ids.ID, weights ...uint64) (validators.Manager, []ids.NodeID) {
+	vdrs := validators.NewManager()
+	nodeIDs := make([]ids.NodeID, len(weights))
+	for i, weight := range weights {
+		nodeIDs[i] = ids.GenerateTestNodeID()
+		require.NoError(t, vdrs.AddStaker(subnetID, nodeIDs[i], nil, ids.Empty, weight))
+	}
+	return vdrs, nodeIDs
+}
+
+func TestConfigVerify(t *testing.T) {
+	nodeID := ids.GenerateTestNodeID()
+	tests := []struct {
+		name        string
+		config      Config
+		expectedErr error
+	}{
+		{
+			name:   "default",
+			config: Config{},
+		},
+		{
+			name: "latency with defaults",
+			config: Config{
+				Strategy: LatencyStrategy,
+			},
+		},
+		{
+			name: "negative target latency",
+			config: Config{
+				Strategy:      LatencyStrategy,
+				TargetLatency: -time.Second,
+			},
+			expectedErr: errInvalidTargetLatency,
+		},
+		{
+			name: "min weight factor too large",
+			config: Config{
+				Strategy:        LatencyStrategy,
+				MinWeightFactor: 1.5,
+			},
+			expectedErr: errInvalidMinWeightFactor,
+		},
+		{
+			name: "max entity weight too large",
+			config: Config{
+				Strategy:        StakeCappedStrategy,
+				MaxEntityWeight: 2,
+			},
+			expectedErr: errInvalidMaxEntityWeight,
+		},
+		{
+			name: "node in multiple entities",
+			config: Config{
+				Strategy: StakeCappedStrategy,
+				Entities: map[string][]ids.NodeID{
+					"a": {nodeID},
+					"b": {nodeID},
+				},
+			},
+			expectedErr: errDuplicateEntityNode,
+		},
+		{
+			name: "unknown strategy",
+			config: Config{
+				Strategy: "fastest",
+			},
+			expectedErr: ErrUnknownStrategy,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.Verify()
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestSampleStake(t *testing.T) {
+	require := require.New(t)
+
+	subnetID := ids.GenerateTestID()
+	vdrs, nodeIDs := newTestValidators(t, subnetID, 1, 3)
+
+	s, err := New(Config{}, subnetID, vdrs, nil, nil)
+	require.NoError(err)
+
+	// The sampling weight is sampled without replacement, so sampling the
+	// total weight returns every unit of stake.
+	sampled, err := s.Sample(4)
+	require.NoError(err)
+	counts := make(map[ids.NodeID]int)
+	for _, nodeID := range sampled {
+		counts[nodeID]++
+	}
+	require.Equal(map[ids.NodeID]int{
+		nodeIDs[0]: 1,
+		nodeIDs[1]: 3,
+	}, counts)
+
+	_, err = s.Sample(5)
+	require.ErrorIs(err, sampler.ErrOutOfRange)
+}
+
+func TestSampleDeterministic(t *testing.T) {
+	require := require.New(t)
+
+	subnetID := ids.GenerateTestID()
+	vdrs, _ := newTestValidators(t, subnetID, 10, 20, 30, 40, 50)
+
+	s, err := New(Config{}, subnetID, vdrs, nil, nil)
+	require.NoError(err)
+
+	s.Seed(0)
+	sampled1, err := s.Sample(10)
+	require.NoError(err)
+	s.Seed(0)
+	sampled2, err := s.Sample(10)
+	require.NoError(err)
+	require.Equal(sampled1, sampled2)
+}
+
+func TestSampleBenchlist(t *testing.T) {
+	require := require.New(t)
+
+	subnetID := ids.GenerateTestID()
+	vdrs, nodeIDs := newTestValidators(t, subnetID, 10, 10, 10)
+
+	benched := set.Of(nodeIDs[0])
+	s, err := New(
+		Config{Strategy: BenchlistStrategy},
+		subnetID,
+		vdrs,
+		benched.Contains,
+		nil,
+	)
+	require.NoError(err)
+
+	sampled, err := s.Sample(20)
+	require.NoError(err)
+	require.NotContains(sampled, nodeIDs[0])
+
+	// When every validator is benched, the validators are sampled by stake.
+	benched.Add(nodeIDs[1:]...)
+	sampled, err = s.Sample(30)
+	require.NoError(err)
+	require.Contains(sampled, nodeIDs[0])
+}
+
+func TestSampleStakeCapped(t *testing.T) {
+	require := require.New(t)
+
+	subnetID := ids.GenerateTestID()
+	vdrs, nodeIDs := newTestValidators(t, subnetID, 60, 20, 20, 20, 100)
+
+	s, err := New(
+		Config{
+			Strategy:        StakeCappedStrategy,
+			MaxEntityWeight: .25,
+			Entities: map[string][]ids.NodeID{
+				"big": {nodeIDs[0], nodeIDs[4]},
+			},
+		},
+		subnetID,
+		vdrs,
+		nil,
+		nil,
+	)
+	require.NoError(err)
+
+	// The other entities have a combined stake of 60, so "big" is capped at
+	// 20 to make up 25% of the total weight. Its weight is split between its
+	// validators proportionally to their stake.
+	ws := s.(*weightedSampler)
+	weights := ws.weigh(nodeIDs, []uint64{60, 20, 20, 20, 100})
+	require.Equal([]uint64{7, 20, 20, 20, 12}, weights)
+}
+
+func TestEntityWeightLimit(t *testing.T) {
+	tests := []struct {
+		name            string
+		entityStakes    []float64
+		maxEntityWeight float64
+		expected        float64
+	}{
+		{
+			name:            "no entity capped",
+			entityStakes:    []float64{10, 10, 10, 10},
+			maxEntityWeight: .5,
+			expected:        20,
+		},
+		{
+			name:            "one entity capped",
+			entityStakes:    []float64{70, 10, 10, 10},
+			maxEntityWeight: .25,
+			expected:        10,
+		},
+		{
+			name:            "two entities capped",
+			entityStakes:    []float64{40, 40, 5, 5, 5, 5},
+			maxEntityWeight: .25,
+			expected:        10,
+		},
+		{
+			name:            "too few entities",
+			entityStakes:    []float64{70, 20, 10},
+			maxEntityWeight: .25,
+			expected:        10,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.InDelta(t, test.expected, entityWeightLimit(test.entityStakes, test.maxEntityWeight), 1e-9)
+		})
+	}
+}
+
+func TestSampleLatency(t *testing.T) {
+	require := require.New(t)
+
+	subnetID := ids.GenerateTestID()
+	vdrs, nodeIDs := newTestValidators(t, subnetID, 1000, 1000, 1000)
+
+	clk := &mockable.Clock{}
+	clk.Set(time.Unix(0, 0))
+	s, err := New(
+		Config{
+			Strategy:        LatencyStrategy,
+			TargetLatency:   100 * time.Millisecond,
+			MinWeightFactor: .1,
+		},
+		subnetID,
+		vdrs,
+		nil,
+		clk,
+	)
+	require.NoError(err)
+	ws := s.(*weightedSampler)
+
+	for requestID := uint32(0); requestID < 100; requestID++ {
+		s.RegisterQuery(set.Of(nodeIDs...), requestID)
+		clk.Set(clk.Time().Add(50 * time.Millisecond))
+		s.RegisterResponse(nodeIDs[0], requestID)
+		clk.Set(clk.Time().Add(150 * time.Millisecond))
+		s.RegisterResponse(nodeIDs[1], requestID)
+		s.RegisterFailure(nodeIDs[2], requestID)
+
+		// Duplicate and unrequested responses are ignored.
+		s.RegisterResponse(nodeIDs[0], requestID)
+		s.RegisterResponse(nodeIDs[0], requestID+1000)
+	}
+	require.Empty(ws.latency.queries)
+
+	// Responses within the target latency keep the full stake, slow responses
+	// reduce it proportionally to the latency, and failures reduce it to the
+	// minimum.
+	weights := ws.weigh(nodeIDs, []uint64{1000, 1000, 1000})
+	require.Equal(uint64(1000), weights[0])
+	require.InDelta(500, weights[1], 1)
+	require.InDelta(100, weights[2], 1)
+}
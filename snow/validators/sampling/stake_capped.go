@@ -0,0 +1,91 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sampling
+
+import (
+	"golang.org/x/exp/slices"
+
+	"github.com/luxdefi/node/ids"
+)
+
+// stakeCappedWeights returns a weighFunc that reduces the stake of every
+// entity so that no entity has more than [maxEntityWeight] of the total
+// weight. The stake of a capped entity is reduced proportionally across its
+// validators. Validators that aren't in [entityOf] are their own entity.
+func stakeCappedWeights(entityOf map[ids.NodeID]string, maxEntityWeight float64) weighFunc {
+	return func(nodeIDs []ids.NodeID, stakes []uint64) []uint64 {
+		// Group the validators into entities. Validators without an entity
+		// are identified by their index.
+		var (
+			entityIndices = make(map[string]int)
+			nodeEntity    = make([]int, len(nodeIDs))
+			entityStakes  []float64
+		)
+		for i, nodeID := range nodeIDs {
+			entity, ok := entityOf[nodeID]
+			if !ok {
+				nodeEntity[i] = len(entityStakes)
+				entityStakes = append(entityStakes, float64(stakes[i]))
+				continue
+			}
+
+			index, ok := entityIndices[entity]
+			if !ok {
+				index = len(entityStakes)
+				entityIndices[entity] = index
+				entityStakes = append(entityStakes, 0)
+			}
+			nodeEntity[i] = index
+			entityStakes[index] += float64(stakes[i])
+		}
+
+		limit := entityWeightLimit(entityStakes, maxEntityWeight)
+		weights := make([]uint64, len(stakes))
+		for i, stake := range stakes {
+			entityStake := entityStakes[nodeEntity[i]]
+			if entityStake <= limit {
+				weights[i] = stake
+				continue
+			}
+			weights[i] = uint64(float64(stake) * limit / entityStake)
+		}
+		return weights
+	}
+}
+
+// entityWeightLimit returns the largest weight that an entity can be given so
+// that, after every entity's stake is reduced to at most the limit, no entity
+// has more than [maxEntityWeight] of the total weight.
+//
+// If there are too few entities for that to be possible, the limit is the
+// smallest stake, which gives every entity the same weight.
+func entityWeightLimit(entityStakes []float64, maxEntityWeight float64) float64 {
+	sorted := slices.Clone(entityStakes)
+	slices.Sort(sorted)
+	slices.Reverse(sorted)
+
+	var remainingStake float64
+	for _, stake := range sorted {
+		remainingStake += stake
+	}
+
+	// If the [numCapped] largest entities are capped, the limit must satisfy:
+	//   limit = maxEntityWeight * (numCapped * limit + remainingStake)
+	// where [remainingStake] is the stake of the uncapped entities.
+	for numCapped, stake := range sorted {
+		cappedFraction := float64(numCapped) * maxEntityWeight
+		if cappedFraction >= 1 {
+			break
+		}
+		limit := maxEntityWeight * remainingStake / (1 - cappedFraction)
+		if stake <= limit {
+			return limit
+		}
+		remainingStake -= stake
+	}
+	if len(sorted) == 0 {
+		return 0
+	}
+	return sorted[len(sorted)-1]
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/luxdefi/node/ids"
 	"github.com/luxdefi/node/snow/consensus/snowball"
+	"github.com/luxdefi/node/snow/validators/sampling"
 	"github.com/luxdefi/node/utils/set"
 )
 
@@ -39,6 +40,9 @@ type Config struct {
 	// ValidatorOnly is enabled.
 	AllowedNodes        set.Set[ids.NodeID] `json:"allowedNodes"        yaml:"allowedNodes"`
 	ConsensusParameters snowball.Parameters `json:"consensusParameters" yaml:"consensusParameters"`
+	// QuerySampling selects how the validators that consensus queries are
+	// sent to are sampled. By default, validators are sampled by stake.
+	QuerySampling sampling.Config `json:"querySampling" yaml:"querySampling"`
 
 	// ProposerMinBlockDelay is the minimum delay this node will enforce when
 	// building a snowman++ block.
@@ -69,6 +73,9 @@ func (c *Config) Valid() error {
 	if err := c.ConsensusParameters.Verify(); err != nil {
 		return fmt.Errorf("consensus %w", err)
 	}
+	if err := c.QuerySampling.Verify(); err != nil {
+		return fmt.Errorf("query sampling %w", err)
+	}
 	if !c.ValidatorOnly && c.AllowedNodes.Len() > 0 {
 		return errAllowedNodesWhenNotValidatorOnly
 	}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/luxdefi/node/ids"
 	"github.com/luxdefi/node/snow/consensus/snowball"
+	"github.com/luxdefi/node/snow/validators/sampling"
 	"github.com/luxdefi/node/utils/set"
 )
 
@@ -50,6 +51,16 @@ func TestValid(t *testing.T) {
 			},
 			expectedErr: errAllowedNodesWhenNotValidatorOnly,
 		},
+		{
+			name: "invalid query sampling",
+			s: Config{
+				ConsensusParameters: validParameters,
+				QuerySampling: sampling.Config{
+					Strategy: "fastest",
+				},
+			},
+			expectedErr: sampling.ErrUnknownStrategy,
+		},
 		{
 			name: "valid",
 			s: Config{
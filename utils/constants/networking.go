@@ -115,4 +115,7 @@ const (
 	// Delays
 	DefaultNetworkInitialReconnectDelay = time.Second
 	DefaultNetworkMaxReconnectDelay     = time.Minute
+
+	// Address book
+	DefaultNetworkAddressBookMaxAge = 14 * 24 * time.Hour
 )
@@ -104,6 +104,30 @@ var (
 		constants.MainnetID: time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
 		constants.TestnetID: time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
 	}
+
+	// TODO: update this before release
+	ContinuousStakingTimes = map[uint32]time.Time{
+		constants.MainnetID: time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.TestnetID: time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	// TODO: update this before release
+	SubnetConversionTimes = map[uint32]time.Time{
+		constants.MainnetID: time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.TestnetID: time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	// TODO: update this before release
+	PooledDelegationTimes = map[uint32]time.Time{
+		constants.MainnetID: time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.TestnetID: time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	// TODO: update this before release
+	DynamicFeesTimes = map[uint32]time.Time{
+		constants.MainnetID: time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.TestnetID: time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+	}
 )
 
 func init() {
@@ -199,6 +223,34 @@ func GetDurangoTime(networkID uint32) time.Time {
 	return DefaultUpgradeTime
 }
 
+func GetContinuousStakingTime(networkID uint32) time.Time {
+	if upgradeTime, exists := ContinuousStakingTimes[networkID]; exists {
+		return upgradeTime
+	}
+	return DefaultUpgradeTime
+}
+
+func GetSubnetConversionTime(networkID uint32) time.Time {
+	if upgradeTime, exists := SubnetConversionTimes[networkID]; exists {
+		return upgradeTime
+	}
+	return DefaultUpgradeTime
+}
+
+func GetPooledDelegationTime(networkID uint32) time.Time {
+	if upgradeTime, exists := PooledDelegationTimes[networkID]; exists {
+		return upgradeTime
+	}
+	return DefaultUpgradeTime
+}
+
+func GetDynamicFeesTime(networkID uint32) time.Time {
+	if upgradeTime, exists := DynamicFeesTimes[networkID]; exists {
+		return upgradeTime
+	}
+	return DefaultUpgradeTime
+}
+
 func GetCompatibility(networkID uint32) Compatibility {
 	return NewCompatibility(
 		CurrentApp,
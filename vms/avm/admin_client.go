@@ -0,0 +1,49 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/luxdefi/node/api"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/constants"
+	"github.com/luxdefi/node/utils/rpc"
+)
+
+var _ AdminClient = (*adminClient)(nil)
+
+// AdminClient interface for interacting with the admin API of an AVM on
+// [chain]
+type AdminClient interface {
+	// RemoveMempoolTx removes the transaction [txID] from the mempool
+	RemoveMempoolTx(ctx context.Context, txID ids.ID, options ...rpc.Option) error
+}
+
+// adminClient implementation for interacting with the admin API of an AVM on
+// [chain]
+type adminClient struct {
+	requester rpc.EndpointRequester
+}
+
+// NewAdminClient returns a client to interact with the admin API of an AVM on
+// [chain], which is only exposed if enabled in the chain config
+func NewAdminClient(uri, chain string) AdminClient {
+	path := fmt.Sprintf(
+		"%s/ext/%s/%s/admin",
+		uri,
+		constants.ChainAliasPrefix,
+		chain,
+	)
+	return &adminClient{
+		requester: rpc.NewEndpointRequester(path),
+	}
+}
+
+func (c *adminClient) RemoveMempoolTx(ctx context.Context, txID ids.ID, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.removeMempoolTx", &api.JSONTxID{
+		TxID: txID,
+	}, &api.EmptyReply{}, options...)
+}
@@ -0,0 +1,54 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/luxdefi/node/api"
+	"github.com/luxdefi/node/vms/avm/txs"
+)
+
+var (
+	errTxNotInMempool = errors.New("tx isn't in the mempool")
+
+	// ErrRemovedByAdmin is the drop reason of the txs removed from the mempool
+	// over the admin API.
+	ErrRemovedByAdmin = errors.New("removed from the mempool by the node operator")
+)
+
+// AdminService defines the API calls that are only exposed to the node
+// operator.
+type AdminService struct {
+	vm *VM
+}
+
+// RemoveMempoolTx removes a tx from the mempool and marks it as dropped. While
+// the tx is remembered as dropped, it isn't added back to the mempool, whether
+// it is gossiped to this node or issued over the API.
+func (s *AdminService) RemoveMempoolTx(_ *http.Request, args *api.JSONTxID, _ *api.EmptyReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "removeMempoolTx"),
+		zap.Stringer("txID", args.TxID),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	if s.vm.mempool == nil {
+		return errNotLinearized
+	}
+	tx := s.vm.mempool.Get(args.TxID)
+	if tx == nil {
+		return fmt.Errorf("%w: %s", errTxNotInMempool, args.TxID)
+	}
+	s.vm.mempool.Remove([]*txs.Tx{tx})
+	s.vm.mempool.MarkDropped(args.TxID, ErrRemovedByAdmin)
+	return nil
+}
@@ -504,7 +504,7 @@ func TestBlockBuilderAddLocalTx(t *testing.T) {
 
 	registerer := prometheus.NewRegistry()
 	toEngine := make(chan common.Message, 100)
-	mempool, err := mempool.New("mempool", registerer, toEngine)
+	mempool, err := mempool.New("mempool", registerer, toEngine, nil)
 	require.NoError(err)
 	// add a tx to the mempool
 	tx := transactions[0]
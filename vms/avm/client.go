@@ -41,6 +41,9 @@ type Client interface {
 	// TODO: Move this function off of the Client interface into a utility
 	// function.
 	ConfirmTx(ctx context.Context, txID ids.ID, freq time.Duration, options ...rpc.Option) (choices.Status, error)
+	// GetMempool returns the transactions waiting in the mempool and the
+	// transactions that were recently dropped
+	GetMempool(ctx context.Context, options ...rpc.Option) (*api.GetMempoolReply, error)
 	// GetTx returns the byte representation of [txID]
 	GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error)
 	// GetUTXOs returns the byte representation of the UTXOs controlled by [addrs]
@@ -305,6 +308,12 @@ func (c *client) ConfirmTx(ctx context.Context, txID ids.ID, freq time.Duration,
 	}
 }
 
+func (c *client) GetMempool(ctx context.Context, options ...rpc.Option) (*api.GetMempoolReply, error) {
+	res := &api.GetMempoolReply{}
+	err := c.requester.SendRequest(ctx, "avm.getMempool", struct{}{}, res, options...)
+	return res, err
+}
+
 func (c *client) GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error) {
 	res := &api.FormattedTx{}
 	err := c.requester.SendRequest(ctx, "avm.getTx", &api.GetTxArgs{
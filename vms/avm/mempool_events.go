@@ -0,0 +1,60 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"github.com/luxdefi/node/api"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/pubsub"
+	"github.com/luxdefi/node/vms/avm/txs"
+	"github.com/luxdefi/node/vms/avm/txs/mempool"
+	"github.com/luxdefi/node/vms/components/lux"
+)
+
+var _ mempool.Listener = (*mempoolEventPublisher)(nil)
+
+// mempoolEventPublisher publishes the changes made to the mempool to the
+// connections subscribed to one of the addresses the tx sends funds to.
+type mempoolEventPublisher struct {
+	server *pubsub.Server
+}
+
+func (p *mempoolEventPublisher) Added(tx *txs.Tx) {
+	p.publish(tx, api.MempoolEvent{
+		Event: api.MempoolTxAdded,
+		TxID:  tx.ID(),
+	})
+}
+
+func (p *mempoolEventPublisher) Removed(tx *txs.Tx) {
+	p.publish(tx, api.MempoolEvent{
+		Event: api.MempoolTxRemoved,
+		TxID:  tx.ID(),
+	})
+}
+
+// Dropped publishes the event to every subscribed connection if [tx] isn't
+// known.
+func (p *mempoolEventPublisher) Dropped(txID ids.ID, tx *txs.Tx, reason error) {
+	p.publish(tx, api.MempoolEvent{
+		Event:  api.MempoolTxDropped,
+		TxID:   txID,
+		Reason: reason.Error(),
+	})
+}
+
+func (p *mempoolEventPublisher) publish(tx *txs.Tx, event api.MempoolEvent) {
+	var addrs [][]byte
+	if tx != nil {
+		addrs = [][]byte{}
+		for _, utxo := range tx.UTXOs() {
+			addressable, ok := utxo.Out.(lux.Addressable)
+			if !ok {
+				continue
+			}
+			addrs = append(addrs, addressable.Addresses()...)
+		}
+	}
+	p.server.Publish(pubsub.NewAddressFilterer(addrs, event))
+}
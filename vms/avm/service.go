@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math"
 	"net/http"
+	"time"
 
 	stdjson "encoding/json"
 
@@ -327,6 +328,63 @@ func (s *Service) GetTxStatus(_ *http.Request, args *api.JSONTxID, reply *GetTxS
 	return nil
 }
 
+// GetMempool returns the txs waiting in the mempool and the txs that were
+// recently dropped.
+func (s *Service) GetMempool(_ *http.Request, _ *struct{}, reply *api.GetMempoolReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "avm"),
+		zap.String("method", "getMempool"),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	if s.vm.mempool == nil {
+		return errNotLinearized
+	}
+
+	var (
+		now = s.vm.clock.Time()
+		err error
+	)
+	reply.Txs = []api.MempoolTx{}
+	s.vm.mempool.Iterate(func(tx *txs.Tx, addedTime time.Time) bool {
+		var burned uint64
+		burned, err = txs.Burned(tx.Unsigned, s.vm.feeAssetID)
+		if err != nil {
+			err = fmt.Errorf("failed to calculate the fee of tx %s: %w", tx.ID(), err)
+			return false
+		}
+
+		var age time.Duration
+		if now.After(addedTime) {
+			age = now.Sub(addedTime)
+		}
+		reply.Txs = append(reply.Txs, api.MempoolTx{
+			TxID:      tx.ID(),
+			Size:      json.Uint64(len(tx.Bytes())),
+			Fee:       json.Uint64(burned),
+			AddedTime: json.Uint64(addedTime.Unix()),
+			Age:       json.Uint64(age / time.Second),
+		})
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	droppedTxs := s.vm.mempool.DroppedTxs()
+	reply.Dropped = make([]api.DroppedMempoolTx, len(droppedTxs))
+	for i, droppedTx := range droppedTxs {
+		reply.Dropped[i] = api.DroppedMempoolTx{
+			TxID:        droppedTx.TxID,
+			Reason:      droppedTx.Reason.Error(),
+			DroppedTime: json.Uint64(droppedTx.Time.Unix()),
+		}
+	}
+	return nil
+}
+
 // GetTx returns the specified transaction
 func (s *Service) GetTx(_ *http.Request, args *api.GetTxArgs, reply *api.GetTxReply) error {
 	s.vm.ctx.Log.Debug("API called",
@@ -110,6 +110,59 @@ func TestServiceGetTxStatus(t *testing.T) {
 	require.Equal(choices.Accepted, statusReply.Status)
 }
 
+func TestServiceGetMempool(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{})
+	env.vm.ctx.Lock.Unlock()
+
+	defer func() {
+		env.vm.ctx.Lock.Lock()
+		require.NoError(env.vm.Shutdown(context.Background()))
+		env.vm.ctx.Lock.Unlock()
+	}()
+
+	tx := newTx(t, env.genesisBytes, env.vm, "LUX")
+	txStr, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	require.NoError(err)
+	require.NoError(env.service.IssueTx(nil, &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, &api.JSONTxID{}))
+
+	reply := api.GetMempoolReply{}
+	require.NoError(env.service.GetMempool(nil, nil, &reply))
+	require.Len(reply.Txs, 1)
+	require.Equal(tx.ID(), reply.Txs[0].TxID)
+	require.Equal(json.Uint64(len(tx.Bytes())), reply.Txs[0].Size)
+	require.Equal(json.Uint64(startBalance), reply.Txs[0].Fee)
+	require.Empty(reply.Dropped)
+
+	adminService := &AdminService{vm: env.vm}
+	require.NoError(adminService.RemoveMempoolTx(nil, &api.JSONTxID{TxID: tx.ID()}, &api.EmptyReply{}))
+	err = adminService.RemoveMempoolTx(nil, &api.JSONTxID{TxID: tx.ID()}, &api.EmptyReply{})
+	require.ErrorIs(err, errTxNotInMempool)
+
+	reply = api.GetMempoolReply{}
+	require.NoError(env.service.GetMempool(nil, nil, &reply))
+	require.Empty(reply.Txs)
+	require.Len(reply.Dropped, 1)
+	require.Equal(tx.ID(), reply.Dropped[0].TxID)
+	require.Equal(ErrRemovedByAdmin.Error(), reply.Dropped[0].Reason)
+}
+
+func TestServiceGetMempoolNotLinearized(t *testing.T) {
+	service := &Service{
+		vm: &VM{
+			ctx: &snow.Context{
+				Log: logging.NoLog{},
+			},
+		},
+	}
+	err := service.GetMempool(nil, nil, &api.GetMempoolReply{})
+	require.ErrorIs(t, err, errNotLinearized)
+}
+
 // Test the GetBalance method when argument Strict is true
 func TestServiceGetBalanceStrict(t *testing.T) {
 	require := require.New(t)
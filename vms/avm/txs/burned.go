@@ -0,0 +1,77 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/math"
+	"github.com/luxdefi/node/vms/components/lux"
+)
+
+var _ Visitor = (*flowCollector)(nil)
+
+// Burned returns the amount of [assetID] that [utx] consumes without
+// producing, which is the fee paid by [utx] when [assetID] is the fee asset.
+func Burned(utx UnsignedTx, assetID ids.ID) (uint64, error) {
+	collector := flowCollector{}
+	if err := utx.Visit(&collector); err != nil {
+		return 0, err
+	}
+
+	var (
+		consumed uint64
+		produced uint64
+		err      error
+	)
+	for _, in := range collector.ins {
+		if in.AssetID() != assetID {
+			continue
+		}
+		consumed, err = math.Add64(consumed, in.Input().Amount())
+		if err != nil {
+			return 0, err
+		}
+	}
+	for _, out := range collector.outs {
+		if out.AssetID() != assetID {
+			continue
+		}
+		produced, err = math.Add64(produced, out.Output().Amount())
+		if err != nil {
+			return 0, err
+		}
+	}
+	return math.Sub(consumed, produced)
+}
+
+// flowCollector collects the inputs consumed and the outputs produced by a tx,
+// including the imported inputs and the exported outputs.
+type flowCollector struct {
+	ins  []*lux.TransferableInput
+	outs []*lux.TransferableOutput
+}
+
+func (c *flowCollector) BaseTx(tx *BaseTx) error {
+	c.ins = append(c.ins, tx.Ins...)
+	c.outs = append(c.outs, tx.Outs...)
+	return nil
+}
+
+func (c *flowCollector) CreateAssetTx(tx *CreateAssetTx) error {
+	return c.BaseTx(&tx.BaseTx)
+}
+
+func (c *flowCollector) OperationTx(tx *OperationTx) error {
+	return c.BaseTx(&tx.BaseTx)
+}
+
+func (c *flowCollector) ImportTx(tx *ImportTx) error {
+	c.ins = append(c.ins, tx.ImportedIns...)
+	return c.BaseTx(&tx.BaseTx)
+}
+
+func (c *flowCollector) ExportTx(tx *ExportTx) error {
+	c.outs = append(c.outs, tx.ExportedOuts...)
+	return c.BaseTx(&tx.BaseTx)
+}
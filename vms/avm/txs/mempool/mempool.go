@@ -6,14 +6,15 @@ package mempool
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
-	"github.com/luxdefi/node/cache"
 	"github.com/luxdefi/node/ids"
 	"github.com/luxdefi/node/snow/engine/common"
 	"github.com/luxdefi/node/utils/linkedhashmap"
 	"github.com/luxdefi/node/utils/set"
+	"github.com/luxdefi/node/utils/timer/mockable"
 	"github.com/luxdefi/node/utils/units"
 	"github.com/luxdefi/node/vms/avm/txs"
 )
@@ -23,8 +24,8 @@ const (
 	// allowed into the mempool.
 	MaxTxSize = 64 * units.KiB
 
-	// droppedTxIDsCacheSize is the maximum number of dropped txIDs to cache
-	droppedTxIDsCacheSize = 64
+	// droppedTxsSize is the maximum number of dropped txs to remember
+	droppedTxsSize = 1024
 
 	initialConsumedUTXOsSize = 512
 
@@ -55,10 +56,37 @@ type Mempool interface {
 	// built if there is at least one transaction in the mempool.
 	RequestBuildBlock()
 
-	// Note: Dropped txs are added to droppedTxIDs but not evicted from
+	// Note: Dropped txs are added to droppedTxs but not evicted from
 	// unissued. This allows previously dropped txs to be possibly reissued.
 	MarkDropped(txID ids.ID, reason error)
 	GetDropReason(txID ids.ID) error
+
+	// Iterate calls [f] with every tx in the mempool, and the time it was
+	// added, in the order they were added. Iteration stops early if [f]
+	// returns false.
+	Iterate(f func(tx *txs.Tx, addedTime time.Time) bool)
+	// DroppedTxs returns the most recently dropped txs, from the oldest to
+	// the newest.
+	DroppedTxs() []DroppedTx
+}
+
+// Listener is notified of the changes made to the mempool.
+type Listener interface {
+	// Added is called once [tx] is added to the mempool.
+	Added(tx *txs.Tx)
+	// Removed is called once [tx] is removed from the mempool.
+	Removed(tx *txs.Tx)
+	// Dropped is called once [txID] is marked as dropped. [tx] is nil if the
+	// tx isn't in the mempool.
+	Dropped(txID ids.ID, tx *txs.Tx, reason error)
+}
+
+// DroppedTx is a tx that was marked as dropped.
+type DroppedTx struct {
+	TxID   ids.ID
+	Reason error
+	// Time the tx was last marked as dropped
+	Time time.Time
 }
 
 type mempool struct {
@@ -67,20 +95,28 @@ type mempool struct {
 
 	unissuedTxs linkedhashmap.LinkedHashmap[ids.ID, *txs.Tx]
 	numTxs      prometheus.Gauge
+	// Key: Tx ID
+	// Value: Time the tx was added
+	addedTimes map[ids.ID]time.Time
 
 	toEngine chan<- common.Message
+	// May be nil
+	listener Listener
 
 	// Key: Tx ID
-	// Value: Verification error
-	droppedTxIDs *cache.LRU[ids.ID, error]
+	// Value: Dropped tx, which is moved to the end once it is dropped again
+	droppedTxs linkedhashmap.LinkedHashmap[ids.ID, DroppedTx]
 
 	consumedUTXOs set.Set[ids.ID]
+
+	clock mockable.Clock
 }
 
 func New(
 	namespace string,
 	registerer prometheus.Registerer,
 	toEngine chan<- common.Message,
+	listener Listener,
 ) (Mempool, error) {
 	bytesAvailableMetric := prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: namespace,
@@ -106,8 +142,10 @@ func New(
 		bytesAvailable:       maxMempoolSize,
 		unissuedTxs:          linkedhashmap.New[ids.ID, *txs.Tx](),
 		numTxs:               numTxsMetric,
+		addedTimes:           make(map[ids.ID]time.Time),
 		toEngine:             toEngine,
-		droppedTxIDs:         &cache.LRU[ids.ID, error]{Size: droppedTxIDsCacheSize},
+		listener:             listener,
+		droppedTxs:           linkedhashmap.New[ids.ID, DroppedTx](),
 		consumedUTXOs:        set.NewSet[ids.ID](initialConsumedUTXOsSize),
 	}, nil
 }
@@ -146,13 +184,18 @@ func (m *mempool) Add(tx *txs.Tx) error {
 	m.bytesAvailableMetric.Set(float64(m.bytesAvailable))
 
 	m.unissuedTxs.Put(txID, tx)
+	m.addedTimes[txID] = m.clock.Time()
 	m.numTxs.Inc()
 
 	// Mark these UTXOs as consumed in the mempool
 	m.consumedUTXOs.Union(inputs)
 
 	// An explicitly added tx must not be marked as dropped.
-	m.droppedTxIDs.Evict(txID)
+	m.droppedTxs.Delete(txID)
+
+	if m.listener != nil {
+		m.listener.Added(tx)
+	}
 	return nil
 }
 
@@ -171,6 +214,7 @@ func (m *mempool) Remove(txsToRemove []*txs.Tx) {
 		if !m.unissuedTxs.Delete(txID) {
 			continue
 		}
+		delete(m.addedTimes, txID)
 
 		m.bytesAvailable += len(tx.Bytes())
 		m.bytesAvailableMetric.Set(float64(m.bytesAvailable))
@@ -179,6 +223,10 @@ func (m *mempool) Remove(txsToRemove []*txs.Tx) {
 
 		inputs := tx.Unsigned.InputIDs()
 		m.consumedUTXOs.Difference(inputs)
+
+		if m.listener != nil {
+			m.listener.Removed(tx)
+		}
 	}
 }
 
@@ -206,10 +254,42 @@ func (m *mempool) RequestBuildBlock() {
 }
 
 func (m *mempool) MarkDropped(txID ids.ID, reason error) {
-	m.droppedTxIDs.Put(txID, reason)
+	// Re-inserting the tx moves it to the end of the dropped txs.
+	m.droppedTxs.Delete(txID)
+	m.droppedTxs.Put(txID, DroppedTx{
+		TxID:   txID,
+		Reason: reason,
+		Time:   m.clock.Time(),
+	})
+	if m.droppedTxs.Len() > droppedTxsSize {
+		oldestTxID, _, _ := m.droppedTxs.Oldest()
+		m.droppedTxs.Delete(oldestTxID)
+	}
+
+	if m.listener != nil {
+		m.listener.Dropped(txID, m.Get(txID), reason)
+	}
 }
 
 func (m *mempool) GetDropReason(txID ids.ID) error {
-	err, _ := m.droppedTxIDs.Get(txID)
-	return err
+	droppedTx, _ := m.droppedTxs.Get(txID)
+	return droppedTx.Reason
+}
+
+func (m *mempool) Iterate(f func(tx *txs.Tx, addedTime time.Time) bool) {
+	txIter := m.unissuedTxs.NewIterator()
+	for txIter.Next() {
+		if !f(txIter.Value(), m.addedTimes[txIter.Key()]) {
+			return
+		}
+	}
+}
+
+func (m *mempool) DroppedTxs() []DroppedTx {
+	droppedTxs := make([]DroppedTx, 0, m.droppedTxs.Len())
+	droppedIter := m.droppedTxs.NewIterator()
+	for droppedIter.Next() {
+		droppedTxs = append(droppedTxs, droppedIter.Value())
+	}
+	return droppedTxs
 }
@@ -4,7 +4,9 @@
 package mempool
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -33,7 +35,7 @@ func TestBlockBuilderMaxMempoolSizeHandling(t *testing.T) {
 	require := require.New(t)
 
 	registerer := prometheus.NewRegistry()
-	mempoolIntf, err := New("mempool", registerer, nil)
+	mempoolIntf, err := New("mempool", registerer, nil, nil)
 	require.NoError(err)
 
 	mempool := mempoolIntf.(*mempool)
@@ -58,7 +60,7 @@ func TestTxsInMempool(t *testing.T) {
 
 	registerer := prometheus.NewRegistry()
 	toEngine := make(chan common.Message, 100)
-	mempool, err := New("mempool", registerer, toEngine)
+	mempool, err := New("mempool", registerer, toEngine, nil)
 	require.NoError(err)
 
 	testTxs := createTestTxs(2)
@@ -115,6 +117,56 @@ func TestTxsInMempool(t *testing.T) {
 	}
 }
 
+func TestMempoolInspection(t *testing.T) {
+	require := require.New(t)
+
+	registerer := prometheus.NewRegistry()
+	mempoolIntf, err := New("mempool", registerer, nil, nil)
+	require.NoError(err)
+
+	mempool := mempoolIntf.(*mempool)
+
+	testTxs := createTestTxs(2)
+	tx0, tx1 := testTxs[0], testTxs[1]
+
+	startTime := time.Unix(1000, 0)
+	mempool.clock.Set(startTime)
+	require.NoError(mempool.Add(tx0))
+	mempool.clock.Set(startTime.Add(time.Second))
+	require.NoError(mempool.Add(tx1))
+
+	var (
+		iteratedTxs []*txs.Tx
+		addedTimes  []time.Time
+	)
+	mempool.Iterate(func(tx *txs.Tx, addedTime time.Time) bool {
+		iteratedTxs = append(iteratedTxs, tx)
+		addedTimes = append(addedTimes, addedTime)
+		return true
+	})
+	require.Equal([]*txs.Tx{tx0, tx1}, iteratedTxs)
+	require.Equal([]time.Time{startTime, startTime.Add(time.Second)}, addedTimes)
+
+	errTest := errors.New("non-nil error")
+	droppedTime := startTime.Add(2 * time.Second)
+	mempool.clock.Set(droppedTime)
+	mempool.MarkDropped(tx1.ID(), errTest)
+	mempool.MarkDropped(tx0.ID(), errTest)
+	require.Equal(
+		[]DroppedTx{
+			{TxID: tx1.ID(), Reason: errTest, Time: droppedTime},
+			{TxID: tx0.ID(), Reason: errTest, Time: droppedTime},
+		},
+		mempool.DroppedTxs(),
+	)
+
+	// Re-adding a dropped tx clears its drop reason.
+	mempool.Remove([]*txs.Tx{tx0})
+	require.NoError(mempool.Add(tx0))
+	require.NoError(mempool.GetDropReason(tx0.ID()))
+	require.Len(mempool.DroppedTxs(), 1)
+}
+
 func createTestTxs(count int) []*txs.Tx {
 	testTxs := make([]*txs.Tx, 0, count)
 	addr := keys[0].PublicKey().Address()
@@ -9,6 +9,7 @@ package mempool
 
 import (
 	reflect "reflect"
+	time "time"
 
 	ids "github.com/luxdefi/node/ids"
 	txs "github.com/luxdefi/node/vms/avm/txs"
@@ -52,6 +53,20 @@ func (mr *MockMempoolMockRecorder) Add(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockMempool)(nil).Add), arg0)
 }
 
+// DroppedTxs mocks base method.
+func (m *MockMempool) DroppedTxs() []DroppedTx {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DroppedTxs")
+	ret0, _ := ret[0].([]DroppedTx)
+	return ret0
+}
+
+// DroppedTxs indicates an expected call of DroppedTxs.
+func (mr *MockMempoolMockRecorder) DroppedTxs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DroppedTxs", reflect.TypeOf((*MockMempool)(nil).DroppedTxs))
+}
+
 // Get mocks base method.
 func (m *MockMempool) Get(arg0 ids.ID) *txs.Tx {
 	m.ctrl.T.Helper()
@@ -94,6 +109,18 @@ func (mr *MockMempoolMockRecorder) Has(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Has", reflect.TypeOf((*MockMempool)(nil).Has), arg0)
 }
 
+// Iterate mocks base method.
+func (m *MockMempool) Iterate(arg0 func(*txs.Tx, time.Time) bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Iterate", arg0)
+}
+
+// Iterate indicates an expected call of Iterate.
+func (mr *MockMempoolMockRecorder) Iterate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Iterate", reflect.TypeOf((*MockMempool)(nil).Iterate), arg0)
+}
+
 // MarkDropped mocks base method.
 func (m *MockMempool) MarkDropped(arg0 ids.ID, arg1 error) {
 	m.ctrl.T.Helper()
@@ -87,6 +87,12 @@ type VM struct {
 	parser block.Parser
 
 	pubsub *pubsub.Server
+	// Streams the changes made to [mempool]
+	mempoolEvents *pubsub.Server
+
+	// Exposes the admin API, which allows the node operator to remove txs from
+	// the mempool
+	adminAPIEnabled bool
 
 	appSender common.AppSender
 
@@ -118,6 +124,7 @@ type VM struct {
 	blockbuilder.Builder
 	chainManager blockexecutor.Manager
 	network      network.Network
+	mempool      mempool.Mempool
 }
 
 func (*VM) Connected(context.Context, ids.NodeID, *version.Application) error {
@@ -138,6 +145,7 @@ type Config struct {
 	IndexTransactions    bool `json:"index-transactions"`
 	IndexAllowIncomplete bool `json:"index-allow-incomplete"`
 	ChecksumsEnabled     bool `json:"checksums-enabled"`
+	AdminAPIEnabled      bool `json:"admin-api-enabled"`
 }
 
 func (vm *VM) Initialize(
@@ -187,6 +195,8 @@ func (vm *VM) Initialize(
 	vm.assetToFxCache = &cache.LRU[ids.ID, set.Bits64]{Size: assetToFxCacheSize}
 
 	vm.pubsub = pubsub.New(ctx.Log)
+	vm.mempoolEvents = pubsub.New(ctx.Log)
+	vm.adminAPIEnabled = avmConfig.AdminAPIEnabled
 
 	typedFxs := make([]extensions.Fx, len(fxs))
 	vm.fxs = make([]*extensions.ParsedFx, len(fxs))
@@ -335,13 +345,28 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]http.Handler, error) {
 	walletServer.RegisterInterceptFunc(vm.metrics.InterceptRequest)
 	walletServer.RegisterAfterFunc(vm.metrics.AfterRequest)
 	// name this service "wallet"
-	err := walletServer.RegisterService(&vm.walletService, "wallet")
+	if err := walletServer.RegisterService(&vm.walletService, "wallet"); err != nil {
+		return nil, err
+	}
 
-	return map[string]http.Handler{
-		"":        rpcServer,
-		"/wallet": walletServer,
-		"/events": vm.pubsub,
-	}, err
+	handlers := map[string]http.Handler{
+		"":                rpcServer,
+		"/wallet":         walletServer,
+		"/events":         vm.pubsub,
+		"/mempool/events": vm.mempoolEvents,
+	}
+	if !vm.adminAPIEnabled {
+		return handlers, nil
+	}
+
+	adminServer := rpc.NewServer()
+	adminServer.RegisterCodec(codec, "application/json")
+	adminServer.RegisterCodec(codec, "application/json;charset=UTF-8")
+	adminServer.RegisterInterceptFunc(vm.metrics.InterceptRequest)
+	adminServer.RegisterAfterFunc(vm.metrics.AfterRequest)
+	handlers["/admin"] = adminServer
+	// name this service "admin"
+	return handlers, adminServer.RegisterService(&AdminService{vm: vm}, "admin")
 }
 
 func (*VM) CreateStaticHandlers(context.Context) (map[string]http.Handler, error) {
@@ -403,13 +428,18 @@ func (vm *VM) Linearize(_ context.Context, stopVertexID ids.ID, toEngine chan<-
 		return err
 	}
 
-	mempool, err := mempool.New("mempool", vm.registerer, toEngine)
+	vm.mempool, err = mempool.New(
+		"mempool",
+		vm.registerer,
+		toEngine,
+		&mempoolEventPublisher{server: vm.mempoolEvents},
+	)
 	if err != nil {
 		return fmt.Errorf("failed to create mempool: %w", err)
 	}
 
 	vm.chainManager = blockexecutor.NewManager(
-		mempool,
+		vm.mempool,
 		vm.metrics,
 		vm.state,
 		vm.txBackend,
@@ -421,14 +451,14 @@ func (vm *VM) Linearize(_ context.Context, stopVertexID ids.ID, toEngine chan<-
 		vm.txBackend,
 		vm.chainManager,
 		&vm.clock,
-		mempool,
+		vm.mempool,
 	)
 
 	vm.network = network.New(
 		vm.ctx,
 		vm.parser,
 		vm.chainManager,
-		mempool,
+		vm.mempool,
 		vm.appSender,
 	)
 
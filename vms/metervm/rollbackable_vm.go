@@ -0,0 +1,24 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metervm
+
+import (
+	"context"
+
+	"github.com/luxdefi/node/snow/engine/snowman/block"
+)
+
+func (vm *blockVM) VerifyRollback(ctx context.Context, height uint64) error {
+	if vm.rollbackVM == nil {
+		return block.ErrRollbackNotSupported
+	}
+	return vm.rollbackVM.VerifyRollback(ctx, height)
+}
+
+func (vm *blockVM) Rollback(ctx context.Context, height uint64) error {
+	if vm.rollbackVM == nil {
+		return block.ErrRollbackNotSupported
+	}
+	return vm.rollbackVM.Rollback(ctx, height)
+}
@@ -0,0 +1,40 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+
+	"github.com/luxdefi/node/api"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/rpc"
+)
+
+var _ AdminClient = (*adminClient)(nil)
+
+// AdminClient interface for interacting with the admin API of the P Chain
+type AdminClient interface {
+	// RemoveMempoolTx removes the transaction [txID] from the mempool
+	RemoveMempoolTx(ctx context.Context, txID ids.ID, options ...rpc.Option) error
+}
+
+// adminClient implementation for interacting with the admin API of the P
+// Chain
+type adminClient struct {
+	requester rpc.EndpointRequester
+}
+
+// NewAdminClient returns a client to interact with the admin API of the P
+// Chain, which is only exposed if enabled in the chain config
+func NewAdminClient(uri string) AdminClient {
+	return &adminClient{requester: rpc.NewEndpointRequester(
+		uri + "/ext/P/admin",
+	)}
+}
+
+func (c *adminClient) RemoveMempoolTx(ctx context.Context, txID ids.ID, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.removeMempoolTx", &api.JSONTxID{
+		TxID: txID,
+	}, &api.EmptyReply{}, options...)
+}
@@ -0,0 +1,185 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/luxdefi/node/database"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/vms/platformvm/block"
+	"github.com/luxdefi/node/vms/platformvm/checkpoint"
+)
+
+const (
+	// maxBackfillBlocks is the number of blocks requested at once while
+	// fetching the blocks below a synced checkpoint.
+	maxBackfillBlocks = 256
+
+	// backfillRetryDelay is the time to wait before requesting blocks again
+	// after a request failed.
+	backfillRetryDelay = time.Second
+)
+
+var _ checkpoint.BlockGetter = (*historicalBlocks)(nil)
+
+// historicalBlocks provides the accepted blocks that are served to peers
+// fetching the blocks below a checkpoint. Requests are handled without the
+// context lock held.
+type historicalBlocks struct {
+	vm *VM
+}
+
+func (b historicalBlocks) GetBlockAtHeight(height uint64) ([]byte, error) {
+	b.vm.ctx.Lock.Lock()
+	defer b.vm.ctx.Lock.Unlock()
+
+	blkID, err := b.vm.state.GetBlockIDAtHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	blk, err := b.vm.state.GetStatelessBlock(blkID)
+	if err != nil {
+		return nil, err
+	}
+	return blk.Bytes(), nil
+}
+
+// startBackfill starts fetching the blocks below the synced checkpoint, if
+// they haven't all been fetched yet. It must be called with the context lock
+// held once the state is loaded.
+func (vm *VM) startBackfill() error {
+	height, err := vm.checkpoints.GetBackfill()
+	if err == database.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	blkID, err := vm.state.GetBlockIDAtHeight(height)
+	if err != nil {
+		return err
+	}
+	blk, err := vm.state.GetStatelessBlock(blkID)
+	if err != nil {
+		return err
+	}
+
+	vm.ctx.Log.Info("fetching blocks below checkpoint",
+		zap.Uint64("height", height),
+	)
+
+	vm.checkpointWG.Add(1)
+	go vm.backfillBlocks(height, blk.Parent())
+	return nil
+}
+
+// backfillBlocks fetches the ancestors of the block at [height], whose parent
+// is [parentID], from peers until the genesis block is reached. The progress
+// is recorded in [vm.checkpoints] so that it's resumed after a restart.
+func (vm *VM) backfillBlocks(height uint64, parentID ids.ID) {
+	defer vm.checkpointWG.Done()
+
+	for height > 0 {
+		blks, nodeID, err := vm.blockClient.GetBlocks(vm.checkpointCtx, height-1, maxBackfillBlocks)
+		if err == nil {
+			vm.ctx.Lock.Lock()
+			if vm.checkpointCtx.Err() != nil {
+				vm.ctx.Lock.Unlock()
+				return
+			}
+			var done bool
+			height, parentID, done, err = vm.putBackfilledBlocks(height, parentID, blks)
+			vm.ctx.Lock.Unlock()
+			if done {
+				return
+			}
+			if err == nil {
+				continue
+			}
+		}
+
+		vm.ctx.Log.Debug("failed to fetch blocks below checkpoint",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint64("height", height-1),
+			zap.Error(err),
+		)
+
+		select {
+		case <-time.After(backfillRetryDelay):
+		case <-vm.checkpointCtx.Done():
+			return
+		}
+	}
+}
+
+// putBackfilledBlocks writes the ancestors of the block at [height], whose
+// parent is [parentID], that are at the start of [blksBytes]. It returns the
+// height and parent of the lowest known block, and whether the backfill is
+// finished. It must be called with the context lock held.
+func (vm *VM) putBackfilledBlocks(
+	height uint64,
+	parentID ids.ID,
+	blksBytes [][]byte,
+) (uint64, ids.ID, bool, error) {
+	// The backfill is stopped if another checkpoint was synced, or if the
+	// state was reset, since this one started.
+	backfillHeight, err := vm.checkpoints.GetBackfill()
+	if err == database.ErrNotFound || (err == nil && backfillHeight != height) {
+		return height, parentID, true, nil
+	}
+	if err != nil {
+		vm.ctx.Log.Error("failed to get backfill height",
+			zap.Error(err),
+		)
+		return height, parentID, true, err
+	}
+
+	var numAdded int
+	for _, blkBytes := range blksBytes {
+		blk, err := block.Parse(block.Codec, blkBytes)
+		if err != nil {
+			break
+		}
+		if blk.ID() != parentID {
+			break
+		}
+		vm.state.AddStatelessBlock(blk)
+		height = blk.Height()
+		parentID = blk.Parent()
+		numAdded++
+	}
+	if numAdded == 0 {
+		return height, parentID, false, fmt.Errorf("expected block %s", parentID)
+	}
+
+	if err := vm.state.Commit(); err != nil {
+		vm.ctx.Log.Error("failed to write blocks below checkpoint",
+			zap.Error(err),
+		)
+		return height, parentID, true, err
+	}
+	if height > 0 {
+		if err := vm.checkpoints.PutBackfill(height); err != nil {
+			vm.ctx.Log.Error("failed to write backfill height",
+				zap.Error(err),
+			)
+			return height, parentID, true, err
+		}
+		return height, parentID, false, nil
+	}
+
+	if err := vm.checkpoints.DeleteBackfill(); err != nil {
+		vm.ctx.Log.Error("failed to delete backfill height",
+			zap.Error(err),
+		)
+		return height, parentID, true, err
+	}
+	vm.ctx.Log.Info("finished fetching blocks below checkpoint")
+	return height, parentID, true, nil
+}
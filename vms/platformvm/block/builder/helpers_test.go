@@ -169,7 +169,7 @@ func newEnvironment(t *testing.T) *environment {
 	metrics, err := metrics.New("", registerer)
 	require.NoError(err)
 
-	res.mempool, err = mempool.New("mempool", registerer, nil)
+	res.mempool, err = mempool.New("mempool", registerer, nil, res.ctx.LUXAssetID, nil)
 	require.NoError(err)
 
 	res.blkManager = blockexecutor.NewManager(
@@ -178,6 +178,7 @@ func newEnvironment(t *testing.T) *environment {
 		res.state,
 		&res.backend,
 		pvalidators.TestManager,
+		nil,
 	)
 
 	res.network = network.New(
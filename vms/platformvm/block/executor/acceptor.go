@@ -30,6 +30,7 @@ type acceptor struct {
 	metrics      metrics.Metrics
 	validators   validators.Manager
 	bootstrapped *utils.Atomic[bool]
+	onCommit     func(block.Block)
 }
 
 func (a *acceptor) BanffAbortBlock(b *block.BanffAbortBlock) error {
@@ -103,6 +104,7 @@ func (a *acceptor) ApricotAtomicBlock(b *block.ApricotAtomicBlock) error {
 			err,
 		)
 	}
+	a.committed(b)
 
 	a.ctx.Log.Trace(
 		"accepted block",
@@ -183,6 +185,7 @@ func (a *acceptor) optionBlock(b, parent block.Block, blockType string) error {
 	if err := a.state.Commit(); err != nil {
 		return err
 	}
+	a.committed(b)
 
 	a.ctx.Log.Trace(
 		"accepted block",
@@ -258,6 +261,7 @@ func (a *acceptor) standardBlock(b block.Block, blockType string) error {
 	if err := a.ctx.SharedMemory.Apply(blkState.atomicRequests, batch); err != nil {
 		return fmt.Errorf("failed to apply vm's state to shared memory: %w", err)
 	}
+	a.committed(b)
 
 	if onAcceptFunc := blkState.onAcceptFunc; onAcceptFunc != nil {
 		onAcceptFunc()
@@ -289,3 +293,10 @@ func (a *acceptor) commonAccept(b block.Block) error {
 	a.validators.OnAcceptedBlockID(blkID)
 	return nil
 }
+
+// committed is called after the state as of [b] has been written to disk.
+func (a *acceptor) committed(b block.Block) {
+	if a.onCommit != nil {
+		a.onCommit(b)
+	}
+}
@@ -178,6 +178,10 @@ func TestAcceptorVisitStandardBlock(t *testing.T) {
 		metrics:    metrics.Noop,
 		validators: validators.TestManager,
 	}
+	var committed block.Block
+	acceptor.onCommit = func(b block.Block) {
+		committed = b
+	}
 
 	blk, err := block.NewBanffStandardBlock(
 		clk.Time(),
@@ -242,9 +246,11 @@ func TestAcceptorVisitStandardBlock(t *testing.T) {
 	sharedMemory.EXPECT().Apply(atomicRequests, batch).Return(nil).Times(1)
 	s.EXPECT().Checksum().Return(ids.Empty).Times(1)
 
+	require.Nil(committed)
 	require.NoError(acceptor.BanffStandardBlock(blk))
 	require.True(calledOnAcceptFunc)
 	require.Equal(blk.ID(), acceptor.backend.lastAccepted)
+	require.Equal(blk, committed)
 }
 
 func TestAcceptorVisitCommitBlock(t *testing.T) {
@@ -193,7 +193,7 @@ func newEnvironment(t *testing.T, ctrl *gomock.Controller) *environment {
 	metrics := metrics.Noop
 
 	var err error
-	res.mempool, err = mempool.New("mempool", registerer, nil)
+	res.mempool, err = mempool.New("mempool", registerer, nil, res.ctx.LUXAssetID, nil)
 	if err != nil {
 		panic(fmt.Errorf("failed to create mempool: %w", err))
 	}
@@ -205,6 +205,7 @@ func newEnvironment(t *testing.T, ctrl *gomock.Controller) *environment {
 			res.state,
 			res.backend,
 			pvalidators.TestManager,
+			nil,
 		)
 		addSubnet(res)
 	} else {
@@ -214,6 +215,7 @@ func newEnvironment(t *testing.T, ctrl *gomock.Controller) *environment {
 			res.mockedState,
 			res.backend,
 			pvalidators.TestManager,
+			nil,
 		)
 		// we do not add any subnet to state, since we can mock
 		// whatever we need
@@ -41,12 +41,16 @@ type Manager interface {
 	VerifyTx(tx *txs.Tx) error
 }
 
+// NewManager returns a block manager. If [onCommit] is non-nil, it is called
+// with the last accepted block every time the accepted state is committed to
+// the database.
 func NewManager(
 	mempool mempool.Mempool,
 	metrics metrics.Metrics,
 	s state.State,
 	txExecutorBackend *executor.Backend,
 	validatorManager validators.Manager,
+	onCommit func(block.Block),
 ) Manager {
 	lastAccepted := s.GetLastAccepted()
 	backend := &backend{
@@ -68,6 +72,7 @@ func NewManager(
 			metrics:      metrics,
 			validators:   validatorManager,
 			bootstrapped: txExecutorBackend.Bootstrapped,
+			onCommit:     onCommit,
 		},
 		rejector: &rejector{
 			backend:         backend,
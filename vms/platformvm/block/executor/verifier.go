@@ -9,12 +9,14 @@ import (
 
 	"github.com/luxdefi/node/chains/atomic"
 	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/math"
 	"github.com/luxdefi/node/utils/set"
 	"github.com/luxdefi/node/vms/platformvm/block"
 	"github.com/luxdefi/node/vms/platformvm/state"
 	"github.com/luxdefi/node/vms/platformvm/status"
 	"github.com/luxdefi/node/vms/platformvm/txs"
 	"github.com/luxdefi/node/vms/platformvm/txs/executor"
+	"github.com/luxdefi/node/vms/platformvm/txs/fee"
 )
 
 var (
@@ -207,6 +209,10 @@ func (v *verifier) ApricotAtomicBlock(b *block.ApricotAtomicBlock) error {
 		return err
 	}
 
+	if err := v.updateGasPrice(atomicExecutor.OnAccept, []*txs.Tx{b.Tx}); err != nil {
+		return err
+	}
+
 	blkID := b.ID()
 	v.blkIDToState[blkID] = &blockState{
 		standardBlockState: standardBlockState{
@@ -373,6 +379,15 @@ func (v *verifier) proposalBlock(
 	onCommitState.AddTx(b.Tx, status.Committed)
 	onAbortState.AddTx(b.Tx, status.Aborted)
 
+	// The gas price is updated on both sides of the proposal, so that the
+	// option blocks inherit it.
+	if err := v.updateGasPrice(onCommitState, []*txs.Tx{b.Tx}); err != nil {
+		return err
+	}
+	if err := v.updateGasPrice(onAbortState, []*txs.Tx{b.Tx}); err != nil {
+		return err
+	}
+
 	blkID := b.ID()
 	v.blkIDToState[blkID] = &blockState{
 		proposalBlockState: proposalBlockState{
@@ -445,6 +460,10 @@ func (v *verifier) standardBlock(
 		return err
 	}
 
+	if err := v.updateGasPrice(onAcceptState, b.Transactions); err != nil {
+		return err
+	}
+
 	if numFuncs := len(funcs); numFuncs == 1 {
 		blkState.onAcceptFunc = funcs[0]
 	} else if numFuncs > 1 {
@@ -462,6 +481,38 @@ func (v *verifier) standardBlock(
 	return nil
 }
 
+// updateGasPrice sets the gas price that the children of a block containing
+// [transactions] will charge, based on the gas the block consumed.
+func (v *verifier) updateGasPrice(onAcceptState state.Diff, transactions []*txs.Tx) error {
+	cfg := v.txExecutorBackend.Config
+	if !cfg.IsDynamicFeesActivated(onAcceptState.GetTimestamp()) {
+		return nil
+	}
+
+	var gasUsed uint64
+	for _, tx := range transactions {
+		complexity, err := fee.TxComplexity(tx)
+		if err != nil {
+			return err
+		}
+		gas, err := complexity.Gas(cfg.DynamicFeeConfig.Weights)
+		if err != nil {
+			return err
+		}
+		gasUsed, err = math.Add64(gasUsed, gas)
+		if err != nil {
+			return err
+		}
+	}
+
+	gasPrice, err := onAcceptState.GetGasPrice()
+	if err != nil {
+		return err
+	}
+	onAcceptState.SetGasPrice(cfg.DynamicFeeConfig.NextGasPrice(gasPrice, gasUsed))
+	return nil
+}
+
 // verifyUniqueInputs verifies that the inputs of the given block are not
 // duplicated in any of the parent blocks pinned in memory.
 func (v *verifier) verifyUniqueInputs(block block.Block, inputs set.Set[ids.ID]) error {
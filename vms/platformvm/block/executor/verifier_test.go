@@ -26,6 +26,7 @@ import (
 	"github.com/luxdefi/node/vms/platformvm/status"
 	"github.com/luxdefi/node/vms/platformvm/txs"
 	"github.com/luxdefi/node/vms/platformvm/txs/executor"
+	"github.com/luxdefi/node/vms/platformvm/txs/fee"
 	"github.com/luxdefi/node/vms/platformvm/txs/mempool"
 )
 
@@ -115,6 +116,105 @@ func TestVerifierVisitProposalBlock(t *testing.T) {
 	require.NoError(blk.Verify(context.Background()))
 }
 
+func TestVerifierVisitProposalBlockGasPrice(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	s := state.NewMockState(ctrl)
+	mempool := mempool.NewMockMempool(ctrl)
+	parentID := ids.GenerateTestID()
+	parentStatelessBlk := block.NewMockBlock(ctrl)
+	parentOnAcceptState := state.NewMockDiff(ctrl)
+	timestamp := time.Now()
+	// One call for each of onCommitState and onAbortState.
+	parentOnAcceptState.EXPECT().GetTimestamp().Return(timestamp).Times(2)
+
+	const parentGasPrice = 1_000
+	parentOnAcceptState.EXPECT().GetGasPrice().Return(uint64(parentGasPrice), nil).Times(2)
+
+	backend := &backend{
+		lastAccepted: parentID,
+		blkIDToState: map[ids.ID]*blockState{
+			parentID: {
+				statelessBlock: parentStatelessBlk,
+				onAcceptState:  parentOnAcceptState,
+			},
+		},
+		Mempool: mempool,
+		state:   s,
+		ctx: &snow.Context{
+			Log: logging.NoLog{},
+		},
+	}
+	dynamicFeeConfig := &fee.DynamicConfig{
+		Weights:                   fee.Dimensions{Bandwidth: 1},
+		MinGasPrice:               1,
+		MaxGasPrice:               1_000_000,
+		TargetGasPerBlock:         1_000_000,
+		GasPriceChangeDenominator: 8,
+	}
+	verifier := &verifier{
+		txExecutorBackend: &executor.Backend{
+			Config: &config.Config{
+				BanffTime:        mockable.MaxTime, // banff is not activated
+				DynamicFeeConfig: dynamicFeeConfig,
+			},
+			Clk: &mockable.Clock{},
+		},
+		backend: backend,
+	}
+	manager := &manager{
+		backend:  backend,
+		verifier: verifier,
+	}
+
+	blkTx := txs.NewMockUnsignedTx(ctrl)
+	blkTx.EXPECT().Visit(gomock.AssignableToTypeOf(&executor.ProposalTxExecutor{})).Return(nil).Times(1)
+	blkTx.EXPECT().InputIDs().Return(nil).AnyTimes()
+	blkTx.EXPECT().Outputs().Return(nil).AnyTimes()
+
+	apricotBlk, err := block.NewApricotProposalBlock(
+		parentID,
+		2,
+		&txs.Tx{
+			Unsigned: &txs.AdvanceTimeTx{},
+			Creds:    []verify.Verifiable{},
+		},
+	)
+	require.NoError(err)
+	apricotBlk.Tx.Unsigned = blkTx
+
+	tx := apricotBlk.Txs()[0]
+	parentStatelessBlk.EXPECT().Height().Return(uint64(1)).Times(1)
+	mempool.EXPECT().Remove([]*txs.Tx{tx}).Times(1)
+
+	blk := manager.NewBlock(apricotBlk)
+	require.NoError(blk.Verify(context.Background()))
+
+	// The block consumed less than the target, so the price decreases on both
+	// sides of the proposal.
+	expectedGasPrice := dynamicFeeConfig.NextGasPrice(parentGasPrice, uint64(len(tx.Bytes())))
+	require.Less(expectedGasPrice, uint64(parentGasPrice))
+
+	gotBlkState := verifier.backend.blkIDToState[apricotBlk.ID()]
+	gasPrice, err := gotBlkState.onCommitState.GetGasPrice()
+	require.NoError(err)
+	require.Equal(expectedGasPrice, gasPrice)
+
+	gasPrice, err = gotBlkState.onAbortState.GetGasPrice()
+	require.NoError(err)
+	require.Equal(expectedGasPrice, gasPrice)
+
+	// The option blocks inherit the gas price of the proposal.
+	commitBlk, err := block.NewApricotCommitBlock(apricotBlk.ID(), 3)
+	require.NoError(err)
+	require.NoError(manager.NewBlock(commitBlk).Verify(context.Background()))
+
+	gasPrice, err = verifier.backend.blkIDToState[commitBlk.ID()].onAcceptState.GetGasPrice()
+	require.NoError(err)
+	require.Equal(expectedGasPrice, gasPrice)
+}
+
 func TestVerifierVisitAtomicBlock(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
@@ -187,7 +287,9 @@ func TestVerifierVisitAtomicBlock(t *testing.T) {
 	parentStatelessBlk.EXPECT().Parent().Return(grandparentID).Times(1)
 	mempool.EXPECT().Remove([]*txs.Tx{apricotBlk.Tx}).Times(1)
 	onAccept.EXPECT().AddTx(apricotBlk.Tx, status.Committed).Times(1)
-	onAccept.EXPECT().GetTimestamp().Return(timestamp).Times(1)
+	// One call to check whether dynamic fees are activated and one for the
+	// block timestamp.
+	onAccept.EXPECT().GetTimestamp().Return(timestamp).Times(2)
 
 	blk := manager.NewBlock(apricotBlk)
 	require.NoError(blk.Verify(context.Background()))
@@ -0,0 +1,94 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package checkpoint
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/luxdefi/node/database"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/network/p2p"
+	"github.com/luxdefi/node/utils/logging"
+	"github.com/luxdefi/node/utils/math"
+	"github.com/luxdefi/node/utils/units"
+)
+
+const (
+	maxBlocksPerResponse = 512
+	maxBlocksBytes       = units.MiB
+)
+
+var _ p2p.Handler = (*BlockServer)(nil)
+
+// BlockGetter returns the bytes of accepted blocks.
+type BlockGetter interface {
+	// GetBlockAtHeight returns the bytes of the accepted block at [height].
+	// Returns database.ErrNotFound if the block isn't known.
+	GetBlockAtHeight(height uint64) ([]byte, error)
+}
+
+// BlockServer serves accepted blocks to peers that are fetching the blocks
+// below a checkpoint.
+type BlockServer struct {
+	p2p.NoOpHandler
+
+	log    logging.Logger
+	blocks BlockGetter
+}
+
+func NewBlockServer(log logging.Logger, blocks BlockGetter) *BlockServer {
+	return &BlockServer{
+		log:    log,
+		blocks: blocks,
+	}
+}
+
+func (s *BlockServer) AppRequest(_ context.Context, nodeID ids.NodeID, _ time.Time, requestBytes []byte) ([]byte, error) {
+	request, err := ParseBlocksRequest(requestBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		maxBlocks = math.Min(request.MaxBlocks, maxBlocksPerResponse)
+		response  BlocksResponse
+		size      int
+	)
+	for height := request.Height; uint32(len(response.Blocks)) < maxBlocks; height-- {
+		blkBytes, err := s.blocks.GetBlockAtHeight(height)
+		if err == database.ErrNotFound {
+			break
+		}
+		if err != nil {
+			s.log.Fatal("failed to get block",
+				zap.Uint64("height", height),
+				zap.Error(err),
+			)
+			return nil, err
+		}
+
+		size += len(blkBytes)
+		if len(response.Blocks) > 0 && size > maxBlocksBytes {
+			break
+		}
+		response.Blocks = append(response.Blocks, blkBytes)
+
+		if height == 0 {
+			break
+		}
+	}
+
+	if len(response.Blocks) == 0 {
+		s.log.Debug("dropping blocks request",
+			zap.String("reason", "unknown block"),
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint64("height", request.Height),
+		)
+		return nil, database.ErrNotFound
+	}
+	return response.Bytes()
+}
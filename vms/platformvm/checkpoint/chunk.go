@@ -0,0 +1,108 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package checkpoint
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/luxdefi/node/database"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/hashing"
+)
+
+// TargetChunkSize is the approximate number of bytes of keys and values
+// included in each chunk.
+const TargetChunkSize = 1024 * 1024
+
+var ErrClosing = errors.New("closing")
+
+type KeyValue struct {
+	Key   []byte `serialize:"true"`
+	Value []byte `serialize:"true"`
+}
+
+// Chunk is a contiguous range of the key/value pairs that make up a
+// checkpoint.
+type Chunk struct {
+	KeyValues []KeyValue `serialize:"true"`
+}
+
+func ParseChunk(bytes []byte) (*Chunk, error) {
+	chunk := &Chunk{}
+	version, err := c.Unmarshal(bytes, chunk)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal checkpoint chunk due to: %w", err)
+	}
+	if version != codecVersion {
+		return nil, errWrongCodecVersion
+	}
+	return chunk, nil
+}
+
+// WriteChunks splits the key/value pairs of [it] for which [include] returns
+// true into chunks, writes the chunks into [s] at [height] and returns their
+// IDs.
+//
+// If [closing] is closed before all the chunks are written, WriteChunks
+// returns [ErrClosing].
+func WriteChunks(
+	s Store,
+	height uint64,
+	it database.Iterator,
+	include func(key []byte) bool,
+	closing <-chan struct{},
+) ([]ids.ID, error) {
+	var (
+		chunkIDs []ids.ID
+		chunk    Chunk
+		size     int
+	)
+	writeChunk := func() error {
+		bytes, err := c.Marshal(codecVersion, &chunk)
+		if err != nil {
+			return fmt.Errorf("cannot marshal checkpoint chunk due to: %w", err)
+		}
+		if err := s.PutChunk(height, uint32(len(chunkIDs)), bytes); err != nil {
+			return err
+		}
+		chunkIDs = append(chunkIDs, hashing.ComputeHash256Array(bytes))
+		chunk.KeyValues = nil
+		size = 0
+		return nil
+	}
+
+	for it.Next() {
+		select {
+		case <-closing:
+			return nil, ErrClosing
+		default:
+		}
+
+		key := it.Key()
+		if !include(key) {
+			continue
+		}
+
+		value := it.Value()
+		chunk.KeyValues = append(chunk.KeyValues, KeyValue{
+			Key:   key,
+			Value: value,
+		})
+		size += len(key) + len(value)
+		if size < TargetChunkSize {
+			continue
+		}
+		if err := writeChunk(); err != nil {
+			return nil, err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	if len(chunk.KeyValues) == 0 {
+		return chunkIDs, nil
+	}
+	return chunkIDs, writeChunk()
+}
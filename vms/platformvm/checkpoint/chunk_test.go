@@ -0,0 +1,64 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package checkpoint
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxdefi/node/database/memdb"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/hashing"
+)
+
+func TestWriteChunks(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	value := make([]byte, TargetChunkSize/2)
+	for _, key := range [][]byte{{0}, {1}, {2}, {3}, {4}} {
+		require.NoError(db.Put(key, value))
+	}
+
+	s := NewStore(memdb.New())
+	it := db.NewIterator()
+	defer it.Release()
+
+	include := func(key []byte) bool {
+		return !bytes.Equal(key, []byte{2})
+	}
+	chunkIDs, err := WriteChunks(s, 10, it, include, nil)
+	require.NoError(err)
+	require.Len(chunkIDs, 2)
+
+	var keys [][]byte
+	for i, chunkID := range chunkIDs {
+		chunkBytes, err := s.GetChunk(10, uint32(i))
+		require.NoError(err)
+		require.Equal(chunkID, ids.ID(hashing.ComputeHash256Array(chunkBytes)))
+
+		chunk, err := ParseChunk(chunkBytes)
+		require.NoError(err)
+		for _, kv := range chunk.KeyValues {
+			require.Equal(value, kv.Value)
+			keys = append(keys, kv.Key)
+		}
+	}
+	require.Equal([][]byte{{0}, {1}, {3}, {4}}, keys)
+}
+
+func TestWriteChunksClosing(t *testing.T) {
+	db := memdb.New()
+	require.NoError(t, db.Put([]byte{0}, nil))
+
+	it := db.NewIterator()
+	defer it.Release()
+
+	closing := make(chan struct{})
+	close(closing)
+	_, err := WriteChunks(NewStore(memdb.New()), 1, it, func([]byte) bool { return true }, closing)
+	require.ErrorIs(t, err, ErrClosing)
+}
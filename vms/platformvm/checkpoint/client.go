@@ -0,0 +1,91 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package checkpoint
+
+import (
+	"context"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/network/p2p"
+)
+
+// Client fetches checkpoint chunks and blocks from connected peers.
+type Client struct {
+	client *p2p.Client
+}
+
+// NewClient returns a Client that sends requests using [client], which must
+// be registered with a Server or a BlockServer as the handler of its protocol.
+func NewClient(client *p2p.Client) *Client {
+	return &Client{
+		client: client,
+	}
+}
+
+type response struct {
+	nodeID ids.NodeID
+	bytes  []byte
+	err    error
+}
+
+// GetChunk requests the chunk at [index] of the checkpoint at [height] from a
+// connected peer. The returned bytes are not verified.
+func (c *Client) GetChunk(ctx context.Context, height uint64, index uint32) ([]byte, ids.NodeID, error) {
+	request := Request{
+		Height: height,
+		Index:  index,
+	}
+	requestBytes, err := request.Bytes()
+	if err != nil {
+		return nil, ids.EmptyNodeID, err
+	}
+	return c.appRequestAny(ctx, requestBytes)
+}
+
+// GetBlocks requests up to [maxBlocks] accepted blocks from a connected peer,
+// starting with the block at [height] and continuing with its ancestors. The
+// returned blocks are not verified.
+func (c *Client) GetBlocks(ctx context.Context, height uint64, maxBlocks uint32) ([][]byte, ids.NodeID, error) {
+	request := BlocksRequest{
+		Height:    height,
+		MaxBlocks: maxBlocks,
+	}
+	requestBytes, err := request.Bytes()
+	if err != nil {
+		return nil, ids.EmptyNodeID, err
+	}
+
+	responseBytes, nodeID, err := c.appRequestAny(ctx, requestBytes)
+	if err != nil {
+		return nil, nodeID, err
+	}
+	response, err := ParseBlocksResponse(responseBytes)
+	if err != nil {
+		return nil, nodeID, err
+	}
+	return response.Blocks, nodeID, nil
+}
+
+// appRequestAny sends [requestBytes] to a connected peer and waits for its
+// response.
+func (c *Client) appRequestAny(ctx context.Context, requestBytes []byte) ([]byte, ids.NodeID, error) {
+	responseChan := make(chan response, 1)
+	onResponse := func(_ context.Context, nodeID ids.NodeID, responseBytes []byte, err error) {
+		responseChan <- response{
+			nodeID: nodeID,
+			bytes:  responseBytes,
+			err:    err,
+		}
+	}
+	if err := c.client.AppRequestAny(ctx, requestBytes, onResponse); err != nil {
+		return nil, ids.EmptyNodeID, err
+	}
+
+	select {
+	case response := <-responseChan:
+		return response.bytes, response.nodeID, response.err
+	case <-ctx.Done():
+		return nil, ids.EmptyNodeID, ctx.Err()
+	}
+}
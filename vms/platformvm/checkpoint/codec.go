@@ -0,0 +1,28 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package checkpoint
+
+import (
+	"errors"
+	"math"
+
+	"github.com/luxdefi/node/codec"
+	"github.com/luxdefi/node/codec/linearcodec"
+)
+
+const codecVersion = 0
+
+var (
+	c codec.Manager
+
+	errWrongCodecVersion = errors.New("wrong codec version")
+)
+
+func init() {
+	lc := linearcodec.NewCustomMaxLength(math.MaxUint32)
+	c = codec.NewManager(math.MaxInt32)
+	if err := c.RegisterCodec(codecVersion, lc); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package checkpoint
+
+import "fmt"
+
+// Request asks a peer for the chunk at [Index] of the checkpoint at [Height].
+// The response is the bytes of the chunk.
+type Request struct {
+	Height uint64 `serialize:"true"`
+	Index  uint32 `serialize:"true"`
+}
+
+func (r *Request) Bytes() ([]byte, error) {
+	bytes, err := c.Marshal(codecVersion, r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal checkpoint request due to: %w", err)
+	}
+	return bytes, nil
+}
+
+func ParseRequest(bytes []byte) (*Request, error) {
+	request := &Request{}
+	version, err := c.Unmarshal(bytes, request)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal checkpoint request due to: %w", err)
+	}
+	if version != codecVersion {
+		return nil, errWrongCodecVersion
+	}
+	return request, nil
+}
+
+// BlocksRequest asks a peer for up to [MaxBlocks] accepted blocks, starting
+// with the block at [Height] and continuing with its ancestors.
+type BlocksRequest struct {
+	Height    uint64 `serialize:"true"`
+	MaxBlocks uint32 `serialize:"true"`
+}
+
+func (r *BlocksRequest) Bytes() ([]byte, error) {
+	bytes, err := c.Marshal(codecVersion, r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal blocks request due to: %w", err)
+	}
+	return bytes, nil
+}
+
+func ParseBlocksRequest(bytes []byte) (*BlocksRequest, error) {
+	request := &BlocksRequest{}
+	version, err := c.Unmarshal(bytes, request)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal blocks request due to: %w", err)
+	}
+	if version != codecVersion {
+		return nil, errWrongCodecVersion
+	}
+	return request, nil
+}
+
+// BlocksResponse holds the blocks returned for a BlocksRequest, in order of
+// decreasing height.
+type BlocksResponse struct {
+	Blocks [][]byte `serialize:"true"`
+}
+
+func (r *BlocksResponse) Bytes() ([]byte, error) {
+	bytes, err := c.Marshal(codecVersion, r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal blocks response due to: %w", err)
+	}
+	return bytes, nil
+}
+
+func ParseBlocksResponse(bytes []byte) (*BlocksResponse, error) {
+	response := &BlocksResponse{}
+	version, err := c.Unmarshal(bytes, response)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal blocks response due to: %w", err)
+	}
+	if version != codecVersion {
+		return nil, errWrongCodecVersion
+	}
+	return response, nil
+}
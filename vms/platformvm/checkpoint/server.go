@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package checkpoint
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/luxdefi/node/database"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/network/p2p"
+	"github.com/luxdefi/node/utils/logging"
+)
+
+var _ p2p.Handler = (*Server)(nil)
+
+// Server serves the chunks of the checkpoints in a Store to peers.
+type Server struct {
+	p2p.NoOpHandler
+
+	log   logging.Logger
+	store Store
+}
+
+func NewServer(log logging.Logger, store Store) *Server {
+	return &Server{
+		log:   log,
+		store: store,
+	}
+}
+
+func (s *Server) AppRequest(_ context.Context, nodeID ids.NodeID, _ time.Time, requestBytes []byte) ([]byte, error) {
+	request, err := ParseRequest(requestBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	chunk, err := s.store.GetChunk(request.Height, request.Index)
+	switch {
+	case err == database.ErrNotFound:
+		s.log.Debug("dropping checkpoint request",
+			zap.String("reason", "unknown chunk"),
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint64("height", request.Height),
+			zap.Uint32("index", request.Index),
+		)
+		return nil, err
+	case err != nil:
+		s.log.Fatal("failed to get checkpoint chunk",
+			zap.Uint64("height", request.Height),
+			zap.Uint32("index", request.Index),
+			zap.Error(err),
+		)
+		return nil, err
+	default:
+		return chunk, nil
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package checkpoint
+
+import (
+	"bytes"
+
+	"github.com/luxdefi/node/database"
+	"github.com/luxdefi/node/database/prefixdb"
+	"github.com/luxdefi/node/utils/hashing"
+	"github.com/luxdefi/node/utils/set"
+	"github.com/luxdefi/node/utils/wrappers"
+)
+
+const (
+	summaryPrefix byte = iota
+	chunkPrefix
+	syncingPrefix
+	applyingPrefix
+	rollbackPrefix
+	backfillPrefix
+)
+
+var (
+	_ Store = (*store)(nil)
+
+	storeDBPrefix  = []byte("checkpoint")
+	storeKeyPrefix = hashing.ComputeHash256(storeDBPrefix)
+
+	syncingKey  = []byte{syncingPrefix}
+	applyingKey = []byte{applyingPrefix}
+	rollbackKey = []byte{rollbackPrefix}
+	backfillKey = []byte{backfillPrefix}
+)
+
+// Store persists the checkpoints created by this node and the progress of an
+// ongoing checkpoint sync.
+type Store interface {
+	// GetLastSummary returns the summary of the most recent complete
+	// checkpoint.
+	//
+	// Returns database.ErrNotFound if there is no checkpoint.
+	GetLastSummary() (*Summary, error)
+	GetSummary(height uint64) (*Summary, error)
+	// PutSummary marks the checkpoint at the summary's height as complete. All
+	// of its chunks must have already been written.
+	PutSummary(summary *Summary) error
+
+	GetChunk(height uint64, index uint32) ([]byte, error)
+	PutChunk(height uint64, index uint32, chunk []byte) error
+
+	// Prune removes all but the [numToKeep] most recent checkpoints, along
+	// with any chunks that belong to neither a complete checkpoint nor the
+	// ongoing sync.
+	Prune(numToKeep int) error
+	// DeleteSummariesAbove removes the summaries of the checkpoints after
+	// [height]. Their chunks are removed by the next call to Prune.
+	DeleteSummariesAbove(height uint64) error
+
+	// GetSyncing returns the summary this node is currently syncing to.
+	//
+	// Returns database.ErrNotFound if there is no ongoing sync.
+	GetSyncing() (*Summary, error)
+	PutSyncing(summary *Summary) error
+	DeleteSyncing() error
+
+	// IsApplying returns true if the chunks of the ongoing sync may have been
+	// partially written into the VM's database.
+	IsApplying() (bool, error)
+	SetApplying(applying bool) error
+
+	// GetRollback returns the height of the checkpoint that the VM's state is
+	// being rolled back to.
+	//
+	// Returns database.ErrNotFound if there is no ongoing rollback.
+	GetRollback() (uint64, error)
+	PutRollback(height uint64) error
+	DeleteRollback() error
+
+	// GetBackfill returns the height of the lowest block of the VM after a
+	// checkpoint sync. The blocks below it are still being fetched.
+	//
+	// Returns database.ErrNotFound if no blocks are being fetched.
+	GetBackfill() (uint64, error)
+	PutBackfill(height uint64) error
+	DeleteBackfill() error
+}
+
+type store struct {
+	db database.Database
+}
+
+// NewStore returns a store that writes its keys into [db] under a single
+// prefix. IsStoreKey reports whether a key of [db] belongs to the store.
+func NewStore(db database.Database) Store {
+	return &store{
+		// NewNested is used so that every key of the store is prefixed by
+		// [storeKeyPrefix], regardless of whether [db] is a prefixed database.
+		db: prefixdb.NewNested(storeDBPrefix, db),
+	}
+}
+
+// IsStoreKey returns true if [key], in the database provided to NewStore, was
+// written by the store.
+func IsStoreKey(key []byte) bool {
+	return bytes.HasPrefix(key, storeKeyPrefix)
+}
+
+func (s *store) GetLastSummary() (*Summary, error) {
+	it := s.db.NewIteratorWithPrefix([]byte{summaryPrefix})
+	defer it.Release()
+
+	var summaryBytes []byte
+	for it.Next() {
+		summaryBytes = it.Value()
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	if summaryBytes == nil {
+		return nil, database.ErrNotFound
+	}
+	return ParseSummary(summaryBytes)
+}
+
+func (s *store) GetSummary(height uint64) (*Summary, error) {
+	summaryBytes, err := s.db.Get(summaryKey(height))
+	if err != nil {
+		return nil, err
+	}
+	return ParseSummary(summaryBytes)
+}
+
+func (s *store) PutSummary(summary *Summary) error {
+	return s.db.Put(summaryKey(summary.Height()), summary.Bytes())
+}
+
+func (s *store) GetChunk(height uint64, index uint32) ([]byte, error) {
+	return s.db.Get(chunkKey(height, index))
+}
+
+func (s *store) PutChunk(height uint64, index uint32, chunk []byte) error {
+	return s.db.Put(chunkKey(height, index), chunk)
+}
+
+func (s *store) Prune(numToKeep int) error {
+	var heights []uint64
+	it := s.db.NewIteratorWithPrefix([]byte{summaryPrefix})
+	for it.Next() {
+		heights = append(heights, parseHeight(it.Key()))
+	}
+	err := it.Error()
+	it.Release()
+	if err != nil {
+		return err
+	}
+
+	keep := set.Set[uint64]{}
+	for i, height := range heights {
+		if i+numToKeep >= len(heights) {
+			keep.Add(height)
+			continue
+		}
+		if err := s.db.Delete(summaryKey(height)); err != nil {
+			return err
+		}
+	}
+
+	syncing, err := s.GetSyncing()
+	switch err {
+	case nil:
+		keep.Add(syncing.Height())
+	case database.ErrNotFound:
+	default:
+		return err
+	}
+
+	batch := s.db.NewBatch()
+	it = s.db.NewIteratorWithPrefix([]byte{chunkPrefix})
+	defer it.Release()
+	for it.Next() {
+		if keep.Contains(parseHeight(it.Key())) {
+			continue
+		}
+		if err := batch.Delete(it.Key()); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+func (s *store) DeleteSummariesAbove(height uint64) error {
+	batch := s.db.NewBatch()
+	it := s.db.NewIteratorWithStartAndPrefix(summaryKey(height+1), []byte{summaryPrefix})
+	defer it.Release()
+	for it.Next() {
+		if err := batch.Delete(it.Key()); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+func (s *store) GetSyncing() (*Summary, error) {
+	summaryBytes, err := s.db.Get(syncingKey)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSummary(summaryBytes)
+}
+
+func (s *store) PutSyncing(summary *Summary) error {
+	return s.db.Put(syncingKey, summary.Bytes())
+}
+
+func (s *store) DeleteSyncing() error {
+	return s.db.Delete(syncingKey)
+}
+
+func (s *store) IsApplying() (bool, error) {
+	return s.db.Has(applyingKey)
+}
+
+func (s *store) SetApplying(applying bool) error {
+	if applying {
+		return s.db.Put(applyingKey, nil)
+	}
+	return s.db.Delete(applyingKey)
+}
+
+func (s *store) GetRollback() (uint64, error) {
+	return database.GetUInt64(s.db, rollbackKey)
+}
+
+func (s *store) PutRollback(height uint64) error {
+	return database.PutUInt64(s.db, rollbackKey, height)
+}
+
+func (s *store) DeleteRollback() error {
+	return s.db.Delete(rollbackKey)
+}
+
+func (s *store) GetBackfill() (uint64, error) {
+	return database.GetUInt64(s.db, backfillKey)
+}
+
+func (s *store) PutBackfill(height uint64) error {
+	return database.PutUInt64(s.db, backfillKey, height)
+}
+
+func (s *store) DeleteBackfill() error {
+	return s.db.Delete(backfillKey)
+}
+
+func summaryKey(height uint64) []byte {
+	p := wrappers.Packer{Bytes: make([]byte, 1+wrappers.LongLen)}
+	p.PackByte(summaryPrefix)
+	p.PackLong(height)
+	return p.Bytes
+}
+
+func chunkKey(height uint64, index uint32) []byte {
+	p := wrappers.Packer{Bytes: make([]byte, 1+wrappers.LongLen+wrappers.IntLen)}
+	p.PackByte(chunkPrefix)
+	p.PackLong(height)
+	p.PackInt(index)
+	return p.Bytes
+}
+
+// parseHeight returns the height packed into a summary or chunk key.
+func parseHeight(key []byte) uint64 {
+	p := wrappers.Packer{Bytes: key, Offset: 1}
+	return p.UnpackLong()
+}
@@ -0,0 +1,152 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package checkpoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxdefi/node/database"
+	"github.com/luxdefi/node/database/memdb"
+	"github.com/luxdefi/node/database/prefixdb"
+)
+
+func TestStoreKeys(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	s := NewStore(prefixdb.New([]byte("vm"), db))
+	require.NoError(s.PutChunk(1, 0, []byte{1}))
+	require.NoError(s.SetApplying(true))
+
+	// Keys must be prefixed even when the store is nested in a prefixed
+	// database.
+	vmDB := prefixdb.New([]byte("vm"), db)
+	it := vmDB.NewIterator()
+	defer it.Release()
+
+	numKeys := 0
+	for it.Next() {
+		require.True(IsStoreKey(it.Key()))
+		numKeys++
+	}
+	require.NoError(it.Error())
+	require.Equal(2, numKeys)
+}
+
+func TestStorePrune(t *testing.T) {
+	require := require.New(t)
+
+	s := NewStore(memdb.New())
+
+	_, err := s.GetLastSummary()
+	require.ErrorIs(err, database.ErrNotFound)
+
+	summaries := make([]*Summary, 3)
+	for i := range summaries {
+		height := uint64(i + 1)
+		require.NoError(s.PutChunk(height, 0, []byte{byte(i)}))
+
+		summary := newTestSummary(t, height)
+		require.NoError(s.PutSummary(summary))
+		summaries[i] = summary
+	}
+
+	// The chunk of an incomplete checkpoint.
+	require.NoError(s.PutChunk(4, 0, []byte{3}))
+
+	// The chunk of the ongoing sync.
+	syncing := newTestSummary(t, 5)
+	require.NoError(s.PutSyncing(syncing))
+	require.NoError(s.PutChunk(5, 0, []byte{4}))
+
+	require.NoError(s.Prune(2))
+
+	lastSummary, err := s.GetLastSummary()
+	require.NoError(err)
+	require.Equal(summaries[2].Bytes(), lastSummary.Bytes())
+
+	_, err = s.GetSummary(1)
+	require.ErrorIs(err, database.ErrNotFound)
+	_, err = s.GetChunk(1, 0)
+	require.ErrorIs(err, database.ErrNotFound)
+	_, err = s.GetChunk(4, 0)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	for _, height := range []uint64{2, 3, 5} {
+		_, err := s.GetChunk(height, 0)
+		require.NoError(err)
+	}
+	_, err = s.GetSummary(2)
+	require.NoError(err)
+
+	gotSyncing, err := s.GetSyncing()
+	require.NoError(err)
+	require.Equal(syncing.Bytes(), gotSyncing.Bytes())
+	require.NoError(s.DeleteSyncing())
+	_, err = s.GetSyncing()
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestStoreDeleteSummariesAbove(t *testing.T) {
+	require := require.New(t)
+
+	s := NewStore(memdb.New())
+	for height := uint64(1); height <= 3; height++ {
+		require.NoError(s.PutChunk(height, 0, []byte{byte(height)}))
+
+		summary := newTestSummary(t, height)
+		require.NoError(s.PutSummary(summary))
+	}
+
+	require.NoError(s.DeleteSummariesAbove(1))
+	require.NoError(s.Prune(2))
+
+	lastSummary, err := s.GetLastSummary()
+	require.NoError(err)
+	require.Equal(uint64(1), lastSummary.Height())
+	_, err = s.GetChunk(1, 0)
+	require.NoError(err)
+	for _, height := range []uint64{2, 3} {
+		_, err := s.GetSummary(height)
+		require.ErrorIs(err, database.ErrNotFound)
+		_, err = s.GetChunk(height, 0)
+		require.ErrorIs(err, database.ErrNotFound)
+	}
+}
+
+func TestStoreRollback(t *testing.T) {
+	require := require.New(t)
+
+	s := NewStore(memdb.New())
+	_, err := s.GetRollback()
+	require.ErrorIs(err, database.ErrNotFound)
+
+	require.NoError(s.PutRollback(5))
+	height, err := s.GetRollback()
+	require.NoError(err)
+	require.Equal(uint64(5), height)
+
+	require.NoError(s.DeleteRollback())
+	_, err = s.GetRollback()
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestStoreBackfill(t *testing.T) {
+	require := require.New(t)
+
+	s := NewStore(memdb.New())
+	_, err := s.GetBackfill()
+	require.ErrorIs(err, database.ErrNotFound)
+
+	require.NoError(s.PutBackfill(5))
+	height, err := s.GetBackfill()
+	require.NoError(err)
+	require.Equal(uint64(5), height)
+
+	require.NoError(s.DeleteBackfill())
+	_, err = s.GetBackfill()
+	require.ErrorIs(err, database.ErrNotFound)
+}
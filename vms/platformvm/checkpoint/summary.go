@@ -0,0 +1,146 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package checkpoint
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/crypto/bls"
+	"github.com/luxdefi/node/utils/hashing"
+	"github.com/luxdefi/node/vms/platformvm/warp"
+	"github.com/luxdefi/node/vms/platformvm/warp/payload"
+)
+
+var ErrInvalidSignature = errors.New("invalid checkpoint signature")
+
+// UnsignedSummary describes a checkpoint of the P-chain state as of the block
+// at [BlockHeight]. The state itself is split into chunks that are fetched
+// separately and verified against [ChunkIDs].
+//
+// Every node that creates a checkpoint at the same height creates the same
+// UnsignedSummary, so its hash is used as the ID of the summary.
+type UnsignedSummary struct {
+	BlockHeight uint64   `serialize:"true"`
+	Block       []byte   `serialize:"true"`
+	ChunkIDs    []ids.ID `serialize:"true"`
+}
+
+// Summary is an UnsignedSummary signed by the node that created the
+// checkpoint.
+type Summary struct {
+	UnsignedSummary `serialize:"true"`
+
+	Signer ids.NodeID `serialize:"true"`
+	// Signature is the BLS signature of [Signer] over the warp message
+	// returned by Message.
+	Signature [bls.SignatureLen]byte `serialize:"true"`
+
+	id    ids.ID
+	bytes []byte
+}
+
+func (s *Summary) ID() ids.ID {
+	return s.id
+}
+
+func (s *Summary) Height() uint64 {
+	return s.BlockHeight
+}
+
+func (s *Summary) Bytes() []byte {
+	return s.bytes
+}
+
+// Message returns the message that is signed by the creator of the
+// checkpoint. It attests to the ID of the summary on behalf of [chainID].
+func (s *Summary) Message(networkID uint32, chainID ids.ID) (*warp.UnsignedMessage, error) {
+	hash, err := payload.NewHash(s.id)
+	if err != nil {
+		return nil, err
+	}
+	return warp.NewUnsignedMessage(networkID, chainID, hash.Bytes())
+}
+
+// Verify returns nil if [Signature] is a valid signature of the summary by
+// [pk].
+func (s *Summary) Verify(networkID uint32, chainID ids.ID, pk *bls.PublicKey) error {
+	msg, err := s.Message(networkID, chainID)
+	if err != nil {
+		return err
+	}
+	sig, err := bls.SignatureFromBytes(s.Signature[:])
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+	if !bls.Verify(pk, sig, msg.Bytes()) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// NewSummary returns the summary of the checkpoint at [height], signed by
+// [signer] on behalf of [nodeID].
+func NewSummary(
+	networkID uint32,
+	chainID ids.ID,
+	nodeID ids.NodeID,
+	signer warp.Signer,
+	height uint64,
+	block []byte,
+	chunkIDs []ids.ID,
+) (*Summary, error) {
+	summary := &Summary{
+		UnsignedSummary: UnsignedSummary{
+			BlockHeight: height,
+			Block:       block,
+			ChunkIDs:    chunkIDs,
+		},
+		Signer: nodeID,
+	}
+	if err := summary.initializeID(); err != nil {
+		return nil, err
+	}
+
+	msg, err := summary.Message(networkID, chainID)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := signer.Sign(msg)
+	if err != nil {
+		return nil, fmt.Errorf("cannot sign checkpoint summary due to: %w", err)
+	}
+	copy(summary.Signature[:], sig)
+
+	bytes, err := c.Marshal(codecVersion, summary)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal checkpoint summary due to: %w", err)
+	}
+	summary.bytes = bytes
+	return summary, nil
+}
+
+func ParseSummary(bytes []byte) (*Summary, error) {
+	summary := &Summary{
+		bytes: bytes,
+	}
+	version, err := c.Unmarshal(bytes, summary)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal checkpoint summary due to: %w", err)
+	}
+	if version != codecVersion {
+		return nil, errWrongCodecVersion
+	}
+	return summary, summary.initializeID()
+}
+
+func (s *Summary) initializeID() error {
+	unsignedBytes, err := c.Marshal(codecVersion, &s.UnsignedSummary)
+	if err != nil {
+		return fmt.Errorf("cannot marshal unsigned checkpoint summary due to: %w", err)
+	}
+	s.id = hashing.ComputeHash256Array(unsignedBytes)
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package checkpoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxdefi/node/codec"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/constants"
+	"github.com/luxdefi/node/utils/crypto/bls"
+	"github.com/luxdefi/node/vms/platformvm/warp"
+)
+
+// newTestSummary returns a signed summary of a checkpoint at [height] with a
+// single chunk.
+func newTestSummary(t *testing.T, height uint64) *Summary {
+	sk, err := bls.NewSecretKey()
+	require.NoError(t, err)
+
+	summary, err := NewSummary(
+		constants.UnitTestID,
+		constants.PlatformChainID,
+		ids.GenerateTestNodeID(),
+		warp.NewSigner(sk, constants.UnitTestID, constants.PlatformChainID),
+		height,
+		nil,
+		[]ids.ID{ids.GenerateTestID()},
+	)
+	require.NoError(t, err)
+	return summary
+}
+
+func TestSummary(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	signer := warp.NewSigner(sk, constants.UnitTestID, constants.PlatformChainID)
+
+	height := uint64(2022)
+	block := []byte("blockBytes")
+	chunkIDs := []ids.ID{ids.GenerateTestID(), ids.GenerateTestID()}
+	nodeID := ids.GenerateTestNodeID()
+	builtSummary, err := NewSummary(constants.UnitTestID, constants.PlatformChainID, nodeID, signer, height, block, chunkIDs)
+	require.NoError(err)
+
+	require.Equal(height, builtSummary.Height())
+	require.Equal(block, builtSummary.Block)
+	require.Equal(chunkIDs, builtSummary.ChunkIDs)
+	require.Equal(nodeID, builtSummary.Signer)
+	require.NoError(builtSummary.Verify(constants.UnitTestID, constants.PlatformChainID, bls.PublicFromSecretKey(sk)))
+
+	parsedSummary, err := ParseSummary(builtSummary.Bytes())
+	require.NoError(err)
+	require.Equal(builtSummary, parsedSummary)
+}
+
+func TestSummaryIDIgnoresSigner(t *testing.T) {
+	require := require.New(t)
+
+	height := uint64(2022)
+	block := []byte("blockBytes")
+	chunkIDs := []ids.ID{ids.GenerateTestID()}
+
+	sk0, err := bls.NewSecretKey()
+	require.NoError(err)
+	signer0 := warp.NewSigner(sk0, constants.UnitTestID, constants.PlatformChainID)
+	summary0, err := NewSummary(constants.UnitTestID, constants.PlatformChainID, ids.GenerateTestNodeID(), signer0, height, block, chunkIDs)
+	require.NoError(err)
+
+	sk1, err := bls.NewSecretKey()
+	require.NoError(err)
+	signer1 := warp.NewSigner(sk1, constants.UnitTestID, constants.PlatformChainID)
+	summary1, err := NewSummary(constants.UnitTestID, constants.PlatformChainID, ids.GenerateTestNodeID(), signer1, height, block, chunkIDs)
+	require.NoError(err)
+
+	// Peers vote on summary IDs, so checkpoints created by different nodes
+	// must have the same ID.
+	require.Equal(summary0.ID(), summary1.ID())
+	require.NotEqual(summary0.Bytes(), summary1.Bytes())
+
+	err = summary1.Verify(constants.UnitTestID, constants.PlatformChainID, bls.PublicFromSecretKey(sk0))
+	require.ErrorIs(err, ErrInvalidSignature)
+}
+
+func TestParseSummaryGibberish(t *testing.T) {
+	_, err := ParseSummary([]byte{0, 1, 2, 3, 4, 5})
+	require.ErrorIs(t, err, codec.ErrUnknownVersion)
+}
@@ -202,6 +202,15 @@ type Client interface {
 	GetBlockchains(ctx context.Context, options ...rpc.Option) ([]APIBlockchain, error)
 	// IssueTx issues the transaction and returns its txID
 	IssueTx(ctx context.Context, tx []byte, options ...rpc.Option) (ids.ID, error)
+	// SimulateTx executes the signed or unsigned transaction against the
+	// preferred state without issuing it
+	SimulateTx(ctx context.Context, tx []byte, options ...rpc.Option) (*SimulateTxReply, error)
+	// GetTxFees returns the fees that transactions must pay to be included in
+	// the next block
+	GetTxFees(ctx context.Context, options ...rpc.Option) (*GetTxFeesReply, error)
+	// EstimateTxFee returns the fee that the signed or unsigned transaction
+	// must burn to be included in the next block
+	EstimateTxFee(ctx context.Context, tx []byte, options ...rpc.Option) (*EstimateTxFeeReply, error)
 	// GetTx returns the byte representation of the transaction corresponding to [txID]
 	GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error)
 	// GetTxStatus returns the status of the transaction corresponding to [txID]
@@ -216,6 +225,9 @@ type Client interface {
 		freq time.Duration,
 		options ...rpc.Option,
 	) (*GetTxStatusResponse, error)
+	// GetMempool returns the transactions waiting in the mempool and the
+	// transactions that were recently dropped
+	GetMempool(ctx context.Context, options ...rpc.Option) (*api.GetMempoolReply, error)
 	// GetStake returns the amount of nLUX that [addrs] have cumulatively
 	// staked on the Primary Network.
 	//
@@ -249,6 +261,12 @@ type Client interface {
 	//
 	// Deprecated: GetRewardUTXOs should be fetched from a dedicated indexer.
 	GetRewardUTXOs(context.Context, *api.GetTxArgs, ...rpc.Option) ([][]byte, error)
+	// EstimateReward returns the reward a staker would receive, based on the
+	// current supply, if it was rewarded at the end of its staking period
+	EstimateReward(ctx context.Context, args *EstimateRewardArgs, options ...rpc.Option) (*EstimateRewardReply, error)
+	// GetRewardHistory returns the rewards paid and forfeited by the stakers
+	// of a node, or owned by a reward address
+	GetRewardHistory(ctx context.Context, args *GetRewardHistoryArgs, options ...rpc.Option) (*GetRewardHistoryReply, error)
 	// GetTimestamp returns the current chain timestamp
 	GetTimestamp(ctx context.Context, options ...rpc.Option) (time.Time, error)
 	// GetValidatorsAt returns the weights of the validator set of a provided
@@ -259,10 +277,56 @@ type Client interface {
 		height uint64,
 		options ...rpc.Option,
 	) (map[ids.NodeID]*validators.GetValidatorOutput, error)
+	// GetValidatorSetDiffs returns the changes made to the validator set of
+	// a provided subnet by the blocks from [fromHeight] through [toHeight],
+	// along with the last height included. If [toHeight] is 0, the height of
+	// the last accepted block is used.
+	GetValidatorSetDiffs(
+		ctx context.Context,
+		subnetID ids.ID,
+		fromHeight uint64,
+		toHeight uint64,
+		options ...rpc.Option,
+	) ([]APIValidatorSetDiff, uint64, error)
 	// GetBlock returns the block with the given id.
 	GetBlock(ctx context.Context, blockID ids.ID, options ...rpc.Option) ([]byte, error)
 	// GetBlockByHeight returns the block at the given [height].
 	GetBlockByHeight(ctx context.Context, height uint64, options ...rpc.Option) ([]byte, error)
+	// GetUptimeProof returns a Warp message signed by [quorumPercent] of the
+	// primary network stake attesting that [nodeID] has been online for at
+	// least [uptime] seconds of its current staking period, along with the
+	// P-chain height of the validator set that signed it. If [uptime] is 0,
+	// the node's view of the uptime is used. If [quorumPercent] is 0, 67% is
+	// used.
+	GetUptimeProof(
+		ctx context.Context,
+		nodeID ids.NodeID,
+		uptime uint64,
+		quorumPercent uint64,
+		options ...rpc.Option,
+	) ([]byte, uint64, error)
+	// VerifyUptimeProof verifies that [proof] was signed by [quorumPercent] of
+	// the primary network stake at [pChainHeight] and returns the attestation.
+	// If [quorumPercent] is 0, 67% is used.
+	VerifyUptimeProof(
+		ctx context.Context,
+		proof []byte,
+		pChainHeight uint64,
+		quorumPercent uint64,
+		options ...rpc.Option,
+	) (*VerifyUptimeProofReply, error)
+	// GetValidatorUptimeHistory returns the per-day uptime of [nodeID] on
+	// [subnetID], as observed by the node, between [startTime] and [endTime].
+	// If [startTime] is the zero time, the history starts at the Unix epoch.
+	// If [endTime] is the zero time, the current time is used.
+	GetValidatorUptimeHistory(
+		ctx context.Context,
+		nodeID ids.NodeID,
+		subnetID ids.ID,
+		startTime time.Time,
+		endTime time.Time,
+		options ...rpc.Option,
+	) (*GetValidatorUptimeHistoryReply, error)
 }
 
 // Client implementation for interacting with the P Chain endpoint
@@ -718,6 +782,40 @@ func (c *client) IssueTx(ctx context.Context, txBytes []byte, options ...rpc.Opt
 	return res.TxID, err
 }
 
+func (c *client) SimulateTx(ctx context.Context, txBytes []byte, options ...rpc.Option) (*SimulateTxReply, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &SimulateTxReply{}
+	err = c.requester.SendRequest(ctx, "platform.simulateTx", &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetTxFees(ctx context.Context, options ...rpc.Option) (*GetTxFeesReply, error) {
+	res := &GetTxFeesReply{}
+	err := c.requester.SendRequest(ctx, "platform.getTxFees", struct{}{}, res, options...)
+	return res, err
+}
+
+func (c *client) EstimateTxFee(ctx context.Context, txBytes []byte, options ...rpc.Option) (*EstimateTxFeeReply, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &EstimateTxFeeReply{}
+	err = c.requester.SendRequest(ctx, "platform.estimateTxFee", &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error) {
 	res := &api.FormattedTx{}
 	err := c.requester.SendRequest(ctx, "platform.getTx", &api.GetTxArgs{
@@ -744,6 +842,12 @@ func (c *client) GetTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Op
 	return res, err
 }
 
+func (c *client) GetMempool(ctx context.Context, options ...rpc.Option) (*api.GetMempoolReply, error) {
+	res := &api.GetMempoolReply{}
+	err := c.requester.SendRequest(ctx, "platform.getMempool", struct{}{}, res, options...)
+	return res, err
+}
+
 func (c *client) AwaitTxDecided(ctx context.Context, txID ids.ID, freq time.Duration, options ...rpc.Option) (*GetTxStatusResponse, error) {
 	ticker := time.NewTicker(freq)
 	defer ticker.Stop()
@@ -849,6 +953,18 @@ func (c *client) GetRewardUTXOs(ctx context.Context, args *api.GetTxArgs, option
 	return utxos, err
 }
 
+func (c *client) EstimateReward(ctx context.Context, args *EstimateRewardArgs, options ...rpc.Option) (*EstimateRewardReply, error) {
+	res := &EstimateRewardReply{}
+	err := c.requester.SendRequest(ctx, "platform.estimateReward", args, res, options...)
+	return res, err
+}
+
+func (c *client) GetRewardHistory(ctx context.Context, args *GetRewardHistoryArgs, options ...rpc.Option) (*GetRewardHistoryReply, error) {
+	res := &GetRewardHistoryReply{}
+	err := c.requester.SendRequest(ctx, "platform.getRewardHistory", args, res, options...)
+	return res, err
+}
+
 func (c *client) GetTimestamp(ctx context.Context, options ...rpc.Option) (time.Time, error) {
 	res := &GetTimestampReply{}
 	err := c.requester.SendRequest(ctx, "platform.getTimestamp", struct{}{}, res, options...)
@@ -869,6 +985,22 @@ func (c *client) GetValidatorsAt(
 	return res.Validators, err
 }
 
+func (c *client) GetValidatorSetDiffs(
+	ctx context.Context,
+	subnetID ids.ID,
+	fromHeight uint64,
+	toHeight uint64,
+	options ...rpc.Option,
+) ([]APIValidatorSetDiff, uint64, error) {
+	res := &GetValidatorSetDiffsReply{}
+	err := c.requester.SendRequest(ctx, "platform.getValidatorSetDiffs", &GetValidatorSetDiffsArgs{
+		SubnetID:   subnetID,
+		FromHeight: json.Uint64(fromHeight),
+		ToHeight:   json.Uint64(toHeight),
+	}, res, options...)
+	return res.Diffs, uint64(res.ToHeight), err
+}
+
 func (c *client) GetBlock(ctx context.Context, blockID ids.ID, options ...rpc.Option) ([]byte, error) {
 	res := &api.FormattedBlock{}
 	if err := c.requester.SendRequest(ctx, "platform.getBlock", &api.GetBlockArgs{
@@ -891,3 +1023,68 @@ func (c *client) GetBlockByHeight(ctx context.Context, height uint64, options ..
 	}
 	return formatting.Decode(res.Encoding, res.Block)
 }
+
+func (c *client) GetUptimeProof(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	uptime uint64,
+	quorumPercent uint64,
+	options ...rpc.Option,
+) ([]byte, uint64, error) {
+	res := &GetUptimeProofReply{}
+	err := c.requester.SendRequest(ctx, "platform.getUptimeProof", &GetUptimeProofArgs{
+		NodeID:        nodeID,
+		Uptime:        json.Uint64(uptime),
+		QuorumPercent: json.Uint64(quorumPercent),
+		Encoding:      formatting.HexNC,
+	}, res, options...)
+	if err != nil {
+		return nil, 0, err
+	}
+	proof, err := formatting.Decode(res.Encoding, res.Message)
+	return proof, uint64(res.PChainHeight), err
+}
+
+func (c *client) VerifyUptimeProof(
+	ctx context.Context,
+	proof []byte,
+	pChainHeight uint64,
+	quorumPercent uint64,
+	options ...rpc.Option,
+) (*VerifyUptimeProofReply, error) {
+	proofStr, err := formatting.Encode(formatting.HexNC, proof)
+	if err != nil {
+		return nil, err
+	}
+	res := &VerifyUptimeProofReply{}
+	err = c.requester.SendRequest(ctx, "platform.verifyUptimeProof", &VerifyUptimeProofArgs{
+		Message:       proofStr,
+		Encoding:      formatting.HexNC,
+		PChainHeight:  json.Uint64(pChainHeight),
+		QuorumPercent: json.Uint64(quorumPercent),
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetValidatorUptimeHistory(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	subnetID ids.ID,
+	startTime time.Time,
+	endTime time.Time,
+	options ...rpc.Option,
+) (*GetValidatorUptimeHistoryReply, error) {
+	args := &GetValidatorUptimeHistoryArgs{
+		NodeID:   nodeID,
+		SubnetID: subnetID,
+	}
+	if !startTime.IsZero() {
+		args.StartTime = json.Uint64(startTime.Unix())
+	}
+	if !endTime.IsZero() {
+		args.EndTime = json.Uint64(endTime.Unix())
+	}
+	res := &GetValidatorUptimeHistoryReply{}
+	err := c.requester.SendRequest(ctx, "platform.getValidatorUptimeHistory", args, res, options...)
+	return res, err
+}
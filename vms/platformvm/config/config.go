@@ -14,6 +14,7 @@ import (
 	"github.com/luxdefi/node/utils/set"
 	"github.com/luxdefi/node/vms/platformvm/reward"
 	"github.com/luxdefi/node/vms/platformvm/txs"
+	"github.com/luxdefi/node/vms/platformvm/txs/fee"
 )
 
 // Struct collecting all foundational parameters of PlatformVM
@@ -107,6 +108,24 @@ type Config struct {
 	// Time of the Durango network upgrade
 	DurangoTime time.Time
 
+	// Time that continuous validators can be added and stopped
+	ContinuousStakingTime time.Time
+
+	// Time that subnets can be converted so that their validators are
+	// managed by a validator manager
+	SubnetConversionTime time.Time
+
+	// Time that pooled delegators can be added
+	PooledDelegationTime time.Time
+
+	// Time that the dynamic fee model is activated
+	DynamicFeesTime time.Time
+
+	// DynamicFeeConfig, if provided, charges transactions accepted after
+	// [DynamicFeesTime] based on their complexity and on the fullness of
+	// recently accepted blocks. If nil, only the static fees are charged.
+	DynamicFeeConfig *fee.DynamicConfig
+
 	// UseCurrentHeight forces [GetMinimumHeight] to return the current height
 	// of the P-Chain instead of the oldest block in the [recentlyAccepted]
 	// window.
@@ -137,6 +156,22 @@ func (c *Config) IsDurangoActivated(timestamp time.Time) bool {
 	return !timestamp.Before(c.DurangoTime)
 }
 
+func (c *Config) IsContinuousStakingActivated(timestamp time.Time) bool {
+	return !timestamp.Before(c.ContinuousStakingTime)
+}
+
+func (c *Config) IsSubnetConversionActivated(timestamp time.Time) bool {
+	return !timestamp.Before(c.SubnetConversionTime)
+}
+
+func (c *Config) IsPooledDelegationActivated(timestamp time.Time) bool {
+	return !timestamp.Before(c.PooledDelegationTime)
+}
+
+func (c *Config) IsDynamicFeesActivated(timestamp time.Time) bool {
+	return c.DynamicFeeConfig != nil && !timestamp.Before(c.DynamicFeesTime)
+}
+
 func (c *Config) GetCreateBlockchainTxFee(timestamp time.Time) uint64 {
 	if c.IsApricotPhase3Activated(timestamp) {
 		return c.CreateBlockchainTxFee
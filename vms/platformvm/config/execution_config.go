@@ -19,6 +19,9 @@ var DefaultExecutionConfig = ExecutionConfig{
 	BlockIDCacheSize:             8192,
 	FxOwnerCacheSize:             4 * units.MiB,
 	ChecksumsEnabled:             false,
+	CheckpointInterval:           0,
+	CheckpointSyncEnabled:        false,
+	AdminAPIEnabled:              false,
 }
 
 // ExecutionConfig provides execution parameters of PlatformVM
@@ -32,6 +35,20 @@ type ExecutionConfig struct {
 	BlockIDCacheSize             int  `json:"block-id-cache-size"`
 	FxOwnerCacheSize             int  `json:"fx-owner-cache-size"`
 	ChecksumsEnabled             bool `json:"checksums-enabled"`
+	// CheckpointInterval is the number of blocks between state checkpoints
+	// that this node makes available to peers. If 0, no checkpoints are made.
+	// Checkpoints are signed with the node's BLS key, and peers only keep a
+	// checkpoint signed by a primary network validator.
+	CheckpointInterval uint64 `json:"checkpoint-interval"`
+	// CheckpointSyncEnabled allows a node that is behind the network to start
+	// from a checkpoint agreed upon by the network rather than executing every
+	// block since its last accepted block. The blocks below the checkpoint
+	// are then fetched from peers in the background. They aren't added to the
+	// index of accepted blocks.
+	CheckpointSyncEnabled bool `json:"checkpoint-sync-enabled"`
+	// AdminAPIEnabled exposes the admin API of the chain, which allows the
+	// node operator to remove txs from the mempool.
+	AdminAPIEnabled bool `json:"admin-api-enabled"`
 }
 
 // GetExecutionConfig returns an ExecutionConfig
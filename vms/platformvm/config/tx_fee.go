@@ -0,0 +1,164 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"time"
+
+	"github.com/luxdefi/node/utils/constants"
+	"github.com/luxdefi/node/utils/math"
+	"github.com/luxdefi/node/vms/platformvm/txs"
+	"github.com/luxdefi/node/vms/platformvm/txs/fee"
+)
+
+var _ txs.Visitor = (*staticFeeCalculator)(nil)
+
+// GetStaticTxFee returns the fee configured for the type of [tx] at
+// [timestamp], ignoring the dynamic fee model.
+func (c *Config) GetStaticTxFee(tx txs.UnsignedTx, timestamp time.Time) uint64 {
+	calculator := staticFeeCalculator{
+		config:    c,
+		timestamp: timestamp,
+	}
+	// The static fee calculator never errors.
+	_ = tx.Visit(&calculator)
+	return calculator.fee
+}
+
+// GetTxFee returns the fee [tx] must burn to be accepted at [timestamp] when
+// the last accepted gas price is [gasPrice].
+//
+// Once the dynamic fee model is activated, the fee is the larger of the static
+// fee and the cost of the gas consumed by [tx].
+func (c *Config) GetTxFee(tx *txs.Tx, timestamp time.Time, gasPrice uint64) (uint64, error) {
+	staticFee := c.GetStaticTxFee(tx.Unsigned, timestamp)
+	if !c.IsDynamicFeesActivated(timestamp) {
+		return staticFee, nil
+	}
+
+	complexity, err := fee.TxComplexity(tx)
+	if err != nil {
+		return 0, err
+	}
+	dynamicFee, err := c.DynamicFeeConfig.Fee(complexity, gasPrice)
+	if err != nil {
+		return 0, err
+	}
+	return math.Max(staticFee, dynamicFee), nil
+}
+
+type staticFeeCalculator struct {
+	config    *Config
+	timestamp time.Time
+
+	// outputs of visitor execution
+	fee uint64
+}
+
+func (c *staticFeeCalculator) AddValidatorTx(*txs.AddValidatorTx) error {
+	c.fee = c.config.AddPrimaryNetworkValidatorFee
+	return nil
+}
+
+func (c *staticFeeCalculator) AddSubnetValidatorTx(*txs.AddSubnetValidatorTx) error {
+	c.fee = c.config.AddSubnetValidatorFee
+	return nil
+}
+
+func (c *staticFeeCalculator) AddDelegatorTx(*txs.AddDelegatorTx) error {
+	c.fee = c.config.AddPrimaryNetworkDelegatorFee
+	return nil
+}
+
+func (c *staticFeeCalculator) CreateChainTx(*txs.CreateChainTx) error {
+	c.fee = c.config.GetCreateBlockchainTxFee(c.timestamp)
+	return nil
+}
+
+func (c *staticFeeCalculator) CreateSubnetTx(*txs.CreateSubnetTx) error {
+	c.fee = c.config.GetCreateSubnetTxFee(c.timestamp)
+	return nil
+}
+
+func (c *staticFeeCalculator) ImportTx(*txs.ImportTx) error {
+	c.fee = c.config.TxFee
+	return nil
+}
+
+func (c *staticFeeCalculator) ExportTx(*txs.ExportTx) error {
+	c.fee = c.config.TxFee
+	return nil
+}
+
+func (c *staticFeeCalculator) AdvanceTimeTx(*txs.AdvanceTimeTx) error {
+	c.fee = 0
+	return nil
+}
+
+func (c *staticFeeCalculator) RewardValidatorTx(*txs.RewardValidatorTx) error {
+	c.fee = 0
+	return nil
+}
+
+func (c *staticFeeCalculator) RemoveSubnetValidatorTx(*txs.RemoveSubnetValidatorTx) error {
+	c.fee = c.config.TxFee
+	return nil
+}
+
+func (c *staticFeeCalculator) TransformSubnetTx(*txs.TransformSubnetTx) error {
+	c.fee = c.config.TransformSubnetTxFee
+	return nil
+}
+
+func (c *staticFeeCalculator) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessValidatorTx) error {
+	if tx.Subnet != constants.PrimaryNetworkID {
+		c.fee = c.config.AddSubnetValidatorFee
+	} else {
+		c.fee = c.config.AddPrimaryNetworkValidatorFee
+	}
+	return nil
+}
+
+func (c *staticFeeCalculator) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
+	if tx.Subnet != constants.PrimaryNetworkID {
+		c.fee = c.config.AddSubnetDelegatorFee
+	} else {
+		c.fee = c.config.AddPrimaryNetworkDelegatorFee
+	}
+	return nil
+}
+
+func (c *staticFeeCalculator) TransferSubnetOwnershipTx(*txs.TransferSubnetOwnershipTx) error {
+	c.fee = c.config.TxFee
+	return nil
+}
+
+func (c *staticFeeCalculator) BaseTx(*txs.BaseTx) error {
+	c.fee = c.config.TxFee
+	return nil
+}
+
+func (c *staticFeeCalculator) AddContinuousValidatorTx(*txs.AddContinuousValidatorTx) error {
+	c.fee = c.config.AddPrimaryNetworkValidatorFee
+	return nil
+}
+
+func (c *staticFeeCalculator) StopContinuousValidatorTx(*txs.StopContinuousValidatorTx) error {
+	c.fee = c.config.TxFee
+	return nil
+}
+
+func (c *staticFeeCalculator) ConvertSubnetTx(*txs.ConvertSubnetTx) error {
+	c.fee = c.config.TransformSubnetTxFee
+	return nil
+}
+
+func (c *staticFeeCalculator) SetSubnetValidatorWeightTx(*txs.SetSubnetValidatorWeightTx) error {
+	c.fee = c.config.AddSubnetValidatorFee
+	return nil
+}
+
+func (c *staticFeeCalculator) AddPooledDelegatorTx(tx *txs.AddPooledDelegatorTx) error {
+	return c.AddPermissionlessDelegatorTx(&tx.AddPermissionlessDelegatorTx)
+}
@@ -0,0 +1,168 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/constants"
+	"github.com/luxdefi/node/vms/components/lux"
+	"github.com/luxdefi/node/vms/platformvm/txs"
+	"github.com/luxdefi/node/vms/platformvm/txs/fee"
+)
+
+func TestGetStaticTxFee(t *testing.T) {
+	ap3Time := time.Unix(1_000, 0)
+	c := &Config{
+		TxFee:                         1,
+		CreateAssetTxFee:              2,
+		CreateSubnetTxFee:             3,
+		TransformSubnetTxFee:          4,
+		CreateBlockchainTxFee:         5,
+		AddPrimaryNetworkValidatorFee: 6,
+		AddPrimaryNetworkDelegatorFee: 7,
+		AddSubnetValidatorFee:         8,
+		AddSubnetDelegatorFee:         9,
+		ApricotPhase3Time:             ap3Time,
+	}
+	subnetID := ids.GenerateTestID()
+
+	tests := []struct {
+		name        string
+		tx          txs.UnsignedTx
+		timestamp   time.Time
+		expectedFee uint64
+	}{
+		{
+			name:        "BaseTx",
+			tx:          &txs.BaseTx{},
+			timestamp:   ap3Time,
+			expectedFee: 1,
+		},
+		{
+			name:        "CreateSubnetTx pre-AP3",
+			tx:          &txs.CreateSubnetTx{},
+			timestamp:   ap3Time.Add(-time.Second),
+			expectedFee: 2,
+		},
+		{
+			name:        "CreateSubnetTx",
+			tx:          &txs.CreateSubnetTx{},
+			timestamp:   ap3Time,
+			expectedFee: 3,
+		},
+		{
+			name:        "TransformSubnetTx",
+			tx:          &txs.TransformSubnetTx{},
+			timestamp:   ap3Time,
+			expectedFee: 4,
+		},
+		{
+			name:        "CreateChainTx",
+			tx:          &txs.CreateChainTx{},
+			timestamp:   ap3Time,
+			expectedFee: 5,
+		},
+		{
+			name:        "AddValidatorTx",
+			tx:          &txs.AddValidatorTx{},
+			timestamp:   ap3Time,
+			expectedFee: 6,
+		},
+		{
+			name: "AddPermissionlessValidatorTx primary network",
+			tx: &txs.AddPermissionlessValidatorTx{
+				Subnet: constants.PrimaryNetworkID,
+			},
+			timestamp:   ap3Time,
+			expectedFee: 6,
+		},
+		{
+			name: "AddPermissionlessDelegatorTx primary network",
+			tx: &txs.AddPermissionlessDelegatorTx{
+				Subnet: constants.PrimaryNetworkID,
+			},
+			timestamp:   ap3Time,
+			expectedFee: 7,
+		},
+		{
+			name:        "AddSubnetValidatorTx",
+			tx:          &txs.AddSubnetValidatorTx{},
+			timestamp:   ap3Time,
+			expectedFee: 8,
+		},
+		{
+			name: "AddPermissionlessValidatorTx subnet",
+			tx: &txs.AddPermissionlessValidatorTx{
+				Subnet: subnetID,
+			},
+			timestamp:   ap3Time,
+			expectedFee: 8,
+		},
+		{
+			name: "AddPermissionlessDelegatorTx subnet",
+			tx: &txs.AddPermissionlessDelegatorTx{
+				Subnet: subnetID,
+			},
+			timestamp:   ap3Time,
+			expectedFee: 9,
+		},
+		{
+			name:        "RewardValidatorTx",
+			tx:          &txs.RewardValidatorTx{},
+			timestamp:   ap3Time,
+			expectedFee: 0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expectedFee, c.GetStaticTxFee(test.tx, test.timestamp))
+		})
+	}
+}
+
+func TestGetTxFee(t *testing.T) {
+	require := require.New(t)
+
+	dynamicFeesTime := time.Unix(1_000, 0)
+	c := &Config{
+		TxFee:           1_000,
+		DynamicFeesTime: dynamicFeesTime,
+		DynamicFeeConfig: &fee.DynamicConfig{
+			Weights: fee.Dimensions{
+				Bandwidth: 1,
+			},
+			MinGasPrice:               1,
+			MaxGasPrice:               1_000,
+			TargetGasPerBlock:         1_000,
+			GasPriceChangeDenominator: 8,
+		},
+	}
+
+	tx, err := txs.NewSigned(&txs.BaseTx{BaseTx: lux.BaseTx{
+		Memo: make([]byte, 100),
+	}}, txs.Codec, nil)
+	require.NoError(err)
+	size := uint64(len(tx.Bytes()))
+	require.Less(size, c.TxFee)
+
+	// Before the upgrade, only the static fee is charged.
+	txFee, err := c.GetTxFee(tx, dynamicFeesTime.Add(-time.Second), 1_000)
+	require.NoError(err)
+	require.Equal(c.TxFee, txFee)
+
+	// The static fee is charged while gas is cheap.
+	txFee, err = c.GetTxFee(tx, dynamicFeesTime, 1)
+	require.NoError(err)
+	require.Equal(c.TxFee, txFee)
+
+	// The gas is charged once it costs more than the static fee.
+	txFee, err = c.GetTxFee(tx, dynamicFeesTime, 10)
+	require.NoError(err)
+	require.Equal(10*size, txFee)
+}
@@ -29,7 +29,12 @@ type txMetrics struct {
 	numAddPermissionlessValidatorTxs,
 	numAddPermissionlessDelegatorTxs,
 	numTransferSubnetOwnershipTxs,
-	numBaseTxs prometheus.Counter
+	numBaseTxs,
+	numAddContinuousValidatorTxs,
+	numStopContinuousValidatorTxs,
+	numConvertSubnetTxs,
+	numSetSubnetValidatorWeightTxs,
+	numAddPooledDelegatorTxs prometheus.Counter
 }
 
 func newTxMetrics(
@@ -53,6 +58,11 @@ func newTxMetrics(
 		numAddPermissionlessDelegatorTxs: newTxMetric(namespace, "add_permissionless_delegator", registerer, &errs),
 		numTransferSubnetOwnershipTxs:    newTxMetric(namespace, "transfer_subnet_ownership", registerer, &errs),
 		numBaseTxs:                       newTxMetric(namespace, "base", registerer, &errs),
+		numAddContinuousValidatorTxs:     newTxMetric(namespace, "add_continuous_validator", registerer, &errs),
+		numStopContinuousValidatorTxs:    newTxMetric(namespace, "stop_continuous_validator", registerer, &errs),
+		numConvertSubnetTxs:              newTxMetric(namespace, "convert_subnet", registerer, &errs),
+		numSetSubnetValidatorWeightTxs:   newTxMetric(namespace, "set_subnet_validator_weight", registerer, &errs),
+		numAddPooledDelegatorTxs:         newTxMetric(namespace, "add_pooled_delegator", registerer, &errs),
 	}
 	return m, errs.Err
 }
@@ -146,3 +156,28 @@ func (m *txMetrics) BaseTx(*txs.BaseTx) error {
 	m.numBaseTxs.Inc()
 	return nil
 }
+
+func (m *txMetrics) AddContinuousValidatorTx(*txs.AddContinuousValidatorTx) error {
+	m.numAddContinuousValidatorTxs.Inc()
+	return nil
+}
+
+func (m *txMetrics) StopContinuousValidatorTx(*txs.StopContinuousValidatorTx) error {
+	m.numStopContinuousValidatorTxs.Inc()
+	return nil
+}
+
+func (m *txMetrics) ConvertSubnetTx(*txs.ConvertSubnetTx) error {
+	m.numConvertSubnetTxs.Inc()
+	return nil
+}
+
+func (m *txMetrics) SetSubnetValidatorWeightTx(*txs.SetSubnetValidatorWeightTx) error {
+	m.numSetSubnetValidatorWeightTxs.Inc()
+	return nil
+}
+
+func (m *txMetrics) AddPooledDelegatorTx(*txs.AddPooledDelegatorTx) error {
+	m.numAddPooledDelegatorTxs.Inc()
+	return nil
+}
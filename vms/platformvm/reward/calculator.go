@@ -85,3 +85,33 @@ func Split(totalAmount uint64, shares uint32) (uint64, uint64) {
 	amountFromShares := totalAmount - remainderAmount
 	return amountFromShares, remainderAmount
 }
+
+// SplitProRata splits [totalAmount] between [weights], pro rata to each
+// weight. It returns the amount of each weight, rounded down, and the
+// remainder of [totalAmount] that couldn't be split evenly.
+//
+// Invariant: The sum of [weights] is non-zero and doesn't overflow.
+func SplitProRata(totalAmount uint64, weights []uint64) ([]uint64, uint64) {
+	totalWeight := new(big.Int)
+	for _, weight := range weights {
+		totalWeight.Add(totalWeight, new(big.Int).SetUint64(weight))
+	}
+
+	var (
+		bigTotalAmount = new(big.Int).SetUint64(totalAmount)
+		amounts        = make([]uint64, len(weights))
+		remainder      = totalAmount
+		amount         = new(big.Int)
+	)
+	for i, weight := range weights {
+		amount.SetUint64(weight)
+		amount.Mul(amount, bigTotalAmount)
+		amount.Div(amount, totalWeight)
+
+		// Because the weight is at most the total weight, the amount is at
+		// most [totalAmount].
+		amounts[i] = amount.Uint64()
+		remainder -= amounts[i]
+	}
+	return amounts, remainder
+}
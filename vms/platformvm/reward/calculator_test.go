@@ -233,3 +233,58 @@ func TestSplit(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitProRata(t *testing.T) {
+	tests := []struct {
+		name              string
+		amount            uint64
+		weights           []uint64
+		expectedAmounts   []uint64
+		expectedRemainder uint64
+	}{
+		{
+			name:              "single weight",
+			amount:            1000,
+			weights:           []uint64{7},
+			expectedAmounts:   []uint64{1000},
+			expectedRemainder: 0,
+		},
+		{
+			name:              "even split",
+			amount:            1000,
+			weights:           []uint64{1, 3},
+			expectedAmounts:   []uint64{250, 750},
+			expectedRemainder: 0,
+		},
+		{
+			name:              "rounded down",
+			amount:            1000,
+			weights:           []uint64{1, 1, 1},
+			expectedAmounts:   []uint64{333, 333, 333},
+			expectedRemainder: 1,
+		},
+		{
+			name:              "no amount",
+			amount:            0,
+			weights:           []uint64{1, 2},
+			expectedAmounts:   []uint64{0, 0},
+			expectedRemainder: 0,
+		},
+		{
+			name:              "large amounts",
+			amount:            math.MaxUint64,
+			weights:           []uint64{math.MaxUint64 / 2, math.MaxUint64 / 2},
+			expectedAmounts:   []uint64{math.MaxUint64 / 2, math.MaxUint64 / 2},
+			expectedRemainder: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			amounts, remainder := SplitProRata(test.amount, test.weights)
+			require.Equal(test.expectedAmounts, amounts)
+			require.Equal(test.expectedRemainder, remainder)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/luxdefi/node/database"
+	"github.com/luxdefi/node/vms/platformvm/block"
+	"github.com/luxdefi/node/vms/platformvm/checkpoint"
+	"github.com/luxdefi/node/vms/platformvm/state"
+	"github.com/luxdefi/node/vms/platformvm/txs"
+
+	snowmanblock "github.com/luxdefi/node/snow/engine/snowman/block"
+)
+
+var (
+	_ snowmanblock.RollbackableVM = (*VM)(nil)
+
+	errRollbackAboveLastAccepted = errors.New("rollback height is above the last accepted height")
+	errCheckpointNotAccepted     = errors.New("checkpoint block was not accepted")
+)
+
+// VerifyRollback returns nil if this node has a checkpoint of the state as of
+// [height]. The state is only rolled back to checkpoints, so the checkpoint
+// interval determines which heights can be rolled back to.
+//
+// Blocks containing atomic txs can't be rolled back, as the shared memory
+// operations they performed may already have been observed by the other
+// chain.
+func (vm *VM) VerifyRollback(_ context.Context, height uint64) error {
+	_, err := vm.getRollbackCheckpoint(height)
+	return err
+}
+
+// Rollback replaces the state with the checkpoint at [height] and removes the
+// blocks accepted after [height]. The uptime history of this node is kept.
+func (vm *VM) Rollback(_ context.Context, height uint64) error {
+	summary, err := vm.getRollbackCheckpoint(height)
+	if err != nil {
+		return err
+	}
+	if summary == nil {
+		return nil
+	}
+
+	vm.Builder.Shutdown()
+
+	if err := vm.removeValidators(); err != nil {
+		return err
+	}
+	if err := vm.state.Close(); err != nil {
+		return err
+	}
+	if err := vm.checkpoints.PutRollback(height); err != nil {
+		return err
+	}
+	if err := vm.writeRollback(height); err != nil {
+		return err
+	}
+	if err := vm.initState(context.TODO(), reloadRegisterer{vm.registerer}); err != nil {
+		return err
+	}
+
+	vm.ctx.Log.Info("rolled back platformvm",
+		zap.Uint64("height", height),
+		zap.Stringer("lastAcceptedID", vm.state.GetLastAccepted()),
+	)
+	return nil
+}
+
+// getRollbackCheckpoint returns the checkpoint that the state should be
+// replaced with to roll back to [height]. If the last accepted block is at
+// [height], nil is returned.
+func (vm *VM) getRollbackCheckpoint(height uint64) (*checkpoint.Summary, error) {
+	switch {
+	case height > vm.lastCommittedHeight:
+		return nil, fmt.Errorf("%w: %d > %d", errRollbackAboveLastAccepted, height, vm.lastCommittedHeight)
+	case height == vm.lastCommittedHeight:
+		return nil, nil
+	case !vm.pruned.Get():
+		return nil, fmt.Errorf("%w: state is being pruned", snowmanblock.ErrRollbackNotSupported)
+	}
+
+	summary, err := vm.checkpoints.GetSummary(height)
+	if err == database.ErrNotFound {
+		return nil, fmt.Errorf("%w: no checkpoint at height %d", snowmanblock.ErrRollbackNotSupported, height)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure that the checkpoint describes the chain that this node
+	// accepted.
+	blk, err := block.Parse(block.Codec, summary.Block)
+	if err != nil {
+		return nil, err
+	}
+	acceptedID, err := vm.state.GetBlockIDAtHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	if blkID := blk.ID(); blkID != acceptedID {
+		return nil, fmt.Errorf("%w: checkpoint has block %s but %s was accepted at height %d",
+			errCheckpointNotAccepted,
+			blkID,
+			acceptedID,
+			height,
+		)
+	}
+	if err := vm.verifyNoAtomicTxsAbove(height); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// verifyNoAtomicTxsAbove returns an error if a block accepted after [height]
+// contains an atomic tx.
+func (vm *VM) verifyNoAtomicTxsAbove(height uint64) error {
+	for blkHeight := height + 1; blkHeight <= vm.lastCommittedHeight; blkHeight++ {
+		blkID, err := vm.state.GetBlockIDAtHeight(blkHeight)
+		if err != nil {
+			return err
+		}
+		blk, err := vm.state.GetStatelessBlock(blkID)
+		if err != nil {
+			return err
+		}
+		for _, tx := range blk.Txs() {
+			switch tx.Unsigned.(type) {
+			case *txs.ImportTx, *txs.ExportTx:
+				return fmt.Errorf("%w: block %s at height %d contains atomic tx %s",
+					snowmanblock.ErrRollbackNotSupported,
+					blkID,
+					blkHeight,
+					tx.ID(),
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// writeRollback replaces the state in [vm.db] with the checkpoint at [height]
+// and removes every block and checkpoint after it. If the node shuts down part
+// way through, the rollback is retried on startup.
+func (vm *VM) writeRollback(height uint64) error {
+	summary, err := vm.checkpoints.GetSummary(height)
+	if err != nil {
+		return err
+	}
+	if err := vm.replaceState(summary, isCheckpointKey); err != nil {
+		return err
+	}
+	if err := state.DeleteBlocksAbove(vm.db, height); err != nil {
+		return err
+	}
+	if err := vm.checkpoints.DeleteSummariesAbove(height); err != nil {
+		return err
+	}
+	if err := vm.checkpoints.Prune(numCheckpointsToKeep); err != nil {
+		return err
+	}
+	return vm.checkpoints.DeleteRollback()
+}
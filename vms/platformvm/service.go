@@ -41,6 +41,9 @@ import (
 	"github.com/luxdefi/node/vms/platformvm/txs"
 	"github.com/luxdefi/node/vms/platformvm/txs/builder"
 	"github.com/luxdefi/node/vms/platformvm/txs/executor"
+	"github.com/luxdefi/node/vms/platformvm/txs/fee"
+	"github.com/luxdefi/node/vms/platformvm/uptimeproof"
+	"github.com/luxdefi/node/vms/platformvm/warp"
 	"github.com/luxdefi/node/vms/secp256k1fx"
 
 	safemath "github.com/luxdefi/node/utils/math"
@@ -61,6 +64,17 @@ const (
 	// Note: Staker attributes cache should be large enough so that no evictions
 	// happen when the API loops through all stakers.
 	stakerAttributesCacheSize = 100_000
+
+	// Percentage of the primary network stake that must sign an uptime proof
+	// if the caller doesn't specify one
+	defaultUptimeProofQuorumPercent = 67
+
+	// Maximum amount of time to wait for validators to sign an uptime proof
+	uptimeProofTimeout = 10 * time.Second
+
+	// Max number of heights whose validator set diffs can be fetched by a
+	// single call to GetValidatorSetDiffs
+	maxValidatorSetDiffHeights = 1024
 )
 
 var (
@@ -80,6 +94,11 @@ var (
 	errMissingPrivateKey        = errors.New("argument 'privateKey' not given")
 	errStartAfterEndTime        = errors.New("start time must be before end time")
 	errStartTimeInThePast       = errors.New("start time in the past")
+	errInvalidQuorumPercent     = errors.New("argument 'quorumPercent' must be between 0 and 100, inclusive")
+	errChainNotBootstrapped     = errors.New("chain is not bootstrapped")
+	errNoStakeAmount            = errors.New("argument 'stakeAmount' must be > 0")
+	errInvalidStakeDuration     = errors.New("argument 'duration' is outside of the allowed stake durations")
+	errNodeIDXorRewardAddress   = errors.New("exactly one of 'nodeID' and 'rewardAddress' must be provided")
 )
 
 // Service defines the API calls that can be made to the platform chain
@@ -742,7 +761,12 @@ func (s *Service) loadStakerTxAttributes(txID ids.ID) (*stakerAttributes, error)
 	switch stakerTx := tx.Unsigned.(type) {
 	case txs.ValidatorTx:
 		var pop *signer.ProofOfPossession
-		if staker, ok := stakerTx.(*txs.AddPermissionlessValidatorTx); ok {
+		switch staker := stakerTx.(type) {
+		case *txs.AddPermissionlessValidatorTx:
+			if s, ok := staker.Signer.(*signer.ProofOfPossession); ok {
+				pop = s
+			}
+		case *txs.AddContinuousValidatorTx:
 			if s, ok := staker.Signer.(*signer.ProofOfPossession); ok {
 				pop = s
 			}
@@ -851,7 +875,7 @@ func (s *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentValidato
 
 		switch currentStaker.Priority {
 		case txs.PrimaryNetworkValidatorCurrentPriority, txs.SubnetPermissionlessValidatorCurrentPriority:
-			attr, err := s.loadStakerTxAttributes(currentStaker.TxID)
+			attr, err := s.loadStakerTxAttributes(currentStaker.StakerTxID())
 			if err != nil {
 				return err
 			}
@@ -903,7 +927,7 @@ func (s *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentValidato
 			// If we are handling multiple nodeIDs, we don't return the
 			// delegator information.
 			if numNodeIDs == 1 {
-				attr, err := s.loadStakerTxAttributes(currentStaker.TxID)
+				attr, err := s.loadStakerTxAttributes(currentStaker.StakerTxID())
 				if err != nil {
 					return err
 				}
@@ -2181,6 +2205,335 @@ func (s *Service) IssueTx(req *http.Request, args *api.FormattedTx, response *ap
 	return nil
 }
 
+// parseFormattedTx parses a signed tx, or an unsigned tx that is given
+// placeholder credentials. The returned bool is true if the tx is signed.
+func parseFormattedTx(args *api.FormattedTx) (*txs.Tx, bool, error) {
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return nil, false, fmt.Errorf("problem decoding transaction: %w", err)
+	}
+
+	tx, err := txs.Parse(txs.Codec, txBytes)
+	if err == nil {
+		return tx, true, nil
+	}
+
+	var utx txs.UnsignedTx
+	if _, unsignedErr := txs.Codec.Unmarshal(txBytes, &utx); unsignedErr != nil {
+		return nil, false, fmt.Errorf("couldn't parse tx: %w", err)
+	}
+	tx, err = executor.NewUnsignedTx(utx)
+	if err != nil {
+		return nil, false, fmt.Errorf("couldn't parse unsigned tx: %w", err)
+	}
+	return tx, false, nil
+}
+
+// SimulatedUTXO is a UTXO consumed or produced by a simulated tx
+type SimulatedUTXO struct {
+	// Chain that holds the UTXO
+	ChainID ids.ID      `json:"chainID"`
+	UTXOID  string      `json:"utxoID"`
+	AssetID ids.ID      `json:"assetID"`
+	Amount  json.Uint64 `json:"amount"`
+	// The encoded UTXO
+	UTXO string `json:"utxo"`
+}
+
+// SimulatedStakerChange is a staker added to, or removed from, the current or
+// pending staker set by a simulated tx
+type SimulatedStakerChange struct {
+	Added     bool        `json:"added"`
+	Current   bool        `json:"current"`
+	Delegator bool        `json:"delegator"`
+	TxID      ids.ID      `json:"txID"`
+	NodeID    ids.NodeID  `json:"nodeID"`
+	SubnetID  ids.ID      `json:"subnetID"`
+	Weight    json.Uint64 `json:"weight"`
+	StartTime json.Uint64 `json:"startTime"`
+	EndTime   json.Uint64 `json:"endTime"`
+}
+
+// SimulateTxReply is the response from SimulateTx
+type SimulateTxReply struct {
+	// ID of the tx. If the tx isn't signed, the ID is computed with empty
+	// signatures and changes once the tx is signed.
+	TxID   ids.ID `json:"txID"`
+	Signed bool   `json:"signed"`
+	// Reason that the tx would be dropped. If empty, the tx is valid.
+	Error string `json:"error,omitempty"`
+	// UTXOs consumed from the P-chain, or imported from another chain
+	Consumed []SimulatedUTXO `json:"consumed"`
+	// UTXOs produced on the P-chain, or exported to another chain
+	Produced      []SimulatedUTXO         `json:"produced"`
+	StakerChanges []SimulatedStakerChange `json:"stakerChanges"`
+	// Asset ID --> amount burned by the tx
+	Burned   map[ids.ID]json.Uint64 `json:"burned"`
+	Encoding formatting.Encoding    `json:"encoding"`
+}
+
+// SimulateTx executes a signed or unsigned tx against the preferred state, as
+// if it was included in the next block, without issuing it. The signatures of
+// unsigned txs aren't verified, but the number of signatures they require is.
+func (s *Service) SimulateTx(_ *http.Request, args *api.FormattedTx, reply *SimulateTxReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "simulateTx"),
+	)
+
+	tx, signed, err := parseFormattedTx(args)
+	if err != nil {
+		return err
+	}
+
+	backend := s.vm.txExecutorBackend
+	if !signed {
+		backend = s.vm.unsignedTxExecutorBackend
+	}
+	reply.Signed = signed
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	if !s.vm.bootstrapped.Get() {
+		return errChainNotBootstrapped
+	}
+
+	reply.TxID = tx.ID()
+	reply.Encoding = args.Encoding
+	simulation, err := executor.SimulateTx(backend, s.vm.manager, s.vm.manager.Preferred(), tx)
+	if err != nil {
+		reply.Error = err.Error()
+		return nil
+	}
+
+	reply.Consumed, err = s.getSimulatedUTXOs(simulation.Consumed, simulation.Imported, args.Encoding)
+	if err != nil {
+		return err
+	}
+	reply.Produced, err = s.getSimulatedUTXOs(simulation.Produced, simulation.Exported, args.Encoding)
+	if err != nil {
+		return err
+	}
+
+	reply.StakerChanges = make([]SimulatedStakerChange, 0,
+		len(simulation.AddedCurrentStakers)+len(simulation.RemovedCurrentStakers)+
+			len(simulation.AddedPendingStakers)+len(simulation.RemovedPendingStakers),
+	)
+	for _, change := range []struct {
+		added   bool
+		current bool
+		stakers []*state.Staker
+	}{
+		{added: false, current: true, stakers: simulation.RemovedCurrentStakers},
+		{added: false, current: false, stakers: simulation.RemovedPendingStakers},
+		{added: true, current: false, stakers: simulation.AddedPendingStakers},
+		{added: true, current: true, stakers: simulation.AddedCurrentStakers},
+	} {
+		for _, staker := range change.stakers {
+			reply.StakerChanges = append(reply.StakerChanges, SimulatedStakerChange{
+				Added:     change.added,
+				Current:   change.current,
+				Delegator: staker.Priority.IsDelegator(),
+				TxID:      staker.TxID,
+				NodeID:    staker.NodeID,
+				SubnetID:  staker.SubnetID,
+				Weight:    json.Uint64(staker.Weight),
+				StartTime: json.Uint64(staker.StartTime.Unix()),
+				EndTime:   json.Uint64(staker.EndTime.Unix()),
+			})
+		}
+	}
+
+	reply.Burned = make(map[ids.ID]json.Uint64, len(simulation.Burned))
+	for assetID, amount := range simulation.Burned {
+		reply.Burned[assetID] = json.Uint64(amount)
+	}
+	return nil
+}
+
+// getSimulatedUTXOs returns [utxos], which are held on the P-chain, followed by
+// [atomicUTXOs], which are held on other chains.
+func (s *Service) getSimulatedUTXOs(
+	utxos []*lux.UTXO,
+	atomicUTXOs map[ids.ID][]*lux.UTXO,
+	encoding formatting.Encoding,
+) ([]SimulatedUTXO, error) {
+	chainIDs := maps.Keys(atomicUTXOs)
+	utils.Sort(chainIDs)
+
+	simulatedUTXOs := make([]SimulatedUTXO, 0, len(utxos))
+	for _, chainID := range append([]ids.ID{s.vm.ctx.ChainID}, chainIDs...) {
+		chainUTXOs := utxos
+		if chainID != s.vm.ctx.ChainID {
+			chainUTXOs = atomicUTXOs[chainID]
+		}
+		for _, utxo := range chainUTXOs {
+			utxoBytes, err := txs.Codec.Marshal(txs.Version, utxo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode UTXO to bytes: %w", err)
+			}
+			utxoStr, err := formatting.Encode(encoding, utxoBytes)
+			if err != nil {
+				return nil, fmt.Errorf("couldn't encode utxo as %s: %w", encoding, err)
+			}
+
+			var amount uint64
+			if out, ok := utxo.Out.(lux.Amounter); ok {
+				amount = out.Amount()
+			}
+			simulatedUTXOs = append(simulatedUTXOs, SimulatedUTXO{
+				ChainID: chainID,
+				UTXOID:  utxo.UTXOID.String(),
+				AssetID: utxo.AssetID(),
+				Amount:  json.Uint64(amount),
+				UTXO:    utxoStr,
+			})
+		}
+	}
+	return simulatedUTXOs, nil
+}
+
+// GetTxFeesReply is the response from GetTxFees
+type GetTxFeesReply struct {
+	// Chain time of the preferred block, which the fees are computed at
+	Timestamp time.Time `json:"timestamp"`
+
+	// Static fee of each tx type
+	TxFee                         json.Uint64 `json:"txFee"`
+	CreateSubnetTxFee             json.Uint64 `json:"createSubnetTxFee"`
+	TransformSubnetTxFee          json.Uint64 `json:"transformSubnetTxFee"`
+	CreateBlockchainTxFee         json.Uint64 `json:"createBlockchainTxFee"`
+	AddPrimaryNetworkValidatorFee json.Uint64 `json:"addPrimaryNetworkValidatorFee"`
+	AddPrimaryNetworkDelegatorFee json.Uint64 `json:"addPrimaryNetworkDelegatorFee"`
+	AddSubnetValidatorFee         json.Uint64 `json:"addSubnetValidatorFee"`
+	AddSubnetDelegatorFee         json.Uint64 `json:"addSubnetDelegatorFee"`
+
+	// True if txs must also pay for the gas they consume. If so, a tx is
+	// charged the larger of its static fee and the cost of its gas.
+	DynamicFeesActivated bool `json:"dynamicFeesActivated"`
+	// Price, in nLUX, of a unit of gas in the next block
+	GasPrice json.Uint64 `json:"gasPrice"`
+	// Gas charged per unit of complexity
+	Weights *fee.Dimensions `json:"weights,omitempty"`
+}
+
+// GetTxFees returns the fees that txs must pay to be included in the next
+// block.
+func (s *Service) GetTxFees(_ *http.Request, _ *struct{}, reply *GetTxFeesReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getTxFees"),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	preferredID := s.vm.manager.Preferred()
+	preferredState, ok := s.vm.manager.GetState(preferredID)
+	if !ok {
+		return fmt.Errorf("could not retrieve state for block %s", preferredID)
+	}
+
+	cfg := &s.vm.Config
+	timestamp := preferredState.GetTimestamp()
+	reply.Timestamp = timestamp
+	reply.TxFee = json.Uint64(cfg.TxFee)
+	reply.CreateSubnetTxFee = json.Uint64(cfg.GetCreateSubnetTxFee(timestamp))
+	reply.TransformSubnetTxFee = json.Uint64(cfg.TransformSubnetTxFee)
+	reply.CreateBlockchainTxFee = json.Uint64(cfg.GetCreateBlockchainTxFee(timestamp))
+	reply.AddPrimaryNetworkValidatorFee = json.Uint64(cfg.AddPrimaryNetworkValidatorFee)
+	reply.AddPrimaryNetworkDelegatorFee = json.Uint64(cfg.AddPrimaryNetworkDelegatorFee)
+	reply.AddSubnetValidatorFee = json.Uint64(cfg.AddSubnetValidatorFee)
+	reply.AddSubnetDelegatorFee = json.Uint64(cfg.AddSubnetDelegatorFee)
+
+	if !cfg.IsDynamicFeesActivated(timestamp) {
+		return nil
+	}
+	gasPrice, err := preferredState.GetGasPrice()
+	if err != nil {
+		return err
+	}
+	reply.DynamicFeesActivated = true
+	reply.GasPrice = json.Uint64(cfg.DynamicFeeConfig.GasPrice(gasPrice))
+	reply.Weights = &cfg.DynamicFeeConfig.Weights
+	return nil
+}
+
+// EstimateTxFeeReply is the response from EstimateTxFee
+type EstimateTxFeeReply struct {
+	// ID of the tx. If the tx isn't signed, the ID is computed with empty
+	// signatures and changes once the tx is signed.
+	TxID   ids.ID `json:"txID"`
+	Signed bool   `json:"signed"`
+	// Resources consumed by the tx
+	Complexity fee.Dimensions `json:"complexity"`
+	// Gas consumed by the tx, if dynamic fees are activated
+	Gas json.Uint64 `json:"gas"`
+	// Price, in nLUX, of a unit of gas in the next block, if dynamic fees are
+	// activated
+	GasPrice json.Uint64 `json:"gasPrice"`
+	// Static fee of the tx's type
+	StaticFee json.Uint64 `json:"staticFee"`
+	// Fee, in nLUX, that the tx must burn to be included in the next block
+	Fee json.Uint64 `json:"fee"`
+}
+
+// EstimateTxFee returns the fee that a signed or unsigned tx must burn to be
+// included in the next block. Unsigned txs are sized as if they were signed.
+func (s *Service) EstimateTxFee(_ *http.Request, args *api.FormattedTx, reply *EstimateTxFeeReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "estimateTxFee"),
+	)
+
+	tx, signed, err := parseFormattedTx(args)
+	if err != nil {
+		return err
+	}
+	reply.TxID = tx.ID()
+	reply.Signed = signed
+
+	reply.Complexity, err = fee.TxComplexity(tx)
+	if err != nil {
+		return err
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	preferredID := s.vm.manager.Preferred()
+	preferredState, ok := s.vm.manager.GetState(preferredID)
+	if !ok {
+		return fmt.Errorf("could not retrieve state for block %s", preferredID)
+	}
+
+	cfg := &s.vm.Config
+	timestamp := preferredState.GetTimestamp()
+	reply.StaticFee = json.Uint64(cfg.GetStaticTxFee(tx.Unsigned, timestamp))
+
+	var gasPrice uint64
+	if cfg.IsDynamicFeesActivated(timestamp) {
+		gas, err := reply.Complexity.Gas(cfg.DynamicFeeConfig.Weights)
+		if err != nil {
+			return err
+		}
+		gasPrice, err = preferredState.GetGasPrice()
+		if err != nil {
+			return err
+		}
+		reply.Gas = json.Uint64(gas)
+		reply.GasPrice = json.Uint64(cfg.DynamicFeeConfig.GasPrice(gasPrice))
+	}
+
+	txFee, err := cfg.GetTxFee(tx, timestamp, gasPrice)
+	if err != nil {
+		return err
+	}
+	reply.Fee = json.Uint64(txFee)
+	return nil
+}
+
 func (s *Service) GetTx(_ *http.Request, args *api.GetTxArgs, response *api.GetTxReply) error {
 	s.vm.ctx.Log.Debug("API called",
 		zap.String("service", "platform"),
@@ -2280,6 +2633,59 @@ func (s *Service) GetTxStatus(_ *http.Request, args *GetTxStatusArgs, response *
 	return nil
 }
 
+// GetMempool returns the txs waiting in the mempool and the txs that were
+// recently dropped.
+func (s *Service) GetMempool(_ *http.Request, _ *struct{}, reply *api.GetMempoolReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getMempool"),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	var (
+		now = s.vm.clock.Time()
+		err error
+	)
+	reply.Txs = []api.MempoolTx{}
+	s.vm.Builder.Iterate(func(tx *txs.Tx, addedTime time.Time) bool {
+		var burned uint64
+		burned, err = fee.Burned(tx.Unsigned, s.vm.ctx.LUXAssetID)
+		if err != nil {
+			err = fmt.Errorf("failed to calculate the fee of tx %s: %w", tx.ID(), err)
+			return false
+		}
+
+		var age time.Duration
+		if now.After(addedTime) {
+			age = now.Sub(addedTime)
+		}
+		reply.Txs = append(reply.Txs, api.MempoolTx{
+			TxID:      tx.ID(),
+			Size:      json.Uint64(len(tx.Bytes())),
+			Fee:       json.Uint64(burned),
+			AddedTime: json.Uint64(addedTime.Unix()),
+			Age:       json.Uint64(age / time.Second),
+		})
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	droppedTxs := s.vm.Builder.DroppedTxs()
+	reply.Dropped = make([]api.DroppedMempoolTx, len(droppedTxs))
+	for i, droppedTx := range droppedTxs {
+		reply.Dropped[i] = api.DroppedMempoolTx{
+			TxID:        droppedTx.TxID,
+			Reason:      droppedTx.Reason.Error(),
+			DroppedTime: json.Uint64(droppedTx.Time.Unix()),
+		}
+	}
+	return nil
+}
+
 type GetStakeArgs struct {
 	api.JSONAddresses
 	ValidatorsOnly bool                `json:"validatorsOnly"`
@@ -2339,8 +2745,12 @@ func (s *Service) GetStake(_ *http.Request, args *GetStakeArgs, response *GetSta
 		if args.ValidatorsOnly && !staker.Priority.IsValidator() {
 			continue
 		}
+		if staker.Managed {
+			// Managed validators don't lock any stake on the P-chain.
+			continue
+		}
 
-		tx, _, err := s.vm.state.GetTx(staker.TxID)
+		tx, _, err := s.vm.state.GetTx(staker.StakerTxID())
 		if err != nil {
 			return err
 		}
@@ -2570,6 +2980,243 @@ func (s *Service) GetRewardUTXOs(_ *http.Request, args *api.GetTxArgs, reply *Ge
 	return nil
 }
 
+// EstimateRewardArgs are the arguments for calling EstimateReward
+type EstimateRewardArgs struct {
+	// ID of the subnet the stake is added to. If omitted, defaults to the
+	// primary network.
+	SubnetID ids.ID `json:"subnetID"`
+	// Amount staked
+	StakeAmount json.Uint64 `json:"stakeAmount"`
+	// Unix time the staking period starts at. If omitted, defaults to the
+	// current chain time.
+	StartTime json.Uint64 `json:"startTime"`
+	// Number of seconds the stake is locked for
+	Duration json.Uint64 `json:"duration"`
+	// Delegation fee rate (0-100) of the validator the stake is delegated to.
+	// Omitted when estimating the reward of a validator.
+	DelegationFeeRate json.Float32 `json:"delegationFeeRate"`
+}
+
+// EstimateRewardReply is the response from calling EstimateReward
+type EstimateRewardReply struct {
+	StartTime json.Uint64 `json:"startTime"`
+	EndTime   json.Uint64 `json:"endTime"`
+	// Supply of the subnet the estimate is based on
+	CurrentSupply json.Uint64 `json:"currentSupply"`
+	// Reward minted if the staker is rewarded
+	PotentialReward json.Uint64 `json:"potentialReward"`
+	// Part of [PotentialReward] paid to the staker
+	Reward json.Uint64 `json:"reward"`
+	// Part of [PotentialReward] paid to the validator as a delegation fee
+	DelegationFee json.Uint64 `json:"delegationFee"`
+}
+
+// EstimateReward returns the reward a staker would receive, based on the
+// current supply, if it was rewarded at the end of its staking period.
+func (s *Service) EstimateReward(_ *http.Request, args *EstimateRewardArgs, reply *EstimateRewardReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "estimateReward"),
+		zap.Stringer("subnetID", args.SubnetID),
+	)
+
+	switch {
+	case args.StakeAmount == 0:
+		return errNoStakeAmount
+	case args.DelegationFeeRate < 0 || args.DelegationFeeRate > 100:
+		return errInvalidDelegationRate
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	chainTime := s.vm.state.GetTimestamp()
+	startTime := chainTime
+	if args.StartTime != 0 {
+		startTime = time.Unix(int64(args.StartTime), 0)
+	}
+	if startTime.Before(chainTime) {
+		return errStartTimeInThePast
+	}
+
+	minStakeDuration := s.vm.MinStakeDuration
+	maxStakeDuration := s.vm.MaxStakeDuration
+	if args.SubnetID != constants.PrimaryNetworkID {
+		transformSubnet, err := executor.GetTransformSubnetTx(s.vm.state, args.SubnetID)
+		if err != nil {
+			return fmt.Errorf("couldn't get staking parameters of %s: %w", args.SubnetID, err)
+		}
+		minStakeDuration = time.Duration(transformSubnet.MinStakeDuration) * time.Second
+		maxStakeDuration = time.Duration(transformSubnet.MaxStakeDuration) * time.Second
+	}
+	duration := time.Duration(args.Duration) * time.Second
+	if duration < minStakeDuration || duration > maxStakeDuration {
+		return fmt.Errorf("%w: %s not in [%s, %s]",
+			errInvalidStakeDuration,
+			duration,
+			minStakeDuration,
+			maxStakeDuration,
+		)
+	}
+
+	currentSupply, err := s.vm.state.GetCurrentSupply(args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("fetching current supply failed: %w", err)
+	}
+	rewards, err := executor.GetRewardsCalculator(s.vm.txExecutorBackend, s.vm.state, args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("couldn't get rewards calculator of %s: %w", args.SubnetID, err)
+	}
+
+	potentialReward := rewards.Calculate(duration, uint64(args.StakeAmount), currentSupply)
+	shares := uint32(10000 * args.DelegationFeeRate)
+	delegationFee, stakerReward := reward.Split(potentialReward, shares)
+
+	reply.StartTime = json.Uint64(startTime.Unix())
+	reply.EndTime = json.Uint64(startTime.Add(duration).Unix())
+	reply.CurrentSupply = json.Uint64(currentSupply)
+	reply.PotentialReward = json.Uint64(potentialReward)
+	reply.Reward = json.Uint64(stakerReward)
+	reply.DelegationFee = json.Uint64(delegationFee)
+	return nil
+}
+
+// GetRewardHistoryArgs are the arguments for calling GetRewardHistory.
+// Exactly one of [NodeID] and [RewardAddress] must be provided.
+type GetRewardHistoryArgs struct {
+	// Node whose validators and delegators are returned
+	NodeID ids.NodeID `json:"nodeID"`
+	// Address owning the rewards that are returned
+	RewardAddress string `json:"rewardAddress"`
+	// If provided, only the stakers whose staking period ended at or after
+	// this Unix time are returned
+	StartTime json.Uint64 `json:"startTime"`
+	// If provided, only the stakers whose staking period ended at or before
+	// this Unix time are returned
+	EndTime json.Uint64 `json:"endTime"`
+}
+
+// APIRewardRecord is the outcome of the staking period of a staker
+type APIRewardRecord struct {
+	// ID of the tx that added the staker
+	TxID      ids.ID      `json:"txID"`
+	NodeID    ids.NodeID  `json:"nodeID"`
+	SubnetID  ids.ID      `json:"subnetID"`
+	Delegator bool        `json:"delegator"`
+	Weight    json.Uint64 `json:"weight"`
+	StartTime json.Uint64 `json:"startTime"`
+	EndTime   json.Uint64 `json:"endTime"`
+	Rewarded  bool        `json:"rewarded"`
+	// Reward paid to the staker
+	Reward json.Uint64 `json:"reward"`
+	// For delegators, the delegation fee credited to the validator. For
+	// validators, the accrued delegation fees paid to the validator.
+	DelegationFee json.Uint64 `json:"delegationFee"`
+	// Potential reward that wasn't minted because the staker wasn't rewarded
+	Forfeited       json.Uint64 `json:"forfeited"`
+	RewardAddresses []string    `json:"rewardAddresses"`
+}
+
+// GetRewardHistoryReply is the response from calling GetRewardHistory
+type GetRewardHistoryReply struct {
+	// Records sorted by end time
+	Records        []APIRewardRecord `json:"records"`
+	TotalReward    json.Uint64       `json:"totalReward"`
+	TotalForfeited json.Uint64       `json:"totalForfeited"`
+}
+
+// GetRewardHistory returns the rewards paid and forfeited by the stakers that
+// were removed from the staker set.
+func (s *Service) GetRewardHistory(_ *http.Request, args *GetRewardHistoryArgs, reply *GetRewardHistoryReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getRewardHistory"),
+		zap.Stringer("nodeID", args.NodeID),
+		zap.String("rewardAddress", args.RewardAddress),
+	)
+
+	hasNodeID := args.NodeID != ids.EmptyNodeID
+	hasRewardAddress := args.RewardAddress != ""
+	switch {
+	case hasNodeID == hasRewardAddress:
+		return errNodeIDXorRewardAddress
+	case args.EndTime != 0 && args.EndTime < args.StartTime:
+		return errStartAfterEndTime
+	}
+
+	var rewardAddr ids.ShortID
+	if hasRewardAddress {
+		var err error
+		rewardAddr, err = lux.ParseServiceAddress(s.addrManager, args.RewardAddress)
+		if err != nil {
+			return fmt.Errorf("problem while parsing reward address: %w", err)
+		}
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	var (
+		records []*state.RewardRecord
+		err     error
+	)
+	if hasNodeID {
+		records, err = s.vm.state.GetRewardRecords(args.NodeID)
+	} else {
+		records, err = s.vm.state.GetRewardRecordsByAddress(rewardAddr)
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't get reward records: %w", err)
+	}
+
+	reply.Records = []APIRewardRecord{}
+	var totalReward, totalForfeited uint64
+	for _, record := range records {
+		if record.EndTime < uint64(args.StartTime) {
+			continue
+		}
+		if args.EndTime != 0 && record.EndTime > uint64(args.EndTime) {
+			continue
+		}
+
+		rewardAddresses := make([]string, 0, len(record.RewardAddrs))
+		for _, addr := range record.RewardAddrs {
+			addrStr, err := s.addrManager.FormatLocalAddress(addr)
+			if err != nil {
+				return err
+			}
+			rewardAddresses = append(rewardAddresses, addrStr)
+		}
+
+		reply.Records = append(reply.Records, APIRewardRecord{
+			TxID:            record.TxID,
+			NodeID:          record.NodeID,
+			SubnetID:        record.SubnetID,
+			Delegator:       record.Delegator,
+			Weight:          json.Uint64(record.Weight),
+			StartTime:       json.Uint64(record.StartTime),
+			EndTime:         json.Uint64(record.EndTime),
+			Rewarded:        record.Rewarded,
+			Reward:          json.Uint64(record.Reward),
+			DelegationFee:   json.Uint64(record.DelegationFee),
+			Forfeited:       json.Uint64(record.Forfeited),
+			RewardAddresses: rewardAddresses,
+		})
+
+		totalReward, err = safemath.Add64(totalReward, record.Reward)
+		if err != nil {
+			return err
+		}
+		totalForfeited, err = safemath.Add64(totalForfeited, record.Forfeited)
+		if err != nil {
+			return err
+		}
+	}
+	reply.TotalReward = json.Uint64(totalReward)
+	reply.TotalForfeited = json.Uint64(totalForfeited)
+	return nil
+}
+
 // GetTimestampReply is the response from GetTimestamp
 type GetTimestampReply struct {
 	// Current timestamp
@@ -2683,6 +3330,119 @@ func (s *Service) GetValidatorsAt(r *http.Request, args *GetValidatorsAtArgs, re
 	return nil
 }
 
+// APIValidatorDiff is the change made to a validator by a block
+type APIValidatorDiff struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	// True if the weight of the validator was decreased by [Weight]
+	WeightDecrease bool `json:"weightDecrease"`
+	// Amount the weight of the validator changed by. 0 if it didn't change.
+	Weight json.Uint64 `json:"weight"`
+	// True if the public key of the validator changed. Public keys are only
+	// tracked for the primary network.
+	PublicKeyChanged bool `json:"publicKeyChanged"`
+	// Public key of the validator after the change. Omitted if it was removed.
+	PublicKey *string `json:"publicKey,omitempty"`
+}
+
+// APIValidatorSetDiff is the changes made to the validator set of a subnet by
+// the block at [Height]
+type APIValidatorSetDiff struct {
+	SubnetID   ids.ID             `json:"subnetID"`
+	Height     json.Uint64        `json:"height"`
+	Validators []APIValidatorDiff `json:"validators"`
+}
+
+// newAPIValidatorSetDiffs groups [diffs], which must be sorted by height, by
+// the height they were made at.
+func newAPIValidatorSetDiffs(subnetID ids.ID, diffs []*state.ValidatorDiff) ([]APIValidatorSetDiff, error) {
+	setDiffs := []APIValidatorSetDiff{}
+	for _, diff := range diffs {
+		height := json.Uint64(diff.Height)
+		if len(setDiffs) == 0 || setDiffs[len(setDiffs)-1].Height != height {
+			setDiffs = append(setDiffs, APIValidatorSetDiff{
+				SubnetID: subnetID,
+				Height:   height,
+			})
+		}
+
+		apiDiff := APIValidatorDiff{
+			NodeID:           diff.NodeID,
+			PublicKeyChanged: diff.PublicKeyChanged,
+		}
+		if diff.WeightDiff != nil {
+			apiDiff.WeightDecrease = diff.WeightDiff.Decrease
+			apiDiff.Weight = json.Uint64(diff.WeightDiff.Amount)
+		}
+		if diff.PublicKey != nil {
+			pk, err := formatting.Encode(formatting.HexNC, bls.PublicKeyToBytes(diff.PublicKey))
+			if err != nil {
+				return nil, err
+			}
+			apiDiff.PublicKey = &pk
+		}
+
+		setDiff := &setDiffs[len(setDiffs)-1]
+		setDiff.Validators = append(setDiff.Validators, apiDiff)
+	}
+	return setDiffs, nil
+}
+
+// GetValidatorSetDiffsArgs are the arguments for calling GetValidatorSetDiffs
+type GetValidatorSetDiffsArgs struct {
+	SubnetID   ids.ID      `json:"subnetID"`
+	FromHeight json.Uint64 `json:"fromHeight"`
+	// If 0, or greater than the height of the last accepted block, the height
+	// of the last accepted block is used.
+	ToHeight json.Uint64 `json:"toHeight"`
+}
+
+// GetValidatorSetDiffsReply is the response from calling GetValidatorSetDiffs
+type GetValidatorSetDiffsReply struct {
+	// Diffs sorted by height. Heights without changes are omitted.
+	Diffs []APIValidatorSetDiff `json:"diffs"`
+	// Last height included in [Diffs]. The range is truncated if it spans
+	// more than 1024 heights.
+	ToHeight json.Uint64 `json:"toHeight"`
+}
+
+// GetValidatorSetDiffs returns the changes made to the validator set of a
+// subnet by the blocks in the provided range of heights.
+func (s *Service) GetValidatorSetDiffs(r *http.Request, args *GetValidatorSetDiffsArgs, reply *GetValidatorSetDiffsReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getValidatorSetDiffs"),
+		zap.Stringer("subnetID", args.SubnetID),
+		zap.Uint64("fromHeight", uint64(args.FromHeight)),
+		zap.Uint64("toHeight", uint64(args.ToHeight)),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	ctx := r.Context()
+	lastAcceptedHeight, err := s.vm.GetCurrentHeight(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the last accepted height: %w", err)
+	}
+
+	fromHeight := uint64(args.FromHeight)
+	toHeight := uint64(args.ToHeight)
+	if toHeight == 0 || toHeight > lastAcceptedHeight {
+		toHeight = lastAcceptedHeight
+	}
+	if toHeight >= fromHeight && toHeight-fromHeight >= maxValidatorSetDiffHeights {
+		toHeight = fromHeight + maxValidatorSetDiffHeights - 1
+	}
+	reply.ToHeight = json.Uint64(toHeight)
+
+	diffs, err := s.vm.state.GetValidatorDiffs(ctx, args.SubnetID, fromHeight, toHeight)
+	if err != nil {
+		return fmt.Errorf("failed to get validator set diffs: %w", err)
+	}
+	reply.Diffs, err = newAPIValidatorSetDiffs(args.SubnetID, diffs)
+	return err
+}
+
 func (s *Service) GetBlock(_ *http.Request, args *api.GetBlockArgs, response *api.GetBlockResponse) error {
 	s.vm.ctx.Log.Debug("API called",
 		zap.String("service", "platform"),
@@ -2757,6 +3517,255 @@ func (s *Service) GetBlockByHeight(_ *http.Request, args *api.GetBlockByHeightAr
 	return err
 }
 
+// GetUptimeProofArgs are the arguments for calling GetUptimeProof
+type GetUptimeProofArgs struct {
+	// Primary network validator to prove the uptime of
+	NodeID ids.NodeID `json:"nodeID"`
+	// Uptime, in seconds, that validators are asked to attest to. If 0, this
+	// node's view of the validator's uptime is used.
+	Uptime json.Uint64 `json:"uptime"`
+	// Percentage of the primary network stake that must sign the proof. If 0,
+	// defaults to 67.
+	QuorumPercent json.Uint64         `json:"quorumPercent"`
+	Encoding      formatting.Encoding `json:"encoding"`
+}
+
+// GetUptimeProofReply is the response from GetUptimeProof
+type GetUptimeProofReply struct {
+	// ID of the transaction that added the validator
+	TxID ids.ID `json:"txID"`
+	// Uptime, in seconds, attested to by the signers
+	Uptime json.Uint64 `json:"uptime"`
+	// P-chain height of the validator set that signed the proof. The proof
+	// must be verified against the validator set at this height.
+	PChainHeight json.Uint64 `json:"pChainHeight"`
+	// The signed Warp message
+	Message  string              `json:"message"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetUptimeProof collects signatures from the primary network validators
+// attesting that a validator has been online for at least the requested uptime
+// during its current staking period.
+func (s *Service) GetUptimeProof(r *http.Request, args *GetUptimeProofArgs, reply *GetUptimeProofReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getUptimeProof"),
+		zap.Stringer("nodeID", args.NodeID),
+		zap.Uint64("uptime", uint64(args.Uptime)),
+	)
+
+	quorumNum, err := getQuorumPercent(args.QuorumPercent)
+	if err != nil {
+		return err
+	}
+
+	txID, uptime, err := uptimeBackend{vm: s.vm}.GetUptime(args.NodeID)
+	if err != nil {
+		return fmt.Errorf("couldn't get uptime of %s: %w", args.NodeID, err)
+	}
+	claimedUptime := uint64(args.Uptime)
+	if claimedUptime == 0 {
+		claimedUptime = uint64(uptime / time.Second)
+	}
+	attestation, err := uptimeproof.NewAttestation(txID, args.NodeID, claimedUptime)
+	if err != nil {
+		return err
+	}
+
+	s.vm.ctx.Lock.Lock()
+	height, err := s.vm.GetCurrentHeight(r.Context())
+	s.vm.ctx.Lock.Unlock()
+	if err != nil {
+		return fmt.Errorf("couldn't get current height: %w", err)
+	}
+
+	// The validators' responses are handled while waiting, so the context lock
+	// must not be held.
+	ctx, cancel := context.WithTimeout(r.Context(), uptimeProofTimeout)
+	defer cancel()
+	msg, err := s.vm.uptimeAggregator.Aggregate(ctx, attestation, height, quorumNum, 100)
+	if err != nil {
+		return fmt.Errorf("couldn't aggregate uptime attestations: %w", err)
+	}
+
+	reply.TxID = txID
+	reply.Uptime = json.Uint64(claimedUptime)
+	reply.PChainHeight = json.Uint64(height)
+	reply.Encoding = args.Encoding
+	reply.Message, err = formatting.Encode(args.Encoding, msg.Bytes())
+	if err != nil {
+		return fmt.Errorf("couldn't encode uptime proof as %s: %w", args.Encoding, err)
+	}
+	return nil
+}
+
+// VerifyUptimeProofArgs are the arguments for calling VerifyUptimeProof
+type VerifyUptimeProofArgs struct {
+	Message  string              `json:"message"`
+	Encoding formatting.Encoding `json:"encoding"`
+	// P-chain height of the validator set that signed the proof
+	PChainHeight json.Uint64 `json:"pChainHeight"`
+	// Percentage of the primary network stake that must have signed the proof.
+	// If 0, defaults to 67.
+	QuorumPercent json.Uint64 `json:"quorumPercent"`
+}
+
+// VerifyUptimeProofReply is the response from VerifyUptimeProof
+type VerifyUptimeProofReply struct {
+	TxID   ids.ID      `json:"txID"`
+	NodeID ids.NodeID  `json:"nodeID"`
+	Uptime json.Uint64 `json:"uptime"`
+}
+
+// VerifyUptimeProof verifies an uptime proof returned by GetUptimeProof and
+// returns the attested uptime.
+func (s *Service) VerifyUptimeProof(r *http.Request, args *VerifyUptimeProofArgs, reply *VerifyUptimeProofReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "verifyUptimeProof"),
+		zap.Uint64("pChainHeight", uint64(args.PChainHeight)),
+	)
+
+	quorumNum, err := getQuorumPercent(args.QuorumPercent)
+	if err != nil {
+		return err
+	}
+
+	msgBytes, err := formatting.Decode(args.Encoding, args.Message)
+	if err != nil {
+		return fmt.Errorf("problem decoding uptime proof: %w", err)
+	}
+	msg, err := warp.ParseMessage(msgBytes)
+	if err != nil {
+		return fmt.Errorf("couldn't parse uptime proof: %w", err)
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	attestation, err := uptimeproof.Verify(
+		r.Context(),
+		msg,
+		s.vm.ctx.NetworkID,
+		s.vm,
+		uint64(args.PChainHeight),
+		quorumNum,
+		100,
+	)
+	if err != nil {
+		return fmt.Errorf("invalid uptime proof: %w", err)
+	}
+
+	reply.TxID = attestation.TxID
+	reply.NodeID = attestation.NodeID
+	reply.Uptime = json.Uint64(attestation.Uptime)
+	return nil
+}
+
+func getQuorumPercent(quorumPercent json.Uint64) (uint64, error) {
+	switch {
+	case quorumPercent == 0:
+		return defaultUptimeProofQuorumPercent, nil
+	case quorumPercent > 100:
+		return 0, errInvalidQuorumPercent
+	default:
+		return uint64(quorumPercent), nil
+	}
+}
+
+// GetValidatorUptimeHistoryArgs are the arguments for calling
+// GetValidatorUptimeHistory
+type GetValidatorUptimeHistoryArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	// If omitted, defaults to the primary network
+	SubnetID ids.ID `json:"subnetID"`
+	// Unix time, in seconds, of the start of the range. It is rounded down to
+	// the start of the day.
+	StartTime json.Uint64 `json:"startTime"`
+	// Unix time, in seconds, of the end of the range. If 0, defaults to now.
+	EndTime json.Uint64 `json:"endTime"`
+}
+
+// APIUptimeEpoch is the uptime of a validator during one day, as observed by
+// this node
+type APIUptimeEpoch struct {
+	// Unix time, in seconds, of the start of the day
+	StartTime json.Uint64 `json:"startTime"`
+	// Number of seconds this node tracked the validator for
+	Observed json.Uint64 `json:"observed"`
+	// Number of the observed seconds the validator was connected for
+	Connected json.Uint64 `json:"connected"`
+	// Percentage (0-100) of the observed time the validator was connected for
+	UptimePercentage json.Float64 `json:"uptimePercentage"`
+}
+
+// GetValidatorUptimeHistoryReply is the response from calling
+// GetValidatorUptimeHistory
+type GetValidatorUptimeHistoryReply struct {
+	// Days during which the validator was observed, in order
+	Epochs []APIUptimeEpoch `json:"epochs"`
+	// Totals over all of [Epochs]
+	Observed         json.Uint64  `json:"observed"`
+	Connected        json.Uint64  `json:"connected"`
+	UptimePercentage json.Float64 `json:"uptimePercentage"`
+}
+
+// GetValidatorUptimeHistory returns the per-day uptime of a validator, as
+// observed by this node, over a range of time. Only the periods during which
+// the node was a validator and this node was running are included.
+func (s *Service) GetValidatorUptimeHistory(_ *http.Request, args *GetValidatorUptimeHistoryArgs, reply *GetValidatorUptimeHistoryReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getValidatorUptimeHistory"),
+		zap.Stringer("nodeID", args.NodeID),
+		zap.Stringer("subnetID", args.SubnetID),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	startTime := time.Unix(int64(args.StartTime), 0)
+	endTime := s.vm.clock.UnixTime()
+	if args.EndTime != 0 {
+		endTime = time.Unix(int64(args.EndTime), 0)
+	}
+	if endTime.Before(startTime) {
+		return errStartAfterEndTime
+	}
+
+	epochs, err := s.vm.uptimeManager.CalculateUptimeHistory(args.NodeID, args.SubnetID, startTime, endTime)
+	if err != nil {
+		return fmt.Errorf("couldn't get uptime history of %s: %w", args.NodeID, err)
+	}
+
+	reply.Epochs = make([]APIUptimeEpoch, len(epochs))
+	var observed, connected time.Duration
+	for i, epoch := range epochs {
+		reply.Epochs[i] = APIUptimeEpoch{
+			StartTime:        json.Uint64(epoch.Start.Unix()),
+			Observed:         json.Uint64(epoch.Observed / time.Second),
+			Connected:        json.Uint64(epoch.Connected / time.Second),
+			UptimePercentage: json.Float64(uptimePercentage(epoch.Observed, epoch.Connected)),
+		}
+		observed += epoch.Observed
+		connected += epoch.Connected
+	}
+	reply.Observed = json.Uint64(observed / time.Second)
+	reply.Connected = json.Uint64(connected / time.Second)
+	reply.UptimePercentage = json.Float64(uptimePercentage(observed, connected))
+	return nil
+}
+
+// uptimePercentage returns the percentage (0-100) of [observed] that is
+// [connected]. If nothing was observed, 0 is returned.
+func uptimePercentage(observed, connected time.Duration) float64 {
+	if observed == 0 {
+		return 0
+	}
+	return 100 * float64(connected) / float64(observed)
+}
+
 func (s *Service) getAPIUptime(staker *state.Staker) (*json.Float32, error) {
 	// Only report uptimes that we have been actively tracking.
 	if constants.PrimaryNetworkID != staker.SubnetID && !s.vm.TrackedSubnets.Contains(staker.SubnetID) {
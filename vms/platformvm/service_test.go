@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"net/http"
 	"testing"
 	"time"
 
@@ -35,11 +36,16 @@ import (
 	"github.com/luxdefi/node/utils/formatting"
 	"github.com/luxdefi/node/utils/json"
 	"github.com/luxdefi/node/utils/logging"
+	"github.com/luxdefi/node/utils/units"
+	"github.com/luxdefi/node/version"
 	"github.com/luxdefi/node/vms/components/lux"
+	"github.com/luxdefi/node/vms/components/message"
 	"github.com/luxdefi/node/vms/platformvm/block"
+	"github.com/luxdefi/node/vms/platformvm/reward"
 	"github.com/luxdefi/node/vms/platformvm/state"
 	"github.com/luxdefi/node/vms/platformvm/status"
 	"github.com/luxdefi/node/vms/platformvm/txs"
+	"github.com/luxdefi/node/vms/platformvm/txs/fee"
 	"github.com/luxdefi/node/vms/secp256k1fx"
 
 	vmkeystore "github.com/luxdefi/node/vms/components/keystore"
@@ -1052,3 +1058,587 @@ func TestServiceGetBlockByHeight(t *testing.T) {
 		})
 	}
 }
+
+func TestGetValidatorUptimeHistory(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	defer func() {
+		service.vm.ctx.Lock.Lock()
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	nodeID := genesisNodeIDs[0]
+
+	service.vm.ctx.Lock.Lock()
+	startTime := service.vm.clock.UnixTime()
+	require.NoError(service.vm.Connected(context.Background(), nodeID, version.CurrentApp))
+	service.vm.clock.Set(startTime.Add(time.Hour))
+	require.NoError(service.vm.Disconnected(context.Background(), nodeID))
+	service.vm.clock.Set(startTime.Add(2 * time.Hour))
+	service.vm.ctx.Lock.Unlock()
+
+	args := GetValidatorUptimeHistoryArgs{
+		NodeID:    nodeID,
+		StartTime: json.Uint64(startTime.Unix()),
+	}
+	reply := GetValidatorUptimeHistoryReply{}
+	require.NoError(service.GetValidatorUptimeHistory(nil, &args, &reply))
+	require.NotEmpty(reply.Epochs)
+	require.Equal(json.Uint64(2*time.Hour/time.Second), reply.Observed)
+	require.Equal(json.Uint64(time.Hour/time.Second), reply.Connected)
+	require.Equal(json.Float64(50), reply.UptimePercentage)
+
+	args.EndTime = json.Uint64(startTime.Add(-time.Second).Unix())
+	err := service.GetValidatorUptimeHistory(nil, &args, &reply)
+	require.ErrorIs(err, errStartAfterEndTime)
+}
+
+func TestSimulateTx(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	defer func() {
+		service.vm.ctx.Lock.Lock()
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	service.vm.ctx.Lock.Lock()
+	startTime := service.vm.clock.Time().Add(txexecutor.SyncBound).Add(time.Second)
+	endTime := startTime.Add(defaultMinStakingDuration)
+	nodeID := ids.GenerateTestNodeID()
+	addValidatorTx, err := service.vm.txBuilder.NewAddValidatorTx(
+		service.vm.MinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		nodeID,
+		ids.GenerateTestShortID(),
+		reward.PercentDenominator,
+		[]*secp256k1.PrivateKey{keys[0]},
+		ids.ShortEmpty, // change addr
+	)
+	require.NoError(err)
+	exportTx, err := service.vm.txBuilder.NewExportTx(
+		100,
+		service.vm.ctx.XChainID,
+		ids.GenerateTestShortID(),
+		[]*secp256k1.PrivateKey{keys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	require.NoError(err)
+	service.vm.ctx.Lock.Unlock()
+
+	unsignedAddValidatorTxBytes, err := txs.Codec.Marshal(txs.Version, &addValidatorTx.Unsigned)
+	require.NoError(err)
+	for _, test := range []struct {
+		name    string
+		txBytes []byte
+		signed  bool
+	}{
+		{
+			name:    "signed",
+			txBytes: addValidatorTx.Bytes(),
+			signed:  true,
+		},
+		{
+			name:    "unsigned",
+			txBytes: unsignedAddValidatorTxBytes,
+			signed:  false,
+		},
+	} {
+		txStr, err := formatting.Encode(formatting.Hex, test.txBytes)
+		require.NoError(err)
+		reply := SimulateTxReply{}
+		require.NoError(service.SimulateTx(nil, &api.FormattedTx{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		}, &reply))
+		require.Empty(reply.Error, test.name)
+		require.Equal(test.signed, reply.Signed)
+		require.Equal(test.signed, reply.TxID == addValidatorTx.ID())
+
+		require.Len(reply.Consumed, len(addValidatorTx.Unsigned.InputIDs()))
+		for _, utxo := range reply.Consumed {
+			require.Equal(constants.PlatformChainID, utxo.ChainID)
+		}
+		require.Len(reply.Produced, len(addValidatorTx.Unsigned.Outputs()))
+		require.Len(reply.StakerChanges, 1)
+		stakerChange := reply.StakerChanges[0]
+		require.True(stakerChange.Added)
+		require.False(stakerChange.Current)
+		require.False(stakerChange.Delegator)
+		require.Equal(reply.TxID, stakerChange.TxID)
+		require.Equal(nodeID, stakerChange.NodeID)
+		require.Equal(json.Uint64(service.vm.MinValidatorStake), stakerChange.Weight)
+	}
+
+	// Exported UTXOs are produced on the destination chain and the fee of the
+	// tx is burned.
+	txStr, err := formatting.Encode(formatting.Hex, exportTx.Bytes())
+	require.NoError(err)
+	reply := SimulateTxReply{}
+	require.NoError(service.SimulateTx(nil, &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, &reply))
+	require.Empty(reply.Error)
+	require.Empty(reply.StakerChanges)
+	exportedUTXO := reply.Produced[len(reply.Produced)-1]
+	require.Equal(service.vm.ctx.XChainID, exportedUTXO.ChainID)
+	require.Equal(json.Uint64(100), exportedUTXO.Amount)
+	require.Equal(
+		map[ids.ID]json.Uint64{
+			service.vm.ctx.LUXAssetID: json.Uint64(service.vm.TxFee),
+		},
+		reply.Burned,
+	)
+
+	// The signatures of signed txs are verified.
+	invalidTx := *exportTx
+	invalidTx.Creds = addValidatorTx.Creds
+	require.NoError(invalidTx.Initialize(txs.Codec))
+	txStr, err = formatting.Encode(formatting.Hex, invalidTx.Bytes())
+	require.NoError(err)
+	reply = SimulateTxReply{}
+	require.NoError(service.SimulateTx(nil, &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, &reply))
+	require.NotEmpty(reply.Error)
+
+	// Simulating txs doesn't issue them.
+	service.vm.ctx.Lock.Lock()
+	require.False(service.vm.mempool.Has(addValidatorTx.ID()))
+	require.False(service.vm.mempool.Has(exportTx.ID()))
+	service.vm.ctx.Lock.Unlock()
+}
+
+func TestGetTxFees(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	defer func() {
+		service.vm.ctx.Lock.Lock()
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	reply := GetTxFeesReply{}
+	require.NoError(service.GetTxFees(nil, nil, &reply))
+	require.Equal(json.Uint64(service.vm.TxFee), reply.TxFee)
+	require.Equal(json.Uint64(service.vm.GetCreateSubnetTxFee(reply.Timestamp)), reply.CreateSubnetTxFee)
+	require.Equal(json.Uint64(service.vm.AddSubnetValidatorFee), reply.AddSubnetValidatorFee)
+	require.False(reply.DynamicFeesActivated)
+	require.Nil(reply.Weights)
+
+	service.vm.ctx.Lock.Lock()
+	service.vm.DynamicFeesTime = service.vm.state.GetTimestamp()
+	service.vm.DynamicFeeConfig = &fee.DynamicConfig{
+		Weights: fee.Dimensions{
+			Bandwidth:    1,
+			Signatures:   1,
+			UTXOsRead:    1,
+			UTXOsWritten: 1,
+		},
+		MinGasPrice:               1,
+		MaxGasPrice:               units.Lux,
+		TargetGasPerBlock:         1,
+		GasPriceChangeDenominator: 1,
+	}
+	service.vm.ctx.Lock.Unlock()
+
+	reply = GetTxFeesReply{}
+	require.NoError(service.GetTxFees(nil, nil, &reply))
+	require.True(reply.DynamicFeesActivated)
+	require.Equal(json.Uint64(1), reply.GasPrice)
+	require.Equal(&service.vm.DynamicFeeConfig.Weights, reply.Weights)
+
+	// Accepting a block that consumes more than the target gas increases the
+	// gas price.
+	service.vm.ctx.Lock.Lock()
+	tx, err := service.vm.txBuilder.NewExportTx(
+		100,
+		service.vm.ctx.XChainID,
+		ids.GenerateTestShortID(),
+		[]*secp256k1.PrivateKey{keys[0]},
+		ids.ShortEmpty, // change addr
+	)
+	require.NoError(err)
+	require.NoError(service.vm.Network.IssueTx(context.Background(), tx))
+
+	blk, err := service.vm.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(blk.Accept(context.Background()))
+	require.NoError(service.vm.SetPreference(context.Background(), blk.ID()))
+	service.vm.ctx.Lock.Unlock()
+
+	reply = GetTxFeesReply{}
+	require.NoError(service.GetTxFees(nil, nil, &reply))
+	require.Greater(reply.GasPrice, json.Uint64(1))
+}
+
+func TestEstimateTxFee(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	defer func() {
+		service.vm.ctx.Lock.Lock()
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	service.vm.ctx.Lock.Lock()
+	tx, err := service.vm.txBuilder.NewExportTx(
+		100,
+		service.vm.ctx.XChainID,
+		ids.GenerateTestShortID(),
+		[]*secp256k1.PrivateKey{keys[0]},
+		ids.ShortEmpty, // change addr
+	)
+	require.NoError(err)
+	service.vm.ctx.Lock.Unlock()
+
+	complexity, err := fee.TxComplexity(tx)
+	require.NoError(err)
+
+	unsignedTxBytes, err := txs.Codec.Marshal(txs.Version, &tx.Unsigned)
+	require.NoError(err)
+	estimate := func(txBytes []byte) EstimateTxFeeReply {
+		txStr, err := formatting.Encode(formatting.Hex, txBytes)
+		require.NoError(err)
+		reply := EstimateTxFeeReply{}
+		require.NoError(service.EstimateTxFee(nil, &api.FormattedTx{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		}, &reply))
+		return reply
+	}
+
+	reply := estimate(tx.Bytes())
+	require.Equal(tx.ID(), reply.TxID)
+	require.True(reply.Signed)
+	require.Equal(complexity, reply.Complexity)
+	require.Zero(reply.Gas)
+	require.Equal(json.Uint64(service.vm.TxFee), reply.StaticFee)
+	require.Equal(json.Uint64(service.vm.TxFee), reply.Fee)
+
+	service.vm.ctx.Lock.Lock()
+	service.vm.DynamicFeesTime = service.vm.state.GetTimestamp()
+	service.vm.DynamicFeeConfig = &fee.DynamicConfig{
+		Weights: fee.Dimensions{
+			Bandwidth: 1,
+		},
+		MinGasPrice:               2,
+		MaxGasPrice:               units.Lux,
+		TargetGasPerBlock:         units.Lux,
+		GasPriceChangeDenominator: 8,
+	}
+	service.vm.ctx.Lock.Unlock()
+
+	// Unsigned txs are charged as if they were signed.
+	for _, txBytes := range [][]byte{tx.Bytes(), unsignedTxBytes} {
+		reply := estimate(txBytes)
+		require.Equal(complexity, reply.Complexity)
+		require.Equal(json.Uint64(complexity.Bandwidth), reply.Gas)
+		require.Equal(json.Uint64(2), reply.GasPrice)
+		require.Equal(json.Uint64(service.vm.TxFee), reply.StaticFee)
+		require.Equal(json.Uint64(2*complexity.Bandwidth), reply.Fee)
+	}
+}
+
+func TestGetMempool(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	defer func() {
+		service.vm.ctx.Lock.Lock()
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	service.vm.ctx.Lock.Lock()
+	tx, err := service.vm.txBuilder.NewExportTx(
+		100,
+		service.vm.ctx.XChainID,
+		ids.GenerateTestShortID(),
+		[]*secp256k1.PrivateKey{keys[0]},
+		ids.ShortEmpty, // change addr
+	)
+	require.NoError(err)
+	require.NoError(service.vm.Builder.Add(tx))
+
+	errTest := errors.New("non-nil error")
+	droppedTxID := ids.GenerateTestID()
+	service.vm.Builder.MarkDropped(droppedTxID, errTest)
+	service.vm.ctx.Lock.Unlock()
+
+	reply := api.GetMempoolReply{}
+	require.NoError(service.GetMempool(nil, nil, &reply))
+	require.Len(reply.Txs, 1)
+	require.Equal(tx.ID(), reply.Txs[0].TxID)
+	require.Equal(json.Uint64(len(tx.Bytes())), reply.Txs[0].Size)
+	require.Equal(json.Uint64(service.vm.TxFee), reply.Txs[0].Fee)
+	require.Len(reply.Dropped, 1)
+	require.Equal(droppedTxID, reply.Dropped[0].TxID)
+	require.Equal(errTest.Error(), reply.Dropped[0].Reason)
+}
+
+func TestAdminRemoveMempoolTx(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	defer func() {
+		service.vm.ctx.Lock.Lock()
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+	adminService := &AdminService{vm: service.vm}
+
+	service.vm.ctx.Lock.Lock()
+	tx, err := service.vm.txBuilder.NewExportTx(
+		100,
+		service.vm.ctx.XChainID,
+		ids.GenerateTestShortID(),
+		[]*secp256k1.PrivateKey{keys[0]},
+		ids.ShortEmpty, // change addr
+	)
+	require.NoError(err)
+	require.NoError(service.vm.Builder.Add(tx))
+	service.vm.ctx.Lock.Unlock()
+
+	txID := tx.ID()
+	require.NoError(adminService.RemoveMempoolTx(nil, &api.JSONTxID{TxID: txID}, &api.EmptyReply{}))
+
+	reply := GetTxStatusResponse{}
+	require.NoError(service.GetTxStatus(nil, &GetTxStatusArgs{TxID: txID}, &reply))
+	require.Equal(status.Dropped, reply.Status)
+	require.Equal(ErrRemovedByAdmin.Error(), reply.Reason)
+
+	err = adminService.RemoveMempoolTx(nil, &api.JSONTxID{TxID: txID}, &api.EmptyReply{})
+	require.ErrorIs(err, errTxNotInMempool)
+
+	// The tx isn't added back when it is gossiped to this node.
+	msgBytes, err := message.Build(&message.Tx{Tx: tx.Bytes()})
+	require.NoError(err)
+	require.NoError(service.vm.Network.AppGossip(context.Background(), ids.GenerateTestNodeID(), msgBytes))
+	service.vm.ctx.Lock.Lock()
+	require.False(service.vm.Builder.Has(txID))
+	service.vm.ctx.Lock.Unlock()
+}
+
+func TestEstimateReward(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	defer func() {
+		service.vm.ctx.Lock.Lock()
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	service.vm.ctx.Lock.Lock()
+	chainTime := service.vm.state.GetTimestamp()
+	currentSupply, err := service.vm.state.GetCurrentSupply(constants.PrimaryNetworkID)
+	require.NoError(err)
+	service.vm.ctx.Lock.Unlock()
+
+	stakeAmount := service.vm.MinValidatorStake
+	potentialReward := service.vm.txExecutorBackend.Rewards.Calculate(
+		defaultMinStakingDuration,
+		stakeAmount,
+		currentSupply,
+	)
+	require.NotZero(potentialReward)
+
+	// Validator
+	reply := EstimateRewardReply{}
+	require.NoError(service.EstimateReward(nil, &EstimateRewardArgs{
+		StakeAmount: json.Uint64(stakeAmount),
+		Duration:    json.Uint64(defaultMinStakingDuration / time.Second),
+	}, &reply))
+	require.Equal(json.Uint64(chainTime.Unix()), reply.StartTime)
+	require.Equal(json.Uint64(chainTime.Add(defaultMinStakingDuration).Unix()), reply.EndTime)
+	require.Equal(json.Uint64(currentSupply), reply.CurrentSupply)
+	require.Equal(json.Uint64(potentialReward), reply.PotentialReward)
+	require.Equal(json.Uint64(potentialReward), reply.Reward)
+	require.Zero(reply.DelegationFee)
+
+	// Delegator
+	delegationFee, delegatorReward := reward.Split(potentialReward, 250_000)
+	reply = EstimateRewardReply{}
+	require.NoError(service.EstimateReward(nil, &EstimateRewardArgs{
+		StakeAmount:       json.Uint64(stakeAmount),
+		Duration:          json.Uint64(defaultMinStakingDuration / time.Second),
+		DelegationFeeRate: 25,
+	}, &reply))
+	require.Equal(json.Uint64(potentialReward), reply.PotentialReward)
+	require.Equal(json.Uint64(delegatorReward), reply.Reward)
+	require.Equal(json.Uint64(delegationFee), reply.DelegationFee)
+
+	err = service.EstimateReward(nil, &EstimateRewardArgs{
+		StakeAmount: json.Uint64(stakeAmount),
+		Duration:    json.Uint64(defaultMaxStakingDuration/time.Second) + 1,
+	}, &EstimateRewardReply{})
+	require.ErrorIs(err, errInvalidStakeDuration)
+
+	err = service.EstimateReward(nil, &EstimateRewardArgs{
+		StakeAmount: json.Uint64(stakeAmount),
+		StartTime:   json.Uint64(chainTime.Unix() - 1),
+		Duration:    json.Uint64(defaultMinStakingDuration / time.Second),
+	}, &EstimateRewardReply{})
+	require.ErrorIs(err, errStartTimeInThePast)
+
+	err = service.EstimateReward(nil, &EstimateRewardArgs{
+		Duration: json.Uint64(defaultMinStakingDuration / time.Second),
+	}, &EstimateRewardReply{})
+	require.ErrorIs(err, errNoStakeAmount)
+}
+
+func TestGetRewardHistory(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	defer func() {
+		service.vm.ctx.Lock.Lock()
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	var (
+		nodeID         = ids.GenerateTestNodeID()
+		rewardAddr     = keys[0].Address()
+		rewardedRecord = &state.RewardRecord{
+			TxID:        ids.GenerateTestID(),
+			NodeID:      nodeID,
+			Weight:      10,
+			StartTime:   100,
+			EndTime:     200,
+			Rewarded:    true,
+			Reward:      7,
+			RewardAddrs: []ids.ShortID{rewardAddr},
+		}
+		forfeitedRecord = &state.RewardRecord{
+			TxID:        ids.GenerateTestID(),
+			NodeID:      nodeID,
+			Delegator:   true,
+			Weight:      5,
+			StartTime:   150,
+			EndTime:     300,
+			Forfeited:   3,
+			RewardAddrs: []ids.ShortID{ids.GenerateTestShortID()},
+		}
+	)
+
+	service.vm.ctx.Lock.Lock()
+	service.vm.state.AddRewardRecord(rewardedRecord)
+	service.vm.state.AddRewardRecord(forfeitedRecord)
+	require.NoError(service.vm.state.Commit())
+	service.vm.ctx.Lock.Unlock()
+
+	reply := GetRewardHistoryReply{}
+	require.NoError(service.GetRewardHistory(nil, &GetRewardHistoryArgs{
+		NodeID: nodeID,
+	}, &reply))
+	require.Len(reply.Records, 2)
+	require.Equal(rewardedRecord.TxID, reply.Records[0].TxID)
+	require.True(reply.Records[0].Rewarded)
+	require.Equal(forfeitedRecord.TxID, reply.Records[1].TxID)
+	require.True(reply.Records[1].Delegator)
+	require.Equal(json.Uint64(7), reply.TotalReward)
+	require.Equal(json.Uint64(3), reply.TotalForfeited)
+
+	rewardAddrStr, err := service.addrManager.FormatLocalAddress(rewardAddr)
+	require.NoError(err)
+	reply = GetRewardHistoryReply{}
+	require.NoError(service.GetRewardHistory(nil, &GetRewardHistoryArgs{
+		RewardAddress: rewardAddrStr,
+	}, &reply))
+	require.Len(reply.Records, 1)
+	require.Equal(rewardedRecord.TxID, reply.Records[0].TxID)
+	require.Equal([]string{rewardAddrStr}, reply.Records[0].RewardAddresses)
+
+	// Only the records ending in the requested range are returned.
+	reply = GetRewardHistoryReply{}
+	require.NoError(service.GetRewardHistory(nil, &GetRewardHistoryArgs{
+		NodeID:    nodeID,
+		StartTime: 250,
+	}, &reply))
+	require.Len(reply.Records, 1)
+	require.Equal(forfeitedRecord.TxID, reply.Records[0].TxID)
+	require.Zero(reply.TotalReward)
+
+	err = service.GetRewardHistory(nil, &GetRewardHistoryArgs{}, &GetRewardHistoryReply{})
+	require.ErrorIs(err, errNodeIDXorRewardAddress)
+}
+
+func TestGetValidatorSetDiffs(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	defer func() {
+		service.vm.ctx.Lock.Lock()
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	service.vm.ctx.Lock.Lock()
+	startTime := service.vm.clock.Time().Add(txexecutor.SyncBound).Add(time.Second)
+	endTime := startTime.Add(defaultMinStakingDuration)
+	nodeID := ids.GenerateTestNodeID()
+	tx, err := service.vm.txBuilder.NewAddValidatorTx(
+		service.vm.MinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		nodeID,
+		ids.GenerateTestShortID(),
+		reward.PercentDenominator,
+		[]*secp256k1.PrivateKey{keys[0]},
+		ids.ShortEmpty, // change addr
+	)
+	require.NoError(err)
+	require.NoError(service.vm.Network.IssueTx(context.Background(), tx))
+
+	// Accept the block that adds the pending validator.
+	blk, err := service.vm.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(blk.Accept(context.Background()))
+	require.NoError(service.vm.SetPreference(context.Background(), blk.ID()))
+
+	// Accept the block that moves the validator into the current validator
+	// set.
+	service.vm.clock.Set(startTime)
+	blk, err = service.vm.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(blk.Accept(context.Background()))
+	require.NoError(service.vm.SetPreference(context.Background(), blk.ID()))
+	service.vm.ctx.Lock.Unlock()
+
+	reply := GetValidatorSetDiffsReply{}
+	require.NoError(service.GetValidatorSetDiffs(&http.Request{}, &GetValidatorSetDiffsArgs{
+		SubnetID:   constants.PrimaryNetworkID,
+		FromHeight: 1,
+	}, &reply))
+	require.Equal(json.Uint64(blk.Height()), reply.ToHeight)
+	require.Equal([]APIValidatorSetDiff{
+		{
+			SubnetID: constants.PrimaryNetworkID,
+			Height:   json.Uint64(blk.Height()),
+			Validators: []APIValidatorDiff{
+				{
+					NodeID: nodeID,
+					Weight: json.Uint64(service.vm.MinValidatorStake),
+				},
+			},
+		},
+	}, reply.Diffs)
+
+	// Heights after the last accepted block have no diffs yet.
+	reply = GetValidatorSetDiffsReply{}
+	require.NoError(service.GetValidatorSetDiffs(&http.Request{}, &GetValidatorSetDiffsArgs{
+		SubnetID:   constants.PrimaryNetworkID,
+		FromHeight: json.Uint64(blk.Height() + 1),
+	}, &reply))
+	require.Equal(json.Uint64(blk.Height()), reply.ToHeight)
+	require.Empty(reply.Diffs)
+
+	err = service.GetValidatorSetDiffs(&http.Request{}, &GetValidatorSetDiffsArgs{
+		SubnetID: constants.PrimaryNetworkID,
+	}, &reply)
+	require.ErrorIs(err, state.ErrHeightNotIndexed)
+}
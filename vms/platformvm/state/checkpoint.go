@@ -0,0 +1,85 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"bytes"
+
+	"github.com/luxdefi/node/database"
+	"github.com/luxdefi/node/database/prefixdb"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/hashing"
+	"github.com/luxdefi/node/vms/platformvm/block"
+)
+
+// historicalPrefixes are the prefixes of the databases that only hold blocks
+// before the last accepted block. Every other key written into the database
+// provided to New is part of the state of the chain. Because nested prefixed
+// databases compress their prefixes, the state can't be described as a list of
+// prefixes to include.
+var historicalPrefixes = [][]byte{
+	hashing.ComputeHash256(blockIDPrefix),
+	hashing.ComputeHash256(blockPrefix),
+}
+
+// IsCheckpointKey returns true if [key], in the database provided to New, must
+// be copied to reproduce the state of the chain as of the last accepted block.
+// Historical blocks and the block height index are not included.
+func IsCheckpointKey(key []byte) bool {
+	for _, prefix := range historicalPrefixes {
+		if bytes.HasPrefix(key, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// PutCheckpointBlock writes [blk] into [db], the database provided to New, as
+// an accepted block. This allows a state copied using CheckpointPrefixes to be
+// loaded without any of the blocks before [blk].
+func PutCheckpointBlock(db database.Database, blk block.Block) error {
+	// NewNested is used to match the keys of the databases created in New,
+	// regardless of whether [db] is itself a prefixed database.
+	blockIDDB := prefixdb.NewNested(blockIDPrefix, db)
+	blockDB := prefixdb.NewNested(blockPrefix, db)
+
+	blkID := blk.ID()
+	heightKey := database.PackUInt64(blk.Height())
+	if err := database.PutID(blockIDDB, heightKey, blkID); err != nil {
+		return err
+	}
+	return blockDB.Put(blkID[:], blk.Bytes())
+}
+
+// DeleteBlocksAbove removes the blocks after [height] from [db], the database
+// provided to New, along with their entries in the block height index. This
+// allows the state to be rolled back to the block at [height].
+func DeleteBlocksAbove(db database.Database, height uint64) error {
+	blockIDDB := prefixdb.NewNested(blockIDPrefix, db)
+	blockDB := prefixdb.NewNested(blockPrefix, db)
+
+	blockIDBatch := blockIDDB.NewBatch()
+	blockBatch := blockDB.NewBatch()
+	it := blockIDDB.NewIteratorWithStart(database.PackUInt64(height + 1))
+	defer it.Release()
+	for it.Next() {
+		blkID, err := ids.ToID(it.Value())
+		if err != nil {
+			return err
+		}
+		if err := blockBatch.Delete(blkID[:]); err != nil {
+			return err
+		}
+		if err := blockIDBatch.Delete(it.Key()); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	if err := blockBatch.Write(); err != nil {
+		return err
+	}
+	return blockIDBatch.Write()
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxdefi/node/database"
+	"github.com/luxdefi/node/database/memdb"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/constants"
+	"github.com/luxdefi/node/vms/platformvm/block"
+)
+
+func TestCheckpointCopy(t *testing.T) {
+	require := require.New(t)
+
+	s, db := newInitializedState(require)
+	genesisBlkID := s.GetLastAccepted()
+
+	blk, err := block.NewApricotCommitBlock(genesisBlkID, 1)
+	require.NoError(err)
+	blkID := blk.ID()
+
+	s.AddStatelessBlock(blk)
+	s.SetLastAccepted(blkID)
+	s.SetHeight(blk.Height())
+	require.NoError(s.Commit())
+
+	checkpointDB := memdb.New()
+	it := db.NewIterator()
+	for it.Next() {
+		if IsCheckpointKey(it.Key()) {
+			require.NoError(checkpointDB.Put(it.Key(), it.Value()))
+		}
+	}
+	require.NoError(it.Error())
+	it.Release()
+	require.NoError(PutCheckpointBlock(checkpointDB, blk))
+
+	checkpointState := newStateFromDB(require, checkpointDB).(*state)
+	require.NoError(checkpointState.load())
+
+	require.Equal(blkID, checkpointState.GetLastAccepted())
+	require.Equal(s.GetTimestamp(), checkpointState.GetTimestamp())
+	require.Equal(s.Checksum(), checkpointState.Checksum())
+
+	gotBlk, err := checkpointState.GetStatelessBlock(blkID)
+	require.NoError(err)
+	require.Equal(blk.Bytes(), gotBlk.Bytes())
+
+	gotBlkID, err := checkpointState.GetBlockIDAtHeight(blk.Height())
+	require.NoError(err)
+	require.Equal(blkID, gotBlkID)
+
+	// Blocks before the checkpoint aren't copied.
+	_, err = checkpointState.GetStatelessBlock(genesisBlkID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	_, err = checkpointState.GetCurrentValidator(constants.PrimaryNetworkID, initialNodeID)
+	require.NoError(err)
+
+	chains, err := checkpointState.GetChains(constants.PrimaryNetworkID)
+	require.NoError(err)
+	require.Len(chains, 1)
+
+	_, _, err = checkpointState.GetTx(ids.GenerateTestID())
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestDeleteBlocksAbove(t *testing.T) {
+	require := require.New(t)
+
+	s, db := newInitializedState(require)
+	genesisBlkID := s.GetLastAccepted()
+
+	blkIDs := []ids.ID{genesisBlkID}
+	for height := uint64(1); height <= 3; height++ {
+		blk, err := block.NewApricotCommitBlock(blkIDs[height-1], height)
+		require.NoError(err)
+
+		s.AddStatelessBlock(blk)
+		s.SetLastAccepted(blk.ID())
+		s.SetHeight(height)
+		require.NoError(s.Commit())
+		blkIDs = append(blkIDs, blk.ID())
+	}
+
+	require.NoError(DeleteBlocksAbove(db, 1))
+
+	rolledBackState := newStateFromDB(require, db)
+	for height := uint64(0); height <= 1; height++ {
+		blkID, err := rolledBackState.GetBlockIDAtHeight(height)
+		require.NoError(err)
+		require.Equal(blkIDs[height], blkID)
+
+		_, err = rolledBackState.GetStatelessBlock(blkID)
+		require.NoError(err)
+	}
+	for height := uint64(2); height <= 3; height++ {
+		_, err := rolledBackState.GetBlockIDAtHeight(height)
+		require.ErrorIs(err, database.ErrNotFound)
+
+		_, err = rolledBackState.GetStatelessBlock(blkIDs[height])
+		require.ErrorIs(err, database.ErrNotFound)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"time"
+
+	"github.com/luxdefi/node/database"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/vms/platformvm/txs"
+)
+
+// continuousValidatorPeriod is a renewed staking period of a continuous
+// validator. It is stored for every current validator whose staking period
+// was renewed, as the staking period no longer matches the tx that added the
+// validator.
+type continuousValidatorPeriod struct {
+	// ID of the AddContinuousValidatorTx that added the validator
+	TxID      ids.ID `v0:"true"`
+	StartTime uint64 `v0:"true"` // Unix time in seconds
+	Weight    uint64 `v0:"true"`
+}
+
+// isRenewedStaker returns true if [staker] is a renewed staking period of a
+// continuous validator.
+func isRenewedStaker(staker *Staker) bool {
+	return staker.ContinuousTxID != ids.Empty && staker.ContinuousTxID != staker.TxID
+}
+
+func putContinuousValidatorPeriod(db database.KeyValueWriter, staker *Staker) error {
+	period := &continuousValidatorPeriod{
+		TxID:      staker.ContinuousTxID,
+		StartTime: uint64(staker.StartTime.Unix()),
+		Weight:    staker.Weight,
+	}
+	periodBytes, err := metadataCodec.Marshal(v0, period)
+	if err != nil {
+		return err
+	}
+	return db.Put(staker.TxID[:], periodBytes)
+}
+
+func getContinuousValidatorPeriod(db database.KeyValueReader, txID ids.ID) (*continuousValidatorPeriod, error) {
+	periodBytes, err := db.Get(txID[:])
+	if err != nil {
+		return nil, err
+	}
+	period := &continuousValidatorPeriod{}
+	if _, err := metadataCodec.Unmarshal(periodBytes, period); err != nil {
+		return nil, err
+	}
+	return period, nil
+}
+
+// apply sets the staking period of [staker], which was created from the
+// AddContinuousValidatorTx [tx], to [p].
+func (p *continuousValidatorPeriod) apply(staker *Staker, tx *txs.AddContinuousValidatorTx) {
+	staker.ContinuousTxID = p.TxID
+	staker.Weight = p.Weight
+	staker.StartTime = time.Unix(int64(p.StartTime), 0)
+	staker.EndTime = staker.StartTime.Add(tx.Period())
+	staker.NextTime = staker.EndTime
+}
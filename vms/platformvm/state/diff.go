@@ -10,6 +10,7 @@ import (
 
 	"github.com/luxdefi/node/database"
 	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/set"
 	"github.com/luxdefi/node/vms/components/lux"
 	"github.com/luxdefi/node/vms/platformvm/fx"
 	"github.com/luxdefi/node/vms/platformvm/status"
@@ -37,6 +38,9 @@ type diff struct {
 	// Subnet ID --> supply of native asset of the subnet
 	currentSupply map[ids.ID]uint64
 
+	// nil if the gas price wasn't modified in this diff
+	gasPrice *uint64
+
 	currentStakerDiffs diffStakers
 	// map of subnetID -> nodeID -> total accrued delegatee rewards
 	modifiedDelegateeRewards map[ids.ID]map[ids.NodeID]uint64
@@ -47,11 +51,20 @@ type diff struct {
 	subnetOwners map[ids.ID]fx.Owner
 	// Subnet ID --> Tx that transforms the subnet
 	transformedSubnets map[ids.ID]*txs.Tx
+	// IDs of the AddContinuousValidatorTxs that were stopped in this diff
+	stoppedContinuousValidators set.Set[ids.ID]
+	// Subnet ID --> Validator manager of the subnet
+	subnetManagers map[ids.ID]SubnetManager
+	// Subnet ID + Node ID --> Nonce of the last applied validator manager
+	// message
+	subnetValidatorNonces map[subnetIDNodeID]uint64
 
 	addedChains map[ids.ID][]*txs.Tx
 
 	addedRewardUTXOs map[ids.ID][]*lux.UTXO
 
+	addedRewardRecords []*RewardRecord
+
 	addedTxs map[ids.ID]*txAndStatus
 
 	// map of modified UTXOID -> *UTXO if the UTXO is nil, it has been removed
@@ -106,6 +119,23 @@ func (d *diff) SetCurrentSupply(subnetID ids.ID, currentSupply uint64) {
 	}
 }
 
+func (d *diff) GetGasPrice() (uint64, error) {
+	if d.gasPrice != nil {
+		return *d.gasPrice, nil
+	}
+
+	// If the gas price wasn't modified in this diff, ask the parent state.
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+	return parentState.GetGasPrice()
+}
+
+func (d *diff) SetGasPrice(gasPrice uint64) {
+	d.gasPrice = &gasPrice
+}
+
 func (d *diff) GetCurrentValidator(subnetID ids.ID, nodeID ids.NodeID) (*Staker, error) {
 	// If the validator was modified in this diff, return the modified
 	// validator.
@@ -125,6 +155,18 @@ func (d *diff) GetCurrentValidator(subnetID ids.ID, nodeID ids.NodeID) (*Staker,
 	}
 }
 
+func (d *diff) GetCurrentValidators(subnetID ids.ID) ([]*Staker, error) {
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+	parentValidators, err := parentState.GetCurrentValidators(subnetID)
+	if err != nil {
+		return nil, err
+	}
+	return d.currentStakerDiffs.GetValidators(subnetID, parentValidators), nil
+}
+
 func (d *diff) SetDelegateeReward(subnetID ids.ID, nodeID ids.NodeID, amount uint64) error {
 	if d.modifiedDelegateeRewards == nil {
 		d.modifiedDelegateeRewards = make(map[ids.ID]map[ids.NodeID]uint64)
@@ -279,6 +321,72 @@ func (d *diff) SetSubnetOwner(subnetID ids.ID, owner fx.Owner) {
 	d.subnetOwners[subnetID] = owner
 }
 
+func (d *diff) GetSubnetManager(subnetID ids.ID) (SubnetManager, error) {
+	manager, exists := d.subnetManagers[subnetID]
+	if exists {
+		return manager, nil
+	}
+
+	// If the subnet wasn't converted in this diff, ask the parent state.
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return SubnetManager{}, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+	return parentState.GetSubnetManager(subnetID)
+}
+
+func (d *diff) SetSubnetManager(subnetID ids.ID, manager SubnetManager) {
+	if d.subnetManagers == nil {
+		d.subnetManagers = make(map[ids.ID]SubnetManager)
+	}
+	d.subnetManagers[subnetID] = manager
+}
+
+func (d *diff) GetSubnetValidatorNonce(subnetID ids.ID, nodeID ids.NodeID) (uint64, error) {
+	key := subnetIDNodeID{
+		subnetID: subnetID,
+		nodeID:   nodeID,
+	}
+	nonce, exists := d.subnetValidatorNonces[key]
+	if exists {
+		return nonce, nil
+	}
+
+	// If the nonce wasn't modified in this diff, ask the parent state.
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+	return parentState.GetSubnetValidatorNonce(subnetID, nodeID)
+}
+
+func (d *diff) SetSubnetValidatorNonce(subnetID ids.ID, nodeID ids.NodeID, nonce uint64) {
+	if d.subnetValidatorNonces == nil {
+		d.subnetValidatorNonces = make(map[subnetIDNodeID]uint64)
+	}
+	key := subnetIDNodeID{
+		subnetID: subnetID,
+		nodeID:   nodeID,
+	}
+	d.subnetValidatorNonces[key] = nonce
+}
+
+func (d *diff) IsContinuousValidatorStopped(txID ids.ID) (bool, error) {
+	if d.stoppedContinuousValidators.Contains(txID) {
+		return true, nil
+	}
+
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return false, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+	return parentState.IsContinuousValidatorStopped(txID)
+}
+
+func (d *diff) StopContinuousValidator(txID ids.ID) {
+	d.stoppedContinuousValidators.Add(txID)
+}
+
 func (d *diff) GetSubnetTransformation(subnetID ids.ID) (*txs.Tx, error) {
 	tx, exists := d.transformedSubnets[subnetID]
 	if exists {
@@ -349,6 +457,10 @@ func (d *diff) AddRewardUTXO(txID ids.ID, utxo *lux.UTXO) {
 	d.addedRewardUTXOs[txID] = append(d.addedRewardUTXOs[txID], utxo)
 }
 
+func (d *diff) AddRewardRecord(record *RewardRecord) {
+	d.addedRewardRecords = append(d.addedRewardRecords, record)
+}
+
 func (d *diff) GetUTXO(utxoID ids.ID) (*lux.UTXO, error) {
 	utxo, modified := d.modifiedUTXOs[utxoID]
 	if !modified {
@@ -389,10 +501,16 @@ func (d *diff) Apply(baseState Chain) error {
 	for subnetID, supply := range d.currentSupply {
 		baseState.SetCurrentSupply(subnetID, supply)
 	}
+	if d.gasPrice != nil {
+		baseState.SetGasPrice(*d.gasPrice)
+	}
 	for _, subnetValidatorDiffs := range d.currentStakerDiffs.validatorDiffs {
 		for _, validatorDiff := range subnetValidatorDiffs {
 			switch validatorDiff.validatorStatus {
 			case added:
+				if validatorDiff.replacedValidator != nil {
+					baseState.DeleteCurrentValidator(validatorDiff.replacedValidator)
+				}
 				baseState.PutCurrentValidator(validatorDiff.validator)
 			case deleted:
 				baseState.DeleteCurrentValidator(validatorDiff.validator)
@@ -455,6 +573,9 @@ func (d *diff) Apply(baseState Chain) error {
 			baseState.AddRewardUTXO(txID, utxo)
 		}
 	}
+	for _, record := range d.addedRewardRecords {
+		baseState.AddRewardRecord(record)
+	}
 	for utxoID, utxo := range d.modifiedUTXOs {
 		if utxo != nil {
 			baseState.AddUTXO(utxo)
@@ -465,5 +586,14 @@ func (d *diff) Apply(baseState Chain) error {
 	for subnetID, owner := range d.subnetOwners {
 		baseState.SetSubnetOwner(subnetID, owner)
 	}
+	for txID := range d.stoppedContinuousValidators {
+		baseState.StopContinuousValidator(txID)
+	}
+	for subnetID, manager := range d.subnetManagers {
+		baseState.SetSubnetManager(subnetID, manager)
+	}
+	for key, nonce := range d.subnetValidatorNonces {
+		baseState.SetSubnetValidatorNonce(key.subnetID, key.nodeID, nonce)
+	}
 	return nil
 }
@@ -74,6 +74,41 @@ func TestDiffCurrentSupply(t *testing.T) {
 	require.Equal(initialCurrentSupply, returnedBaseCurrentSupply)
 }
 
+func TestDiffGasPrice(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	lastAcceptedID := ids.GenerateTestID()
+	state, _ := newInitializedState(require)
+	versions := NewMockVersions(ctrl)
+	versions.EXPECT().GetState(lastAcceptedID).AnyTimes().Return(state, true)
+
+	state.SetGasPrice(10)
+
+	d, err := NewDiff(lastAcceptedID, versions)
+	require.NoError(err)
+
+	gasPrice, err := d.GetGasPrice()
+	require.NoError(err)
+	require.Equal(uint64(10), gasPrice)
+
+	d.SetGasPrice(20)
+
+	gasPrice, err = d.GetGasPrice()
+	require.NoError(err)
+	require.Equal(uint64(20), gasPrice)
+
+	gasPrice, err = state.GetGasPrice()
+	require.NoError(err)
+	require.Equal(uint64(10), gasPrice)
+
+	require.NoError(d.Apply(state))
+
+	gasPrice, err = state.GetGasPrice()
+	require.NoError(err)
+	require.Equal(uint64(20), gasPrice)
+}
+
 func TestDiffCurrentValidator(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
@@ -102,6 +137,17 @@ func TestDiffCurrentValidator(t *testing.T) {
 	require.NoError(err)
 	require.Equal(currentValidator, gotCurrentValidator)
 
+	// Assert that the current validator is merged with the parent validators
+	parentValidator := &Staker{
+		TxID:     ids.GenerateTestID(),
+		SubnetID: currentValidator.SubnetID,
+		NodeID:   ids.GenerateTestNodeID(),
+	}
+	state.EXPECT().GetCurrentValidators(currentValidator.SubnetID).Return([]*Staker{parentValidator}, nil).Times(1)
+	gotCurrentValidators, err := d.GetCurrentValidators(currentValidator.SubnetID)
+	require.NoError(err)
+	require.ElementsMatch([]*Staker{parentValidator, currentValidator}, gotCurrentValidators)
+
 	// Delete the current validator
 	d.DeleteCurrentValidator(currentValidator)
 
@@ -109,6 +155,11 @@ func TestDiffCurrentValidator(t *testing.T) {
 	state.EXPECT().GetCurrentValidator(currentValidator.SubnetID, currentValidator.NodeID).Return(nil, database.ErrNotFound).Times(1)
 	_, err = d.GetCurrentValidator(currentValidator.SubnetID, currentValidator.NodeID)
 	require.ErrorIs(err, database.ErrNotFound)
+
+	state.EXPECT().GetCurrentValidators(currentValidator.SubnetID).Return([]*Staker{parentValidator}, nil).Times(1)
+	gotCurrentValidators, err = d.GetCurrentValidators(currentValidator.SubnetID)
+	require.NoError(err)
+	require.Equal([]*Staker{parentValidator}, gotCurrentValidators)
 }
 
 func TestDiffPendingValidator(t *testing.T) {
@@ -517,6 +568,14 @@ func assertChainsEqual(t *testing.T, expected, actual Chain) {
 	require.NoError(err)
 
 	require.Equal(expectedCurrentSupply, actualCurrentSupply)
+
+	expectedGasPrice, err := expected.GetGasPrice()
+	require.NoError(err)
+
+	actualGasPrice, err := actual.GetGasPrice()
+	require.NoError(err)
+
+	require.Equal(expectedGasPrice, actualGasPrice)
 }
 
 func TestDiffSubnetOwner(t *testing.T) {
@@ -60,6 +60,18 @@ func (mr *MockChainMockRecorder) AddChain(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddChain", reflect.TypeOf((*MockChain)(nil).AddChain), arg0)
 }
 
+// AddRewardRecord mocks base method.
+func (m *MockChain) AddRewardRecord(arg0 *RewardRecord) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddRewardRecord", arg0)
+}
+
+// AddRewardRecord indicates an expected call of AddRewardRecord.
+func (mr *MockChainMockRecorder) AddRewardRecord(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRewardRecord", reflect.TypeOf((*MockChain)(nil).AddRewardRecord), arg0)
+}
+
 // AddRewardUTXO mocks base method.
 func (m *MockChain) AddRewardUTXO(arg0 ids.ID, arg1 *lux.UTXO) {
 	m.ctrl.T.Helper()
@@ -240,6 +252,21 @@ func (mr *MockChainMockRecorder) GetCurrentValidator(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentValidator", reflect.TypeOf((*MockChain)(nil).GetCurrentValidator), arg0, arg1)
 }
 
+// GetCurrentValidators mocks base method.
+func (m *MockChain) GetCurrentValidators(arg0 ids.ID) ([]*Staker, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentValidators", arg0)
+	ret0, _ := ret[0].([]*Staker)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentValidators indicates an expected call of GetCurrentValidators.
+func (mr *MockChainMockRecorder) GetCurrentValidators(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentValidators", reflect.TypeOf((*MockChain)(nil).GetCurrentValidators), arg0)
+}
+
 // GetDelegateeReward mocks base method.
 func (m *MockChain) GetDelegateeReward(arg0 ids.ID, arg1 ids.NodeID) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -255,6 +282,21 @@ func (mr *MockChainMockRecorder) GetDelegateeReward(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegateeReward", reflect.TypeOf((*MockChain)(nil).GetDelegateeReward), arg0, arg1)
 }
 
+// GetGasPrice mocks base method.
+func (m *MockChain) GetGasPrice() (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGasPrice")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGasPrice indicates an expected call of GetGasPrice.
+func (mr *MockChainMockRecorder) GetGasPrice() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGasPrice", reflect.TypeOf((*MockChain)(nil).GetGasPrice))
+}
+
 // GetPendingDelegatorIterator mocks base method.
 func (m *MockChain) GetPendingDelegatorIterator(arg0 ids.ID, arg1 ids.NodeID) (StakerIterator, error) {
 	m.ctrl.T.Helper()
@@ -300,6 +342,21 @@ func (mr *MockChainMockRecorder) GetPendingValidator(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingValidator", reflect.TypeOf((*MockChain)(nil).GetPendingValidator), arg0, arg1)
 }
 
+// GetSubnetManager mocks base method.
+func (m *MockChain) GetSubnetManager(arg0 ids.ID) (SubnetManager, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetManager", arg0)
+	ret0, _ := ret[0].(SubnetManager)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetManager indicates an expected call of GetSubnetManager.
+func (mr *MockChainMockRecorder) GetSubnetManager(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetManager", reflect.TypeOf((*MockChain)(nil).GetSubnetManager), arg0)
+}
+
 // GetSubnetOwner mocks base method.
 func (m *MockChain) GetSubnetOwner(arg0 ids.ID) (fx.Owner, error) {
 	m.ctrl.T.Helper()
@@ -330,6 +387,21 @@ func (mr *MockChainMockRecorder) GetSubnetTransformation(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetTransformation", reflect.TypeOf((*MockChain)(nil).GetSubnetTransformation), arg0)
 }
 
+// GetSubnetValidatorNonce mocks base method.
+func (m *MockChain) GetSubnetValidatorNonce(arg0 ids.ID, arg1 ids.NodeID) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetValidatorNonce", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetValidatorNonce indicates an expected call of GetSubnetValidatorNonce.
+func (mr *MockChainMockRecorder) GetSubnetValidatorNonce(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetValidatorNonce", reflect.TypeOf((*MockChain)(nil).GetSubnetValidatorNonce), arg0, arg1)
+}
+
 // GetTimestamp mocks base method.
 func (m *MockChain) GetTimestamp() time.Time {
 	m.ctrl.T.Helper()
@@ -375,6 +447,21 @@ func (mr *MockChainMockRecorder) GetUTXO(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUTXO", reflect.TypeOf((*MockChain)(nil).GetUTXO), arg0)
 }
 
+// IsContinuousValidatorStopped mocks base method.
+func (m *MockChain) IsContinuousValidatorStopped(arg0 ids.ID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsContinuousValidatorStopped", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsContinuousValidatorStopped indicates an expected call of IsContinuousValidatorStopped.
+func (mr *MockChainMockRecorder) IsContinuousValidatorStopped(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsContinuousValidatorStopped", reflect.TypeOf((*MockChain)(nil).IsContinuousValidatorStopped), arg0)
+}
+
 // PutCurrentDelegator mocks base method.
 func (m *MockChain) PutCurrentDelegator(arg0 *Staker) {
 	m.ctrl.T.Helper()
@@ -449,6 +536,30 @@ func (mr *MockChainMockRecorder) SetDelegateeReward(arg0, arg1, arg2 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDelegateeReward", reflect.TypeOf((*MockChain)(nil).SetDelegateeReward), arg0, arg1, arg2)
 }
 
+// SetGasPrice mocks base method.
+func (m *MockChain) SetGasPrice(arg0 uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetGasPrice", arg0)
+}
+
+// SetGasPrice indicates an expected call of SetGasPrice.
+func (mr *MockChainMockRecorder) SetGasPrice(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGasPrice", reflect.TypeOf((*MockChain)(nil).SetGasPrice), arg0)
+}
+
+// SetSubnetManager mocks base method.
+func (m *MockChain) SetSubnetManager(arg0 ids.ID, arg1 SubnetManager) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSubnetManager", arg0, arg1)
+}
+
+// SetSubnetManager indicates an expected call of SetSubnetManager.
+func (mr *MockChainMockRecorder) SetSubnetManager(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetManager", reflect.TypeOf((*MockChain)(nil).SetSubnetManager), arg0, arg1)
+}
+
 // SetSubnetOwner mocks base method.
 func (m *MockChain) SetSubnetOwner(arg0 ids.ID, arg1 fx.Owner) {
 	m.ctrl.T.Helper()
@@ -461,6 +572,18 @@ func (mr *MockChainMockRecorder) SetSubnetOwner(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetOwner", reflect.TypeOf((*MockChain)(nil).SetSubnetOwner), arg0, arg1)
 }
 
+// SetSubnetValidatorNonce mocks base method.
+func (m *MockChain) SetSubnetValidatorNonce(arg0 ids.ID, arg1 ids.NodeID, arg2 uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSubnetValidatorNonce", arg0, arg1, arg2)
+}
+
+// SetSubnetValidatorNonce indicates an expected call of SetSubnetValidatorNonce.
+func (mr *MockChainMockRecorder) SetSubnetValidatorNonce(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetValidatorNonce", reflect.TypeOf((*MockChain)(nil).SetSubnetValidatorNonce), arg0, arg1, arg2)
+}
+
 // SetTimestamp mocks base method.
 func (m *MockChain) SetTimestamp(arg0 time.Time) {
 	m.ctrl.T.Helper()
@@ -473,6 +596,18 @@ func (mr *MockChainMockRecorder) SetTimestamp(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTimestamp", reflect.TypeOf((*MockChain)(nil).SetTimestamp), arg0)
 }
 
+// StopContinuousValidator mocks base method.
+func (m *MockChain) StopContinuousValidator(arg0 ids.ID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "StopContinuousValidator", arg0)
+}
+
+// StopContinuousValidator indicates an expected call of StopContinuousValidator.
+func (mr *MockChainMockRecorder) StopContinuousValidator(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopContinuousValidator", reflect.TypeOf((*MockChain)(nil).StopContinuousValidator), arg0)
+}
+
 // MockDiff is a mock of Diff interface.
 type MockDiff struct {
 	ctrl     *gomock.Controller
@@ -508,6 +643,18 @@ func (mr *MockDiffMockRecorder) AddChain(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddChain", reflect.TypeOf((*MockDiff)(nil).AddChain), arg0)
 }
 
+// AddRewardRecord mocks base method.
+func (m *MockDiff) AddRewardRecord(arg0 *RewardRecord) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddRewardRecord", arg0)
+}
+
+// AddRewardRecord indicates an expected call of AddRewardRecord.
+func (mr *MockDiffMockRecorder) AddRewardRecord(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRewardRecord", reflect.TypeOf((*MockDiff)(nil).AddRewardRecord), arg0)
+}
+
 // AddRewardUTXO mocks base method.
 func (m *MockDiff) AddRewardUTXO(arg0 ids.ID, arg1 *lux.UTXO) {
 	m.ctrl.T.Helper()
@@ -702,6 +849,21 @@ func (mr *MockDiffMockRecorder) GetCurrentValidator(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentValidator", reflect.TypeOf((*MockDiff)(nil).GetCurrentValidator), arg0, arg1)
 }
 
+// GetCurrentValidators mocks base method.
+func (m *MockDiff) GetCurrentValidators(arg0 ids.ID) ([]*Staker, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentValidators", arg0)
+	ret0, _ := ret[0].([]*Staker)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentValidators indicates an expected call of GetCurrentValidators.
+func (mr *MockDiffMockRecorder) GetCurrentValidators(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentValidators", reflect.TypeOf((*MockDiff)(nil).GetCurrentValidators), arg0)
+}
+
 // GetDelegateeReward mocks base method.
 func (m *MockDiff) GetDelegateeReward(arg0 ids.ID, arg1 ids.NodeID) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -717,6 +879,21 @@ func (mr *MockDiffMockRecorder) GetDelegateeReward(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegateeReward", reflect.TypeOf((*MockDiff)(nil).GetDelegateeReward), arg0, arg1)
 }
 
+// GetGasPrice mocks base method.
+func (m *MockDiff) GetGasPrice() (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGasPrice")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGasPrice indicates an expected call of GetGasPrice.
+func (mr *MockDiffMockRecorder) GetGasPrice() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGasPrice", reflect.TypeOf((*MockDiff)(nil).GetGasPrice))
+}
+
 // GetPendingDelegatorIterator mocks base method.
 func (m *MockDiff) GetPendingDelegatorIterator(arg0 ids.ID, arg1 ids.NodeID) (StakerIterator, error) {
 	m.ctrl.T.Helper()
@@ -762,6 +939,21 @@ func (mr *MockDiffMockRecorder) GetPendingValidator(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingValidator", reflect.TypeOf((*MockDiff)(nil).GetPendingValidator), arg0, arg1)
 }
 
+// GetSubnetManager mocks base method.
+func (m *MockDiff) GetSubnetManager(arg0 ids.ID) (SubnetManager, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetManager", arg0)
+	ret0, _ := ret[0].(SubnetManager)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetManager indicates an expected call of GetSubnetManager.
+func (mr *MockDiffMockRecorder) GetSubnetManager(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetManager", reflect.TypeOf((*MockDiff)(nil).GetSubnetManager), arg0)
+}
+
 // GetSubnetOwner mocks base method.
 func (m *MockDiff) GetSubnetOwner(arg0 ids.ID) (fx.Owner, error) {
 	m.ctrl.T.Helper()
@@ -792,6 +984,21 @@ func (mr *MockDiffMockRecorder) GetSubnetTransformation(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetTransformation", reflect.TypeOf((*MockDiff)(nil).GetSubnetTransformation), arg0)
 }
 
+// GetSubnetValidatorNonce mocks base method.
+func (m *MockDiff) GetSubnetValidatorNonce(arg0 ids.ID, arg1 ids.NodeID) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetValidatorNonce", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetValidatorNonce indicates an expected call of GetSubnetValidatorNonce.
+func (mr *MockDiffMockRecorder) GetSubnetValidatorNonce(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetValidatorNonce", reflect.TypeOf((*MockDiff)(nil).GetSubnetValidatorNonce), arg0, arg1)
+}
+
 // GetTimestamp mocks base method.
 func (m *MockDiff) GetTimestamp() time.Time {
 	m.ctrl.T.Helper()
@@ -837,6 +1044,21 @@ func (mr *MockDiffMockRecorder) GetUTXO(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUTXO", reflect.TypeOf((*MockDiff)(nil).GetUTXO), arg0)
 }
 
+// IsContinuousValidatorStopped mocks base method.
+func (m *MockDiff) IsContinuousValidatorStopped(arg0 ids.ID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsContinuousValidatorStopped", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsContinuousValidatorStopped indicates an expected call of IsContinuousValidatorStopped.
+func (mr *MockDiffMockRecorder) IsContinuousValidatorStopped(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsContinuousValidatorStopped", reflect.TypeOf((*MockDiff)(nil).IsContinuousValidatorStopped), arg0)
+}
+
 // PutCurrentDelegator mocks base method.
 func (m *MockDiff) PutCurrentDelegator(arg0 *Staker) {
 	m.ctrl.T.Helper()
@@ -911,6 +1133,30 @@ func (mr *MockDiffMockRecorder) SetDelegateeReward(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDelegateeReward", reflect.TypeOf((*MockDiff)(nil).SetDelegateeReward), arg0, arg1, arg2)
 }
 
+// SetGasPrice mocks base method.
+func (m *MockDiff) SetGasPrice(arg0 uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetGasPrice", arg0)
+}
+
+// SetGasPrice indicates an expected call of SetGasPrice.
+func (mr *MockDiffMockRecorder) SetGasPrice(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGasPrice", reflect.TypeOf((*MockDiff)(nil).SetGasPrice), arg0)
+}
+
+// SetSubnetManager mocks base method.
+func (m *MockDiff) SetSubnetManager(arg0 ids.ID, arg1 SubnetManager) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSubnetManager", arg0, arg1)
+}
+
+// SetSubnetManager indicates an expected call of SetSubnetManager.
+func (mr *MockDiffMockRecorder) SetSubnetManager(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetManager", reflect.TypeOf((*MockDiff)(nil).SetSubnetManager), arg0, arg1)
+}
+
 // SetSubnetOwner mocks base method.
 func (m *MockDiff) SetSubnetOwner(arg0 ids.ID, arg1 fx.Owner) {
 	m.ctrl.T.Helper()
@@ -923,6 +1169,18 @@ func (mr *MockDiffMockRecorder) SetSubnetOwner(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetOwner", reflect.TypeOf((*MockDiff)(nil).SetSubnetOwner), arg0, arg1)
 }
 
+// SetSubnetValidatorNonce mocks base method.
+func (m *MockDiff) SetSubnetValidatorNonce(arg0 ids.ID, arg1 ids.NodeID, arg2 uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSubnetValidatorNonce", arg0, arg1, arg2)
+}
+
+// SetSubnetValidatorNonce indicates an expected call of SetSubnetValidatorNonce.
+func (mr *MockDiffMockRecorder) SetSubnetValidatorNonce(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetValidatorNonce", reflect.TypeOf((*MockDiff)(nil).SetSubnetValidatorNonce), arg0, arg1, arg2)
+}
+
 // SetTimestamp mocks base method.
 func (m *MockDiff) SetTimestamp(arg0 time.Time) {
 	m.ctrl.T.Helper()
@@ -935,6 +1193,18 @@ func (mr *MockDiffMockRecorder) SetTimestamp(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTimestamp", reflect.TypeOf((*MockDiff)(nil).SetTimestamp), arg0)
 }
 
+// StopContinuousValidator mocks base method.
+func (m *MockDiff) StopContinuousValidator(arg0 ids.ID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "StopContinuousValidator", arg0)
+}
+
+// StopContinuousValidator indicates an expected call of StopContinuousValidator.
+func (mr *MockDiffMockRecorder) StopContinuousValidator(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopContinuousValidator", reflect.TypeOf((*MockDiff)(nil).StopContinuousValidator), arg0)
+}
+
 // MockState is a mock of State interface.
 type MockState struct {
 	ctrl     *gomock.Controller
@@ -982,6 +1252,18 @@ func (mr *MockStateMockRecorder) AddChain(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddChain", reflect.TypeOf((*MockState)(nil).AddChain), arg0)
 }
 
+// AddRewardRecord mocks base method.
+func (m *MockState) AddRewardRecord(arg0 *RewardRecord) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddRewardRecord", arg0)
+}
+
+// AddRewardRecord indicates an expected call of AddRewardRecord.
+func (mr *MockStateMockRecorder) AddRewardRecord(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRewardRecord", reflect.TypeOf((*MockState)(nil).AddRewardRecord), arg0)
+}
+
 // AddRewardUTXO mocks base method.
 func (m *MockState) AddRewardUTXO(arg0 ids.ID, arg1 *lux.UTXO) {
 	m.ctrl.T.Helper()
@@ -1289,6 +1571,21 @@ func (mr *MockStateMockRecorder) GetCurrentValidator(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentValidator", reflect.TypeOf((*MockState)(nil).GetCurrentValidator), arg0, arg1)
 }
 
+// GetCurrentValidators mocks base method.
+func (m *MockState) GetCurrentValidators(arg0 ids.ID) ([]*Staker, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentValidators", arg0)
+	ret0, _ := ret[0].([]*Staker)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentValidators indicates an expected call of GetCurrentValidators.
+func (mr *MockStateMockRecorder) GetCurrentValidators(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentValidators", reflect.TypeOf((*MockState)(nil).GetCurrentValidators), arg0)
+}
+
 // GetDelegateeReward mocks base method.
 func (m *MockState) GetDelegateeReward(arg0 ids.ID, arg1 ids.NodeID) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -1304,6 +1601,21 @@ func (mr *MockStateMockRecorder) GetDelegateeReward(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegateeReward", reflect.TypeOf((*MockState)(nil).GetDelegateeReward), arg0, arg1)
 }
 
+// GetGasPrice mocks base method.
+func (m *MockState) GetGasPrice() (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGasPrice")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGasPrice indicates an expected call of GetGasPrice.
+func (mr *MockStateMockRecorder) GetGasPrice() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGasPrice", reflect.TypeOf((*MockState)(nil).GetGasPrice))
+}
+
 // GetLastAccepted mocks base method.
 func (m *MockState) GetLastAccepted() ids.ID {
 	m.ctrl.T.Helper()
@@ -1363,6 +1675,36 @@ func (mr *MockStateMockRecorder) GetPendingValidator(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingValidator", reflect.TypeOf((*MockState)(nil).GetPendingValidator), arg0, arg1)
 }
 
+// GetRewardRecords mocks base method.
+func (m *MockState) GetRewardRecords(arg0 ids.NodeID) ([]*RewardRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRewardRecords", arg0)
+	ret0, _ := ret[0].([]*RewardRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRewardRecords indicates an expected call of GetRewardRecords.
+func (mr *MockStateMockRecorder) GetRewardRecords(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRewardRecords", reflect.TypeOf((*MockState)(nil).GetRewardRecords), arg0)
+}
+
+// GetRewardRecordsByAddress mocks base method.
+func (m *MockState) GetRewardRecordsByAddress(arg0 ids.ShortID) ([]*RewardRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRewardRecordsByAddress", arg0)
+	ret0, _ := ret[0].([]*RewardRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRewardRecordsByAddress indicates an expected call of GetRewardRecordsByAddress.
+func (mr *MockStateMockRecorder) GetRewardRecordsByAddress(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRewardRecordsByAddress", reflect.TypeOf((*MockState)(nil).GetRewardRecordsByAddress), arg0)
+}
+
 // GetRewardUTXOs mocks base method.
 func (m *MockState) GetRewardUTXOs(arg0 ids.ID) ([]*lux.UTXO, error) {
 	m.ctrl.T.Helper()
@@ -1408,6 +1750,21 @@ func (mr *MockStateMockRecorder) GetStatelessBlock(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatelessBlock", reflect.TypeOf((*MockState)(nil).GetStatelessBlock), arg0)
 }
 
+// GetSubnetManager mocks base method.
+func (m *MockState) GetSubnetManager(arg0 ids.ID) (SubnetManager, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetManager", arg0)
+	ret0, _ := ret[0].(SubnetManager)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetManager indicates an expected call of GetSubnetManager.
+func (mr *MockStateMockRecorder) GetSubnetManager(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetManager", reflect.TypeOf((*MockState)(nil).GetSubnetManager), arg0)
+}
+
 // GetSubnetOwner mocks base method.
 func (m *MockState) GetSubnetOwner(arg0 ids.ID) (fx.Owner, error) {
 	m.ctrl.T.Helper()
@@ -1438,6 +1795,21 @@ func (mr *MockStateMockRecorder) GetSubnetTransformation(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetTransformation", reflect.TypeOf((*MockState)(nil).GetSubnetTransformation), arg0)
 }
 
+// GetSubnetValidatorNonce mocks base method.
+func (m *MockState) GetSubnetValidatorNonce(arg0 ids.ID, arg1 ids.NodeID) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetValidatorNonce", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetValidatorNonce indicates an expected call of GetSubnetValidatorNonce.
+func (mr *MockStateMockRecorder) GetSubnetValidatorNonce(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetValidatorNonce", reflect.TypeOf((*MockState)(nil).GetSubnetValidatorNonce), arg0, arg1)
+}
+
 // GetSubnets mocks base method.
 func (m *MockState) GetSubnets() ([]*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -1514,6 +1886,36 @@ func (mr *MockStateMockRecorder) GetUptime(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUptime", reflect.TypeOf((*MockState)(nil).GetUptime), arg0, arg1)
 }
 
+// GetValidatorDiffs mocks base method.
+func (m *MockState) GetValidatorDiffs(arg0 context.Context, arg1 ids.ID, arg2, arg3 uint64) ([]*ValidatorDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidatorDiffs", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*ValidatorDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValidatorDiffs indicates an expected call of GetValidatorDiffs.
+func (mr *MockStateMockRecorder) GetValidatorDiffs(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidatorDiffs", reflect.TypeOf((*MockState)(nil).GetValidatorDiffs), arg0, arg1, arg2, arg3)
+}
+
+// IsContinuousValidatorStopped mocks base method.
+func (m *MockState) IsContinuousValidatorStopped(arg0 ids.ID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsContinuousValidatorStopped", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsContinuousValidatorStopped indicates an expected call of IsContinuousValidatorStopped.
+func (mr *MockStateMockRecorder) IsContinuousValidatorStopped(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsContinuousValidatorStopped", reflect.TypeOf((*MockState)(nil).IsContinuousValidatorStopped), arg0)
+}
+
 // PruneAndIndex mocks base method.
 func (m *MockState) PruneAndIndex(arg0 sync.Locker, arg1 logging.Logger) error {
 	m.ctrl.T.Helper()
@@ -1602,6 +2004,18 @@ func (mr *MockStateMockRecorder) SetDelegateeReward(arg0, arg1, arg2 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDelegateeReward", reflect.TypeOf((*MockState)(nil).SetDelegateeReward), arg0, arg1, arg2)
 }
 
+// SetGasPrice mocks base method.
+func (m *MockState) SetGasPrice(arg0 uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetGasPrice", arg0)
+}
+
+// SetGasPrice indicates an expected call of SetGasPrice.
+func (mr *MockStateMockRecorder) SetGasPrice(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGasPrice", reflect.TypeOf((*MockState)(nil).SetGasPrice), arg0)
+}
+
 // SetHeight mocks base method.
 func (m *MockState) SetHeight(arg0 uint64) {
 	m.ctrl.T.Helper()
@@ -1626,6 +2040,18 @@ func (mr *MockStateMockRecorder) SetLastAccepted(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLastAccepted", reflect.TypeOf((*MockState)(nil).SetLastAccepted), arg0)
 }
 
+// SetSubnetManager mocks base method.
+func (m *MockState) SetSubnetManager(arg0 ids.ID, arg1 SubnetManager) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSubnetManager", arg0, arg1)
+}
+
+// SetSubnetManager indicates an expected call of SetSubnetManager.
+func (mr *MockStateMockRecorder) SetSubnetManager(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetManager", reflect.TypeOf((*MockState)(nil).SetSubnetManager), arg0, arg1)
+}
+
 // SetSubnetOwner mocks base method.
 func (m *MockState) SetSubnetOwner(arg0 ids.ID, arg1 fx.Owner) {
 	m.ctrl.T.Helper()
@@ -1638,6 +2064,18 @@ func (mr *MockStateMockRecorder) SetSubnetOwner(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetOwner", reflect.TypeOf((*MockState)(nil).SetSubnetOwner), arg0, arg1)
 }
 
+// SetSubnetValidatorNonce mocks base method.
+func (m *MockState) SetSubnetValidatorNonce(arg0 ids.ID, arg1 ids.NodeID, arg2 uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSubnetValidatorNonce", arg0, arg1, arg2)
+}
+
+// SetSubnetValidatorNonce indicates an expected call of SetSubnetValidatorNonce.
+func (mr *MockStateMockRecorder) SetSubnetValidatorNonce(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetValidatorNonce", reflect.TypeOf((*MockState)(nil).SetSubnetValidatorNonce), arg0, arg1, arg2)
+}
+
 // SetTimestamp mocks base method.
 func (m *MockState) SetTimestamp(arg0 time.Time) {
 	m.ctrl.T.Helper()
@@ -1679,6 +2117,18 @@ func (mr *MockStateMockRecorder) ShouldPrune() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShouldPrune", reflect.TypeOf((*MockState)(nil).ShouldPrune))
 }
 
+// StopContinuousValidator mocks base method.
+func (m *MockState) StopContinuousValidator(arg0 ids.ID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "StopContinuousValidator", arg0)
+}
+
+// StopContinuousValidator indicates an expected call of StopContinuousValidator.
+func (mr *MockStateMockRecorder) StopContinuousValidator(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopContinuousValidator", reflect.TypeOf((*MockState)(nil).StopContinuousValidator), arg0)
+}
+
 // UTXOIDs mocks base method.
 func (m *MockState) UTXOIDs(arg0 []byte, arg1 ids.ID, arg2 int) ([]ids.ID, error) {
 	m.ctrl.T.Helper()
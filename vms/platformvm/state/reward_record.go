@@ -0,0 +1,45 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"github.com/luxdefi/node/database"
+	"github.com/luxdefi/node/ids"
+)
+
+// RewardRecord is the outcome of the RewardValidatorTx that removed a staker
+// from the current staker set.
+type RewardRecord struct {
+	// ID of the tx that added the staker
+	TxID      ids.ID     `v0:"true"`
+	NodeID    ids.NodeID `v0:"true"`
+	SubnetID  ids.ID     `v0:"true"`
+	Delegator bool       `v0:"true"`
+	Weight    uint64     `v0:"true"`
+	StartTime uint64     `v0:"true"` // Unix time in seconds
+	EndTime   uint64     `v0:"true"` // Unix time in seconds
+
+	// Rewarded is true if the staker was rewarded.
+	Rewarded bool `v0:"true"`
+	// Reward paid to the staker's rewards owner.
+	Reward uint64 `v0:"true"`
+	// For delegators, the delegation fee credited to the validator. For
+	// validators, the accrued delegation fees paid to the validator's
+	// delegation rewards owner.
+	DelegationFee uint64 `v0:"true"`
+	// Forfeited is the potential reward that wasn't minted because the staker
+	// wasn't rewarded.
+	Forfeited uint64 `v0:"true"`
+	// Addresses of the owners of the rewards
+	RewardAddrs []ids.ShortID `v0:"true"`
+}
+
+// rewardRecordIndexKey returns the key of [record] in the index under [owner],
+// which sorts the records of [owner] by end time.
+func rewardRecordIndexKey(owner []byte, record *RewardRecord) []byte {
+	key := make([]byte, 0, len(owner)+database.Uint64Size+ids.IDLen)
+	key = append(key, owner...)
+	key = append(key, database.PackUInt64(record.EndTime)...)
+	return append(key, record.TxID[:]...)
+}
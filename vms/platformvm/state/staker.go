@@ -56,6 +56,33 @@ type Staker struct {
 	// [priorities.go] and depends on if the stakers are in the pending or
 	// current validator set.
 	Priority txs.Priority
+
+	// ContinuousTxID is the ID of the AddContinuousValidatorTx that added this
+	// staker. It is empty if the staker isn't a continuous validator.
+	//
+	// Each staking period of a continuous validator is a distinct staker. The
+	// first staking period has TxID equal to ContinuousTxID, and each renewed
+	// staking period has a TxID derived from ContinuousTxID and its start time.
+	ContinuousTxID ids.ID
+
+	// Managed is true if this staker was added by the validator manager of a
+	// converted subnet rather than by a tx. TxID is then the ID of the Warp
+	// message that set the weight of the staker.
+	Managed bool
+}
+
+// StakerTxID returns the ID of the tx that added this staker.
+func (s *Staker) StakerTxID() ids.ID {
+	if s.ContinuousTxID != ids.Empty {
+		return s.ContinuousTxID
+	}
+	return s.TxID
+}
+
+// RenewedStakerTxID returns the TxID of the staking period of the continuous
+// validator added by [continuousTxID] that starts at [startTime].
+func RenewedStakerTxID(continuousTxID ids.ID, startTime time.Time) ids.ID {
+	return continuousTxID.Prefix(uint64(startTime.Unix()))
 }
 
 // A *Staker is considered to be less than another *Staker when:
@@ -100,6 +127,7 @@ func NewCurrentStaker(txID ids.ID, staker txs.Staker, potentialReward uint64) (*
 		PotentialReward: potentialReward,
 		NextTime:        endTime,
 		Priority:        staker.CurrentPriority(),
+		ContinuousTxID:  continuousTxID(txID, staker),
 	}, nil
 }
 
@@ -119,5 +147,38 @@ func NewPendingStaker(txID ids.ID, staker txs.Staker) (*Staker, error) {
 		EndTime:   staker.EndTime(),
 		NextTime:  startTime,
 		Priority:  staker.PendingPriority(),
+
+		ContinuousTxID: continuousTxID(txID, staker),
 	}, nil
 }
+
+// NewManagedStaker returns the current validator [nodeID] of the converted
+// subnet [subnetID], as set by the Warp message [msgID] of the validator
+// manager of the subnet.
+func NewManagedStaker(
+	msgID ids.ID,
+	subnetID ids.ID,
+	nodeID ids.NodeID,
+	weight uint64,
+	startTime time.Time,
+	endTime time.Time,
+) *Staker {
+	return &Staker{
+		TxID:      msgID,
+		NodeID:    nodeID,
+		SubnetID:  subnetID,
+		Weight:    weight,
+		StartTime: startTime,
+		EndTime:   endTime,
+		NextTime:  endTime,
+		Priority:  txs.SubnetPermissionedValidatorCurrentPriority,
+		Managed:   true,
+	}
+}
+
+func continuousTxID(txID ids.ID, staker txs.Staker) ids.ID {
+	if _, ok := staker.(*txs.AddContinuousValidatorTx); ok {
+		return txID
+	}
+	return ids.Empty
+}
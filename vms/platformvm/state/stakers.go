@@ -21,6 +21,10 @@ type CurrentStakers interface {
 	// [database.ErrNotFound] is returned.
 	GetCurrentValidator(subnetID ids.ID, nodeID ids.NodeID) (*Staker, error)
 
+	// GetCurrentValidators returns the [staker]s describing the validators on
+	// [subnetID], in no particular order.
+	GetCurrentValidators(subnetID ids.ID) ([]*Staker, error)
+
 	// PutCurrentValidator adds the [staker] describing a validator to the
 	// staker set.
 	//
@@ -131,11 +135,25 @@ func (v *baseStakers) GetValidator(subnetID ids.ID, nodeID ids.NodeID) (*Staker,
 	return validator.validator, nil
 }
 
+func (v *baseStakers) GetValidators(subnetID ids.ID) []*Staker {
+	subnetValidators := v.validators[subnetID]
+	validators := make([]*Staker, 0, len(subnetValidators))
+	for _, validator := range subnetValidators {
+		if validator.validator != nil {
+			validators = append(validators, validator.validator)
+		}
+	}
+	return validators
+}
+
 func (v *baseStakers) PutValidator(staker *Staker) {
 	validator := v.getOrCreateValidator(staker.SubnetID, staker.NodeID)
 	validator.validator = staker
 
 	validatorDiff := v.getOrCreateValidatorDiff(staker.SubnetID, staker.NodeID)
+	if validatorDiff.validatorStatus == deleted {
+		validatorDiff.replacedValidator = validatorDiff.validator
+	}
 	validatorDiff.validatorStatus = added
 	validatorDiff.validator = staker
 
@@ -150,6 +168,12 @@ func (v *baseStakers) DeleteValidator(staker *Staker) {
 	validatorDiff := v.getOrCreateValidatorDiff(staker.SubnetID, staker.NodeID)
 	validatorDiff.validatorStatus = deleted
 	validatorDiff.validator = staker
+	if validatorDiff.replacedValidator != nil {
+		// The validator that replaced the written validator was removed, so
+		// the written validator is the one to remove.
+		validatorDiff.validator = validatorDiff.replacedValidator
+		validatorDiff.replacedValidator = nil
+	}
 
 	v.stakers.Delete(staker)
 }
@@ -263,6 +287,10 @@ type diffValidator struct {
 	// mean that diffValidator hasn't change, since delegators may have changed.
 	validatorStatus diffValidatorStatus
 	validator       *Staker
+	// replacedValidator is the validator that [validator] replaced, if the
+	// validator was removed and then added back. It is only set if
+	// [validatorStatus] is added.
+	replacedValidator *Staker
 
 	addedDelegators   *btree.BTreeG[*Staker]
 	deletedDelegators map[ids.ID]*Staker
@@ -270,7 +298,9 @@ type diffValidator struct {
 
 // GetValidator attempts to fetch the validator with the given subnetID and
 // nodeID.
-// Invariant: Assumes that the validator will never be removed and then added.
+// Invariant: Assumes that the validator is only removed and then added when
+// the staking period of a continuous validator is renewed, or when the weight
+// of a validator of a converted subnet is set.
 func (s *diffStakers) GetValidator(subnetID ids.ID, nodeID ids.NodeID) (*Staker, diffValidatorStatus) {
 	subnetValidatorDiffs, ok := s.validatorDiffs[subnetID]
 	if !ok {
@@ -288,8 +318,30 @@ func (s *diffStakers) GetValidator(subnetID ids.ID, nodeID ids.NodeID) (*Staker,
 	return nil, validatorDiff.validatorStatus
 }
 
+// GetValidators returns the validators of [subnetID] after applying this diff
+// to [parentValidators].
+func (s *diffStakers) GetValidators(subnetID ids.ID, parentValidators []*Staker) []*Staker {
+	subnetValidatorDiffs := s.validatorDiffs[subnetID]
+	validators := make([]*Staker, 0, len(parentValidators)+len(subnetValidatorDiffs))
+	for _, validator := range parentValidators {
+		validatorDiff, ok := subnetValidatorDiffs[validator.NodeID]
+		if !ok || validatorDiff.validatorStatus == unmodified {
+			validators = append(validators, validator)
+		}
+	}
+	for _, validatorDiff := range subnetValidatorDiffs {
+		if validatorDiff.validatorStatus == added {
+			validators = append(validators, validatorDiff.validator)
+		}
+	}
+	return validators
+}
+
 func (s *diffStakers) PutValidator(staker *Staker) {
 	validatorDiff := s.getOrCreateDiff(staker.SubnetID, staker.NodeID)
+	if validatorDiff.validatorStatus == deleted {
+		validatorDiff.replacedValidator = validatorDiff.validator
+	}
 	validatorDiff.validatorStatus = added
 	validatorDiff.validator = staker
 
@@ -301,13 +353,21 @@ func (s *diffStakers) PutValidator(staker *Staker) {
 
 func (s *diffStakers) DeleteValidator(staker *Staker) {
 	validatorDiff := s.getOrCreateDiff(staker.SubnetID, staker.NodeID)
-	if validatorDiff.validatorStatus == added {
+	switch {
+	case validatorDiff.validatorStatus == added && validatorDiff.replacedValidator != nil:
+		// This validator replaced a validator that was removed in this diff.
+		// We treat it as if only the replaced validator was removed.
+		s.addedStakers.Delete(validatorDiff.validator)
+		validatorDiff.validatorStatus = deleted
+		validatorDiff.validator = validatorDiff.replacedValidator
+		validatorDiff.replacedValidator = nil
+	case validatorDiff.validatorStatus == added:
 		// This validator was added and immediately removed in this diff. We
 		// treat it as if it was never added.
 		validatorDiff.validatorStatus = unmodified
 		s.addedStakers.Delete(validatorDiff.validator)
 		validatorDiff.validator = nil
-	} else {
+	default:
 		validatorDiff.validatorStatus = deleted
 		validatorDiff.validator = staker
 		if s.deletedStakers == nil {
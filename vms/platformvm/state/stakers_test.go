@@ -86,12 +86,18 @@ func TestBaseStakersValidator(t *testing.T) {
 	stakerIterator := v.GetStakerIterator()
 	assertIteratorsEqual(t, NewSliceIterator(delegator), stakerIterator)
 
+	// subnets with only delegators have no validators
+	require.Empty(v.GetValidators(delegator.SubnetID))
+
 	v.PutValidator(staker)
 
 	returnedStaker, err := v.GetValidator(staker.SubnetID, staker.NodeID)
 	require.NoError(err)
 	require.Equal(staker, returnedStaker)
 
+	require.Equal([]*Staker{staker}, v.GetValidators(staker.SubnetID))
+	require.Empty(v.GetValidators(ids.GenerateTestID()))
+
 	v.DeleteDelegator(delegator)
 
 	stakerIterator = v.GetStakerIterator()
@@ -104,6 +110,8 @@ func TestBaseStakersValidator(t *testing.T) {
 
 	stakerIterator = v.GetStakerIterator()
 	assertIteratorsEqual(t, EmptyIterator, stakerIterator)
+
+	require.Empty(v.GetValidators(staker.SubnetID))
 }
 
 func TestBaseStakersDelegator(t *testing.T) {
@@ -166,6 +174,8 @@ func TestDiffStakersValidator(t *testing.T) {
 	require.Equal(added, status)
 	require.Equal(staker, returnedStaker)
 
+	require.Equal([]*Staker{staker}, v.GetValidators(staker.SubnetID, nil))
+
 	v.DeleteValidator(staker)
 
 	// Validators created and deleted in the same diff are marked as unmodified.
@@ -195,6 +205,44 @@ func TestDiffStakersDeleteValidator(t *testing.T) {
 	require.Nil(returnedStaker)
 }
 
+func TestDiffStakersReplaceValidator(t *testing.T) {
+	require := require.New(t)
+	staker := newTestStaker()
+	renewedStaker := *staker
+	renewedStaker.TxID = ids.GenerateTestID()
+	renewedStaker.StartTime = staker.EndTime
+	renewedStaker.EndTime = staker.EndTime.Add(staker.EndTime.Sub(staker.StartTime))
+	renewedStaker.NextTime = renewedStaker.EndTime
+
+	v := diffStakers{}
+
+	v.DeleteValidator(staker)
+	v.PutValidator(&renewedStaker)
+
+	// A validator that is removed and then added back is marked as added.
+	returnedStaker, status := v.GetValidator(staker.SubnetID, staker.NodeID)
+	require.Equal(added, status)
+	require.Equal(&renewedStaker, returnedStaker)
+
+	stakerIterator := v.GetStakerIterator(NewSliceIterator(staker))
+	assertIteratorsEqual(t, NewSliceIterator(&renewedStaker), stakerIterator)
+
+	validators := v.GetValidators(staker.SubnetID, []*Staker{staker})
+	require.Equal([]*Staker{&renewedStaker}, validators)
+
+	v.DeleteValidator(&renewedStaker)
+
+	// Removing the replacing validator leaves only the original removal.
+	returnedStaker, status = v.GetValidator(staker.SubnetID, staker.NodeID)
+	require.Equal(deleted, status)
+	require.Nil(returnedStaker)
+
+	stakerIterator = v.GetStakerIterator(NewSliceIterator(staker))
+	assertIteratorsEqual(t, EmptyIterator, stakerIterator)
+
+	require.Empty(v.GetValidators(staker.SubnetID, []*Staker{staker}))
+}
+
 func TestDiffStakersDelegator(t *testing.T) {
 	staker := newTestStaker()
 	delegator := newTestStaker()
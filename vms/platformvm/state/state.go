@@ -33,6 +33,7 @@ import (
 	"github.com/luxdefi/node/utils/crypto/bls"
 	"github.com/luxdefi/node/utils/hashing"
 	"github.com/luxdefi/node/utils/logging"
+	"github.com/luxdefi/node/utils/set"
 	"github.com/luxdefi/node/utils/timer"
 	"github.com/luxdefi/node/utils/wrappers"
 	"github.com/luxdefi/node/vms/components/lux"
@@ -70,15 +71,23 @@ var (
 	delegatorPrefix                     = []byte("delegator")
 	subnetValidatorPrefix               = []byte("subnetValidator")
 	subnetDelegatorPrefix               = []byte("subnetDelegator")
+	continuousValidatorPrefix           = []byte("continuousValidator")
+	stoppedContinuousValidatorPrefix    = []byte("stoppedContinuousValidator")
+	managedValidatorPrefix              = []byte("managedValidator")
+	subnetValidatorNoncePrefix          = []byte("subnetValidatorNonce")
 	nestedValidatorWeightDiffsPrefix    = []byte("validatorDiffs")
 	nestedValidatorPublicKeyDiffsPrefix = []byte("publicKeyDiffs")
 	flatValidatorWeightDiffsPrefix      = []byte("flatValidatorDiffs")
 	flatValidatorPublicKeyDiffsPrefix   = []byte("flatPublicKeyDiffs")
 	txPrefix                            = []byte("tx")
 	rewardUTXOsPrefix                   = []byte("rewardUTXOs")
+	rewardRecordPrefix                  = []byte("rewardRecord")
+	rewardRecordNodeIDPrefix            = []byte("rewardRecordNodeID")
+	rewardRecordAddrPrefix              = []byte("rewardRecordAddr")
 	utxoPrefix                          = []byte("utxo")
 	subnetPrefix                        = []byte("subnet")
 	subnetOwnerPrefix                   = []byte("subnetOwner")
+	subnetManagerPrefix                 = []byte("subnetManager")
 	transformedSubnetPrefix             = []byte("transformedSubnet")
 	supplyPrefix                        = []byte("supply")
 	chainPrefix                         = []byte("chain")
@@ -86,6 +95,7 @@ var (
 
 	timestampKey      = []byte("timestamp")
 	currentSupplyKey  = []byte("current supply")
+	gasPriceKey       = []byte("gas price")
 	lastAcceptedKey   = []byte("last accepted")
 	heightsIndexedKey = []byte("heights indexed")
 	initializedKey    = []byte("initialized")
@@ -106,13 +116,35 @@ type Chain interface {
 	GetCurrentSupply(subnetID ids.ID) (uint64, error)
 	SetCurrentSupply(subnetID ids.ID, cs uint64)
 
+	// GetGasPrice returns the gas price set by the last accepted block. Zero
+	// is returned if no block has set the gas price.
+	GetGasPrice() (uint64, error)
+	SetGasPrice(gasPrice uint64)
+
 	AddRewardUTXO(txID ids.ID, utxo *lux.UTXO)
+	AddRewardRecord(record *RewardRecord)
 
 	AddSubnet(createSubnetTx *txs.Tx)
 
+	// IsContinuousValidatorStopped returns true if the continuous validator
+	// added by [txID] won't be renewed at the end of its staking period.
+	IsContinuousValidatorStopped(txID ids.ID) (bool, error)
+	StopContinuousValidator(txID ids.ID)
+
 	GetSubnetOwner(subnetID ids.ID) (fx.Owner, error)
 	SetSubnetOwner(subnetID ids.ID, owner fx.Owner)
 
+	// GetSubnetManager returns the validator manager of [subnetID].
+	// database.ErrNotFound is returned if [subnetID] hasn't been converted.
+	GetSubnetManager(subnetID ids.ID) (SubnetManager, error)
+	SetSubnetManager(subnetID ids.ID, manager SubnetManager)
+
+	// GetSubnetValidatorNonce returns the nonce of the last message of the
+	// validator manager of [subnetID] that was applied to [nodeID], or 0 if
+	// there is none.
+	GetSubnetValidatorNonce(subnetID ids.ID, nodeID ids.NodeID) (uint64, error)
+	SetSubnetValidatorNonce(subnetID ids.ID, nodeID ids.NodeID, nonce uint64)
+
 	GetSubnetTransformation(subnetID ids.ID) (*txs.Tx, error)
 	AddSubnetTransformation(transformSubnetTx *txs.Tx)
 
@@ -138,6 +170,12 @@ type State interface {
 	GetBlockIDAtHeight(height uint64) (ids.ID, error)
 
 	GetRewardUTXOs(txID ids.ID) ([]*lux.UTXO, error)
+	// GetRewardRecords returns the reward records of the stakers of [nodeID],
+	// sorted by end time.
+	GetRewardRecords(nodeID ids.NodeID) ([]*RewardRecord, error)
+	// GetRewardRecordsByAddress returns the reward records whose rewards are
+	// owned by [addr], sorted by end time.
+	GetRewardRecordsByAddress(addr ids.ShortID) ([]*RewardRecord, error)
 	GetSubnets() ([]*txs.Tx, error)
 	GetChains(subnetID ids.ID) ([]*txs.Tx, error)
 
@@ -178,6 +216,16 @@ type State interface {
 		endHeight uint64,
 	) error
 
+	// GetValidatorDiffs returns the changes made to the validators of
+	// [subnetID] by the blocks from [startHeight] through [endHeight], sorted
+	// by height and then by nodeID.
+	GetValidatorDiffs(
+		ctx context.Context,
+		subnetID ids.ID,
+		startHeight uint64,
+		endHeight uint64,
+	) ([]*ValidatorDiff, error)
+
 	SetHeight(height uint64)
 
 	// Discard uncommitted changes to the database.
@@ -227,9 +275,13 @@ type stateBlk struct {
  * | | |-. subnetValidator
  * | | | '-. list
  * | | |   '-- txID -> uptime + potential reward + potential delegatee reward
- * | | '-. subnetDelegator
- * | |   '-. list
- * | |     '-- txID -> potential reward
+ * | | |-. subnetDelegator
+ * | | | '-. list
+ * | | |   '-- txID -> potential reward
+ * | | |-. continuousValidator
+ * | | | '-- txID -> addContinuousValidatorTxID + start time + weight
+ * | | '-. managedValidator
+ * | |   '-- warpMessageID -> subnetID + nodeID + weight + start time + end time
  * | |-. pending
  * | | |-. validator
  * | | | '-. list
@@ -251,6 +303,10 @@ type stateBlk struct {
  * | | '-. height
  * | |   '-. list
  * | |     '-- nodeID -> compressed public key
+ * | |-. stoppedContinuousValidator
+ * | | '-- addContinuousValidatorTxID -> nil
+ * | |-. subnetValidatorNonce
+ * | | '-- subnetID+nodeID -> nonce
  * | |-. flat weight diffs
  * | | '-- subnet+height+nodeID -> weightChange
  * | '-. flat pub key diffs
@@ -265,6 +321,12 @@ type stateBlk struct {
  * | '-. txID
  * |   '-. list
  * |     '-- utxoID -> utxo bytes
+ * |-. rewardRecords
+ * | '-- txID -> reward record bytes
+ * |-. rewardRecordNodeIDs
+ * | '-- nodeID+endTime+txID -> nil
+ * |-. rewardRecordAddrs
+ * | '-- addr+endTime+txID -> nil
  * |- utxos
  * | '-- utxoDB
  * |-. subnets
@@ -272,6 +334,8 @@ type stateBlk struct {
  * |   '-- txID -> nil
  * |-. subnetOwners
  * | '-. subnetID -> owner
+ * |-. subnetManagers
+ * | '-. subnetID -> chainID + address
  * |-. chains
  * | '-. subnetID
  * |   '-. list
@@ -281,6 +345,7 @@ type stateBlk struct {
  *   |-- prunedKey -> nil
  *   |-- timestampKey -> timestamp
  *   |-- currentSupplyKey -> currentSupply
+ *   |-- gasPriceKey -> gasPrice
  *   |-- lastAcceptedKey -> lastAccepted
  *   '-- heightsIndexKey -> startIndexHeight + endIndexHeight
  */
@@ -317,6 +382,8 @@ type state struct {
 	currentSubnetValidatorList   linkeddb.LinkedDB
 	currentSubnetDelegatorBaseDB database.Database
 	currentSubnetDelegatorList   linkeddb.LinkedDB
+	continuousValidatorDB        database.Database
+	managedValidatorDB           database.Database
 	pendingValidatorsDB          database.Database
 	pendingValidatorBaseDB       database.Database
 	pendingValidatorList         linkeddb.LinkedDB
@@ -332,6 +399,12 @@ type state struct {
 	flatValidatorWeightDiffsDB      database.Database
 	flatValidatorPublicKeyDiffsDB   database.Database
 
+	stoppedContinuousValidators  set.Set[ids.ID] // txIDs of the continuous validators stopped since the last commit
+	stoppedContinuousValidatorDB database.Database
+
+	subnetValidatorNonces  map[subnetIDNodeID]uint64 // map of subnetID+nodeID -> nonce
+	subnetValidatorNonceDB database.Database
+
 	addedTxs map[ids.ID]*txAndStatus            // map of txID -> {*txs.Tx, Status}
 	txCache  cache.Cacher[ids.ID, *txAndStatus] // txID -> {*txs.Tx, Status}. If the entry is nil, it isn't in the database
 	txDB     database.Database
@@ -340,6 +413,11 @@ type state struct {
 	rewardUTXOsCache cache.Cacher[ids.ID, []*lux.UTXO] // txID -> []*UTXO
 	rewardUTXODB     database.Database
 
+	addedRewardRecords   []*RewardRecord
+	rewardRecordDB       database.Database
+	rewardRecordNodeIDDB database.Database
+	rewardRecordAddrDB   database.Database
+
 	modifiedUTXOs map[ids.ID]*lux.UTXO // map of modified UTXOID -> *UTXO if the UTXO is nil, it has been removed
 	utxoDB        database.Database
 	utxoState     lux.UTXOState
@@ -354,6 +432,9 @@ type state struct {
 	subnetOwnerCache cache.Cacher[ids.ID, fxOwnerAndSize] // cache of subnetID -> owner if the entry is nil, it is not in the database
 	subnetOwnerDB    database.Database
 
+	subnetManagers  map[ids.ID]SubnetManager // map of subnetID -> validator manager
+	subnetManagerDB database.Database
+
 	transformedSubnets     map[ids.ID]*txs.Tx            // map of subnetID -> transformSubnetTx
 	transformedSubnetCache cache.Cacher[ids.ID, *txs.Tx] // cache of subnetID -> transformSubnetTx if the entry is nil, it is not in the database
 	transformedSubnetDB    database.Database
@@ -370,6 +451,7 @@ type state struct {
 	// The persisted fields represent the current database value
 	timestamp, persistedTimestamp         time.Time
 	currentSupply, persistedCurrentSupply uint64
+	gasPrice, persistedGasPrice           uint64
 	// [lastAccepted] is the most recently accepted block.
 	lastAccepted, persistedLastAccepted ids.ID
 	indexedHeights                      *heightRange
@@ -538,6 +620,8 @@ func newState(
 	currentDelegatorBaseDB := prefixdb.New(delegatorPrefix, currentValidatorsDB)
 	currentSubnetValidatorBaseDB := prefixdb.New(subnetValidatorPrefix, currentValidatorsDB)
 	currentSubnetDelegatorBaseDB := prefixdb.New(subnetDelegatorPrefix, currentValidatorsDB)
+	continuousValidatorDB := prefixdb.New(continuousValidatorPrefix, currentValidatorsDB)
+	managedValidatorDB := prefixdb.New(managedValidatorPrefix, currentValidatorsDB)
 
 	pendingValidatorsDB := prefixdb.New(pendingPrefix, validatorsDB)
 	pendingValidatorBaseDB := prefixdb.New(validatorPrefix, pendingValidatorsDB)
@@ -655,6 +739,8 @@ func newState(
 		currentSubnetValidatorList:      linkeddb.NewDefault(currentSubnetValidatorBaseDB),
 		currentSubnetDelegatorBaseDB:    currentSubnetDelegatorBaseDB,
 		currentSubnetDelegatorList:      linkeddb.NewDefault(currentSubnetDelegatorBaseDB),
+		continuousValidatorDB:           continuousValidatorDB,
+		managedValidatorDB:              managedValidatorDB,
 		pendingValidatorsDB:             pendingValidatorsDB,
 		pendingValidatorBaseDB:          pendingValidatorBaseDB,
 		pendingValidatorList:            linkeddb.NewDefault(pendingValidatorBaseDB),
@@ -669,6 +755,11 @@ func newState(
 		flatValidatorWeightDiffsDB:      flatValidatorWeightDiffsDB,
 		flatValidatorPublicKeyDiffsDB:   flatValidatorPublicKeyDiffsDB,
 
+		stoppedContinuousValidatorDB: prefixdb.New(stoppedContinuousValidatorPrefix, validatorsDB),
+
+		subnetValidatorNonces:  make(map[subnetIDNodeID]uint64),
+		subnetValidatorNonceDB: prefixdb.New(subnetValidatorNoncePrefix, validatorsDB),
+
 		addedTxs: make(map[ids.ID]*txAndStatus),
 		txDB:     prefixdb.New(txPrefix, baseDB),
 		txCache:  txCache,
@@ -677,6 +768,10 @@ func newState(
 		rewardUTXODB:     rewardUTXODB,
 		rewardUTXOsCache: rewardUTXOsCache,
 
+		rewardRecordDB:       prefixdb.New(rewardRecordPrefix, baseDB),
+		rewardRecordNodeIDDB: prefixdb.New(rewardRecordNodeIDPrefix, baseDB),
+		rewardRecordAddrDB:   prefixdb.New(rewardRecordAddrPrefix, baseDB),
+
 		modifiedUTXOs: make(map[ids.ID]*lux.UTXO),
 		utxoDB:        utxoDB,
 		utxoState:     utxoState,
@@ -688,6 +783,9 @@ func newState(
 		subnetOwnerDB:    subnetOwnerDB,
 		subnetOwnerCache: subnetOwnerCache,
 
+		subnetManagers:  make(map[ids.ID]SubnetManager),
+		subnetManagerDB: prefixdb.New(subnetManagerPrefix, baseDB),
+
 		transformedSubnets:     make(map[ids.ID]*txs.Tx),
 		transformedSubnetCache: transformedSubnetCache,
 		transformedSubnetDB:    prefixdb.New(transformedSubnetPrefix, baseDB),
@@ -709,6 +807,10 @@ func (s *state) GetCurrentValidator(subnetID ids.ID, nodeID ids.NodeID) (*Staker
 	return s.currentStakers.GetValidator(subnetID, nodeID)
 }
 
+func (s *state) GetCurrentValidators(subnetID ids.ID) ([]*Staker, error) {
+	return s.currentStakers.GetValidators(subnetID), nil
+}
+
 func (s *state) PutCurrentValidator(staker *Staker) {
 	s.currentStakers.PutValidator(staker)
 }
@@ -886,6 +988,62 @@ func (s *state) SetSubnetOwner(subnetID ids.ID, owner fx.Owner) {
 	s.subnetOwners[subnetID] = owner
 }
 
+func (s *state) GetSubnetManager(subnetID ids.ID) (SubnetManager, error) {
+	if manager, exists := s.subnetManagers[subnetID]; exists {
+		return manager, nil
+	}
+
+	managerBytes, err := s.subnetManagerDB.Get(subnetID[:])
+	if err != nil {
+		return SubnetManager{}, err
+	}
+
+	var manager SubnetManager
+	if _, err := metadataCodec.Unmarshal(managerBytes, &manager); err != nil {
+		return SubnetManager{}, fmt.Errorf("failed to unmarshal subnet manager: %w", err)
+	}
+	return manager, nil
+}
+
+func (s *state) SetSubnetManager(subnetID ids.ID, manager SubnetManager) {
+	s.subnetManagers[subnetID] = manager
+}
+
+func (s *state) GetSubnetValidatorNonce(subnetID ids.ID, nodeID ids.NodeID) (uint64, error) {
+	key := subnetIDNodeID{
+		subnetID: subnetID,
+		nodeID:   nodeID,
+	}
+	if nonce, exists := s.subnetValidatorNonces[key]; exists {
+		return nonce, nil
+	}
+
+	nonce, err := database.GetUInt64(s.subnetValidatorNonceDB, key.Marshal())
+	if err == database.ErrNotFound {
+		return 0, nil
+	}
+	return nonce, err
+}
+
+func (s *state) SetSubnetValidatorNonce(subnetID ids.ID, nodeID ids.NodeID, nonce uint64) {
+	key := subnetIDNodeID{
+		subnetID: subnetID,
+		nodeID:   nodeID,
+	}
+	s.subnetValidatorNonces[key] = nonce
+}
+
+func (s *state) IsContinuousValidatorStopped(txID ids.ID) (bool, error) {
+	if s.stoppedContinuousValidators.Contains(txID) {
+		return true, nil
+	}
+	return s.stoppedContinuousValidatorDB.Has(txID[:])
+}
+
+func (s *state) StopContinuousValidator(txID ids.ID) {
+	s.stoppedContinuousValidators.Add(txID)
+}
+
 func (s *state) GetSubnetTransformation(subnetID ids.ID) (*txs.Tx, error) {
 	if tx, exists := s.transformedSubnets[subnetID]; exists {
 		return tx, nil
@@ -1046,6 +1204,66 @@ func (s *state) AddRewardUTXO(txID ids.ID, utxo *lux.UTXO) {
 	s.addedRewardUTXOs[txID] = append(s.addedRewardUTXOs[txID], utxo)
 }
 
+func (s *state) AddRewardRecord(record *RewardRecord) {
+	s.addedRewardRecords = append(s.addedRewardRecords, record)
+}
+
+func (s *state) GetRewardRecords(nodeID ids.NodeID) ([]*RewardRecord, error) {
+	return s.getRewardRecords(s.rewardRecordNodeIDDB, nodeID[:], func(record *RewardRecord) bool {
+		return record.NodeID == nodeID
+	})
+}
+
+func (s *state) GetRewardRecordsByAddress(addr ids.ShortID) ([]*RewardRecord, error) {
+	return s.getRewardRecords(s.rewardRecordAddrDB, addr[:], func(record *RewardRecord) bool {
+		for _, rewardAddr := range record.RewardAddrs {
+			if rewardAddr == addr {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// getRewardRecords returns the records indexed under [owner] in [indexDB],
+// followed by the records that haven't been written yet and match [isOwned].
+func (s *state) getRewardRecords(
+	indexDB database.Database,
+	owner []byte,
+	isOwned func(*RewardRecord) bool,
+) ([]*RewardRecord, error) {
+	it := indexDB.NewIteratorWithPrefix(owner)
+	defer it.Release()
+
+	records := []*RewardRecord{}
+	for it.Next() {
+		key := it.Key()
+		txID, err := ids.ToID(key[len(key)-ids.IDLen:])
+		if err != nil {
+			return nil, err
+		}
+		recordBytes, err := s.rewardRecordDB.Get(txID[:])
+		if err != nil {
+			return nil, fmt.Errorf("failed to get reward record of %s: %w", txID, err)
+		}
+		record := &RewardRecord{}
+		if _, err := metadataCodec.Unmarshal(recordBytes, record); err != nil {
+			return nil, fmt.Errorf("failed to parse reward record of %s: %w", txID, err)
+		}
+		records = append(records, record)
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	for _, record := range s.addedRewardRecords {
+		if isOwned(record) {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
 func (s *state) GetUTXO(utxoID ids.ID) (*lux.UTXO, error) {
 	if utxo, exists := s.modifiedUTXOs[utxoID]; exists {
 		if utxo == nil {
@@ -1131,6 +1349,14 @@ func (s *state) SetCurrentSupply(subnetID ids.ID, cs uint64) {
 	}
 }
 
+func (s *state) GetGasPrice() (uint64, error) {
+	return s.gasPrice, nil
+}
+
+func (s *state) SetGasPrice(gasPrice uint64) {
+	s.gasPrice = gasPrice
+}
+
 func (s *state) ApplyValidatorWeightDiffs(
 	ctx context.Context,
 	validators map[ids.NodeID]*validators.GetValidatorOutput,
@@ -1399,6 +1625,17 @@ func (s *state) loadMetadata() error {
 	s.persistedCurrentSupply = currentSupply
 	s.SetCurrentSupply(constants.PrimaryNetworkID, currentSupply)
 
+	gasPrice, err := database.GetUInt64(s.singletonDB, gasPriceKey)
+	switch err {
+	case nil:
+		s.persistedGasPrice = gasPrice
+		s.gasPrice = gasPrice
+	case database.ErrNotFound:
+		// The gas price hasn't been set by any block yet.
+	default:
+		return err
+	}
+
 	lastAccepted, err := database.GetID(s.singletonDB, lastAcceptedKey)
 	if err != nil {
 		return err
@@ -1446,7 +1683,20 @@ func (s *state) loadCurrentValidators() error {
 		if err != nil {
 			return err
 		}
-		tx, _, err := s.GetTx(txID)
+
+		// If this is a renewed staking period of a continuous validator, the
+		// validator was added by a different tx.
+		stakerTxID := txID
+		period, err := getContinuousValidatorPeriod(s.continuousValidatorDB, txID)
+		switch err {
+		case nil:
+			stakerTxID = period.TxID
+		case database.ErrNotFound:
+		default:
+			return err
+		}
+
+		tx, _, err := s.GetTx(stakerTxID)
 		if err != nil {
 			return err
 		}
@@ -1470,6 +1720,13 @@ func (s *state) loadCurrentValidators() error {
 		if err != nil {
 			return err
 		}
+		if period != nil {
+			continuousTx, ok := tx.Unsigned.(*txs.AddContinuousValidatorTx)
+			if !ok {
+				return fmt.Errorf("expected tx type *txs.AddContinuousValidatorTx but got %T", tx.Unsigned)
+			}
+			period.apply(staker, continuousTx)
+		}
 
 		validator := s.currentStakers.getOrCreateValidator(staker.SubnetID, staker.NodeID)
 		validator.validator = staker
@@ -1487,31 +1744,23 @@ func (s *state) loadCurrentValidators() error {
 		if err != nil {
 			return err
 		}
-		tx, _, err := s.GetTx(txID)
+		staker, err := s.loadCurrentSubnetValidator(txID)
 		if err != nil {
 			return err
 		}
 
-		stakerTx, ok := tx.Unsigned.(txs.Staker)
-		if !ok {
-			return fmt.Errorf("expected tx type txs.Staker but got %T", tx.Unsigned)
-		}
-
 		metadataBytes := subnetValidatorIt.Value()
 		metadata := &validatorMetadata{
 			txID: txID,
 			// use the start time as the fallback value
 			// in case it's not stored in the database
-			LastUpdated: uint64(stakerTx.StartTime().Unix()),
+			LastUpdated: uint64(staker.StartTime.Unix()),
 		}
 		if err := parseValidatorMetadata(metadataBytes, metadata); err != nil {
 			return err
 		}
+		staker.PotentialReward = metadata.PotentialReward
 
-		staker, err := NewCurrentStaker(txID, stakerTx, metadata.PotentialReward)
-		if err != nil {
-			return err
-		}
 		validator := s.currentStakers.getOrCreateValidator(staker.SubnetID, staker.NodeID)
 		validator.validator = staker
 
@@ -1574,6 +1823,27 @@ func (s *state) loadCurrentValidators() error {
 	)
 }
 
+// loadCurrentSubnetValidator returns the current subnet validator [txID],
+// without its potential reward.
+func (s *state) loadCurrentSubnetValidator(txID ids.ID) (*Staker, error) {
+	// Validators of converted subnets weren't added by a tx.
+	staker, err := getManagedStaker(s.managedValidatorDB, txID)
+	if err != database.ErrNotFound {
+		return staker, err
+	}
+
+	tx, _, err := s.GetTx(txID)
+	if err != nil {
+		return nil, err
+	}
+
+	stakerTx, ok := tx.Unsigned.(txs.Staker)
+	if !ok {
+		return nil, fmt.Errorf("expected tx type txs.Staker but got %T", tx.Unsigned)
+	}
+	return NewCurrentStaker(txID, stakerTx, 0)
+}
+
 func (s *state) loadPendingValidators() error {
 	s.pendingStakers = newBaseStakers()
 
@@ -1702,9 +1972,13 @@ func (s *state) write(updateValidators bool, height uint64) error {
 		s.WriteValidatorMetadata(s.currentValidatorList, s.currentSubnetValidatorList), // Must be called after writeCurrentStakers
 		s.writeTXs(),
 		s.writeRewardUTXOs(),
+		s.writeRewardRecords(),
 		s.writeUTXOs(),
 		s.writeSubnets(),
 		s.writeSubnetOwners(),
+		s.writeStoppedContinuousValidators(),
+		s.writeSubnetManagers(),
+		s.writeSubnetValidatorNonces(),
 		s.writeTransformedSubnets(),
 		s.writeSubnetSupplies(),
 		s.writeChains(),
@@ -1727,6 +2001,9 @@ func (s *state) Close() error {
 		s.validatorsDB.Close(),
 		s.txDB.Close(),
 		s.rewardUTXODB.Close(),
+		s.rewardRecordDB.Close(),
+		s.rewardRecordNodeIDDB.Close(),
+		s.rewardRecordAddrDB.Close(),
 		s.utxoDB.Close(),
 		s.subnetBaseDB.Close(),
 		s.transformedSubnetDB.Close(),
@@ -1963,21 +2240,40 @@ func (s *state) writeCurrentStakers(updateValidators bool, height uint64) error
 			switch validatorDiff.validatorStatus {
 			case added:
 				staker := validatorDiff.validator
-				weightDiff.Amount = staker.Weight
-
-				// Invariant: Only the Primary Network contains non-nil public
-				// keys.
-				if staker.PublicKey != nil {
-					// Record that the public key for the validator is being
-					// added. This means the prior value for the public key was
-					// nil.
-					err := s.flatValidatorPublicKeyDiffsDB.Put(
-						marshalDiffKey(constants.PrimaryNetworkID, height, nodeID),
-						nil,
-					)
-					if err != nil {
+				if replaced := validatorDiff.replacedValidator; replaced != nil {
+					// The staking period of a continuous validator was renewed,
+					// or the weight of a validator of a converted subnet was
+					// set.
+					//
+					// Invariant: The public key of a replaced validator is
+					// unchanged.
+					if err := weightDiff.Add(true, replaced.Weight); err != nil {
+						return fmt.Errorf("failed to decrease node weight diff: %w", err)
+					}
+					if err := weightDiff.Add(false, staker.Weight); err != nil {
+						return fmt.Errorf("failed to increase node weight diff: %w", err)
+					}
+					if err := s.deleteCurrentValidator(validatorDB, replaced); err != nil {
 						return err
 					}
+					s.validatorState.DeleteValidatorMetadata(nodeID, subnetID)
+				} else {
+					weightDiff.Amount = staker.Weight
+
+					// Invariant: Only the Primary Network contains non-nil
+					// public keys.
+					if staker.PublicKey != nil {
+						// Record that the public key for the validator is being
+						// added. This means the prior value for the public key
+						// was nil.
+						err := s.flatValidatorPublicKeyDiffsDB.Put(
+							marshalDiffKey(constants.PrimaryNetworkID, height, nodeID),
+							nil,
+						)
+						if err != nil {
+							return err
+						}
+					}
 				}
 
 				// The validator is being added.
@@ -2002,6 +2298,16 @@ func (s *state) writeCurrentStakers(updateValidators bool, height uint64) error
 				if err = validatorDB.Put(staker.TxID[:], metadataBytes); err != nil {
 					return fmt.Errorf("failed to write current validator to list: %w", err)
 				}
+				if isRenewedStaker(staker) {
+					if err := putContinuousValidatorPeriod(s.continuousValidatorDB, staker); err != nil {
+						return fmt.Errorf("failed to write continuous validator period: %w", err)
+					}
+				}
+				if staker.Managed {
+					if err := putManagedValidator(s.managedValidatorDB, staker); err != nil {
+						return fmt.Errorf("failed to write managed validator: %w", err)
+					}
+				}
 
 				s.validatorState.LoadValidatorMetadata(nodeID, subnetID, metadata)
 			case deleted:
@@ -2036,8 +2342,8 @@ func (s *state) writeCurrentStakers(updateValidators bool, height uint64) error
 					}
 				}
 
-				if err := validatorDB.Delete(staker.TxID[:]); err != nil {
-					return fmt.Errorf("failed to delete current staker: %w", err)
+				if err := s.deleteCurrentValidator(validatorDB, staker); err != nil {
+					return err
 				}
 
 				s.validatorState.DeleteValidatorMetadata(nodeID, subnetID)
@@ -2082,7 +2388,7 @@ func (s *state) writeCurrentStakers(updateValidators bool, height uint64) error
 			if weightDiff.Decrease {
 				err = s.validators.RemoveWeight(subnetID, nodeID, weightDiff.Amount)
 			} else {
-				if validatorDiff.validatorStatus == added {
+				if validatorDiff.validatorStatus == added && validatorDiff.replacedValidator == nil {
 					staker := validatorDiff.validator
 					err = s.validators.AddStaker(
 						subnetID,
@@ -2118,6 +2424,23 @@ func (s *state) writeCurrentStakers(updateValidators bool, height uint64) error
 	return nil
 }
 
+func (s *state) deleteCurrentValidator(validatorDB linkeddb.LinkedDB, staker *Staker) error {
+	if err := validatorDB.Delete(staker.TxID[:]); err != nil {
+		return fmt.Errorf("failed to delete current staker: %w", err)
+	}
+	if isRenewedStaker(staker) {
+		if err := s.continuousValidatorDB.Delete(staker.TxID[:]); err != nil {
+			return fmt.Errorf("failed to delete continuous validator period: %w", err)
+		}
+	}
+	if staker.Managed {
+		if err := s.managedValidatorDB.Delete(staker.TxID[:]); err != nil {
+			return fmt.Errorf("failed to delete managed validator: %w", err)
+		}
+	}
+	return nil
+}
+
 func writeCurrentDelegatorDiff(
 	currentDelegatorList linkeddb.LinkedDB,
 	weightDiff *ValidatorWeightDiff,
@@ -2263,6 +2586,31 @@ func (s *state) writeRewardUTXOs() error {
 	return nil
 }
 
+func (s *state) writeRewardRecords() error {
+	for _, record := range s.addedRewardRecords {
+		recordBytes, err := metadataCodec.Marshal(v0, record)
+		if err != nil {
+			return fmt.Errorf("failed to serialize reward record: %w", err)
+		}
+		if err := s.rewardRecordDB.Put(record.TxID[:], recordBytes); err != nil {
+			return fmt.Errorf("failed to write reward record: %w", err)
+		}
+
+		nodeIDKey := rewardRecordIndexKey(record.NodeID[:], record)
+		if err := s.rewardRecordNodeIDDB.Put(nodeIDKey, nil); err != nil {
+			return fmt.Errorf("failed to index reward record: %w", err)
+		}
+		for _, addr := range record.RewardAddrs {
+			addrKey := rewardRecordIndexKey(addr[:], record)
+			if err := s.rewardRecordAddrDB.Put(addrKey, nil); err != nil {
+				return fmt.Errorf("failed to index reward record: %w", err)
+			}
+		}
+	}
+	s.addedRewardRecords = nil
+	return nil
+}
+
 func (s *state) writeUTXOs() error {
 	for utxoID, utxo := range s.modifiedUTXOs {
 		delete(s.modifiedUTXOs, utxoID)
@@ -2315,6 +2663,44 @@ func (s *state) writeSubnetOwners() error {
 	return nil
 }
 
+func (s *state) writeStoppedContinuousValidators() error {
+	for txID := range s.stoppedContinuousValidators {
+		if err := s.stoppedContinuousValidatorDB.Put(txID[:], nil); err != nil {
+			return fmt.Errorf("failed to write stopped continuous validator: %w", err)
+		}
+	}
+	s.stoppedContinuousValidators.Clear()
+	return nil
+}
+
+func (s *state) writeSubnetManagers() error {
+	for subnetID, manager := range s.subnetManagers {
+		subnetID := subnetID
+		manager := manager
+		delete(s.subnetManagers, subnetID)
+
+		managerBytes, err := metadataCodec.Marshal(v0, &manager)
+		if err != nil {
+			return fmt.Errorf("failed to marshal subnet manager: %w", err)
+		}
+		if err := s.subnetManagerDB.Put(subnetID[:], managerBytes); err != nil {
+			return fmt.Errorf("failed to write subnet manager: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *state) writeSubnetValidatorNonces() error {
+	for key, nonce := range s.subnetValidatorNonces {
+		delete(s.subnetValidatorNonces, key)
+
+		if err := database.PutUInt64(s.subnetValidatorNonceDB, key.Marshal(), nonce); err != nil {
+			return fmt.Errorf("failed to write subnet validator nonce: %w", err)
+		}
+	}
+	return nil
+}
+
 func (s *state) writeTransformedSubnets() error {
 	for subnetID, tx := range s.transformedSubnets {
 		txID := tx.ID()
@@ -2371,6 +2757,12 @@ func (s *state) writeMetadata() error {
 		}
 		s.persistedCurrentSupply = s.currentSupply
 	}
+	if s.persistedGasPrice != s.gasPrice {
+		if err := database.PutUInt64(s.singletonDB, gasPriceKey, s.gasPrice); err != nil {
+			return fmt.Errorf("failed to write gas price: %w", err)
+		}
+		s.persistedGasPrice = s.gasPrice
+	}
 	if s.persistedLastAccepted != s.lastAccepted {
 		if err := database.PutID(s.singletonDB, lastAcceptedKey, s.lastAccepted); err != nil {
 			return fmt.Errorf("failed to write last accepted: %w", err)
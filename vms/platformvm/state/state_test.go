@@ -32,6 +32,8 @@ import (
 	"github.com/luxdefi/node/vms/platformvm/genesis"
 	"github.com/luxdefi/node/vms/platformvm/metrics"
 	"github.com/luxdefi/node/vms/platformvm/reward"
+	"github.com/luxdefi/node/vms/platformvm/signer"
+	"github.com/luxdefi/node/vms/platformvm/status"
 	"github.com/luxdefi/node/vms/platformvm/txs"
 	"github.com/luxdefi/node/vms/secp256k1fx"
 
@@ -692,3 +694,366 @@ func TestStateSubnetOwner(t *testing.T) {
 	require.NoError(err)
 	require.Equal(owner2, owner)
 }
+
+func TestStateGasPrice(t *testing.T) {
+	require := require.New(t)
+
+	s, db := newInitializedState(require)
+
+	gasPrice, err := s.GetGasPrice()
+	require.NoError(err)
+	require.Zero(gasPrice)
+
+	s.SetGasPrice(25)
+	require.NoError(s.Commit())
+	require.NoError(s.Close())
+
+	s = newStateFromDB(require, db)
+	require.NoError(s.(*state).loadMetadata())
+
+	gasPrice, err = s.GetGasPrice()
+	require.NoError(err)
+	require.Equal(uint64(25), gasPrice)
+}
+
+func TestStateRewardRecords(t *testing.T) {
+	require := require.New(t)
+
+	s, db := newInitializedState(require)
+
+	var (
+		nodeID     = ids.GenerateTestNodeID()
+		rewardAddr = ids.GenerateTestShortID()
+		lateRecord = &RewardRecord{
+			TxID:        ids.GenerateTestID(),
+			NodeID:      nodeID,
+			EndTime:     20,
+			Rewarded:    true,
+			Reward:      5,
+			RewardAddrs: []ids.ShortID{rewardAddr},
+		}
+		earlyRecord = &RewardRecord{
+			TxID:        ids.GenerateTestID(),
+			NodeID:      nodeID,
+			Delegator:   true,
+			EndTime:     10,
+			Forfeited:   3,
+			RewardAddrs: []ids.ShortID{ids.GenerateTestShortID()},
+		}
+		otherRecord = &RewardRecord{
+			TxID:        ids.GenerateTestID(),
+			NodeID:      ids.GenerateTestNodeID(),
+			EndTime:     15,
+			RewardAddrs: []ids.ShortID{rewardAddr},
+		}
+	)
+	s.AddRewardRecord(lateRecord)
+	s.AddRewardRecord(earlyRecord)
+
+	// Records that weren't written yet are returned.
+	records, err := s.GetRewardRecords(nodeID)
+	require.NoError(err)
+	require.Equal([]*RewardRecord{lateRecord, earlyRecord}, records)
+
+	require.NoError(s.Commit())
+	s.AddRewardRecord(otherRecord)
+	require.NoError(s.Commit())
+	require.NoError(s.Close())
+
+	s = newStateFromDB(require, db)
+
+	// Written records are sorted by end time.
+	records, err = s.GetRewardRecords(nodeID)
+	require.NoError(err)
+	require.Equal([]*RewardRecord{earlyRecord, lateRecord}, records)
+
+	records, err = s.GetRewardRecordsByAddress(rewardAddr)
+	require.NoError(err)
+	require.Equal([]*RewardRecord{otherRecord, lateRecord}, records)
+
+	records, err = s.GetRewardRecords(ids.GenerateTestNodeID())
+	require.NoError(err)
+	require.Empty(records)
+}
+
+func TestStateValidatorDiffs(t *testing.T) {
+	require := require.New(t)
+
+	s, _ := newInitializedState(require)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	var (
+		startTime = time.Now()
+		endTime   = startTime.Add(24 * time.Hour)
+		subnetID  = ids.GenerateTestID()
+		primary   = &Staker{
+			TxID:      ids.GenerateTestID(),
+			NodeID:    ids.GenerateTestNodeID(),
+			PublicKey: bls.PublicFromSecretKey(sk),
+			SubnetID:  constants.PrimaryNetworkID,
+			Weight:    10,
+			StartTime: startTime,
+			EndTime:   endTime,
+		}
+		subnet = &Staker{
+			TxID:      ids.GenerateTestID(),
+			NodeID:    ids.GenerateTestNodeID(),
+			SubnetID:  subnetID,
+			Weight:    5,
+			StartTime: startTime,
+			EndTime:   endTime,
+		}
+		readded = &Staker{
+			TxID:      ids.GenerateTestID(),
+			NodeID:    primary.NodeID,
+			PublicKey: primary.PublicKey,
+			SubnetID:  constants.PrimaryNetworkID,
+			Weight:    20,
+			StartTime: startTime,
+			EndTime:   endTime,
+		}
+	)
+
+	// Height 1: add a primary network and a subnet validator.
+	s.PutCurrentValidator(primary)
+	s.PutCurrentValidator(subnet)
+	s.SetHeight(1)
+	require.NoError(s.Commit())
+
+	// Height 2: remove the primary network validator.
+	s.DeleteCurrentValidator(primary)
+	s.SetHeight(2)
+	require.NoError(s.Commit())
+
+	// Height 3: add the primary network validator back.
+	s.PutCurrentValidator(readded)
+	s.SetHeight(3)
+	require.NoError(s.Commit())
+
+	diffs, err := s.GetValidatorDiffs(context.Background(), constants.PrimaryNetworkID, 1, 3)
+	require.NoError(err)
+	require.Equal([]*ValidatorDiff{
+		{
+			Height:           1,
+			NodeID:           primary.NodeID,
+			WeightDiff:       &ValidatorWeightDiff{Amount: 10},
+			PublicKeyChanged: true,
+			PublicKey:        primary.PublicKey,
+		},
+		{
+			Height:           2,
+			NodeID:           primary.NodeID,
+			WeightDiff:       &ValidatorWeightDiff{Decrease: true, Amount: 10},
+			PublicKeyChanged: true,
+		},
+		{
+			Height:           3,
+			NodeID:           primary.NodeID,
+			WeightDiff:       &ValidatorWeightDiff{Amount: 20},
+			PublicKeyChanged: true,
+			PublicKey:        primary.PublicKey,
+		},
+	}, diffs)
+
+	diffs, err = s.GetValidatorDiffs(context.Background(), constants.PrimaryNetworkID, 1, 1)
+	require.NoError(err)
+	require.Len(diffs, 1)
+	require.Equal(primary.PublicKey, diffs[0].PublicKey)
+
+	diffs, err = s.GetValidatorDiffs(context.Background(), subnetID, 1, 3)
+	require.NoError(err)
+	require.Equal([]*ValidatorDiff{
+		{
+			Height:     1,
+			NodeID:     subnet.NodeID,
+			WeightDiff: &ValidatorWeightDiff{Amount: 5},
+		},
+	}, diffs)
+
+	_, err = s.GetValidatorDiffs(context.Background(), subnetID, 1, 4)
+	require.ErrorIs(err, ErrHeightNotIndexed)
+}
+
+func TestStateContinuousValidatorRenewal(t *testing.T) {
+	require := require.New(t)
+
+	s, db := newInitializedState(require)
+
+	var (
+		nodeID    = ids.GenerateTestNodeID()
+		startTime = initialTime.Add(time.Second)
+		period    = 24 * time.Hour
+	)
+	addTx := &txs.Tx{Unsigned: &txs.AddContinuousValidatorTx{
+		AddPermissionlessValidatorTx: txs.AddPermissionlessValidatorTx{
+			Validator: txs.Validator{
+				NodeID: nodeID,
+				Start:  uint64(startTime.Unix()),
+				End:    uint64(startTime.Add(period).Unix()),
+				Wght:   units.Lux,
+			},
+			Subnet: constants.PrimaryNetworkID,
+			Signer: &signer.Empty{},
+			StakeOuts: []*lux.TransferableOutput{
+				{
+					Asset: lux.Asset{ID: initialTxID},
+					Out: &secp256k1fx.TransferOutput{
+						Amt: units.Lux,
+					},
+				},
+			},
+			ValidatorRewardsOwner: &secp256k1fx.OutputOwners{},
+			DelegatorRewardsOwner: &secp256k1fx.OutputOwners{},
+			DelegationShares:      reward.PercentDenominator,
+		},
+		AutoCompound: true,
+	}}
+	require.NoError(addTx.Initialize(txs.Codec))
+	addTxID := addTx.ID()
+
+	staker, err := NewCurrentStaker(addTxID, addTx.Unsigned.(txs.Staker), 10)
+	require.NoError(err)
+	require.Equal(addTxID, staker.ContinuousTxID)
+
+	s.PutCurrentValidator(staker)
+	s.AddTx(addTx, status.Committed)
+	s.SetHeight(1)
+	require.NoError(s.Commit())
+
+	renewed := *staker
+	renewed.TxID = RenewedStakerTxID(addTxID, staker.EndTime)
+	renewed.Weight += staker.PotentialReward
+	renewed.StartTime = staker.EndTime
+	renewed.EndTime = staker.EndTime.Add(period)
+	renewed.NextTime = renewed.EndTime
+	renewed.PotentialReward = 20
+
+	s.DeleteCurrentValidator(staker)
+	s.PutCurrentValidator(&renewed)
+
+	vdr, err := s.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+	require.NoError(err)
+	require.Equal(&renewed, vdr)
+
+	s.SetHeight(2)
+	require.NoError(s.Commit())
+
+	// The renewed staking period replaces the prior one in the validator set
+	// and starts tracking uptime from its start time.
+	require.Equal(renewed.Weight, s.(*state).validators.GetWeight(constants.PrimaryNetworkID, nodeID))
+	_, lastUpdated, err := s.GetUptime(nodeID, constants.PrimaryNetworkID)
+	require.NoError(err)
+	require.Equal(renewed.StartTime.Unix(), lastUpdated.Unix())
+
+	stopped, err := s.IsContinuousValidatorStopped(addTxID)
+	require.NoError(err)
+	require.False(stopped)
+
+	s.StopContinuousValidator(addTxID)
+	require.NoError(s.Commit())
+	require.NoError(s.Close())
+
+	s = newStateFromDB(require, db)
+	require.NoError(s.(*state).load())
+
+	vdr, err = s.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+	require.NoError(err)
+	require.Equal(renewed.TxID, vdr.TxID)
+	require.Equal(addTxID, vdr.ContinuousTxID)
+	require.Equal(renewed.Weight, vdr.Weight)
+	require.Equal(renewed.StartTime.Unix(), vdr.StartTime.Unix())
+	require.Equal(renewed.EndTime.Unix(), vdr.EndTime.Unix())
+	require.Equal(renewed.NextTime.Unix(), vdr.NextTime.Unix())
+	require.Equal(renewed.PotentialReward, vdr.PotentialReward)
+
+	stopped, err = s.IsContinuousValidatorStopped(addTxID)
+	require.NoError(err)
+	require.True(stopped)
+
+	s.DeleteCurrentValidator(vdr)
+	s.SetHeight(3)
+	require.NoError(s.Commit())
+
+	has, err := s.(*state).continuousValidatorDB.Has(renewed.TxID[:])
+	require.NoError(err)
+	require.False(has)
+}
+
+func TestStateManagedSubnetValidators(t *testing.T) {
+	require := require.New(t)
+
+	s, db := newInitializedState(require)
+
+	var (
+		subnetID = ids.GenerateTestID()
+		nodeID   = ids.GenerateTestNodeID()
+		manager  = SubnetManager{
+			ChainID: ids.GenerateTestID(),
+			Address: []byte{'m', 'g', 'r'},
+		}
+		startTime = initialTime.Add(time.Second)
+		endTime   = startTime.Add(24 * time.Hour)
+	)
+
+	_, err := s.GetSubnetManager(subnetID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	nonce, err := s.GetSubnetValidatorNonce(subnetID, nodeID)
+	require.NoError(err)
+	require.Zero(nonce)
+
+	staker := NewManagedStaker(ids.GenerateTestID(), subnetID, nodeID, 5, startTime, endTime)
+	s.SetSubnetManager(subnetID, manager)
+	s.SetSubnetValidatorNonce(subnetID, nodeID, 1)
+	s.PutCurrentValidator(staker)
+	s.SetHeight(1)
+	require.NoError(s.Commit())
+
+	// Changing the weight replaces the staker with one identified by the new
+	// message.
+	replacement := NewManagedStaker(ids.GenerateTestID(), subnetID, nodeID, 7, startTime.Add(time.Hour), endTime)
+	s.DeleteCurrentValidator(staker)
+	s.PutCurrentValidator(replacement)
+	s.SetSubnetValidatorNonce(subnetID, nodeID, 2)
+	s.SetHeight(2)
+	require.NoError(s.Commit())
+	require.NoError(s.Close())
+
+	s = newStateFromDB(require, db)
+	require.NoError(s.(*state).load())
+
+	gotManager, err := s.GetSubnetManager(subnetID)
+	require.NoError(err)
+	require.Equal(manager, gotManager)
+
+	nonce, err = s.GetSubnetValidatorNonce(subnetID, nodeID)
+	require.NoError(err)
+	require.Equal(uint64(2), nonce)
+
+	vdr, err := s.GetCurrentValidator(subnetID, nodeID)
+	require.NoError(err)
+	require.True(vdr.Managed)
+	require.Equal(replacement.TxID, vdr.TxID)
+	require.Equal(replacement.Weight, vdr.Weight)
+	require.Equal(replacement.StartTime.Unix(), vdr.StartTime.Unix())
+	require.Equal(replacement.EndTime.Unix(), vdr.EndTime.Unix())
+	require.Equal(replacement.Priority, vdr.Priority)
+
+	// Removing the validator removes its record.
+	s.DeleteCurrentValidator(vdr)
+	s.SetHeight(3)
+	require.NoError(s.Commit())
+	require.NoError(s.Close())
+
+	s = newStateFromDB(require, db)
+	require.NoError(s.(*state).load())
+
+	_, err = s.GetCurrentValidator(subnetID, nodeID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	has, err := s.(*state).managedValidatorDB.Has(replacement.TxID[:])
+	require.NoError(err)
+	require.False(has)
+}
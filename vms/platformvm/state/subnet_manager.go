@@ -0,0 +1,77 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"time"
+
+	"github.com/luxdefi/node/database"
+	"github.com/luxdefi/node/ids"
+)
+
+// SubnetManager is the validator manager of a converted subnet. Validators of
+// the subnet are only modified by Warp messages sent by [Address] on
+// [ChainID].
+type SubnetManager struct {
+	ChainID ids.ID `v0:"true"`
+	Address []byte `v0:"true"`
+}
+
+// subnetIDNodeID identifies a validator of a subnet.
+type subnetIDNodeID struct {
+	subnetID ids.ID
+	nodeID   ids.NodeID
+}
+
+func (s subnetIDNodeID) Marshal() []byte {
+	key := make([]byte, ids.IDLen+ids.NodeIDLen)
+	copy(key, s.subnetID[:])
+	copy(key[ids.IDLen:], s.nodeID[:])
+	return key
+}
+
+// managedValidator is a current validator of a converted subnet. It is stored
+// for every managed staker, as there is no tx that describes the staker.
+type managedValidator struct {
+	SubnetID  ids.ID     `v0:"true"`
+	NodeID    ids.NodeID `v0:"true"`
+	Weight    uint64     `v0:"true"`
+	StartTime uint64     `v0:"true"` // Unix time in seconds
+	EndTime   uint64     `v0:"true"` // Unix time in seconds
+}
+
+func putManagedValidator(db database.KeyValueWriter, staker *Staker) error {
+	vdr := &managedValidator{
+		SubnetID:  staker.SubnetID,
+		NodeID:    staker.NodeID,
+		Weight:    staker.Weight,
+		StartTime: uint64(staker.StartTime.Unix()),
+		EndTime:   uint64(staker.EndTime.Unix()),
+	}
+	vdrBytes, err := metadataCodec.Marshal(v0, vdr)
+	if err != nil {
+		return err
+	}
+	return db.Put(staker.TxID[:], vdrBytes)
+}
+
+// getManagedStaker returns the managed staker whose TxID is [msgID].
+func getManagedStaker(db database.KeyValueReader, msgID ids.ID) (*Staker, error) {
+	vdrBytes, err := db.Get(msgID[:])
+	if err != nil {
+		return nil, err
+	}
+	vdr := &managedValidator{}
+	if _, err := metadataCodec.Unmarshal(vdrBytes, vdr); err != nil {
+		return nil, err
+	}
+	return NewManagedStaker(
+		msgID,
+		vdr.SubnetID,
+		vdr.NodeID,
+		vdr.Weight,
+		time.Unix(int64(vdr.StartTime), 0),
+		time.Unix(int64(vdr.EndTime), 0),
+	), nil
+}
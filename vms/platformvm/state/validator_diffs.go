@@ -0,0 +1,187 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/luxdefi/node/database"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/constants"
+	"github.com/luxdefi/node/utils/crypto/bls"
+)
+
+var ErrHeightNotIndexed = errors.New("height is not indexed")
+
+// ValidatorDiff is the change made to a validator of a subnet by the block at
+// Height.
+type ValidatorDiff struct {
+	Height uint64
+	NodeID ids.NodeID
+	// WeightDiff is nil if the weight of the validator didn't change.
+	WeightDiff *ValidatorWeightDiff
+	// PublicKeyChanged is true if the public key of the validator changed.
+	// Public keys are only tracked for the Primary Network.
+	PublicKeyChanged bool
+	// PublicKey is the public key of the validator after the change. It is nil
+	// if the public key was removed.
+	PublicKey *bls.PublicKey
+}
+
+func (s *state) GetValidatorDiffs(
+	ctx context.Context,
+	subnetID ids.ID,
+	startHeight uint64,
+	endHeight uint64,
+) ([]*ValidatorDiff, error) {
+	if s.indexedHeights == nil || startHeight < s.indexedHeights.LowerBound {
+		return nil, fmt.Errorf("%w: %d", ErrHeightNotIndexed, startHeight)
+	}
+	if endHeight > s.indexedHeights.UpperBound {
+		return nil, fmt.Errorf("%w: %d", ErrHeightNotIndexed, endHeight)
+	}
+	if startHeight > endHeight {
+		return nil, nil
+	}
+
+	type diffKey struct {
+		height uint64
+		nodeID ids.NodeID
+	}
+	diffs := make(map[diffKey]*ValidatorDiff)
+	getDiff := func(height uint64, nodeID ids.NodeID) *ValidatorDiff {
+		key := diffKey{
+			height: height,
+			nodeID: nodeID,
+		}
+		diff, ok := diffs[key]
+		if !ok {
+			diff = &ValidatorDiff{
+				Height: height,
+				NodeID: nodeID,
+			}
+			diffs[key] = diff
+		}
+		return diff
+	}
+
+	weightIter := s.flatValidatorWeightDiffsDB.NewIteratorWithStartAndPrefix(
+		marshalStartDiffKey(subnetID, endHeight),
+		subnetID[:],
+	)
+	defer weightIter.Release()
+
+	for weightIter.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		_, height, nodeID, err := unmarshalDiffKey(weightIter.Key())
+		if err != nil {
+			return nil, err
+		}
+		if height < startHeight {
+			break
+		}
+
+		weightDiff, err := unmarshalWeightDiff(weightIter.Value())
+		if err != nil {
+			return nil, err
+		}
+		getDiff(height, nodeID).WeightDiff = weightDiff
+	}
+	if err := weightIter.Error(); err != nil {
+		return nil, err
+	}
+
+	if subnetID == constants.PrimaryNetworkID {
+		if err := s.addPublicKeyDiffs(ctx, startHeight, endHeight, getDiff); err != nil {
+			return nil, err
+		}
+	}
+
+	result := make([]*ValidatorDiff, 0, len(diffs))
+	for _, diff := range diffs {
+		result = append(result, diff)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Height != result[j].Height {
+			return result[i].Height < result[j].Height
+		}
+		return bytes.Compare(result[i].NodeID[:], result[j].NodeID[:]) < 0
+	})
+	return result, nil
+}
+
+// addPublicKeyDiffs reports the public key changes of the Primary Network
+// validators from [startHeight] through [endHeight].
+//
+// The public key diffs only record the value of the public key prior to each
+// change. To find the value after a change, the diffs are iterated from the
+// last accepted height towards the genesis, starting from the current
+// validator set.
+func (s *state) addPublicKeyDiffs(
+	ctx context.Context,
+	startHeight uint64,
+	endHeight uint64,
+	getDiff func(height uint64, nodeID ids.NodeID) *ValidatorDiff,
+) error {
+	// nextPublicKeys maps a nodeID to its public key after the height that is
+	// currently being iterated over.
+	nextPublicKeys := make(map[ids.NodeID]*bls.PublicKey)
+	getNextPublicKey := func(nodeID ids.NodeID) (*bls.PublicKey, error) {
+		if pk, ok := nextPublicKeys[nodeID]; ok {
+			return pk, nil
+		}
+		staker, err := s.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+		switch err {
+		case nil:
+			return staker.PublicKey, nil
+		case database.ErrNotFound:
+			return nil, nil
+		default:
+			return nil, err
+		}
+	}
+
+	pkIter := s.flatValidatorPublicKeyDiffsDB.NewIteratorWithPrefix(
+		constants.PrimaryNetworkID[:],
+	)
+	defer pkIter.Release()
+
+	for pkIter.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		_, height, nodeID, err := unmarshalDiffKey(pkIter.Key())
+		if err != nil {
+			return err
+		}
+		if height < startHeight {
+			break
+		}
+
+		pk, err := getNextPublicKey(nodeID)
+		if err != nil {
+			return err
+		}
+		if height <= endHeight {
+			diff := getDiff(height, nodeID)
+			diff.PublicKeyChanged = true
+			diff.PublicKey = pk
+		}
+
+		var priorPK *bls.PublicKey
+		if pkBytes := pkIter.Value(); len(pkBytes) != 0 {
+			priorPK = bls.DeserializePublicKey(pkBytes)
+		}
+		nextPublicKeys[nodeID] = priorPK
+	}
+	return pkIter.Error()
+}
@@ -0,0 +1,608 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go.uber.org/zap"
+
+	"github.com/luxdefi/node/database"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/snow/engine/common"
+	"github.com/luxdefi/node/snow/uptime"
+	"github.com/luxdefi/node/utils"
+	"github.com/luxdefi/node/utils/constants"
+	"github.com/luxdefi/node/utils/hashing"
+	"github.com/luxdefi/node/utils/set"
+	"github.com/luxdefi/node/utils/units"
+	"github.com/luxdefi/node/vms/platformvm/block"
+	"github.com/luxdefi/node/vms/platformvm/checkpoint"
+	"github.com/luxdefi/node/vms/platformvm/state"
+
+	snowmanblock "github.com/luxdefi/node/snow/engine/snowman/block"
+)
+
+const (
+	// numCheckpointsToKeep is the number of complete checkpoints that are
+	// served to peers. Keeping more than one allows peers that started
+	// syncing to the previous checkpoint to finish.
+	numCheckpointsToKeep = 2
+
+	// chunkRetryDelay is the time to wait before requesting a chunk again
+	// after a request failed.
+	chunkRetryDelay = time.Second
+
+	// checkpointBatchSize is the size at which writes of a synced checkpoint
+	// are flushed to the database.
+	checkpointBatchSize = units.MiB
+)
+
+var (
+	_ snowmanblock.StateSyncableVM = (*VM)(nil)
+	_ snowmanblock.StateSummary    = (*stateSummary)(nil)
+	_ prometheus.Registerer        = (*reloadRegisterer)(nil)
+
+	errUnexpectedCheckpointKey    = errors.New("unexpected checkpoint key")
+	errUnexpectedCheckpointHeight = errors.New("unexpected checkpoint height")
+	errUnknownCheckpointSigner    = errors.New("checkpoint signer isn't a primary network validator with a BLS key")
+)
+
+// stateSummary notifies the VM of the acceptance of a checkpoint summary.
+type stateSummary struct {
+	*checkpoint.Summary
+
+	vm *VM
+}
+
+func (s *stateSummary) Accept(context.Context) (snowmanblock.StateSyncMode, error) {
+	return s.vm.acceptCheckpoint(s.Summary)
+}
+
+func (vm *VM) StateSyncEnabled(context.Context) (bool, error) {
+	return vm.execConfig.CheckpointSyncEnabled, nil
+}
+
+func (vm *VM) GetOngoingSyncStateSummary(context.Context) (snowmanblock.StateSummary, error) {
+	summary, err := vm.checkpoints.GetSyncing()
+	if err != nil {
+		return nil, err // includes database.ErrNotFound case
+	}
+	return vm.newStateSummary(summary), nil
+}
+
+func (vm *VM) GetLastStateSummary(context.Context) (snowmanblock.StateSummary, error) {
+	summary, err := vm.checkpoints.GetLastSummary()
+	if err != nil {
+		return nil, err // includes database.ErrNotFound case
+	}
+	return vm.newStateSummary(summary), nil
+}
+
+func (vm *VM) ParseStateSummary(_ context.Context, summaryBytes []byte) (snowmanblock.StateSummary, error) {
+	summary, err := checkpoint.ParseSummary(summaryBytes)
+	if err != nil {
+		return nil, err
+	}
+	return vm.newStateSummary(summary), nil
+}
+
+func (vm *VM) GetStateSummary(_ context.Context, height uint64) (snowmanblock.StateSummary, error) {
+	summary, err := vm.checkpoints.GetSummary(height)
+	if err != nil {
+		return nil, err // includes database.ErrNotFound case
+	}
+	return vm.newStateSummary(summary), nil
+}
+
+func (vm *VM) newStateSummary(summary *checkpoint.Summary) *stateSummary {
+	return &stateSummary{
+		Summary: summary,
+		vm:      vm,
+	}
+}
+
+// isCheckpointKey returns true if [key] of [vm.db] is included in
+// checkpoints. The uptime history is local to this node, so it isn't included.
+func isCheckpointKey(key []byte) bool {
+	return state.IsCheckpointKey(key) && !checkpoint.IsStoreKey(key) && !uptime.IsHistoryKey(key)
+}
+
+// isReplacedKey returns true if [key] of [vm.db] is removed when the state is
+// replaced. The checkpoints and the uptime history of this node are kept.
+func isReplacedKey(key []byte) bool {
+	return !checkpoint.IsStoreKey(key) && !uptime.IsHistoryKey(key)
+}
+
+// initCheckpoints finishes writing a synced checkpoint, or a rollback, into
+// [vm.db] if the node previously shut down while doing so. It must be called
+// before the state is loaded. A synced checkpoint is then verified by
+// initSyncedCheckpoint.
+func (vm *VM) initCheckpoints() error {
+	switch height, err := vm.checkpoints.GetRollback(); err {
+	case nil:
+		vm.ctx.Log.Info("resuming rollback",
+			zap.Uint64("height", height),
+		)
+		if err := vm.writeRollback(height); err != nil {
+			return err
+		}
+	case database.ErrNotFound:
+	default:
+		return err
+	}
+
+	applying, err := vm.checkpoints.IsApplying()
+	if err != nil {
+		return err
+	}
+	if applying {
+		summary, err := vm.checkpoints.GetSyncing()
+		if err != nil {
+			return err
+		}
+
+		vm.ctx.Log.Info("resuming writing checkpoint",
+			zap.Stringer("summaryID", summary.ID()),
+			zap.Uint64("height", summary.Height()),
+		)
+		if err := vm.writeSyncedCheckpoint(summary); err != nil {
+			return err
+		}
+	}
+
+	// Remove any chunks left behind by a checkpoint that was being written
+	// when the node shut down.
+	return vm.checkpoints.Prune(numCheckpointsToKeep)
+}
+
+// onCommit is called with the context lock held every time the accepted state
+// is committed. A checkpoint is started whenever a multiple of the checkpoint
+// interval is crossed.
+func (vm *VM) onCommit(blk block.Block) {
+	vm.publishValidatorSetDiffs(blk)
+
+	height := blk.Height()
+	prevHeight := vm.lastCommittedHeight
+	vm.lastCommittedHeight = height
+
+	interval := vm.execConfig.CheckpointInterval
+	if interval == 0 || prevHeight/interval == height/interval {
+		return
+	}
+	if vm.buildingCheckpoint.Get() {
+		vm.ctx.Log.Debug("skipping checkpoint",
+			zap.String("reason", "previous checkpoint is still being written"),
+			zap.Uint64("height", height),
+		)
+		return
+	}
+
+	// The iterator is created while the context lock is held so that it
+	// iterates over exactly the state as of [blk].
+	vm.buildingCheckpoint.Set(true)
+	vm.checkpointWG.Add(1)
+	go vm.writeCheckpoint(height, blk.Bytes(), vm.db.NewIterator())
+}
+
+func (vm *VM) writeCheckpoint(height uint64, blkBytes []byte, it database.Iterator) {
+	defer func() {
+		it.Release()
+		vm.buildingCheckpoint.Set(false)
+		vm.checkpointWG.Done()
+	}()
+
+	chunkIDs, err := checkpoint.WriteChunks(vm.checkpoints, height, it, isCheckpointKey, vm.checkpointCtx.Done())
+	if errors.Is(err, checkpoint.ErrClosing) {
+		return
+	}
+	if err != nil {
+		vm.ctx.Log.Error("failed to write checkpoint",
+			zap.Uint64("height", height),
+			zap.Error(err),
+		)
+		return
+	}
+
+	summary, err := checkpoint.NewSummary(
+		vm.ctx.NetworkID,
+		vm.ctx.ChainID,
+		vm.ctx.NodeID,
+		vm.ctx.WarpSigner,
+		height,
+		blkBytes,
+		chunkIDs,
+	)
+	if err != nil {
+		vm.ctx.Log.Error("failed to create checkpoint summary",
+			zap.Uint64("height", height),
+			zap.Error(err),
+		)
+		return
+	}
+	if err := vm.checkpoints.PutSummary(summary); err != nil {
+		vm.ctx.Log.Error("failed to write checkpoint summary",
+			zap.Uint64("height", height),
+			zap.Error(err),
+		)
+		return
+	}
+	if err := vm.checkpoints.Prune(numCheckpointsToKeep); err != nil {
+		vm.ctx.Log.Error("failed to prune checkpoints",
+			zap.Error(err),
+		)
+		return
+	}
+
+	vm.ctx.Log.Info("created checkpoint",
+		zap.Stringer("summaryID", summary.ID()),
+		zap.Uint64("height", height),
+		zap.Int("numChunks", len(chunkIDs)),
+	)
+}
+
+// acceptCheckpoint is called with the context lock held once the network
+// agreed on [summary]. The chunks of the checkpoint are fetched in the
+// background and the engine is notified once the state has been replaced.
+func (vm *VM) acceptCheckpoint(summary *checkpoint.Summary) (snowmanblock.StateSyncMode, error) {
+	if summary.Height() <= vm.lastCommittedHeight {
+		vm.ctx.Log.Info("skipping checkpoint sync",
+			zap.String("reason", "already past the checkpoint"),
+			zap.Uint64("height", summary.Height()),
+			zap.Uint64("lastCommittedHeight", vm.lastCommittedHeight),
+		)
+		if err := vm.checkpoints.DeleteSyncing(); err != nil {
+			return snowmanblock.StateSyncSkipped, err
+		}
+		return snowmanblock.StateSyncSkipped, nil
+	}
+	if !vm.pruned.Get() {
+		vm.ctx.Log.Info("skipping checkpoint sync",
+			zap.String("reason", "state is being pruned"),
+			zap.Uint64("height", summary.Height()),
+		)
+		return snowmanblock.StateSyncSkipped, nil
+	}
+
+	if err := vm.checkpoints.PutSyncing(summary); err != nil {
+		return snowmanblock.StateSyncSkipped, err
+	}
+
+	vm.ctx.Log.Info("starting checkpoint sync",
+		zap.Stringer("summaryID", summary.ID()),
+		zap.Uint64("height", summary.Height()),
+		zap.Int("numChunks", len(summary.ChunkIDs)),
+	)
+
+	vm.checkpointWG.Add(1)
+	go vm.syncCheckpoint(summary)
+	return snowmanblock.StateSyncStatic, nil
+}
+
+func (vm *VM) syncCheckpoint(summary *checkpoint.Summary) {
+	defer vm.checkpointWG.Done()
+
+	for i, chunkID := range summary.ChunkIDs {
+		if err := vm.fetchChunk(summary.Height(), uint32(i), chunkID); err != nil {
+			if vm.checkpointCtx.Err() == nil {
+				vm.ctx.Log.Error("failed to fetch checkpoint chunk",
+					zap.Uint64("height", summary.Height()),
+					zap.Int("index", i),
+					zap.Error(err),
+				)
+			}
+			return
+		}
+	}
+
+	vm.ctx.Lock.Lock()
+	if vm.checkpointCtx.Err() != nil {
+		vm.ctx.Lock.Unlock()
+		return
+	}
+	err := vm.applyCheckpoint(summary)
+	if err == nil {
+		err = vm.startBackfill()
+	}
+	vm.ctx.Lock.Unlock()
+	if err != nil {
+		vm.ctx.Log.Fatal("failed to apply checkpoint",
+			zap.Stringer("summaryID", summary.ID()),
+			zap.Uint64("height", summary.Height()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	vm.ctx.Log.Info("finished checkpoint sync",
+		zap.Stringer("summaryID", summary.ID()),
+		zap.Uint64("height", summary.Height()),
+	)
+
+	select {
+	case vm.toEngine <- common.StateSyncDone:
+	case <-vm.checkpointCtx.Done():
+	}
+}
+
+// fetchChunk fetches the chunk at [index] of the checkpoint at [height] from
+// peers until a chunk matching [chunkID] is received.
+func (vm *VM) fetchChunk(height uint64, index uint32, chunkID ids.ID) error {
+	// The chunk may have been fetched before the node restarted.
+	chunk, err := vm.checkpoints.GetChunk(height, index)
+	if err == nil && hashing.ComputeHash256Array(chunk) == chunkID {
+		return nil
+	}
+	if err != nil && err != database.ErrNotFound {
+		return err
+	}
+
+	for {
+		chunk, nodeID, err := vm.checkpointClient.GetChunk(vm.checkpointCtx, height, index)
+		if err == nil && hashing.ComputeHash256Array(chunk) == chunkID {
+			return vm.checkpoints.PutChunk(height, index, chunk)
+		}
+		if err == nil {
+			err = fmt.Errorf("expected chunk %s", chunkID)
+		}
+		vm.ctx.Log.Debug("failed to fetch checkpoint chunk",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint64("height", height),
+			zap.Uint32("index", index),
+			zap.Error(err),
+		)
+
+		select {
+		case <-time.After(chunkRetryDelay):
+		case <-vm.checkpointCtx.Done():
+			return vm.checkpointCtx.Err()
+		}
+	}
+}
+
+// applyCheckpoint replaces the state of the VM with the fetched chunks of
+// [summary]. It must be called with the context lock held.
+func (vm *VM) applyCheckpoint(summary *checkpoint.Summary) error {
+	vm.Builder.Shutdown()
+
+	if err := vm.removeValidators(); err != nil {
+		return err
+	}
+	if err := vm.state.Close(); err != nil {
+		return err
+	}
+	if err := vm.checkpoints.SetApplying(true); err != nil {
+		return err
+	}
+	if err := vm.writeSyncedCheckpoint(summary); err != nil {
+		return err
+	}
+	if err := vm.initState(context.TODO(), reloadRegisterer{vm.registerer}); err != nil {
+		return err
+	}
+	return vm.verifySyncedCheckpoint(summary)
+}
+
+// initSyncedCheckpoint verifies the synced checkpoint written by
+// initCheckpoints, if any. It must be called after the state is loaded.
+func (vm *VM) initSyncedCheckpoint() error {
+	applying, err := vm.checkpoints.IsApplying()
+	if err != nil || !applying {
+		return err
+	}
+	summary, err := vm.checkpoints.GetSyncing()
+	if err != nil {
+		return err
+	}
+	return vm.verifySyncedCheckpoint(summary)
+}
+
+// verifySyncedCheckpoint verifies that [summary] was signed by a primary
+// network validator of the state that was loaded from its checkpoint. If it
+// wasn't, the checkpoint is discarded and the state is reset to genesis, from
+// which the node bootstraps normally. It must be called with the context lock
+// held.
+func (vm *VM) verifySyncedCheckpoint(summary *checkpoint.Summary) error {
+	err := vm.verifyCheckpointSignature(summary)
+	switch {
+	case errors.Is(err, errUnknownCheckpointSigner) || errors.Is(err, checkpoint.ErrInvalidSignature):
+		vm.ctx.Log.Warn("discarding checkpoint",
+			zap.Stringer("summaryID", summary.ID()),
+			zap.Uint64("height", summary.Height()),
+			zap.Stringer("signer", summary.Signer),
+			zap.Error(err),
+		)
+		if err := vm.resetState(); err != nil {
+			return err
+		}
+		if err := vm.checkpoints.DeleteBackfill(); err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	default:
+		// The blocks below the checkpoint are fetched in the background.
+		if err := vm.checkpoints.PutBackfill(summary.Height()); err != nil {
+			return err
+		}
+	}
+
+	return utils.Err(
+		vm.checkpoints.SetApplying(false),
+		vm.checkpoints.DeleteSyncing(),
+	)
+}
+
+// verifyCheckpointSignature returns nil if [summary] is signed by its signer
+// and the signer is a primary network validator of the loaded state.
+func (vm *VM) verifyCheckpointSignature(summary *checkpoint.Summary) error {
+	validator, err := vm.state.GetCurrentValidator(constants.PrimaryNetworkID, summary.Signer)
+	if err == database.ErrNotFound {
+		return fmt.Errorf("%w: %s", errUnknownCheckpointSigner, summary.Signer)
+	}
+	if err != nil {
+		return err
+	}
+	if validator.PublicKey == nil {
+		return fmt.Errorf("%w: %s", errUnknownCheckpointSigner, summary.Signer)
+	}
+	return summary.Verify(vm.ctx.NetworkID, vm.ctx.ChainID, validator.PublicKey)
+}
+
+// resetState replaces the state of the VM with the genesis state. It must be
+// called with the context lock held.
+func (vm *VM) resetState() error {
+	vm.Builder.Shutdown()
+
+	if err := vm.removeValidators(); err != nil {
+		return err
+	}
+	if err := vm.state.Close(); err != nil {
+		return err
+	}
+	if err := vm.deleteState(isReplacedKey); err != nil {
+		return err
+	}
+	return vm.initState(context.TODO(), reloadRegisterer{vm.registerer})
+}
+
+// removeValidators removes the validators loaded from the current state from
+// the validator manager, which the reloaded state expects to be empty.
+func (vm *VM) removeValidators() error {
+	stakerIterator, err := vm.state.GetCurrentStakerIterator()
+	if err != nil {
+		return err
+	}
+	defer stakerIterator.Release()
+
+	subnetIDs := set.Set[ids.ID]{}
+	for stakerIterator.Next() {
+		subnetIDs.Add(stakerIterator.Value().SubnetID)
+	}
+	for subnetID := range subnetIDs {
+		for _, nodeID := range vm.Validators.GetValidatorIDs(subnetID) {
+			weight := vm.Validators.GetWeight(subnetID, nodeID)
+			if err := vm.Validators.RemoveWeight(subnetID, nodeID, weight); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeSyncedCheckpoint replaces the contents of [vm.db] with the fetched
+// chunks of [summary]. Blocks before the checkpoint are removed, and fetched
+// again by backfillBlocks, while the uptime history of this node is kept. If the node shuts down part way through,
+// the write is retried on startup.
+func (vm *VM) writeSyncedCheckpoint(summary *checkpoint.Summary) error {
+	if err := vm.replaceState(summary, isReplacedKey); err != nil {
+		return err
+	}
+
+	blk, err := block.Parse(block.Codec, summary.Block)
+	if err != nil {
+		return err
+	}
+	if blk.Height() != summary.Height() {
+		return fmt.Errorf("%w: expected %d but got %d", errUnexpectedCheckpointHeight, summary.Height(), blk.Height())
+	}
+	return state.PutCheckpointBlock(vm.db, blk)
+}
+
+// replaceState deletes the keys of [vm.db] for which [isReplaced] returns true
+// and writes the key/value pairs of the checkpoint described by [summary],
+// whose chunks must be in [vm.checkpoints].
+func (vm *VM) replaceState(summary *checkpoint.Summary, isReplaced func(key []byte) bool) error {
+	if err := vm.deleteState(isReplaced); err != nil {
+		return err
+	}
+
+	batch := vm.db.NewBatch()
+	for i := range summary.ChunkIDs {
+		chunkBytes, err := vm.checkpoints.GetChunk(summary.Height(), uint32(i))
+		if err != nil {
+			return err
+		}
+		chunk, err := checkpoint.ParseChunk(chunkBytes)
+		if err != nil {
+			return err
+		}
+		for _, kv := range chunk.KeyValues {
+			if !isCheckpointKey(kv.Key) {
+				return fmt.Errorf("%w: %x", errUnexpectedCheckpointKey, kv.Key)
+			}
+			if err := batch.Put(kv.Key, kv.Value); err != nil {
+				return err
+			}
+			if err := flushCheckpointBatch(batch); err != nil {
+				return err
+			}
+		}
+	}
+	return batch.Write()
+}
+
+// deleteState deletes the keys of [vm.db] for which [isReplaced] returns true.
+func (vm *VM) deleteState(isReplaced func(key []byte) bool) error {
+	batch := vm.db.NewBatch()
+	it := vm.db.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		if !isReplaced(it.Key()) {
+			continue
+		}
+		if err := batch.Delete(it.Key()); err != nil {
+			return err
+		}
+		if err := flushCheckpointBatch(batch); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// flushCheckpointBatch writes [batch] once it reaches checkpointBatchSize.
+func flushCheckpointBatch(batch database.Batch) error {
+	if batch.Size() < checkpointBatchSize {
+		return nil
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	batch.Reset()
+	return nil
+}
+
+// reloadRegisterer replaces any collectors registered by the state that is
+// being reloaded.
+type reloadRegisterer struct {
+	prometheus.Registerer
+}
+
+func (r reloadRegisterer) Register(c prometheus.Collector) error {
+	err := r.Registerer.Register(c)
+	var alreadyRegistered prometheus.AlreadyRegisteredError
+	if !errors.As(err, &alreadyRegistered) {
+		return err
+	}
+	r.Registerer.Unregister(alreadyRegistered.ExistingCollector)
+	return r.Registerer.Register(c)
+}
+
+func (r reloadRegisterer) MustRegister(cs ...prometheus.Collector) {
+	for _, c := range cs {
+		if err := r.Register(c); err != nil {
+			panic(err)
+		}
+	}
+}
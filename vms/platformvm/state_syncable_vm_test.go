@@ -0,0 +1,455 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxdefi/node/chains"
+	"github.com/luxdefi/node/chains/atomic"
+	"github.com/luxdefi/node/database"
+	"github.com/luxdefi/node/database/memdb"
+	"github.com/luxdefi/node/database/prefixdb"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/snow"
+	"github.com/luxdefi/node/snow/consensus/snowman"
+	"github.com/luxdefi/node/snow/engine/common"
+	"github.com/luxdefi/node/snow/uptime"
+	"github.com/luxdefi/node/snow/validators"
+	"github.com/luxdefi/node/utils/constants"
+	"github.com/luxdefi/node/utils/crypto/bls"
+	"github.com/luxdefi/node/utils/crypto/secp256k1"
+	"github.com/luxdefi/node/utils/formatting"
+	"github.com/luxdefi/node/utils/set"
+	"github.com/luxdefi/node/version"
+	"github.com/luxdefi/node/vms/platformvm/api"
+	"github.com/luxdefi/node/vms/platformvm/config"
+	"github.com/luxdefi/node/vms/platformvm/signer"
+	"github.com/luxdefi/node/vms/platformvm/txs"
+	"github.com/luxdefi/node/vms/platformvm/warp"
+
+	snowmanblock "github.com/luxdefi/node/snow/engine/snowman/block"
+)
+
+// checkpointGenesis returns the default genesis, in which the first genesis
+// validator registered the BLS key [sk].
+func checkpointGenesis(t *testing.T, sk *bls.SecretKey) []byte {
+	require := require.New(t)
+
+	args, _ := defaultGenesis(t)
+	args.Validators[0].Signer = signer.NewProofOfPossession(sk)
+
+	reply := api.BuildGenesisReply{}
+	platformvmSS := api.StaticService{}
+	require.NoError(platformvmSS.BuildGenesis(nil, args, &reply))
+
+	genesisBytes, err := formatting.Decode(reply.Encoding, reply.Bytes)
+	require.NoError(err)
+	return genesisBytes
+}
+
+// newCheckpointVM returns a VM running as the first genesis validator, which
+// signs its checkpoints with [sk].
+func newCheckpointVM(
+	t *testing.T,
+	configBytes []byte,
+	genesisBytes []byte,
+	sk *bls.SecretKey,
+	appSender common.AppSender,
+) (*VM, chan common.Message) {
+	require := require.New(t)
+
+	vm := &VM{Config: config.Config{
+		Chains:                 chains.TestManager,
+		UptimeLockedCalculator: uptime.NewLockedCalculator(),
+		SybilProtectionEnabled: true,
+		Validators:             validators.NewManager(),
+		TxFee:                  defaultTxFee,
+		CreateSubnetTxFee:      100 * defaultTxFee,
+		TransformSubnetTxFee:   100 * defaultTxFee,
+		CreateBlockchainTxFee:  100 * defaultTxFee,
+		MinValidatorStake:      defaultMinValidatorStake,
+		MaxValidatorStake:      defaultMaxValidatorStake,
+		MinDelegatorStake:      defaultMinDelegatorStake,
+		MinStakeDuration:       defaultMinStakingDuration,
+		MaxStakeDuration:       defaultMaxStakingDuration,
+		RewardConfig:           defaultRewardConfig,
+		ApricotPhase3Time:      defaultValidateEndTime,
+		ApricotPhase5Time:      defaultValidateEndTime,
+		BanffTime:              banffForkTime,
+	}}
+	vm.clock.Set(banffForkTime.Add(time.Second))
+
+	db := memdb.New()
+	chainDB := prefixdb.New([]byte{0}, db)
+	atomicDB := prefixdb.New([]byte{1}, db)
+
+	ctx := defaultContext(t)
+	ctx.NodeID = genesisNodeIDs[0]
+	ctx.WarpSigner = warp.NewSigner(sk, ctx.NetworkID, ctx.ChainID)
+	m := atomic.NewMemory(atomicDB)
+	ctx.SharedMemory = m.NewSharedMemory(ctx.ChainID)
+
+	ctx.Lock.Lock()
+	defer ctx.Lock.Unlock()
+
+	msgChan := make(chan common.Message, 1)
+	require.NoError(vm.Initialize(
+		context.Background(),
+		ctx,
+		chainDB,
+		genesisBytes,
+		nil,
+		configBytes,
+		msgChan,
+		nil,
+		appSender,
+	))
+	return vm, msgChan
+}
+
+func TestCheckpointSync(t *testing.T) {
+	validatorKey, err := bls.NewSecretKey()
+	require.NoError(t, err)
+	unknownKey, err := bls.NewSecretKey()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		serverKey *bls.SecretKey
+		// expectSynced is true if the client keeps the synced checkpoint,
+		// rather than resetting to genesis.
+		expectSynced bool
+	}{
+		{
+			name:         "signed by validator",
+			serverKey:    validatorKey,
+			expectSynced: true,
+		},
+		{
+			name:         "signed with unregistered key",
+			serverKey:    unknownKey,
+			expectSynced: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			var (
+				serverNodeID = ids.GenerateTestNodeID()
+				clientNodeID = ids.GenerateTestNodeID()
+				server       *VM
+				client       *VM
+			)
+			serverSender := &common.SenderTest{
+				T: t,
+				SendAppResponseF: func(ctx context.Context, _ ids.NodeID, requestID uint32, response []byte) error {
+					return client.AppResponse(ctx, serverNodeID, requestID, response)
+				},
+			}
+			clientSender := &common.SenderTest{
+				T: t,
+				SendAppRequestF: func(ctx context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, request []byte) error {
+					require.Equal(set.Of(serverNodeID), nodeIDs)
+					// Requests are delivered asynchronously, as they are by
+					// the network.
+					go func() {
+						if err := server.AppRequest(ctx, clientNodeID, requestID, time.Time{}, request); err != nil {
+							t.Error(err)
+						}
+					}()
+					return nil
+				},
+			}
+
+			genesisBytes := checkpointGenesis(t, validatorKey)
+			server, _ = newCheckpointVM(t, []byte(`{"checkpoint-interval":1}`), genesisBytes, tt.serverKey, serverSender)
+			client, clientMsgChan := newCheckpointVM(t, []byte(`{"checkpoint-sync-enabled":true}`), genesisBytes, validatorKey, clientSender)
+
+			server.ctx.Lock.Lock()
+			require.NoError(server.SetState(context.Background(), snow.NormalOp))
+
+			enabled, err := server.StateSyncEnabled(context.Background())
+			require.NoError(err)
+			require.False(enabled)
+
+			_, err = server.GetLastStateSummary(context.Background())
+			require.ErrorIs(err, database.ErrNotFound)
+
+			// Accepting a block crosses the checkpoint interval.
+			subnetTx, err := server.txBuilder.NewCreateSubnetTx(
+				1,
+				[]ids.ShortID{keys[0].PublicKey().Address()},
+				[]*secp256k1.PrivateKey{keys[0]},
+				keys[0].PublicKey().Address(),
+			)
+			require.NoError(err)
+			require.NoError(server.Network.IssueTx(context.Background(), subnetTx))
+			blk, err := server.Builder.BuildBlock(context.Background())
+			require.NoError(err)
+			require.NoError(blk.Verify(context.Background()))
+			require.NoError(blk.Accept(context.Background()))
+			server.checkpointWG.Wait()
+
+			summary, err := server.GetLastStateSummary(context.Background())
+			require.NoError(err)
+			require.Equal(blk.Height(), summary.Height())
+			server.ctx.Lock.Unlock()
+			defer func() {
+				server.ctx.Lock.Lock()
+				require.NoError(server.Shutdown(context.Background()))
+				server.ctx.Lock.Unlock()
+			}()
+
+			client.ctx.Lock.Lock()
+			enabled, err = client.StateSyncEnabled(context.Background())
+			require.NoError(err)
+			require.True(enabled)
+			require.NoError(client.Connected(context.Background(), serverNodeID, version.CurrentApp))
+
+			genesisID, err := client.LastAccepted(context.Background())
+			require.NoError(err)
+			genesisValidators := client.Validators.GetMap(constants.PrimaryNetworkID)
+
+			parsedSummary, err := client.ParseStateSummary(context.Background(), summary.Bytes())
+			require.NoError(err)
+			require.Equal(summary.ID(), parsedSummary.ID())
+			client.ctx.Lock.Unlock()
+
+			// The client may still be indexing its genesis state.
+			require.Eventually(client.pruned.Get, time.Minute, 10*time.Millisecond)
+
+			client.ctx.Lock.Lock()
+			mode, err := parsedSummary.Accept(context.Background())
+			require.NoError(err)
+			require.Equal(snowmanblock.StateSyncStatic, mode)
+			client.ctx.Lock.Unlock()
+
+			require.Equal(common.StateSyncDone, <-clientMsgChan)
+
+			client.ctx.Lock.Lock()
+			defer func() {
+				require.NoError(client.Shutdown(context.Background()))
+				client.ctx.Lock.Unlock()
+			}()
+
+			_, err = client.GetOngoingSyncStateSummary(context.Background())
+			require.ErrorIs(err, database.ErrNotFound)
+
+			lastAccepted, err := client.LastAccepted(context.Background())
+			require.NoError(err)
+			if !tt.expectSynced {
+				// The checkpoint was discarded, so the client bootstraps from
+				// genesis.
+				require.Equal(genesisID, lastAccepted)
+
+				_, _, err = client.state.GetTx(subnetTx.ID())
+				require.ErrorIs(err, database.ErrNotFound)
+
+				require.Equal(genesisValidators, client.Validators.GetMap(constants.PrimaryNetworkID))
+
+				_, err = client.checkpoints.GetBackfill()
+				require.ErrorIs(err, database.ErrNotFound)
+				return
+			}
+			require.Equal(blk.ID(), lastAccepted)
+
+			// The blocks below the checkpoint are fetched from the server in
+			// the background.
+			client.ctx.Lock.Unlock()
+			require.Eventually(func() bool {
+				client.ctx.Lock.Lock()
+				defer client.ctx.Lock.Unlock()
+
+				_, err := client.checkpoints.GetBackfill()
+				return err == database.ErrNotFound
+			}, time.Minute, 10*time.Millisecond)
+			client.ctx.Lock.Lock()
+
+			parent, err := client.GetBlock(context.Background(), blk.Parent())
+			require.NoError(err)
+			require.Equal(genesisID, parent.ID())
+
+			genesisHeightID, err := client.GetBlockIDAtHeight(context.Background(), 0)
+			require.NoError(err)
+			require.Equal(genesisID, genesisHeightID)
+
+			gotSubnetTx, _, err := client.state.GetTx(subnetTx.ID())
+			require.NoError(err)
+			require.Equal(subnetTx.Bytes(), gotSubnetTx.Bytes())
+
+			require.Equal(
+				server.Validators.GetMap(constants.PrimaryNetworkID),
+				client.Validators.GetMap(constants.PrimaryNetworkID),
+			)
+
+			// A summary at or below the last accepted height is skipped.
+			mode, err = parsedSummary.Accept(context.Background())
+			require.NoError(err)
+			require.Equal(snowmanblock.StateSyncSkipped, mode)
+		})
+	}
+}
+
+func TestRollback(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	vm, _ := newCheckpointVM(t, []byte(`{"checkpoint-interval":1}`), checkpointGenesis(t, sk), sk, &common.SenderTest{T: t})
+	vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+		vm.ctx.Lock.Unlock()
+	}()
+	require.NoError(vm.SetState(context.Background(), snow.NormalOp))
+
+	// Accept a block at heights 1 and 2, each of which is checkpointed.
+	var (
+		subnetTxs = make([]*txs.Tx, 2)
+		blks      = make([]snowman.Block, 2)
+	)
+	for i := range blks {
+		subnetTx, err := vm.txBuilder.NewCreateSubnetTx(
+			1,
+			[]ids.ShortID{keys[i].PublicKey().Address()},
+			[]*secp256k1.PrivateKey{keys[i]},
+			keys[i].PublicKey().Address(),
+		)
+		require.NoError(err)
+		require.NoError(vm.Network.IssueTx(context.Background(), subnetTx))
+		blk, err := vm.Builder.BuildBlock(context.Background())
+		require.NoError(err)
+		require.NoError(blk.Verify(context.Background()))
+		require.NoError(blk.Accept(context.Background()))
+		require.NoError(vm.SetPreference(context.Background(), blk.ID()))
+		vm.checkpointWG.Wait()
+
+		subnetTxs[i] = subnetTx
+		blks[i] = blk
+	}
+
+	// Rolling back requires the state to be pruned.
+	vm.ctx.Lock.Unlock()
+	require.Eventually(vm.pruned.Get, time.Minute, 10*time.Millisecond)
+	vm.ctx.Lock.Lock()
+
+	err = vm.VerifyRollback(context.Background(), 3)
+	require.ErrorIs(err, errRollbackAboveLastAccepted)
+	err = vm.VerifyRollback(context.Background(), 0)
+	require.ErrorIs(err, snowmanblock.ErrRollbackNotSupported)
+	require.NoError(vm.VerifyRollback(context.Background(), 2))
+
+	require.NoError(vm.Rollback(context.Background(), 1))
+
+	lastAccepted, err := vm.LastAccepted(context.Background())
+	require.NoError(err)
+	require.Equal(blks[0].ID(), lastAccepted)
+
+	_, err = vm.GetBlock(context.Background(), blks[1].ID())
+	require.ErrorIs(err, database.ErrNotFound)
+	_, err = vm.GetBlockIDAtHeight(context.Background(), 2)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	_, _, err = vm.state.GetTx(subnetTxs[0].ID())
+	require.NoError(err)
+	_, _, err = vm.state.GetTx(subnetTxs[1].ID())
+	require.ErrorIs(err, database.ErrNotFound)
+
+	_, err = vm.GetStateSummary(context.Background(), 2)
+	require.ErrorIs(err, database.ErrNotFound)
+	_, err = vm.checkpoints.GetRollback()
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// Rolling back to the last accepted height is a no-op.
+	require.NoError(vm.Rollback(context.Background(), 1))
+
+	// The rolled back transaction can be issued again.
+	require.NoError(vm.SetPreference(context.Background(), lastAccepted))
+	require.NoError(vm.Network.IssueTx(context.Background(), subnetTxs[1]))
+	blk, err := vm.Builder.BuildBlock(context.Background())
+	require.NoError(err)
+	require.Equal(uint64(2), blk.Height())
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(blk.Accept(context.Background()))
+	vm.checkpointWG.Wait()
+}
+
+func TestRollbackAtomicTx(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	vm, _ := newCheckpointVM(t, []byte(`{"checkpoint-interval":1}`), checkpointGenesis(t, sk), sk, &common.SenderTest{T: t})
+	vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+		vm.ctx.Lock.Unlock()
+	}()
+	require.NoError(vm.SetState(context.Background(), snow.NormalOp))
+
+	// Accept a block at height 1, an export at height 2 and another block at
+	// height 3.
+	subnetTx, err := vm.txBuilder.NewCreateSubnetTx(
+		1,
+		[]ids.ShortID{keys[0].PublicKey().Address()},
+		[]*secp256k1.PrivateKey{keys[0]},
+		keys[0].PublicKey().Address(),
+	)
+	require.NoError(err)
+	exportTx, err := vm.txBuilder.NewExportTx(
+		100,
+		vm.ctx.XChainID,
+		ids.GenerateTestShortID(),
+		[]*secp256k1.PrivateKey{keys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	require.NoError(err)
+	lastSubnetTx, err := vm.txBuilder.NewCreateSubnetTx(
+		1,
+		[]ids.ShortID{keys[2].PublicKey().Address()},
+		[]*secp256k1.PrivateKey{keys[2]},
+		keys[2].PublicKey().Address(),
+	)
+	require.NoError(err)
+	for _, tx := range []*txs.Tx{subnetTx, exportTx, lastSubnetTx} {
+		require.NoError(vm.Network.IssueTx(context.Background(), tx))
+		blk, err := vm.Builder.BuildBlock(context.Background())
+		require.NoError(err)
+		require.NoError(blk.Verify(context.Background()))
+		require.NoError(blk.Accept(context.Background()))
+		require.NoError(vm.SetPreference(context.Background(), blk.ID()))
+		vm.checkpointWG.Wait()
+	}
+
+	vm.ctx.Lock.Unlock()
+	require.Eventually(vm.pruned.Get, time.Minute, 10*time.Millisecond)
+	vm.ctx.Lock.Lock()
+
+	// The export can't be rolled back.
+	err = vm.VerifyRollback(context.Background(), 1)
+	require.ErrorIs(err, snowmanblock.ErrRollbackNotSupported)
+	err = vm.Rollback(context.Background(), 1)
+	require.ErrorIs(err, snowmanblock.ErrRollbackNotSupported)
+
+	lastAccepted, err := vm.LastAccepted(context.Background())
+	require.NoError(err)
+	lastAcceptedBlk, err := vm.GetBlock(context.Background(), lastAccepted)
+	require.NoError(err)
+	require.Equal(uint64(3), lastAcceptedBlk.Height())
+
+	// The blocks after the export can be rolled back.
+	require.NoError(vm.VerifyRollback(context.Background(), 2))
+	require.NoError(vm.Rollback(context.Background(), 2))
+
+	_, _, err = vm.state.GetTx(exportTx.ID())
+	require.NoError(err)
+	_, _, err = vm.state.GetTx(lastSubnetTx.ID())
+	require.ErrorIs(err, database.ErrNotFound)
+}
@@ -0,0 +1,53 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"time"
+
+	"github.com/luxdefi/node/snow"
+	"github.com/luxdefi/node/utils/constants"
+)
+
+var (
+	_ ValidatorTx = (*AddContinuousValidatorTx)(nil)
+
+	errContinuousValidatorNotPrimaryNetwork = errors.New("continuous validators must validate the primary network")
+)
+
+// AddContinuousValidatorTx is an unsigned addContinuousValidatorTx. It adds a
+// primary network validator whose staking period is renewed, for the same
+// duration as its first staking period, each time it ends until the validator
+// is stopped with a StopContinuousValidatorTx.
+type AddContinuousValidatorTx struct {
+	// Describes the validator and its first staking period
+	AddPermissionlessValidatorTx `serialize:"true"`
+	// If true, the validation rewards of each staking period are added to the
+	// stake of the next staking period. Otherwise, they are paid out at the end
+	// of each staking period.
+	AutoCompound bool `serialize:"true" json:"autoCompound"`
+}
+
+// Period returns the duration of each staking period of the validator.
+func (tx *AddContinuousValidatorTx) Period() time.Duration {
+	return tx.Validator.Duration()
+}
+
+// SyntacticVerify returns nil iff [tx] is valid
+func (tx *AddContinuousValidatorTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified: // already passed syntactic verification
+		return nil
+	case tx.Subnet != constants.PrimaryNetworkID:
+		return errContinuousValidatorNotPrimaryNetwork
+	}
+	return tx.AddPermissionlessValidatorTx.SyntacticVerify(ctx)
+}
+
+func (tx *AddContinuousValidatorTx) Visit(visitor Visitor) error {
+	return visitor.AddContinuousValidatorTx(tx)
+}
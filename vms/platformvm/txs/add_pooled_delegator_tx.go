@@ -0,0 +1,109 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/luxdefi/node/snow"
+	"github.com/luxdefi/node/utils/math"
+	"github.com/luxdefi/node/vms/components/verify"
+	"github.com/luxdefi/node/vms/platformvm/fx"
+)
+
+// MaxPoolContributions is the maximum number of contributions to a pooled
+// delegation. Each contribution may produce a reward UTXO.
+const MaxPoolContributions = 128
+
+var (
+	_ DelegatorTx = (*AddPooledDelegatorTx)(nil)
+
+	errNoPoolContributions         = errors.New("no pool contributions")
+	errTooManyPoolContributions    = errors.New("too many pool contributions")
+	errZeroPoolContribution        = errors.New("pool contribution has no weight")
+	errPoolContributionsMismatched = errors.New("pool contributions don't sum up to the delegator weight")
+)
+
+// AddPooledDelegatorTx is an unsigned addPooledDelegatorTx. It adds a
+// delegator whose stake is contributed by several owners. The rewards of the
+// delegation are split between the owners pro rata to their contributions.
+type AddPooledDelegatorTx struct {
+	// Describes the delegation. The part of the rewards that can't be split
+	// evenly between the contributions is sent to [DelegationRewardsOwner].
+	AddPermissionlessDelegatorTx `serialize:"true"`
+	// Contributions to the stake of the delegation
+	Contributions []*PoolContribution `serialize:"true" json:"contributions"`
+}
+
+// PoolContribution is the part of the stake of a pooled delegation contributed
+// by one owner.
+//
+// The weight of a contribution isn't bound to the stake outputs or to the
+// inputs that funded them. The declared split is only enforced by the
+// signatures of the inputs: by signing the tx, every owner of a consumed UTXO
+// agrees to the declared contributions.
+type PoolContribution struct {
+	// Amount of stake contributed
+	Weight uint64 `serialize:"true" json:"weight"`
+	// Where to send the share of the staking rewards of this contribution
+	RewardsOwner fx.Owner `serialize:"true" json:"rewardsOwner"`
+}
+
+// InitCtx sets the FxID fields in the inputs and outputs of this
+// [AddPooledDelegatorTx]. Also sets the [ctx] to the given [vm.ctx] so that
+// the addresses can be json marshalled into human readable format
+func (tx *AddPooledDelegatorTx) InitCtx(ctx *snow.Context) {
+	tx.AddPermissionlessDelegatorTx.InitCtx(ctx)
+	for _, contribution := range tx.Contributions {
+		contribution.RewardsOwner.InitCtx(ctx)
+	}
+}
+
+// ContributionWeights returns the weight of each contribution, in order.
+func (tx *AddPooledDelegatorTx) ContributionWeights() []uint64 {
+	weights := make([]uint64, len(tx.Contributions))
+	for i, contribution := range tx.Contributions {
+		weights[i] = contribution.Weight
+	}
+	return weights
+}
+
+// SyntacticVerify returns nil iff [tx] is valid
+func (tx *AddPooledDelegatorTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified: // already passed syntactic verification
+		return nil
+	case len(tx.Contributions) == 0:
+		return errNoPoolContributions
+	case len(tx.Contributions) > MaxPoolContributions:
+		return fmt.Errorf("%w: %d > %d", errTooManyPoolContributions, len(tx.Contributions), MaxPoolContributions)
+	}
+
+	var totalWeight uint64
+	for _, contribution := range tx.Contributions {
+		if contribution.Weight == 0 {
+			return errZeroPoolContribution
+		}
+		if err := verify.All(contribution.RewardsOwner); err != nil {
+			return fmt.Errorf("failed to verify pool contribution rewards owner: %w", err)
+		}
+
+		var err error
+		totalWeight, err = math.Add64(totalWeight, contribution.Weight)
+		if err != nil {
+			return err
+		}
+	}
+	if totalWeight != tx.Wght {
+		return fmt.Errorf("%w: %d != %d", errPoolContributionsMismatched, totalWeight, tx.Wght)
+	}
+	return tx.AddPermissionlessDelegatorTx.SyntacticVerify(ctx)
+}
+
+func (tx *AddPooledDelegatorTx) Visit(visitor Visitor) error {
+	return visitor.AddPooledDelegatorTx(tx)
+}
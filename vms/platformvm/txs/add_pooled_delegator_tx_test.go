@@ -0,0 +1,139 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/snow"
+	"github.com/luxdefi/node/utils/constants"
+	"github.com/luxdefi/node/vms/components/lux"
+	"github.com/luxdefi/node/vms/secp256k1fx"
+
+	safemath "github.com/luxdefi/node/utils/math"
+)
+
+func TestAddPooledDelegatorTxSyntacticVerify(t *testing.T) {
+	var (
+		networkID = uint32(1337)
+		chainID   = ids.GenerateTestID()
+		assetID   = ids.GenerateTestID()
+	)
+
+	ctx := &snow.Context{
+		ChainID:   chainID,
+		NetworkID: networkID,
+	}
+
+	// newTx returns a pooled delegation of 3 staked between contributions with
+	// the given weights.
+	newTx := func(weights ...uint64) *AddPooledDelegatorTx {
+		contributions := make([]*PoolContribution, len(weights))
+		for i, weight := range weights {
+			contributions[i] = &PoolContribution{
+				Weight:       weight,
+				RewardsOwner: &secp256k1fx.OutputOwners{},
+			}
+		}
+		return &AddPooledDelegatorTx{
+			AddPermissionlessDelegatorTx: AddPermissionlessDelegatorTx{
+				BaseTx: BaseTx{
+					BaseTx: lux.BaseTx{
+						NetworkID:    networkID,
+						BlockchainID: chainID,
+					},
+				},
+				Validator: Validator{
+					Wght: 3,
+				},
+				Subnet: constants.PrimaryNetworkID,
+				StakeOuts: []*lux.TransferableOutput{
+					{
+						Asset: lux.Asset{
+							ID: assetID,
+						},
+						Out: &secp256k1fx.TransferOutput{
+							Amt: 3,
+						},
+					},
+				},
+				DelegationRewardsOwner: &secp256k1fx.OutputOwners{},
+			},
+			Contributions: contributions,
+		}
+	}
+
+	invalidOwnerTx := newTx(1, 2)
+	invalidOwnerTx.Contributions[1].RewardsOwner = &secp256k1fx.OutputOwners{
+		Threshold: 1,
+	}
+
+	tests := []struct {
+		name string
+		tx   *AddPooledDelegatorTx
+		err  error
+	}{
+		{
+			name: "nil tx",
+			tx:   nil,
+			err:  ErrNilTx,
+		},
+		{
+			name: "no contributions",
+			tx:   newTx(),
+			err:  errNoPoolContributions,
+		},
+		{
+			name: "too many contributions",
+			tx:   newTx(make([]uint64, MaxPoolContributions+1)...),
+			err:  errTooManyPoolContributions,
+		},
+		{
+			name: "zero contribution",
+			tx:   newTx(3, 0),
+			err:  errZeroPoolContribution,
+		},
+		{
+			name: "invalid contribution rewards owner",
+			tx:   invalidOwnerTx,
+			err:  secp256k1fx.ErrOutputUnspendable,
+		},
+		{
+			name: "contributions overflow",
+			tx:   newTx(math.MaxUint64, 1),
+			err:  safemath.ErrOverflow,
+		},
+		{
+			name: "contributions don't sum up to the weight",
+			tx:   newTx(1, 1),
+			err:  errPoolContributionsMismatched,
+		},
+		{
+			name: "valid",
+			tx:   newTx(1, 2),
+			err:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.tx.SyntacticVerify(ctx)
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestAddPooledDelegatorTxContributionWeights(t *testing.T) {
+	tx := &AddPooledDelegatorTx{
+		Contributions: []*PoolContribution{
+			{Weight: 5},
+			{Weight: 1},
+			{Weight: 3},
+		},
+	}
+	require.Equal(t, []uint64{5, 1, 3}, tx.ContributionWeights())
+}
@@ -23,13 +23,20 @@ import (
 	"github.com/luxdefi/node/vms/secp256k1fx"
 )
 
-// Max number of items allowed in a page
-const MaxPageSize = 1024
+const (
+	// Max number of items allowed in a page
+	MaxPageSize = 1024
+
+	// Max number of times a tx is rebuilt to pay its dynamic fee
+	maxFeeAttempts = 4
+)
 
 var (
 	_ Builder = (*builder)(nil)
 
 	ErrNoFunds = errors.New("no spendable funds were found")
+
+	errFeeNotConverged = errors.New("couldn't build a tx that pays its fee")
 )
 
 type Builder interface {
@@ -223,102 +230,134 @@ type builder struct {
 	fx  fx.Fx
 }
 
-func (b *builder) NewImportTx(
-	from ids.ID,
-	to ids.ShortID,
-	keys []*secp256k1.PrivateKey,
-	changeAddr ids.ShortID,
-) (*txs.Tx, error) {
-	kc := secp256k1fx.NewKeychain(keys...)
+// withFee calls [build] with the fee to burn, starting from [fee]. If the
+// dynamic fee of the resulting tx is larger than the fee it burns, the tx is
+// rebuilt to burn the required fee.
+func (b *builder) withFee(fee uint64, build func(fee uint64) (*txs.Tx, error)) (*txs.Tx, error) {
+	timestamp := b.state.GetTimestamp()
+	if !b.cfg.IsDynamicFeesActivated(timestamp) {
+		return build(fee)
+	}
 
-	atomicUTXOs, _, _, err := b.GetAtomicUTXOs(from, kc.Addresses(), ids.ShortEmpty, ids.Empty, MaxPageSize)
+	gasPrice, err := b.state.GetGasPrice()
 	if err != nil {
-		return nil, fmt.Errorf("problem retrieving atomic UTXOs: %w", err)
+		return nil, err
 	}
-
-	importedInputs := []*lux.TransferableInput{}
-	signers := [][]*secp256k1.PrivateKey{}
-
-	importedAmounts := make(map[ids.ID]uint64)
-	now := b.clk.Unix()
-	for _, utxo := range atomicUTXOs {
-		inputIntf, utxoSigners, err := kc.Spend(utxo.Out, now)
+	for i := 0; i < maxFeeAttempts; i++ {
+		tx, err := build(fee)
 		if err != nil {
-			continue
-		}
-		input, ok := inputIntf.(lux.TransferableIn)
-		if !ok {
-			continue
+			return nil, err
 		}
-		assetID := utxo.AssetID()
-		importedAmounts[assetID], err = math.Add64(importedAmounts[assetID], input.Amount())
+		requiredFee, err := b.cfg.GetTxFee(tx, timestamp, gasPrice)
 		if err != nil {
 			return nil, err
 		}
-		importedInputs = append(importedInputs, &lux.TransferableInput{
-			UTXOID: utxo.UTXOID,
-			Asset:  utxo.Asset,
-			In:     input,
-		})
-		signers = append(signers, utxoSigners)
-	}
-	lux.SortTransferableInputsWithSigners(importedInputs, signers)
-
-	if len(importedAmounts) == 0 {
-		return nil, ErrNoFunds // No imported UTXOs were spendable
+		if requiredFee <= fee {
+			return tx, nil
+		}
+		fee = requiredFee
 	}
+	return nil, errFeeNotConverged
+}
 
-	importedLUX := importedAmounts[b.ctx.LUXAssetID]
+func (b *builder) NewImportTx(
+	from ids.ID,
+	to ids.ShortID,
+	keys []*secp256k1.PrivateKey,
+	changeAddr ids.ShortID,
+) (*txs.Tx, error) {
+	return b.withFee(b.cfg.TxFee, func(fee uint64) (*txs.Tx, error) {
+		kc := secp256k1fx.NewKeychain(keys...)
 
-	ins := []*lux.TransferableInput{}
-	outs := []*lux.TransferableOutput{}
-	switch {
-	case importedLUX < b.cfg.TxFee: // imported amount goes toward paying tx fee
-		var baseSigners [][]*secp256k1.PrivateKey
-		ins, outs, _, baseSigners, err = b.Spend(b.state, keys, 0, b.cfg.TxFee-importedLUX, changeAddr)
+		atomicUTXOs, _, _, err := b.GetAtomicUTXOs(from, kc.Addresses(), ids.ShortEmpty, ids.Empty, MaxPageSize)
 		if err != nil {
-			return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+			return nil, fmt.Errorf("problem retrieving atomic UTXOs: %w", err)
 		}
-		signers = append(baseSigners, signers...)
-		delete(importedAmounts, b.ctx.LUXAssetID)
-	case importedLUX == b.cfg.TxFee:
-		delete(importedAmounts, b.ctx.LUXAssetID)
-	default:
-		importedAmounts[b.ctx.LUXAssetID] -= b.cfg.TxFee
-	}
 
-	for assetID, amount := range importedAmounts {
-		outs = append(outs, &lux.TransferableOutput{
-			Asset: lux.Asset{ID: assetID},
-			Out: &secp256k1fx.TransferOutput{
-				Amt: amount,
-				OutputOwners: secp256k1fx.OutputOwners{
-					Locktime:  0,
-					Threshold: 1,
-					Addrs:     []ids.ShortID{to},
+		importedInputs := []*lux.TransferableInput{}
+		signers := [][]*secp256k1.PrivateKey{}
+
+		importedAmounts := make(map[ids.ID]uint64)
+		now := b.clk.Unix()
+		for _, utxo := range atomicUTXOs {
+			inputIntf, utxoSigners, err := kc.Spend(utxo.Out, now)
+			if err != nil {
+				continue
+			}
+			input, ok := inputIntf.(lux.TransferableIn)
+			if !ok {
+				continue
+			}
+			assetID := utxo.AssetID()
+			importedAmounts[assetID], err = math.Add64(importedAmounts[assetID], input.Amount())
+			if err != nil {
+				return nil, err
+			}
+			importedInputs = append(importedInputs, &lux.TransferableInput{
+				UTXOID: utxo.UTXOID,
+				Asset:  utxo.Asset,
+				In:     input,
+			})
+			signers = append(signers, utxoSigners)
+		}
+		lux.SortTransferableInputsWithSigners(importedInputs, signers)
+
+		if len(importedAmounts) == 0 {
+			return nil, ErrNoFunds // No imported UTXOs were spendable
+		}
+
+		importedLUX := importedAmounts[b.ctx.LUXAssetID]
+
+		ins := []*lux.TransferableInput{}
+		outs := []*lux.TransferableOutput{}
+		switch {
+		case importedLUX < fee: // imported amount goes toward paying tx fee
+			var baseSigners [][]*secp256k1.PrivateKey
+			ins, outs, _, baseSigners, err = b.Spend(b.state, keys, 0, fee-importedLUX, changeAddr)
+			if err != nil {
+				return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+			}
+			signers = append(baseSigners, signers...)
+			delete(importedAmounts, b.ctx.LUXAssetID)
+		case importedLUX == fee:
+			delete(importedAmounts, b.ctx.LUXAssetID)
+		default:
+			importedAmounts[b.ctx.LUXAssetID] -= fee
+		}
+
+		for assetID, amount := range importedAmounts {
+			outs = append(outs, &lux.TransferableOutput{
+				Asset: lux.Asset{ID: assetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: amount,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Locktime:  0,
+						Threshold: 1,
+						Addrs:     []ids.ShortID{to},
+					},
 				},
-			},
-		})
-	}
+			})
+		}
 
-	lux.SortTransferableOutputs(outs, txs.Codec) // sort imported outputs
-
-	// Create the transaction
-	utx := &txs.ImportTx{
-		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
-			NetworkID:    b.ctx.NetworkID,
-			BlockchainID: b.ctx.ChainID,
-			Outs:         outs,
-			Ins:          ins,
-		}},
-		SourceChain:    from,
-		ImportedInputs: importedInputs,
-	}
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
-	if err != nil {
-		return nil, err
-	}
-	return tx, tx.SyntacticVerify(b.ctx)
+		lux.SortTransferableOutputs(outs, txs.Codec) // sort imported outputs
+
+		// Create the transaction
+		utx := &txs.ImportTx{
+			BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+				NetworkID:    b.ctx.NetworkID,
+				BlockchainID: b.ctx.ChainID,
+				Outs:         outs,
+				Ins:          ins,
+			}},
+			SourceChain:    from,
+			ImportedInputs: importedInputs,
+		}
+		tx, err := txs.NewSigned(utx, txs.Codec, signers)
+		if err != nil {
+			return nil, err
+		}
+		return tx, tx.SyntacticVerify(b.ctx)
+	})
 }
 
 // TODO: should support other assets than LUX
@@ -329,41 +368,43 @@ func (b *builder) NewExportTx(
 	keys []*secp256k1.PrivateKey,
 	changeAddr ids.ShortID,
 ) (*txs.Tx, error) {
-	toBurn, err := math.Add64(amount, b.cfg.TxFee)
-	if err != nil {
-		return nil, fmt.Errorf("amount (%d) + tx fee(%d) overflows", amount, b.cfg.TxFee)
-	}
-	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, toBurn, changeAddr)
-	if err != nil {
-		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
-	}
+	return b.withFee(b.cfg.TxFee, func(fee uint64) (*txs.Tx, error) {
+		toBurn, err := math.Add64(amount, fee)
+		if err != nil {
+			return nil, fmt.Errorf("amount (%d) + tx fee(%d) overflows", amount, fee)
+		}
+		ins, outs, _, signers, err := b.Spend(b.state, keys, 0, toBurn, changeAddr)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+		}
 
-	// Create the transaction
-	utx := &txs.ExportTx{
-		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
-			NetworkID:    b.ctx.NetworkID,
-			BlockchainID: b.ctx.ChainID,
-			Ins:          ins,
-			Outs:         outs, // Non-exported outputs
-		}},
-		DestinationChain: chainID,
-		ExportedOutputs: []*lux.TransferableOutput{{ // Exported to X-Chain
-			Asset: lux.Asset{ID: b.ctx.LUXAssetID},
-			Out: &secp256k1fx.TransferOutput{
-				Amt: amount,
-				OutputOwners: secp256k1fx.OutputOwners{
-					Locktime:  0,
-					Threshold: 1,
-					Addrs:     []ids.ShortID{to},
+		// Create the transaction
+		utx := &txs.ExportTx{
+			BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+				NetworkID:    b.ctx.NetworkID,
+				BlockchainID: b.ctx.ChainID,
+				Ins:          ins,
+				Outs:         outs, // Non-exported outputs
+			}},
+			DestinationChain: chainID,
+			ExportedOutputs: []*lux.TransferableOutput{{ // Exported to X-Chain
+				Asset: lux.Asset{ID: b.ctx.LUXAssetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: amount,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Locktime:  0,
+						Threshold: 1,
+						Addrs:     []ids.ShortID{to},
+					},
 				},
-			},
-		}},
-	}
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
-	if err != nil {
-		return nil, err
-	}
-	return tx, tx.SyntacticVerify(b.ctx)
+			}},
+		}
+		tx, err := txs.NewSigned(utx, txs.Codec, signers)
+		if err != nil {
+			return nil, err
+		}
+		return tx, tx.SyntacticVerify(b.ctx)
+	})
 }
 
 func (b *builder) NewCreateChainTx(
@@ -375,42 +416,42 @@ func (b *builder) NewCreateChainTx(
 	keys []*secp256k1.PrivateKey,
 	changeAddr ids.ShortID,
 ) (*txs.Tx, error) {
-	timestamp := b.state.GetTimestamp()
-	createBlockchainTxFee := b.cfg.GetCreateBlockchainTxFee(timestamp)
-	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, createBlockchainTxFee, changeAddr)
-	if err != nil {
-		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
-	}
+	return b.withFee(b.cfg.GetCreateBlockchainTxFee(b.state.GetTimestamp()), func(fee uint64) (*txs.Tx, error) {
+		ins, outs, _, signers, err := b.Spend(b.state, keys, 0, fee, changeAddr)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+		}
 
-	subnetAuth, subnetSigners, err := b.Authorize(b.state, subnetID, keys)
-	if err != nil {
-		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
-	}
-	signers = append(signers, subnetSigners)
-
-	// Sort the provided fxIDs
-	utils.Sort(fxIDs)
-
-	// Create the tx
-	utx := &txs.CreateChainTx{
-		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
-			NetworkID:    b.ctx.NetworkID,
-			BlockchainID: b.ctx.ChainID,
-			Ins:          ins,
-			Outs:         outs,
-		}},
-		SubnetID:    subnetID,
-		ChainName:   chainName,
-		VMID:        vmID,
-		FxIDs:       fxIDs,
-		GenesisData: genesisData,
-		SubnetAuth:  subnetAuth,
-	}
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
-	if err != nil {
-		return nil, err
-	}
-	return tx, tx.SyntacticVerify(b.ctx)
+		subnetAuth, subnetSigners, err := b.Authorize(b.state, subnetID, keys)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
+		}
+		signers = append(signers, subnetSigners)
+
+		// Sort the provided fxIDs
+		utils.Sort(fxIDs)
+
+		// Create the tx
+		utx := &txs.CreateChainTx{
+			BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+				NetworkID:    b.ctx.NetworkID,
+				BlockchainID: b.ctx.ChainID,
+				Ins:          ins,
+				Outs:         outs,
+			}},
+			SubnetID:    subnetID,
+			ChainName:   chainName,
+			VMID:        vmID,
+			FxIDs:       fxIDs,
+			GenesisData: genesisData,
+			SubnetAuth:  subnetAuth,
+		}
+		tx, err := txs.NewSigned(utx, txs.Codec, signers)
+		if err != nil {
+			return nil, err
+		}
+		return tx, tx.SyntacticVerify(b.ctx)
+	})
 }
 
 func (b *builder) NewCreateSubnetTx(
@@ -419,34 +460,34 @@ func (b *builder) NewCreateSubnetTx(
 	keys []*secp256k1.PrivateKey,
 	changeAddr ids.ShortID,
 ) (*txs.Tx, error) {
-	timestamp := b.state.GetTimestamp()
-	createSubnetTxFee := b.cfg.GetCreateSubnetTxFee(timestamp)
-	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, createSubnetTxFee, changeAddr)
-	if err != nil {
-		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
-	}
+	return b.withFee(b.cfg.GetCreateSubnetTxFee(b.state.GetTimestamp()), func(fee uint64) (*txs.Tx, error) {
+		ins, outs, _, signers, err := b.Spend(b.state, keys, 0, fee, changeAddr)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+		}
 
-	// Sort control addresses
-	utils.Sort(ownerAddrs)
-
-	// Create the tx
-	utx := &txs.CreateSubnetTx{
-		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
-			NetworkID:    b.ctx.NetworkID,
-			BlockchainID: b.ctx.ChainID,
-			Ins:          ins,
-			Outs:         outs,
-		}},
-		Owner: &secp256k1fx.OutputOwners{
-			Threshold: threshold,
-			Addrs:     ownerAddrs,
-		},
-	}
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
-	if err != nil {
-		return nil, err
-	}
-	return tx, tx.SyntacticVerify(b.ctx)
+		// Sort control addresses
+		utils.Sort(ownerAddrs)
+
+		// Create the tx
+		utx := &txs.CreateSubnetTx{
+			BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+				NetworkID:    b.ctx.NetworkID,
+				BlockchainID: b.ctx.ChainID,
+				Ins:          ins,
+				Outs:         outs,
+			}},
+			Owner: &secp256k1fx.OutputOwners{
+				Threshold: threshold,
+				Addrs:     ownerAddrs,
+			},
+		}
+		tx, err := txs.NewSigned(utx, txs.Codec, signers)
+		if err != nil {
+			return nil, err
+		}
+		return tx, tx.SyntacticVerify(b.ctx)
+	})
 }
 
 func (b *builder) NewAddValidatorTx(
@@ -459,37 +500,39 @@ func (b *builder) NewAddValidatorTx(
 	keys []*secp256k1.PrivateKey,
 	changeAddr ids.ShortID,
 ) (*txs.Tx, error) {
-	ins, unstakedOuts, stakedOuts, signers, err := b.Spend(b.state, keys, stakeAmount, b.cfg.AddPrimaryNetworkValidatorFee, changeAddr)
-	if err != nil {
-		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
-	}
-	// Create the tx
-	utx := &txs.AddValidatorTx{
-		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
-			NetworkID:    b.ctx.NetworkID,
-			BlockchainID: b.ctx.ChainID,
-			Ins:          ins,
-			Outs:         unstakedOuts,
-		}},
-		Validator: txs.Validator{
-			NodeID: nodeID,
-			Start:  startTime,
-			End:    endTime,
-			Wght:   stakeAmount,
-		},
-		StakeOuts: stakedOuts,
-		RewardsOwner: &secp256k1fx.OutputOwners{
-			Locktime:  0,
-			Threshold: 1,
-			Addrs:     []ids.ShortID{rewardAddress},
-		},
-		DelegationShares: shares,
-	}
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
-	if err != nil {
-		return nil, err
-	}
-	return tx, tx.SyntacticVerify(b.ctx)
+	return b.withFee(b.cfg.AddPrimaryNetworkValidatorFee, func(fee uint64) (*txs.Tx, error) {
+		ins, unstakedOuts, stakedOuts, signers, err := b.Spend(b.state, keys, stakeAmount, fee, changeAddr)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+		}
+		// Create the tx
+		utx := &txs.AddValidatorTx{
+			BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+				NetworkID:    b.ctx.NetworkID,
+				BlockchainID: b.ctx.ChainID,
+				Ins:          ins,
+				Outs:         unstakedOuts,
+			}},
+			Validator: txs.Validator{
+				NodeID: nodeID,
+				Start:  startTime,
+				End:    endTime,
+				Wght:   stakeAmount,
+			},
+			StakeOuts: stakedOuts,
+			RewardsOwner: &secp256k1fx.OutputOwners{
+				Locktime:  0,
+				Threshold: 1,
+				Addrs:     []ids.ShortID{rewardAddress},
+			},
+			DelegationShares: shares,
+		}
+		tx, err := txs.NewSigned(utx, txs.Codec, signers)
+		if err != nil {
+			return nil, err
+		}
+		return tx, tx.SyntacticVerify(b.ctx)
+	})
 }
 
 func (b *builder) NewAddDelegatorTx(
@@ -501,36 +544,38 @@ func (b *builder) NewAddDelegatorTx(
 	keys []*secp256k1.PrivateKey,
 	changeAddr ids.ShortID,
 ) (*txs.Tx, error) {
-	ins, unlockedOuts, lockedOuts, signers, err := b.Spend(b.state, keys, stakeAmount, b.cfg.AddPrimaryNetworkDelegatorFee, changeAddr)
-	if err != nil {
-		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
-	}
-	// Create the tx
-	utx := &txs.AddDelegatorTx{
-		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
-			NetworkID:    b.ctx.NetworkID,
-			BlockchainID: b.ctx.ChainID,
-			Ins:          ins,
-			Outs:         unlockedOuts,
-		}},
-		Validator: txs.Validator{
-			NodeID: nodeID,
-			Start:  startTime,
-			End:    endTime,
-			Wght:   stakeAmount,
-		},
-		StakeOuts: lockedOuts,
-		DelegationRewardsOwner: &secp256k1fx.OutputOwners{
-			Locktime:  0,
-			Threshold: 1,
-			Addrs:     []ids.ShortID{rewardAddress},
-		},
-	}
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
-	if err != nil {
-		return nil, err
-	}
-	return tx, tx.SyntacticVerify(b.ctx)
+	return b.withFee(b.cfg.AddPrimaryNetworkDelegatorFee, func(fee uint64) (*txs.Tx, error) {
+		ins, unlockedOuts, lockedOuts, signers, err := b.Spend(b.state, keys, stakeAmount, fee, changeAddr)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+		}
+		// Create the tx
+		utx := &txs.AddDelegatorTx{
+			BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+				NetworkID:    b.ctx.NetworkID,
+				BlockchainID: b.ctx.ChainID,
+				Ins:          ins,
+				Outs:         unlockedOuts,
+			}},
+			Validator: txs.Validator{
+				NodeID: nodeID,
+				Start:  startTime,
+				End:    endTime,
+				Wght:   stakeAmount,
+			},
+			StakeOuts: lockedOuts,
+			DelegationRewardsOwner: &secp256k1fx.OutputOwners{
+				Locktime:  0,
+				Threshold: 1,
+				Addrs:     []ids.ShortID{rewardAddress},
+			},
+		}
+		tx, err := txs.NewSigned(utx, txs.Codec, signers)
+		if err != nil {
+			return nil, err
+		}
+		return tx, tx.SyntacticVerify(b.ctx)
+	})
 }
 
 func (b *builder) NewAddSubnetValidatorTx(
@@ -542,41 +587,43 @@ func (b *builder) NewAddSubnetValidatorTx(
 	keys []*secp256k1.PrivateKey,
 	changeAddr ids.ShortID,
 ) (*txs.Tx, error) {
-	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, b.cfg.TxFee, changeAddr)
-	if err != nil {
-		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
-	}
+	return b.withFee(b.cfg.TxFee, func(fee uint64) (*txs.Tx, error) {
+		ins, outs, _, signers, err := b.Spend(b.state, keys, 0, fee, changeAddr)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+		}
 
-	subnetAuth, subnetSigners, err := b.Authorize(b.state, subnetID, keys)
-	if err != nil {
-		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
-	}
-	signers = append(signers, subnetSigners)
-
-	// Create the tx
-	utx := &txs.AddSubnetValidatorTx{
-		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
-			NetworkID:    b.ctx.NetworkID,
-			BlockchainID: b.ctx.ChainID,
-			Ins:          ins,
-			Outs:         outs,
-		}},
-		SubnetValidator: txs.SubnetValidator{
-			Validator: txs.Validator{
-				NodeID: nodeID,
-				Start:  startTime,
-				End:    endTime,
-				Wght:   weight,
+		subnetAuth, subnetSigners, err := b.Authorize(b.state, subnetID, keys)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
+		}
+		signers = append(signers, subnetSigners)
+
+		// Create the tx
+		utx := &txs.AddSubnetValidatorTx{
+			BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+				NetworkID:    b.ctx.NetworkID,
+				BlockchainID: b.ctx.ChainID,
+				Ins:          ins,
+				Outs:         outs,
+			}},
+			SubnetValidator: txs.SubnetValidator{
+				Validator: txs.Validator{
+					NodeID: nodeID,
+					Start:  startTime,
+					End:    endTime,
+					Wght:   weight,
+				},
+				Subnet: subnetID,
 			},
-			Subnet: subnetID,
-		},
-		SubnetAuth: subnetAuth,
-	}
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
-	if err != nil {
-		return nil, err
-	}
-	return tx, tx.SyntacticVerify(b.ctx)
+			SubnetAuth: subnetAuth,
+		}
+		tx, err := txs.NewSigned(utx, txs.Codec, signers)
+		if err != nil {
+			return nil, err
+		}
+		return tx, tx.SyntacticVerify(b.ctx)
+	})
 }
 
 func (b *builder) NewRemoveSubnetValidatorTx(
@@ -585,34 +632,36 @@ func (b *builder) NewRemoveSubnetValidatorTx(
 	keys []*secp256k1.PrivateKey,
 	changeAddr ids.ShortID,
 ) (*txs.Tx, error) {
-	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, b.cfg.TxFee, changeAddr)
-	if err != nil {
-		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
-	}
+	return b.withFee(b.cfg.TxFee, func(fee uint64) (*txs.Tx, error) {
+		ins, outs, _, signers, err := b.Spend(b.state, keys, 0, fee, changeAddr)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+		}
 
-	subnetAuth, subnetSigners, err := b.Authorize(b.state, subnetID, keys)
-	if err != nil {
-		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
-	}
-	signers = append(signers, subnetSigners)
-
-	// Create the tx
-	utx := &txs.RemoveSubnetValidatorTx{
-		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
-			NetworkID:    b.ctx.NetworkID,
-			BlockchainID: b.ctx.ChainID,
-			Ins:          ins,
-			Outs:         outs,
-		}},
-		Subnet:     subnetID,
-		NodeID:     nodeID,
-		SubnetAuth: subnetAuth,
-	}
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
-	if err != nil {
-		return nil, err
-	}
-	return tx, tx.SyntacticVerify(b.ctx)
+		subnetAuth, subnetSigners, err := b.Authorize(b.state, subnetID, keys)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
+		}
+		signers = append(signers, subnetSigners)
+
+		// Create the tx
+		utx := &txs.RemoveSubnetValidatorTx{
+			BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+				NetworkID:    b.ctx.NetworkID,
+				BlockchainID: b.ctx.ChainID,
+				Ins:          ins,
+				Outs:         outs,
+			}},
+			Subnet:     subnetID,
+			NodeID:     nodeID,
+			SubnetAuth: subnetAuth,
+		}
+		tx, err := txs.NewSigned(utx, txs.Codec, signers)
+		if err != nil {
+			return nil, err
+		}
+		return tx, tx.SyntacticVerify(b.ctx)
+	})
 }
 
 func (b *builder) NewAdvanceTimeTx(timestamp time.Time) (*txs.Tx, error) {
@@ -641,36 +690,38 @@ func (b *builder) NewTransferSubnetOwnershipTx(
 	keys []*secp256k1.PrivateKey,
 	changeAddr ids.ShortID,
 ) (*txs.Tx, error) {
-	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, b.cfg.TxFee, changeAddr)
-	if err != nil {
-		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
-	}
+	return b.withFee(b.cfg.TxFee, func(fee uint64) (*txs.Tx, error) {
+		ins, outs, _, signers, err := b.Spend(b.state, keys, 0, fee, changeAddr)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+		}
 
-	subnetAuth, subnetSigners, err := b.Authorize(b.state, subnetID, keys)
-	if err != nil {
-		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
-	}
-	signers = append(signers, subnetSigners)
-
-	utx := &txs.TransferSubnetOwnershipTx{
-		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
-			NetworkID:    b.ctx.NetworkID,
-			BlockchainID: b.ctx.ChainID,
-			Ins:          ins,
-			Outs:         outs,
-		}},
-		Subnet:     subnetID,
-		SubnetAuth: subnetAuth,
-		Owner: &secp256k1fx.OutputOwners{
-			Threshold: threshold,
-			Addrs:     ownerAddrs,
-		},
-	}
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
-	if err != nil {
-		return nil, err
-	}
-	return tx, tx.SyntacticVerify(b.ctx)
+		subnetAuth, subnetSigners, err := b.Authorize(b.state, subnetID, keys)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
+		}
+		signers = append(signers, subnetSigners)
+
+		utx := &txs.TransferSubnetOwnershipTx{
+			BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+				NetworkID:    b.ctx.NetworkID,
+				BlockchainID: b.ctx.ChainID,
+				Ins:          ins,
+				Outs:         outs,
+			}},
+			Subnet:     subnetID,
+			SubnetAuth: subnetAuth,
+			Owner: &secp256k1fx.OutputOwners{
+				Threshold: threshold,
+				Addrs:     ownerAddrs,
+			},
+		}
+		tx, err := txs.NewSigned(utx, txs.Codec, signers)
+		if err != nil {
+			return nil, err
+		}
+		return tx, tx.SyntacticVerify(b.ctx)
+	})
 }
 
 func (b *builder) NewBaseTx(
@@ -679,36 +730,38 @@ func (b *builder) NewBaseTx(
 	keys []*secp256k1.PrivateKey,
 	changeAddr ids.ShortID,
 ) (*txs.Tx, error) {
-	toBurn, err := math.Add64(amount, b.cfg.TxFee)
-	if err != nil {
-		return nil, fmt.Errorf("amount (%d) + tx fee(%d) overflows", amount, b.cfg.TxFee)
-	}
-	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, toBurn, changeAddr)
-	if err != nil {
-		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
-	}
+	return b.withFee(b.cfg.TxFee, func(fee uint64) (*txs.Tx, error) {
+		toBurn, err := math.Add64(amount, fee)
+		if err != nil {
+			return nil, fmt.Errorf("amount (%d) + tx fee(%d) overflows", amount, fee)
+		}
+		ins, outs, _, signers, err := b.Spend(b.state, keys, 0, toBurn, changeAddr)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+		}
 
-	outs = append(outs, &lux.TransferableOutput{
-		Asset: lux.Asset{ID: b.ctx.LUXAssetID},
-		Out: &secp256k1fx.TransferOutput{
-			Amt:          amount,
-			OutputOwners: owner,
-		},
-	})
+		outs = append(outs, &lux.TransferableOutput{
+			Asset: lux.Asset{ID: b.ctx.LUXAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          amount,
+				OutputOwners: owner,
+			},
+		})
 
-	lux.SortTransferableOutputs(outs, txs.Codec)
+		lux.SortTransferableOutputs(outs, txs.Codec)
 
-	utx := &txs.BaseTx{
-		BaseTx: lux.BaseTx{
-			NetworkID:    b.ctx.NetworkID,
-			BlockchainID: b.ctx.ChainID,
-			Ins:          ins,
-			Outs:         outs,
-		},
-	}
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
-	if err != nil {
-		return nil, err
-	}
-	return tx, tx.SyntacticVerify(b.ctx)
+		utx := &txs.BaseTx{
+			BaseTx: lux.BaseTx{
+				NetworkID:    b.ctx.NetworkID,
+				BlockchainID: b.ctx.ChainID,
+				Ins:          ins,
+				Outs:         outs,
+			},
+		}
+		tx, err := txs.NewSigned(utx, txs.Codec, signers)
+		if err != nil {
+			return nil, err
+		}
+		return tx, tx.SyntacticVerify(b.ctx)
+	})
 }
@@ -107,5 +107,10 @@ func RegisterDUnsignedTxsTypes(targetCodec linearcodec.Codec) error {
 	return utils.Err(
 		targetCodec.RegisterType(&TransferSubnetOwnershipTx{}),
 		targetCodec.RegisterType(&BaseTx{}),
+		targetCodec.RegisterType(&AddContinuousValidatorTx{}),
+		targetCodec.RegisterType(&StopContinuousValidatorTx{}),
+		targetCodec.RegisterType(&ConvertSubnetTx{}),
+		targetCodec.RegisterType(&SetSubnetValidatorWeightTx{}),
+		targetCodec.RegisterType(&AddPooledDelegatorTx{}),
 	)
 }
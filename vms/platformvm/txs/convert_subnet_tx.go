@@ -0,0 +1,72 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/snow"
+	"github.com/luxdefi/node/utils/constants"
+	"github.com/luxdefi/node/utils/units"
+	"github.com/luxdefi/node/vms/components/verify"
+)
+
+const MaxSubnetManagerAddressLen = 4 * units.KiB
+
+var (
+	_ UnsignedTx = (*ConvertSubnetTx)(nil)
+
+	ErrConvertPermissionlessSubnet = errors.New("cannot convert a permissionless subnet")
+
+	errEmptyManagerChainID   = errors.New("validator manager chain ID cannot be empty")
+	errManagerAddressTooLong = errors.New("validator manager address too long")
+)
+
+// ConvertSubnetTx hands the management of the validators of a subnet over to
+// a validator manager. Once a subnet is converted, its validators can only be
+// modified by Warp messages sent by [Address] on [ChainID], which are issued
+// with a SetSubnetValidatorWeightTx.
+type ConvertSubnetTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// ID of the subnet this tx is modifying
+	Subnet ids.ID `serialize:"true" json:"subnetID"`
+	// ID of the chain the validator manager is deployed on
+	ChainID ids.ID `serialize:"true" json:"chainID"`
+	// Address of the validator manager on [ChainID]
+	Address []byte `serialize:"true" json:"address"`
+	// Proves that the issuer has the right to convert the subnet.
+	SubnetAuth verify.Verifiable `serialize:"true" json:"subnetAuthorization"`
+}
+
+func (tx *ConvertSubnetTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified:
+		// already passed syntactic verification
+		return nil
+	case tx.Subnet == constants.PrimaryNetworkID:
+		return ErrConvertPermissionlessSubnet
+	case tx.ChainID == ids.Empty:
+		return errEmptyManagerChainID
+	case len(tx.Address) > MaxSubnetManagerAddressLen:
+		return errManagerAddressTooLong
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := tx.SubnetAuth.Verify(); err != nil {
+		return err
+	}
+
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *ConvertSubnetTx) Visit(visitor Visitor) error {
+	return visitor.ConvertSubnetTx(tx)
+}
@@ -14,9 +14,11 @@ import (
 	"github.com/luxdefi/node/utils/math"
 	"github.com/luxdefi/node/vms/components/lux"
 	"github.com/luxdefi/node/vms/components/verify"
+	"github.com/luxdefi/node/vms/platformvm/fx"
 	"github.com/luxdefi/node/vms/platformvm/reward"
 	"github.com/luxdefi/node/vms/platformvm/state"
 	"github.com/luxdefi/node/vms/platformvm/txs"
+	"github.com/luxdefi/node/vms/secp256k1fx"
 )
 
 const (
@@ -105,6 +107,26 @@ func (*ProposalTxExecutor) BaseTx(*txs.BaseTx) error {
 	return ErrWrongTxType
 }
 
+func (*ProposalTxExecutor) AddContinuousValidatorTx(*txs.AddContinuousValidatorTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) StopContinuousValidatorTx(*txs.StopContinuousValidatorTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) ConvertSubnetTx(*txs.ConvertSubnetTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) SetSubnetValidatorWeightTx(*txs.SetSubnetValidatorWeightTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) AddPooledDelegatorTx(*txs.AddPooledDelegatorTx) error {
+	return ErrWrongTxType
+}
+
 func (e *ProposalTxExecutor) AddValidatorTx(tx *txs.AddValidatorTx) error {
 	// AddValidatorTx is a proposal transaction until the Banff fork
 	// activation. Following the activation, AddValidatorTxs must be issued into
@@ -363,16 +385,54 @@ func (e *ProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx) error
 		return err
 	}
 
-	stakerTx, _, err := e.OnCommitState.GetTx(stakerToReward.TxID)
+	stakerTx, _, err := e.OnCommitState.GetTx(stakerToReward.StakerTxID())
 	if err != nil {
 		return fmt.Errorf("failed to get next removed staker tx: %w", err)
 	}
 
+	// renewContinuousValidator is true if the staking period of a continuous
+	// validator must be renewed after [stakerToReward] is removed.
+	renewContinuousValidator := false
+
 	// Invariant: A [txs.DelegatorTx] does not also implement the
 	//            [txs.ValidatorTx] interface.
 	switch uStakerTx := stakerTx.Unsigned.(type) {
+	case *txs.AddContinuousValidatorTx:
+		stopped, err := e.OnCommitState.IsContinuousValidatorStopped(stakerToReward.ContinuousTxID)
+		if err != nil {
+			return err
+		}
+		if stopped {
+			onCommitOffset, onAbortOffset, err := e.rewardValidatorTx(uStakerTx, stakerToReward)
+			if err != nil {
+				return err
+			}
+			if err := e.refundCompoundedStake(e.OnCommitState, uStakerTx, stakerToReward, onCommitOffset); err != nil {
+				return err
+			}
+			if err := e.refundCompoundedStake(e.OnAbortState, uStakerTx, stakerToReward, onAbortOffset); err != nil {
+				return err
+			}
+		} else {
+			// The staking period is only renewed if the validator is
+			// rewarded. Otherwise, the validator is removed as if it had
+			// been stopped.
+			onAbortOffset, err := e.rewardContinuousValidatorTx(uStakerTx, stakerToReward)
+			if err != nil {
+				return err
+			}
+			refundStake(e.OnAbortState, uStakerTx, stakerToReward)
+			if err := e.refundCompoundedStake(e.OnAbortState, uStakerTx, stakerToReward, onAbortOffset); err != nil {
+				return err
+			}
+			renewContinuousValidator = true
+		}
+
+		// Handle staker lifecycle.
+		e.OnCommitState.DeleteCurrentValidator(stakerToReward)
+		e.OnAbortState.DeleteCurrentValidator(stakerToReward)
 	case txs.ValidatorTx:
-		if err := e.rewardValidatorTx(uStakerTx, stakerToReward); err != nil {
+		if _, _, err := e.rewardValidatorTx(uStakerTx, stakerToReward); err != nil {
 			return err
 		}
 
@@ -406,12 +466,26 @@ func (e *ProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx) error
 	}
 	e.OnAbortState.SetCurrentSupply(stakerToReward.SubnetID, newSupply)
 
+	if renewContinuousValidator {
+		uStakerTx := stakerTx.Unsigned.(*txs.AddContinuousValidatorTx)
+		commitWeight, err := e.compoundedWeight(uStakerTx, stakerToReward)
+		if err != nil {
+			return err
+		}
+		if err := e.renewContinuousValidator(e.OnCommitState, uStakerTx, stakerToReward, commitWeight); err != nil {
+			return err
+		}
+	}
+
 	// handle option preference
 	e.PrefersCommit, err = e.shouldBeRewarded(stakerToReward, primaryNetworkValidator)
 	return err
 }
 
-func (e *ProposalTxExecutor) rewardValidatorTx(uValidatorTx txs.ValidatorTx, validator *state.Staker) error {
+// rewardValidatorTx refunds the stake of [validator] and pays out its rewards.
+// It returns the number of reward UTXOs that were created after the stake on
+// commit and on abort.
+func (e *ProposalTxExecutor) rewardValidatorTx(uValidatorTx txs.ValidatorTx, validator *state.Staker) (int, int, error) {
 	var (
 		txID    = validator.TxID
 		stake   = uValidatorTx.Stake()
@@ -443,11 +517,11 @@ func (e *ProposalTxExecutor) rewardValidatorTx(uValidatorTx txs.ValidatorTx, val
 		validationRewardsOwner := uValidatorTx.ValidationRewardsOwner()
 		outIntf, err := e.Fx.CreateOutput(reward, validationRewardsOwner)
 		if err != nil {
-			return fmt.Errorf("failed to create output: %w", err)
+			return 0, 0, fmt.Errorf("failed to create output: %w", err)
 		}
 		out, ok := outIntf.(verify.State)
 		if !ok {
-			return ErrInvalidState
+			return 0, 0, ErrInvalidState
 		}
 
 		utxo := &lux.UTXO{
@@ -470,21 +544,30 @@ func (e *ProposalTxExecutor) rewardValidatorTx(uValidatorTx txs.ValidatorTx, val
 		validator.NodeID,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to fetch accrued delegatee rewards: %w", err)
+		return 0, 0, fmt.Errorf("failed to fetch accrued delegatee rewards: %w", err)
 	}
 
+	e.addRewardRecords(
+		validator,
+		false,
+		validator.PotentialReward,
+		delegateeReward,
+		uValidatorTx.ValidationRewardsOwner(),
+		uValidatorTx.DelegationRewardsOwner(),
+	)
+
 	if delegateeReward == 0 {
-		return nil
+		return utxosOffset, 0, nil
 	}
 
 	delegationRewardsOwner := uValidatorTx.DelegationRewardsOwner()
 	outIntf, err := e.Fx.CreateOutput(delegateeReward, delegationRewardsOwner)
 	if err != nil {
-		return fmt.Errorf("failed to create output: %w", err)
+		return 0, 0, fmt.Errorf("failed to create output: %w", err)
 	}
 	out, ok := outIntf.(verify.State)
 	if !ok {
-		return ErrInvalidState
+		return 0, 0, ErrInvalidState
 	}
 
 	onCommitUtxo := &lux.UTXO{
@@ -510,7 +593,7 @@ func (e *ProposalTxExecutor) rewardValidatorTx(uValidatorTx txs.ValidatorTx, val
 	}
 	e.OnAbortState.AddUTXO(onAbortUtxo)
 	e.OnAbortState.AddRewardUTXO(txID, onAbortUtxo)
-	return nil
+	return utxosOffset + 1, 1, nil
 }
 
 func (e *ProposalTxExecutor) rewardDelegatorTx(uDelegatorTx txs.DelegatorTx, delegator *state.Staker) error {
@@ -544,7 +627,7 @@ func (e *ProposalTxExecutor) rewardDelegatorTx(uDelegatorTx txs.DelegatorTx, del
 		return fmt.Errorf("failed to get whether %s is a validator: %w", delegator.NodeID, err)
 	}
 
-	vdrTxIntf, _, err := e.OnCommitState.GetTx(validator.TxID)
+	vdrTxIntf, _, err := e.OnCommitState.GetTx(validator.StakerTxID())
 	if err != nil {
 		return fmt.Errorf("failed to get whether %s is a validator: %w", delegator.NodeID, err)
 	}
@@ -560,14 +643,29 @@ func (e *ProposalTxExecutor) rewardDelegatorTx(uDelegatorTx txs.DelegatorTx, del
 
 	// Calculate split of reward between delegator/delegatee
 	delegateeReward, delegatorReward := reward.Split(delegator.PotentialReward, vdrTx.Shares())
+	rewardsOwners := []fx.Owner{uDelegatorTx.RewardsOwner()}
+	delegatorRewards := []uint64{delegatorReward}
+	if e.Config.IsPooledDelegationActivated(delegator.StartTime) {
+		rewardsOwners, delegatorRewards = splitDelegatorReward(uDelegatorTx, delegatorReward)
+	}
+
+	e.addRewardRecords(
+		delegator,
+		true,
+		delegatorReward,
+		delegateeReward,
+		rewardsOwners...,
+	)
 
 	utxosOffset := 0
 
 	// Reward the delegator here
-	reward := delegatorReward
-	if reward > 0 {
-		rewardsOwner := uDelegatorTx.RewardsOwner()
-		outIntf, err := e.Fx.CreateOutput(reward, rewardsOwner)
+	for i, delegatorReward := range delegatorRewards {
+		if delegatorReward == 0 {
+			continue
+		}
+
+		outIntf, err := e.Fx.CreateOutput(delegatorReward, rewardsOwners[i])
 		if err != nil {
 			return fmt.Errorf("failed to create output: %w", err)
 		}
@@ -578,7 +676,7 @@ func (e *ProposalTxExecutor) rewardDelegatorTx(uDelegatorTx txs.DelegatorTx, del
 		utxo := &lux.UTXO{
 			UTXOID: lux.UTXOID{
 				TxID:        txID,
-				OutputIndex: uint32(len(outputs) + len(stake)),
+				OutputIndex: uint32(len(outputs) + len(stake) + utxosOffset),
 			},
 			Asset: stakeAsset,
 			Out:   out,
@@ -646,6 +744,289 @@ func (e *ProposalTxExecutor) rewardDelegatorTx(uDelegatorTx txs.DelegatorTx, del
 	return nil
 }
 
+// splitDelegatorReward returns the owners of the reward of [uDelegatorTx] and
+// the part of [delegatorReward] each of them receives. The reward of a pooled
+// delegator is split between its contributions pro rata to their weights, and
+// the remainder is sent to the rewards owner of the delegator.
+func splitDelegatorReward(uDelegatorTx txs.DelegatorTx, delegatorReward uint64) ([]fx.Owner, []uint64) {
+	pooledTx, ok := uDelegatorTx.(*txs.AddPooledDelegatorTx)
+	if !ok {
+		return []fx.Owner{uDelegatorTx.RewardsOwner()}, []uint64{delegatorReward}
+	}
+
+	rewards, remainder := reward.SplitProRata(delegatorReward, pooledTx.ContributionWeights())
+	rewardsOwners := make([]fx.Owner, len(pooledTx.Contributions), len(pooledTx.Contributions)+1)
+	for i, contribution := range pooledTx.Contributions {
+		rewardsOwners[i] = contribution.RewardsOwner
+	}
+	return append(rewardsOwners, pooledTx.RewardsOwner()), append(rewards, remainder)
+}
+
+// rewardContinuousValidatorTx pays out the rewards of the staking period of
+// the continuous validator [validator] that is about to be renewed. On commit,
+// the stake remains locked and, if the validator compounds its rewards, only
+// the part of the reward that would exceed the maximum validator stake is paid
+// out. On abort, only the accrued delegatee rewards are paid out. It returns
+// the number of reward UTXOs that were created after the stake on abort.
+func (e *ProposalTxExecutor) rewardContinuousValidatorTx(uValidatorTx *txs.AddContinuousValidatorTx, validator *state.Staker) (int, error) {
+	var (
+		txID    = validator.TxID
+		stake   = uValidatorTx.Stake()
+		outputs = uValidatorTx.Outputs()
+		// Invariant: The staked asset must be equal to the reward asset.
+		stakeAsset = stake[0].Asset
+	)
+
+	utxosOffset := 0
+
+	// Provide the uncompounded reward here
+	compoundedWeight, err := e.compoundedWeight(uValidatorTx, validator)
+	if err != nil {
+		return 0, err
+	}
+	reward := validator.PotentialReward - (compoundedWeight - validator.Weight)
+	if reward > 0 {
+		validationRewardsOwner := uValidatorTx.ValidationRewardsOwner()
+		outIntf, err := e.Fx.CreateOutput(reward, validationRewardsOwner)
+		if err != nil {
+			return 0, fmt.Errorf("failed to create output: %w", err)
+		}
+		out, ok := outIntf.(verify.State)
+		if !ok {
+			return 0, ErrInvalidState
+		}
+
+		utxo := &lux.UTXO{
+			UTXOID: lux.UTXOID{
+				TxID:        txID,
+				OutputIndex: uint32(len(outputs) + len(stake)),
+			},
+			Asset: stakeAsset,
+			Out:   out,
+		}
+		e.OnCommitState.AddUTXO(utxo)
+		e.OnCommitState.AddRewardUTXO(txID, utxo)
+
+		utxosOffset++
+	}
+
+	// Provide the accrued delegatee rewards from successful delegations here.
+	delegateeReward, err := e.OnCommitState.GetDelegateeReward(
+		validator.SubnetID,
+		validator.NodeID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch accrued delegatee rewards: %w", err)
+	}
+
+	e.addRewardRecords(
+		validator,
+		false,
+		validator.PotentialReward,
+		delegateeReward,
+		uValidatorTx.ValidationRewardsOwner(),
+		uValidatorTx.DelegationRewardsOwner(),
+	)
+
+	if delegateeReward == 0 {
+		return 0, nil
+	}
+
+	// The accrued delegatee rewards were paid out, so the renewed staking
+	// period starts without any.
+	if err := e.OnCommitState.SetDelegateeReward(validator.SubnetID, validator.NodeID, 0); err != nil {
+		return 0, fmt.Errorf("failed to reset delegatee reward: %w", err)
+	}
+
+	delegationRewardsOwner := uValidatorTx.DelegationRewardsOwner()
+	outIntf, err := e.Fx.CreateOutput(delegateeReward, delegationRewardsOwner)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create output: %w", err)
+	}
+	out, ok := outIntf.(verify.State)
+	if !ok {
+		return 0, ErrInvalidState
+	}
+
+	onCommitUtxo := &lux.UTXO{
+		UTXOID: lux.UTXOID{
+			TxID:        txID,
+			OutputIndex: uint32(len(outputs) + len(stake) + utxosOffset),
+		},
+		Asset: stakeAsset,
+		Out:   out,
+	}
+	e.OnCommitState.AddUTXO(onCommitUtxo)
+	e.OnCommitState.AddRewardUTXO(txID, onCommitUtxo)
+
+	// Note: There is no [offset] if the RewardValidatorTx is
+	// aborted, because the validator reward is not awarded.
+	onAbortUtxo := &lux.UTXO{
+		UTXOID: lux.UTXOID{
+			TxID:        txID,
+			OutputIndex: uint32(len(outputs) + len(stake)),
+		},
+		Asset: stakeAsset,
+		Out:   out,
+	}
+	e.OnAbortState.AddUTXO(onAbortUtxo)
+	e.OnAbortState.AddRewardUTXO(txID, onAbortUtxo)
+	return 1, nil
+}
+
+// compoundedWeight returns the weight of the continuous validator [validator]
+// for its next staking period if it is rewarded.
+func (e *ProposalTxExecutor) compoundedWeight(uValidatorTx *txs.AddContinuousValidatorTx, validator *state.Staker) (uint64, error) {
+	if !uValidatorTx.AutoCompound || validator.Weight >= e.Config.MaxValidatorStake {
+		return validator.Weight, nil
+	}
+	weight, err := math.Add64(validator.Weight, validator.PotentialReward)
+	if err != nil {
+		return 0, err
+	}
+	return math.Min(weight, e.Config.MaxValidatorStake), nil
+}
+
+// refundStake returns the stake of the tx that added the continuous validator
+// [validator] in [chainState].
+func refundStake(chainState state.Diff, uValidatorTx *txs.AddContinuousValidatorTx, validator *state.Staker) {
+	outputs := uValidatorTx.Outputs()
+	for i, out := range uValidatorTx.Stake() {
+		utxo := &lux.UTXO{
+			UTXOID: lux.UTXOID{
+				TxID:        validator.TxID,
+				OutputIndex: uint32(len(outputs) + i),
+			},
+			Asset: out.Asset,
+			Out:   out.Output(),
+		}
+		chainState.AddUTXO(utxo)
+	}
+}
+
+// refundCompoundedStake returns the rewards that the removed continuous
+// validator [validator] compounded into its stake in prior staking periods in
+// [chainState]. The refund follows the stake and the [utxosOffset] reward
+// UTXOs that were created in [chainState].
+func (e *ProposalTxExecutor) refundCompoundedStake(
+	chainState state.Diff,
+	uValidatorTx *txs.AddContinuousValidatorTx,
+	validator *state.Staker,
+	utxosOffset int,
+) error {
+	compounded := validator.Weight - uValidatorTx.Weight()
+	if compounded == 0 {
+		return nil
+	}
+
+	var (
+		txID    = validator.TxID
+		stake   = uValidatorTx.Stake()
+		outputs = uValidatorTx.Outputs()
+		// Invariant: The staked asset must be equal to the reward asset.
+		stakeAsset = stake[0].Asset
+	)
+
+	outIntf, err := e.Fx.CreateOutput(compounded, uValidatorTx.ValidationRewardsOwner())
+	if err != nil {
+		return fmt.Errorf("failed to create output: %w", err)
+	}
+	out, ok := outIntf.(verify.State)
+	if !ok {
+		return ErrInvalidState
+	}
+
+	utxo := &lux.UTXO{
+		UTXOID: lux.UTXOID{
+			TxID:        txID,
+			OutputIndex: uint32(len(outputs) + len(stake) + utxosOffset),
+		},
+		Asset: stakeAsset,
+		Out:   out,
+	}
+	chainState.AddUTXO(utxo)
+	return nil
+}
+
+// renewContinuousValidator adds the next staking period of the continuous
+// validator [validator], with [weight], to [chainState].
+func (e *ProposalTxExecutor) renewContinuousValidator(
+	chainState state.Diff,
+	uValidatorTx *txs.AddContinuousValidatorTx,
+	validator *state.Staker,
+	weight uint64,
+) error {
+	supply, err := chainState.GetCurrentSupply(validator.SubnetID)
+	if err != nil {
+		return err
+	}
+
+	rewards, err := GetRewardsCalculator(e.Backend, chainState, validator.SubnetID)
+	if err != nil {
+		return err
+	}
+
+	period := uValidatorTx.Period()
+	potentialReward := rewards.Calculate(period, weight, supply)
+
+	renewed := *validator
+	renewed.TxID = state.RenewedStakerTxID(validator.ContinuousTxID, validator.EndTime)
+	renewed.Weight = weight
+	renewed.StartTime = validator.EndTime
+	renewed.EndTime = validator.EndTime.Add(period)
+	renewed.NextTime = renewed.EndTime
+	renewed.PotentialReward = potentialReward
+
+	// Invariant: [rewards.Calculate] can never return a [potentialReward]
+	//            such that [supply + potentialReward > maximumSupply].
+	chainState.SetCurrentSupply(validator.SubnetID, supply+potentialReward)
+	chainState.PutCurrentValidator(&renewed)
+	return nil
+}
+
+// addRewardRecords records the outcome of removing [staker] on commit and on
+// abort. [reward] is only paid on commit. The [delegationFee] of a delegator is
+// only credited on commit, while the accrued delegation fees of a validator are
+// paid in both cases.
+func (e *ProposalTxExecutor) addRewardRecords(
+	staker *state.Staker,
+	isDelegator bool,
+	reward uint64,
+	delegationFee uint64,
+	rewardsOwners ...fx.Owner,
+) {
+	var rewardAddrs []ids.ShortID
+	for _, owner := range rewardsOwners {
+		if owners, ok := owner.(*secp256k1fx.OutputOwners); ok {
+			rewardAddrs = append(rewardAddrs, owners.Addrs...)
+		}
+	}
+
+	onCommitRecord := &state.RewardRecord{
+		TxID:          staker.TxID,
+		NodeID:        staker.NodeID,
+		SubnetID:      staker.SubnetID,
+		Delegator:     isDelegator,
+		Weight:        staker.Weight,
+		StartTime:     uint64(staker.StartTime.Unix()),
+		EndTime:       uint64(staker.EndTime.Unix()),
+		Rewarded:      true,
+		Reward:        reward,
+		DelegationFee: delegationFee,
+		RewardAddrs:   rewardAddrs,
+	}
+	e.OnCommitState.AddRewardRecord(onCommitRecord)
+
+	onAbortRecord := *onCommitRecord
+	onAbortRecord.Rewarded = false
+	onAbortRecord.Reward = 0
+	onAbortRecord.Forfeited = staker.PotentialReward
+	if isDelegator {
+		onAbortRecord.DelegationFee = 0
+	}
+	e.OnAbortState.AddRewardRecord(&onAbortRecord)
+}
+
 func (e *ProposalTxExecutor) shouldBeRewarded(stakerToReward, primaryNetworkValidator *state.Staker) (bool, error) {
 	expectedUptimePercentage := e.Config.UptimePercentage
 	if stakerToReward.SubnetID != constants.PrimaryNetworkID {
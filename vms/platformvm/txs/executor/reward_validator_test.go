@@ -12,11 +12,14 @@ import (
 	"github.com/luxdefi/node/database"
 	"github.com/luxdefi/node/ids"
 	"github.com/luxdefi/node/utils/constants"
+	"github.com/luxdefi/node/utils/crypto/bls"
 	"github.com/luxdefi/node/utils/crypto/secp256k1"
 	"github.com/luxdefi/node/utils/math"
 	"github.com/luxdefi/node/utils/set"
+	"github.com/luxdefi/node/utils/timer/mockable"
 	"github.com/luxdefi/node/vms/components/lux"
 	"github.com/luxdefi/node/vms/platformvm/reward"
+	"github.com/luxdefi/node/vms/platformvm/signer"
 	"github.com/luxdefi/node/vms/platformvm/state"
 	"github.com/luxdefi/node/vms/platformvm/status"
 	"github.com/luxdefi/node/vms/platformvm/txs"
@@ -124,6 +127,15 @@ func TestRewardValidatorTxExecuteOnCommit(t *testing.T) {
 	onCommitBalance, err := lux.GetBalance(env.state, stakeOwners)
 	require.NoError(err)
 	require.Equal(oldBalance+stakerToRemove.Weight+27697, onCommitBalance)
+
+	records, err := env.state.GetRewardRecords(stakerToRemove.NodeID)
+	require.NoError(err)
+	require.Len(records, 1)
+	require.Equal(stakerToRemove.TxID, records[0].TxID)
+	require.False(records[0].Delegator)
+	require.True(records[0].Rewarded)
+	require.Equal(uint64(27697), records[0].Reward)
+	require.Zero(records[0].Forfeited)
 }
 
 func TestRewardValidatorTxExecuteOnAbort(t *testing.T) {
@@ -221,6 +233,13 @@ func TestRewardValidatorTxExecuteOnAbort(t *testing.T) {
 	onAbortBalance, err := lux.GetBalance(env.state, stakeOwners)
 	require.NoError(err)
 	require.Equal(oldBalance+stakerToRemove.Weight, onAbortBalance)
+
+	records, err := env.state.GetRewardRecords(stakerToRemove.NodeID)
+	require.NoError(err)
+	require.Len(records, 1)
+	require.False(records[0].Rewarded)
+	require.Zero(records[0].Reward)
+	require.Equal(stakerToRemove.PotentialReward, records[0].Forfeited)
 }
 
 func TestRewardDelegatorTxExecuteOnCommitPreDelegateeDeferral(t *testing.T) {
@@ -341,6 +360,14 @@ func TestRewardDelegatorTxExecuteOnCommitPreDelegateeDeferral(t *testing.T) {
 
 	stake = env.config.Validators.GetWeight(constants.PrimaryNetworkID, vdrNodeID)
 	require.Equal(env.config.MinValidatorStake, stake)
+
+	records, err := env.state.GetRewardRecordsByAddress(delRewardAddress)
+	require.NoError(err)
+	require.Len(records, 1)
+	require.Equal(delTx.ID(), records[0].TxID)
+	require.True(records[0].Delegator)
+	require.Equal(delReward, records[0].Reward)
+	require.Equal(vdrReward, records[0].DelegationFee)
 }
 
 func TestRewardDelegatorTxExecuteOnCommitPostDelegateeDeferral(t *testing.T) {
@@ -716,6 +743,196 @@ func TestRewardDelegatorTxAndValidatorTxExecuteOnCommitPostDelegateeDeferral(t *
 	require.Equal(delRewardAmt, delReward+delegateeReward, "expected total reward to be %d but is %d", delRewardAmt, delReward+vdrReward)
 }
 
+func TestRewardPooledDelegatorTxExecuteOnCommitPreDelegateeDeferral(t *testing.T) {
+	require := require.New(t)
+	env := newEnvironment(t, false /*=postBanff*/, false /*=postCortina*/)
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+	dummyHeight := uint64(1)
+
+	var (
+		vdrRewardAddress = ids.GenerateTestShortID()
+		vdrRewardOwner   = &secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{vdrRewardAddress},
+		}
+		delRewardOwner = &secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+		}
+		contributions = []*txs.PoolContribution{
+			{
+				Weight: env.config.MinDelegatorStake / 3,
+				RewardsOwner: &secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+				},
+			},
+			{
+				Weight: env.config.MinDelegatorStake - env.config.MinDelegatorStake/3,
+				RewardsOwner: &secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+				},
+			},
+		}
+	)
+
+	vdrStartTime := uint64(defaultValidateStartTime.Unix()) + 1
+	vdrEndTime := uint64(defaultValidateStartTime.Add(2 * defaultMinStakingDuration).Unix())
+	vdrNodeID := ids.GenerateTestNodeID()
+
+	vdrTx, err := env.txBuilder.NewAddValidatorTx(
+		env.config.MinValidatorStake, // stakeAmt
+		vdrStartTime,
+		vdrEndTime,
+		vdrNodeID,        // node ID
+		vdrRewardAddress, // reward address
+		reward.PercentDenominator/4,
+		[]*secp256k1.PrivateKey{preFundedKeys[0]},
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	ins, unstakedOuts, stakedOuts, signers, err := env.utxosHandler.Spend(
+		env.state,
+		[]*secp256k1.PrivateKey{preFundedKeys[0]},
+		env.config.MinDelegatorStake,
+		env.config.AddPrimaryNetworkDelegatorFee,
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	delTx, err := txs.NewSigned(&txs.AddPooledDelegatorTx{
+		AddPermissionlessDelegatorTx: txs.AddPermissionlessDelegatorTx{
+			BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+				NetworkID:    env.ctx.NetworkID,
+				BlockchainID: env.ctx.ChainID,
+				Ins:          ins,
+				Outs:         unstakedOuts,
+			}},
+			Validator: txs.Validator{
+				NodeID: vdrNodeID,
+				Start:  vdrStartTime,
+				End:    vdrEndTime,
+				Wght:   env.config.MinDelegatorStake,
+			},
+			Subnet:                 constants.PrimaryNetworkID,
+			StakeOuts:              stakedOuts,
+			DelegationRewardsOwner: delRewardOwner,
+		},
+		Contributions: contributions,
+	}, txs.Codec, signers)
+	require.NoError(err)
+	delTxID := delTx.ID()
+
+	vdrStaker, err := state.NewCurrentStaker(
+		vdrTx.ID(),
+		vdrTx.Unsigned.(*txs.AddValidatorTx),
+		0,
+	)
+	require.NoError(err)
+
+	potentialReward := uint64(1000000)
+	delStaker, err := state.NewCurrentStaker(
+		delTxID,
+		delTx.Unsigned.(*txs.AddPooledDelegatorTx),
+		potentialReward,
+	)
+	require.NoError(err)
+
+	env.state.PutCurrentValidator(vdrStaker)
+	env.state.AddTx(vdrTx, status.Committed)
+	env.state.PutCurrentDelegator(delStaker)
+	env.state.AddTx(delTx, status.Committed)
+	env.state.SetTimestamp(time.Unix(int64(vdrEndTime), 0))
+	env.state.SetHeight(dummyHeight)
+	require.NoError(env.state.Commit())
+
+	tx, err := env.txBuilder.NewRewardValidatorTx(delTxID)
+	require.NoError(err)
+
+	onCommitState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	onAbortState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	txExecutor := ProposalTxExecutor{
+		OnCommitState: onCommitState,
+		OnAbortState:  onAbortState,
+		Backend:       &env.backend,
+		Tx:            tx,
+	}
+	require.NoError(tx.Unsigned.Visit(&txExecutor))
+
+	// The delegator reward is split pro rata to the contributions, and the
+	// part that can't be split evenly is sent to the delegator rewards owner.
+	delegateeReward, delegatorReward := reward.Split(potentialReward, reward.PercentDenominator/4)
+	contributionRewards, remainder := reward.SplitProRata(
+		delegatorReward,
+		[]uint64{contributions[0].Weight, contributions[1].Weight},
+	)
+	require.NotZero(remainder)
+
+	// The reward UTXOs follow the stake in order: one per contribution, then
+	// the remainder and, as the validator started before Cortina, the
+	// delegatee reward.
+	expectedRewards := []struct {
+		amount uint64
+		owner  *secp256k1fx.OutputOwners
+	}{
+		{
+			amount: contributionRewards[0],
+			owner:  contributions[0].RewardsOwner.(*secp256k1fx.OutputOwners),
+		},
+		{
+			amount: contributionRewards[1],
+			owner:  contributions[1].RewardsOwner.(*secp256k1fx.OutputOwners),
+		},
+		{
+			amount: remainder,
+			owner:  delRewardOwner,
+		},
+		{
+			amount: delegateeReward,
+			owner:  vdrRewardOwner,
+		},
+	}
+
+	utxosOffset := len(unstakedOuts) + len(stakedOuts)
+	for i, expected := range expectedRewards {
+		utxoID := lux.UTXOID{
+			TxID:        delTxID,
+			OutputIndex: uint32(utxosOffset + i),
+		}
+		utxo, err := onCommitState.GetUTXO(utxoID.InputID())
+		require.NoError(err)
+		out := utxo.Out.(*secp256k1fx.TransferOutput)
+		require.Equal(expected.amount, out.Amount())
+		require.Equal(expected.owner.Addrs, out.Addrs)
+
+		_, err = onAbortState.GetUTXO(utxoID.InputID())
+		require.ErrorIs(err, database.ErrNotFound)
+	}
+
+	utxoID := lux.UTXOID{
+		TxID:        delTxID,
+		OutputIndex: uint32(utxosOffset + len(expectedRewards)),
+	}
+	_, err = onCommitState.GetUTXO(utxoID.InputID())
+	require.ErrorIs(err, database.ErrNotFound)
+
+	require.NoError(onCommitState.Apply(env.state))
+	env.state.SetHeight(dummyHeight)
+	require.NoError(env.state.Commit())
+
+	rewardUTXOs, err := env.state.GetRewardUTXOs(delTxID)
+	require.NoError(err)
+	require.Len(rewardUTXOs, len(expectedRewards))
+}
+
 func TestRewardDelegatorTxExecuteOnAbort(t *testing.T) {
 	require := require.New(t)
 	env := newEnvironment(t, false /*=postBanff*/, false /*=postCortina*/)
@@ -830,3 +1047,268 @@ func TestRewardDelegatorTxExecuteOnAbort(t *testing.T) {
 	require.NoError(err)
 	require.Equal(initialSupply-expectedReward, newSupply, "should have removed un-rewarded tokens from the potential supply")
 }
+
+func TestRewardContinuousValidatorTx(t *testing.T) {
+	require := require.New(t)
+	env := newEnvironment(t, true /*=postBanff*/, true /*=postCortina*/)
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	var (
+		rewardKey     = preFundedKeys[1]
+		rewardAddress = rewardKey.PublicKey().Address()
+		rewardOwner   = &secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{rewardAddress},
+		}
+		nodeID    = ids.GenerateTestNodeID()
+		startTime = defaultValidateStartTime.Add(time.Second)
+		period    = 2 * defaultMinStakingDuration
+		endTime   = startTime.Add(period)
+	)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	ins, unstakedOuts, stakedOuts, signers, err := env.utxosHandler.Spend(
+		env.state,
+		[]*secp256k1.PrivateKey{preFundedKeys[0]},
+		env.config.MinValidatorStake,
+		env.config.AddPrimaryNetworkValidatorFee,
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	addTx, err := txs.NewSigned(&txs.AddContinuousValidatorTx{
+		AddPermissionlessValidatorTx: txs.AddPermissionlessValidatorTx{
+			BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+				NetworkID:    env.ctx.NetworkID,
+				BlockchainID: env.ctx.ChainID,
+				Ins:          ins,
+				Outs:         unstakedOuts,
+			}},
+			Validator: txs.Validator{
+				NodeID: nodeID,
+				Start:  uint64(startTime.Unix()),
+				End:    uint64(endTime.Unix()),
+				Wght:   env.config.MinValidatorStake,
+			},
+			Subnet:                constants.PrimaryNetworkID,
+			Signer:                signer.NewProofOfPossession(sk),
+			StakeOuts:             stakedOuts,
+			ValidatorRewardsOwner: rewardOwner,
+			DelegatorRewardsOwner: rewardOwner,
+			DelegationShares:      reward.PercentDenominator,
+		},
+		AutoCompound: true,
+	}, txs.Codec, signers)
+	require.NoError(err)
+	addTxID := addTx.ID()
+
+	potentialReward := uint64(2000000)
+	staker, err := state.NewCurrentStaker(
+		addTxID,
+		addTx.Unsigned.(*txs.AddContinuousValidatorTx),
+		potentialReward,
+	)
+	require.NoError(err)
+	require.Equal(addTxID, staker.ContinuousTxID)
+
+	env.state.PutCurrentValidator(staker)
+	env.state.AddTx(addTx, status.Committed)
+	env.state.SetTimestamp(endTime)
+	env.state.SetHeight(1)
+	require.NoError(env.state.Commit())
+
+	rewardAddrs := set.Of(rewardAddress)
+	oldBalance, err := lux.GetBalance(env.state, rewardAddrs)
+	require.NoError(err)
+
+	// The first staking period ends, so the staking period is renewed with the
+	// reward compounded into the stake.
+	tx, err := env.txBuilder.NewRewardValidatorTx(addTxID)
+	require.NoError(err)
+
+	onCommitState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	onAbortState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	txExecutor := ProposalTxExecutor{
+		OnCommitState: onCommitState,
+		OnAbortState:  onAbortState,
+		Backend:       &env.backend,
+		Tx:            tx,
+	}
+	require.NoError(tx.Unsigned.Visit(&txExecutor))
+
+	renewedTxID := state.RenewedStakerTxID(addTxID, endTime)
+	renewedEndTime := endTime.Add(period)
+
+	// If the validator isn't rewarded, it is removed and its stake is
+	// returned.
+	_, err = onAbortState.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	numOutputs := len(addTx.Unsigned.Outputs())
+	for i, out := range stakedOuts {
+		utxoID := lux.UTXOID{
+			TxID:        addTxID,
+			OutputIndex: uint32(numOutputs + i),
+		}
+		utxo, err := onAbortState.GetUTXO(utxoID.InputID())
+		require.NoError(err)
+		require.Equal(out.Output(), utxo.Out)
+	}
+
+	onCommitValidator, err := onCommitState.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+	require.NoError(err)
+	require.Equal(renewedTxID, onCommitValidator.TxID)
+	require.Equal(addTxID, onCommitValidator.ContinuousTxID)
+	require.Equal(addTxID, onCommitValidator.StakerTxID())
+	require.Equal(env.config.MinValidatorStake+potentialReward, onCommitValidator.Weight)
+	require.Equal(endTime.Unix(), onCommitValidator.StartTime.Unix())
+	require.Equal(renewedEndTime.Unix(), onCommitValidator.EndTime.Unix())
+	require.Equal(renewedEndTime.Unix(), onCommitValidator.NextTime.Unix())
+	require.NotZero(onCommitValidator.PotentialReward)
+
+	require.NoError(onCommitState.Apply(env.state))
+	env.state.SetHeight(2)
+	require.NoError(env.state.Commit())
+
+	// The compounded reward isn't paid out.
+	newBalance, err := lux.GetBalance(env.state, rewardAddrs)
+	require.NoError(err)
+	require.Equal(oldBalance, newBalance)
+	require.Equal(
+		env.config.MinValidatorStake+potentialReward,
+		env.config.Validators.GetWeight(constants.PrimaryNetworkID, nodeID),
+	)
+
+	// Stop renewing the staking period.
+	ins, outs, _, signers, err := env.utxosHandler.Spend(
+		env.state,
+		[]*secp256k1.PrivateKey{preFundedKeys[0]},
+		0,
+		env.config.TxFee,
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	kc := secp256k1fx.NewKeychain(rewardKey)
+	sigIndices, stakerSigners, ok := kc.Match(rewardOwner, uint64(env.state.GetTimestamp().Unix()))
+	require.True(ok)
+	signers = append(signers, stakerSigners)
+
+	stopTx, err := txs.NewSigned(&txs.StopContinuousValidatorTx{
+		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+			NetworkID:    env.ctx.NetworkID,
+			BlockchainID: env.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+		}},
+		TxID:       addTxID,
+		StakerAuth: &secp256k1fx.Input{SigIndices: sigIndices},
+	}, txs.Codec, signers)
+	require.NoError(err)
+
+	stopDiff, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	// Continuous staking has its own activation time.
+	env.config.ContinuousStakingTime = mockable.MaxTime
+	err = stopTx.Unsigned.Visit(&StandardTxExecutor{
+		Backend: &env.backend,
+		State:   stopDiff,
+		Tx:      stopTx,
+	})
+	require.ErrorIs(err, ErrContinuousStakingNotActive)
+	env.config.ContinuousStakingTime = time.Time{}
+
+	require.NoError(stopTx.Unsigned.Visit(&StandardTxExecutor{
+		Backend: &env.backend,
+		State:   stopDiff,
+		Tx:      stopTx,
+	}))
+
+	stopped, err := stopDiff.IsContinuousValidatorStopped(addTxID)
+	require.NoError(err)
+	require.True(stopped)
+
+	// The validator can't be stopped twice.
+	err = stopTx.Unsigned.Visit(&StandardTxExecutor{
+		Backend: &env.backend,
+		State:   stopDiff,
+		Tx:      stopTx,
+	})
+	require.ErrorIs(err, ErrContinuousValidatorStopped)
+
+	require.NoError(stopDiff.Apply(env.state))
+	env.state.SetTimestamp(renewedEndTime)
+	env.state.SetHeight(3)
+	require.NoError(env.state.Commit())
+
+	stopped, err = env.state.IsContinuousValidatorStopped(addTxID)
+	require.NoError(err)
+	require.True(stopped)
+
+	// The renewed staking period ends, so the validator is removed and the
+	// compounded reward is paid out along with the new reward.
+	tx, err = env.txBuilder.NewRewardValidatorTx(renewedTxID)
+	require.NoError(err)
+
+	onCommitState, err = state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	onAbortState, err = state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	txExecutor = ProposalTxExecutor{
+		OnCommitState: onCommitState,
+		OnAbortState:  onAbortState,
+		Backend:       &env.backend,
+		Tx:            tx,
+	}
+	require.NoError(tx.Unsigned.Visit(&txExecutor))
+
+	_, err = onCommitState.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// The compounded reward follows the reward UTXO on commit, and directly
+	// follows the stake on abort.
+	compoundedUTXOID := lux.UTXOID{
+		TxID:        renewedTxID,
+		OutputIndex: uint32(numOutputs + len(stakedOuts) + 1),
+	}
+	compoundedUTXO, err := onCommitState.GetUTXO(compoundedUTXOID.InputID())
+	require.NoError(err)
+	require.Equal(potentialReward, compoundedUTXO.Out.(*secp256k1fx.TransferOutput).Amount())
+
+	compoundedUTXOID = lux.UTXOID{
+		TxID:        renewedTxID,
+		OutputIndex: uint32(numOutputs + len(stakedOuts)),
+	}
+	compoundedUTXO, err = onAbortState.GetUTXO(compoundedUTXOID.InputID())
+	require.NoError(err)
+	require.Equal(potentialReward, compoundedUTXO.Out.(*secp256k1fx.TransferOutput).Amount())
+
+	_, err = onAbortState.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	require.NoError(onCommitState.Apply(env.state))
+	env.state.SetHeight(4)
+	require.NoError(env.state.Commit())
+
+	newBalance, err = lux.GetBalance(env.state, rewardAddrs)
+	require.NoError(err)
+	require.Equal(oldBalance+potentialReward+onCommitValidator.PotentialReward, newBalance)
+	require.Zero(env.config.Validators.GetWeight(constants.PrimaryNetworkID, nodeID))
+
+	records, err := env.state.GetRewardRecords(nodeID)
+	require.NoError(err)
+	require.Len(records, 2)
+	require.Equal(addTxID, records[0].TxID)
+	require.Equal(renewedTxID, records[1].TxID)
+}
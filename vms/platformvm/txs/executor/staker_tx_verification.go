@@ -4,9 +4,12 @@
 package executor
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"math"
+	"time"
 
 	"github.com/luxdefi/node/database"
 	"github.com/luxdefi/node/ids"
@@ -14,6 +17,9 @@ import (
 	"github.com/luxdefi/node/vms/components/lux"
 	"github.com/luxdefi/node/vms/platformvm/state"
 	"github.com/luxdefi/node/vms/platformvm/txs"
+	"github.com/luxdefi/node/vms/platformvm/warp"
+	"github.com/luxdefi/node/vms/platformvm/warp/message"
+	"github.com/luxdefi/node/vms/platformvm/warp/payload"
 
 	safemath "github.com/luxdefi/node/utils/math"
 )
@@ -38,6 +44,17 @@ var (
 	ErrDelegateToPermissionedValidator = errors.New("delegation to permissioned validator")
 	ErrWrongStakedAssetID              = errors.New("incorrect staked assetID")
 	ErrDurangoUpgradeNotActive         = errors.New("attempting to use a Durango-upgrade feature prior to activation")
+	ErrContinuousStakingNotActive      = errors.New("attempting to use continuous staking prior to activation")
+	ErrSubnetConversionNotActive       = errors.New("attempting to convert a subnet prior to activation")
+	ErrPooledDelegationNotActive       = errors.New("attempting to use pooled delegation prior to activation")
+	ErrNotContinuousValidatorTx        = errors.New("is not an add continuous validator tx")
+	ErrNotContinuousValidator          = errors.New("isn't a current continuous validator")
+	ErrContinuousValidatorStopped      = errors.New("continuous validator is already stopped")
+	errUnauthorizedStakerModification  = errors.New("unauthorized staker modification")
+	ErrManagerChainNotInSubnet         = errors.New("validator manager chain isn't validated by the subnet")
+	ErrSubnetNotManaged                = errors.New("subnet isn't managed by a validator manager")
+	ErrWrongSubnetManager              = errors.New("message wasn't sent by the validator manager of the subnet")
+	ErrStaleNonce                      = errors.New("nonce isn't greater than the nonce of the last applied message")
 )
 
 // verifySubnetValidatorPrimaryNetworkRequirements verifies the primary
@@ -151,6 +168,11 @@ func verifyAddValidatorTx(
 		)
 	}
 
+	txFee, err := getTxFee(backend, chainState, sTx, currentTimestamp)
+	if err != nil {
+		return nil, err
+	}
+
 	// Verify the flowcheck
 	if err := backend.FlowChecker.VerifySpend(
 		tx,
@@ -159,7 +181,7 @@ func verifyAddValidatorTx(
 		outs,
 		sTx.Creds,
 		map[ids.ID]uint64{
-			backend.Ctx.LUXAssetID: backend.Config.AddPrimaryNetworkValidatorFee,
+			backend.Ctx.LUXAssetID: txFee,
 		},
 	); err != nil {
 		return nil, fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
@@ -241,6 +263,11 @@ func verifyAddSubnetValidatorTx(
 		return err
 	}
 
+	txFee, err := getTxFee(backend, chainState, sTx, currentTimestamp)
+	if err != nil {
+		return err
+	}
+
 	// Verify the flowcheck
 	if err := backend.FlowChecker.VerifySpend(
 		tx,
@@ -249,7 +276,7 @@ func verifyAddSubnetValidatorTx(
 		tx.Outs,
 		baseTxCreds,
 		map[ids.ID]uint64{
-			backend.Ctx.LUXAssetID: backend.Config.AddSubnetValidatorFee,
+			backend.Ctx.LUXAssetID: txFee,
 		},
 	); err != nil {
 		return fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
@@ -310,11 +337,20 @@ func verifyRemoveSubnetValidatorTx(
 		return vdr, isCurrentValidator, nil
 	}
 
+	if err := verifySubnetIsNotManaged(backend, chainState, tx.Subnet); err != nil {
+		return nil, false, err
+	}
+
 	baseTxCreds, err := verifySubnetAuthorization(backend, chainState, sTx, tx.Subnet, tx.SubnetAuth)
 	if err != nil {
 		return nil, false, err
 	}
 
+	txFee, err := getTxFee(backend, chainState, sTx, chainState.GetTimestamp())
+	if err != nil {
+		return nil, false, err
+	}
+
 	// Verify the flowcheck
 	if err := backend.FlowChecker.VerifySpend(
 		tx,
@@ -323,7 +359,7 @@ func verifyRemoveSubnetValidatorTx(
 		tx.Outs,
 		baseTxCreds,
 		map[ids.ID]uint64{
-			backend.Ctx.LUXAssetID: backend.Config.TxFee,
+			backend.Ctx.LUXAssetID: txFee,
 		},
 	); err != nil {
 		return nil, false, fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
@@ -424,6 +460,11 @@ func verifyAddDelegatorTx(
 		return nil, ErrOverDelegated
 	}
 
+	txFee, err := getTxFee(backend, chainState, sTx, currentTimestamp)
+	if err != nil {
+		return nil, err
+	}
+
 	// Verify the flowcheck
 	if err := backend.FlowChecker.VerifySpend(
 		tx,
@@ -432,7 +473,7 @@ func verifyAddDelegatorTx(
 		outs,
 		sTx.Creds,
 		map[ids.ID]uint64{
-			backend.Ctx.LUXAssetID: backend.Config.AddPrimaryNetworkDelegatorFee,
+			backend.Ctx.LUXAssetID: txFee,
 		},
 	); err != nil {
 		return nil, fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
@@ -533,15 +574,15 @@ func verifyAddPermissionlessValidatorTx(
 		)
 	}
 
-	var txFee uint64
 	if tx.Subnet != constants.PrimaryNetworkID {
 		if err := verifySubnetValidatorPrimaryNetworkRequirements(chainState, tx.Validator); err != nil {
 			return err
 		}
+	}
 
-		txFee = backend.Config.AddSubnetValidatorFee
-	} else {
-		txFee = backend.Config.AddPrimaryNetworkValidatorFee
+	txFee, err := getTxFee(backend, chainState, sTx, currentTimestamp)
+	if err != nil {
+		return err
 	}
 
 	outs := make([]*lux.TransferableOutput, len(tx.Outs)+len(tx.StakeOuts))
@@ -675,7 +716,6 @@ func verifyAddPermissionlessDelegatorTx(
 	copy(outs, tx.Outs)
 	copy(outs[len(tx.Outs):], tx.StakeOuts)
 
-	var txFee uint64
 	if tx.Subnet != constants.PrimaryNetworkID {
 		// Invariant: Delegators must only be able to reference validator
 		//            transactions that implement [txs.ValidatorTx]. All
@@ -686,10 +726,11 @@ func verifyAddPermissionlessDelegatorTx(
 		if validator.Priority.IsPermissionedValidator() {
 			return ErrDelegateToPermissionedValidator
 		}
+	}
 
-		txFee = backend.Config.AddSubnetDelegatorFee
-	} else {
-		txFee = backend.Config.AddPrimaryNetworkDelegatorFee
+	txFee, err := getTxFee(backend, chainState, sTx, currentTimestamp)
+	if err != nil {
+		return err
 	}
 
 	// Verify the flowcheck
@@ -727,7 +768,8 @@ func verifyTransferSubnetOwnershipTx(
 	sTx *txs.Tx,
 	tx *txs.TransferSubnetOwnershipTx,
 ) error {
-	if !backend.Config.IsDurangoActivated(chainState.GetTimestamp()) {
+	currentTimestamp := chainState.GetTimestamp()
+	if !backend.Config.IsDurangoActivated(currentTimestamp) {
 		return ErrDurangoUpgradeNotActive
 	}
 
@@ -746,6 +788,11 @@ func verifyTransferSubnetOwnershipTx(
 		return err
 	}
 
+	txFee, err := getTxFee(backend, chainState, sTx, currentTimestamp)
+	if err != nil {
+		return err
+	}
+
 	// Verify the flowcheck
 	if err := backend.FlowChecker.VerifySpend(
 		tx,
@@ -754,7 +801,121 @@ func verifyTransferSubnetOwnershipTx(
 		tx.Outs,
 		baseTxCreds,
 		map[ids.ID]uint64{
-			backend.Ctx.LUXAssetID: backend.Config.TxFee,
+			backend.Ctx.LUXAssetID: txFee,
+		},
+	); err != nil {
+		return fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
+	}
+
+	return nil
+}
+
+// verifyAddContinuousValidatorTx carries out the validation for an
+// AddContinuousValidatorTx.
+func verifyAddContinuousValidatorTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.AddContinuousValidatorTx,
+) error {
+	if !backend.Config.IsContinuousStakingActivated(chainState.GetTimestamp()) {
+		return ErrContinuousStakingNotActive
+	}
+	return verifyAddPermissionlessValidatorTx(backend, chainState, sTx, &tx.AddPermissionlessValidatorTx)
+}
+
+// verifyAddPooledDelegatorTx carries out the validation for an
+// AddPooledDelegatorTx.
+func verifyAddPooledDelegatorTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.AddPooledDelegatorTx,
+) error {
+	if !backend.Config.IsPooledDelegationActivated(chainState.GetTimestamp()) {
+		return ErrPooledDelegationNotActive
+	}
+	return verifyAddPermissionlessDelegatorTx(backend, chainState, sTx, &tx.AddPermissionlessDelegatorTx)
+}
+
+// verifyStopContinuousValidatorTx carries out the validation for a
+// StopContinuousValidatorTx.
+func verifyStopContinuousValidatorTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.StopContinuousValidatorTx,
+) error {
+	currentTimestamp := chainState.GetTimestamp()
+	if !backend.Config.IsContinuousStakingActivated(currentTimestamp) {
+		return ErrContinuousStakingNotActive
+	}
+
+	// Verify the tx is well-formed
+	if err := sTx.SyntacticVerify(backend.Ctx); err != nil {
+		return err
+	}
+
+	if !backend.Bootstrapped.Get() {
+		// Not bootstrapped yet -- don't need to do full verification.
+		return nil
+	}
+
+	addTxIntf, _, err := chainState.GetTx(tx.TxID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch tx %s: %w", tx.TxID, err)
+	}
+	addTx, ok := addTxIntf.Unsigned.(*txs.AddContinuousValidatorTx)
+	if !ok {
+		return fmt.Errorf("%s %w", tx.TxID, ErrNotContinuousValidatorTx)
+	}
+
+	nodeID := addTx.NodeID()
+	vdr, err := chainState.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+	if err != nil && err != database.ErrNotFound {
+		return fmt.Errorf(
+			"failed to fetch the current validator %s: %w",
+			nodeID,
+			err,
+		)
+	}
+	if err == database.ErrNotFound || vdr.ContinuousTxID != tx.TxID {
+		return fmt.Errorf("%s %w", nodeID, ErrNotContinuousValidator)
+	}
+
+	stopped, err := chainState.IsContinuousValidatorStopped(tx.TxID)
+	if err != nil {
+		return err
+	}
+	if stopped {
+		return ErrContinuousValidatorStopped
+	}
+
+	if len(sTx.Creds) == 0 {
+		// Ensure there is at least one credential for the staker authorization
+		return errWrongNumberOfCredentials
+	}
+
+	baseTxCredsLen := len(sTx.Creds) - 1
+	stakerCred := sTx.Creds[baseTxCredsLen]
+	if err := backend.Fx.VerifyPermission(sTx.Unsigned, tx.StakerAuth, stakerCred, addTx.ValidatorRewardsOwner); err != nil {
+		return fmt.Errorf("%w: %w", errUnauthorizedStakerModification, err)
+	}
+
+	txFee, err := getTxFee(backend, chainState, sTx, currentTimestamp)
+	if err != nil {
+		return err
+	}
+
+	// Verify the flowcheck
+	if err := backend.FlowChecker.VerifySpend(
+		tx,
+		chainState,
+		tx.Ins,
+		tx.Outs,
+		sTx.Creds[:baseTxCredsLen],
+		map[ids.ID]uint64{
+			backend.Ctx.LUXAssetID: txFee,
 		},
 	); err != nil {
 		return fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
@@ -762,3 +923,188 @@ func verifyTransferSubnetOwnershipTx(
 
 	return nil
 }
+
+// verifyConvertSubnetTx carries out the validation for a ConvertSubnetTx.
+func verifyConvertSubnetTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.ConvertSubnetTx,
+) error {
+	currentTimestamp := chainState.GetTimestamp()
+	if !backend.Config.IsSubnetConversionActivated(currentTimestamp) {
+		return ErrSubnetConversionNotActive
+	}
+
+	// Verify the tx is well-formed
+	if err := sTx.SyntacticVerify(backend.Ctx); err != nil {
+		return err
+	}
+
+	if !backend.Bootstrapped.Get() {
+		// Not bootstrapped yet -- don't need to do full verification.
+		return nil
+	}
+
+	baseTxCreds, err := verifyPoASubnetAuthorization(backend, chainState, sTx, tx.Subnet, tx.SubnetAuth)
+	if err != nil {
+		return err
+	}
+
+	subnetID, err := chainValidatorState{chainState: chainState}.GetSubnetID(context.TODO(), tx.ChainID)
+	if err != nil {
+		return err
+	}
+	if subnetID != tx.Subnet {
+		return fmt.Errorf("%w: %s is validated by %s", ErrManagerChainNotInSubnet, tx.ChainID, subnetID)
+	}
+
+	txFee, err := getTxFee(backend, chainState, sTx, currentTimestamp)
+	if err != nil {
+		return err
+	}
+
+	// Verify the flowcheck
+	if err := backend.FlowChecker.VerifySpend(
+		tx,
+		chainState,
+		tx.Ins,
+		tx.Outs,
+		baseTxCreds,
+		map[ids.ID]uint64{
+			backend.Ctx.LUXAssetID: txFee,
+		},
+	); err != nil {
+		return fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
+	}
+
+	return nil
+}
+
+// verifySetSubnetValidatorWeightTx carries out the validation for a
+// SetSubnetValidatorWeightTx. It returns the ID of the Warp message of the tx
+// and the message of the validator manager it carries.
+func verifySetSubnetValidatorWeightTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.SetSubnetValidatorWeightTx,
+) (ids.ID, *message.SubnetValidatorWeight, error) {
+	currentTimestamp := chainState.GetTimestamp()
+	if !backend.Config.IsSubnetConversionActivated(currentTimestamp) {
+		return ids.Empty, nil, ErrSubnetConversionNotActive
+	}
+
+	// Verify the tx is well-formed
+	if err := sTx.SyntacticVerify(backend.Ctx); err != nil {
+		return ids.Empty, nil, err
+	}
+
+	warpMsg, err := warp.ParseMessage(tx.Message)
+	if err != nil {
+		return ids.Empty, nil, err
+	}
+	addressedCall, err := payload.ParseAddressedCall(warpMsg.Payload)
+	if err != nil {
+		return ids.Empty, nil, err
+	}
+	msg, err := message.ParseSubnetValidatorWeight(addressedCall.Payload)
+	if err != nil {
+		return ids.Empty, nil, err
+	}
+	msgID := warpMsg.ID()
+
+	if !backend.Bootstrapped.Get() {
+		// Not bootstrapped yet -- don't need to do full verification.
+		return msgID, msg, nil
+	}
+
+	manager, err := chainState.GetSubnetManager(msg.SubnetID)
+	if err == database.ErrNotFound {
+		return ids.Empty, nil, fmt.Errorf("%q %w", msg.SubnetID, ErrSubnetNotManaged)
+	}
+	if err != nil {
+		return ids.Empty, nil, err
+	}
+	if warpMsg.SourceChainID != manager.ChainID || !bytes.Equal(addressedCall.SourceAddress, manager.Address) {
+		return ids.Empty, nil, ErrWrongSubnetManager
+	}
+
+	nonce, err := chainState.GetSubnetValidatorNonce(msg.SubnetID, msg.NodeID)
+	if err != nil {
+		return ids.Empty, nil, err
+	}
+	if msg.Nonce <= nonce {
+		return ids.Empty, nil, fmt.Errorf("%w: %d <= %d", ErrStaleNonce, msg.Nonce, nonce)
+	}
+
+	_, err = chainState.GetPendingValidator(msg.SubnetID, msg.NodeID)
+	if err == nil {
+		return ids.Empty, nil, fmt.Errorf(
+			"%w: %s is a pending validator of %s",
+			ErrDuplicateValidator,
+			msg.NodeID,
+			msg.SubnetID,
+		)
+	}
+	if err != database.ErrNotFound {
+		return ids.Empty, nil, err
+	}
+
+	if msg.Weight == 0 {
+		// Removing a validator that isn't a current validator is a no-op, so
+		// it is rejected.
+		_, err := chainState.GetCurrentValidator(msg.SubnetID, msg.NodeID)
+		if err == database.ErrNotFound {
+			return ids.Empty, nil, fmt.Errorf(
+				"%s %w of %s",
+				msg.NodeID,
+				ErrNotValidator,
+				msg.SubnetID,
+			)
+		}
+		if err != nil {
+			return ids.Empty, nil, err
+		}
+	} else {
+		endTime := time.Unix(int64(msg.EndTime), 0)
+		if !endTime.After(currentTimestamp) {
+			return ids.Empty, nil, ErrStakeTooShort
+		}
+
+		vdr := txs.Validator{
+			NodeID: msg.NodeID,
+			Start:  uint64(currentTimestamp.Unix()),
+			End:    msg.EndTime,
+			Wght:   msg.Weight,
+		}
+		if err := verifySubnetValidatorPrimaryNetworkRequirements(chainState, vdr); err != nil {
+			return ids.Empty, nil, err
+		}
+	}
+
+	if err := verifyWarpMessage(backend, chainState, warpMsg); err != nil {
+		return ids.Empty, nil, err
+	}
+
+	txFee, err := getTxFee(backend, chainState, sTx, currentTimestamp)
+	if err != nil {
+		return ids.Empty, nil, err
+	}
+
+	// Verify the flowcheck
+	if err := backend.FlowChecker.VerifySpend(
+		tx,
+		chainState,
+		tx.Ins,
+		tx.Outs,
+		sTx.Creds,
+		map[ids.ID]uint64{
+			backend.Ctx.LUXAssetID: txFee,
+		},
+	); err != nil {
+		return ids.Empty, nil, fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
+	}
+
+	return msgID, msg, nil
+}
@@ -16,13 +16,19 @@ import (
 	"github.com/luxdefi/node/snow"
 	"github.com/luxdefi/node/utils"
 	"github.com/luxdefi/node/utils/constants"
+	"github.com/luxdefi/node/utils/crypto/bls"
+	"github.com/luxdefi/node/utils/set"
 	"github.com/luxdefi/node/utils/timer/mockable"
 	"github.com/luxdefi/node/vms/components/lux"
 	"github.com/luxdefi/node/vms/components/verify"
 	"github.com/luxdefi/node/vms/platformvm/config"
 	"github.com/luxdefi/node/vms/platformvm/state"
+	"github.com/luxdefi/node/vms/platformvm/status"
 	"github.com/luxdefi/node/vms/platformvm/txs"
 	"github.com/luxdefi/node/vms/platformvm/utxo"
+	"github.com/luxdefi/node/vms/platformvm/warp"
+	"github.com/luxdefi/node/vms/platformvm/warp/message"
+	"github.com/luxdefi/node/vms/platformvm/warp/payload"
 	"github.com/luxdefi/node/vms/secp256k1fx"
 )
 
@@ -781,3 +787,294 @@ func TestGetDelegatorRules(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifySetSubnetValidatorWeightTx(t *testing.T) {
+	var (
+		ctx             = snow.DefaultContextTest()
+		subnetID        = ids.GenerateTestID()
+		managerChainID  = ids.GenerateTestID()
+		managerAddress  = []byte{'m', 'g', 'r'}
+		signerNodeID    = ids.GenerateTestNodeID()
+		nodeID          = ids.GenerateTestNodeID()
+		now             = time.Unix(1_000, 0)
+		endTime         = uint64(now.Add(time.Hour).Unix())
+		managerChainTx  = &txs.Tx{Unsigned: &txs.CreateChainTx{SubnetID: subnetID}}
+		primaryEndTime  = now.Add(24 * time.Hour)
+		primaryNodeVdr  = &state.Staker{StartTime: now, EndTime: primaryEndTime}
+		subnetSignerVdr = &state.Staker{SubnetID: subnetID, NodeID: signerNodeID, Weight: 10}
+	)
+
+	signerSK, err := bls.NewSecretKey()
+	require.NoError(t, err)
+	otherSK, err := bls.NewSecretKey()
+	require.NoError(t, err)
+	primarySignerVdr := &state.Staker{PublicKey: bls.PublicFromSecretKey(signerSK)}
+
+	// newTx returns a tx carrying [msg], sent by [sourceAddress] on the
+	// manager chain and signed with [sk].
+	newTx := func(t *testing.T, msg *message.SubnetValidatorWeight, sourceAddress []byte, sk *bls.SecretKey) (*txs.Tx, *txs.SetSubnetValidatorWeightTx) {
+		require := require.New(t)
+
+		addressedCall, err := payload.NewAddressedCall(sourceAddress, msg.Bytes())
+		require.NoError(err)
+		unsignedMsg, err := warp.NewUnsignedMessage(ctx.NetworkID, managerChainID, addressedCall.Bytes())
+		require.NoError(err)
+
+		signers := set.NewBits(0)
+		sig := &warp.BitSetSignature{
+			Signers: signers.Bytes(),
+		}
+		copy(sig.Signature[:], bls.SignatureToBytes(bls.Sign(sk, unsignedMsg.Bytes())))
+		warpMsg, err := warp.NewMessage(unsignedMsg, sig)
+		require.NoError(err)
+
+		utx := &txs.SetSubnetValidatorWeightTx{
+			BaseTx: txs.BaseTx{
+				SyntacticallyVerified: true,
+				BaseTx: lux.BaseTx{
+					NetworkID:    ctx.NetworkID,
+					BlockchainID: ctx.ChainID,
+				},
+			},
+			Message: warpMsg.Bytes(),
+		}
+		tx, err := txs.NewSigned(utx, txs.Codec, nil)
+		require.NoError(err)
+		return tx, utx
+	}
+
+	newMsg := func(t *testing.T, nonce uint64, weight uint64) *message.SubnetValidatorWeight {
+		msg, err := message.NewSubnetValidatorWeight(subnetID, nodeID, nonce, weight, endTime)
+		require.NoError(t, err)
+		return msg
+	}
+
+	// expectManaged sets the expectations of a converted subnet whose last
+	// applied nonce for [nodeID] is [nonce].
+	expectManaged := func(s *state.MockChain, nonce uint64) {
+		s.EXPECT().GetTimestamp().Return(now)
+		s.EXPECT().GetSubnetManager(subnetID).Return(state.SubnetManager{
+			ChainID: managerChainID,
+			Address: managerAddress,
+		}, nil)
+		s.EXPECT().GetSubnetValidatorNonce(subnetID, nodeID).Return(nonce, nil)
+		s.EXPECT().GetPendingValidator(subnetID, nodeID).Return(nil, database.ErrNotFound)
+	}
+
+	// expectWarp sets the expectations of the verification of a Warp message
+	// sent on the manager chain.
+	expectWarp := func(_ *gomock.Controller, s *state.MockChain) {
+		s.EXPECT().GetTx(managerChainID).Return(managerChainTx, status.Committed, nil)
+		s.EXPECT().GetCurrentValidators(subnetID).Return([]*state.Staker{subnetSignerVdr}, nil)
+		s.EXPECT().GetCurrentDelegatorIterator(subnetID, signerNodeID).Return(state.EmptyIterator, nil)
+		s.EXPECT().GetCurrentValidator(constants.PrimaryNetworkID, signerNodeID).Return(primarySignerVdr, nil)
+	}
+
+	tests := []struct {
+		name                 string
+		subnetConversionTime time.Time
+		stateF               func(*gomock.Controller) state.Chain
+		flowCheckerF         func(*gomock.Controller) utxo.Verifier
+		msg                  *message.SubnetValidatorWeight
+		sourceAddress        []byte
+		sk                   *bls.SecretKey
+		expectedErr          error
+	}{
+		{
+			name:                 "subnet conversion not activated",
+			subnetConversionTime: mockable.MaxTime,
+			stateF: func(ctrl *gomock.Controller) state.Chain {
+				s := state.NewMockChain(ctrl)
+				s.EXPECT().GetTimestamp().Return(now)
+				return s
+			},
+			msg:           newMsg(t, 1, 1),
+			sourceAddress: managerAddress,
+			sk:            signerSK,
+			expectedErr:   ErrSubnetConversionNotActive,
+		},
+		{
+			name: "subnet not managed",
+			stateF: func(ctrl *gomock.Controller) state.Chain {
+				s := state.NewMockChain(ctrl)
+				s.EXPECT().GetTimestamp().Return(now)
+				s.EXPECT().GetSubnetManager(subnetID).Return(state.SubnetManager{}, database.ErrNotFound)
+				return s
+			},
+			msg:           newMsg(t, 1, 1),
+			sourceAddress: managerAddress,
+			sk:            signerSK,
+			expectedErr:   ErrSubnetNotManaged,
+		},
+		{
+			name: "wrong manager address",
+			stateF: func(ctrl *gomock.Controller) state.Chain {
+				s := state.NewMockChain(ctrl)
+				s.EXPECT().GetTimestamp().Return(now)
+				s.EXPECT().GetSubnetManager(subnetID).Return(state.SubnetManager{
+					ChainID: managerChainID,
+					Address: managerAddress,
+				}, nil)
+				return s
+			},
+			msg:           newMsg(t, 1, 1),
+			sourceAddress: []byte{'b', 'a', 'd'},
+			sk:            signerSK,
+			expectedErr:   ErrWrongSubnetManager,
+		},
+		{
+			name: "stale nonce",
+			stateF: func(ctrl *gomock.Controller) state.Chain {
+				s := state.NewMockChain(ctrl)
+				s.EXPECT().GetTimestamp().Return(now)
+				s.EXPECT().GetSubnetManager(subnetID).Return(state.SubnetManager{
+					ChainID: managerChainID,
+					Address: managerAddress,
+				}, nil)
+				s.EXPECT().GetSubnetValidatorNonce(subnetID, nodeID).Return(uint64(1), nil)
+				return s
+			},
+			msg:           newMsg(t, 1, 1),
+			sourceAddress: managerAddress,
+			sk:            signerSK,
+			expectedErr:   ErrStaleNonce,
+		},
+		{
+			name: "remove non-validator",
+			stateF: func(ctrl *gomock.Controller) state.Chain {
+				s := state.NewMockChain(ctrl)
+				expectManaged(s, 0)
+				s.EXPECT().GetCurrentValidator(subnetID, nodeID).Return(nil, database.ErrNotFound)
+				return s
+			},
+			msg:           newMsg(t, 1, 0),
+			sourceAddress: managerAddress,
+			sk:            signerSK,
+			expectedErr:   ErrNotValidator,
+		},
+		{
+			name: "invalid signature",
+			stateF: func(ctrl *gomock.Controller) state.Chain {
+				s := state.NewMockChain(ctrl)
+				expectManaged(s, 0)
+				s.EXPECT().GetCurrentValidator(constants.PrimaryNetworkID, nodeID).Return(primaryNodeVdr, nil)
+				expectWarp(ctrl, s)
+				return s
+			},
+			msg:           newMsg(t, 1, 1),
+			sourceAddress: managerAddress,
+			sk:            otherSK,
+			expectedErr:   warp.ErrInvalidSignature,
+		},
+		{
+			name: "valid",
+			stateF: func(ctrl *gomock.Controller) state.Chain {
+				s := state.NewMockChain(ctrl)
+				expectManaged(s, 0)
+				s.EXPECT().GetCurrentValidator(constants.PrimaryNetworkID, nodeID).Return(primaryNodeVdr, nil)
+				expectWarp(ctrl, s)
+				return s
+			},
+			flowCheckerF: func(ctrl *gomock.Controller) utxo.Verifier {
+				flowChecker := utxo.NewMockVerifier(ctrl)
+				flowChecker.EXPECT().VerifySpend(
+					gomock.Any(),
+					gomock.Any(),
+					gomock.Any(),
+					gomock.Any(),
+					gomock.Any(),
+					gomock.Any(),
+				).Return(nil)
+				return flowChecker
+			},
+			msg:           newMsg(t, 1, 1),
+			sourceAddress: managerAddress,
+			sk:            signerSK,
+			expectedErr:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			bootstrapped := &utils.Atomic[bool]{}
+			bootstrapped.Set(true)
+			backend := &Backend{
+				Config: &config.Config{
+					SubnetConversionTime: tt.subnetConversionTime,
+					DynamicFeesTime:      mockable.MaxTime,
+				},
+				Ctx:          ctx,
+				Bootstrapped: bootstrapped,
+			}
+			if tt.flowCheckerF != nil {
+				backend.FlowChecker = tt.flowCheckerF(ctrl)
+			}
+
+			sTx, tx := newTx(t, tt.msg, tt.sourceAddress, tt.sk)
+			_, msg, err := verifySetSubnetValidatorWeightTx(backend, tt.stateF(ctrl), sTx, tx)
+			require.ErrorIs(err, tt.expectedErr)
+			if tt.expectedErr != nil {
+				return
+			}
+			require.Equal(tt.msg.Bytes(), msg.Bytes())
+		})
+	}
+}
+
+func TestVerifyConvertSubnetTxNotActivated(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	now := time.Unix(1_000, 0)
+	chainState := state.NewMockChain(ctrl)
+	chainState.EXPECT().GetTimestamp().Return(now)
+
+	backend := &Backend{
+		Config: &config.Config{
+			SubnetConversionTime: now.Add(time.Second),
+		},
+		Ctx: snow.DefaultContextTest(),
+	}
+
+	utx := &txs.ConvertSubnetTx{
+		Subnet:  ids.GenerateTestID(),
+		ChainID: ids.GenerateTestID(),
+		Address: []byte{'m', 'g', 'r'},
+	}
+	tx := &txs.Tx{Unsigned: utx}
+
+	err := verifyConvertSubnetTx(backend, chainState, tx, utx)
+	require.ErrorIs(err, ErrSubnetConversionNotActive)
+}
+
+func TestVerifyAddPooledDelegatorTxNotActivated(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	now := time.Unix(1_000, 0)
+	chainState := state.NewMockChain(ctrl)
+	chainState.EXPECT().GetTimestamp().Return(now)
+
+	backend := &Backend{
+		Config: &config.Config{
+			PooledDelegationTime: now.Add(time.Second),
+		},
+		Ctx: snow.DefaultContextTest(),
+	}
+
+	utx := &txs.AddPooledDelegatorTx{
+		AddPermissionlessDelegatorTx: txs.AddPermissionlessDelegatorTx{
+			Validator: txs.Validator{
+				NodeID: ids.GenerateTestNodeID(),
+			},
+			Subnet: constants.PrimaryNetworkID,
+		},
+	}
+	tx := &txs.Tx{Unsigned: utx}
+
+	err := verifyAddPooledDelegatorTx(backend, chainState, tx, utx)
+	require.ErrorIs(err, ErrPooledDelegationNotActive)
+}
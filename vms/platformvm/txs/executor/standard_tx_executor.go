@@ -12,6 +12,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/luxdefi/node/chains/atomic"
+	"github.com/luxdefi/node/database"
 	"github.com/luxdefi/node/ids"
 	"github.com/luxdefi/node/utils/constants"
 	"github.com/luxdefi/node/utils/set"
@@ -60,7 +61,10 @@ func (e *StandardTxExecutor) CreateChainTx(tx *txs.CreateChainTx) error {
 
 	// Verify the flowcheck
 	timestamp := e.State.GetTimestamp()
-	createBlockchainTxFee := e.Config.GetCreateBlockchainTxFee(timestamp)
+	txFee, err := getTxFee(e.Backend, e.State, e.Tx, timestamp)
+	if err != nil {
+		return err
+	}
 	if err := e.FlowChecker.VerifySpend(
 		tx,
 		e.State,
@@ -68,7 +72,7 @@ func (e *StandardTxExecutor) CreateChainTx(tx *txs.CreateChainTx) error {
 		tx.Outs,
 		baseTxCreds,
 		map[ids.ID]uint64{
-			e.Ctx.LUXAssetID: createBlockchainTxFee,
+			e.Ctx.LUXAssetID: txFee,
 		},
 	); err != nil {
 		return err
@@ -99,7 +103,10 @@ func (e *StandardTxExecutor) CreateSubnetTx(tx *txs.CreateSubnetTx) error {
 
 	// Verify the flowcheck
 	timestamp := e.State.GetTimestamp()
-	createSubnetTxFee := e.Config.GetCreateSubnetTxFee(timestamp)
+	txFee, err := getTxFee(e.Backend, e.State, e.Tx, timestamp)
+	if err != nil {
+		return err
+	}
 	if err := e.FlowChecker.VerifySpend(
 		tx,
 		e.State,
@@ -107,7 +114,7 @@ func (e *StandardTxExecutor) CreateSubnetTx(tx *txs.CreateSubnetTx) error {
 		tx.Outs,
 		e.Tx.Creds,
 		map[ids.ID]uint64{
-			e.Ctx.LUXAssetID: createSubnetTxFee,
+			e.Ctx.LUXAssetID: txFee,
 		},
 	); err != nil {
 		return err
@@ -171,6 +178,10 @@ func (e *StandardTxExecutor) ImportTx(tx *txs.ImportTx) error {
 		copy(ins, tx.Ins)
 		copy(ins[len(tx.Ins):], tx.ImportedInputs)
 
+		txFee, err := getTxFee(e.Backend, e.State, e.Tx, e.State.GetTimestamp())
+		if err != nil {
+			return err
+		}
 		if err := e.FlowChecker.VerifySpendUTXOs(
 			tx,
 			utxos,
@@ -178,7 +189,7 @@ func (e *StandardTxExecutor) ImportTx(tx *txs.ImportTx) error {
 			tx.Outs,
 			e.Tx.Creds,
 			map[ids.ID]uint64{
-				e.Ctx.LUXAssetID: e.Config.TxFee,
+				e.Ctx.LUXAssetID: txFee,
 			},
 		); err != nil {
 			return err
@@ -219,6 +230,10 @@ func (e *StandardTxExecutor) ExportTx(tx *txs.ExportTx) error {
 	}
 
 	// Verify the flowcheck
+	txFee, err := getTxFee(e.Backend, e.State, e.Tx, e.State.GetTimestamp())
+	if err != nil {
+		return err
+	}
 	if err := e.FlowChecker.VerifySpend(
 		tx,
 		e.State,
@@ -226,7 +241,7 @@ func (e *StandardTxExecutor) ExportTx(tx *txs.ExportTx) error {
 		outs,
 		e.Tx.Creds,
 		map[ids.ID]uint64{
-			e.Ctx.LUXAssetID: e.Config.TxFee,
+			e.Ctx.LUXAssetID: txFee,
 		},
 	); err != nil {
 		return fmt.Errorf("failed verifySpend: %w", err)
@@ -404,6 +419,11 @@ func (e *StandardTxExecutor) TransformSubnetTx(tx *txs.TransformSubnetTx) error
 		return err
 	}
 
+	txFee, err := getTxFee(e.Backend, e.State, e.Tx, e.State.GetTimestamp())
+	if err != nil {
+		return err
+	}
+
 	totalRewardAmount := tx.MaximumSupply - tx.InitialSupply
 	if err := e.Backend.FlowChecker.VerifySpend(
 		tx,
@@ -415,7 +435,7 @@ func (e *StandardTxExecutor) TransformSubnetTx(tx *txs.TransformSubnetTx) error
 		//            entry in this map literal from being overwritten by the
 		//            second entry.
 		map[ids.ID]uint64{
-			e.Ctx.LUXAssetID: e.Config.TransformSubnetTxFee,
+			e.Ctx.LUXAssetID: txFee,
 			tx.AssetID:        totalRewardAmount,
 		},
 	); err != nil {
@@ -515,8 +535,73 @@ func (e *StandardTxExecutor) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwn
 	return nil
 }
 
+func (e *StandardTxExecutor) ConvertSubnetTx(tx *txs.ConvertSubnetTx) error {
+	err := verifyConvertSubnetTx(
+		e.Backend,
+		e.State,
+		e.Tx,
+		tx,
+	)
+	if err != nil {
+		return err
+	}
+
+	e.State.SetSubnetManager(tx.Subnet, state.SubnetManager{
+		ChainID: tx.ChainID,
+		Address: tx.Address,
+	})
+
+	txID := e.Tx.ID()
+	lux.Consume(e.State, tx.Ins)
+	lux.Produce(e.State, txID, tx.Outs)
+
+	return nil
+}
+
+func (e *StandardTxExecutor) SetSubnetValidatorWeightTx(tx *txs.SetSubnetValidatorWeightTx) error {
+	msgID, msg, err := verifySetSubnetValidatorWeightTx(
+		e.Backend,
+		e.State,
+		e.Tx,
+		tx,
+	)
+	if err != nil {
+		return err
+	}
+
+	staker, err := e.State.GetCurrentValidator(msg.SubnetID, msg.NodeID)
+	switch err {
+	case nil:
+		e.State.DeleteCurrentValidator(staker)
+	case database.ErrNotFound:
+	default:
+		return err
+	}
+
+	// A weight change replaces the current validator with a staker identified
+	// by the ID of the Warp message.
+	if msg.Weight != 0 {
+		e.State.PutCurrentValidator(state.NewManagedStaker(
+			msgID,
+			msg.SubnetID,
+			msg.NodeID,
+			msg.Weight,
+			e.State.GetTimestamp(),
+			time.Unix(int64(msg.EndTime), 0),
+		))
+	}
+	e.State.SetSubnetValidatorNonce(msg.SubnetID, msg.NodeID, msg.Nonce)
+
+	txID := e.Tx.ID()
+	lux.Consume(e.State, tx.Ins)
+	lux.Produce(e.State, txID, tx.Outs)
+
+	return nil
+}
+
 func (e *StandardTxExecutor) BaseTx(tx *txs.BaseTx) error {
-	if !e.Backend.Config.IsDurangoActivated(e.State.GetTimestamp()) {
+	currentTimestamp := e.State.GetTimestamp()
+	if !e.Backend.Config.IsDurangoActivated(currentTimestamp) {
 		return ErrDurangoUpgradeNotActive
 	}
 
@@ -526,6 +611,10 @@ func (e *StandardTxExecutor) BaseTx(tx *txs.BaseTx) error {
 	}
 
 	// Verify the flowcheck
+	txFee, err := getTxFee(e.Backend, e.State, e.Tx, currentTimestamp)
+	if err != nil {
+		return err
+	}
 	if err := e.FlowChecker.VerifySpend(
 		tx,
 		e.State,
@@ -533,7 +622,7 @@ func (e *StandardTxExecutor) BaseTx(tx *txs.BaseTx) error {
 		tx.Outs,
 		e.Tx.Creds,
 		map[ids.ID]uint64{
-			e.Ctx.LUXAssetID: e.Config.TxFee,
+			e.Ctx.LUXAssetID: txFee,
 		},
 	); err != nil {
 		return err
@@ -545,3 +634,77 @@ func (e *StandardTxExecutor) BaseTx(tx *txs.BaseTx) error {
 	lux.Produce(e.State, e.Tx.ID(), tx.Outs)
 	return nil
 }
+
+func (e *StandardTxExecutor) AddContinuousValidatorTx(tx *txs.AddContinuousValidatorTx) error {
+	if err := verifyAddContinuousValidatorTx(
+		e.Backend,
+		e.State,
+		e.Tx,
+		tx,
+	); err != nil {
+		return err
+	}
+
+	txID := e.Tx.ID()
+	newStaker, err := state.NewPendingStaker(txID, tx)
+	if err != nil {
+		return err
+	}
+
+	e.State.PutPendingValidator(newStaker)
+	lux.Consume(e.State, tx.Ins)
+	lux.Produce(e.State, txID, tx.Outs)
+
+	if e.Config.PartialSyncPrimaryNetwork && tx.Validator.NodeID == e.Ctx.NodeID {
+		e.Ctx.Log.Warn("verified transaction that would cause this node to become unhealthy",
+			zap.String("reason", "primary network is not being fully synced"),
+			zap.Stringer("txID", txID),
+			zap.String("txType", "addContinuousValidator"),
+			zap.Stringer("nodeID", tx.Validator.NodeID),
+		)
+	}
+
+	return nil
+}
+
+func (e *StandardTxExecutor) StopContinuousValidatorTx(tx *txs.StopContinuousValidatorTx) error {
+	if err := verifyStopContinuousValidatorTx(
+		e.Backend,
+		e.State,
+		e.Tx,
+		tx,
+	); err != nil {
+		return err
+	}
+
+	e.State.StopContinuousValidator(tx.TxID)
+
+	txID := e.Tx.ID()
+	lux.Consume(e.State, tx.Ins)
+	lux.Produce(e.State, txID, tx.Outs)
+
+	return nil
+}
+
+func (e *StandardTxExecutor) AddPooledDelegatorTx(tx *txs.AddPooledDelegatorTx) error {
+	if err := verifyAddPooledDelegatorTx(
+		e.Backend,
+		e.State,
+		e.Tx,
+		tx,
+	); err != nil {
+		return err
+	}
+
+	txID := e.Tx.ID()
+	newStaker, err := state.NewPendingStaker(txID, tx)
+	if err != nil {
+		return err
+	}
+
+	e.State.PutPendingDelegator(newStaker)
+	lux.Consume(e.State, tx.Ins)
+	lux.Produce(e.State, txID, tx.Outs)
+
+	return nil
+}
@@ -18,11 +18,14 @@ var (
 	errWrongNumberOfCredentials       = errors.New("should have the same number of credentials as inputs")
 	errIsImmutable                    = errors.New("is immutable")
 	errUnauthorizedSubnetModification = errors.New("unauthorized subnet modification")
+
+	ErrSubnetManaged = errors.New("validators are managed by a validator manager")
 )
 
 // verifyPoASubnetAuthorization carries out the validation for modifying a PoA
 // subnet. This is an extension of [verifySubnetAuthorization] that additionally
-// verifies that the subnet being modified is currently a PoA subnet.
+// verifies that the subnet being modified is currently a PoA subnet, which
+// excludes both permissionless and converted subnets.
 func verifyPoASubnetAuthorization(
 	backend *Backend,
 	chainState state.Chain,
@@ -43,9 +46,31 @@ func verifyPoASubnetAuthorization(
 		return nil, err
 	}
 
+	if err := verifySubnetIsNotManaged(backend, chainState, subnetID); err != nil {
+		return nil, err
+	}
+
 	return creds, nil
 }
 
+// verifySubnetIsNotManaged verifies that [subnetID] hasn't been converted, so
+// that its validators are still managed by the subnet owner. Subnets can't be
+// converted before the subnet conversion upgrade is activated.
+func verifySubnetIsNotManaged(backend *Backend, chainState state.Chain, subnetID ids.ID) error {
+	if !backend.Config.IsSubnetConversionActivated(chainState.GetTimestamp()) {
+		return nil
+	}
+
+	_, err := chainState.GetSubnetManager(subnetID)
+	if err == nil {
+		return fmt.Errorf("%q %w", subnetID, ErrSubnetManaged)
+	}
+	if err != database.ErrNotFound {
+		return err
+	}
+	return nil
+}
+
 // verifySubnetAuthorization carries out the validation for modifying a subnet.
 // The last credential in [sTx.Creds] is used as the subnet authorization.
 // Returns the remaining tx credentials that should be used to authorize the
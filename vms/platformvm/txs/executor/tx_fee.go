@@ -0,0 +1,25 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"time"
+
+	"github.com/luxdefi/node/vms/platformvm/state"
+	"github.com/luxdefi/node/vms/platformvm/txs"
+)
+
+// getTxFee returns the fee [tx] must burn to be accepted on top of
+// [chainState], whose timestamp is [timestamp].
+func getTxFee(backend *Backend, chainState state.Chain, tx *txs.Tx, timestamp time.Time) (uint64, error) {
+	var gasPrice uint64
+	if backend.Config.IsDynamicFeesActivated(timestamp) {
+		var err error
+		gasPrice, err = chainState.GetGasPrice()
+		if err != nil {
+			return 0, err
+		}
+	}
+	return backend.Config.GetTxFee(tx, timestamp, gasPrice)
+}
@@ -0,0 +1,92 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/crypto/secp256k1"
+	"github.com/luxdefi/node/utils/units"
+	"github.com/luxdefi/node/vms/platformvm/state"
+	"github.com/luxdefi/node/vms/platformvm/txs"
+	"github.com/luxdefi/node/vms/platformvm/txs/fee"
+	"github.com/luxdefi/node/vms/platformvm/utxo"
+)
+
+func TestStandardTxExecutorDynamicFees(t *testing.T) {
+	require := require.New(t)
+	env := newEnvironment(t, true /*=postBanff*/, false /*=postCortina*/)
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	keys := []*secp256k1.PrivateKey{preFundedKeys[0]}
+	to := ids.GenerateTestShortID()
+
+	// Built while only the static fee is charged.
+	staticTx, err := env.txBuilder.NewExportTx(
+		units.MilliLux,
+		xChainID,
+		to,
+		keys,
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	env.config.DynamicFeesTime = env.state.GetTimestamp()
+	env.config.DynamicFeeConfig = &fee.DynamicConfig{
+		Weights: fee.Dimensions{
+			Bandwidth:    1,
+			Signatures:   10,
+			UTXOsRead:    10,
+			UTXOsWritten: 10,
+		},
+		MinGasPrice:               1,
+		MaxGasPrice:               units.Lux,
+		TargetGasPerBlock:         10_000,
+		GasPriceChangeDenominator: 8,
+	}
+	env.state.SetGasPrice(10)
+
+	// The static fee no longer covers the gas consumed by the tx.
+	requiredFee, err := getTxFee(&env.backend, env.state, staticTx, env.state.GetTimestamp())
+	require.NoError(err)
+	require.Greater(requiredFee, defaultTxFee)
+
+	executeTx := func(tx *txs.Tx) error {
+		onAcceptState, err := state.NewDiff(lastAcceptedID, env)
+		require.NoError(err)
+
+		return tx.Unsigned.Visit(&StandardTxExecutor{
+			Backend: &env.backend,
+			State:   onAcceptState,
+			Tx:      tx,
+		})
+	}
+	err = executeTx(staticTx)
+	require.ErrorIs(err, utxo.ErrInsufficientUnlockedFunds)
+
+	// The builder pays the dynamic fee.
+	dynamicTx, err := env.txBuilder.NewExportTx(
+		units.MilliLux,
+		xChainID,
+		to,
+		keys,
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+	require.NoError(executeTx(dynamicTx))
+
+	complexity, err := fee.TxComplexity(dynamicTx)
+	require.NoError(err)
+	gas, err := complexity.Gas(env.config.DynamicFeeConfig.Weights)
+	require.NoError(err)
+	requiredFee, err = getTxFee(&env.backend, env.state, dynamicTx, env.state.GetTimestamp())
+	require.NoError(err)
+	require.Equal(10*gas, requiredFee)
+}
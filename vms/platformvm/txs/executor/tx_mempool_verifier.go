@@ -82,6 +82,26 @@ func (v *MempoolTxVerifier) BaseTx(tx *txs.BaseTx) error {
 	return v.standardTx(tx)
 }
 
+func (v *MempoolTxVerifier) AddContinuousValidatorTx(tx *txs.AddContinuousValidatorTx) error {
+	return v.standardTx(tx)
+}
+
+func (v *MempoolTxVerifier) StopContinuousValidatorTx(tx *txs.StopContinuousValidatorTx) error {
+	return v.standardTx(tx)
+}
+
+func (v *MempoolTxVerifier) ConvertSubnetTx(tx *txs.ConvertSubnetTx) error {
+	return v.standardTx(tx)
+}
+
+func (v *MempoolTxVerifier) SetSubnetValidatorWeightTx(tx *txs.SetSubnetValidatorWeightTx) error {
+	return v.standardTx(tx)
+}
+
+func (v *MempoolTxVerifier) AddPooledDelegatorTx(tx *txs.AddPooledDelegatorTx) error {
+	return v.standardTx(tx)
+}
+
 func (v *MempoolTxVerifier) standardTx(tx txs.UnsignedTx) error {
 	baseState, err := v.standardBaseState()
 	if err != nil {
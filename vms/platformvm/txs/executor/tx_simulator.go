@@ -0,0 +1,426 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils"
+	"github.com/luxdefi/node/utils/crypto/secp256k1"
+	"github.com/luxdefi/node/utils/math"
+	"github.com/luxdefi/node/utils/set"
+	"github.com/luxdefi/node/vms/components/lux"
+	"github.com/luxdefi/node/vms/components/verify"
+	"github.com/luxdefi/node/vms/platformvm/stakeable"
+	"github.com/luxdefi/node/vms/platformvm/state"
+	"github.com/luxdefi/node/vms/platformvm/txs"
+	"github.com/luxdefi/node/vms/secp256k1fx"
+)
+
+var (
+	_ txs.Visitor    = (*emptyCredentialsVisitor)(nil)
+	_ state.Versions = (*simulationVersions)(nil)
+
+	errUnknownInputType = errors.New("unknown input type")
+)
+
+// TxSimulation describes the changes that a tx makes when it's executed.
+type TxSimulation struct {
+	// UTXOs spent from the P-chain
+	Consumed []*lux.UTXO
+	// Source chain ID --> UTXOs imported from the chain
+	Imported map[ids.ID][]*lux.UTXO
+	// UTXOs created on the P-chain
+	Produced []*lux.UTXO
+	// Destination chain ID --> UTXOs exported to the chain
+	Exported map[ids.ID][]*lux.UTXO
+
+	AddedCurrentStakers   []*state.Staker
+	RemovedCurrentStakers []*state.Staker
+	AddedPendingStakers   []*state.Staker
+	RemovedPendingStakers []*state.Staker
+
+	// Asset ID --> amount that was consumed but was neither produced nor
+	// staked
+	Burned map[ids.ID]uint64
+}
+
+// SimulateTx executes [tx] the way the mempool verifies it, on top of the
+// state after [parentID] with the chain time advanced to the time of the next
+// block. Neither the state nor the mempool are modified. The returned error is
+// the reason that [tx] would be dropped.
+func SimulateTx(
+	backend *Backend,
+	versions state.Versions,
+	parentID ids.ID,
+	tx *txs.Tx,
+) (*TxSimulation, error) {
+	switch tx.Unsigned.(type) {
+	case *txs.AdvanceTimeTx, *txs.RewardValidatorTx:
+		return nil, ErrWrongTxType
+	}
+
+	verifier := MempoolTxVerifier{
+		Backend:       backend,
+		ParentID:      parentID,
+		StateVersions: versions,
+		Tx:            tx,
+	}
+	baseState, err := verifier.standardBaseState()
+	if err != nil {
+		return nil, err
+	}
+
+	// The tx is executed on its own diff so that the changes made by advancing
+	// the chain time aren't attributed to the tx.
+	txState, err := state.NewDiff(parentID, simulationVersions{state: baseState})
+	if err != nil {
+		return nil, err
+	}
+	executor := StandardTxExecutor{
+		Backend: backend,
+		State:   txState,
+		Tx:      tx,
+	}
+	if err := tx.Unsigned.Visit(&executor); err != nil {
+		return nil, err
+	}
+
+	simulation := &TxSimulation{
+		Imported: make(map[ids.ID][]*lux.UTXO),
+		Produced: tx.UTXOs(),
+		Exported: make(map[ids.ID][]*lux.UTXO),
+	}
+	importedIDs := set.Set[ids.ID]{}
+	for chainID, requests := range executor.AtomicRequests {
+		if len(requests.RemoveRequests) > 0 {
+			utxosBytes, err := backend.Ctx.SharedMemory.Get(chainID, requests.RemoveRequests)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get shared memory: %w", err)
+			}
+			utxos, err := parseUTXOs(utxosBytes)
+			if err != nil {
+				return nil, err
+			}
+			for _, utxo := range utxos {
+				importedIDs.Add(utxo.InputID())
+			}
+			simulation.Imported[chainID] = utxos
+		}
+		if len(requests.PutRequests) > 0 {
+			utxosBytes := make([][]byte, len(requests.PutRequests))
+			for i, elem := range requests.PutRequests {
+				utxosBytes[i] = elem.Value
+			}
+			utxos, err := parseUTXOs(utxosBytes)
+			if err != nil {
+				return nil, err
+			}
+			simulation.Exported[chainID] = utxos
+		}
+	}
+
+	inputIDs := tx.Unsigned.InputIDs()
+	inputIDs.Difference(importedIDs)
+	consumedIDs := inputIDs.List()
+	utils.Sort(consumedIDs)
+	simulation.Consumed = make([]*lux.UTXO, len(consumedIDs))
+	for i, utxoID := range consumedIDs {
+		simulation.Consumed[i], err = baseState.GetUTXO(utxoID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get UTXO %s: %w", utxoID, err)
+		}
+	}
+
+	if err := simulation.setStakerChanges(baseState, txState); err != nil {
+		return nil, err
+	}
+	return simulation, simulation.setBurned(tx.Unsigned)
+}
+
+func (s *TxSimulation) setStakerChanges(before state.Chain, after state.Chain) error {
+	var err error
+	s.AddedCurrentStakers, s.RemovedCurrentStakers, err = diffStakers(
+		before.GetCurrentStakerIterator,
+		after.GetCurrentStakerIterator,
+	)
+	if err != nil {
+		return err
+	}
+	s.AddedPendingStakers, s.RemovedPendingStakers, err = diffStakers(
+		before.GetPendingStakerIterator,
+		after.GetPendingStakerIterator,
+	)
+	return err
+}
+
+// diffStakers returns the stakers that are only returned by [after] and the
+// stakers that are only returned by [before].
+func diffStakers(
+	before func() (state.StakerIterator, error),
+	after func() (state.StakerIterator, error),
+) ([]*state.Staker, []*state.Staker, error) {
+	beforeStakers, err := collectStakers(before)
+	if err != nil {
+		return nil, nil, err
+	}
+	afterStakers, err := collectStakers(after)
+	if err != nil {
+		return nil, nil, err
+	}
+	return subtractStakers(afterStakers, beforeStakers), subtractStakers(beforeStakers, afterStakers), nil
+}
+
+func collectStakers(newIterator func() (state.StakerIterator, error)) ([]*state.Staker, error) {
+	it, err := newIterator()
+	if err != nil {
+		return nil, err
+	}
+	defer it.Release()
+
+	var stakers []*state.Staker
+	for it.Next() {
+		stakers = append(stakers, it.Value())
+	}
+	return stakers, nil
+}
+
+// subtractStakers returns the stakers in [a] that aren't in [b].
+func subtractStakers(a, b []*state.Staker) []*state.Staker {
+	txIDs := set.NewSet[ids.ID](len(b))
+	for _, staker := range b {
+		txIDs.Add(staker.TxID)
+	}
+	var stakers []*state.Staker
+	for _, staker := range a {
+		if !txIDs.Contains(staker.TxID) {
+			stakers = append(stakers, staker)
+		}
+	}
+	return stakers
+}
+
+func (s *TxSimulation) setBurned(utx txs.UnsignedTx) error {
+	consumed := make(map[ids.ID]uint64)
+	if err := addUTXOAmounts(consumed, s.Consumed); err != nil {
+		return err
+	}
+	for _, utxos := range s.Imported {
+		if err := addUTXOAmounts(consumed, utxos); err != nil {
+			return err
+		}
+	}
+
+	produced := make(map[ids.ID]uint64)
+	if err := addUTXOAmounts(produced, s.Produced); err != nil {
+		return err
+	}
+	for _, utxos := range s.Exported {
+		if err := addUTXOAmounts(produced, utxos); err != nil {
+			return err
+		}
+	}
+	if staker, ok := utx.(txs.PermissionlessStaker); ok {
+		for _, out := range staker.Stake() {
+			if err := addAmount(produced, out.AssetID(), out.Output().Amount()); err != nil {
+				return err
+			}
+		}
+	}
+
+	s.Burned = make(map[ids.ID]uint64)
+	for assetID, consumedAmount := range consumed {
+		burned, err := math.Sub(consumedAmount, produced[assetID])
+		if err != nil {
+			return err
+		}
+		if burned > 0 {
+			s.Burned[assetID] = burned
+		}
+	}
+	return nil
+}
+
+func addUTXOAmounts(amounts map[ids.ID]uint64, utxos []*lux.UTXO) error {
+	for _, utxo := range utxos {
+		out, ok := utxo.Out.(lux.Amounter)
+		if !ok {
+			continue
+		}
+		if err := addAmount(amounts, utxo.AssetID(), out.Amount()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addAmount(amounts map[ids.ID]uint64, assetID ids.ID, amount uint64) error {
+	newAmount, err := math.Add64(amounts[assetID], amount)
+	if err != nil {
+		return err
+	}
+	amounts[assetID] = newAmount
+	return nil
+}
+
+func parseUTXOs(utxosBytes [][]byte) ([]*lux.UTXO, error) {
+	utxos := make([]*lux.UTXO, len(utxosBytes))
+	for i, utxoBytes := range utxosBytes {
+		utxo := &lux.UTXO{}
+		if _, err := txs.Codec.Unmarshal(utxoBytes, utxo); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal UTXO: %w", err)
+		}
+		utxos[i] = utxo
+	}
+	return utxos, nil
+}
+
+// simulationVersions returns [state] as the state of every block.
+type simulationVersions struct {
+	state state.Chain
+}
+
+func (v simulationVersions) GetState(ids.ID) (state.Chain, bool) {
+	return v.state, true
+}
+
+// NewUnsignedTx returns a tx that carries [utx] along with credentials that
+// hold the number of signatures that [utx] requires, all of them empty. The
+// returned tx can only pass verification with a Backend whose Fx doesn't
+// verify signatures, and its ID changes once it's signed.
+func NewUnsignedTx(utx txs.UnsignedTx) (*txs.Tx, error) {
+	visitor := &emptyCredentialsVisitor{}
+	if err := utx.Visit(visitor); err != nil {
+		return nil, err
+	}
+	tx := &txs.Tx{
+		Unsigned: utx,
+		Creds:    visitor.creds,
+	}
+	return tx, tx.Initialize(txs.Codec)
+}
+
+// emptyCredentialsVisitor creates a credential, with empty signatures, for
+// every input and subnet authorization of a tx. The credentials are ordered
+// the same way that the wallet signs them.
+type emptyCredentialsVisitor struct {
+	creds []verify.Verifiable
+}
+
+func (*emptyCredentialsVisitor) AdvanceTimeTx(*txs.AdvanceTimeTx) error {
+	return ErrWrongTxType
+}
+
+func (*emptyCredentialsVisitor) RewardValidatorTx(*txs.RewardValidatorTx) error {
+	return ErrWrongTxType
+}
+
+func (v *emptyCredentialsVisitor) AddValidatorTx(tx *txs.AddValidatorTx) error {
+	return v.addInputs(tx.Ins)
+}
+
+func (v *emptyCredentialsVisitor) AddSubnetValidatorTx(tx *txs.AddSubnetValidatorTx) error {
+	return v.addSubnetInputs(tx.Ins, tx.SubnetAuth)
+}
+
+func (v *emptyCredentialsVisitor) AddDelegatorTx(tx *txs.AddDelegatorTx) error {
+	return v.addInputs(tx.Ins)
+}
+
+func (v *emptyCredentialsVisitor) CreateChainTx(tx *txs.CreateChainTx) error {
+	return v.addSubnetInputs(tx.Ins, tx.SubnetAuth)
+}
+
+func (v *emptyCredentialsVisitor) CreateSubnetTx(tx *txs.CreateSubnetTx) error {
+	return v.addInputs(tx.Ins)
+}
+
+func (v *emptyCredentialsVisitor) ImportTx(tx *txs.ImportTx) error {
+	if err := v.addInputs(tx.Ins); err != nil {
+		return err
+	}
+	return v.addInputs(tx.ImportedInputs)
+}
+
+func (v *emptyCredentialsVisitor) ExportTx(tx *txs.ExportTx) error {
+	return v.addInputs(tx.Ins)
+}
+
+func (v *emptyCredentialsVisitor) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidatorTx) error {
+	return v.addSubnetInputs(tx.Ins, tx.SubnetAuth)
+}
+
+func (v *emptyCredentialsVisitor) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
+	return v.addSubnetInputs(tx.Ins, tx.SubnetAuth)
+}
+
+func (v *emptyCredentialsVisitor) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessValidatorTx) error {
+	return v.addInputs(tx.Ins)
+}
+
+func (v *emptyCredentialsVisitor) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
+	return v.addInputs(tx.Ins)
+}
+
+func (v *emptyCredentialsVisitor) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershipTx) error {
+	return v.addSubnetInputs(tx.Ins, tx.SubnetAuth)
+}
+
+func (v *emptyCredentialsVisitor) BaseTx(tx *txs.BaseTx) error {
+	return v.addInputs(tx.Ins)
+}
+
+func (v *emptyCredentialsVisitor) AddContinuousValidatorTx(tx *txs.AddContinuousValidatorTx) error {
+	return v.addInputs(tx.Ins)
+}
+
+func (v *emptyCredentialsVisitor) StopContinuousValidatorTx(tx *txs.StopContinuousValidatorTx) error {
+	return v.addSubnetInputs(tx.Ins, tx.StakerAuth)
+}
+
+func (v *emptyCredentialsVisitor) ConvertSubnetTx(tx *txs.ConvertSubnetTx) error {
+	return v.addSubnetInputs(tx.Ins, tx.SubnetAuth)
+}
+
+func (v *emptyCredentialsVisitor) SetSubnetValidatorWeightTx(tx *txs.SetSubnetValidatorWeightTx) error {
+	return v.addInputs(tx.Ins)
+}
+
+func (v *emptyCredentialsVisitor) AddPooledDelegatorTx(tx *txs.AddPooledDelegatorTx) error {
+	return v.addInputs(tx.Ins)
+}
+
+func (v *emptyCredentialsVisitor) addSubnetInputs(ins []*lux.TransferableInput, subnetAuth verify.Verifiable) error {
+	if err := v.addInputs(ins); err != nil {
+		return err
+	}
+	return v.addInput(subnetAuth)
+}
+
+func (v *emptyCredentialsVisitor) addInputs(ins []*lux.TransferableInput) error {
+	for _, in := range ins {
+		if err := v.addInput(in.In); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *emptyCredentialsVisitor) addInput(in interface{}) error {
+	switch in := in.(type) {
+	case *stakeable.LockIn:
+		return v.addInput(in.TransferableIn)
+	case *secp256k1fx.TransferInput:
+		return v.addInput(&in.Input)
+	case *secp256k1fx.Input:
+		v.creds = append(v.creds, &secp256k1fx.Credential{
+			Sigs: make([][secp256k1.SignatureLen]byte, len(in.SigIndices)),
+		})
+		return nil
+	default:
+		return fmt.Errorf("%w: %T", errUnknownInputType, in)
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/vms/components/lux"
+	"github.com/luxdefi/node/vms/components/verify"
+	"github.com/luxdefi/node/vms/platformvm/stakeable"
+	"github.com/luxdefi/node/vms/platformvm/txs"
+	"github.com/luxdefi/node/vms/secp256k1fx"
+)
+
+func TestNewUnsignedTx(t *testing.T) {
+	require := require.New(t)
+
+	utx := &txs.CreateChainTx{
+		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+			Ins: []*lux.TransferableInput{
+				{
+					UTXOID: lux.UTXOID{TxID: ids.GenerateTestID()},
+					Asset:  lux.Asset{ID: ids.GenerateTestID()},
+					In: &secp256k1fx.TransferInput{
+						Amt:   1,
+						Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+					},
+				},
+				{
+					UTXOID: lux.UTXOID{TxID: ids.GenerateTestID()},
+					Asset:  lux.Asset{ID: ids.GenerateTestID()},
+					In: &stakeable.LockIn{
+						Locktime: 1,
+						TransferableIn: &secp256k1fx.TransferInput{
+							Amt:   1,
+							Input: secp256k1fx.Input{SigIndices: []uint32{0, 1}},
+						},
+					},
+				},
+			},
+		}},
+		SubnetID:   ids.GenerateTestID(),
+		SubnetAuth: &secp256k1fx.Input{SigIndices: []uint32{0, 1, 2}},
+	}
+
+	tx, err := NewUnsignedTx(utx)
+	require.NoError(err)
+	require.NotEqual(ids.Empty, tx.ID())
+	require.Len(tx.Creds, 3)
+	for i, numSigs := range []int{1, 2, 3} {
+		require.IsType(&secp256k1fx.Credential{}, tx.Creds[i])
+		require.Len(tx.Creds[i].(*secp256k1fx.Credential).Sigs, numSigs)
+	}
+
+	_, err = NewUnsignedTx(&txs.AdvanceTimeTx{})
+	require.ErrorIs(err, ErrWrongTxType)
+
+	utx.SubnetAuth = (*unknownInput)(nil)
+	_, err = NewUnsignedTx(utx)
+	require.ErrorIs(err, errUnknownInputType)
+}
+
+type unknownInput struct {
+	verify.Verifiable
+}
@@ -0,0 +1,138 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/luxdefi/node/database"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/snow/validators"
+	"github.com/luxdefi/node/utils/constants"
+	"github.com/luxdefi/node/vms/platformvm/state"
+	"github.com/luxdefi/node/vms/platformvm/txs"
+	"github.com/luxdefi/node/vms/platformvm/warp"
+
+	safemath "github.com/luxdefi/node/utils/math"
+)
+
+const (
+	// WarpQuorumNumerator and WarpQuorumDenominator are the portion of the
+	// stake of the source subnet that must have signed a Warp message for it
+	// to be accepted by the P-chain.
+	WarpQuorumNumerator   = 67
+	WarpQuorumDenominator = 100
+)
+
+var (
+	_ validators.State = (*chainValidatorState)(nil)
+
+	errNotBlockchain = errors.New("not a blockchain")
+)
+
+// verifyWarpMessage verifies that [msg] was signed by the current validators
+// of its source chain in [chainState].
+func verifyWarpMessage(backend *Backend, chainState state.Chain, msg *warp.Message) error {
+	return msg.Signature.Verify(
+		context.TODO(),
+		&msg.UnsignedMessage,
+		backend.Ctx.NetworkID,
+		chainValidatorState{chainState: chainState},
+		0, // The height is ignored by chainValidatorState
+		WarpQuorumNumerator,
+		WarpQuorumDenominator,
+	)
+}
+
+// chainValidatorState exposes the current validators of [chainState] as a
+// validators.State. Using it rather than the validator sets at a P-chain
+// height makes the verification of Warp messages deterministic, as it only
+// depends on the state the tx is executed on.
+type chainValidatorState struct {
+	chainState state.Chain
+}
+
+func (chainValidatorState) GetMinimumHeight(context.Context) (uint64, error) {
+	return 0, nil
+}
+
+func (chainValidatorState) GetCurrentHeight(context.Context) (uint64, error) {
+	return 0, nil
+}
+
+func (s chainValidatorState) GetSubnetID(_ context.Context, chainID ids.ID) (ids.ID, error) {
+	if chainID == constants.PlatformChainID {
+		return constants.PrimaryNetworkID, nil
+	}
+
+	chainTx, _, err := s.chainState.GetTx(chainID)
+	if err != nil {
+		return ids.Empty, fmt.Errorf("problem retrieving blockchain %q: %w", chainID, err)
+	}
+	chain, ok := chainTx.Unsigned.(*txs.CreateChainTx)
+	if !ok {
+		return ids.Empty, fmt.Errorf("%q is %w, but is %T", chainID, errNotBlockchain, chainTx.Unsigned)
+	}
+	return chain.SubnetID, nil
+}
+
+// GetValidatorSet returns the current validators of [subnetID], ignoring
+// [height]. The public key of a validator is the public key it registered on
+// the primary network.
+func (s chainValidatorState) GetValidatorSet(
+	_ context.Context,
+	_ uint64,
+	subnetID ids.ID,
+) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	subnetValidators, err := s.chainState.GetCurrentValidators(subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	vdrs := make(map[ids.NodeID]*validators.GetValidatorOutput, len(subnetValidators))
+	for _, validator := range subnetValidators {
+		weight, err := s.getWeight(validator)
+		if err != nil {
+			return nil, err
+		}
+		vdrs[validator.NodeID] = &validators.GetValidatorOutput{
+			NodeID: validator.NodeID,
+			Weight: weight,
+		}
+	}
+
+	for nodeID, vdr := range vdrs {
+		primaryNetworkValidator, err := s.chainState.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+		switch err {
+		case nil:
+			vdr.PublicKey = primaryNetworkValidator.PublicKey
+		case database.ErrNotFound:
+			// The validator can't sign Warp messages.
+		default:
+			return nil, fmt.Errorf("failed to fetch the primary network validator for %s: %w", nodeID, err)
+		}
+	}
+	return vdrs, nil
+}
+
+// getWeight returns the weight of [validator] including the weight of its
+// current delegators.
+func (s chainValidatorState) getWeight(validator *state.Staker) (uint64, error) {
+	delegatorIterator, err := s.chainState.GetCurrentDelegatorIterator(validator.SubnetID, validator.NodeID)
+	if err != nil {
+		return 0, err
+	}
+	defer delegatorIterator.Release()
+
+	weight := validator.Weight
+	for delegatorIterator.Next() {
+		weight, err = safemath.Add64(weight, delegatorIterator.Value().Weight)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %w", warp.ErrWeightOverflow, err)
+		}
+	}
+	return weight, nil
+}
@@ -0,0 +1,161 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fee
+
+import (
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/math"
+	"github.com/luxdefi/node/vms/components/lux"
+	"github.com/luxdefi/node/vms/platformvm/txs"
+)
+
+var _ txs.Visitor = (*flowCollector)(nil)
+
+// Burned returns the amount of [assetID] that [utx] consumes without
+// producing, which is the fee paid by [utx] when [assetID] is the fee asset.
+func Burned(utx txs.UnsignedTx, assetID ids.ID) (uint64, error) {
+	collector := flowCollector{}
+	if err := utx.Visit(&collector); err != nil {
+		return 0, err
+	}
+
+	var (
+		consumed uint64
+		produced uint64
+		err      error
+	)
+	for _, in := range collector.ins {
+		if in.AssetID() != assetID {
+			continue
+		}
+		consumed, err = math.Add64(consumed, in.Input().Amount())
+		if err != nil {
+			return 0, err
+		}
+	}
+	for _, out := range collector.outs {
+		if out.AssetID() != assetID {
+			continue
+		}
+		produced, err = math.Add64(produced, out.Output().Amount())
+		if err != nil {
+			return 0, err
+		}
+	}
+	return math.Sub(consumed, produced)
+}
+
+// flowCollector collects the inputs consumed and the outputs produced by a tx,
+// including the imported inputs, the exported outputs and the staked outputs.
+type flowCollector struct {
+	ins  []*lux.TransferableInput
+	outs []*lux.TransferableOutput
+}
+
+func (c *flowCollector) AddValidatorTx(tx *txs.AddValidatorTx) error {
+	c.baseTx(&tx.BaseTx)
+	c.outs = append(c.outs, tx.StakeOuts...)
+	return nil
+}
+
+func (c *flowCollector) AddSubnetValidatorTx(tx *txs.AddSubnetValidatorTx) error {
+	c.baseTx(&tx.BaseTx)
+	return nil
+}
+
+func (c *flowCollector) AddDelegatorTx(tx *txs.AddDelegatorTx) error {
+	c.baseTx(&tx.BaseTx)
+	c.outs = append(c.outs, tx.StakeOuts...)
+	return nil
+}
+
+func (c *flowCollector) CreateChainTx(tx *txs.CreateChainTx) error {
+	c.baseTx(&tx.BaseTx)
+	return nil
+}
+
+func (c *flowCollector) CreateSubnetTx(tx *txs.CreateSubnetTx) error {
+	c.baseTx(&tx.BaseTx)
+	return nil
+}
+
+func (c *flowCollector) ImportTx(tx *txs.ImportTx) error {
+	c.baseTx(&tx.BaseTx)
+	c.ins = append(c.ins, tx.ImportedInputs...)
+	return nil
+}
+
+func (c *flowCollector) ExportTx(tx *txs.ExportTx) error {
+	c.baseTx(&tx.BaseTx)
+	c.outs = append(c.outs, tx.ExportedOutputs...)
+	return nil
+}
+
+func (*flowCollector) AdvanceTimeTx(*txs.AdvanceTimeTx) error {
+	return nil
+}
+
+func (*flowCollector) RewardValidatorTx(*txs.RewardValidatorTx) error {
+	return nil
+}
+
+func (c *flowCollector) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidatorTx) error {
+	c.baseTx(&tx.BaseTx)
+	return nil
+}
+
+func (c *flowCollector) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
+	c.baseTx(&tx.BaseTx)
+	return nil
+}
+
+func (c *flowCollector) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessValidatorTx) error {
+	c.baseTx(&tx.BaseTx)
+	c.outs = append(c.outs, tx.StakeOuts...)
+	return nil
+}
+
+func (c *flowCollector) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
+	c.baseTx(&tx.BaseTx)
+	c.outs = append(c.outs, tx.StakeOuts...)
+	return nil
+}
+
+func (c *flowCollector) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershipTx) error {
+	c.baseTx(&tx.BaseTx)
+	return nil
+}
+
+func (c *flowCollector) BaseTx(tx *txs.BaseTx) error {
+	c.baseTx(tx)
+	return nil
+}
+
+func (c *flowCollector) AddContinuousValidatorTx(tx *txs.AddContinuousValidatorTx) error {
+	return c.AddPermissionlessValidatorTx(&tx.AddPermissionlessValidatorTx)
+}
+
+func (c *flowCollector) StopContinuousValidatorTx(tx *txs.StopContinuousValidatorTx) error {
+	c.baseTx(&tx.BaseTx)
+	return nil
+}
+
+func (c *flowCollector) ConvertSubnetTx(tx *txs.ConvertSubnetTx) error {
+	c.baseTx(&tx.BaseTx)
+	return nil
+}
+
+func (c *flowCollector) SetSubnetValidatorWeightTx(tx *txs.SetSubnetValidatorWeightTx) error {
+	c.baseTx(&tx.BaseTx)
+	return nil
+}
+
+func (c *flowCollector) AddPooledDelegatorTx(tx *txs.AddPooledDelegatorTx) error {
+	return c.AddPermissionlessDelegatorTx(&tx.AddPermissionlessDelegatorTx)
+}
+
+func (c *flowCollector) baseTx(tx *txs.BaseTx) {
+	c.ins = append(c.ins, tx.Ins...)
+	c.outs = append(c.outs, tx.Outs...)
+}
@@ -0,0 +1,109 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fee
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/math"
+	"github.com/luxdefi/node/vms/components/lux"
+	"github.com/luxdefi/node/vms/platformvm/txs"
+	"github.com/luxdefi/node/vms/secp256k1fx"
+)
+
+func TestBurned(t *testing.T) {
+	feeAssetID := ids.GenerateTestID()
+	otherAssetID := ids.GenerateTestID()
+	newInput := func(assetID ids.ID, amount uint64) *lux.TransferableInput {
+		return &lux.TransferableInput{
+			UTXOID: lux.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  lux.Asset{ID: assetID},
+			In:     &secp256k1fx.TransferInput{Amt: amount},
+		}
+	}
+	newOutput := func(assetID ids.ID, amount uint64) *lux.TransferableOutput {
+		return &lux.TransferableOutput{
+			Asset: lux.Asset{ID: assetID},
+			Out:   &secp256k1fx.TransferOutput{Amt: amount},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		utx            txs.UnsignedTx
+		expectedBurned uint64
+		expectedErr    error
+	}{
+		{
+			name: "base tx",
+			utx: &txs.BaseTx{BaseTx: lux.BaseTx{
+				Ins: []*lux.TransferableInput{
+					newInput(feeAssetID, 10),
+					newInput(otherAssetID, 100),
+				},
+				Outs: []*lux.TransferableOutput{
+					newOutput(feeAssetID, 7),
+					newOutput(otherAssetID, 50),
+				},
+			}},
+			expectedBurned: 3,
+		},
+		{
+			name: "import tx",
+			utx: &txs.ImportTx{
+				BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+					Outs: []*lux.TransferableOutput{newOutput(feeAssetID, 7)},
+				}},
+				ImportedInputs: []*lux.TransferableInput{newInput(feeAssetID, 10)},
+			},
+			expectedBurned: 3,
+		},
+		{
+			name: "export tx",
+			utx: &txs.ExportTx{
+				BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+					Ins: []*lux.TransferableInput{newInput(feeAssetID, 10)},
+				}},
+				ExportedOutputs: []*lux.TransferableOutput{newOutput(feeAssetID, 6)},
+			},
+			expectedBurned: 4,
+		},
+		{
+			name: "staked outputs aren't burned",
+			utx: &txs.AddPermissionlessDelegatorTx{
+				BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+					Ins:  []*lux.TransferableInput{newInput(feeAssetID, 10)},
+					Outs: []*lux.TransferableOutput{newOutput(feeAssetID, 1)},
+				}},
+				StakeOuts: []*lux.TransferableOutput{newOutput(feeAssetID, 8)},
+			},
+			expectedBurned: 1,
+		},
+		{
+			name:           "reward validator tx",
+			utx:            &txs.RewardValidatorTx{},
+			expectedBurned: 0,
+		},
+		{
+			name: "produces more than consumed",
+			utx: &txs.BaseTx{BaseTx: lux.BaseTx{
+				Ins:  []*lux.TransferableInput{newInput(feeAssetID, 1)},
+				Outs: []*lux.TransferableOutput{newOutput(feeAssetID, 2)},
+			}},
+			expectedErr: math.ErrUnderflow,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			burned, err := Burned(test.utx, feeAssetID)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedBurned, burned)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fee
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/luxdefi/node/vms/platformvm/signer"
+	"github.com/luxdefi/node/vms/platformvm/txs"
+	"github.com/luxdefi/node/vms/secp256k1fx"
+)
+
+var errUnknownCredentialType = errors.New("unknown credential type")
+
+// TxComplexity returns the resources consumed by [tx].
+//
+// Invariant: [tx] has been initialized.
+func TxComplexity(tx *txs.Tx) (Dimensions, error) {
+	complexity := Dimensions{
+		Bandwidth:    uint64(len(tx.Bytes())),
+		UTXOsRead:    uint64(tx.Unsigned.InputIDs().Len()),
+		UTXOsWritten: uint64(len(tx.Unsigned.Outputs())),
+	}
+
+	for _, cred := range tx.Creds {
+		secpCred, ok := cred.(*secp256k1fx.Credential)
+		if !ok {
+			return Dimensions{}, fmt.Errorf("%w: %T", errUnknownCredentialType, cred)
+		}
+		complexity.Signatures += uint64(len(secpCred.Sigs))
+	}
+
+	switch utx := tx.Unsigned.(type) {
+	case *txs.ExportTx:
+		complexity.UTXOsWritten += uint64(len(utx.ExportedOutputs))
+	case *txs.AddPermissionlessValidatorTx:
+		if _, ok := utx.Signer.(*signer.ProofOfPossession); ok {
+			complexity.Signatures++
+		}
+	}
+
+	// Staked outputs are written back to the UTXO set once the staker leaves
+	// the validator set.
+	if staker, ok := tx.Unsigned.(txs.PermissionlessStaker); ok {
+		complexity.UTXOsWritten += uint64(len(staker.Stake()))
+	}
+	return complexity, nil
+}
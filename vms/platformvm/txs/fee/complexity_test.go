@@ -0,0 +1,68 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fee
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/crypto/secp256k1"
+	"github.com/luxdefi/node/vms/components/lux"
+	"github.com/luxdefi/node/vms/platformvm/txs"
+	"github.com/luxdefi/node/vms/secp256k1fx"
+)
+
+func TestTxComplexity(t *testing.T) {
+	require := require.New(t)
+
+	keys := secp256k1.TestKeys()
+	assetID := ids.GenerateTestID()
+	newInput := func(sigIndices ...uint32) *lux.TransferableInput {
+		return &lux.TransferableInput{
+			UTXOID: lux.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  lux.Asset{ID: assetID},
+			In: &secp256k1fx.TransferInput{
+				Amt:   1,
+				Input: secp256k1fx.Input{SigIndices: sigIndices},
+			},
+		}
+	}
+	newOutput := func() *lux.TransferableOutput {
+		return &lux.TransferableOutput{
+			Asset: lux.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 1,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+				},
+			},
+		}
+	}
+
+	utx := &txs.ExportTx{
+		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+			Ins:  []*lux.TransferableInput{newInput(0), newInput(0, 1)},
+			Outs: []*lux.TransferableOutput{newOutput()},
+		}},
+		DestinationChain: ids.GenerateTestID(),
+		ExportedOutputs:  []*lux.TransferableOutput{newOutput(), newOutput()},
+	}
+	tx, err := txs.NewSigned(utx, txs.Codec, [][]*secp256k1.PrivateKey{
+		{keys[0]},
+		{keys[0], keys[1]},
+	})
+	require.NoError(err)
+
+	complexity, err := TxComplexity(tx)
+	require.NoError(err)
+	require.Equal(Dimensions{
+		Bandwidth:    uint64(len(tx.Bytes())),
+		Signatures:   3,
+		UTXOsRead:    2,
+		UTXOsWritten: 3,
+	}, complexity)
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fee
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/luxdefi/node/utils/math"
+)
+
+var (
+	errNoTargetGas          = errors.New("target gas per block must be non-zero")
+	errNoChangeDenominator  = errors.New("gas price change denominator must be non-zero")
+	errInvalidGasPriceRange = errors.New("min gas price must be non-zero and less than or equal to the max gas price")
+)
+
+// DynamicConfig describes a gas based fee model. Every transaction is charged
+// [Weights] gas per unit of complexity, and the price of gas moves with the
+// amount of gas consumed by recently accepted blocks.
+type DynamicConfig struct {
+	// Weights is the amount of gas charged per unit of each dimension
+	Weights Dimensions `json:"weights"`
+
+	// MinGasPrice is the lowest price, in nLUX, that can be charged per unit
+	// of gas
+	MinGasPrice uint64 `json:"minGasPrice"`
+
+	// MaxGasPrice is the highest price, in nLUX, that can be charged per unit
+	// of gas
+	MaxGasPrice uint64 `json:"maxGasPrice"`
+
+	// TargetGasPerBlock is the amount of gas a block can consume without
+	// changing the gas price. Blocks consuming more gas increase the price and
+	// blocks consuming less gas decrease it.
+	TargetGasPerBlock uint64 `json:"targetGasPerBlock"`
+
+	// GasPriceChangeDenominator bounds the relative change of the gas price
+	// between two blocks. A block consuming twice [TargetGasPerBlock] raises the
+	// price by 1/[GasPriceChangeDenominator].
+	GasPriceChangeDenominator uint64 `json:"gasPriceChangeDenominator"`
+}
+
+func (c *DynamicConfig) Verify() error {
+	switch {
+	case c.TargetGasPerBlock == 0:
+		return errNoTargetGas
+	case c.GasPriceChangeDenominator == 0:
+		return errNoChangeDenominator
+	case c.MinGasPrice == 0 || c.MinGasPrice > c.MaxGasPrice:
+		return errInvalidGasPriceRange
+	default:
+		return nil
+	}
+}
+
+// GasPrice returns the price that is charged when the last accepted gas price
+// is [gasPrice]. A zero [gasPrice] means that no block has been priced yet.
+func (c *DynamicConfig) GasPrice(gasPrice uint64) uint64 {
+	return math.Min(math.Max(gasPrice, c.MinGasPrice), c.MaxGasPrice)
+}
+
+// Fee returns the fee, in nLUX, of a transaction with the provided complexity
+// when gas costs [gasPrice].
+func (c *DynamicConfig) Fee(complexity Dimensions, gasPrice uint64) (uint64, error) {
+	gas, err := complexity.Gas(c.Weights)
+	if err != nil {
+		return 0, err
+	}
+	return math.Mul64(gas, c.GasPrice(gasPrice))
+}
+
+// NextGasPrice returns the gas price of the block following a block that
+// consumed [gasUsed] gas while charging [gasPrice].
+func (c *DynamicConfig) NextGasPrice(gasPrice, gasUsed uint64) uint64 {
+	gasPrice = c.GasPrice(gasPrice)
+	if gasUsed == c.TargetGasPerBlock {
+		return gasPrice
+	}
+
+	// delta = gasPrice * |gasUsed - target| / target / denominator
+	delta := new(big.Int).SetUint64(gasPrice)
+	delta.Mul(delta, new(big.Int).SetUint64(math.AbsDiff(gasUsed, c.TargetGasPerBlock)))
+	delta.Div(delta, new(big.Int).SetUint64(c.TargetGasPerBlock))
+	delta.Div(delta, new(big.Int).SetUint64(c.GasPriceChangeDenominator))
+
+	if gasUsed < c.TargetGasPerBlock {
+		// [delta] is at most [gasPrice] / [GasPriceChangeDenominator], so this
+		// can't underflow.
+		return c.GasPrice(gasPrice - delta.Uint64())
+	}
+
+	// Make sure the price always increases when the target is exceeded, even
+	// if the price is too small for the relative increase to be visible.
+	if delta.Sign() == 0 {
+		delta.SetUint64(1)
+	}
+	nextGasPrice := delta.Add(delta, new(big.Int).SetUint64(gasPrice))
+	if !nextGasPrice.IsUint64() {
+		return c.MaxGasPrice
+	}
+	return c.GasPrice(nextGasPrice.Uint64())
+}
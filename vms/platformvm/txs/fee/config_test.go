@@ -0,0 +1,164 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fee
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testConfig = DynamicConfig{
+	Weights: Dimensions{
+		Bandwidth:    1,
+		Signatures:   1_000,
+		UTXOsRead:    1_000,
+		UTXOsWritten: 1_000,
+	},
+	MinGasPrice:               10,
+	MaxGasPrice:               1_000,
+	TargetGasPerBlock:         100_000,
+	GasPriceChangeDenominator: 8,
+}
+
+func TestDynamicConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      func() DynamicConfig
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			config: func() DynamicConfig {
+				return testConfig
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "no target gas",
+			config: func() DynamicConfig {
+				c := testConfig
+				c.TargetGasPerBlock = 0
+				return c
+			},
+			expectedErr: errNoTargetGas,
+		},
+		{
+			name: "no change denominator",
+			config: func() DynamicConfig {
+				c := testConfig
+				c.GasPriceChangeDenominator = 0
+				return c
+			},
+			expectedErr: errNoChangeDenominator,
+		},
+		{
+			name: "zero min gas price",
+			config: func() DynamicConfig {
+				c := testConfig
+				c.MinGasPrice = 0
+				return c
+			},
+			expectedErr: errInvalidGasPriceRange,
+		},
+		{
+			name: "min gas price above max",
+			config: func() DynamicConfig {
+				c := testConfig
+				c.MinGasPrice = c.MaxGasPrice + 1
+				return c
+			},
+			expectedErr: errInvalidGasPriceRange,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := test.config()
+			require.ErrorIs(t, config.Verify(), test.expectedErr)
+		})
+	}
+}
+
+func TestDynamicConfigFee(t *testing.T) {
+	require := require.New(t)
+
+	complexity := Dimensions{
+		Bandwidth:    500,
+		Signatures:   2,
+		UTXOsRead:    2,
+		UTXOsWritten: 2,
+	}
+
+	// An unset gas price is charged the min gas price.
+	fee, err := testConfig.Fee(complexity, 0)
+	require.NoError(err)
+	require.Equal(uint64(6_500*10), fee)
+
+	fee, err = testConfig.Fee(complexity, 100)
+	require.NoError(err)
+	require.Equal(uint64(6_500*100), fee)
+
+	// The gas price is capped.
+	fee, err = testConfig.Fee(complexity, math.MaxUint64)
+	require.NoError(err)
+	require.Equal(uint64(6_500*1_000), fee)
+}
+
+func TestDynamicConfigNextGasPrice(t *testing.T) {
+	tests := []struct {
+		name             string
+		gasPrice         uint64
+		gasUsed          uint64
+		expectedGasPrice uint64
+	}{
+		{
+			name:             "unset price at target",
+			gasPrice:         0,
+			gasUsed:          100_000,
+			expectedGasPrice: 10,
+		},
+		{
+			name:             "at target",
+			gasPrice:         500,
+			gasUsed:          100_000,
+			expectedGasPrice: 500,
+		},
+		{
+			name:             "twice the target",
+			gasPrice:         400,
+			gasUsed:          200_000,
+			expectedGasPrice: 450,
+		},
+		{
+			name:             "slightly above target",
+			gasPrice:         10,
+			gasUsed:          100_001,
+			expectedGasPrice: 11,
+		},
+		{
+			name:             "empty block",
+			gasPrice:         400,
+			gasUsed:          0,
+			expectedGasPrice: 350,
+		},
+		{
+			name:             "empty block at min price",
+			gasPrice:         10,
+			gasUsed:          0,
+			expectedGasPrice: 10,
+		},
+		{
+			name:             "capped at max price",
+			gasPrice:         1_000,
+			gasUsed:          math.MaxUint64,
+			expectedGasPrice: 1_000,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expectedGasPrice, testConfig.NextGasPrice(test.gasPrice, test.gasUsed))
+		})
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fee
+
+import "github.com/luxdefi/node/utils/math"
+
+// Dimensions describes the resources a transaction consumes. When used as
+// weights, each field is the amount of gas charged per unit of the resource.
+type Dimensions struct {
+	// Bandwidth is the size, in bytes, of the signed transaction
+	Bandwidth uint64 `json:"bandwidth"`
+	// Signatures is the number of signatures that must be verified
+	Signatures uint64 `json:"signatures"`
+	// UTXOsRead is the number of UTXOs the transaction consumes
+	UTXOsRead uint64 `json:"utxosRead"`
+	// UTXOsWritten is the number of UTXOs the transaction produces
+	UTXOsWritten uint64 `json:"utxosWritten"`
+}
+
+// Add returns the sum of [d] and [o].
+func (d Dimensions) Add(o Dimensions) (Dimensions, error) {
+	var (
+		sum Dimensions
+		err error
+	)
+	if sum.Bandwidth, err = math.Add64(d.Bandwidth, o.Bandwidth); err != nil {
+		return Dimensions{}, err
+	}
+	if sum.Signatures, err = math.Add64(d.Signatures, o.Signatures); err != nil {
+		return Dimensions{}, err
+	}
+	if sum.UTXOsRead, err = math.Add64(d.UTXOsRead, o.UTXOsRead); err != nil {
+		return Dimensions{}, err
+	}
+	if sum.UTXOsWritten, err = math.Add64(d.UTXOsWritten, o.UTXOsWritten); err != nil {
+		return Dimensions{}, err
+	}
+	return sum, nil
+}
+
+// Gas returns the amount of gas consumed by [d] when every dimension is
+// charged according to [weights].
+func (d Dimensions) Gas(weights Dimensions) (uint64, error) {
+	var gas uint64
+	for _, pair := range [][2]uint64{
+		{d.Bandwidth, weights.Bandwidth},
+		{d.Signatures, weights.Signatures},
+		{d.UTXOsRead, weights.UTXOsRead},
+		{d.UTXOsWritten, weights.UTXOsWritten},
+	} {
+		dimensionGas, err := math.Mul64(pair[0], pair[1])
+		if err != nil {
+			return 0, err
+		}
+		gas, err = math.Add64(gas, dimensionGas)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return gas, nil
+}
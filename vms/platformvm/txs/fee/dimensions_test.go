@@ -0,0 +1,102 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fee
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	safemath "github.com/luxdefi/node/utils/math"
+)
+
+func TestDimensionsAdd(t *testing.T) {
+	require := require.New(t)
+
+	sum, err := Dimensions{
+		Bandwidth:    1,
+		Signatures:   2,
+		UTXOsRead:    3,
+		UTXOsWritten: 4,
+	}.Add(Dimensions{
+		Bandwidth:    10,
+		Signatures:   20,
+		UTXOsRead:    30,
+		UTXOsWritten: 40,
+	})
+	require.NoError(err)
+	require.Equal(Dimensions{
+		Bandwidth:    11,
+		Signatures:   22,
+		UTXOsRead:    33,
+		UTXOsWritten: 44,
+	}, sum)
+
+	_, err = Dimensions{UTXOsWritten: math.MaxUint64}.Add(Dimensions{UTXOsWritten: 1})
+	require.ErrorIs(err, safemath.ErrOverflow)
+}
+
+func TestDimensionsGas(t *testing.T) {
+	tests := []struct {
+		name        string
+		complexity  Dimensions
+		weights     Dimensions
+		expectedGas uint64
+		expectedErr error
+	}{
+		{
+			name: "no weights",
+			complexity: Dimensions{
+				Bandwidth:    100,
+				Signatures:   2,
+				UTXOsRead:    2,
+				UTXOsWritten: 3,
+			},
+			weights:     Dimensions{},
+			expectedGas: 0,
+		},
+		{
+			name: "weighted",
+			complexity: Dimensions{
+				Bandwidth:    100,
+				Signatures:   2,
+				UTXOsRead:    2,
+				UTXOsWritten: 3,
+			},
+			weights: Dimensions{
+				Bandwidth:    1,
+				Signatures:   1_000,
+				UTXOsRead:    100,
+				UTXOsWritten: 10,
+			},
+			expectedGas: 100 + 2_000 + 200 + 30,
+		},
+		{
+			name:        "multiplication overflow",
+			complexity:  Dimensions{Bandwidth: math.MaxUint64},
+			weights:     Dimensions{Bandwidth: 2},
+			expectedErr: safemath.ErrOverflow,
+		},
+		{
+			name: "addition overflow",
+			complexity: Dimensions{
+				Bandwidth:  math.MaxUint64,
+				Signatures: 1,
+			},
+			weights: Dimensions{
+				Bandwidth:  1,
+				Signatures: 1,
+			},
+			expectedErr: safemath.ErrOverflow,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gas, err := test.complexity.Gas(test.weights)
+			require.ErrorIs(t, err, test.expectedErr)
+			require.Equal(t, test.expectedGas, gas)
+		})
+	}
+}
@@ -6,6 +6,7 @@ package mempool
 import (
 	"errors"
 	"fmt"
+	"math/bits"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -13,10 +14,17 @@ import (
 	"github.com/luxdefi/node/cache"
 	"github.com/luxdefi/node/ids"
 	"github.com/luxdefi/node/snow/engine/common"
+	"github.com/luxdefi/node/utils/crypto/secp256k1"
+	"github.com/luxdefi/node/utils/hashing"
+	"github.com/luxdefi/node/utils/heap"
 	"github.com/luxdefi/node/utils/linkedhashmap"
+	"github.com/luxdefi/node/utils/math"
 	"github.com/luxdefi/node/utils/set"
+	"github.com/luxdefi/node/utils/timer/mockable"
 	"github.com/luxdefi/node/utils/units"
 	"github.com/luxdefi/node/vms/platformvm/txs"
+	"github.com/luxdefi/node/vms/platformvm/txs/fee"
+	"github.com/luxdefi/node/vms/secp256k1fx"
 )
 
 const (
@@ -24,13 +32,23 @@ const (
 	// allowed into the mempool.
 	MaxTxSize = 64 * units.KiB
 
-	// droppedTxIDsCacheSize is the maximum number of dropped txIDs to cache
-	droppedTxIDsCacheSize = 64
+	// droppedTxsSize is the maximum number of dropped txs to remember
+	droppedTxsSize = 1024
 
 	initialConsumedUTXOsSize = 512
 
 	// maxMempoolSize is the maximum number of bytes allowed in the mempool
 	maxMempoolSize = 64 * units.MiB
+
+	// maxTxsPerSender is the maximum number of txs a single sender can have in
+	// the mempool
+	maxTxsPerSender = 64
+
+	// minReplacementFeeBump is the minimum percentage by which a tx must
+	// increase the fees paid by the txs it conflicts with to replace them
+	minReplacementFeeBump = 10
+
+	senderCacheSize = 2048
 )
 
 var (
@@ -40,6 +58,8 @@ var (
 	errTxTooLarge                 = errors.New("tx too large")
 	errMempoolFull                = errors.New("mempool is full")
 	errConflictsWithOtherTx       = errors.New("tx conflicts with other tx")
+	errSenderLimitReached         = errors.New("sender has too many txs in the mempool")
+	errReplacedByFee              = errors.New("replaced by a tx paying a higher fee")
 	errCantIssueAdvanceTimeTx     = errors.New("can not issue an advance time tx")
 	errCantIssueRewardValidatorTx = errors.New("can not issue a reward validator tx")
 )
@@ -60,7 +80,9 @@ type Mempool interface {
 	// HasTxs allow to check for availability of any mempool transaction.
 	HasTxs() bool
 	// PeekTxs returns the next txs for Banff blocks
-	// up to maxTxsBytes without removing them from the mempool.
+	// up to maxTxsBytes without removing them from the mempool. Txs paying a
+	// higher fee per byte are returned first, txs paying the same fee per byte
+	// are returned in the order they were added.
 	PeekTxs(maxTxsBytes int) []*txs.Tx
 
 	// Drops all [txs.Staker] transactions whose [StartTime] is before
@@ -76,11 +98,53 @@ type Mempool interface {
 	// the mempool.
 	RequestBuildBlock(emptyBlockPermitted bool)
 
-	// Note: dropped txs are added to droppedTxIDs but are not evicted from
+	// Note: dropped txs are added to droppedTxs but are not evicted from
 	// unissued decision/staker txs. This allows previously dropped txs to be
 	// possibly reissued.
 	MarkDropped(txID ids.ID, reason error)
 	GetDropReason(txID ids.ID) error
+
+	// Iterate calls [f] with every tx in the mempool, and the time it was
+	// added, in the order they were added. Iteration stops early if [f]
+	// returns false.
+	Iterate(f func(tx *txs.Tx, addedTime time.Time) bool)
+	// DroppedTxs returns the most recently dropped txs, from the oldest to
+	// the newest.
+	DroppedTxs() []DroppedTx
+}
+
+// Listener is notified of the changes made to the mempool.
+type Listener interface {
+	// Added is called once [tx] is added to the mempool.
+	Added(tx *txs.Tx)
+	// Removed is called once [tx] is removed from the mempool.
+	Removed(tx *txs.Tx)
+	// Dropped is called once [txID] is marked as dropped. [tx] is nil if the
+	// tx isn't in the mempool.
+	Dropped(txID ids.ID, tx *txs.Tx, reason error)
+}
+
+// DroppedTx is a tx that was marked as dropped.
+type DroppedTx struct {
+	TxID   ids.ID
+	Reason error
+	// Time the tx was last marked as dropped
+	Time time.Time
+}
+
+// mempoolTx is a tx in the mempool along with the metadata used to prioritize
+// it.
+type mempoolTx struct {
+	tx        *txs.Tx
+	size      uint64
+	fee       uint64
+	addedTime time.Time
+	// sequence orders the txs paying the same fee per byte by the order they
+	// were added in
+	sequence uint64
+
+	sender    ids.ShortID
+	hasSender bool
 }
 
 // Transactions from clients that have not yet been put into blocks and added to
@@ -92,22 +156,41 @@ type mempool struct {
 	bytesAvailableMetric prometheus.Gauge
 	bytesAvailable       int
 
-	unissuedTxs linkedhashmap.LinkedHashmap[ids.ID, *txs.Tx]
-	numTxs      prometheus.Gauge
+	unissuedTxs linkedhashmap.LinkedHashmap[ids.ID, *mempoolTx]
+	// Orders the unissued txs by decreasing fee per byte
+	byFeeRate    heap.Map[ids.ID, *mempoolTx]
+	nextSequence uint64
+	numTxs       prometheus.Gauge
 
 	// Key: Tx ID
-	// Value: Verification error
-	droppedTxIDs *cache.LRU[ids.ID, error]
+	// Value: Dropped tx, which is moved to the end once it is dropped again
+	droppedTxs linkedhashmap.LinkedHashmap[ids.ID, DroppedTx]
 
-	consumedUTXOs set.Set[ids.ID]
+	// Key: UTXO ID
+	// Value: ID of the tx consuming the UTXO
+	consumedUTXOs map[ids.ID]ids.ID
+
+	// Key: Sender address
+	// Value: Number of txs in the mempool sent by the address
+	senderTxs   map[ids.ShortID]int
+	senderCache secp256k1.RecoverCache
+
+	// Asset the fees are paid in
+	luxAssetID ids.ID
 
 	toEngine chan<- common.Message
+	// May be nil
+	listener Listener
+
+	clock mockable.Clock
 }
 
 func New(
 	namespace string,
 	registerer prometheus.Registerer,
 	toEngine chan<- common.Message,
+	luxAssetID ids.ID,
+	listener Listener,
 ) (Mempool, error) {
 	bytesAvailableMetric := prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: namespace,
@@ -132,13 +215,22 @@ func New(
 		bytesAvailableMetric: bytesAvailableMetric,
 		bytesAvailable:       maxMempoolSize,
 
-		unissuedTxs: linkedhashmap.New[ids.ID, *txs.Tx](),
+		unissuedTxs: linkedhashmap.New[ids.ID, *mempoolTx](),
+		byFeeRate:   heap.NewMap[ids.ID, *mempoolTx](hasHigherPriority),
 		numTxs:      numTxs,
 
-		droppedTxIDs:  &cache.LRU[ids.ID, error]{Size: droppedTxIDsCacheSize},
-		consumedUTXOs: set.NewSet[ids.ID](initialConsumedUTXOsSize),
-		dropIncoming:  false, // enable tx adding by default
-		toEngine:      toEngine,
+		droppedTxs:    linkedhashmap.New[ids.ID, DroppedTx](),
+		consumedUTXOs: make(map[ids.ID]ids.ID, initialConsumedUTXOsSize),
+		senderTxs:     make(map[ids.ShortID]int),
+		senderCache: secp256k1.RecoverCache{
+			LRU: cache.LRU[ids.ID, *secp256k1.PublicKey]{
+				Size: senderCacheSize,
+			},
+		},
+		luxAssetID:   luxAssetID,
+		dropIncoming: false, // enable tx adding by default
+		toEngine:     toEngine,
+		listener:     listener,
 	}, nil
 }
 
@@ -150,6 +242,9 @@ func (m *mempool) DisableAdding() {
 	m.dropIncoming = true
 }
 
+// Add adds [tx] to the mempool. If [tx] conflicts with txs already in the
+// mempool, it replaces them only if it pays at least [minReplacementFeeBump]
+// percent more fees than all of them combined.
 func (m *mempool) Add(tx *txs.Tx) error {
 	if m.dropIncoming {
 		return fmt.Errorf("tx %s not added because mempool is closed", tx.ID())
@@ -178,31 +273,96 @@ func (m *mempool) Add(tx *txs.Tx) error {
 			MaxTxSize,
 		)
 	}
-	if txSize > m.bytesAvailable {
+
+	txFee, err := fee.Burned(tx.Unsigned, m.luxAssetID)
+	if err != nil {
+		return fmt.Errorf("failed to calculate the fee of %s: %w", txID, err)
+	}
+
+	inputs := tx.Unsigned.InputIDs()
+	conflicts := m.conflictingTxs(inputs)
+
+	// The space used by the replaced txs is released.
+	bytesAvailable := m.bytesAvailable
+	for _, conflict := range conflicts {
+		bytesAvailable += int(conflict.size)
+	}
+	if txSize > bytesAvailable {
 		return fmt.Errorf("%w: %s size (%d) > available space (%d)",
 			errMempoolFull,
 			txID,
 			txSize,
-			m.bytesAvailable,
+			bytesAvailable,
 		)
 	}
 
-	inputs := tx.Unsigned.InputIDs()
-	if m.consumedUTXOs.Overlaps(inputs) {
-		return fmt.Errorf("%w: %s", errConflictsWithOtherTx, txID)
+	if len(conflicts) > 0 {
+		replacementFee, err := minReplacementFee(conflicts)
+		if err != nil || txFee < replacementFee {
+			return fmt.Errorf("%w: %s fee (%d) < replacement fee (%d)",
+				errConflictsWithOtherTx,
+				txID,
+				txFee,
+				replacementFee,
+			)
+		}
+	}
+
+	sender, hasSender := m.sender(tx)
+	if hasSender {
+		// The replaced txs no longer count against the sender's limit.
+		numSenderTxs := m.senderTxs[sender]
+		for _, conflict := range conflicts {
+			if conflict.hasSender && conflict.sender == sender {
+				numSenderTxs--
+			}
+		}
+		if numSenderTxs >= maxTxsPerSender {
+			return fmt.Errorf("%w: %s has %d txs",
+				errSenderLimitReached,
+				sender,
+				numSenderTxs,
+			)
+		}
 	}
 
-	m.unissuedTxs.Put(tx.ID(), tx)
+	replacedReason := fmt.Errorf("%w %s", errReplacedByFee, txID)
+	for _, conflict := range conflicts {
+		m.MarkDropped(conflict.tx.ID(), replacedReason)
+		m.Remove([]*txs.Tx{conflict.tx})
+	}
+
+	entry := &mempoolTx{
+		tx:        tx,
+		size:      uint64(txSize),
+		fee:       txFee,
+		addedTime: m.clock.Time(),
+		sequence:  m.nextSequence,
+		sender:    sender,
+		hasSender: hasSender,
+	}
+	m.nextSequence++
+
+	m.unissuedTxs.Put(txID, entry)
+	m.byFeeRate.Push(txID, entry)
 	m.numTxs.Inc()
 	m.bytesAvailable -= txSize
 	m.bytesAvailableMetric.Set(float64(m.bytesAvailable))
 
 	// Mark these UTXOs as consumed in the mempool
-	m.consumedUTXOs.Union(inputs)
+	for utxoID := range inputs {
+		m.consumedUTXOs[utxoID] = txID
+	}
+	if hasSender {
+		m.senderTxs[sender]++
+	}
 
 	// An explicitly added tx must not be marked as dropped.
-	m.droppedTxIDs.Evict(txID)
+	m.droppedTxs.Delete(txID)
 
+	if m.listener != nil {
+		m.listener.Added(tx)
+	}
 	return nil
 }
 
@@ -211,23 +371,40 @@ func (m *mempool) Has(txID ids.ID) bool {
 }
 
 func (m *mempool) Get(txID ids.ID) *txs.Tx {
-	tx, _ := m.unissuedTxs.Get(txID)
-	return tx
+	entry, ok := m.unissuedTxs.Get(txID)
+	if !ok {
+		return nil
+	}
+	return entry.tx
 }
 
 func (m *mempool) Remove(txsToRemove []*txs.Tx) {
 	for _, tx := range txsToRemove {
 		txID := tx.ID()
-		if !m.unissuedTxs.Delete(txID) {
+		entry, ok := m.unissuedTxs.Get(txID)
+		if !ok {
 			continue
 		}
+		m.unissuedTxs.Delete(txID)
+		m.byFeeRate.Remove(txID)
 		m.numTxs.Dec()
 
-		m.bytesAvailable += len(tx.Bytes())
+		m.bytesAvailable += int(entry.size)
 		m.bytesAvailableMetric.Set(float64(m.bytesAvailable))
 
-		inputs := tx.Unsigned.InputIDs()
-		m.consumedUTXOs.Difference(inputs)
+		for utxoID := range tx.Unsigned.InputIDs() {
+			delete(m.consumedUTXOs, utxoID)
+		}
+		if entry.hasSender {
+			m.senderTxs[entry.sender]--
+			if m.senderTxs[entry.sender] == 0 {
+				delete(m.senderTxs, entry.sender)
+			}
+		}
+
+		if m.listener != nil {
+			m.listener.Removed(tx)
+		}
 	}
 }
 
@@ -236,27 +413,69 @@ func (m *mempool) HasTxs() bool {
 }
 
 func (m *mempool) PeekTxs(maxTxsBytes int) []*txs.Tx {
-	var txs []*txs.Tx
-	txIter := m.unissuedTxs.NewIterator()
-	size := 0
-	for txIter.Next() {
-		tx := txIter.Value()
-		size += len(tx.Bytes())
+	var (
+		txs    []*txs.Tx
+		popped []*mempoolTx
+		size   int
+	)
+	for m.byFeeRate.Len() > 0 {
+		_, entry, _ := m.byFeeRate.Peek()
+		size += int(entry.size)
 		if size > maxTxsBytes {
-			return txs
+			break
 		}
-		txs = append(txs, tx)
+		m.byFeeRate.Pop()
+		popped = append(popped, entry)
+		txs = append(txs, entry.tx)
+	}
+
+	// The peeked txs must remain in the mempool.
+	for _, entry := range popped {
+		m.byFeeRate.Push(entry.tx.ID(), entry)
 	}
 	return txs
 }
 
 func (m *mempool) MarkDropped(txID ids.ID, reason error) {
-	m.droppedTxIDs.Put(txID, reason)
+	// Re-inserting the tx moves it to the end of the dropped txs.
+	m.droppedTxs.Delete(txID)
+	m.droppedTxs.Put(txID, DroppedTx{
+		TxID:   txID,
+		Reason: reason,
+		Time:   m.clock.Time(),
+	})
+	if m.droppedTxs.Len() > droppedTxsSize {
+		oldestTxID, _, _ := m.droppedTxs.Oldest()
+		m.droppedTxs.Delete(oldestTxID)
+	}
+
+	if m.listener != nil {
+		m.listener.Dropped(txID, m.Get(txID), reason)
+	}
 }
 
 func (m *mempool) GetDropReason(txID ids.ID) error {
-	err, _ := m.droppedTxIDs.Get(txID)
-	return err
+	droppedTx, _ := m.droppedTxs.Get(txID)
+	return droppedTx.Reason
+}
+
+func (m *mempool) Iterate(f func(tx *txs.Tx, addedTime time.Time) bool) {
+	txIter := m.unissuedTxs.NewIterator()
+	for txIter.Next() {
+		entry := txIter.Value()
+		if !f(entry.tx, entry.addedTime) {
+			return
+		}
+	}
+}
+
+func (m *mempool) DroppedTxs() []DroppedTx {
+	droppedTxs := make([]DroppedTx, 0, m.droppedTxs.Len())
+	droppedIter := m.droppedTxs.NewIterator()
+	for droppedIter.Next() {
+		droppedTxs = append(droppedTxs, droppedIter.Value())
+	}
+	return droppedTxs
 }
 
 func (m *mempool) RequestBuildBlock(emptyBlockPermitted bool) {
@@ -279,7 +498,7 @@ func (m *mempool) DropExpiredStakerTxs(minStartTime time.Time) []ids.ID {
 
 	txIter := m.unissuedTxs.NewIterator()
 	for txIter.Next() {
-		tx := txIter.Value()
+		tx := txIter.Value().tx
 		stakerTx, ok := tx.Unsigned.(txs.Staker)
 		if !ok {
 			continue
@@ -304,3 +523,82 @@ func (m *mempool) DropExpiredStakerTxs(minStartTime time.Time) []ids.ID {
 
 	return droppedTxIDs
 }
+
+// conflictingTxs returns the txs in the mempool consuming any of [inputs].
+func (m *mempool) conflictingTxs(inputs set.Set[ids.ID]) []*mempoolTx {
+	var (
+		conflictIDs set.Set[ids.ID]
+		conflicts   []*mempoolTx
+	)
+	for utxoID := range inputs {
+		txID, ok := m.consumedUTXOs[utxoID]
+		if !ok || conflictIDs.Contains(txID) {
+			continue
+		}
+		conflictIDs.Add(txID)
+
+		conflict, _ := m.unissuedTxs.Get(txID)
+		conflicts = append(conflicts, conflict)
+	}
+	return conflicts
+}
+
+// sender returns the address that produced the first signature of [tx]. Txs
+// that aren't signed with a secp256k1fx credential have no sender.
+func (m *mempool) sender(tx *txs.Tx) (ids.ShortID, bool) {
+	if len(tx.Creds) == 0 {
+		return ids.ShortEmpty, false
+	}
+	cred, ok := tx.Creds[0].(*secp256k1fx.Credential)
+	if !ok || len(cred.Sigs) == 0 {
+		return ids.ShortEmpty, false
+	}
+
+	txHash := hashing.ComputeHash256(tx.Unsigned.Bytes())
+	pk, err := m.senderCache.RecoverPublicKeyFromHash(txHash, cred.Sigs[0][:])
+	if err != nil {
+		return ids.ShortEmpty, false
+	}
+	return pk.Address(), true
+}
+
+// minReplacementFee returns the minimum fee a tx must pay to replace
+// [conflicts]. The fee must be larger than the fees paid by [conflicts] by at
+// least [minReplacementFeeBump] percent, and by at least 1.
+func minReplacementFee(conflicts []*mempoolTx) (uint64, error) {
+	var (
+		conflictsFee uint64
+		err          error
+	)
+	for _, conflict := range conflicts {
+		conflictsFee, err = math.Add64(conflictsFee, conflict.fee)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	bumpedFee, err := math.Mul64(conflictsFee, 100+minReplacementFeeBump)
+	if err != nil {
+		return 0, err
+	}
+	bumpedFee /= 100
+	if bumpedFee > conflictsFee {
+		return bumpedFee, nil
+	}
+	return math.Add64(conflictsFee, 1)
+}
+
+// hasHigherPriority returns true if [a] pays a higher fee per byte than [b], or
+// if they pay the same fee per byte and [a] was added first.
+func hasHigherPriority(a, b *mempoolTx) bool {
+	// Compare a.fee/a.size to b.fee/b.size without losing precision.
+	aHi, aLo := bits.Mul64(a.fee, b.size)
+	bHi, bLo := bits.Mul64(b.fee, a.size)
+	if aHi != bHi {
+		return aHi > bHi
+	}
+	if aLo != bLo {
+		return aLo > bLo
+	}
+	return a.sequence < b.sequence
+}
@@ -28,7 +28,7 @@ func TestBlockBuilderMaxMempoolSizeHandling(t *testing.T) {
 	require := require.New(t)
 
 	registerer := prometheus.NewRegistry()
-	mpool, err := New("mempool", registerer, nil)
+	mpool, err := New("mempool", registerer, nil, ids.Empty, nil)
 	require.NoError(err)
 
 	decisionTxs, err := createTestDecisionTxs(1)
@@ -52,7 +52,7 @@ func TestDecisionTxsInMempool(t *testing.T) {
 	require := require.New(t)
 
 	registerer := prometheus.NewRegistry()
-	mpool, err := New("mempool", registerer, nil)
+	mpool, err := New("mempool", registerer, nil, ids.Empty, nil)
 	require.NoError(err)
 
 	decisionTxs, err := createTestDecisionTxs(2)
@@ -104,7 +104,7 @@ func TestProposalTxsInMempool(t *testing.T) {
 	require := require.New(t)
 
 	registerer := prometheus.NewRegistry()
-	mpool, err := New("mempool", registerer, nil)
+	mpool, err := New("mempool", registerer, nil, ids.Empty, nil)
 	require.NoError(err)
 
 	// The proposal txs are ordered by decreasing start time. This means after
@@ -237,7 +237,7 @@ func TestDropExpiredStakerTxs(t *testing.T) {
 	require := require.New(t)
 
 	registerer := prometheus.NewRegistry()
-	mempool, err := New("mempool", registerer, nil)
+	mempool, err := New("mempool", registerer, nil, ids.Empty, nil)
 	require.NoError(err)
 
 	tx1, err := generateAddValidatorTx(10, 20)
@@ -255,3 +255,310 @@ func TestDropExpiredStakerTxs(t *testing.T) {
 	minStartTime := time.Unix(9, 0)
 	require.Len(mempool.DropExpiredStakerTxs(minStartTime), 1)
 }
+
+type recordedEvent struct {
+	event  string
+	txID   ids.ID
+	hasTx  bool
+	reason error
+}
+
+type recordingListener struct {
+	events []recordedEvent
+}
+
+func (l *recordingListener) Added(tx *txs.Tx) {
+	l.events = append(l.events, recordedEvent{event: "added", txID: tx.ID(), hasTx: true})
+}
+
+func (l *recordingListener) Removed(tx *txs.Tx) {
+	l.events = append(l.events, recordedEvent{event: "removed", txID: tx.ID(), hasTx: true})
+}
+
+func (l *recordingListener) Dropped(txID ids.ID, tx *txs.Tx, reason error) {
+	l.events = append(l.events, recordedEvent{event: "dropped", txID: txID, hasTx: tx != nil, reason: reason})
+}
+
+func TestMempoolInspection(t *testing.T) {
+	require := require.New(t)
+
+	listener := &recordingListener{}
+	registerer := prometheus.NewRegistry()
+	mpool, err := New("mempool", registerer, nil, ids.Empty, listener)
+	require.NoError(err)
+
+	decisionTxs, err := createTestDecisionTxs(2)
+	require.NoError(err)
+	tx0, tx1 := decisionTxs[0], decisionTxs[1]
+
+	startTime := time.Unix(1000, 0)
+	mpool.(*mempool).clock.Set(startTime)
+	require.NoError(mpool.Add(tx0))
+	mpool.(*mempool).clock.Set(startTime.Add(time.Second))
+	require.NoError(mpool.Add(tx1))
+
+	var (
+		iteratedTxs []*txs.Tx
+		addedTimes  []time.Time
+	)
+	mpool.Iterate(func(tx *txs.Tx, addedTime time.Time) bool {
+		iteratedTxs = append(iteratedTxs, tx)
+		addedTimes = append(addedTimes, addedTime)
+		return true
+	})
+	require.Equal([]*txs.Tx{tx0, tx1}, iteratedTxs)
+	require.Equal([]time.Time{startTime, startTime.Add(time.Second)}, addedTimes)
+
+	// Iteration stops once false is returned.
+	iteratedTxs = nil
+	mpool.Iterate(func(tx *txs.Tx, _ time.Time) bool {
+		iteratedTxs = append(iteratedTxs, tx)
+		return false
+	})
+	require.Equal([]*txs.Tx{tx0}, iteratedTxs)
+
+	errTest := errors.New("non-nil error")
+	droppedTime := startTime.Add(2 * time.Second)
+	mpool.(*mempool).clock.Set(droppedTime)
+	mpool.MarkDropped(tx0.ID(), errTest)
+	mpool.Remove([]*txs.Tx{tx1})
+	mpool.MarkDropped(tx1.ID(), errTest)
+	require.Equal(
+		[]DroppedTx{
+			{TxID: tx0.ID(), Reason: errTest, Time: droppedTime},
+			{TxID: tx1.ID(), Reason: errTest, Time: droppedTime},
+		},
+		mpool.DroppedTxs(),
+	)
+
+	// Dropping a tx again moves it to the end.
+	mpool.MarkDropped(tx0.ID(), errTest)
+	droppedTxs := mpool.DroppedTxs()
+	require.Len(droppedTxs, 2)
+	require.Equal(tx0.ID(), droppedTxs[1].TxID)
+
+	require.Equal(
+		[]recordedEvent{
+			{event: "added", txID: tx0.ID(), hasTx: true},
+			{event: "added", txID: tx1.ID(), hasTx: true},
+			{event: "dropped", txID: tx0.ID(), hasTx: true, reason: errTest},
+			{event: "removed", txID: tx1.ID(), hasTx: true},
+			{event: "dropped", txID: tx1.ID(), hasTx: false, reason: errTest},
+			{event: "dropped", txID: tx0.ID(), hasTx: true, reason: errTest},
+		},
+		listener.events,
+	)
+}
+
+func TestMempoolDroppedTxsBounded(t *testing.T) {
+	require := require.New(t)
+
+	registerer := prometheus.NewRegistry()
+	mpool, err := New("mempool", registerer, nil, ids.Empty, nil)
+	require.NoError(err)
+
+	errTest := errors.New("non-nil error")
+	firstTxID := ids.GenerateTestID()
+	mpool.MarkDropped(firstTxID, errTest)
+	for i := 0; i < droppedTxsSize; i++ {
+		mpool.MarkDropped(ids.GenerateTestID(), errTest)
+	}
+
+	require.Len(mpool.DroppedTxs(), droppedTxsSize)
+	require.NoError(mpool.GetDropReason(firstTxID))
+}
+
+func newTestBaseTx(
+	luxAssetID ids.ID,
+	utxoID lux.UTXOID,
+	consumed uint64,
+	produced uint64,
+	key *secp256k1.PrivateKey,
+) (*txs.Tx, error) {
+	utx := &txs.BaseTx{BaseTx: lux.BaseTx{
+		NetworkID: 10,
+		Ins: []*lux.TransferableInput{{
+			UTXOID: utxoID,
+			Asset:  lux.Asset{ID: luxAssetID},
+			In: &secp256k1fx.TransferInput{
+				Amt:   consumed,
+				Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+			},
+		}},
+		Outs: []*lux.TransferableOutput{{
+			Asset: lux.Asset{ID: luxAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: produced,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{preFundedKeys[0].PublicKey().Address()},
+				},
+			},
+		}},
+	}}
+
+	var signers [][]*secp256k1.PrivateKey
+	if key != nil {
+		signers = [][]*secp256k1.PrivateKey{{key}}
+	}
+	return txs.NewSigned(utx, txs.Codec, signers)
+}
+
+func TestMempoolFeePriority(t *testing.T) {
+	require := require.New(t)
+
+	luxAssetID := ids.GenerateTestID()
+	registerer := prometheus.NewRegistry()
+	mpool, err := New("mempool", registerer, nil, luxAssetID, nil)
+	require.NoError(err)
+
+	newTx := func(fee uint64) *txs.Tx {
+		tx, err := newTestBaseTx(
+			luxAssetID,
+			lux.UTXOID{TxID: ids.GenerateTestID()},
+			1000,
+			1000-fee,
+			nil,
+		)
+		require.NoError(err)
+		return tx
+	}
+	lowFeeTx := newTx(1)
+	highFeeTx := newTx(10)
+	midFeeTx := newTx(5)
+	otherLowFeeTx := newTx(1)
+
+	require.NoError(mpool.Add(lowFeeTx))
+	require.NoError(mpool.Add(highFeeTx))
+	require.NoError(mpool.Add(midFeeTx))
+	require.NoError(mpool.Add(otherLowFeeTx))
+
+	// Txs paying the same fee are returned in the order they were added.
+	expectedTxs := []*txs.Tx{highFeeTx, midFeeTx, lowFeeTx, otherLowFeeTx}
+	require.Equal(expectedTxs, mpool.PeekTxs(math.MaxInt))
+
+	// Peeking doesn't remove the txs.
+	require.Equal(expectedTxs, mpool.PeekTxs(math.MaxInt))
+
+	txSize := len(highFeeTx.Bytes())
+	require.Equal([]*txs.Tx{highFeeTx, midFeeTx}, mpool.PeekTxs(2*txSize+1))
+
+	// Iteration is still in the order the txs were added.
+	var iteratedTxs []*txs.Tx
+	mpool.Iterate(func(tx *txs.Tx, _ time.Time) bool {
+		iteratedTxs = append(iteratedTxs, tx)
+		return true
+	})
+	require.Equal([]*txs.Tx{lowFeeTx, highFeeTx, midFeeTx, otherLowFeeTx}, iteratedTxs)
+
+	mpool.Remove([]*txs.Tx{highFeeTx})
+	require.Equal([]*txs.Tx{midFeeTx, lowFeeTx, otherLowFeeTx}, mpool.PeekTxs(math.MaxInt))
+}
+
+func TestMempoolReplaceByFee(t *testing.T) {
+	require := require.New(t)
+
+	luxAssetID := ids.GenerateTestID()
+	listener := &recordingListener{}
+	registerer := prometheus.NewRegistry()
+	mpool, err := New("mempool", registerer, nil, luxAssetID, listener)
+	require.NoError(err)
+
+	utxoID := lux.UTXOID{TxID: ids.GenerateTestID()}
+	newTx := func(fee uint64) *txs.Tx {
+		tx, err := newTestBaseTx(luxAssetID, utxoID, 1000, 1000-fee, preFundedKeys[0])
+		require.NoError(err)
+		return tx
+	}
+	originalTx := newTx(100)
+	require.NoError(mpool.Add(originalTx))
+	bytesAvailable := mpool.(*mempool).bytesAvailable
+
+	// The replacement must pay at least 10% more.
+	err = mpool.Add(newTx(109))
+	require.ErrorIs(err, errConflictsWithOtherTx)
+	require.True(mpool.Has(originalTx.ID()))
+
+	replacementTx := newTx(110)
+	require.NoError(mpool.Add(replacementTx))
+	require.False(mpool.Has(originalTx.ID()))
+	require.True(mpool.Has(replacementTx.ID()))
+	require.ErrorIs(mpool.GetDropReason(originalTx.ID()), errReplacedByFee)
+	require.Equal([]*txs.Tx{replacementTx}, mpool.PeekTxs(math.MaxInt))
+	require.Equal(bytesAvailable, mpool.(*mempool).bytesAvailable)
+	require.Equal(1, mpool.(*mempool).senderTxs[preFundedKeys[0].Address()])
+
+	require.Len(listener.events, 4)
+	require.Equal("dropped", listener.events[1].event)
+	require.Equal(originalTx.ID(), listener.events[1].txID)
+	require.Equal("removed", listener.events[2].event)
+	require.Equal(originalTx.ID(), listener.events[2].txID)
+	require.Equal("added", listener.events[3].event)
+	require.Equal(replacementTx.ID(), listener.events[3].txID)
+
+	mpool.Remove([]*txs.Tx{replacementTx})
+	require.Empty(mpool.(*mempool).consumedUTXOs)
+	require.Empty(mpool.(*mempool).senderTxs)
+}
+
+func TestMempoolReplaceFreeTx(t *testing.T) {
+	require := require.New(t)
+
+	luxAssetID := ids.GenerateTestID()
+	registerer := prometheus.NewRegistry()
+	mpool, err := New("mempool", registerer, nil, luxAssetID, nil)
+	require.NoError(err)
+
+	utxoID := lux.UTXOID{TxID: ids.GenerateTestID()}
+	freeTx, err := newTestBaseTx(luxAssetID, utxoID, 1000, 1000, nil)
+	require.NoError(err)
+	require.NoError(mpool.Add(freeTx))
+
+	otherFreeTx, err := newTestBaseTx(luxAssetID, utxoID, 2000, 2000, nil)
+	require.NoError(err)
+	err = mpool.Add(otherFreeTx)
+	require.ErrorIs(err, errConflictsWithOtherTx)
+
+	replacementTx, err := newTestBaseTx(luxAssetID, utxoID, 1000, 999, nil)
+	require.NoError(err)
+	require.NoError(mpool.Add(replacementTx))
+	require.False(mpool.Has(freeTx.ID()))
+}
+
+func TestMempoolSenderLimit(t *testing.T) {
+	require := require.New(t)
+
+	luxAssetID := ids.GenerateTestID()
+	registerer := prometheus.NewRegistry()
+	mpool, err := New("mempool", registerer, nil, luxAssetID, nil)
+	require.NoError(err)
+
+	newTx := func(utxoID lux.UTXOID, fee uint64, key *secp256k1.PrivateKey) *txs.Tx {
+		tx, err := newTestBaseTx(luxAssetID, utxoID, 1000, 1000-fee, key)
+		require.NoError(err)
+		return tx
+	}
+
+	sender := preFundedKeys[0]
+	senderTxs := make([]*txs.Tx, 0, maxTxsPerSender)
+	for i := 0; i < maxTxsPerSender; i++ {
+		tx := newTx(lux.UTXOID{TxID: ids.GenerateTestID()}, 1, sender)
+		require.NoError(mpool.Add(tx))
+		senderTxs = append(senderTxs, tx)
+	}
+
+	err = mpool.Add(newTx(lux.UTXOID{TxID: ids.GenerateTestID()}, 1, sender))
+	require.ErrorIs(err, errSenderLimitReached)
+
+	// Replacing one of the sender's txs doesn't increase its number of txs.
+	replacedUTXOID := senderTxs[0].Unsigned.(*txs.BaseTx).Ins[0].UTXOID
+	require.NoError(mpool.Add(newTx(replacedUTXOID, 2, sender)))
+
+	// Other senders, and unsigned txs, aren't limited.
+	require.NoError(mpool.Add(newTx(lux.UTXOID{TxID: ids.GenerateTestID()}, 1, preFundedKeys[1])))
+	require.NoError(mpool.Add(newTx(lux.UTXOID{TxID: ids.GenerateTestID()}, 1, nil)))
+
+	// Once a tx is removed, the sender can add another one.
+	mpool.Remove(senderTxs[1:2])
+	require.NoError(mpool.Add(newTx(lux.UTXOID{TxID: ids.GenerateTestID()}, 1, sender)))
+}
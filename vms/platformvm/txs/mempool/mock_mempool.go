@@ -79,6 +79,20 @@ func (mr *MockMempoolMockRecorder) DropExpiredStakerTxs(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropExpiredStakerTxs", reflect.TypeOf((*MockMempool)(nil).DropExpiredStakerTxs), arg0)
 }
 
+// DroppedTxs mocks base method.
+func (m *MockMempool) DroppedTxs() []DroppedTx {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DroppedTxs")
+	ret0, _ := ret[0].([]DroppedTx)
+	return ret0
+}
+
+// DroppedTxs indicates an expected call of DroppedTxs.
+func (mr *MockMempoolMockRecorder) DroppedTxs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DroppedTxs", reflect.TypeOf((*MockMempool)(nil).DroppedTxs))
+}
+
 // EnableAdding mocks base method.
 func (m *MockMempool) EnableAdding() {
 	m.ctrl.T.Helper()
@@ -147,6 +161,18 @@ func (mr *MockMempoolMockRecorder) HasTxs() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasTxs", reflect.TypeOf((*MockMempool)(nil).HasTxs))
 }
 
+// Iterate mocks base method.
+func (m *MockMempool) Iterate(arg0 func(*txs.Tx, time.Time) bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Iterate", arg0)
+}
+
+// Iterate indicates an expected call of Iterate.
+func (mr *MockMempoolMockRecorder) Iterate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Iterate", reflect.TypeOf((*MockMempool)(nil).Iterate), arg0)
+}
+
 // MarkDropped mocks base method.
 func (m *MockMempool) MarkDropped(arg0 ids.ID, arg1 error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,49 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+
+	"github.com/luxdefi/node/snow"
+)
+
+var (
+	_ UnsignedTx = (*SetSubnetValidatorWeightTx)(nil)
+
+	errEmptyWarpMessage = errors.New("warp message cannot be empty")
+)
+
+// SetSubnetValidatorWeightTx adds, modifies or removes a validator of a
+// converted subnet, as instructed by the validator manager of the subnet.
+type SetSubnetValidatorWeightTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// Warp message sent by the validator manager of the subnet. Its payload is
+	// an AddressedCall of a SubnetValidatorWeight message.
+	Message []byte `serialize:"true" json:"message"`
+}
+
+func (tx *SetSubnetValidatorWeightTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified:
+		// already passed syntactic verification
+		return nil
+	case len(tx.Message) == 0:
+		return errEmptyWarpMessage
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *SetSubnetValidatorWeightTx) Visit(visitor Visitor) error {
+	return visitor.SetSubnetValidatorWeightTx(tx)
+}
@@ -0,0 +1,55 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/snow"
+	"github.com/luxdefi/node/vms/components/verify"
+)
+
+var (
+	_ UnsignedTx = (*StopContinuousValidatorTx)(nil)
+
+	errEmptyTxID = errors.New("tx ID cannot be empty")
+)
+
+// StopContinuousValidatorTx stops renewing the staking period of a validator
+// added with an AddContinuousValidatorTx. The validator is removed at the end
+// of its current staking period.
+type StopContinuousValidatorTx struct {
+	BaseTx `serialize:"true"`
+	// ID of the AddContinuousValidatorTx that added the validator.
+	TxID ids.ID `serialize:"true" json:"txID"`
+	// Proves that the issuer is the validation rewards owner of the validator.
+	StakerAuth verify.Verifiable `serialize:"true" json:"stakerAuthorization"`
+}
+
+func (tx *StopContinuousValidatorTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified:
+		// already passed syntactic verification
+		return nil
+	case tx.TxID == ids.Empty:
+		return errEmptyTxID
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := tx.StakerAuth.Verify(); err != nil {
+		return err
+	}
+
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *StopContinuousValidatorTx) Visit(visitor Visitor) error {
+	return visitor.StopContinuousValidatorTx(tx)
+}
@@ -20,4 +20,9 @@ type Visitor interface {
 	AddPermissionlessDelegatorTx(*AddPermissionlessDelegatorTx) error
 	TransferSubnetOwnershipTx(*TransferSubnetOwnershipTx) error
 	BaseTx(*BaseTx) error
+	AddContinuousValidatorTx(*AddContinuousValidatorTx) error
+	StopContinuousValidatorTx(*StopContinuousValidatorTx) error
+	ConvertSubnetTx(*ConvertSubnetTx) error
+	SetSubnetValidatorWeightTx(*SetSubnetValidatorWeightTx) error
+	AddPooledDelegatorTx(*AddPooledDelegatorTx) error
 }
@@ -0,0 +1,42 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/constants"
+	"github.com/luxdefi/node/vms/platformvm/uptimeproof"
+)
+
+var (
+	_ uptimeproof.Backend = (*uptimeBackend)(nil)
+
+	errNotBootstrapped = errors.New("not bootstrapped")
+)
+
+// uptimeBackend provides the uptimes that this node attests to. Attestation
+// requests are handled without the context lock held.
+type uptimeBackend struct {
+	vm *VM
+}
+
+func (b uptimeBackend) GetUptime(nodeID ids.NodeID) (ids.ID, time.Duration, error) {
+	b.vm.ctx.Lock.Lock()
+	defer b.vm.ctx.Lock.Unlock()
+
+	// Uptimes aren't tracked until the chain is bootstrapped.
+	if !b.vm.bootstrapped.Get() {
+		return ids.Empty, 0, errNotBootstrapped
+	}
+
+	staker, err := b.vm.state.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	uptime, _, err := b.vm.uptimeManager.CalculateUptime(nodeID, constants.PrimaryNetworkID)
+	return staker.TxID, uptime, err
+}
@@ -0,0 +1,53 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxdefi/node/database"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/constants"
+	"github.com/luxdefi/node/version"
+)
+
+func TestUptimeBackend(t *testing.T) {
+	require := require.New(t)
+	vm, _, _ := defaultVM(t)
+	vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+		vm.ctx.Lock.Unlock()
+	}()
+
+	nodeID := genesisNodeIDs[0]
+	staker, err := vm.state.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+	require.NoError(err)
+
+	backend := uptimeBackend{vm: vm}
+	vm.ctx.Lock.Unlock()
+	_, initialUptime, err := backend.GetUptime(nodeID)
+	vm.ctx.Lock.Lock()
+	require.NoError(err)
+
+	require.NoError(vm.Connected(context.Background(), nodeID, version.CurrentApp))
+	vm.clock.Set(vm.clock.Time().Add(time.Minute))
+
+	// The backend acquires the context lock.
+	vm.ctx.Lock.Unlock()
+	txID, uptime, err := backend.GetUptime(nodeID)
+	vm.ctx.Lock.Lock()
+	require.NoError(err)
+	require.Equal(staker.TxID, txID)
+	require.Equal(initialUptime+time.Minute, uptime)
+
+	vm.ctx.Lock.Unlock()
+	_, _, err = backend.GetUptime(ids.GenerateTestNodeID())
+	vm.ctx.Lock.Lock()
+	require.ErrorIs(err, database.ErrNotFound)
+}
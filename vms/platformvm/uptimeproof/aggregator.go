@@ -0,0 +1,149 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package uptimeproof
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/network/p2p"
+	"github.com/luxdefi/node/utils/constants"
+	"github.com/luxdefi/node/utils/crypto/bls"
+	"github.com/luxdefi/node/utils/logging"
+	"github.com/luxdefi/node/utils/set"
+	"github.com/luxdefi/node/vms/platformvm/warp"
+)
+
+// Aggregator collects signatures of attestations from the primary network
+// validators.
+type Aggregator struct {
+	log       logging.Logger
+	networkID uint32
+	client    *p2p.Client
+	state     warp.ValidatorState
+}
+
+// NewAggregator returns an Aggregator that requests signatures using
+// [client], which must be registered with a Handler as the handler of its
+// protocol.
+func NewAggregator(
+	log logging.Logger,
+	networkID uint32,
+	client *p2p.Client,
+	state warp.ValidatorState,
+) *Aggregator {
+	return &Aggregator{
+		log:       log,
+		networkID: networkID,
+		client:    client,
+		state:     state,
+	}
+}
+
+type signatureResponse struct {
+	nodeID ids.NodeID
+	bytes  []byte
+	err    error
+}
+
+// Aggregate requests signatures of [attestation] from the primary network
+// validators at [pChainHeight] until validators holding at least
+// [quorumNum]/[quorumDen] of the stake have signed it, and returns the
+// attestation as a signed Warp message.
+func (a *Aggregator) Aggregate(
+	ctx context.Context,
+	attestation *Attestation,
+	pChainHeight uint64,
+	quorumNum uint64,
+	quorumDen uint64,
+) (*warp.Message, error) {
+	unsignedMsg, err := attestation.UnsignedMessage(a.networkID)
+	if err != nil {
+		return nil, err
+	}
+
+	vdrs, totalWeight, err := warp.GetCanonicalValidatorSet(ctx, a.state, pChainHeight, constants.PrimaryNetworkID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Node ID --> Index of the validator in [vdrs]
+	indices := make(map[ids.NodeID]int)
+	for i, vdr := range vdrs {
+		for _, nodeID := range vdr.NodeIDs {
+			indices[nodeID] = i
+		}
+	}
+
+	responses := make(chan signatureResponse, len(indices))
+	onResponse := func(_ context.Context, nodeID ids.NodeID, responseBytes []byte, err error) {
+		responses <- signatureResponse{
+			nodeID: nodeID,
+			bytes:  responseBytes,
+			err:    err,
+		}
+	}
+	nodeIDs := set.Set[ids.NodeID]{}
+	for nodeID := range indices {
+		nodeIDs.Add(nodeID)
+	}
+	if err := a.client.AppRequest(ctx, nodeIDs, attestation.Bytes(), onResponse); err != nil {
+		return nil, err
+	}
+
+	var (
+		signers    = set.NewBits()
+		signatures []*bls.Signature
+		sigWeight  uint64
+	)
+	for range indices {
+		var response signatureResponse
+		select {
+		case response = <-responses:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		index, ok := indices[response.nodeID]
+		if !ok || signers.Contains(index) || response.err != nil {
+			continue
+		}
+		vdr := vdrs[index]
+		signature, err := bls.SignatureFromBytes(response.bytes)
+		if err != nil || !bls.Verify(vdr.PublicKey, signature, unsignedMsg.Bytes()) {
+			a.log.Debug("dropping invalid uptime attestation signature",
+				zap.Stringer("nodeID", response.nodeID),
+			)
+			continue
+		}
+
+		signers.Add(index)
+		signatures = append(signatures, signature)
+		sigWeight += vdr.Weight // Can't overflow because [totalWeight] didn't
+		if warp.VerifyWeight(sigWeight, totalWeight, quorumNum, quorumDen) != nil {
+			continue
+		}
+
+		aggregateSignature, err := bls.AggregateSignatures(signatures)
+		if err != nil {
+			return nil, err
+		}
+		bitSetSignature := &warp.BitSetSignature{
+			Signers: signers.Bytes(),
+		}
+		copy(bitSetSignature.Signature[:], bls.SignatureToBytes(aggregateSignature))
+		return warp.NewMessage(unsignedMsg, bitSetSignature)
+	}
+	return nil, fmt.Errorf(
+		"%w: %d*%d > %d*%d",
+		warp.ErrInsufficientWeight,
+		quorumNum,
+		totalWeight,
+		quorumDen,
+		sigWeight,
+	)
+}
@@ -0,0 +1,181 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package uptimeproof
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/network/p2p"
+	"github.com/luxdefi/node/snow/engine/common"
+	"github.com/luxdefi/node/snow/validators"
+	"github.com/luxdefi/node/utils/constants"
+	"github.com/luxdefi/node/utils/crypto/bls"
+	"github.com/luxdefi/node/utils/logging"
+	"github.com/luxdefi/node/utils/set"
+	"github.com/luxdefi/node/vms/platformvm/warp"
+)
+
+const testHandlerID = 0
+
+// newTestValidators returns validators with equal weight, each of which
+// observed the uptime in [uptimes] at the same index, and the aggregator that
+// requests their signatures.
+func newTestValidators(t *testing.T, txID ids.ID, uptimes []time.Duration) (*Aggregator, validators.State) {
+	require := require.New(t)
+
+	var (
+		vdrs     = make(map[ids.NodeID]*validators.GetValidatorOutput)
+		handlers = make(map[ids.NodeID]*Handler)
+		sender   = &common.SenderTest{T: t}
+		network  = p2p.NewNetwork(logging.NoLog{}, sender, prometheus.NewRegistry(), "")
+	)
+	for _, uptime := range uptimes {
+		nodeID := ids.GenerateTestNodeID()
+		sk, err := bls.NewSecretKey()
+		require.NoError(err)
+		vdrs[nodeID] = &validators.GetValidatorOutput{
+			NodeID:    nodeID,
+			PublicKey: bls.PublicFromSecretKey(sk),
+			Weight:    1,
+		}
+		handlers[nodeID] = NewHandler(
+			logging.NoLog{},
+			constants.UnitTestID,
+			&testBackend{
+				txID:   txID,
+				uptime: uptime,
+			},
+			warp.NewSigner(sk, constants.UnitTestID, constants.PlatformChainID),
+		)
+	}
+
+	sender.SendAppRequestF = func(ctx context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, request []byte) error {
+		handlerID, n := binary.Uvarint(request)
+		require.Equal(uint64(testHandlerID), handlerID)
+		for nodeID := range nodeIDs {
+			nodeID := nodeID
+			go func() {
+				response, err := handlers[nodeID].AppRequest(ctx, ids.EmptyNodeID, time.Time{}, request[n:])
+				if err != nil {
+					// Refused requests time out.
+					require.NoError(network.AppRequestFailed(ctx, nodeID, requestID))
+					return
+				}
+				require.NoError(network.AppResponse(ctx, nodeID, requestID, response))
+			}()
+		}
+		return nil
+	}
+
+	state := &validators.TestState{
+		T: t,
+		GetSubnetIDF: func(context.Context, ids.ID) (ids.ID, error) {
+			return constants.PrimaryNetworkID, nil
+		},
+		GetValidatorSetF: func(_ context.Context, _ uint64, subnetID ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			require.Equal(constants.PrimaryNetworkID, subnetID)
+			return vdrs, nil
+		},
+	}
+	client, err := network.NewAppProtocol(testHandlerID, p2p.NoOpHandler{})
+	require.NoError(err)
+	return NewAggregator(logging.NoLog{}, constants.UnitTestID, client, state), state
+}
+
+func TestAggregate(t *testing.T) {
+	tests := []struct {
+		name            string
+		uptimes         []time.Duration
+		uptime          uint64
+		quorumNum       uint64
+		expectedErr     error
+		expectedSigners int
+	}{
+		{
+			name:            "all validators sign",
+			uptimes:         []time.Duration{time.Minute, time.Minute, time.Minute},
+			uptime:          60,
+			quorumNum:       100,
+			expectedSigners: 3,
+		},
+		{
+			name:            "stops once the quorum is reached",
+			uptimes:         []time.Duration{time.Minute, time.Minute, time.Minute, time.Minute},
+			uptime:          60,
+			quorumNum:       50,
+			expectedSigners: 2,
+		},
+		{
+			name:            "quorum despite disagreeing validator",
+			uptimes:         []time.Duration{time.Minute, time.Minute, time.Minute, time.Second},
+			uptime:          30,
+			quorumNum:       75,
+			expectedSigners: 3,
+		},
+		{
+			name:        "insufficient weight",
+			uptimes:     []time.Duration{time.Minute, time.Minute, time.Second, time.Second},
+			uptime:      30,
+			quorumNum:   67,
+			expectedErr: warp.ErrInsufficientWeight,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			txID := ids.GenerateTestID()
+			nodeID := ids.GenerateTestNodeID()
+			aggregator, state := newTestValidators(t, txID, test.uptimes)
+
+			attestation, err := NewAttestation(txID, nodeID, test.uptime)
+			require.NoError(err)
+
+			msg, err := aggregator.Aggregate(context.Background(), attestation, 0, test.quorumNum, 100)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+
+			numSigners, err := msg.Signature.NumSigners()
+			require.NoError(err)
+			require.Equal(test.expectedSigners, numSigners)
+
+			parsedMsg, err := warp.ParseMessage(msg.Bytes())
+			require.NoError(err)
+			verifiedAttestation, err := Verify(context.Background(), parsedMsg, constants.UnitTestID, state, 0, test.quorumNum, 100)
+			require.NoError(err)
+			require.Equal(attestation, verifiedAttestation)
+
+			_, err = Verify(context.Background(), parsedMsg, constants.UnitTestID, state, 0, 100, 100)
+			if test.expectedSigners < len(test.uptimes) {
+				require.ErrorIs(err, warp.ErrInsufficientWeight)
+			} else {
+				require.NoError(err)
+			}
+		})
+	}
+}
+
+func TestVerifyWrongSourceChainID(t *testing.T) {
+	require := require.New(t)
+
+	attestation, err := NewAttestation(ids.GenerateTestID(), ids.GenerateTestNodeID(), 1)
+	require.NoError(err)
+	unsignedMsg, err := warp.NewUnsignedMessage(constants.UnitTestID, ids.GenerateTestID(), attestation.Bytes())
+	require.NoError(err)
+	msg, err := warp.NewMessage(unsignedMsg, &warp.BitSetSignature{})
+	require.NoError(err)
+
+	_, err = Verify(context.Background(), msg, constants.UnitTestID, &validators.TestState{T: t}, 0, 67, 100)
+	require.ErrorIs(err, warp.ErrWrongSourceChainID)
+}
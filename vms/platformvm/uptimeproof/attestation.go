@@ -0,0 +1,70 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package uptimeproof
+
+import (
+	"fmt"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/constants"
+	"github.com/luxdefi/node/vms/platformvm/warp"
+)
+
+// Attestation claims that the primary network validator [NodeID], staking
+// with the transaction [TxID], has been online for at least [Uptime] seconds
+// of its current staking period.
+//
+// Validators sign an attestation only if their own view of the validator's
+// uptime is at least [Uptime], so an attestation signed by enough stake is an
+// objective lower bound on the validator's uptime.
+type Attestation struct {
+	TxID   ids.ID     `serialize:"true"`
+	NodeID ids.NodeID `serialize:"true"`
+	Uptime uint64     `serialize:"true"`
+
+	bytes []byte
+}
+
+// NewAttestation creates a new *Attestation and initializes it.
+func NewAttestation(txID ids.ID, nodeID ids.NodeID, uptime uint64) (*Attestation, error) {
+	a := &Attestation{
+		TxID:   txID,
+		NodeID: nodeID,
+		Uptime: uptime,
+	}
+	bytes, err := c.Marshal(codecVersion, a)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't marshal uptime attestation: %w", err)
+	}
+	a.bytes = bytes
+	return a, nil
+}
+
+// ParseAttestation converts a slice of bytes into an initialized
+// *Attestation.
+func ParseAttestation(bytes []byte) (*Attestation, error) {
+	a := &Attestation{}
+	version, err := c.Unmarshal(bytes, a)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't unmarshal uptime attestation: %w", err)
+	}
+	if version != codecVersion {
+		return nil, errWrongCodecVersion
+	}
+	a.bytes = bytes
+	return a, nil
+}
+
+// Bytes returns the binary representation of this attestation. It assumes that
+// the attestation is initialized from either NewAttestation or
+// ParseAttestation.
+func (a *Attestation) Bytes() []byte {
+	return a.bytes
+}
+
+// UnsignedMessage returns the Warp message that validators sign to attest to
+// [a] on the network [networkID].
+func (a *Attestation) UnsignedMessage(networkID uint32) (*warp.UnsignedMessage, error) {
+	return warp.NewUnsignedMessage(networkID, constants.PlatformChainID, a.bytes)
+}
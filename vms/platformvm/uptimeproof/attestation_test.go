@@ -0,0 +1,40 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package uptimeproof
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/constants"
+)
+
+func TestAttestation(t *testing.T) {
+	require := require.New(t)
+
+	txID := ids.GenerateTestID()
+	nodeID := ids.GenerateTestNodeID()
+	attestation, err := NewAttestation(txID, nodeID, 1337)
+	require.NoError(err)
+
+	parsedAttestation, err := ParseAttestation(attestation.Bytes())
+	require.NoError(err)
+	require.Equal(attestation, parsedAttestation)
+	require.Equal(txID, parsedAttestation.TxID)
+	require.Equal(nodeID, parsedAttestation.NodeID)
+	require.Equal(uint64(1337), parsedAttestation.Uptime)
+
+	msg, err := attestation.UnsignedMessage(constants.UnitTestID)
+	require.NoError(err)
+	require.Equal(constants.UnitTestID, msg.NetworkID)
+	require.Equal(constants.PlatformChainID, msg.SourceChainID)
+	require.Equal(attestation.Bytes(), msg.Payload)
+}
+
+func TestParseAttestationJunk(t *testing.T) {
+	_, err := ParseAttestation([]byte{0, 0, 1})
+	require.Error(t, err) //nolint:forbidigo // error is returned by the codec
+}
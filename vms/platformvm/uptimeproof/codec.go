@@ -0,0 +1,31 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package uptimeproof
+
+import (
+	"errors"
+
+	"github.com/luxdefi/node/codec"
+	"github.com/luxdefi/node/codec/linearcodec"
+	"github.com/luxdefi/node/utils/units"
+)
+
+const (
+	codecVersion = 0
+
+	maxMessageSize = units.KiB
+)
+
+var (
+	c codec.Manager
+
+	errWrongCodecVersion = errors.New("wrong codec version")
+)
+
+func init() {
+	c = codec.NewManager(maxMessageSize)
+	if err := c.RegisterCodec(codecVersion, linearcodec.NewDefault()); err != nil {
+		panic(err)
+	}
+}
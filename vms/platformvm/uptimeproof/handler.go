@@ -0,0 +1,100 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package uptimeproof
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/network/p2p"
+	"github.com/luxdefi/node/utils/logging"
+	"github.com/luxdefi/node/vms/platformvm/warp"
+)
+
+var (
+	_ p2p.Handler = (*Handler)(nil)
+
+	ErrWrongTxID     = errors.New("wrong staking tx ID")
+	ErrUptimeTooHigh = errors.New("claimed uptime is higher than observed uptime")
+)
+
+// Backend provides this node's view of the primary network validators.
+type Backend interface {
+	// GetUptime returns the ID of the transaction that added [nodeID] as a
+	// primary network validator and the amount of time [nodeID] has been
+	// observed online since it started validating.
+	GetUptime(nodeID ids.NodeID) (ids.ID, time.Duration, error)
+}
+
+// Handler signs the attestations that peers request if they are consistent
+// with this node's view of the uptimes of the validators.
+type Handler struct {
+	p2p.NoOpHandler
+
+	log       logging.Logger
+	networkID uint32
+	backend   Backend
+	signer    warp.Signer
+}
+
+func NewHandler(
+	log logging.Logger,
+	networkID uint32,
+	backend Backend,
+	signer warp.Signer,
+) *Handler {
+	return &Handler{
+		log:       log,
+		networkID: networkID,
+		backend:   backend,
+		signer:    signer,
+	}
+}
+
+func (h *Handler) AppRequest(_ context.Context, nodeID ids.NodeID, _ time.Time, requestBytes []byte) ([]byte, error) {
+	attestation, err := ParseAttestation(requestBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := h.Sign(attestation)
+	if err != nil {
+		h.log.Debug("refusing to sign uptime attestation",
+			zap.Stringer("requester", nodeID),
+			zap.Stringer("nodeID", attestation.NodeID),
+			zap.Stringer("txID", attestation.TxID),
+			zap.Uint64("uptime", attestation.Uptime),
+			zap.Error(err),
+		)
+	}
+	return signature, err
+}
+
+// Sign returns this node's BLS signature of [attestation] if [attestation]
+// refers to the current staking period of the validator and this node has
+// observed the validator to be online for at least the claimed uptime.
+func (h *Handler) Sign(attestation *Attestation) ([]byte, error) {
+	txID, uptime, err := h.backend.GetUptime(attestation.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	if txID != attestation.TxID {
+		return nil, fmt.Errorf("%w: expected %s but got %s", ErrWrongTxID, txID, attestation.TxID)
+	}
+	observedUptime := uint64(uptime / time.Second)
+	if observedUptime < attestation.Uptime {
+		return nil, fmt.Errorf("%w: %ds < %ds", ErrUptimeTooHigh, observedUptime, attestation.Uptime)
+	}
+
+	msg, err := attestation.UnsignedMessage(h.networkID)
+	if err != nil {
+		return nil, err
+	}
+	return h.signer.Sign(msg)
+}
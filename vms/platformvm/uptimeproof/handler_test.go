@@ -0,0 +1,115 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package uptimeproof
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxdefi/node/database"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/constants"
+	"github.com/luxdefi/node/utils/crypto/bls"
+	"github.com/luxdefi/node/utils/logging"
+	"github.com/luxdefi/node/vms/platformvm/warp"
+)
+
+type testBackend struct {
+	txID   ids.ID
+	uptime time.Duration
+	err    error
+}
+
+func (b *testBackend) GetUptime(ids.NodeID) (ids.ID, time.Duration, error) {
+	return b.txID, b.uptime, b.err
+}
+
+func TestHandlerSign(t *testing.T) {
+	txID := ids.GenerateTestID()
+	nodeID := ids.GenerateTestNodeID()
+
+	tests := []struct {
+		name        string
+		backend     *testBackend
+		txID        ids.ID
+		uptime      uint64
+		expectedErr error
+	}{
+		{
+			name: "observed uptime equals claimed uptime",
+			backend: &testBackend{
+				txID:   txID,
+				uptime: 100 * time.Second,
+			},
+			txID:   txID,
+			uptime: 100,
+		},
+		{
+			name: "observed uptime exceeds claimed uptime",
+			backend: &testBackend{
+				txID:   txID,
+				uptime: 100*time.Second + time.Millisecond,
+			},
+			txID:   txID,
+			uptime: 50,
+		},
+		{
+			name: "observed uptime is less than claimed uptime",
+			backend: &testBackend{
+				txID:   txID,
+				uptime: 100*time.Second - time.Millisecond,
+			},
+			txID:        txID,
+			uptime:      100,
+			expectedErr: ErrUptimeTooHigh,
+		},
+		{
+			name: "previous staking period",
+			backend: &testBackend{
+				txID:   txID,
+				uptime: 100 * time.Second,
+			},
+			txID:        ids.GenerateTestID(),
+			uptime:      100,
+			expectedErr: ErrWrongTxID,
+		},
+		{
+			name: "not a validator",
+			backend: &testBackend{
+				err: database.ErrNotFound,
+			},
+			txID:        txID,
+			uptime:      100,
+			expectedErr: database.ErrNotFound,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			sk, err := bls.NewSecretKey()
+			require.NoError(err)
+			signer := warp.NewSigner(sk, constants.UnitTestID, constants.PlatformChainID)
+			handler := NewHandler(logging.NoLog{}, constants.UnitTestID, test.backend, signer)
+
+			attestation, err := NewAttestation(test.txID, nodeID, test.uptime)
+			require.NoError(err)
+
+			signatureBytes, err := handler.AppRequest(context.Background(), ids.GenerateTestNodeID(), time.Time{}, attestation.Bytes())
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+
+			msg, err := attestation.UnsignedMessage(constants.UnitTestID)
+			require.NoError(err)
+			signature, err := bls.SignatureFromBytes(signatureBytes)
+			require.NoError(err)
+			require.True(bls.Verify(bls.PublicFromSecretKey(sk), signature, msg.Bytes()))
+		})
+	}
+}
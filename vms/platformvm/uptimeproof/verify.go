@@ -0,0 +1,46 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package uptimeproof
+
+import (
+	"context"
+
+	"github.com/luxdefi/node/snow/validators"
+	"github.com/luxdefi/node/utils/constants"
+	"github.com/luxdefi/node/vms/platformvm/warp"
+)
+
+// Verify returns the attestation of [msg] if [msg] was signed by the primary
+// network validators holding at least [quorumNum]/[quorumDen] of the stake at
+// [pChainHeight].
+func Verify(
+	ctx context.Context,
+	msg *warp.Message,
+	networkID uint32,
+	pChainState validators.State,
+	pChainHeight uint64,
+	quorumNum uint64,
+	quorumDen uint64,
+) (*Attestation, error) {
+	if msg.SourceChainID != constants.PlatformChainID {
+		return nil, warp.ErrWrongSourceChainID
+	}
+	attestation, err := ParseAttestation(msg.Payload)
+	if err != nil {
+		return nil, err
+	}
+	err = msg.Signature.Verify(
+		ctx,
+		&msg.UnsignedMessage,
+		networkID,
+		pChainState,
+		pChainHeight,
+		quorumNum,
+		quorumDen,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return attestation, nil
+}
@@ -0,0 +1,54 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/pubsub"
+	"github.com/luxdefi/node/utils/constants"
+	"github.com/luxdefi/node/vms/platformvm/block"
+)
+
+// publishValidatorSetDiffs publishes the changes made by [blk] to the
+// validator sets of the primary network and of the tracked subnets to every
+// subscribed connection.
+//
+// Diffs aren't published while bootstrapping.
+func (vm *VM) publishValidatorSetDiffs(blk block.Block) {
+	if !vm.bootstrapped.Get() {
+		return
+	}
+
+	height := blk.Height()
+	subnetIDs := append([]ids.ID{constants.PrimaryNetworkID}, vm.TrackedSubnets.List()...)
+	for _, subnetID := range subnetIDs {
+		diffs, err := vm.state.GetValidatorDiffs(context.TODO(), subnetID, height, height)
+		if err != nil {
+			vm.ctx.Log.Warn("failed to get validator set diffs",
+				zap.Stringer("subnetID", subnetID),
+				zap.Uint64("height", height),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		setDiffs, err := newAPIValidatorSetDiffs(subnetID, diffs)
+		if err != nil {
+			vm.ctx.Log.Warn("failed to format validator set diffs",
+				zap.Stringer("subnetID", subnetID),
+				zap.Uint64("height", height),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		for _, setDiff := range setDiffs {
+			vm.validatorSetDiffEvents.Publish(pubsub.NewAddressFilterer(nil, setDiff))
+		}
+	}
+}
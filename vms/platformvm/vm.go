@@ -7,6 +7,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/gorilla/rpc/v2"
 
@@ -19,6 +21,8 @@ import (
 	"github.com/luxdefi/node/codec/linearcodec"
 	"github.com/luxdefi/node/database"
 	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/network/p2p"
+	"github.com/luxdefi/node/pubsub"
 	"github.com/luxdefi/node/snow"
 	"github.com/luxdefi/node/snow/consensus/snowman"
 	"github.com/luxdefi/node/snow/engine/common"
@@ -33,6 +37,7 @@ import (
 	"github.com/luxdefi/node/vms/components/lux"
 	"github.com/luxdefi/node/vms/platformvm/api"
 	"github.com/luxdefi/node/vms/platformvm/block"
+	"github.com/luxdefi/node/vms/platformvm/checkpoint"
 	"github.com/luxdefi/node/vms/platformvm/config"
 	"github.com/luxdefi/node/vms/platformvm/fx"
 	"github.com/luxdefi/node/vms/platformvm/metrics"
@@ -41,6 +46,7 @@ import (
 	"github.com/luxdefi/node/vms/platformvm/state"
 	"github.com/luxdefi/node/vms/platformvm/txs"
 	"github.com/luxdefi/node/vms/platformvm/txs/mempool"
+	"github.com/luxdefi/node/vms/platformvm/uptimeproof"
 	"github.com/luxdefi/node/vms/platformvm/utxo"
 	"github.com/luxdefi/node/vms/secp256k1fx"
 
@@ -52,6 +58,13 @@ import (
 	pvalidators "github.com/luxdefi/node/vms/platformvm/validators"
 )
 
+// Identifiers of the application protocols served over [VM.p2pNetwork]
+const (
+	checkpointHandlerID = iota
+	uptimeHandlerID
+	blockHandlerID
+)
+
 var (
 	_ snowmanblock.ChainVM       = (*VM)(nil)
 	_ secp256k1fx.VM             = (*VM)(nil)
@@ -87,6 +100,46 @@ type VM struct {
 
 	txBuilder txbuilder.Builder
 	manager   blockexecutor.Manager
+	mempool   mempool.Mempool
+	// Streams the changes made to [mempool]
+	mempoolEvents *pubsub.Server
+	// Streams the changes made to the validator sets of the primary network
+	// and of the tracked subnets
+	validatorSetDiffEvents *pubsub.Server
+
+	// Used to simulate txs. [unsignedTxExecutorBackend] doesn't verify
+	// signatures, so that txs can be simulated before they're signed.
+	txExecutorBackend         *txexecutor.Backend
+	unsignedTxExecutorBackend *txexecutor.Backend
+
+	// Retained so that the state can be reloaded after syncing a checkpoint.
+	registerer   prometheus.Registerer
+	genesisBytes []byte
+	execConfig   *config.ExecutionConfig
+	toEngine     chan<- common.Message
+	appSender    common.AppSender
+
+	// Routes the requests and responses of the application protocols to
+	// their handlers and clients.
+	p2pNetwork *p2p.Network
+
+	// Checkpoints of the state that are served to, or fetched from, peers.
+	checkpoints      checkpoint.Store
+	checkpointClient *checkpoint.Client
+	// Fetches the blocks below a synced checkpoint.
+	blockClient *checkpoint.Client
+	// Height of the last block whose state was committed to [db].
+	lastCommittedHeight uint64
+	// Set while a checkpoint is being written.
+	buildingCheckpoint utils.Atomic[bool]
+	// Cancelled on shutdown to stop the checkpoint goroutines, which are
+	// tracked by [checkpointWG].
+	checkpointCtx    context.Context
+	checkpointCancel context.CancelFunc
+	checkpointWG     sync.WaitGroup
+
+	// Collects signatures of uptime attestations from the validators.
+	uptimeAggregator *uptimeproof.Aggregator
 
 	// TODO: Remove after v1.11.x is activated
 	pruned utils.Atomic[bool]
@@ -133,14 +186,119 @@ func (vm *VM) Initialize(
 		return err
 	}
 
+	vm.registerer = registerer
+	vm.genesisBytes = genesisBytes
+	vm.execConfig = execConfig
+	vm.toEngine = toEngine
+	vm.appSender = appSender
+	vm.atomicUtxosManager = lux.NewAtomicUTXOManager(chainCtx.SharedMemory, txs.Codec)
+
+	vm.p2pNetwork = p2p.NewNetwork(chainCtx.Log, appSender, registerer, "p2p")
+
+	vm.checkpoints = checkpoint.NewStore(vm.db)
+	checkpointClient, err := vm.p2pNetwork.NewAppProtocol(
+		checkpointHandlerID,
+		checkpoint.NewServer(chainCtx.Log, vm.checkpoints),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register checkpoint protocol: %w", err)
+	}
+	vm.checkpointClient = checkpoint.NewClient(checkpointClient)
+
+	blockClient, err := vm.p2pNetwork.NewAppProtocol(
+		blockHandlerID,
+		checkpoint.NewBlockServer(chainCtx.Log, historicalBlocks{vm: vm}),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register block protocol: %w", err)
+	}
+	vm.blockClient = checkpoint.NewClient(blockClient)
+
+	uptimeClient, err := vm.p2pNetwork.NewAppProtocol(
+		uptimeHandlerID,
+		uptimeproof.NewHandler(
+			chainCtx.Log,
+			chainCtx.NetworkID,
+			uptimeBackend{vm: vm},
+			chainCtx.WarpSigner,
+		),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register uptime protocol: %w", err)
+	}
+	vm.uptimeAggregator = uptimeproof.NewAggregator(
+		chainCtx.Log,
+		chainCtx.NetworkID,
+		uptimeClient,
+		validators.NewLockedState(&chainCtx.Lock, vm),
+	)
+	vm.checkpointCtx, vm.checkpointCancel = context.WithCancel(context.Background())
+	if err := vm.initCheckpoints(); err != nil {
+		return fmt.Errorf("failed to initialize checkpoints: %w", err)
+	}
+
+	vm.mempoolEvents = pubsub.New(chainCtx.Log)
+	vm.validatorSetDiffEvents = pubsub.New(chainCtx.Log)
+	vm.mempool, err = mempool.New(
+		"mempool",
+		registerer,
+		toEngine,
+		chainCtx.LUXAssetID,
+		&mempoolEventPublisher{server: vm.mempoolEvents},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create mempool: %w", err)
+	}
+
+	if err := vm.initState(ctx, registerer); err != nil {
+		return err
+	}
+	if err := vm.initSyncedCheckpoint(); err != nil {
+		return fmt.Errorf("failed to verify synced checkpoint: %w", err)
+	}
+	if err := vm.startBackfill(); err != nil {
+		return fmt.Errorf("failed to start fetching blocks below checkpoint: %w", err)
+	}
+
+	shouldPrune, err := vm.state.ShouldPrune()
+	if err != nil {
+		return fmt.Errorf(
+			"failed to check if the database should be pruned: %w",
+			err,
+		)
+	}
+	if !shouldPrune {
+		chainCtx.Log.Info("state already pruned and indexed")
+		vm.pruned.Set(true)
+		return nil
+	}
+
+	go func() {
+		err := vm.state.PruneAndIndex(&vm.ctx.Lock, vm.ctx.Log)
+		if err != nil {
+			vm.ctx.Log.Error("state pruning and height indexing failed",
+				zap.Error(err),
+			)
+		}
+
+		vm.pruned.Set(true)
+	}()
+
+	return nil
+}
+
+// initState loads the state from [vm.db] and creates every component that
+// depends on it.
+func (vm *VM) initState(ctx context.Context, registerer prometheus.Registerer) error {
 	rewards := reward.NewCalculator(vm.RewardConfig)
 
+	var err error
 	vm.state, err = state.New(
 		vm.db,
-		genesisBytes,
+		vm.genesisBytes,
 		registerer,
 		vm.Config.Validators,
-		execConfig,
+		vm.execConfig,
 		vm.ctx,
 		vm.metrics,
 		rewards,
@@ -149,12 +307,11 @@ func (vm *VM) Initialize(
 		return err
 	}
 
-	validatorManager := pvalidators.NewManager(chainCtx.Log, vm.Config, vm.state, vm.metrics, &vm.clock)
+	validatorManager := pvalidators.NewManager(vm.ctx.Log, vm.Config, vm.state, vm.metrics, &vm.clock)
 	vm.State = validatorManager
-	vm.atomicUtxosManager = lux.NewAtomicUTXOManager(chainCtx.SharedMemory, txs.Codec)
 	utxoHandler := utxo.NewHandler(vm.ctx, &vm.clock, vm.fx)
-	vm.uptimeManager = uptime.NewManager(vm.state, &vm.clock)
-	vm.UptimeLockedCalculator.SetCalculator(&vm.bootstrapped, &chainCtx.Lock, vm.uptimeManager)
+	vm.uptimeManager = uptime.NewManagerWithHistory(vm.state, uptime.NewHistory(vm.db), &vm.clock)
+	vm.UptimeLockedCalculator.SetCalculator(&vm.bootstrapped, &vm.ctx.Lock, vm.uptimeManager)
 
 	vm.txBuilder = txbuilder.New(
 		vm.ctx,
@@ -177,27 +334,35 @@ func (vm *VM) Initialize(
 		Bootstrapped: &vm.bootstrapped,
 	}
 
-	mempool, err := mempool.New("mempool", registerer, toEngine)
-	if err != nil {
-		return fmt.Errorf("failed to create mempool: %w", err)
+	// The fx isn't notified once the chain is bootstrapped, so it never
+	// verifies signatures.
+	unsignedFx := &secp256k1fx.Fx{}
+	if err := unsignedFx.InitializeVM(vm); err != nil {
+		return err
 	}
+	unsignedTxExecutorBackend := *txExecutorBackend
+	unsignedTxExecutorBackend.Fx = unsignedFx
+	unsignedTxExecutorBackend.FlowChecker = utxo.NewHandler(vm.ctx, &vm.clock, unsignedFx)
+	vm.txExecutorBackend = txExecutorBackend
+	vm.unsignedTxExecutorBackend = &unsignedTxExecutorBackend
 
 	vm.manager = blockexecutor.NewManager(
-		mempool,
+		vm.mempool,
 		vm.metrics,
 		vm.state,
 		txExecutorBackend,
 		validatorManager,
+		vm.onCommit,
 	)
 	vm.Network = network.New(
 		txExecutorBackend.Ctx,
 		vm.manager,
-		mempool,
+		vm.mempool,
 		txExecutorBackend.Config.PartialSyncPrimaryNetwork,
-		appSender,
+		vm.appSender,
 	)
 	vm.Builder = blockbuilder.New(
-		mempool,
+		vm.mempool,
 		vm.txBuilder,
 		txExecutorBackend,
 		vm.manager,
@@ -212,38 +377,16 @@ func (vm *VM) Initialize(
 	}
 
 	lastAcceptedID := vm.state.GetLastAccepted()
-	chainCtx.Log.Info("initializing last accepted",
-		zap.Stringer("blkID", lastAcceptedID),
-	)
-	if err := vm.SetPreference(ctx, lastAcceptedID); err != nil {
-		return err
-	}
-
-	shouldPrune, err := vm.state.ShouldPrune()
+	lastAccepted, err := vm.state.GetStatelessBlock(lastAcceptedID)
 	if err != nil {
-		return fmt.Errorf(
-			"failed to check if the database should be pruned: %w",
-			err,
-		)
-	}
-	if !shouldPrune {
-		chainCtx.Log.Info("state already pruned and indexed")
-		vm.pruned.Set(true)
-		return nil
+		return err
 	}
+	vm.lastCommittedHeight = lastAccepted.Height()
 
-	go func() {
-		err := vm.state.PruneAndIndex(&vm.ctx.Lock, vm.ctx.Log)
-		if err != nil {
-			vm.ctx.Log.Error("state pruning and height indexing failed",
-				zap.Error(err),
-			)
-		}
-
-		vm.pruned.Set(true)
-	}()
-
-	return nil
+	vm.ctx.Log.Info("initializing last accepted",
+		zap.Stringer("blkID", lastAcceptedID),
+	)
+	return vm.SetPreference(ctx, lastAcceptedID)
 }
 
 // Create all chains that exist that this node validates.
@@ -353,6 +496,12 @@ func (vm *VM) Shutdown(context.Context) error {
 
 	vm.Builder.Shutdown()
 
+	// The checkpoint goroutines may be waiting for the context lock.
+	vm.checkpointCancel()
+	vm.ctx.Lock.Unlock()
+	vm.checkpointWG.Wait()
+	vm.ctx.Lock.Lock()
+
 	if vm.bootstrapped.Get() {
 		primaryVdrIDs := vm.Validators.GetValidatorIDs(constants.PrimaryNetworkID)
 		if err := vm.uptimeManager.StopTracking(primaryVdrIDs, constants.PrimaryNetworkID); err != nil {
@@ -424,10 +573,26 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]http.Handler, error) {
 			Size: stakerAttributesCacheSize,
 		},
 	}
-	err := server.RegisterService(service, "platform")
-	return map[string]http.Handler{
-		"": server,
-	}, err
+	if err := server.RegisterService(service, "platform"); err != nil {
+		return nil, err
+	}
+
+	handlers := map[string]http.Handler{
+		"":                         server,
+		"/mempool/events":          vm.mempoolEvents,
+		"/validators/diffs/events": vm.validatorSetDiffEvents,
+	}
+	if !vm.execConfig.AdminAPIEnabled {
+		return handlers, nil
+	}
+
+	adminServer := rpc.NewServer()
+	adminServer.RegisterCodec(json.NewCodec(), "application/json")
+	adminServer.RegisterCodec(json.NewCodec(), "application/json;charset=UTF-8")
+	adminServer.RegisterInterceptFunc(vm.metrics.InterceptRequest)
+	adminServer.RegisterAfterFunc(vm.metrics.AfterRequest)
+	handlers["/admin"] = adminServer
+	return handlers, adminServer.RegisterService(&AdminService{vm: vm}, "admin")
 }
 
 // CreateStaticHandlers returns a map where:
@@ -442,7 +607,22 @@ func (*VM) CreateStaticHandlers(context.Context) (map[string]http.Handler, error
 	}, server.RegisterService(&api.StaticService{}, "platform")
 }
 
-func (vm *VM) Connected(_ context.Context, nodeID ids.NodeID, _ *version.Application) error {
+func (vm *VM) AppRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, deadline time.Time, request []byte) error {
+	return vm.p2pNetwork.AppRequest(ctx, nodeID, requestID, deadline, request)
+}
+
+func (vm *VM) AppResponse(ctx context.Context, nodeID ids.NodeID, requestID uint32, response []byte) error {
+	return vm.p2pNetwork.AppResponse(ctx, nodeID, requestID, response)
+}
+
+func (vm *VM) AppRequestFailed(ctx context.Context, nodeID ids.NodeID, requestID uint32) error {
+	return vm.p2pNetwork.AppRequestFailed(ctx, nodeID, requestID)
+}
+
+func (vm *VM) Connected(ctx context.Context, nodeID ids.NodeID, nodeVersion *version.Application) error {
+	if err := vm.p2pNetwork.Connected(ctx, nodeID, nodeVersion); err != nil {
+		return err
+	}
 	return vm.uptimeManager.Connect(nodeID, constants.PrimaryNetworkID)
 }
 
@@ -450,7 +630,10 @@ func (vm *VM) ConnectedSubnet(_ context.Context, nodeID ids.NodeID, subnetID ids
 	return vm.uptimeManager.Connect(nodeID, subnetID)
 }
 
-func (vm *VM) Disconnected(_ context.Context, nodeID ids.NodeID) error {
+func (vm *VM) Disconnected(ctx context.Context, nodeID ids.NodeID) error {
+	if err := vm.p2pNetwork.Disconnected(ctx, nodeID); err != nil {
+		return err
+	}
 	if err := vm.uptimeManager.Disconnect(nodeID); err != nil {
 		return err
 	}
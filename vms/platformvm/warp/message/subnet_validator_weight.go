@@ -0,0 +1,75 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"fmt"
+
+	"github.com/luxdefi/node/ids"
+)
+
+// SubnetValidatorWeight is sent by the validator manager of a subnet to set
+// the weight of [NodeID] on [SubnetID] to [Weight] until [EndTime].
+//
+// If [NodeID] isn't a validator of [SubnetID], it is added. If [Weight] is 0,
+// [NodeID] is removed from the validator set of [SubnetID].
+//
+// [Nonce] must be greater than the nonce of the last message applied for
+// [NodeID] on [SubnetID], which prevents messages from being replayed.
+type SubnetValidatorWeight struct {
+	SubnetID ids.ID     `serialize:"true"`
+	NodeID   ids.NodeID `serialize:"true"`
+	Nonce    uint64     `serialize:"true"`
+	Weight   uint64     `serialize:"true"`
+	// Unix time, in seconds, at which the validator is removed
+	EndTime uint64 `serialize:"true"`
+
+	bytes []byte
+}
+
+// NewSubnetValidatorWeight creates a new *SubnetValidatorWeight and
+// initializes it.
+func NewSubnetValidatorWeight(
+	subnetID ids.ID,
+	nodeID ids.NodeID,
+	nonce uint64,
+	weight uint64,
+	endTime uint64,
+) (*SubnetValidatorWeight, error) {
+	m := &SubnetValidatorWeight{
+		SubnetID: subnetID,
+		NodeID:   nodeID,
+		Nonce:    nonce,
+		Weight:   weight,
+		EndTime:  endTime,
+	}
+	bytes, err := c.Marshal(codecVersion, m)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't marshal subnet validator weight: %w", err)
+	}
+	m.bytes = bytes
+	return m, nil
+}
+
+// ParseSubnetValidatorWeight converts a slice of bytes into an initialized
+// *SubnetValidatorWeight.
+func ParseSubnetValidatorWeight(bytes []byte) (*SubnetValidatorWeight, error) {
+	m := &SubnetValidatorWeight{}
+	version, err := c.Unmarshal(bytes, m)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't unmarshal subnet validator weight: %w", err)
+	}
+	if version != codecVersion {
+		return nil, errWrongCodecVersion
+	}
+	m.bytes = bytes
+	return m, nil
+}
+
+// Bytes returns the binary representation of this message. It assumes that the
+// message is initialized from either NewSubnetValidatorWeight or
+// ParseSubnetValidatorWeight.
+func (m *SubnetValidatorWeight) Bytes() []byte {
+	return m.bytes
+}
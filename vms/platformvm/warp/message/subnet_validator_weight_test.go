@@ -0,0 +1,36 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxdefi/node/ids"
+)
+
+func TestSubnetValidatorWeight(t *testing.T) {
+	require := require.New(t)
+
+	subnetID := ids.GenerateTestID()
+	nodeID := ids.GenerateTestNodeID()
+	msg, err := NewSubnetValidatorWeight(subnetID, nodeID, 1, 2, 3)
+	require.NoError(err)
+	require.Len(msg.Bytes(), 2+ids.IDLen+ids.NodeIDLen+3*8)
+
+	parsedMsg, err := ParseSubnetValidatorWeight(msg.Bytes())
+	require.NoError(err)
+	require.Equal(msg, parsedMsg)
+	require.Equal(subnetID, parsedMsg.SubnetID)
+	require.Equal(nodeID, parsedMsg.NodeID)
+	require.Equal(uint64(1), parsedMsg.Nonce)
+	require.Equal(uint64(2), parsedMsg.Weight)
+	require.Equal(uint64(3), parsedMsg.EndTime)
+}
+
+func TestParseSubnetValidatorWeightJunk(t *testing.T) {
+	_, err := ParseSubnetValidatorWeight([]byte{0, 0, 1})
+	require.Error(t, err) //nolint:forbidigo // error is returned by the codec
+}
@@ -0,0 +1,129 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/luxdefi/node/database"
+	"github.com/luxdefi/node/snow/choices"
+	"github.com/luxdefi/node/snow/engine/snowman/block"
+)
+
+var (
+	_ block.RollbackableVM = (*VM)(nil)
+
+	errRollbackAboveLastAccepted = errors.New("rollback height is above the last accepted height")
+	errRollbackBeforeFork        = errors.New("rollback height is before the proposervm fork")
+)
+
+// vm.ctx.Lock should be held
+func (vm *VM) VerifyRollback(ctx context.Context, height uint64) error {
+	if vm.rollbackVM == nil {
+		return block.ErrRollbackNotSupported
+	}
+
+	forkHeight, err := vm.State.GetForkHeight()
+	switch err {
+	case nil:
+		if err := vm.verifyPostForkRollback(height, forkHeight); err != nil {
+			return err
+		}
+	case database.ErrNotFound:
+		// Before the fork, the inner VM holds the only index.
+	default:
+		return err
+	}
+	return vm.rollbackVM.VerifyRollback(ctx, height)
+}
+
+func (vm *VM) verifyPostForkRollback(height uint64, forkHeight uint64) error {
+	// The height index is required to find the block to roll back to.
+	if !vm.hIndexer.IsRepaired() {
+		return block.ErrIndexIncomplete
+	}
+	if height < forkHeight {
+		return fmt.Errorf("%w: %d < %d", errRollbackBeforeFork, height, forkHeight)
+	}
+	if height > vm.lastAcceptedHeight {
+		return fmt.Errorf("%w: %d > %d", errRollbackAboveLastAccepted, height, vm.lastAcceptedHeight)
+	}
+	if _, err := vm.State.GetBlockIDAtHeight(height); err != nil {
+		return fmt.Errorf("couldn't find block at height %d: %w", height, err)
+	}
+	return nil
+}
+
+// Rollback reverts the inner VM first. If the node shuts down before the
+// proposervm index is reverted, repairAcceptedChainByHeight moves the last
+// accepted block back to the height of the inner VM on restart and retrying
+// the rollback removes the remaining blocks above [height].
+//
+// vm.ctx.Lock should be held
+func (vm *VM) Rollback(ctx context.Context, height uint64) error {
+	if err := vm.VerifyRollback(ctx, height); err != nil {
+		return err
+	}
+	if err := vm.rollbackVM.Rollback(ctx, height); err != nil {
+		return err
+	}
+	vm.innerBlkCache.Flush()
+
+	if _, err := vm.State.GetForkHeight(); err == database.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	// Forget every block accepted after [height], which may include blocks
+	// above the last accepted block if a previous rollback was interrupted.
+	numReverted := 0
+	for revertHeight := height + 1; ; revertHeight++ {
+		blkID, err := vm.State.GetBlockIDAtHeight(revertHeight)
+		if err == database.ErrNotFound {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		blk, _, err := vm.State.GetBlock(blkID)
+		switch err {
+		case nil:
+			if err := vm.State.PutBlock(blk, choices.Processing); err != nil {
+				return err
+			}
+		case database.ErrNotFound:
+			// The block may have been pruned.
+		default:
+			return err
+		}
+		if err := vm.State.DeleteBlockIDAtHeight(revertHeight); err != nil {
+			return err
+		}
+		numReverted++
+	}
+
+	lastAcceptedID, err := vm.State.GetBlockIDAtHeight(height)
+	if err != nil {
+		return err
+	}
+	if err := vm.State.SetLastAccepted(lastAcceptedID); err != nil {
+		return err
+	}
+	if err := vm.db.Commit(); err != nil {
+		return err
+	}
+
+	vm.ctx.Log.Info("rolled back proposervm",
+		zap.Uint64("height", height),
+		zap.Stringer("lastAcceptedID", lastAcceptedID),
+		zap.Int("numReverted", numReverted),
+	)
+	return vm.setLastAcceptedMetadata(ctx)
+}
@@ -0,0 +1,233 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxdefi/node/database"
+	"github.com/luxdefi/node/database/memdb"
+	"github.com/luxdefi/node/database/prefixdb"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/snow"
+	"github.com/luxdefi/node/snow/choices"
+	"github.com/luxdefi/node/snow/consensus/snowman"
+	"github.com/luxdefi/node/snow/engine/common"
+	"github.com/luxdefi/node/snow/engine/snowman/block"
+
+	statelessblock "github.com/luxdefi/node/vms/proposervm/block"
+)
+
+var _ block.RollbackableVM = (*rollbackableVM)(nil)
+
+type rollbackableVM struct {
+	*block.TestVM
+
+	verifyRollbackF func(context.Context, uint64) error
+	rollbackF       func(context.Context, uint64) error
+}
+
+func (vm *rollbackableVM) VerifyRollback(ctx context.Context, height uint64) error {
+	return vm.verifyRollbackF(ctx, height)
+}
+
+func (vm *rollbackableVM) Rollback(ctx context.Context, height uint64) error {
+	return vm.rollbackF(ctx, height)
+}
+
+// initRollbackTestVM returns a proposervm that accepted a post-fork block at
+// every height in [1, numBlocks], along with the IDs of those blocks.
+func initRollbackTestVM(t *testing.T, numBlocks int) (*rollbackableVM, *VM, []ids.ID) {
+	require := require.New(t)
+
+	innerBlks := make(map[string]*snowman.TestBlock)
+	for height := 0; height <= numBlocks; height++ {
+		innerBlk := &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Accepted,
+			},
+			HeightV: uint64(height),
+			BytesV:  []byte{byte(height)},
+		}
+		innerBlks[string(innerBlk.BytesV)] = innerBlk
+	}
+	innerGenesisBlk := innerBlks[string([]byte{0})]
+
+	innerVM := &rollbackableVM{
+		TestVM: &block.TestVM{
+			TestVM: common.TestVM{
+				T: t,
+			},
+		},
+	}
+	innerVM.InitializeF = func(context.Context, *snow.Context, database.Database,
+		[]byte, []byte, []byte, chan<- common.Message,
+		[]*common.Fx, common.AppSender,
+	) error {
+		return nil
+	}
+	innerVM.VerifyHeightIndexF = func(context.Context) error {
+		return nil
+	}
+	innerVM.LastAcceptedF = func(context.Context) (ids.ID, error) {
+		return innerGenesisBlk.ID(), nil
+	}
+	innerVM.GetBlockF = func(context.Context, ids.ID) (snowman.Block, error) {
+		return innerGenesisBlk, nil
+	}
+	innerVM.ParseBlockF = func(_ context.Context, b []byte) (snowman.Block, error) {
+		innerBlk, ok := innerBlks[string(b)]
+		if !ok {
+			return nil, errUnknownBlock
+		}
+		return innerBlk, nil
+	}
+
+	vm := New(
+		innerVM,
+		time.Time{},
+		0,
+		DefaultMinBlockDelay,
+		DefaultNumHistoricalBlocks,
+		pTestSigner,
+		pTestCert,
+	)
+
+	ctx := snow.DefaultContextTest()
+	ctx.NodeID = ids.NodeIDFromCert(pTestCert)
+
+	require.NoError(vm.Initialize(
+		context.Background(),
+		ctx,
+		prefixdb.New([]byte{}, memdb.New()),
+		innerGenesisBlk.Bytes(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	))
+
+	require.NoError(vm.State.SetForkHeight(1))
+	blkIDs := make([]ids.ID, numBlocks+1)
+	blkIDs[0] = innerGenesisBlk.ID()
+	for height := 1; height <= numBlocks; height++ {
+		blk, err := statelessblock.BuildUnsigned(
+			blkIDs[height-1],
+			time.Unix(int64(height), 0),
+			0,
+			[]byte{byte(height)},
+		)
+		require.NoError(err)
+		require.NoError(vm.State.PutBlock(blk, choices.Accepted))
+		require.NoError(vm.State.SetBlockIDAtHeight(uint64(height), blk.ID()))
+		blkIDs[height] = blk.ID()
+	}
+	require.NoError(vm.State.SetLastAccepted(blkIDs[numBlocks]))
+	require.NoError(vm.setLastAcceptedMetadata(context.Background()))
+	return innerVM, vm, blkIDs
+}
+
+func TestRollback(t *testing.T) {
+	require := require.New(t)
+
+	innerVM, vm, blkIDs := initRollbackTestVM(t, 3)
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+
+	var rolledBackTo []uint64
+	innerVM.verifyRollbackF = func(context.Context, uint64) error {
+		return nil
+	}
+	innerVM.rollbackF = func(_ context.Context, height uint64) error {
+		rolledBackTo = append(rolledBackTo, height)
+		return nil
+	}
+
+	require.NoError(vm.Rollback(context.Background(), 1))
+	require.Equal([]uint64{1}, rolledBackTo)
+
+	lastAcceptedID, err := vm.LastAccepted(context.Background())
+	require.NoError(err)
+	require.Equal(blkIDs[1], lastAcceptedID)
+	require.Equal(uint64(1), vm.lastAcceptedHeight)
+
+	for height := 2; height <= 3; height++ {
+		_, err := vm.State.GetBlockIDAtHeight(uint64(height))
+		require.ErrorIs(err, database.ErrNotFound)
+
+		_, status, err := vm.State.GetBlock(blkIDs[height])
+		require.NoError(err)
+		require.Equal(choices.Processing, status)
+	}
+
+	// Rolling back to the last accepted height is a no-op.
+	require.NoError(vm.Rollback(context.Background(), 1))
+	require.Equal([]uint64{1, 1}, rolledBackTo)
+	lastAcceptedID, err = vm.LastAccepted(context.Background())
+	require.NoError(err)
+	require.Equal(blkIDs[1], lastAcceptedID)
+}
+
+func TestRollbackInterrupted(t *testing.T) {
+	require := require.New(t)
+
+	innerVM, vm, blkIDs := initRollbackTestVM(t, 3)
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+
+	innerVM.verifyRollbackF = func(context.Context, uint64) error {
+		return nil
+	}
+	innerVM.rollbackF = func(context.Context, uint64) error {
+		return nil
+	}
+
+	// Simulate a node that shut down after the inner VM was rolled back to
+	// height 2, but before the proposervm index was reverted.
+	require.NoError(vm.State.SetLastAccepted(blkIDs[2]))
+	require.NoError(vm.setLastAcceptedMetadata(context.Background()))
+
+	require.NoError(vm.Rollback(context.Background(), 2))
+	_, err := vm.State.GetBlockIDAtHeight(3)
+	require.ErrorIs(err, database.ErrNotFound)
+	_, status, err := vm.State.GetBlock(blkIDs[3])
+	require.NoError(err)
+	require.Equal(choices.Processing, status)
+}
+
+func TestVerifyRollback(t *testing.T) {
+	require := require.New(t)
+
+	innerVM, vm, _ := initRollbackTestVM(t, 3)
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+
+	innerVM.verifyRollbackF = func(context.Context, uint64) error {
+		return block.ErrRollbackNotSupported
+	}
+	vm.hIndexer.MarkRepaired(false)
+	require.ErrorIs(vm.VerifyRollback(context.Background(), 2), block.ErrIndexIncomplete)
+
+	vm.hIndexer.MarkRepaired(true)
+	require.ErrorIs(vm.VerifyRollback(context.Background(), 0), errRollbackBeforeFork)
+	require.ErrorIs(vm.VerifyRollback(context.Background(), 4), errRollbackAboveLastAccepted)
+	require.ErrorIs(vm.VerifyRollback(context.Background(), 2), block.ErrRollbackNotSupported)
+
+	innerVM.verifyRollbackF = func(context.Context, uint64) error {
+		return nil
+	}
+	require.NoError(vm.VerifyRollback(context.Background(), 2))
+
+	vm.rollbackVM = nil
+	require.ErrorIs(vm.VerifyRollback(context.Background(), 2), block.ErrRollbackNotSupported)
+}
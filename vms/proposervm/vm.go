@@ -89,6 +89,7 @@ type VM struct {
 	blockBuilderVM block.BuildBlockWithContextChainVM
 	batchedVM      block.BatchedChainVM
 	ssVM           block.StateSyncableVM
+	rollbackVM     block.RollbackableVM
 
 	activationTime      time.Time
 	minimumPChainHeight uint64
@@ -148,11 +149,13 @@ func New(
 	blockBuilderVM, _ := vm.(block.BuildBlockWithContextChainVM)
 	batchedVM, _ := vm.(block.BatchedChainVM)
 	ssVM, _ := vm.(block.StateSyncableVM)
+	rollbackVM, _ := vm.(block.RollbackableVM)
 	return &VM{
 		ChainVM:        vm,
 		blockBuilderVM: blockBuilderVM,
 		batchedVM:      batchedVM,
 		ssVM:           ssVM,
+		rollbackVM:     rollbackVM,
 
 		activationTime:      activationTime,
 		minimumPChainHeight: minimumPChainHeight,
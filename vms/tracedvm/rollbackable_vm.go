@@ -0,0 +1,40 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tracedvm
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/luxdefi/node/snow/engine/snowman/block"
+)
+
+func (vm *blockVM) VerifyRollback(ctx context.Context, height uint64) error {
+	if vm.rollbackVM == nil {
+		return block.ErrRollbackNotSupported
+	}
+
+	ctx, span := vm.tracer.Start(ctx, vm.verifyRollbackTag, oteltrace.WithAttributes(
+		attribute.Int64("height", int64(height)),
+	))
+	defer span.End()
+
+	return vm.rollbackVM.VerifyRollback(ctx, height)
+}
+
+func (vm *blockVM) Rollback(ctx context.Context, height uint64) error {
+	if vm.rollbackVM == nil {
+		return block.ErrRollbackNotSupported
+	}
+
+	ctx, span := vm.tracer.Start(ctx, vm.rollbackTag, oteltrace.WithAttributes(
+		attribute.Int64("height", int64(height)),
+	))
+	defer span.End()
+
+	return vm.rollbackVM.Rollback(ctx, height)
+}
@@ -95,6 +95,17 @@ type Builder interface {
 		options ...common.Option,
 	) (*txs.RemoveSubnetValidatorTx, error)
 
+	// NewTransferSubnetOwnershipTx changes the owner of [subnetID] to
+	// [owner].
+	//
+	// - [subnetID] specifies the subnet whose owner is changed.
+	// - [owner] specifies who is now authorized to manage [subnetID].
+	NewTransferSubnetOwnershipTx(
+		subnetID ids.ID,
+		owner *secp256k1fx.OutputOwners,
+		options ...common.Option,
+	) (*txs.TransferSubnetOwnershipTx, error)
+
 	// NewAddDelegatorTx creates a new delegator to a validator on the primary
 	// network.
 	//
@@ -424,6 +435,41 @@ func (b *builder) NewRemoveSubnetValidatorTx(
 	}, nil
 }
 
+func (b *builder) NewTransferSubnetOwnershipTx(
+	subnetID ids.ID,
+	owner *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.TransferSubnetOwnershipTx, error) {
+	toBurn := map[ids.ID]uint64{
+		b.backend.LUXAssetID(): b.backend.BaseTxFee(),
+	}
+	toStake := map[ids.ID]uint64{}
+	ops := common.NewOptions(options)
+	inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	subnetAuth, err := b.authorizeSubnet(subnetID, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	utils.Sort(owner.Addrs)
+	return &txs.TransferSubnetOwnershipTx{
+		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+			NetworkID:    b.backend.NetworkID(),
+			BlockchainID: constants.PlatformChainID,
+			Ins:          inputs,
+			Outs:         outputs,
+			Memo:         ops.Memo(),
+		}},
+		Subnet:     subnetID,
+		SubnetAuth: subnetAuth,
+		Owner:      owner,
+	}, nil
+}
+
 func (b *builder) NewAddDelegatorTx(
 	vdr *txs.Validator,
 	rewardsOwner *secp256k1fx.OutputOwners,
@@ -0,0 +1,228 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	stdcontext "context"
+
+	"github.com/luxdefi/node/database"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/set"
+	"github.com/luxdefi/node/vms/components/lux"
+	"github.com/luxdefi/node/vms/platformvm/txs"
+	"github.com/luxdefi/node/vms/secp256k1fx"
+)
+
+var (
+	_ SignerBackend = (*PartialTx)(nil)
+	_ SignerBackend = (*recordingSignerBackend)(nil)
+
+	errMismatchedTx          = errors.New("partial txs don't sign the same tx")
+	errMismatchedCredentials = errors.New("partial txs have mismatched credentials")
+	errConflictingSignatures = errors.New("partial txs have conflicting signatures")
+)
+
+// PartialTx is a tx that may be missing signatures, along with the UTXOs it
+// consumes and the txs that define the owners it must be authorized by. All
+// the information needed to sign the tx is included, so it can be passed
+// between the parties that must sign it and signed without access to the
+// network.
+type PartialTx struct {
+	// Tx is the tx being signed. Its credentials are always populated,
+	// missing signatures are left empty.
+	Tx *txs.Tx `serialize:"true"`
+	// UTXOs consumed by Tx.
+	UTXOs []*ChainUTXO `serialize:"true"`
+	// Txs, such as CreateSubnetTxs, that define the owners Tx must be
+	// authorized by.
+	Txs []*txs.Tx `serialize:"true"`
+}
+
+// ChainUTXO is a UTXO along with the ID of the chain it is stored on.
+type ChainUTXO struct {
+	ChainID ids.ID    `serialize:"true"`
+	UTXO    *lux.UTXO `serialize:"true"`
+}
+
+// NewPartialTx returns a PartialTx for [tx], which may already be partially
+// signed. The UTXOs and txs needed to sign [tx] are fetched from [backend].
+func NewPartialTx(ctx stdcontext.Context, backend SignerBackend, tx *txs.Tx) (*PartialTx, error) {
+	recorder := &recordingSignerBackend{
+		backend: backend,
+	}
+
+	// Signing with an empty keychain doesn't add any signature, but fetches
+	// everything the signer needs and populates the credentials of [tx].
+	signer := NewSigner(secp256k1fx.NewKeychain(), recorder)
+	if err := signer.Sign(ctx, tx); err != nil {
+		return nil, err
+	}
+	return &PartialTx{
+		Tx:    tx,
+		UTXOs: recorder.utxos,
+		Txs:   recorder.txs,
+	}, nil
+}
+
+// ParsePartialTx parses a PartialTx serialized by [PartialTx.Bytes].
+func ParsePartialTx(b []byte) (*PartialTx, error) {
+	p := &PartialTx{}
+	if _, err := txs.Codec.Unmarshal(b, p); err != nil {
+		return nil, fmt.Errorf("couldn't parse partial tx: %w", err)
+	}
+	if err := p.Tx.Initialize(txs.Codec); err != nil {
+		return nil, err
+	}
+	for _, tx := range p.Txs {
+		if err := tx.Initialize(txs.Codec); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// Bytes returns the serialized representation of the PartialTx.
+func (p *PartialTx) Bytes() ([]byte, error) {
+	return txs.Codec.Marshal(txs.Version, p)
+}
+
+func (p *PartialTx) GetUTXO(_ stdcontext.Context, chainID, utxoID ids.ID) (*lux.UTXO, error) {
+	for _, utxo := range p.UTXOs {
+		if utxo.ChainID == chainID && utxo.UTXO.InputID() == utxoID {
+			return utxo.UTXO, nil
+		}
+	}
+	return nil, database.ErrNotFound
+}
+
+func (p *PartialTx) GetTx(_ stdcontext.Context, txID ids.ID) (*txs.Tx, error) {
+	for _, tx := range p.Txs {
+		if tx.ID() == txID {
+			return tx, nil
+		}
+	}
+	return nil, database.ErrNotFound
+}
+
+// MissingSignatures returns the number of signatures that must still be added
+// to the tx before it can be issued.
+func (p *PartialTx) MissingSignatures() (int, error) {
+	var missing int
+	for _, credIntf := range p.Tx.Creds {
+		cred, ok := credIntf.(*secp256k1fx.Credential)
+		if !ok {
+			return 0, errUnknownCredentialType
+		}
+		for _, sig := range cred.Sigs {
+			if sig == emptySig {
+				missing++
+			}
+		}
+	}
+	return missing, nil
+}
+
+// Merge adds the signatures of [others] to the tx. All the partial txs must
+// sign the same tx.
+func (p *PartialTx) Merge(others ...*PartialTx) error {
+	unsignedBytes := p.Tx.Unsigned.Bytes()
+	for _, other := range others {
+		if !bytes.Equal(unsignedBytes, other.Tx.Unsigned.Bytes()) {
+			return fmt.Errorf("%w: %s != %s", errMismatchedTx, p.Tx.ID(), other.Tx.ID())
+		}
+		if err := mergeCredentials(p.Tx, other.Tx); err != nil {
+			return err
+		}
+	}
+
+	signedBytes, err := txs.Codec.Marshal(txs.Version, p.Tx)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal tx: %w", err)
+	}
+	p.Tx.SetBytes(unsignedBytes, signedBytes)
+	return nil
+}
+
+// mergeCredentials copies the signatures of [src] that are missing from [dst].
+func mergeCredentials(dst, src *txs.Tx) error {
+	if len(dst.Creds) != len(src.Creds) {
+		return fmt.Errorf("%w: %d != %d credentials", errMismatchedCredentials, len(dst.Creds), len(src.Creds))
+	}
+	for credIndex, dstCredIntf := range dst.Creds {
+		dstCred, ok := dstCredIntf.(*secp256k1fx.Credential)
+		if !ok {
+			return errUnknownCredentialType
+		}
+		srcCred, ok := src.Creds[credIndex].(*secp256k1fx.Credential)
+		if !ok {
+			return errUnknownCredentialType
+		}
+		if len(dstCred.Sigs) != len(srcCred.Sigs) {
+			return fmt.Errorf(
+				"%w: credential %d has %d != %d signatures",
+				errMismatchedCredentials,
+				credIndex,
+				len(dstCred.Sigs),
+				len(srcCred.Sigs),
+			)
+		}
+
+		for sigIndex, sig := range srcCred.Sigs {
+			switch dstSig := dstCred.Sigs[sigIndex]; {
+			case sig == emptySig || sig == dstSig:
+			case dstSig == emptySig:
+				dstCred.Sigs[sigIndex] = sig
+			default:
+				return fmt.Errorf(
+					"%w: credential %d signature %d",
+					errConflictingSignatures,
+					credIndex,
+					sigIndex,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// recordingSignerBackend records the UTXOs and txs fetched from [backend].
+type recordingSignerBackend struct {
+	backend SignerBackend
+
+	utxoIDs set.Set[ids.ID]
+	utxos   []*ChainUTXO
+	txIDs   set.Set[ids.ID]
+	txs     []*txs.Tx
+}
+
+func (r *recordingSignerBackend) GetUTXO(ctx stdcontext.Context, chainID, utxoID ids.ID) (*lux.UTXO, error) {
+	utxo, err := r.backend.GetUTXO(ctx, chainID, utxoID)
+	if err != nil {
+		return nil, err
+	}
+	if !r.utxoIDs.Contains(utxoID) {
+		r.utxoIDs.Add(utxoID)
+		r.utxos = append(r.utxos, &ChainUTXO{
+			ChainID: chainID,
+			UTXO:    utxo,
+		})
+	}
+	return utxo, nil
+}
+
+func (r *recordingSignerBackend) GetTx(ctx stdcontext.Context, txID ids.ID) (*txs.Tx, error) {
+	tx, err := r.backend.GetTx(ctx, txID)
+	if err != nil {
+		return nil, err
+	}
+	if !r.txIDs.Contains(txID) {
+		r.txIDs.Add(txID)
+		r.txs = append(r.txs, tx)
+	}
+	return tx, nil
+}
@@ -0,0 +1,181 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	stdcontext "context"
+
+	"github.com/luxdefi/node/database"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils"
+	"github.com/luxdefi/node/utils/constants"
+	"github.com/luxdefi/node/utils/crypto/secp256k1"
+	"github.com/luxdefi/node/vms/components/lux"
+	"github.com/luxdefi/node/vms/platformvm/txs"
+	"github.com/luxdefi/node/vms/secp256k1fx"
+)
+
+type testSignerBackend struct {
+	utxos map[ids.ID]*lux.UTXO
+	txs   map[ids.ID]*txs.Tx
+}
+
+func (b *testSignerBackend) GetUTXO(_ stdcontext.Context, chainID, utxoID ids.ID) (*lux.UTXO, error) {
+	utxo, ok := b.utxos[utxoID]
+	if !ok || chainID != constants.PlatformChainID {
+		return nil, database.ErrNotFound
+	}
+	return utxo, nil
+}
+
+func (b *testSignerBackend) GetTx(_ stdcontext.Context, txID ids.ID) (*txs.Tx, error) {
+	tx, ok := b.txs[txID]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return tx, nil
+}
+
+func TestPartialTxMultisig(t *testing.T) {
+	require := require.New(t)
+
+	ctx := stdcontext.Background()
+	keys := make([]*secp256k1.PrivateKey, 3)
+	for i := range keys {
+		key, err := secp256k1.NewPrivateKey()
+		require.NoError(err)
+		keys[i] = key
+	}
+	ownerAddrs := []ids.ShortID{
+		keys[0].Address(),
+		keys[1].Address(),
+		keys[2].Address(),
+	}
+	utils.Sort(ownerAddrs)
+
+	// The subnet is authorized by the second and third keys.
+	var subnetSigIndices []uint32
+	for i, addr := range ownerAddrs {
+		if addr != keys[0].Address() {
+			subnetSigIndices = append(subnetSigIndices, uint32(i))
+		}
+	}
+
+	// The subnet is owned by a 2-of-3 multisig and the fee is paid by the
+	// first key.
+	subnetTx := &txs.Tx{Unsigned: &txs.CreateSubnetTx{
+		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+			BlockchainID: constants.PlatformChainID,
+		}},
+		Owner: &secp256k1fx.OutputOwners{
+			Threshold: 2,
+			Addrs:     ownerAddrs,
+		},
+	}}
+	require.NoError(subnetTx.Initialize(txs.Codec))
+	subnetID := subnetTx.ID()
+
+	assetID := ids.GenerateTestID()
+	utxo := &lux.UTXO{
+		UTXOID: lux.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  lux.Asset{ID: assetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 10,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{keys[0].Address()},
+			},
+		},
+	}
+	backend := &testSignerBackend{
+		utxos: map[ids.ID]*lux.UTXO{utxo.InputID(): utxo},
+		txs:   map[ids.ID]*txs.Tx{subnetID: subnetTx},
+	}
+
+	utx := &txs.TransferSubnetOwnershipTx{
+		BaseTx: txs.BaseTx{BaseTx: lux.BaseTx{
+			BlockchainID: constants.PlatformChainID,
+			Ins: []*lux.TransferableInput{{
+				UTXOID: utxo.UTXOID,
+				Asset:  utxo.Asset,
+				In: &secp256k1fx.TransferInput{
+					Amt:   10,
+					Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+				},
+			}},
+		}},
+		Subnet:     subnetID,
+		SubnetAuth: &secp256k1fx.Input{SigIndices: subnetSigIndices},
+		Owner:      &secp256k1fx.OutputOwners{},
+	}
+
+	// The first party signs the fee input and exports the tx.
+	tx, err := NewSigner(secp256k1fx.NewKeychain(keys[0]), backend).SignUnsigned(ctx, utx)
+	require.NoError(err)
+	partialTx, err := NewPartialTx(ctx, backend, tx)
+	require.NoError(err)
+	require.Len(partialTx.UTXOs, 1)
+	require.Len(partialTx.Txs, 1)
+
+	missing, err := partialTx.MissingSignatures()
+	require.NoError(err)
+	require.Equal(2, missing)
+
+	partialTxBytes, err := partialTx.Bytes()
+	require.NoError(err)
+
+	// The other parties sign offline, in parallel.
+	partialTxs := make([]*PartialTx, 2)
+	for i, key := range keys[1:] {
+		partialTx, err := ParsePartialTx(partialTxBytes)
+		require.NoError(err)
+		require.NoError(NewSigner(secp256k1fx.NewKeychain(key), partialTx).Sign(ctx, partialTx.Tx))
+
+		missing, err := partialTx.MissingSignatures()
+		require.NoError(err)
+		require.Equal(1, missing)
+
+		partialTxs[i] = partialTx
+	}
+
+	require.NoError(partialTx.Merge(partialTxs...))
+	missing, err = partialTx.MissingSignatures()
+	require.NoError(err)
+	require.Zero(missing)
+
+	// The merged tx is the tx a single party holding all the keys would
+	// have signed.
+	expectedTx, err := NewSigner(secp256k1fx.NewKeychain(keys...), backend).SignUnsigned(ctx, utx)
+	require.NoError(err)
+	require.Equal(expectedTx.Bytes(), partialTx.Tx.Bytes())
+	require.Equal(expectedTx.ID(), partialTx.Tx.ID())
+
+	// Merging a different signature for a signed input fails.
+	conflicting, err := ParsePartialTx(partialTxBytes)
+	require.NoError(err)
+	conflicting.Tx.Creds[0].(*secp256k1fx.Credential).Sigs[0][0]++
+	err = partialTx.Merge(conflicting)
+	require.ErrorIs(err, errConflictingSignatures)
+}
+
+func TestPartialTxMergeMismatchedTx(t *testing.T) {
+	require := require.New(t)
+
+	newPartialTx := func(memo []byte) *PartialTx {
+		tx := &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: lux.BaseTx{
+			BlockchainID: constants.PlatformChainID,
+			Memo:         memo,
+		}}}
+		partialTx, err := NewPartialTx(stdcontext.Background(), &testSignerBackend{}, tx)
+		require.NoError(err)
+		return partialTx
+	}
+
+	err := newPartialTx([]byte{0}).Merge(newPartialTx([]byte{1}))
+	require.ErrorIs(err, errMismatchedTx)
+}
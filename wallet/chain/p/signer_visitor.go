@@ -17,6 +17,7 @@ import (
 	"github.com/luxdefi/node/utils/hashing"
 	"github.com/luxdefi/node/vms/components/lux"
 	"github.com/luxdefi/node/vms/components/verify"
+	"github.com/luxdefi/node/vms/platformvm/fx"
 	"github.com/luxdefi/node/vms/platformvm/stakeable"
 	"github.com/luxdefi/node/vms/platformvm/txs"
 	"github.com/luxdefi/node/vms/secp256k1fx"
@@ -30,6 +31,7 @@ var (
 	errUnknownCredentialType = errors.New("unknown credential type")
 	errUnknownOutputType     = errors.New("unknown output type")
 	errUnknownSubnetAuthType = errors.New("unknown subnet auth type")
+	errUnknownStakerAuthType = errors.New("unknown staker auth type")
 	errInvalidUTXOSigIndex   = errors.New("invalid UTXO signature index")
 
 	emptySig [secp256k1.SignatureLen]byte
@@ -185,6 +187,56 @@ func (s *signerVisitor) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDe
 	return sign(s.tx, true, txSigners)
 }
 
+func (s *signerVisitor) AddContinuousValidatorTx(tx *txs.AddContinuousValidatorTx) error {
+	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	return sign(s.tx, true, txSigners)
+}
+
+func (s *signerVisitor) StopContinuousValidatorTx(tx *txs.StopContinuousValidatorTx) error {
+	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	stakerAuthSigners, err := s.getStakerSigners(tx.TxID, tx.StakerAuth)
+	if err != nil {
+		return err
+	}
+	txSigners = append(txSigners, stakerAuthSigners)
+	return sign(s.tx, true, txSigners)
+}
+
+func (s *signerVisitor) ConvertSubnetTx(tx *txs.ConvertSubnetTx) error {
+	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	subnetAuthSigners, err := s.getSubnetSigners(tx.Subnet, tx.SubnetAuth)
+	if err != nil {
+		return err
+	}
+	txSigners = append(txSigners, subnetAuthSigners)
+	return sign(s.tx, true, txSigners)
+}
+
+func (s *signerVisitor) SetSubnetValidatorWeightTx(tx *txs.SetSubnetValidatorWeightTx) error {
+	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	return sign(s.tx, true, txSigners)
+}
+
+func (s *signerVisitor) AddPooledDelegatorTx(tx *txs.AddPooledDelegatorTx) error {
+	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	return sign(s.tx, true, txSigners)
+}
+
 func (s *signerVisitor) getSigners(sourceChainID ids.ID, ins []*lux.TransferableInput) ([][]keychain.Signer, error) {
 	txSigners := make([][]keychain.Signer, len(ins))
 	for credIndex, transferInput := range ins {
@@ -259,13 +311,41 @@ func (s *signerVisitor) getSubnetSigners(subnetID ids.ID, subnetAuth verify.Veri
 		return nil, errWrongTxType
 	}
 
-	owner, ok := subnet.Owner.(*secp256k1fx.OutputOwners)
+	return s.getOwnerSigners(subnetInput, subnet.Owner)
+}
+
+func (s *signerVisitor) getStakerSigners(txID ids.ID, stakerAuth verify.Verifiable) ([]keychain.Signer, error) {
+	stakerInput, ok := stakerAuth.(*secp256k1fx.Input)
+	if !ok {
+		return nil, errUnknownStakerAuthType
+	}
+
+	stakerTx, err := s.backend.GetTx(s.ctx, txID)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to fetch staker tx %q: %w",
+			txID,
+			err,
+		)
+	}
+	validatorTx, ok := stakerTx.Unsigned.(*txs.AddContinuousValidatorTx)
+	if !ok {
+		return nil, errWrongTxType
+	}
+
+	return s.getOwnerSigners(stakerInput, validatorTx.ValidatorRewardsOwner)
+}
+
+// getOwnerSigners returns the keys that [input] requires to prove ownership of
+// [ownerIntf].
+func (s *signerVisitor) getOwnerSigners(input *secp256k1fx.Input, ownerIntf fx.Owner) ([]keychain.Signer, error) {
+	owner, ok := ownerIntf.(*secp256k1fx.OutputOwners)
 	if !ok {
 		return nil, errUnknownOwnerType
 	}
 
-	authSigners := make([]keychain.Signer, len(subnetInput.SigIndices))
-	for sigIndex, addrIndex := range subnetInput.SigIndices {
+	authSigners := make([]keychain.Signer, len(input.SigIndices))
+	for sigIndex, addrIndex := range input.SigIndices {
 		if addrIndex >= uint32(len(owner.Addrs)) {
 			return nil, errInvalidUTXOSigIndex
 		}
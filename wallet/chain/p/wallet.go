@@ -81,6 +81,17 @@ type Wallet interface {
 		options ...common.Option,
 	) (*txs.Tx, error)
 
+	// IssueTransferSubnetOwnershipTx creates, signs, and issues a transaction
+	// that changes the owner of a subnet.
+	//
+	// - [subnetID] specifies the subnet whose owner is changed.
+	// - [owner] specifies who is now authorized to manage [subnetID].
+	IssueTransferSubnetOwnershipTx(
+		subnetID ids.ID,
+		owner *secp256k1fx.OutputOwners,
+		options ...common.Option,
+	) (*txs.Tx, error)
+
 	// IssueAddDelegatorTx creates, signs, and issues a new delegator to a
 	// validator on the primary network.
 	//
@@ -232,6 +243,14 @@ type Wallet interface {
 		options ...common.Option,
 	) (*txs.Tx, error)
 
+	// NewPartialTx signs the unsigned tx with the keys the wallet holds. The
+	// returned tx includes the UTXOs and txs needed by other parties to add
+	// their signatures without access to the network.
+	NewPartialTx(
+		utx txs.UnsignedTx,
+		options ...common.Option,
+	) (*PartialTx, error)
+
 	// IssueUnsignedTx signs and issues the unsigned tx.
 	IssueUnsignedTx(
 		utx txs.UnsignedTx,
@@ -321,6 +340,18 @@ func (w *wallet) IssueRemoveSubnetValidatorTx(
 	return w.IssueUnsignedTx(utx, options...)
 }
 
+func (w *wallet) IssueTransferSubnetOwnershipTx(
+	subnetID ids.ID,
+	owner *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.Tx, error) {
+	utx, err := w.builder.NewTransferSubnetOwnershipTx(subnetID, owner, options...)
+	if err != nil {
+		return nil, err
+	}
+	return w.IssueUnsignedTx(utx, options...)
+}
+
 func (w *wallet) IssueAddDelegatorTx(
 	vdr *txs.Validator,
 	rewardsOwner *secp256k1fx.OutputOwners,
@@ -465,6 +496,19 @@ func (w *wallet) IssueAddPermissionlessDelegatorTx(
 	return w.IssueUnsignedTx(utx, options...)
 }
 
+func (w *wallet) NewPartialTx(
+	utx txs.UnsignedTx,
+	options ...common.Option,
+) (*PartialTx, error) {
+	ops := common.NewOptions(options)
+	ctx := ops.Context()
+	tx, err := w.signer.SignUnsigned(ctx, utx)
+	if err != nil {
+		return nil, err
+	}
+	return NewPartialTx(ctx, w.Backend, tx)
+}
+
 func (w *wallet) IssueUnsignedTx(
 	utx txs.UnsignedTx,
 	options ...common.Option,
@@ -84,6 +84,18 @@ func (w *walletWithOptions) IssueRemoveSubnetValidatorTx(
 	)
 }
 
+func (w *walletWithOptions) IssueTransferSubnetOwnershipTx(
+	subnetID ids.ID,
+	owner *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.Tx, error) {
+	return w.Wallet.IssueTransferSubnetOwnershipTx(
+		subnetID,
+		owner,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
 func (w *walletWithOptions) IssueAddDelegatorTx(
 	vdr *txs.Validator,
 	rewardsOwner *secp256k1fx.OutputOwners,
@@ -218,6 +230,16 @@ func (w *walletWithOptions) IssueAddPermissionlessDelegatorTx(
 	)
 }
 
+func (w *walletWithOptions) NewPartialTx(
+	utx txs.UnsignedTx,
+	options ...common.Option,
+) (*PartialTx, error) {
+	return w.Wallet.NewPartialTx(
+		utx,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
 func (w *walletWithOptions) IssueUnsignedTx(
 	utx txs.UnsignedTx,
 	options ...common.Option,
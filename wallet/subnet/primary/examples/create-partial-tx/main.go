@@ -0,0 +1,109 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/luxdefi/node/genesis"
+	"github.com/luxdefi/node/ids"
+	"github.com/luxdefi/node/utils/formatting"
+	"github.com/luxdefi/node/utils/formatting/address"
+	"github.com/luxdefi/node/utils/set"
+	"github.com/luxdefi/node/vms/secp256k1fx"
+	"github.com/luxdefi/node/wallet/subnet/primary"
+	"github.com/luxdefi/node/wallet/subnet/primary/common"
+)
+
+func main() {
+	key := genesis.EWOQKey
+	uri := primary.LocalAPIURI
+	kc := secp256k1fx.NewKeychain(key)
+	// The subnet is owned by a 3-of-5 multisig. [key] pays the fee and is one
+	// of the owners that authorize the transfer.
+	subnetIDStr := "29uVeLPJB1eQJkzRemU8g8wZDw5uJRqpab5U2mX9euieVwiEbL"
+	signerAddrStrs := []string{
+		"P-local18jma8ppw3nhx5r4ap8clazz0dps7rv5u00z96u",
+		"P-local1sww9mdau7u5ea47449996xekqwr4zhjgqj0lyz",
+		"P-local183vfceul342fjfey7wcld9s86nct9h6c3xwm2q",
+	}
+	newOwnerAddrStr := "P-local1aph8wgh5frg005s0fthzu940hdl423062muh7m"
+	partialTxPath := "partial_tx.hex"
+
+	subnetID, err := ids.FromString(subnetIDStr)
+	if err != nil {
+		log.Fatalf("failed to parse subnet ID: %s\n", err)
+	}
+
+	signerAddrs, err := address.ParseToIDs(signerAddrStrs)
+	if err != nil {
+		log.Fatalf("failed to parse signer addresses: %s\n", err)
+	}
+
+	newOwnerAddr, err := address.ParseToID(newOwnerAddrStr)
+	if err != nil {
+		log.Fatalf("failed to parse new owner address: %s\n", err)
+	}
+
+	ctx := context.Background()
+
+	// MakeWallet fetches the available UTXOs owned by [kc] on the network that
+	// [uri] is hosting and registers [subnetID].
+	walletSyncStartTime := time.Now()
+	wallet, err := primary.MakeWallet(ctx, &primary.WalletConfig{
+		URI:              uri,
+		LUXKeychain:      kc,
+		EthKeychain:      kc,
+		PChainTxsToFetch: set.Of(subnetID),
+	})
+	if err != nil {
+		log.Fatalf("failed to initialize wallet: %s\n", err)
+	}
+	log.Printf("synced wallet in %s\n", time.Since(walletSyncStartTime))
+
+	// Get the P-chain wallet
+	pWallet := wallet.P()
+
+	// The subnet is authorized by [signerAddrs], even though the wallet only
+	// holds the key of one of them.
+	utx, err := pWallet.Builder().NewTransferSubnetOwnershipTx(
+		subnetID,
+		&secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs: []ids.ShortID{
+				newOwnerAddr,
+			},
+		},
+		common.WithCustomAddresses(set.Of(signerAddrs...)),
+	)
+	if err != nil {
+		log.Fatalf("failed to build transfer subnet ownership transaction: %s\n", err)
+	}
+
+	partialTx, err := pWallet.NewPartialTx(utx)
+	if err != nil {
+		log.Fatalf("failed to sign transfer subnet ownership transaction: %s\n", err)
+	}
+
+	missing, err := partialTx.MissingSignatures()
+	if err != nil {
+		log.Fatalf("failed to count missing signatures: %s\n", err)
+	}
+
+	partialTxBytes, err := partialTx.Bytes()
+	if err != nil {
+		log.Fatalf("failed to serialize partial transaction: %s\n", err)
+	}
+	partialTxStr, err := formatting.Encode(formatting.Hex, partialTxBytes)
+	if err != nil {
+		log.Fatalf("failed to encode partial transaction: %s\n", err)
+	}
+	if err := os.WriteFile(partialTxPath, []byte(partialTxStr), 0o600); err != nil {
+		log.Fatalf("failed to write partial transaction: %s\n", err)
+	}
+	log.Printf("wrote transaction %s missing %d signatures to %s\n", partialTx.Tx.ID(), missing, partialTxPath)
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/luxdefi/node/utils/formatting"
+	"github.com/luxdefi/node/vms/platformvm"
+	"github.com/luxdefi/node/vms/platformvm/status"
+	"github.com/luxdefi/node/wallet/chain/p"
+	"github.com/luxdefi/node/wallet/subnet/primary"
+)
+
+func main() {
+	uri := primary.LocalAPIURI
+	// The signatures of every file are merged, so the owners can sign the
+	// partial transaction in parallel.
+	partialTxPaths := []string{
+		"partial_tx.hex",
+		"partial_tx_signed_1.hex",
+		"partial_tx_signed_2.hex",
+	}
+
+	partialTxs := make([]*p.PartialTx, len(partialTxPaths))
+	for i, partialTxPath := range partialTxPaths {
+		partialTxStr, err := os.ReadFile(partialTxPath)
+		if err != nil {
+			log.Fatalf("failed to read partial transaction: %s\n", err)
+		}
+		partialTxBytes, err := formatting.Decode(formatting.Hex, string(partialTxStr))
+		if err != nil {
+			log.Fatalf("failed to decode partial transaction: %s\n", err)
+		}
+		partialTxs[i], err = p.ParsePartialTx(partialTxBytes)
+		if err != nil {
+			log.Fatalf("failed to parse partial transaction: %s\n", err)
+		}
+	}
+
+	partialTx := partialTxs[0]
+	if err := partialTx.Merge(partialTxs[1:]...); err != nil {
+		log.Fatalf("failed to merge partial transactions: %s\n", err)
+	}
+
+	missing, err := partialTx.MissingSignatures()
+	if err != nil {
+		log.Fatalf("failed to count missing signatures: %s\n", err)
+	}
+	if missing != 0 {
+		log.Fatalf("transaction %s is missing %d signatures\n", partialTx.Tx.ID(), missing)
+	}
+
+	ctx := context.Background()
+	client := platformvm.NewClient(uri)
+
+	issueTxStartTime := time.Now()
+	txID, err := client.IssueTx(ctx, partialTx.Tx.Bytes())
+	if err != nil {
+		log.Fatalf("failed to issue transaction: %s\n", err)
+	}
+
+	txStatus, err := client.AwaitTxDecided(ctx, txID, 100*time.Millisecond)
+	if err != nil {
+		log.Fatalf("failed to await transaction %s: %s\n", txID, err)
+	}
+	if txStatus.Status != status.Committed {
+		log.Fatalf("transaction %s was dropped: %s\n", txID, txStatus.Reason)
+	}
+	log.Printf("issued transaction %s in %s\n", txID, time.Since(issueTxStartTime))
+}
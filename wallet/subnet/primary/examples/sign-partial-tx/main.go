@@ -0,0 +1,67 @@
+// Copyright (C) 2019-2023, Lux Partners Limited. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/luxdefi/node/utils/crypto/secp256k1"
+	"github.com/luxdefi/node/utils/formatting"
+	"github.com/luxdefi/node/vms/secp256k1fx"
+	"github.com/luxdefi/node/wallet/chain/p"
+)
+
+// Signing doesn't require access to the network, so this can be run on an
+// offline machine holding [keyStr].
+func main() {
+	keyStr := "PrivateKey-n1avbZpLsHmUayFhrrZA3FHfBSV2FmmZBZfg18d8eAUBpP2nR"
+	partialTxPath := "partial_tx.hex"
+	signedTxPath := "partial_tx_signed_1.hex"
+
+	key := new(secp256k1.PrivateKey)
+	if err := key.UnmarshalText([]byte(`"` + keyStr + `"`)); err != nil {
+		log.Fatalf("failed to parse private key: %s\n", err)
+	}
+	kc := secp256k1fx.NewKeychain(key)
+
+	partialTxStr, err := os.ReadFile(partialTxPath)
+	if err != nil {
+		log.Fatalf("failed to read partial transaction: %s\n", err)
+	}
+	partialTxBytes, err := formatting.Decode(formatting.Hex, string(partialTxStr))
+	if err != nil {
+		log.Fatalf("failed to decode partial transaction: %s\n", err)
+	}
+	partialTx, err := p.ParsePartialTx(partialTxBytes)
+	if err != nil {
+		log.Fatalf("failed to parse partial transaction: %s\n", err)
+	}
+
+	// The partial transaction includes everything the signer needs to know
+	// about the UTXOs and the subnet.
+	signer := p.NewSigner(kc, partialTx)
+	if err := signer.Sign(context.Background(), partialTx.Tx); err != nil {
+		log.Fatalf("failed to sign partial transaction: %s\n", err)
+	}
+
+	missing, err := partialTx.MissingSignatures()
+	if err != nil {
+		log.Fatalf("failed to count missing signatures: %s\n", err)
+	}
+
+	signedTxBytes, err := partialTx.Bytes()
+	if err != nil {
+		log.Fatalf("failed to serialize partial transaction: %s\n", err)
+	}
+	signedTxStr, err := formatting.Encode(formatting.Hex, signedTxBytes)
+	if err != nil {
+		log.Fatalf("failed to encode partial transaction: %s\n", err)
+	}
+	if err := os.WriteFile(signedTxPath, []byte(signedTxStr), 0o600); err != nil {
+		log.Fatalf("failed to write partial transaction: %s\n", err)
+	}
+	log.Printf("wrote transaction %s missing %d signatures to %s\n", partialTx.Tx.ID(), missing, signedTxPath)
+}